@@ -19,4 +19,9 @@ go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
 
 protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative plugins/*.proto
 protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative surface/*.proto
-protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative metrics/*.proto
\ No newline at end of file
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative metrics/*.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative workspace/*.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative metrics/lint/lintresults.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative openapiv3/example.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative openapiv3/responses.proto
+protoc -I . -I ./third_party --go_out=. --go_opt=paths=source_relative openapiv3/inheritance.proto
@@ -0,0 +1,260 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+// A source is a description to crawl. Its name is the relative path of
+// the directory where its results are written.
+type source struct {
+	Name string
+	URL  string
+}
+
+// Result statuses.
+const (
+	statusCompiled     = "compiled"
+	statusFetchError   = "fetch-error"
+	statusCompileError = "compile-error"
+)
+
+// A result is the outcome of crawling a source.
+type result struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Version string `json:"version,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// guruIndex is the part of the APIs.guru list of APIs that is used by the
+// crawler. It maps "provider:service" names to the versions of the APIs.
+type guruIndex map[string]struct {
+	Preferred string `json:"preferred"`
+	Versions  map[string]struct {
+		SwaggerURL     string `json:"swaggerUrl"`
+		SwaggerYamlURL string `json:"swaggerYamlUrl"`
+	} `json:"versions"`
+}
+
+// readGuruIndex returns the sources of the preferred versions of the APIs
+// in an APIs.guru list, sorted by name. APIs.guru names each spec
+// "swagger" regardless of its OpenAPI version.
+func readGuruIndex(bytes []byte) ([]source, error) {
+	var index guruIndex
+	if err := json.Unmarshal(bytes, &index); err != nil {
+		return nil, err
+	}
+	sources := make([]source, 0, len(index))
+	for name, api := range index {
+		version, ok := api.Versions[api.Preferred]
+		if !ok || version.SwaggerURL == "" {
+			continue
+		}
+		sources = append(sources, source{
+			Name: cleanName(strings.Replace(name, ":", "/", -1) + "/" + api.Preferred),
+			URL:  version.SwaggerURL,
+		})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources, nil
+}
+
+// readURLList returns the sources in a list with one URL per line. A line
+// can also have a name before its URL; otherwise the name is derived from
+// the URL. Blank lines and lines starting with "#" are ignored.
+func readURLList(r io.Reader) ([]source, error) {
+	var sources []source
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+			sources = append(sources, source{Name: nameForURL(fields[0]), URL: fields[0]})
+		case 2:
+			sources = append(sources, source{Name: cleanName(fields[0]), URL: fields[1]})
+		default:
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+	}
+	return sources, scanner.Err()
+}
+
+// nameForURL derives a name from the host and path of a URL, e.g.
+// "example.com/specs/petstore" for "https://example.com/specs/petstore.yaml".
+func nameForURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return cleanName(s)
+	}
+	p := strings.TrimSuffix(u.Path, path.Ext(u.Path))
+	return cleanName(u.Host + "/" + p)
+}
+
+var unsafeCharacters = regexp.MustCompile(`[^A-Za-z0-9._/-]+`)
+
+// cleanName makes a name safe to use as a relative path.
+func cleanName(name string) string {
+	name = unsafeCharacters.ReplaceAllString(name, "_")
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		if part != "" && part != "." && part != ".." {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "_"
+	}
+	return strings.Join(parts, "/")
+}
+
+// crawl fetches and compiles a source and writes the results to a
+// subdirectory of dir. The fetched description is written as "source"
+// with the extension of its URL. A compiled OpenAPI v2 description is
+// written as "swagger.pb" and a v3 description as "openapi.pb", along
+// with its statistics in "summary.json", the file that is read by
+// gnostic-analyze's summarize program. Errors are written to "errors.txt".
+func crawl(client *http.Client, s source, dir string) *result {
+	r := &result{Name: s.Name, URL: s.URL}
+	out := filepath.Join(dir, filepath.FromSlash(s.Name))
+	if err := os.MkdirAll(out, 0755); err != nil {
+		r.Status = statusFetchError
+		r.Error = err.Error()
+		return r
+	}
+	bytes, err := fetch(client, s.URL)
+	if err != nil {
+		r.Status = statusFetchError
+		r.Error = err.Error()
+		writeErrors(out, err)
+		return r
+	}
+	ext := path.Ext(s.URL)
+	if u, err := url.Parse(s.URL); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	if ext == "" {
+		ext = ".yaml"
+	}
+	if err := ioutil.WriteFile(filepath.Join(out, "source"+ext), bytes, 0644); err != nil {
+		r.Status = statusFetchError
+		r.Error = err.Error()
+		return r
+	}
+	r.Version, err = compile(bytes, s, out)
+	if err != nil {
+		r.Status = statusCompileError
+		r.Error = err.Error()
+		writeErrors(out, err)
+		return r
+	}
+	r.Status = statusCompiled
+	return r
+}
+
+// fetch reads the bytes of a description from a URL or a local file.
+func fetch(client *http.Client, s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return ioutil.ReadFile(s)
+	}
+	response, err := client.Get(s)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", s, response.Status)
+	}
+	return ioutil.ReadAll(response.Body)
+}
+
+// compile compiles a description, writes the binary form of its model and
+// its statistics, and returns its OpenAPI version.
+func compile(bytes []byte, s source, out string) (string, error) {
+	info, err := compiler.ReadInfoFromBytes(s.URL, bytes)
+	if err != nil {
+		return "", err
+	}
+	if len(info.Content) < 1 {
+		return "", errors.New("empty description")
+	}
+	root := info.Content[0]
+	var model proto.Message
+	var stats *statistics.DocumentStatistics
+	var filename string
+	if version := compiler.MapValueForKey(root, "swagger"); version != nil {
+		document, err := openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+		if err != nil {
+			return version.Value, err
+		}
+		model = document
+		stats = statistics.NewDocumentStatistics(s.Name, document)
+		filename = "swagger.pb"
+	} else if version := compiler.MapValueForKey(root, "openapi"); version != nil {
+		document, err := openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+		if err != nil {
+			return version.Value, err
+		}
+		model = document
+		stats = statistics.NewDocumentStatisticsV3(s.Name, document)
+		filename = "openapi.pb"
+	} else {
+		return "", errors.New("unable to identify OpenAPI version")
+	}
+	modelBytes, err := proto.Marshal(model)
+	if err != nil {
+		return stats.Version, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(out, filename), modelBytes, 0644); err != nil {
+		return stats.Version, err
+	}
+	statsBytes, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return stats.Version, err
+	}
+	statsBytes = append(statsBytes, '\n')
+	return stats.Version, ioutil.WriteFile(filepath.Join(out, "summary.json"), statsBytes, 0644)
+}
+
+// writeErrors writes an error to the "errors.txt" file of a source.
+func writeErrors(out string, err error) {
+	ioutil.WriteFile(filepath.Join(out, "errors.txt"), []byte(err.Error()+"\n"), 0644)
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+func TestReadGuruIndex(t *testing.T) {
+	index := `{
+  "example.com:pets": {
+    "preferred": "1.0",
+    "versions": {
+      "0.9": {"swaggerUrl": "https://api.apis.guru/v2/specs/example.com/pets/0.9/openapi.json"},
+      "1.0": {"swaggerUrl": "https://api.apis.guru/v2/specs/example.com/pets/1.0/openapi.json"}
+    }
+  },
+  "books.example.com": {
+    "preferred": "v2",
+    "versions": {
+      "v2": {"swaggerUrl": "https://api.apis.guru/v2/specs/books.example.com/v2/swagger.json"}
+    }
+  }
+}`
+	sources, err := readGuruIndex([]byte(index))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := []source{
+		{Name: "books.example.com/v2", URL: "https://api.apis.guru/v2/specs/books.example.com/v2/swagger.json"},
+		{Name: "example.com/pets/1.0", URL: "https://api.apis.guru/v2/specs/example.com/pets/1.0/openapi.json"},
+	}
+	if len(sources) != len(expected) {
+		t.Fatalf("expected %d sources, got %+v", len(expected), sources)
+	}
+	for i := range expected {
+		if sources[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], sources[i])
+		}
+	}
+}
+
+func TestReadURLList(t *testing.T) {
+	list := `
+# Descriptions to crawl.
+https://example.com/specs/petstore.yaml
+library ../library.yaml
+https://example.com/api?format=json
+`
+	sources, err := readURLList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := []source{
+		{Name: "example.com/specs/petstore", URL: "https://example.com/specs/petstore.yaml"},
+		{Name: "library", URL: "../library.yaml"},
+		{Name: "example.com/api", URL: "https://example.com/api?format=json"},
+	}
+	if len(sources) != len(expected) {
+		t.Fatalf("expected %d sources, got %+v", len(expected), sources)
+	}
+	for i := range expected {
+		if sources[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], sources[i])
+		}
+	}
+	if _, err := readURLList(strings.NewReader("a b c")); err == nil {
+		t.Errorf("expected an error for a line with three fields")
+	}
+}
+
+func TestCleanName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"example.com/pets/1.0": "example.com/pets/1.0",
+		"../../etc/passwd":     "etc/passwd",
+		"a b:c":                "a_b_c",
+		"/":                    "_",
+	} {
+		if cleaned := cleanName(name); cleaned != expected {
+			t.Errorf("cleanName(%q): expected %q, got %q", name, expected, cleaned)
+		}
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	petstore, err := ioutil.ReadFile("../../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/petstore.yaml":
+			w.Write(petstore)
+		case "/invalid.yaml":
+			w.Write([]byte("openapi: 3.0.0\ninfo:\n  title: Invalid\npaths: {}\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "crawl")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	sources := []source{
+		{Name: "petstore", URL: server.URL + "/petstore.yaml"},
+		{Name: "invalid", URL: server.URL + "/invalid.yaml"},
+		{Name: "missing", URL: server.URL + "/missing.yaml"},
+		{Name: "local", URL: "../../examples/v3.0/yaml/petstore.yaml"},
+	}
+	results := crawlAll(server.Client(), sources, dir, 2)
+	expected := []struct {
+		status  string
+		version string
+		files   []string
+	}{
+		{statusCompiled, "2.0", []string{"source.yaml", "swagger.pb", "summary.json"}},
+		{statusCompileError, "3.0.0", []string{"source.yaml", "errors.txt"}},
+		{statusFetchError, "", []string{"errors.txt"}},
+		{statusCompiled, "3.0", []string{"source.yaml", "openapi.pb", "summary.json"}},
+	}
+	for i, e := range expected {
+		r := results[i]
+		if r.Status != e.status || r.Version != e.version {
+			t.Errorf("%s: expected %s %s, got %+v", sources[i].Name, e.status, e.version, r)
+		}
+		for _, file := range e.files {
+			if _, err := os.Stat(filepath.Join(dir, sources[i].Name, file)); err != nil {
+				t.Errorf("%s: %s", sources[i].Name, err)
+			}
+		}
+	}
+
+	// The statistics can be read by summarize.
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, "petstore", "summary.json"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var s statistics.DocumentStatistics
+	if err := json.Unmarshal(bytes, &s); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if s.Name != "petstore" || s.Operations["get"] == 0 {
+		t.Errorf("unexpected statistics %+v", s)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// crawl builds a corpus of API descriptions. It downloads the descriptions
+// in the APIs.guru directory or in lists of URLs, compiles each of them,
+// and writes the descriptions, their compiled models, their errors, and
+// their statistics into a directory that can be summarized with
+// gnostic-analyze's summarize program.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const guruIndexURL = "https://api.apis.guru/v2/list.json"
+
+func main() {
+	guru := flag.Bool("guru", false, "Crawl the descriptions in the APIs.guru directory")
+	index := flag.String("guru-index", guruIndexURL, "URL of the APIs.guru list of APIs")
+	out := flag.String("out", "corpus", "Directory for the results")
+	jobs := flag.Int("jobs", 4, "Number of descriptions to crawl at the same time")
+	timeout := flag.Duration("timeout", 30*time.Second, "Timeout for each download")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: crawl [OPTIONS] [LIST...]\n\n")
+		fmt.Fprintf(os.Stderr, "Downloads and compiles the descriptions in the APIs.guru directory\n")
+		fmt.Fprintf(os.Stderr, "(with -guru) or in lists of URLs with one URL per line.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if !*guru && flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *jobs < 1 {
+		*jobs = 1
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	var sources []source
+	if *guru {
+		bytes, err := fetch(client, *index)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(2)
+		}
+		guruSources, err := readGuruIndex(bytes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", *index, err)
+			os.Exit(2)
+		}
+		sources = append(sources, guruSources...)
+	}
+	for _, filename := range flag.Args() {
+		file, err := os.Open(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(2)
+		}
+		listSources, err := readURLList(file)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", filename, err)
+			os.Exit(2)
+		}
+		sources = append(sources, listSources...)
+	}
+
+	results := crawlAll(client, sources, *out, *jobs)
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", r.Name, r.Status)
+		}
+	}
+	bytes, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+	bytes = append(bytes, '\n')
+	if err := ioutil.WriteFile(filepath.Join(*out, "crawl.json"), bytes, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Crawled %d descriptions: %d compiled, %d with compile errors, %d with fetch errors.\n",
+		len(results), counts[statusCompiled], counts[statusCompileError], counts[statusFetchError])
+}
+
+// crawlAll crawls sources with a number of concurrent jobs and returns
+// their results in the order of the sources.
+func crawlAll(client *http.Client, sources []source, dir string, jobs int) []*result {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+	results := make([]*result, len(sources))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = crawl(client, sources[i], dir)
+			}
+		}()
+	}
+	for i := range sources {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
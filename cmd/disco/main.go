@@ -32,9 +32,13 @@ func main() {
 	usage := `
 Usage:
 	disco help
-	disco list [--raw]
-	disco get [<api>] [<version>] [--raw] [--openapi2] [--openapi3] [--features] [--schemas] [--all]
-	disco <file> [--openapi2] [--openapi3] [--features] [--schemas]
+	disco list [--raw] [--out=<path>]
+	disco get [<api>] [<version>] [--raw] [--openapi2] [--openapi3] [--features] [--schemas] [--all] [--out=<path>]
+	disco <file> [--openapi2] [--openapi3] [--features] [--schemas] [--out=<path>]
+
+A <file> of - is read from standard input. --out writes the requested
+output to <path> instead of a file named after the API, or to standard
+output if <path> is -.
 	`
 	arguments, err := docopt.Parse(usage, nil, false, "Disco 1.0", false)
 	if err != nil {
@@ -57,7 +61,9 @@ Usage:
 			log.Fatalf("%+v", err)
 		}
 		if arguments["--raw"].(bool) {
-			ioutil.WriteFile("disco-list.json", bytes, 0644)
+			if err = writeOutput(arguments, "disco-list.json", bytes); err != nil {
+				log.Fatalf("%+v", err)
+			}
 		} else {
 			// Unpack the apis/list response.
 			listResponse, err := discovery.ParseList(bytes)
@@ -79,6 +85,9 @@ Usage:
 			log.Fatalf("%+v", err)
 		}
 		if arguments["--all"].(bool) {
+			if arguments["--out"] != nil {
+				log.Fatalf("--out can't be used with --all.")
+			}
 			if !arguments["--raw"].(bool) &&
 				!arguments["--openapi2"].(bool) &&
 				!arguments["--openapi3"].(bool) &&
@@ -136,7 +145,12 @@ Usage:
 	if arguments["<file>"] != nil {
 		// Read the local file.
 		filename := arguments["<file>"].(string)
-		bytes, err := ioutil.ReadFile(filename)
+		var bytes []byte
+		if filename == "-" {
+			bytes, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			bytes, err = ioutil.ReadFile(filename)
+		}
 		if err != nil {
 			log.Fatalf("%+v", err)
 		}
@@ -148,6 +162,19 @@ Usage:
 	}
 }
 
+// writeOutput writes bytes to the file named with --out, to standard output
+// if that is "-", or else to filename.
+func writeOutput(arguments map[string]interface{}, filename string, bytes []byte) error {
+	if out, ok := arguments["--out"].(string); ok {
+		filename = out
+	}
+	if filename == "-" {
+		_, err := os.Stdout.Write(bytes)
+		return err
+	}
+	return ioutil.WriteFile(filename, bytes, 0644)
+}
+
 func handleExportArgumentsForBytes(arguments map[string]interface{}, bytes []byte) (handled bool, err error) {
 	// Unpack the discovery document.
 	document, err := discovery.ParseDocument(bytes)
@@ -157,7 +184,10 @@ func handleExportArgumentsForBytes(arguments map[string]interface{}, bytes []byt
 	if arguments["--raw"].(bool) {
 		// Write the Discovery document as a JSON file.
 		filename := "disco-" + document.Name + "-" + document.Version + ".json"
-		ioutil.WriteFile(filename, bytes, 0644)
+		err = writeOutput(arguments, filename, bytes)
+		if err != nil {
+			return handled, err
+		}
 		handled = true
 	}
 	if arguments["--features"].(bool) {
@@ -184,7 +214,7 @@ func handleExportArgumentsForBytes(arguments map[string]interface{}, bytes []byt
 			return handled, err
 		}
 		filename := "openapi3-" + document.Name + "-" + document.Version + ".pb"
-		err = ioutil.WriteFile(filename, bytes, 0644)
+		err = writeOutput(arguments, filename, bytes)
 		if err != nil {
 			return handled, err
 		}
@@ -201,7 +231,7 @@ func handleExportArgumentsForBytes(arguments map[string]interface{}, bytes []byt
 			return handled, err
 		}
 		filename := "openapi2-" + document.Name + "-" + document.Version + ".pb"
-		err = ioutil.WriteFile(filename, bytes, 0644)
+		err = writeOutput(arguments, filename, bytes)
 		if err != nil {
 			return handled, err
 		}
@@ -0,0 +1,38 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-lsp is a language server for OpenAPI descriptions. Editors run
+// it and exchange Language Server Protocol messages with it on its
+// standard input and output.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/google/gnostic/lsp"
+)
+
+func main() {
+	// Standard output carries protocol messages, so logs go to stderr.
+	log.SetOutput(os.Stderr)
+	log.SetPrefix("gnostic-lsp: ")
+	server, err := lsp.NewServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = server.Serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
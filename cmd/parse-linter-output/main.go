@@ -3,36 +3,72 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	lint "github.com/google/gnostic/metrics/lint"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func main() {
 	ibmPtr := flag.Bool("IBM", false, "generates the linter proto for IBM outputs")
 	spectralPtr := flag.Bool("Spectral", false, "generates the linter proto for Spectral outputs")
+	outPtr := flag.String("out", "lintresults.pb", "the file that aggregated lint results are written to, as JSON if its name ends with .json")
 
 	flag.Parse()
 	args := flag.Args()
 
-	if !*ibmPtr && !*spectralPtr {
-		flag.PrintDefaults()
-		fmt.Printf("Please use one of the above command line arguments.\n")
-		os.Exit(-1)
+	if *ibmPtr || *spectralPtr {
+		if len(args) != 1 {
+			fmt.Printf("Usage: report <file.json>\n")
+			return
+		}
+		if *ibmPtr {
+			lint.LintOpenAPIValidator(args[0])
+		}
+		if *spectralPtr {
+			lint.LintSpectral(args[0])
+		}
 		return
 	}
 
-	if len(args) != 1 {
-		fmt.Printf("Usage: report <file.json>\n")
-		return
+	if len(args) == 0 {
+		fmt.Printf("Usage: parse-linter-output [-out=FILE] LINTER=FILE...\n")
+		fmt.Printf("Linters: %s\n", strings.Join(lint.AdapterNames(), ", "))
+		flag.PrintDefaults()
+		os.Exit(-1)
 	}
 
-	if *ibmPtr {
-		lint.LintOpenAPIValidator(args[0])
+	results := &lint.LintResults{}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			fmt.Printf("Invalid argument %q, expected LINTER=FILE\n", arg)
+			os.Exit(-1)
+		}
+		r, err := lint.ReadResults(parts[0], parts[1])
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		results.Results = append(results.Results, r...)
 	}
 
-	if *spectralPtr {
-		lint.LintSpectral(args[0])
+	var bytes []byte
+	var err error
+	if filepath.Ext(*outPtr) == ".json" {
+		bytes, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(results)
+	} else {
+		bytes, err = proto.Marshal(results)
+	}
+	if err == nil {
+		err = ioutil.WriteFile(*outPtr, bytes, 0644)
+	}
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
 	}
-
 }
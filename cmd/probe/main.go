@@ -0,0 +1,134 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// probe checks an API description against a running server. It sends
+// GET requests for the operations of the description and reports
+// responses with undocumented status codes or with bodies that don't
+// conform to the documented schemas.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/gnostic/compiler"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// keyValues is a flag that can be repeated to collect key-value pairs.
+type keyValues struct {
+	separator string
+	pairs     [][2]string
+}
+
+func (kv *keyValues) String() string {
+	return ""
+}
+
+func (kv *keyValues) Set(s string) error {
+	parts := strings.SplitN(s, kv.separator, 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("expected KEY%sVALUE", kv.separator)
+	}
+	kv.pairs = append(kv.pairs, [2]string{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])})
+	return nil
+}
+
+func main() {
+	baseURL := flag.String("base-url", "", "Base URL of the server (defaults to the first server in the description)")
+	timeout := flag.Duration("timeout", 10*time.Second, "Timeout for each request")
+	jsonOutput := flag.Bool("json", false, "Write results as JSON")
+	parameters := &keyValues{separator: "="}
+	flag.Var(parameters, "param", "Value of a parameter as NAME=VALUE (repeatable)")
+	headers := &keyValues{separator: ":"}
+	flag.Var(headers, "header", "Header to send with each request as NAME:VALUE (repeatable)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: probe [OPTIONS] SOURCE\n\n")
+		fmt.Fprintf(os.Stderr, "Sends GET requests for the operations in an OpenAPI description and\n")
+		fmt.Fprintf(os.Stderr, "checks the responses. Operations with required parameters are\n")
+		fmt.Fprintf(os.Stderr, "skipped unless values are given with -param.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	p, err := readDescription(flag.Arg(0), *baseURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+	for _, pair := range parameters.pairs {
+		p.values[pair[0]] = pair[1]
+	}
+	for _, pair := range headers.pairs {
+		p.headers.Add(pair[0], pair[1])
+	}
+	p.client = &http.Client{Timeout: *timeout}
+
+	results := p.probe()
+	failed := false
+	for _, result := range results {
+		failed = failed || result.Failed()
+	}
+	if *jsonOutput {
+		bytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(2)
+		}
+		fmt.Printf("%s\n", bytes)
+	} else {
+		for _, result := range results {
+			fmt.Printf("%s\n", result)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// readDescription reads and compiles an API description to check that
+// it is valid and returns a prober for it.
+func readDescription(filename string, baseURL string) (*prober, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Content) < 1 {
+		return nil, fmt.Errorf("%s has no content", filename)
+	}
+	root := info.Content[0]
+	if compiler.MapValueForKey(root, "swagger") != nil {
+		_, err = openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+	} else {
+		_, err = openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newProber(info, baseURL)
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// maxBodyBytes limits the size of the response bodies that are read.
+const maxBodyBytes = 10 << 20
+
+// Result describes the probe of a single operation.
+type Result struct {
+	Operation string `json:"operation"`
+	URL       string `json:"url,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	// Skipped explains why an operation wasn't probed.
+	Skipped  string   `json:"skipped,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Failed returns true if a probe found problems.
+func (r *Result) Failed() bool {
+	return len(r.Problems) > 0
+}
+
+func (r *Result) String() string {
+	switch {
+	case r.Skipped != "":
+		return fmt.Sprintf("%s skipped: %s", r.Operation, r.Skipped)
+	case r.Status == 0:
+		return fmt.Sprintf("%s FAIL\n  %s", r.Operation, strings.Join(r.Problems, "\n  "))
+	case r.Failed():
+		return fmt.Sprintf("%s %d FAIL\n  %s", r.Operation, r.Status, strings.Join(r.Problems, "\n  "))
+	default:
+		return fmt.Sprintf("%s %d ok", r.Operation, r.Status)
+	}
+}
+
+// prober sends requests for the GET operations of an API description and
+// checks the responses against the description.
+type prober struct {
+	root    *yaml.Node
+	v2      bool
+	baseURL string
+	// values contains the values to use for parameters, keyed by parameter name.
+	values  map[string]string
+	headers http.Header
+	client  *http.Client
+}
+
+func newProber(info *yaml.Node, baseURL string) (*prober, error) {
+	root := info
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	p := &prober{
+		root:    root,
+		v2:      compiler.MapValueForKey(root, "swagger") != nil,
+		values:  make(map[string]string),
+		headers: make(http.Header),
+		client:  http.DefaultClient,
+	}
+	if baseURL == "" {
+		baseURL = p.defaultBaseURL()
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("no absolute base URL, use -base-url to specify one")
+	}
+	p.baseURL = strings.TrimSuffix(baseURL, "/")
+	return p, nil
+}
+
+// defaultBaseURL returns the first server URL of an OpenAPI v3 description
+// or the URL formed by the scheme, host, and base path of an OpenAPI v2 description.
+func (p *prober) defaultBaseURL() string {
+	if p.v2 {
+		host, _ := compiler.StringForScalarNode(compiler.MapValueForKey(p.root, "host"))
+		if host == "" {
+			return ""
+		}
+		scheme := "https"
+		if schemes := compiler.MapValueForKey(p.root, "schemes"); schemes != nil && len(schemes.Content) > 0 {
+			scheme = schemes.Content[0].Value
+		}
+		basePath, _ := compiler.StringForScalarNode(compiler.MapValueForKey(p.root, "basePath"))
+		return scheme + "://" + host + basePath
+	}
+	servers := compiler.MapValueForKey(p.root, "servers")
+	if servers == nil || len(servers.Content) == 0 {
+		return ""
+	}
+	server := servers.Content[0]
+	serverURL, _ := compiler.StringForScalarNode(compiler.MapValueForKey(server, "url"))
+	if variables := compiler.MapValueForKey(server, "variables"); variables != nil {
+		for i := 0; i+1 < len(variables.Content); i += 2 {
+			value, _ := compiler.StringForScalarNode(compiler.MapValueForKey(variables.Content[i+1], "default"))
+			serverURL = strings.ReplaceAll(serverURL, "{"+variables.Content[i].Value+"}", value)
+		}
+	}
+	return serverURL
+}
+
+// probe sends requests for all of the GET operations in the description.
+func (p *prober) probe() []*Result {
+	results := make([]*Result, 0)
+	paths := compiler.MapValueForKey(p.root, "paths")
+	if paths == nil {
+		return results
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, pathItem := paths.Content[i].Value, resolve(p.root, paths.Content[i+1])
+		operation := compiler.MapValueForKey(pathItem, "get")
+		if operation == nil {
+			continue
+		}
+		results = append(results, p.probeOperation(path, pathItem, operation))
+	}
+	return results
+}
+
+func (p *prober) probeOperation(path string, pathItem, operation *yaml.Node) *Result {
+	result := &Result{Operation: "GET " + path}
+	query := url.Values{}
+	headers := p.headers.Clone()
+	for _, parameter := range p.parameters(pathItem, operation) {
+		name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(parameter, "name"))
+		in, _ := compiler.StringForScalarNode(compiler.MapValueForKey(parameter, "in"))
+		required, _ := compiler.BoolForScalarNode(compiler.MapValueForKey(parameter, "required"))
+		value, ok := p.values[name]
+		if !ok {
+			if required || in == "path" {
+				result.Skipped = fmt.Sprintf("no value for required parameter %s", name)
+				return result
+			}
+			continue
+		}
+		switch in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+		case "query":
+			query.Add(name, value)
+		case "header":
+			headers.Set(name, value)
+		}
+	}
+	result.URL = p.baseURL + path
+	if len(query) > 0 {
+		result.URL += "?" + query.Encode()
+	}
+	request, err := http.NewRequest(http.MethodGet, result.URL, nil)
+	if err != nil {
+		result.Problems = append(result.Problems, err.Error())
+		return result
+	}
+	request.Header = headers
+	if request.Header.Get("Accept") == "" {
+		request.Header.Set("Accept", "application/json")
+	}
+	response, err := p.client.Do(request)
+	if err != nil {
+		result.Problems = append(result.Problems, err.Error())
+		return result
+	}
+	defer response.Body.Close()
+	result.Status = response.StatusCode
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxBodyBytes))
+	if err != nil {
+		result.Problems = append(result.Problems, err.Error())
+		return result
+	}
+	p.checkResponse(result, operation, response.Header.Get("Content-Type"), body)
+	return result
+}
+
+// parameters returns the parameters of an operation, including the
+// parameters of its path that it doesn't override.
+func (p *prober) parameters(pathItem, operation *yaml.Node) []*yaml.Node {
+	var parameters []*yaml.Node
+	seen := make(map[string]bool)
+	for _, owner := range []*yaml.Node{operation, pathItem} {
+		list := compiler.MapValueForKey(owner, "parameters")
+		if list == nil {
+			continue
+		}
+		for _, parameter := range list.Content {
+			parameter = resolve(p.root, parameter)
+			if parameter == nil {
+				continue
+			}
+			name, _ := compiler.StringForScalarNode(compiler.MapValueForKey(parameter, "name"))
+			in, _ := compiler.StringForScalarNode(compiler.MapValueForKey(parameter, "in"))
+			if !seen[in+":"+name] {
+				seen[in+":"+name] = true
+				parameters = append(parameters, parameter)
+			}
+		}
+	}
+	return parameters
+}
+
+// checkResponse checks that a status code is documented and that a JSON
+// response body conforms to the documented schema.
+func (p *prober) checkResponse(result *Result, operation *yaml.Node, contentType string, body []byte) {
+	responses := compiler.MapValueForKey(operation, "responses")
+	code := fmt.Sprintf("%d", result.Status)
+	response := compiler.MapValueForKey(responses, code)
+	if response == nil {
+		response = compiler.MapValueForKey(responses, code[0:1]+"XX")
+	}
+	if response == nil {
+		response = compiler.MapValueForKey(responses, "default")
+	}
+	if response == nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("status %d is not documented", result.Status))
+		return
+	}
+	response = resolve(p.root, response)
+	schema := p.responseSchema(response, contentType)
+	if schema == nil || !isJSON(contentType) || len(body) == 0 {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("response body is not valid JSON: %s", err))
+		return
+	}
+	v := &validator{root: p.root}
+	result.Problems = append(result.Problems, v.validate(schema, value)...)
+}
+
+// responseSchema returns the schema of a response for a content type.
+func (p *prober) responseSchema(response *yaml.Node, contentType string) *yaml.Node {
+	if p.v2 {
+		return compiler.MapValueForKey(response, "schema")
+	}
+	content := compiler.MapValueForKey(response, "content")
+	if content == nil {
+		return nil
+	}
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	for _, key := range []string{mediaType, "application/json", "*/*"} {
+		if m := compiler.MapValueForKey(content, key); m != nil {
+			return compiler.MapValueForKey(m, "schema")
+		}
+	}
+	return nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
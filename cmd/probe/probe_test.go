@@ -0,0 +1,188 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/gnostic/compiler"
+)
+
+const description = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: a pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+  /status:
+    get:
+      responses:
+        "2XX":
+          description: status
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties: false
+                properties:
+                  status:
+                    type: string
+                    enum: [ok, degraded]
+    post:
+      responses:
+        "200":
+          description: not probed
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true
+`
+
+func TestProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/pets":
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"id": 1, "name": "fido", "tag": null}, {"id": 2.5}]`))
+		case "/v1/pets/7":
+			w.Write([]byte(`{"id": 7, "name": "rex"}`))
+		case "/v1/status":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"status": "down", "uptime": 10}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer compiler.ClearCaches()
+
+	info, err := compiler.ReadInfoFromBytes("", []byte(description))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	p, err := newProber(info, server.URL+"/v1/")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	p.values["limit"] = "2"
+
+	results := make([]string, 0)
+	for _, result := range p.probe() {
+		results = append(results, result.String())
+	}
+	expected := []string{
+		"GET /pets 200 FAIL\n" +
+			"  response body at /1: required property \"name\" is missing\n" +
+			"  response body at /1/id: number is not a valid integer",
+		"GET /pets/{id} skipped: no value for required parameter id",
+		"GET /owners 404 FAIL\n" +
+			"  status 404 is not documented",
+		"GET /status 202 FAIL\n" +
+			"  response body at /status: value is not one of the allowed values\n" +
+			"  response body: property \"uptime\" is not allowed",
+	}
+	if actual := strings.Join(results, "\n"); actual != strings.Join(expected, "\n") {
+		t.Errorf("unexpected results:\n%s\nexpected:\n%s", actual, strings.Join(expected, "\n"))
+	}
+
+	p.values["id"] = "7"
+	results = results[:0]
+	for _, result := range p.probe() {
+		results = append(results, result.String())
+	}
+	if results[1] != "GET /pets/{id} 200 ok" {
+		t.Errorf("unexpected result: %s", results[1])
+	}
+}
+
+func TestDefaultBaseURL(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		url         string
+	}{
+		{"swagger: '2.0'\nhost: api.example.com\nbasePath: /v1\nschemes: [http]", "http://api.example.com/v1"},
+		{"openapi: 3.0.0\nservers:\n- url: https://{region}.example.com\n  variables:\n    region:\n      default: eu", "https://eu.example.com"},
+	} {
+		info, err := compiler.ReadInfoFromBytes("", []byte(test.description))
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		p, err := newProber(info, "")
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if p.baseURL != test.url {
+			t.Errorf("unexpected base URL %s (expected %s)", p.baseURL, test.url)
+		}
+	}
+	info, _ := compiler.ReadInfoFromBytes("", []byte("openapi: 3.0.0\nservers:\n- url: /v1"))
+	if _, err := newProber(info, ""); err == nil {
+		t.Errorf("expected error for relative server URL")
+	}
+}
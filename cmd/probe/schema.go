@@ -0,0 +1,231 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// maxReferenceDepth limits the length of chains of references.
+const maxReferenceDepth = 32
+
+// resolve follows local references until it reaches a node that isn't a reference.
+// It returns nil if a reference can't be resolved.
+func resolve(root, node *yaml.Node) *yaml.Node {
+	for depth := 0; node != nil; depth++ {
+		ref, ok := compiler.StringForScalarNode(compiler.MapValueForKey(node, "$ref"))
+		if !ok {
+			return node
+		}
+		if depth == maxReferenceDepth || !strings.HasPrefix(ref, "#") {
+			return nil
+		}
+		tokens, err := jsonpointer.ParseFragment(ref)
+		if err != nil {
+			return nil
+		}
+		node, err = jsonpointer.ResolveTokens(root, tokens)
+		if err != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// validator checks that JSON values conform to the schemas of a document.
+// It supports the keywords that describe the structure of values:
+// type, nullable, enum, required, properties, additionalProperties,
+// items, allOf, anyOf, and oneOf. Other keywords are ignored.
+type validator struct {
+	root     *yaml.Node
+	problems []string
+}
+
+func (v *validator) report(pointer string, format string, args ...interface{}) {
+	location := "response body"
+	if pointer != "" {
+		location += " at " + pointer
+	}
+	v.problems = append(v.problems, location+": "+fmt.Sprintf(format, args...))
+}
+
+// validate checks a value decoded from JSON and returns the problems
+// that were found.
+func (v *validator) validate(schema *yaml.Node, value interface{}) []string {
+	v.problems = nil
+	v.check(schema, value, "", 0)
+	return v.problems
+}
+
+func (v *validator) check(schema *yaml.Node, value interface{}, pointer string, depth int) {
+	if depth > maxReferenceDepth {
+		return
+	}
+	if ref, ok := compiler.StringForScalarNode(compiler.MapValueForKey(schema, "$ref")); ok {
+		schema = resolve(v.root, schema)
+		if schema == nil {
+			v.report(pointer, "schema reference %s can't be resolved", ref)
+			return
+		}
+	}
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+	if value == nil {
+		nullable, _ := compiler.BoolForScalarNode(compiler.MapValueForKey(schema, "nullable"))
+		xNullable, _ := compiler.BoolForScalarNode(compiler.MapValueForKey(schema, "x-nullable"))
+		if t, ok := compiler.StringForScalarNode(compiler.MapValueForKey(schema, "type")); ok && !nullable && !xNullable {
+			v.report(pointer, "null is not a valid %s", t)
+		}
+		return
+	}
+	if t, ok := compiler.StringForScalarNode(compiler.MapValueForKey(schema, "type")); ok {
+		if !hasType(value, t) {
+			v.report(pointer, "%s is not a valid %s", typeOf(value), t)
+			return
+		}
+	}
+	if enum := compiler.MapValueForKey(schema, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		found := false
+		for _, item := range enum.Content {
+			var allowed interface{}
+			if item.Decode(&allowed) == nil && reflect.DeepEqual(normalize(allowed), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.report(pointer, "value is not one of the allowed values")
+		}
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.checkObject(schema, value, pointer, depth)
+	case []interface{}:
+		if items := compiler.MapValueForKey(schema, "items"); items != nil {
+			for i, item := range value {
+				v.check(items, item, jsonpointer.Append(pointer, fmt.Sprintf("%d", i)), depth+1)
+			}
+		}
+	}
+	if allOf := compiler.MapValueForKey(schema, "allOf"); allOf != nil {
+		for _, s := range allOf.Content {
+			v.check(s, value, pointer, depth+1)
+		}
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		if schemas := compiler.MapValueForKey(schema, keyword); schemas != nil && len(schemas.Content) > 0 {
+			matched := false
+			for _, s := range schemas.Content {
+				alternative := &validator{root: v.root}
+				alternative.check(s, value, pointer, depth+1)
+				if len(alternative.problems) == 0 {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				v.report(pointer, "value doesn't match any of the %s schemas", keyword)
+			}
+		}
+	}
+}
+
+func (v *validator) checkObject(schema *yaml.Node, value map[string]interface{}, pointer string, depth int) {
+	if required := compiler.MapValueForKey(schema, "required"); required != nil {
+		for _, name := range compiler.StringArrayForSequenceNode(required) {
+			if _, ok := value[name]; !ok {
+				v.report(pointer, "required property %q is missing", name)
+			}
+		}
+	}
+	properties := compiler.MapValueForKey(schema, "properties")
+	additional := compiler.MapValueForKey(schema, "additionalProperties")
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property := compiler.MapValueForKey(properties, name); property != nil {
+			v.check(property, value[name], jsonpointer.Append(pointer, name), depth+1)
+		} else if allowed, ok := compiler.BoolForScalarNode(additional); ok && !allowed {
+			v.report(pointer, "property %q is not allowed", name)
+		} else if additional != nil && additional.Kind == yaml.MappingNode {
+			v.check(additional, value[name], jsonpointer.Append(pointer, name), depth+1)
+		}
+	}
+}
+
+func hasType(value interface{}, t string) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && value == math.Trunc(value))
+	}
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	}
+	return "value"
+}
+
+// normalize converts a value decoded from YAML to the types used by encoding/json.
+func normalize(value interface{}) interface{} {
+	switch value := value.(type) {
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case uint64:
+		return float64(value)
+	case map[string]interface{}:
+		for k, v := range value {
+			value[k] = normalize(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = normalize(v)
+		}
+	}
+	return value
+}
@@ -27,6 +27,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/google/gnostic/jsonschema"
+	v3 "github.com/google/gnostic/openapiv3"
 )
 
 var (
@@ -48,10 +49,11 @@ func init() {
 }
 
 type Configuration struct {
-	BaseURL  *string
-	Version  *string
-	Naming   *string
-	EnumType *string
+	BaseURL       *string
+	Version       *string
+	Naming        *string
+	EnumType      *string
+	StrictObjects *bool
 }
 
 // JSONSchemaGenerator holds internal state needed to generate the JSON Schema documents for a transcoded Protocol Buffer service.
@@ -367,12 +369,44 @@ func (g *JSONSchemaGenerator) buildSchemasFromMessages(messages []*protogen.Mess
 			)
 		}
 
+		if closed, ok := g.closedObject(message); ok {
+			schema.Value.AdditionalProperties = jsonschema.NewSchemaOrBooleanWithBoolean(!closed)
+		}
+
 		schemas = append(schemas, schema)
 	}
 
 	return schemas
 }
 
+// closedObject reports whether the schema of a message disallows properties
+// other than its fields. The second result is false if the schema leaves
+// additional properties unspecified. The additional_properties field of an (openapi.v3.schema) annotation of the
+// message takes precedence. Otherwise, with strict_objects, messages are closed
+// unless they contain maps or fields that hold arbitrary JSON objects.
+func (g *JSONSchemaGenerator) closedObject(message *protogen.Message) (bool, bool) {
+	extSchema := proto.GetExtension(message.Desc.Options(), v3.E_Schema).(*v3.Schema)
+	if additional, ok := extSchema.GetAdditionalProperties().GetOneof().(*v3.AdditionalPropertiesItem_Boolean); ok {
+		return !additional.Boolean, true
+	}
+
+	if g.conf.StrictObjects == nil || !*g.conf.StrictObjects {
+		return false, false
+	}
+	for _, field := range message.Fields {
+		if field.Desc.IsMap() {
+			return false, false
+		}
+		if field.Desc.Kind() == protoreflect.MessageKind {
+			switch field.Desc.Message().FullName() {
+			case "google.protobuf.Any", "google.protobuf.Struct":
+				return false, false
+			}
+		}
+	}
+	return true, true
+}
+
 var reSchemaVersion = regexp.MustCompile(`https*://json-schema.org/draft[/-]([^/]+)/schema`)
 
 func getSchemaVersion(schema *jsonschema.Schema) string {
@@ -27,10 +27,11 @@ var flags flag.FlagSet
 
 func main() {
 	conf := generator.Configuration{
-		BaseURL:  flags.String("baseurl", "", "the base url to use in schema ids"),
-		Version:  flags.String("version", "http://json-schema.org/draft-07/schema#", "schema version URL used in $schema. Currently supported: draft-06, draft-07"),
-		Naming:   flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
-		EnumType: flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
+		BaseURL:       flags.String("baseurl", "", "the base url to use in schema ids"),
+		Version:       flags.String("version", "http://json-schema.org/draft-07/schema#", "schema version URL used in $schema. Currently supported: draft-06, draft-07"),
+		Naming:        flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
+		EnumType:      flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
+		StrictObjects: flags.Bool("strict_objects", false, `disallow additional properties. If "true", sets additionalProperties to false in the schemas of messages that don't contain maps or google.protobuf.Any or Struct fields`),
 	}
 
 	opts := protogen.Options{
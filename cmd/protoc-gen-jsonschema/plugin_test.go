@@ -40,6 +40,7 @@ var jsonschemaTests = []struct {
 	{name: "Embedded messages", path: "examples/tests/embedded/", pkg: "", protofile: "message.proto"},
 	{name: "Protobuf types", path: "examples/tests/protobuftypes/", pkg: "", protofile: "message.proto"},
 	{name: "Enum Options", path: "examples/tests/enumoptions/", pkg: "", protofile: "message.proto"},
+	{name: "Strict objects", path: "examples/tests/strictobjects/", pkg: "", protofile: "message.proto"},
 }
 
 func TestJSONSchemaProtobufNaming(t *testing.T) {
@@ -202,3 +203,37 @@ func TestJSONSchemaStringEnums(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONSchemaStrictObjects(t *testing.T) {
+	for _, tt := range jsonschemaTests {
+		schemasPath := path.Join(tt.path, "schemas_strict_objects")
+		if _, err := os.Stat(schemasPath); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			os.RemoveAll(testSchemasPath)
+			os.MkdirAll(testSchemasPath, 0777)
+			// Run protoc and the protoc-gen-jsonschema plugin to generate JSON Schema(s) for closed objects.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(tt.path, tt.protofile),
+				"--jsonschema_opt=baseurl=http://example.com/schemas",
+				"--jsonschema_opt=strict_objects=true",
+				"--jsonschema_out="+testSchemasPath).Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+
+			// Verify that the generated spec matches our expected version.
+			err = exec.Command("diff", testSchemasPath, schemasPath).Run()
+			if err != nil {
+				t.Fatalf("Diff failed: %+v", err)
+			}
+
+			// if the test succeeded, clean up
+			os.RemoveAll(testSchemasPath)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package generator
+
+import (
+	"encoding/base64"
+	"log"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// exampleOption returns the example that is set with the (openapi.v3.example)
+// option of a field, or an empty string if there is none.
+func exampleOption(field protoreflect.FieldDescriptor) string {
+	return proto.GetExtension(field.Options(), v3.E_Example).(string)
+}
+
+// parseExample parses an example that is written in YAML or JSON.
+func parseExample(text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		log.Printf("invalid example %q: %s", text, err.Error())
+		return nil
+	}
+	example := &node
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		example = node.Content[0]
+	}
+	clearStyle(example)
+	return example
+}
+
+// clearStyle removes the styles of a parsed example, so that it is
+// written in the same style as the rest of the document.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// exampleValue returns an example as the value of a schema or media type.
+func exampleValue(example *yaml.Node) *v3.Any {
+	bytes, err := yaml.Marshal(example)
+	if err != nil {
+		log.Printf("failed to marshal example: %s", err.Error())
+		return nil
+	}
+	return &v3.Any{Yaml: string(bytes)}
+}
+
+// exampleForMessage synthesizes an example of a message as it is written in
+// JSON. Examples that are given in annotations are used where they exist, and
+// other fields get representative values of their types. It returns nil if no
+// example can be built, e.g. for google.protobuf.Empty.
+func (r *OpenAPIv3Reflector) exampleForMessage(message protoreflect.MessageDescriptor) *yaml.Node {
+	return r.messageExample(message, make(map[protoreflect.FullName]bool))
+}
+
+// messageExample builds the example of a message. Messages that are already
+// being visited are omitted to stop the recursion of circular messages.
+func (r *OpenAPIv3Reflector) messageExample(message protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) *yaml.Node {
+	typeName := r.fullMessageTypeName(message)
+
+	if schema, ok := r.mappedSchema(typeName); ok {
+		if example := schema.GetSchema().GetExample(); example != nil {
+			return parseExample(example.Yaml)
+		}
+		return nil
+	}
+
+	switch typeName {
+
+	case ".google.api.HttpBody", ".google.protobuf.Empty", ".google.protobuf.Value":
+		return nil
+
+	case ".google.protobuf.Timestamp", ".google.type.DateTime":
+		return compiler.NewScalarNodeForString("1970-01-01T00:00:00Z")
+
+	case ".google.protobuf.Duration":
+		return compiler.NewScalarNodeForString("1s")
+
+	case ".google.type.Date":
+		return compiler.NewScalarNodeForString("1970-01-01")
+
+	case ".google.protobuf.FieldMask":
+		return compiler.NewScalarNodeForString("name")
+
+	case ".google.protobuf.Struct":
+		return compiler.NewMappingNode()
+
+	case ".google.protobuf.ListValue":
+		return compiler.NewSequenceNode()
+
+	case ".google.protobuf.Any":
+		example := compiler.NewMappingNode()
+		example.Content = append(example.Content,
+			compiler.NewScalarNodeForString("@type"),
+			compiler.NewScalarNodeForString("type.googleapis.com/google.protobuf.Empty"))
+		return example
+
+	case ".google.protobuf.BoolValue", ".google.protobuf.BytesValue",
+		".google.protobuf.Int32Value", ".google.protobuf.UInt32Value",
+		".google.protobuf.Int64Value", ".google.protobuf.UInt64Value",
+		".google.protobuf.StringValue",
+		".google.protobuf.FloatValue", ".google.protobuf.DoubleValue":
+		// Wrappers are written as the values that they wrap.
+		return r.scalarExample(message.Fields().ByName("value"))
+	}
+
+	if extSchema, ok := proto.GetExtension(message.Options(), v3.E_Schema).(*v3.Schema); ok && extSchema.GetExample() != nil {
+		return parseExample(extSchema.Example.Yaml)
+	}
+
+	if visiting[message.FullName()] {
+		return nil
+	}
+	visiting[message.FullName()] = true
+	defer delete(visiting, message.FullName())
+
+	example := compiler.NewMappingNode()
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		// Only the first field of a oneof is set in examples.
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && oneof.Fields().Get(0) != field {
+			continue
+		}
+		value := r.fieldExample(field, visiting)
+		if value == nil {
+			continue
+		}
+		example.Content = append(example.Content,
+			compiler.NewScalarNodeForString(r.formatFieldName(field)),
+			value)
+	}
+	return example
+}
+
+// fieldExample builds the example of a field. Repeated fields are examples
+// with one element and maps are examples with one entry.
+func (r *OpenAPIv3Reflector) fieldExample(field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) *yaml.Node {
+	if text := exampleOption(field); text != "" {
+		return parseExample(text)
+	}
+	if extProperty, ok := proto.GetExtension(field.Options(), v3.E_Property).(*v3.Schema); ok && extProperty.GetExample() != nil {
+		return parseExample(extProperty.Example.Yaml)
+	}
+
+	if field.IsMap() {
+		value := r.fieldExample(field.MapValue(), visiting)
+		if value == nil {
+			return nil
+		}
+		key := "key"
+		if field.MapKey().Kind() != protoreflect.StringKind {
+			key = r.scalarExample(field.MapKey()).Value
+		}
+		example := compiler.NewMappingNode()
+		example.Content = append(example.Content, compiler.NewScalarNodeForString(key), value)
+		return example
+	}
+
+	var value *yaml.Node
+	if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		value = r.messageExample(field.Message(), visiting)
+	} else {
+		value = r.scalarExample(field)
+	}
+	if value == nil || !field.IsList() {
+		return value
+	}
+	example := compiler.NewSequenceNode()
+	example.Content = append(example.Content, value)
+	return example
+}
+
+// scalarExample returns an example of a field with a scalar type. Default
+// values of proto2 fields are used as their examples, enums are represented
+// by their first values, and values are written in the formats that are
+// used for their types in JSON, e.g. 64-bit integers are strings.
+func (r *OpenAPIv3Reflector) scalarExample(field protoreflect.FieldDescriptor) *yaml.Node {
+	// Default is the zero value of the type if no default is set.
+	value := field.Default()
+
+	switch field.Kind() {
+
+	case protoreflect.StringKind:
+		if field.HasDefault() {
+			return compiler.NewScalarNodeForString(value.String())
+		}
+		return compiler.NewScalarNodeForString("string")
+
+	case protoreflect.BoolKind:
+		if field.HasDefault() {
+			return compiler.NewScalarNodeForBool(value.Bool())
+		}
+		return compiler.NewScalarNodeForBool(true)
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return compiler.NewScalarNodeForInt(value.Int())
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return compiler.NewScalarNodeForInt(int64(value.Uint()))
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return compiler.NewScalarNodeForString(strconv.FormatInt(value.Int(), 10))
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return compiler.NewScalarNodeForString(strconv.FormatUint(value.Uint(), 10))
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		// Floats are written without tags, so whole numbers look like integers.
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: strconv.FormatFloat(value.Float(), 'g', -1, 64)}
+
+	case protoreflect.BytesKind:
+		bytes := []byte("bytes")
+		if field.HasDefault() {
+			bytes = value.Bytes()
+		}
+		return compiler.NewScalarNodeForString(base64.StdEncoding.EncodeToString(bytes))
+
+	case protoreflect.EnumKind:
+		enumValue := field.Enum().Values().Get(0)
+		if field.HasDefault() {
+			enumValue = field.DefaultEnumValue()
+		}
+		if *r.conf.EnumType == "string" {
+			return compiler.NewScalarNodeForString(string(enumValue.Name()))
+		}
+		return compiler.NewScalarNodeForInt(int64(enumValue.Number()))
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"google.golang.org/genproto/googleapis/api/annotations"
@@ -28,22 +29,54 @@ import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	any_pb "google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
 
 	wk "github.com/google/gnostic/cmd/protoc-gen-openapi/generator/wellknown"
+	"github.com/google/gnostic/jsonwriter"
 	v3 "github.com/google/gnostic/openapiv3"
 )
 
 type Configuration struct {
-	Version         *string
-	Title           *string
-	Description     *string
-	Naming          *string
-	FQSchemaNaming  *bool
-	EnumType        *string
-	CircularDepth   *int
-	DefaultResponse *bool
-	OutputMode      *string
+	Version            *string
+	Title              *string
+	Description        *string
+	Naming             *string
+	FQSchemaNaming     *bool
+	EnumType           *string
+	CircularDepth      *int
+	DefaultResponse    *bool
+	GrpcErrorResponses *bool
+	OutputMode         *string
+	Format             *string
+	PathOrder          *string
+	Examples           *bool
+	// OpenAPIv2Annotations enables reading the options of grpc-gateway's
+	// protoc-gen-openapiv2.
+	OpenAPIv2Annotations *bool
+	// Servers replace the servers of the document, including servers that
+	// are given with (openapi.v3.document) options.
+	Servers []*v3.Server
+	// TypeMappings maps fully-qualified message type names to the schemas
+	// that are used for them instead of generated schemas.
+	TypeMappings map[string]*v3.SchemaOrReference
+	// NestedNaming is the naming of the schemas of nested messages: they
+	// are joined with the names of the messages that contain them with
+	// "underscore" or "dot", or named like other messages with "flatten".
+	NestedNaming *string
+	// Sorting is the order of the tags, paths, and schemas of documents:
+	// "alpha" to sort them by name, "source" for the order of the services,
+	// methods, and messages in the proto files, or "none" for the order in
+	// which they are generated.
+	Sorting *string
+	// QueryMessageStyle is the encoding of message fields in queries:
+	// "dotted" for a parameter for each of their fields, or "deepObject"
+	// or "form" for a single parameter with that style.
+	QueryMessageStyle *string
+	// QueryExplode is the explode value of parameters for repeated fields
+	// and of form-style message parameters.
+	QueryExplode *bool
 }
 
 const (
@@ -63,14 +96,22 @@ type OpenAPIv3Generator struct {
 
 	inputFiles        []*protogen.File
 	reflect           *OpenAPIv3Reflector
+	explicitServers   bool     // True if the servers of the document are given explicitly.
 	generatedSchemas  []string // Names of schemas that have already been generated.
 	linterRulePattern *regexp.Regexp
 	pathPattern       *regexp.Regexp
 	namedPathPattern  *regexp.Regexp
+	statistics        *Statistics            // Statistics of the document that was generated by Run.
+	openapiv2Types    *protoregistry.Types   // Types of the options of protoc-gen-openapiv2, if they are read.
+	unexploded        map[*v3.Parameter]bool // Parameters that are written with "explode: false".
 }
 
 // NewOpenAPIv3Generator creates a new generator for a protoc plugin invocation.
 func NewOpenAPIv3Generator(plugin *protogen.Plugin, conf Configuration, inputFiles []*protogen.File) *OpenAPIv3Generator {
+	var openapiv2Types *protoregistry.Types
+	if conf.OpenAPIv2Annotations != nil && *conf.OpenAPIv2Annotations {
+		openapiv2Types = newOpenAPIv2Types(plugin.Files)
+	}
 	return &OpenAPIv3Generator{
 		conf:   conf,
 		plugin: plugin,
@@ -81,20 +122,73 @@ func NewOpenAPIv3Generator(plugin *protogen.Plugin, conf Configuration, inputFil
 		linterRulePattern: regexp.MustCompile(`\(-- .* --\)`),
 		pathPattern:       regexp.MustCompile("{([^=}]+)}"),
 		namedPathPattern:  regexp.MustCompile("{(.+)=(.+)}"),
+		openapiv2Types:    openapiv2Types,
 	}
 }
 
 // Run runs the generator.
 func (g *OpenAPIv3Generator) Run(outputFile *protogen.GeneratedFile) error {
 	d := g.buildDocumentV3()
-	bytes, err := d.YAMLValue("Generated with protoc-gen-openapi\n" + infoURL)
+	rawInfo := d.ToRawInfo()
+	g.addUnexplodedParameters(rawInfo, d)
+	var size int
+	var err error
+	if g.conf.Format != nil && *g.conf.Format == "json" {
+		size, err = writeJSON(outputFile, rawInfo)
+	} else {
+		size, err = writeYAML(outputFile, rawInfo)
+	}
+	if err != nil {
+		return err
+	}
+	g.statistics = newStatistics(d, size)
+	return nil
+}
+
+// writeYAML writes a document as YAML and returns its size in bytes.
+func writeYAML(outputFile *protogen.GeneratedFile, rawInfo *yaml.Node) (int, error) {
+	bytes, err := yaml.Marshal(&yaml.Node{
+		Kind:        yaml.DocumentNode,
+		Content:     []*yaml.Node{rawInfo},
+		HeadComment: "Generated with protoc-gen-openapi\n" + infoURL,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal yaml: %s", err.Error())
+		return 0, fmt.Errorf("failed to marshal yaml: %s", err.Error())
 	}
 	if _, err = outputFile.Write(bytes); err != nil {
-		return fmt.Errorf("failed to write yaml: %s", err.Error())
+		return 0, fmt.Errorf("failed to write yaml: %s", err.Error())
 	}
-	return nil
+	return len(bytes), nil
+}
+
+// writeJSON writes a document as JSON and returns its size in bytes. Keys are
+// written in the order used by the YAML output, so both formats are stable and
+// easy to compare.
+func writeJSON(outputFile *protogen.GeneratedFile, rawInfo *yaml.Node) (int, error) {
+	bytes, err := jsonwriter.Marshal(&yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{rawInfo},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal json: %s", err.Error())
+	}
+	if _, err = outputFile.Write(bytes); err != nil {
+		return 0, fmt.Errorf("failed to write json: %s", err.Error())
+	}
+	return len(bytes), nil
+}
+
+// addStatusSchemaToDocumentV3 adds the schemas for google.rpc.Status and
+// google.protobuf.Any to the document and returns the name of the Status schema.
+func (g *OpenAPIv3Generator) addStatusSchemaToDocumentV3(d *v3.Document) string {
+	anySchemaName := g.reflect.formatMessageName(anyProtoDesc)
+	anySchema := wk.NewGoogleProtobufAnySchema(anySchemaName)
+	g.addSchemaToDocumentV3(d, anySchema)
+
+	statusSchemaName := g.reflect.formatMessageName(statusProtoDesc)
+	statusSchema := wk.NewGoogleRpcStatusSchema(statusSchemaName, anySchemaName)
+	g.addSchemaToDocumentV3(d, statusSchema)
+	return statusSchemaName
 }
 
 // buildDocumentV3 builds an OpenAPIv3 document for a plugin request.
@@ -115,11 +209,17 @@ func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 		},
 	}
 
+	g.explicitServers = g.hasServers()
+
 	// Go through the files and add the services to the documents, keeping
 	// track of which schemas are referenced in the response so we can
 	// add them later.
 	for _, file := range g.inputFiles {
 		if file.Generate {
+			// Options of protoc-gen-openapiv2 are added first, so that
+			// `Document` annotations take precedence over them.
+			g.addOpenAPIv2SwaggerToDocumentV3(d, file)
+
 			// Merge any `Document` annotations with the current
 			extDocument := proto.GetExtension(file.Desc.Options(), v3.E_Document)
 			if extDocument != nil {
@@ -130,6 +230,10 @@ func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 		}
 	}
 
+	if len(g.conf.Servers) > 0 {
+		d.Servers = g.conf.Servers
+	}
+
 	// While we have required schemas left to generate, go through the files again
 	// looking for the related message and adding them to the document if required.
 	for len(g.reflect.requiredSchemas) > 0 {
@@ -152,6 +256,9 @@ func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 		d.Tags[0].Description = ""
 	}
 
+	// Add the tags that are only used in `Operation` annotations.
+	g.addOperationTagsToDocumentV3(d)
+
 	allServers := []string{}
 
 	// If paths methods has servers, but they're all the same, then move servers to path level
@@ -201,8 +308,8 @@ func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 		}
 	}
 
-	// Set all servers on API level
-	if len(allServers) > 0 {
+	// Set all servers on API level, unless the servers of the document are given explicitly
+	if len(allServers) > 0 && !g.explicitServers {
 		d.Servers = []*v3.Server{}
 		for _, server := range allServers {
 			d.Servers = append(d.Servers, &v3.Server{Url: server})
@@ -210,39 +317,109 @@ func (g *OpenAPIv3Generator) buildDocumentV3() *v3.Document {
 	}
 
 	// If there is only 1 server, we can safely remove all path level servers
-	if len(allServers) == 1 {
+	if len(allServers) == 1 && !g.explicitServers {
 		for _, path := range d.Paths.Path {
 			path.Value.Servers = nil
 		}
 	}
 
-	// Sort the tags.
-	{
+	sorting := g.sorting()
+	// Sort the tags, unless they should stay in the order of the services.
+	if sorting == "alpha" {
 		pairs := d.Tags
 		sort.Slice(pairs, func(i, j int) bool {
 			return pairs[i].Name < pairs[j].Name
 		})
 		d.Tags = pairs
 	}
-	// Sort the paths.
-	{
+	// Sort the paths, unless they should stay in the order in which
+	// their methods are declared.
+	if sorting == "alpha" && (g.conf.PathOrder == nil || *g.conf.PathOrder != "declaration") {
 		pairs := d.Paths.Path
 		sort.Slice(pairs, func(i, j int) bool {
 			return pairs[i].Name < pairs[j].Name
 		})
 		d.Paths.Path = pairs
 	}
-	// Sort the schemas.
-	{
+	// Sort the schemas. Without sorting, they stay in the order in which
+	// they are first referred to.
+	switch sorting {
+	case "alpha":
 		pairs := d.Components.Schemas.AdditionalProperties
 		sort.Slice(pairs, func(i, j int) bool {
 			return pairs[i].Name < pairs[j].Name
 		})
 		d.Components.Schemas.AdditionalProperties = pairs
+	case "source":
+		pairs := d.Components.Schemas.AdditionalProperties
+		positions := g.messagePositions()
+		position := func(name string) int {
+			if p, ok := positions[name]; ok {
+				return p
+			}
+			return len(positions)
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return position(pairs[i].Name) < position(pairs[j].Name)
+		})
+		d.Components.Schemas.AdditionalProperties = pairs
 	}
 	return d
 }
 
+// sorting returns the order of the tags, paths, and schemas of documents.
+func (g *OpenAPIv3Generator) sorting() string {
+	if g.conf.Sorting == nil || *g.conf.Sorting == "" {
+		return "alpha"
+	}
+	return *g.conf.Sorting
+}
+
+// messagePositions returns the positions of the schemas of messages in the
+// order in which the messages are declared. Messages are ordered by the
+// files that contain them, and nested messages follow their parents.
+func (g *OpenAPIv3Generator) messagePositions() map[string]int {
+	positions := make(map[string]int)
+	var add func(messages []*protogen.Message)
+	add = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			name := g.reflect.formatMessageName(message.Desc)
+			if _, ok := positions[name]; !ok {
+				positions[name] = len(positions)
+			}
+			add(message.Messages)
+		}
+	}
+	for _, file := range g.plugin.Files {
+		add(file.Messages)
+	}
+	return positions
+}
+
+// addOperationTagsToDocumentV3 adds the tags of operations that are not
+// tags of services to the tags of the document.
+func (g *OpenAPIv3Generator) addOperationTagsToDocumentV3(d *v3.Document) {
+	for _, path := range d.Paths.Path {
+		for _, op := range []*v3.Operation{path.Value.Get, path.Value.Put, path.Value.Post, path.Value.Delete, path.Value.Patch} {
+			if op == nil {
+				continue
+			}
+			for _, name := range op.Tags {
+				found := false
+				for _, tag := range d.Tags {
+					if tag.Name == name {
+						found = true
+						break
+					}
+				}
+				if !found {
+					d.Tags = append(d.Tags, &v3.Tag{Name: name})
+				}
+			}
+		}
+	}
+}
+
 // filterCommentString removes linter rules from comments.
 func (g *OpenAPIv3Generator) filterCommentString(c protogen.Comments) string {
 	comment := g.linterRulePattern.ReplaceAllString(string(c), "")
@@ -268,6 +445,24 @@ func (g *OpenAPIv3Generator) findAndFormatFieldName(name string, inMessage *prot
 	return name
 }
 
+// pathParameterSchema returns the schema of a path parameter. Parameters
+// that are set from fields of other types than string have the schemas of
+// the fields. Other parameters are strings that match a pattern, if there
+// is one.
+func (g *OpenAPIv3Generator) pathParameterSchema(field *protogen.Field, pattern string) *v3.SchemaOrReference {
+	if field != nil && field.Desc.Kind() != protoreflect.StringKind {
+		return g.reflect.schemaOrReferenceForField(field.Desc)
+	}
+	return &v3.SchemaOrReference{
+		Oneof: &v3.SchemaOrReference_Schema{
+			Schema: &v3.Schema{
+				Type:    "string",
+				Pattern: pattern,
+			},
+		},
+	}
+}
+
 // Note that fields which are mapped to URL query parameters must have a primitive type
 // or a repeated primitive type or a non-repeated message type.
 // In the case of a repeated type, the parameter can be repeated in the URL as ...?param=A&param=B.
@@ -277,31 +472,75 @@ func (g *OpenAPIv3Generator) findAndFormatFieldName(name string, inMessage *prot
 // - for wrapper types it will use the same representation as the wrapped primitive type in JSON
 // - for google.protobuf.timestamp type it will be serialized as a string
 //
-// maps, Struct and Empty can NOT be used
+// maps, Struct, Any and Empty can NOT be used
 // messages can have any number of sub messages - including circular (e.g. sub.subsub.sub.subsub.id)
 
-// buildQueryParamsV3 extracts any valid query params, including sub and recursive messages
-func (g *OpenAPIv3Generator) buildQueryParamsV3(field *protogen.Field) []*v3.ParameterOrReference {
+// skippedParameter is a field of a request message that can't be
+// represented as a query parameter.
+type skippedParameter struct {
+	name   string
+	reason string
+}
+
+// buildQueryParamsV3 extracts any valid query params, including sub and recursive messages.
+// Fields that can't be query parameters are added to skipped.
+func (g *OpenAPIv3Generator) buildQueryParamsV3(field *protogen.Field, skipped *[]*skippedParameter) []*v3.ParameterOrReference {
 	depths := map[string]int{}
-	return g._buildQueryParamsV3(field, depths)
+	parameters := g._buildQueryParamsV3(field, depths, skipped)
+	if isDeprecated(field.Desc) {
+		deprecateParameters(parameters)
+	}
+	return parameters
+}
+
+// unsupportedParametersExtension lists the skipped parameters of an
+// operation so that their omission is visible in the generated document.
+func unsupportedParametersExtension(skipped []*skippedParameter) *v3.NamedAny {
+	type entry struct {
+		Name   string `yaml:"name"`
+		Reason string `yaml:"reason"`
+	}
+	entries := make([]entry, len(skipped))
+	for i, parameter := range skipped {
+		entries[i] = entry{Name: parameter.name, Reason: parameter.reason}
+	}
+	bytes, err := yaml.Marshal(entries)
+	if err != nil {
+		log.Printf("failed to marshal unsupported parameters: %s", err.Error())
+		return nil
+	}
+	return &v3.NamedAny{Name: "x-unsupported-parameters", Value: &v3.Any{Yaml: string(bytes)}}
+}
+
+// deprecateParameters marks the parameters of a deprecated field as deprecated.
+func deprecateParameters(parameters []*v3.ParameterOrReference) {
+	for _, parameter := range parameters {
+		if param, ok := parameter.Oneof.(*v3.ParameterOrReference_Parameter); ok {
+			param.Parameter.Deprecated = true
+		}
+	}
 }
 
 // depths are used to keep track of how many times a message's fields has been seen
-func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths map[string]int) []*v3.ParameterOrReference {
+func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths map[string]int, skipped *[]*skippedParameter) []*v3.ParameterOrReference {
 	parameters := []*v3.ParameterOrReference{}
 
 	queryFieldName := g.reflect.formatFieldName(field.Desc)
 	fieldDescription := g.filterCommentString(field.Comments.Leading)
+	skip := func(reason string) []*v3.ParameterOrReference {
+		*skipped = append(*skipped, &skippedParameter{name: queryFieldName, reason: reason})
+		return parameters
+	}
 
 	if field.Desc.IsMap() {
 		// Map types are not allowed in query parameteres
-		return parameters
+		return skip("map fields can't be query parameters")
 
 	} else if field.Desc.Kind() == protoreflect.MessageKind {
 		typeName := g.reflect.fullMessageTypeName(field.Desc.Message())
 
-		switch typeName {
-		case ".google.protobuf.Value":
+		// Mapped types are represented by their schemas (don't expand them).
+		if _, ok := g.conf.TypeMappings[typeName]; ok {
 			fieldSchema := g.reflect.schemaOrReferenceForField(field.Desc)
 			parameters = append(parameters,
 				&v3.ParameterOrReference{
@@ -316,6 +555,26 @@ func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths m
 					},
 				})
 			return parameters
+		}
+
+		switch typeName {
+		case ".google.protobuf.Value", ".google.protobuf.ListValue":
+			fieldSchema := g.reflect.schemaOrReferenceForField(field.Desc)
+			parameter := &v3.Parameter{
+				Name:        queryFieldName,
+				In:          "query",
+				Description: fieldDescription,
+				Required:    false,
+				Schema:      fieldSchema,
+			}
+			g.setRepeatedStyle(parameter, field)
+			parameters = append(parameters,
+				&v3.ParameterOrReference{
+					Oneof: &v3.ParameterOrReference_Parameter{
+						Parameter: parameter,
+					},
+				})
+			return parameters
 
 		case ".google.protobuf.BoolValue", ".google.protobuf.BytesValue", ".google.protobuf.Int32Value", ".google.protobuf.UInt32Value",
 			".google.protobuf.StringValue", ".google.protobuf.Int64Value", ".google.protobuf.UInt64Value", ".google.protobuf.FloatValue",
@@ -366,11 +625,19 @@ func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths m
 					},
 				})
 			return parameters
+
+		case ".google.protobuf.Any":
+			// Any can't be represented as query parameters
+			return skip("google.protobuf.Any fields can't be query parameters")
+
+		case ".google.protobuf.Struct":
+			// Struct is a map, which can't be represented as query parameters
+			return skip("google.protobuf.Struct fields can't be query parameters")
 		}
 
 		if field.Desc.IsList() {
 			// Only non-repeated message types are valid
-			return parameters
+			return skip("repeated message fields can't be query parameters")
 		}
 
 		// Represent field masks directly as strings (don't expand them).
@@ -391,6 +658,32 @@ func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths m
 			return parameters
 		}
 
+		// Messages with a query style other than "dotted" are a single parameter.
+		if style := g.queryMessageStyle(); style != "dotted" {
+			fieldSchema := g.reflect.schemaOrReferenceForField(field.Desc)
+			if fieldSchema == nil {
+				// Messages without a schema, like Empty, have no parameters.
+				return parameters
+			}
+			parameter := &v3.Parameter{
+				Name:        queryFieldName,
+				In:          "query",
+				Description: fieldDescription,
+				Required:    false,
+				Schema:      fieldSchema,
+				Style:       style,
+			}
+			// Only exploded deepObject parameters are defined.
+			g.setExplode(parameter, style == "deepObject" || g.queryExplode())
+			parameters = append(parameters,
+				&v3.ParameterOrReference{
+					Oneof: &v3.ParameterOrReference_Parameter{
+						Parameter: parameter,
+					},
+				})
+			return parameters
+		}
+
 		// Sub messages are allowed, even circular, as long as the final type is a primitive.
 		// Go through each of the sub message fields
 		for _, subField := range field.Message.Fields {
@@ -402,7 +695,14 @@ func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths m
 
 			if seen < *g.conf.CircularDepth {
 				depths[subFieldFullName]++
-				subParams := g._buildQueryParamsV3(subField, depths)
+				n := len(*skipped)
+				subParams := g._buildQueryParamsV3(subField, depths, skipped)
+				for _, subSkipped := range (*skipped)[n:] {
+					subSkipped.name = queryFieldName + "." + subSkipped.name
+				}
+				if isDeprecated(subField.Desc) {
+					deprecateParameters(subParams)
+				}
 				for _, subParam := range subParams {
 					if param, ok := subParam.Oneof.(*v3.ParameterOrReference_Parameter); ok {
 						param.Parameter.Name = queryFieldName + "." + param.Parameter.Name
@@ -412,20 +712,25 @@ func (g *OpenAPIv3Generator) _buildQueryParamsV3(field *protogen.Field, depths m
 			}
 		}
 
-	} else if field.Desc.Kind() != protoreflect.GroupKind {
+	} else if field.Desc.Kind() == protoreflect.GroupKind {
+		return skip("groups can't be query parameters")
+
+	} else {
 		// schemaOrReferenceForField also handles array types
 		fieldSchema := g.reflect.schemaOrReferenceForField(field.Desc)
 
+		parameter := &v3.Parameter{
+			Name:        queryFieldName,
+			In:          "query",
+			Description: fieldDescription,
+			Required:    false,
+			Schema:      fieldSchema,
+		}
+		g.setRepeatedStyle(parameter, field)
 		parameters = append(parameters,
 			&v3.ParameterOrReference{
 				Oneof: &v3.ParameterOrReference_Parameter{
-					Parameter: &v3.Parameter{
-						Name:        queryFieldName,
-						In:          "query",
-						Description: fieldDescription,
-						Required:    false,
-						Schema:      fieldSchema,
-					},
+					Parameter: parameter,
 				},
 			})
 	}
@@ -447,6 +752,9 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 ) (*v3.Operation, string) {
 	// coveredParameters tracks the parameters that have been used in the body or path.
 	coveredParameters := make([]string, 0)
+	// pathParameters tracks the names of the path parameters, which
+	// request fields with the same names can't also have as query parameters.
+	pathParameters := make([]string, 0)
 	if bodyField != "" {
 		coveredParameters = append(coveredParameters, bodyField)
 	}
@@ -462,23 +770,14 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 			path = strings.Replace(path, matches[1], pathParameter, 1)
 
 			// Add the path parameters to the operation parameters.
-			var fieldSchema *v3.SchemaOrReference
-
 			var fieldDescription string
 			field := g.findField(pathParameter, inputMessage)
 			if field != nil {
-				fieldSchema = g.reflect.schemaOrReferenceForField(field.Desc)
 				fieldDescription = g.filterCommentString(field.Comments.Leading)
-			} else {
-				// If field does not exist, it is safe to set it to string, as it is ignored downstream
-				fieldSchema = &v3.SchemaOrReference{
-					Oneof: &v3.SchemaOrReference_Schema{
-						Schema: &v3.Schema{
-							Type: "string",
-						},
-					},
-				}
 			}
+			// If field does not exist, it is safe to set it to string, as it is ignored downstream
+			fieldSchema := g.pathParameterSchema(field, "")
+			pathParameters = append(pathParameters, pathParameter)
 
 			parameters = append(parameters,
 				&v3.ParameterOrReference{
@@ -497,29 +796,49 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 
 	// Find named path parameters like {name=shelves/*}
 	if matches := g.namedPathPattern.FindStringSubmatch(path); matches != nil {
-		// Build a list of named path parameters.
-		namedPathParameters := make([]string, 0)
-
 		// Add the "name=" "name" value to the list of covered parameters.
 		coveredParameters = append(coveredParameters, matches[1])
 		// Convert the path from the starred form to use named path parameters.
-		starredPath := matches[2]
-		parts := strings.Split(starredPath, "/")
+		segments := strings.Split(matches[2], "/")
+		parts := strings.Split(matches[2], "/")
 		// The starred path is assumed to be in the form "things/*/otherthings/*".
 		// We want to convert it to "things/{thingsId}/otherthings/{otherthingsId}".
-		for i := 0; i < len(parts)-1; i += 2 {
-			section := parts[i]
-			namedPathParameter := g.findAndFormatFieldName(section, inputMessage)
-			namedPathParameter = singular(namedPathParameter)
-			parts[i+1] = "{" + namedPathParameter + "}"
-			namedPathParameters = append(namedPathParameters, namedPathParameter)
-		}
-		// Rewrite the path to use the path parameters.
-		newPath := strings.Join(parts, "/")
-		path = strings.Replace(path, matches[0], newPath, 1)
+		// A path that is only a wildcard, as in {id=*}, is the value of the
+		// named field, so it is converted to "{id}". The other parameters are
+		// parts of the named field and don't set the fields they are named after.
+		// A wildcard that follows another wildcard, as in {name=*/*}, is named
+		// after the field and its position, as in "{name}/{name2}".
+		for i, part := range segments {
+			if part != "*" && part != "**" {
+				continue
+			}
+			var namedPathParameter string
+			var field *protogen.Field
+			if i == 0 {
+				namedPathParameter = g.findAndFormatFieldName(matches[1], inputMessage)
+				field = g.findField(matches[1], inputMessage)
+			} else if previous := segments[i-1]; previous != "*" && previous != "**" {
+				namedPathParameter = g.findAndFormatFieldName(previous, inputMessage)
+				namedPathParameter = singular(namedPathParameter)
+			} else {
+				namedPathParameter = g.findAndFormatFieldName(matches[1], inputMessage) + strconv.Itoa(i+1)
+			}
+			parts[i] = "{" + namedPathParameter + "}"
+			pathParameters = append(pathParameters, namedPathParameter)
+
+			description := "The " + namedPathParameter + " id."
+			if field != nil {
+				if comment := g.filterCommentString(field.Comments.Leading); comment != "" {
+					description = comment
+				}
+			}
+			// A "*" matches a single path segment and a "**" matches any
+			// number of them.
+			pattern := ""
+			if part == "*" {
+				pattern = "^[^/]+$"
+			}
 
-		// Add the named path parameters to the operation parameters.
-		for _, namedPathParameter := range namedPathParameters {
 			parameters = append(parameters,
 				&v3.ParameterOrReference{
 					Oneof: &v3.ParameterOrReference_Parameter{
@@ -527,30 +846,35 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 							Name:        namedPathParameter,
 							In:          "path",
 							Required:    true,
-							Description: "The " + namedPathParameter + " id.",
-							Schema: &v3.SchemaOrReference{
-								Oneof: &v3.SchemaOrReference_Schema{
-									Schema: &v3.Schema{
-										Type: "string",
-									},
-								},
-							},
+							Description: description,
+							Schema:      g.pathParameterSchema(field, pattern),
 						},
 					},
 				})
 		}
+		// Rewrite the path to use the path parameters.
+		newPath := strings.Join(parts, "/")
+		path = strings.Replace(path, matches[0], newPath, 1)
 	}
 
 	// Add any unhandled fields in the request message as query parameters.
+	skipped := []*skippedParameter{}
 	if bodyField != "*" && string(inputMessage.Desc.FullName()) != "google.api.HttpBody" {
 		for _, field := range inputMessage.Fields {
 			fieldName := string(field.Desc.Name())
+			if contains(pathParameters, fieldName) || contains(pathParameters, g.reflect.formatFieldName(field.Desc)) {
+				continue
+			}
 			if !contains(coveredParameters, fieldName) && fieldName != bodyField {
-				fieldParams := g.buildQueryParamsV3(field)
+				fieldParams := g.buildQueryParamsV3(field, &skipped)
 				parameters = append(parameters, fieldParams...)
 			}
 		}
 	}
+	for _, parameter := range skipped {
+		log.Printf("warning: %s: field %s of %s is not a query parameter: %s",
+			operationID, parameter.name, inputMessage.Desc.FullName(), parameter.reason)
+	}
 
 	// Create the response.
 	name, content := g.reflect.responseContentForMessage(outputMessage.Desc)
@@ -570,15 +894,15 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 		},
 	}
 
+	// Add responses for the HTTP statuses of gRPC errors if needed
+	if g.conf.GrpcErrorResponses != nil && *g.conf.GrpcErrorResponses {
+		statusSchemaName := g.addStatusSchemaToDocumentV3(d)
+		responses.ResponseOrReference = append(responses.ResponseOrReference, buildGrpcErrorResponsesV3(statusSchemaName)...)
+	}
+
 	// Add the default reponse if needed
 	if *g.conf.DefaultResponse {
-		anySchemaName := g.reflect.formatMessageName(anyProtoDesc)
-		anySchema := wk.NewGoogleProtobufAnySchema(anySchemaName)
-		g.addSchemaToDocumentV3(d, anySchema)
-
-		statusSchemaName := g.reflect.formatMessageName(statusProtoDesc)
-		statusSchema := wk.NewGoogleRpcStatusSchema(statusSchemaName, anySchemaName)
-		g.addSchemaToDocumentV3(d, statusSchema)
+		statusSchemaName := g.addStatusSchemaToDocumentV3(d)
 
 		defaultResponse := &v3.NamedResponseOrReference{
 			Name: "default",
@@ -605,6 +929,11 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 		Parameters:  parameters,
 		Responses:   responses,
 	}
+	if len(skipped) > 0 {
+		if extension := unsupportedParametersExtension(skipped); extension != nil {
+			op.SpecificationExtension = append(op.SpecificationExtension, extension)
+		}
+	}
 
 	if defaultHost != "" {
 		hostURL, err := url.Parse(defaultHost)
@@ -617,10 +946,12 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 	// If a body field is specified, we need to pass a message as the request body.
 	if bodyField != "" {
 		var requestSchema *v3.SchemaOrReference
+		var requestExample *v3.Any
 
 		if bodyField == "*" {
 			// Pass the entire request message as the request body.
 			requestSchema = g.reflect.schemaOrReferenceForMessage(inputMessage.Desc)
+			requestExample = g.reflect.mediaTypeExample(inputMessage.Desc)
 
 		} else {
 			// If body refers to a message field, use that type.
@@ -638,6 +969,7 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 
 					case protoreflect.MessageKind:
 						requestSchema = g.reflect.schemaOrReferenceForMessage(field.Message.Desc)
+						requestExample = g.reflect.mediaTypeExample(field.Message.Desc)
 
 					default:
 						log.Printf("unsupported field type %+v", field.Desc)
@@ -656,7 +988,8 @@ func (g *OpenAPIv3Generator) buildOperationV3(
 							{
 								Name: "application/json",
 								Value: &v3.MediaType{
-									Schema: requestSchema,
+									Schema:  requestSchema,
+									Example: requestExample,
 								},
 							},
 						},
@@ -748,15 +1081,28 @@ func (g *OpenAPIv3Generator) addPathsToDocumentV3(d *v3.Document, services []*pr
 				}
 
 				if methodName != "" {
-					defaultHost := proto.GetExtension(service.Desc.Options(), annotations.E_DefaultHost).(string)
+					// Servers are derived from default hosts unless they are given explicitly.
+					defaultHost := ""
+					if !g.explicitServers {
+						defaultHost = proto.GetExtension(service.Desc.Options(), annotations.E_DefaultHost).(string)
+					}
 
 					op, path2 := g.buildOperationV3(
 						d, operationID, service.GoName, comment, defaultHost, path, body, inputMessage, outputMessage)
+					op.Deprecated = isDeprecated(method.Desc)
+					g.addOpenAPIv2OperationToOperationV3(op, method)
+					g.addMethodResponsesV3(d, op, method)
 
 					// Merge any `Operation` annotations with the current
 					extOperation := proto.GetExtension(method.Desc.Options(), v3.E_Operation)
 					if extOperation != nil {
 						proto.Merge(op, extOperation.(*v3.Operation))
+						// Tags of the annotation are added to the tag of the service.
+						tags := []string{}
+						for _, tag := range op.Tags {
+							tags = appendUnique(tags, tag)
+						}
+						op.Tags = tags
 					}
 
 					g.addOperationToDocumentV3(d, op, path2, methodName)
@@ -766,7 +1112,14 @@ func (g *OpenAPIv3Generator) addPathsToDocumentV3(d *v3.Document, services []*pr
 
 		if annotationsCount > 0 {
 			comment := g.filterCommentString(service.Comments.Leading)
-			d.Tags = append(d.Tags, &v3.Tag{Name: service.GoName, Description: comment})
+			tag := &v3.Tag{Name: service.GoName, Description: comment}
+			g.addOpenAPIv2TagToTagV3(tag, service)
+			if g.openapiv2Types != nil {
+				// Tags of protoc-gen-openapiv2 options can describe services.
+				addTagToDocumentV3(d, tag)
+			} else {
+				d.Tags = append(d.Tags, tag)
+			}
 		}
 	}
 }
@@ -819,8 +1172,14 @@ func (g *OpenAPIv3Generator) addSchemasForMessagesToDocumentV3(d *v3.Document, m
 			AdditionalProperties: make([]*v3.NamedSchemaOrReference, 0),
 		}
 
+		// Fields of a base message are described by the schema of the base.
+		base := g.baseMessage(message)
+
 		var required []string
 		for _, field := range message.Fields {
+			if base != nil && base.Desc.Fields().ByName(field.Desc.Name()) != nil {
+				continue
+			}
 			// Get the field description from the comments.
 			description := g.filterCommentString(field.Comments.Leading)
 			// Check the field annotations to see if this is a readonly or writeonly field.
@@ -851,8 +1210,17 @@ func (g *OpenAPIv3Generator) addSchemasForMessagesToDocumentV3(d *v3.Document, m
 				continue
 			}
 
+			// Check the field annotations for an example of the field.
+			var example *v3.Any
+			if text := exampleOption(field.Desc); text != "" {
+				if node := parseExample(text); node != nil {
+					example = exampleValue(node)
+				}
+			}
+
 			// If this field has siblings and is a $ref now, create a new schema use `allOf` to wrap it
-			wrapperNeeded := inputOnly || outputOnly || description != ""
+			deprecated := isDeprecated(field.Desc)
+			wrapperNeeded := inputOnly || outputOnly || description != "" || example != nil || deprecated
 			if wrapperNeeded {
 				if _, ok := fieldSchema.Oneof.(*v3.SchemaOrReference_Reference); ok {
 					fieldSchema = &v3.SchemaOrReference{Oneof: &v3.SchemaOrReference_Schema{Schema: &v3.Schema{
@@ -865,6 +1233,10 @@ func (g *OpenAPIv3Generator) addSchemasForMessagesToDocumentV3(d *v3.Document, m
 				schema.Schema.Description = description
 				schema.Schema.ReadOnly = outputOnly
 				schema.Schema.WriteOnly = inputOnly
+				schema.Schema.Deprecated = deprecated
+				if example != nil {
+					schema.Schema.Example = example
+				}
 
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), v3.E_Property)
@@ -887,6 +1259,28 @@ func (g *OpenAPIv3Generator) addSchemasForMessagesToDocumentV3(d *v3.Document, m
 			Description: messageDescription,
 			Properties:  definitionProperties,
 			Required:    required,
+			Deprecated:  isDeprecated(message.Desc),
+		}
+		// Messages with a base message combine the schema of the base with
+		// the schema of their other fields.
+		if base != nil {
+			allOf := []*v3.SchemaOrReference{g.reflect.schemaOrReferenceForMessage(base.Desc)}
+			if len(definitionProperties.AdditionalProperties) > 0 || len(required) > 0 {
+				allOf = append(allOf, &v3.SchemaOrReference{
+					Oneof: &v3.SchemaOrReference_Schema{
+						Schema: &v3.Schema{
+							Type:       "object",
+							Properties: definitionProperties,
+							Required:   required,
+						},
+					},
+				})
+			}
+			schema = &v3.Schema{
+				Description: messageDescription,
+				AllOf:       allOf,
+				Deprecated:  isDeprecated(message.Desc),
+			}
 		}
 
 		// Merge any `Schema` annotations with the current
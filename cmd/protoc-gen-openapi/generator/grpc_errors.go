@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	code_pb "google.golang.org/genproto/googleapis/rpc/code"
+
+	wk "github.com/google/gnostic/cmd/protoc-gen-openapi/generator/wellknown"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// grpcHTTPStatus maps gRPC status codes to the HTTP status codes that
+// envoy and grpc-gateway return for them, as documented in google/rpc/code.proto.
+var grpcHTTPStatus = map[code_pb.Code]int{
+	code_pb.Code_CANCELLED:           499,
+	code_pb.Code_UNKNOWN:             500,
+	code_pb.Code_INVALID_ARGUMENT:    400,
+	code_pb.Code_DEADLINE_EXCEEDED:   504,
+	code_pb.Code_NOT_FOUND:           404,
+	code_pb.Code_ALREADY_EXISTS:      409,
+	code_pb.Code_PERMISSION_DENIED:   403,
+	code_pb.Code_UNAUTHENTICATED:     401,
+	code_pb.Code_RESOURCE_EXHAUSTED:  429,
+	code_pb.Code_FAILED_PRECONDITION: 400,
+	code_pb.Code_ABORTED:             409,
+	code_pb.Code_OUT_OF_RANGE:        400,
+	code_pb.Code_UNIMPLEMENTED:       501,
+	code_pb.Code_INTERNAL:            500,
+	code_pb.Code_UNAVAILABLE:         503,
+	code_pb.Code_DATA_LOSS:           500,
+}
+
+// buildGrpcErrorResponsesV3 builds a response for each HTTP status code that
+// a gRPC error can be mapped to. Each response lists the gRPC status codes
+// that it is returned for and refers to the google.rpc.Status schema.
+func buildGrpcErrorResponsesV3(statusSchemaName string) []*v3.NamedResponseOrReference {
+	codes := make(map[int][]code_pb.Code)
+	for code, status := range grpcHTTPStatus {
+		codes[status] = append(codes[status], code)
+	}
+	statuses := make([]int, 0, len(codes))
+	for status := range codes {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+
+	responses := make([]*v3.NamedResponseOrReference, 0, len(statuses))
+	for _, status := range statuses {
+		sort.Slice(codes[status], func(i, j int) bool {
+			return codes[status][i] < codes[status][j]
+		})
+		names := make([]string, len(codes[status]))
+		for i, code := range codes[status] {
+			names[i] = code.String()
+		}
+		responses = append(responses, &v3.NamedResponseOrReference{
+			Name: strconv.Itoa(status),
+			Value: &v3.ResponseOrReference{
+				Oneof: &v3.ResponseOrReference_Response{
+					Response: &v3.Response{
+						Description: "Returned for gRPC status " + strings.Join(names, ", "),
+						Content: wk.NewApplicationJsonMediaType(&v3.SchemaOrReference{
+							Oneof: &v3.SchemaOrReference_Reference{
+								Reference: &v3.Reference{XRef: "#/components/schemas/" + statusSchemaName}}}),
+					},
+				},
+			},
+		})
+	}
+	return responses
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"log"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// baseMessage returns the message that is given by the (openapi.v3.base)
+// option of a message, or nil if the message has no base. Bases that the
+// message doesn't share all fields with are ignored with a warning.
+func (g *OpenAPIv3Generator) baseMessage(message *protogen.Message) *protogen.Message {
+	name := proto.GetExtension(message.Desc.Options(), v3.E_Base).(string)
+	if name == "" {
+		return nil
+	}
+	base := g.findMessage(protoreflect.FullName(name))
+	if base == nil {
+		log.Printf("warning: %s: base message %s not found", message.Desc.FullName(), name)
+		return nil
+	}
+	// The chain of bases can't lead back to the message.
+	for b := base; b != nil; b = g.baseMessage(b) {
+		if b.Desc.FullName() == message.Desc.FullName() {
+			log.Printf("warning: %s: base message %s is derived from it", message.Desc.FullName(), name)
+			return nil
+		}
+	}
+	for _, baseField := range base.Fields {
+		field := message.Desc.Fields().ByName(baseField.Desc.Name())
+		if field == nil || !sameFieldType(field, baseField.Desc) {
+			log.Printf("warning: %s: ignoring base message %s, which has a field %s that the message doesn't have",
+				message.Desc.FullName(), name, baseField.Desc.Name())
+			return nil
+		}
+	}
+	return base
+}
+
+// sameFieldType returns true if two fields have the same types.
+func sameFieldType(a, b protoreflect.FieldDescriptor) bool {
+	if a.Kind() != b.Kind() || a.Cardinality() != b.Cardinality() || a.IsMap() != b.IsMap() {
+		return false
+	}
+	switch a.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return a.Message().FullName() == b.Message().FullName()
+	case protoreflect.EnumKind:
+		return a.Enum().FullName() == b.Enum().FullName()
+	}
+	return true
+}
+
+// findMessage returns the message with a full name in the files of the
+// plugin, or nil if there is none.
+func (g *OpenAPIv3Generator) findMessage(name protoreflect.FullName) *protogen.Message {
+	var find func(messages []*protogen.Message) *protogen.Message
+	find = func(messages []*protogen.Message) *protogen.Message {
+		for _, message := range messages {
+			if message.Desc.FullName() == name {
+				return message
+			}
+			if m := find(message.Messages); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+	for _, file := range g.plugin.Files {
+		if m := find(file.Messages); m != nil {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// ReadTypeMappings reads a YAML file that maps fully-qualified message type
+// names to the schemas that are used for them instead of generated schemas.
+// Each schema is written as in an OpenAPI document, so it can be a $ref to a
+// schema that is defined elsewhere:
+//
+//	.mycorp.Money:
+//	  $ref: '#/components/schemas/Money'
+//	.mycorp.Color:
+//	  type: string
+//	  pattern: ^#[0-9a-f]{6}$
+func ReadTypeMappings(filename string) (map[string]*v3.SchemaOrReference, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	if info.Kind == yaml.DocumentNode && len(info.Content) > 0 {
+		info = info.Content[0]
+	}
+	if info.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: type mappings must be a map from type names to schemas", filename)
+	}
+	mappings := make(map[string]*v3.SchemaOrReference)
+	for i := 0; i+1 < len(info.Content); i += 2 {
+		typeName, value := info.Content[i].Value, info.Content[i+1]
+		schema, err := v3.NewSchemaOrReference(value, compiler.NewContext(typeName, value, nil))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err.Error())
+		}
+		// Type names are written with a leading dot in descriptors.
+		if !strings.HasPrefix(typeName, ".") {
+			typeName = "." + typeName
+		}
+		mappings[typeName] = schema
+	}
+	return mappings, nil
+}
+
+// mappedSchema returns the schema that a type is mapped to, if any. The
+// schema is copied because the generator modifies the schemas of fields.
+func (r *OpenAPIv3Reflector) mappedSchema(typeName string) (*v3.SchemaOrReference, bool) {
+	schema, ok := r.conf.TypeMappings[typeName]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(schema).(*v3.SchemaOrReference), true
+}
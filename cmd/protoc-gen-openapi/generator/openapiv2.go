@@ -0,0 +1,359 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"log"
+	"sort"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// The options of grpc-gateway's protoc-gen-openapiv2 are read to ease the
+// migration of APIs that are annotated with them. They are read with the
+// descriptors of the options that are in the plugin request, so that
+// protoc-gen-openapi doesn't depend on grpc-gateway.
+const openapiv2OptionsPackage = "grpc.gateway.protoc_gen_openapiv2.options"
+
+// newOpenAPIv2Types returns the types of the extensions of the options of
+// protoc-gen-openapiv2 that are declared in the files of a plugin request.
+func newOpenAPIv2Types(files []*protogen.File) *protoregistry.Types {
+	types := new(protoregistry.Types)
+	for _, file := range files {
+		if file.Desc.Package() != openapiv2OptionsPackage {
+			continue
+		}
+		for _, extension := range file.Extensions {
+			if err := types.RegisterExtension(dynamicpb.NewExtensionType(extension.Desc)); err != nil {
+				log.Printf("failed to register %s: %s", extension.Desc.FullName(), err.Error())
+			}
+		}
+	}
+	return types
+}
+
+// openapiv2Option returns the value of an option of protoc-gen-openapiv2,
+// or nil if it is not set or options of protoc-gen-openapiv2 are not read.
+func (g *OpenAPIv3Generator) openapiv2Option(options proto.Message, name protoreflect.Name) protoreflect.Message {
+	if g.openapiv2Types == nil {
+		return nil
+	}
+	extensionType, err := g.openapiv2Types.FindExtensionByName(openapiv2OptionsPackage + "." + protoreflect.FullName(name))
+	if err != nil {
+		return nil
+	}
+	// The options were parsed without the extension, so it is in their
+	// unknown fields. Parse them again with a resolver that knows it.
+	bytes, err := proto.Marshal(options)
+	if err != nil {
+		return nil
+	}
+	parsed := options.ProtoReflect().New().Interface()
+	if err = (proto.UnmarshalOptions{Resolver: g.openapiv2Types}).Unmarshal(bytes, parsed); err != nil {
+		log.Printf("failed to read %s: %s", extensionType.TypeDescriptor().FullName(), err.Error())
+		return nil
+	}
+	if !parsed.ProtoReflect().Has(extensionType.TypeDescriptor()) {
+		return nil
+	}
+	return parsed.ProtoReflect().Get(extensionType.TypeDescriptor()).Message()
+}
+
+// addOpenAPIv2SwaggerToDocumentV3 adds the (openapiv2_swagger) option of a
+// file to a document: its info, tags, security definitions and
+// requirements, and external docs.
+func (g *OpenAPIv3Generator) addOpenAPIv2SwaggerToDocumentV3(d *v3.Document, file *protogen.File) {
+	swagger := g.openapiv2Option(file.Desc.Options(), "openapiv2_swagger")
+	if swagger == nil {
+		return
+	}
+	if info := messageField(swagger, "info"); info != nil {
+		setString(&d.Info.Title, stringField(info, "title"))
+		setString(&d.Info.Description, stringField(info, "description"))
+		setString(&d.Info.TermsOfService, stringField(info, "terms_of_service"))
+		setString(&d.Info.Version, stringField(info, "version"))
+		if contact := messageField(info, "contact"); contact != nil {
+			d.Info.Contact = &v3.Contact{
+				Name:  stringField(contact, "name"),
+				Url:   stringField(contact, "url"),
+				Email: stringField(contact, "email"),
+			}
+		}
+		if license := messageField(info, "license"); license != nil {
+			d.Info.License = &v3.License{
+				Name: stringField(license, "name"),
+				Url:  stringField(license, "url"),
+			}
+		}
+	}
+	for _, tag := range messagesField(swagger, "tags") {
+		addTagToDocumentV3(d, &v3.Tag{
+			Name:         stringField(tag, "name"),
+			Description:  stringField(tag, "description"),
+			ExternalDocs: buildOpenAPIv2ExternalDocsV3(messageField(tag, "external_docs")),
+		})
+	}
+	if definitions := messageField(swagger, "security_definitions"); definitions != nil {
+		schemes := mapField(definitions, "security")
+		for _, name := range sortedMapKeys(schemes) {
+			if d.Components.SecuritySchemes == nil {
+				d.Components.SecuritySchemes = &v3.SecuritySchemesOrReferences{}
+			}
+			d.Components.SecuritySchemes.AdditionalProperties = append(d.Components.SecuritySchemes.AdditionalProperties,
+				&v3.NamedSecuritySchemeOrReference{
+					Name: name,
+					Value: &v3.SecuritySchemeOrReference{
+						Oneof: &v3.SecuritySchemeOrReference_SecurityScheme{
+							SecurityScheme: buildOpenAPIv2SecuritySchemeV3(schemes.Get(protoreflect.ValueOfString(name).MapKey()).Message()),
+						},
+					},
+				})
+		}
+	}
+	for _, requirement := range messagesField(swagger, "security") {
+		d.Security = append(d.Security, buildOpenAPIv2SecurityRequirementV3(requirement))
+	}
+	if externalDocs := buildOpenAPIv2ExternalDocsV3(messageField(swagger, "external_docs")); externalDocs != nil {
+		d.ExternalDocs = externalDocs
+	}
+}
+
+// addOpenAPIv2OperationToOperationV3 adds the (openapiv2_operation) option
+// of a method to its operation. Tags of the option replace the tag of the
+// service, as they do in protoc-gen-openapiv2.
+func (g *OpenAPIv3Generator) addOpenAPIv2OperationToOperationV3(op *v3.Operation, method *protogen.Method) {
+	operation := g.openapiv2Option(method.Desc.Options(), "openapiv2_operation")
+	if operation == nil {
+		return
+	}
+	if tags := stringsField(operation, "tags"); len(tags) > 0 {
+		op.Tags = tags
+	}
+	setString(&op.Summary, stringField(operation, "summary"))
+	setString(&op.Description, stringField(operation, "description"))
+	setString(&op.OperationId, stringField(operation, "operation_id"))
+	if boolField(operation, "deprecated") {
+		op.Deprecated = true
+	}
+	if externalDocs := buildOpenAPIv2ExternalDocsV3(messageField(operation, "external_docs")); externalDocs != nil {
+		op.ExternalDocs = externalDocs
+	}
+	for _, requirement := range messagesField(operation, "security") {
+		op.Security = append(op.Security, buildOpenAPIv2SecurityRequirementV3(requirement))
+	}
+}
+
+// addOpenAPIv2TagToTagV3 adds the (openapiv2_tag) option of a service to
+// its tag.
+func (g *OpenAPIv3Generator) addOpenAPIv2TagToTagV3(tag *v3.Tag, service *protogen.Service) {
+	option := g.openapiv2Option(service.Desc.Options(), "openapiv2_tag")
+	if option == nil {
+		return
+	}
+	setString(&tag.Description, stringField(option, "description"))
+	if externalDocs := buildOpenAPIv2ExternalDocsV3(messageField(option, "external_docs")); externalDocs != nil {
+		tag.ExternalDocs = externalDocs
+	}
+}
+
+// addTagToDocumentV3 adds a tag to a document, or fills in the empty
+// fields of the tag of the document with the same name.
+func addTagToDocumentV3(d *v3.Document, tag *v3.Tag) {
+	for _, existing := range d.Tags {
+		if existing.Name == tag.Name {
+			if existing.Description == "" {
+				existing.Description = tag.Description
+			}
+			if existing.ExternalDocs == nil {
+				existing.ExternalDocs = tag.ExternalDocs
+			}
+			return
+		}
+	}
+	d.Tags = append(d.Tags, tag)
+}
+
+func buildOpenAPIv2ExternalDocsV3(externalDocs protoreflect.Message) *v3.ExternalDocs {
+	if externalDocs == nil {
+		return nil
+	}
+	return &v3.ExternalDocs{
+		Description: stringField(externalDocs, "description"),
+		Url:         stringField(externalDocs, "url"),
+	}
+}
+
+// buildOpenAPIv2SecuritySchemeV3 converts a security scheme of OpenAPI v2.
+// Basic authentication becomes an HTTP scheme, and the OAuth 2 flows
+// "application" and "accessCode" become "clientCredentials" and
+// "authorizationCode".
+func buildOpenAPIv2SecuritySchemeV3(scheme protoreflect.Message) *v3.SecurityScheme {
+	s := &v3.SecurityScheme{Description: stringField(scheme, "description")}
+	switch enumField(scheme, "type") {
+	case "TYPE_BASIC":
+		s.Type = "http"
+		s.Scheme = "basic"
+	case "TYPE_API_KEY":
+		s.Type = "apiKey"
+		s.Name = stringField(scheme, "name")
+		switch enumField(scheme, "in") {
+		case "IN_QUERY":
+			s.In = "query"
+		case "IN_HEADER":
+			s.In = "header"
+		}
+	case "TYPE_OAUTH2":
+		s.Type = "oauth2"
+		flow := &v3.OauthFlow{
+			AuthorizationUrl: stringField(scheme, "authorization_url"),
+			TokenUrl:         stringField(scheme, "token_url"),
+			Scopes:           &v3.Strings{},
+		}
+		if scopes := messageField(scheme, "scopes"); scopes != nil {
+			m := mapField(scopes, "scope")
+			for _, name := range sortedMapKeys(m) {
+				flow.Scopes.AdditionalProperties = append(flow.Scopes.AdditionalProperties, &v3.NamedString{
+					Name:  name,
+					Value: m.Get(protoreflect.ValueOfString(name).MapKey()).String(),
+				})
+			}
+		}
+		s.Flows = &v3.OauthFlows{}
+		switch enumField(scheme, "flow") {
+		case "FLOW_IMPLICIT":
+			flow.TokenUrl = ""
+			s.Flows.Implicit = flow
+		case "FLOW_PASSWORD":
+			flow.AuthorizationUrl = ""
+			s.Flows.Password = flow
+		case "FLOW_APPLICATION":
+			flow.AuthorizationUrl = ""
+			s.Flows.ClientCredentials = flow
+		case "FLOW_ACCESS_CODE":
+			s.Flows.AuthorizationCode = flow
+		}
+	}
+	return s
+}
+
+func buildOpenAPIv2SecurityRequirementV3(requirement protoreflect.Message) *v3.SecurityRequirement {
+	r := &v3.SecurityRequirement{}
+	m := mapField(requirement, "security_requirement")
+	for _, name := range sortedMapKeys(m) {
+		value := m.Get(protoreflect.ValueOfString(name).MapKey()).Message()
+		r.AdditionalProperties = append(r.AdditionalProperties, &v3.NamedStringArray{
+			Name:  name,
+			Value: &v3.StringArray{Value: stringsField(value, "scope")},
+		})
+	}
+	return r
+}
+
+// setString sets a string unless the value is empty.
+func setString(s *string, value string) {
+	if value != "" {
+		*s = value
+	}
+}
+
+// The following functions read the fields of options by name. They return
+// zero values for fields that are missing from the descriptors of the
+// options, e.g. fields of newer versions of protoc-gen-openapiv2.
+
+func optionField(m protoreflect.Message, name protoreflect.Name) protoreflect.FieldDescriptor {
+	return m.Descriptor().Fields().ByName(name)
+}
+
+func stringField(m protoreflect.Message, name protoreflect.Name) string {
+	if fd := optionField(m, name); fd != nil && fd.Kind() == protoreflect.StringKind && !fd.IsList() {
+		return m.Get(fd).String()
+	}
+	return ""
+}
+
+func boolField(m protoreflect.Message, name protoreflect.Name) bool {
+	if fd := optionField(m, name); fd != nil && fd.Kind() == protoreflect.BoolKind && !fd.IsList() {
+		return m.Get(fd).Bool()
+	}
+	return false
+}
+
+// enumField returns the name of the value of an enum field.
+func enumField(m protoreflect.Message, name protoreflect.Name) protoreflect.Name {
+	if fd := optionField(m, name); fd != nil && fd.Kind() == protoreflect.EnumKind && !fd.IsList() {
+		if value := fd.Enum().Values().ByNumber(m.Get(fd).Enum()); value != nil {
+			return value.Name()
+		}
+	}
+	return ""
+}
+
+func stringsField(m protoreflect.Message, name protoreflect.Name) []string {
+	var values []string
+	if fd := optionField(m, name); fd != nil && fd.Kind() == protoreflect.StringKind && fd.IsList() {
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, list.Get(i).String())
+		}
+	}
+	return values
+}
+
+// messageField returns the value of a message field, or nil if it is not set.
+func messageField(m protoreflect.Message, name protoreflect.Name) protoreflect.Message {
+	if fd := optionField(m, name); fd != nil && fd.Message() != nil && !fd.IsList() && !fd.IsMap() && m.Has(fd) {
+		return m.Get(fd).Message()
+	}
+	return nil
+}
+
+func messagesField(m protoreflect.Message, name protoreflect.Name) []protoreflect.Message {
+	var values []protoreflect.Message
+	if fd := optionField(m, name); fd != nil && fd.Message() != nil && fd.IsList() {
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, list.Get(i).Message())
+		}
+	}
+	return values
+}
+
+// mapField returns the value of a map field with string keys, or nil.
+func mapField(m protoreflect.Message, name protoreflect.Name) protoreflect.Map {
+	if fd := optionField(m, name); fd != nil && fd.IsMap() && fd.MapKey().Kind() == protoreflect.StringKind {
+		return m.Get(fd).Map()
+	}
+	return nil
+}
+
+// sortedMapKeys returns the keys of a map with string keys in order.
+func sortedMapKeys(m protoreflect.Map) []string {
+	var keys []string
+	if m == nil {
+		return keys
+	}
+	m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key.String())
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// queryMessageStyle returns the encoding of message fields in queries.
+func (g *OpenAPIv3Generator) queryMessageStyle() string {
+	if g.conf.QueryMessageStyle == nil || *g.conf.QueryMessageStyle == "" {
+		return "dotted"
+	}
+	return *g.conf.QueryMessageStyle
+}
+
+// queryExplode returns the explode value of repeated and form-style
+// message parameters.
+func (g *OpenAPIv3Generator) queryExplode() bool {
+	return g.conf.QueryExplode == nil || *g.conf.QueryExplode
+}
+
+// setRepeatedStyle sets the style of a parameter for a repeated field when
+// it isn't exploded. Repeated fields are exploded by default, as in
+// "?a=1&a=2", and otherwise are comma-separated lists, as in "?a=1,2".
+func (g *OpenAPIv3Generator) setRepeatedStyle(parameter *v3.Parameter, field *protogen.Field) {
+	if field.Desc.IsList() && !g.queryExplode() {
+		parameter.Style = "form"
+		g.setExplode(parameter, false)
+	}
+}
+
+// setExplode sets the explode value of a parameter. Parameters that are not
+// exploded are recorded, because false is the default of the explode field
+// and isn't written, but "explode: false" must be written for the form
+// style, which is exploded by default.
+func (g *OpenAPIv3Generator) setExplode(parameter *v3.Parameter, explode bool) {
+	parameter.Explode = explode
+	if explode {
+		return
+	}
+	if g.unexploded == nil {
+		g.unexploded = make(map[*v3.Parameter]bool)
+	}
+	g.unexploded[parameter] = true
+}
+
+// addUnexplodedParameters adds "explode: false" to the nodes of the
+// parameters of a document that are not exploded.
+func (g *OpenAPIv3Generator) addUnexplodedParameters(rawInfo *yaml.Node, d *v3.Document) {
+	if len(g.unexploded) == 0 {
+		return
+	}
+	paths := mappingValue(rawInfo, "paths")
+	for _, path := range d.GetPaths().GetPath() {
+		pathItem := mappingValue(paths, path.Name)
+		item := path.Value
+		operations := []struct {
+			method    string
+			operation *v3.Operation
+		}{
+			{"get", item.GetGet()}, {"put", item.GetPut()}, {"post", item.GetPost()},
+			{"delete", item.GetDelete()}, {"options", item.GetOptions()}, {"head", item.GetHead()},
+			{"patch", item.GetPatch()}, {"trace", item.GetTrace()},
+		}
+		for _, o := range operations {
+			parameters := mappingValue(mappingValue(pathItem, o.method), "parameters")
+			if parameters == nil || len(parameters.Content) != len(o.operation.GetParameters()) {
+				continue
+			}
+			for i, parameter := range o.operation.GetParameters() {
+				if g.unexploded[parameter.GetParameter()] {
+					insertAfter(parameters.Content[i], "style",
+						compiler.NewScalarNodeForString("explode"), compiler.NewScalarNodeForBool(false))
+				}
+			}
+		}
+	}
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil if the
+// node isn't a mapping or doesn't have the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// insertAfter inserts a key and value into a mapping node after another
+// key, or at the end of the mapping if the node doesn't have that key.
+func insertAfter(node *yaml.Node, after string, key, value *yaml.Node) {
+	i := len(node.Content)
+	for j := 0; j+1 < len(node.Content); j += 2 {
+		if node.Content[j].Value == after {
+			i = j + 2
+			break
+		}
+	}
+	content := append([]*yaml.Node{}, node.Content[:i]...)
+	content = append(content, key, value)
+	node.Content = append(content, node.Content[i:]...)
+}
@@ -56,10 +56,34 @@ func (r *OpenAPIv3Reflector) getMessageName(message protoreflect.MessageDescript
 	return prefix + string(message.Name())
 }
 
+// nestedMessageName returns the name of a message that is used in the name
+// of its schema. The names of nested messages are joined with the names of
+// the messages that contain them, with "_" by default or "." with
+// nested_naming=dot, or are used without them with nested_naming=flatten.
+func (r *OpenAPIv3Reflector) nestedMessageName(message protoreflect.MessageDescriptor) string {
+	separator := "_"
+	if r.conf.NestedNaming != nil {
+		switch *r.conf.NestedNaming {
+		case "dot":
+			separator = "."
+		case "flatten":
+			return string(message.Name())
+		}
+	}
+	name := string(message.Name())
+	for parent := message.Parent(); parent != nil; parent = parent.Parent() {
+		if _, ok := parent.(protoreflect.MessageDescriptor); !ok {
+			break
+		}
+		name = string(parent.Name()) + separator + name
+	}
+	return name
+}
+
 func (r *OpenAPIv3Reflector) formatMessageName(message protoreflect.MessageDescriptor) string {
 	typeName := r.fullMessageTypeName(message)
 
-	name := r.getMessageName(message)
+	name := r.nestedMessageName(message)
 	if !*r.conf.FQSchemaNaming {
 		if typeName == ".google.protobuf.Value" {
 			name = protobufValueName
@@ -111,7 +135,22 @@ func (r *OpenAPIv3Reflector) responseContentForMessage(message protoreflect.Mess
 		return "200", wk.NewGoogleApiHttpBodyMediaType()
 	}
 
-	return "200", wk.NewApplicationJsonMediaType(r.schemaOrReferenceForMessage(message))
+	content := wk.NewApplicationJsonMediaType(r.schemaOrReferenceForMessage(message))
+	content.AdditionalProperties[0].Value.Example = r.mediaTypeExample(message)
+	return "200", content
+}
+
+// mediaTypeExample returns the example of a message for the media types
+// that use it, or nil if examples are not enabled.
+func (r *OpenAPIv3Reflector) mediaTypeExample(message protoreflect.MessageDescriptor) *v3.Any {
+	if r.conf.Examples == nil || !*r.conf.Examples {
+		return nil
+	}
+	example := r.exampleForMessage(message)
+	if example == nil {
+		return nil
+	}
+	return exampleValue(example)
 }
 
 func (r *OpenAPIv3Reflector) schemaReferenceForMessage(message protoreflect.MessageDescriptor) string {
@@ -127,6 +166,10 @@ func (r *OpenAPIv3Reflector) schemaReferenceForMessage(message protoreflect.Mess
 func (r *OpenAPIv3Reflector) schemaOrReferenceForMessage(message protoreflect.MessageDescriptor) *v3.SchemaOrReference {
 	typeName := r.fullMessageTypeName(message)
 
+	if schema, ok := r.mappedSchema(typeName); ok {
+		return schema
+	}
+
 	switch typeName {
 
 	case ".google.api.HttpBody":
@@ -154,20 +197,28 @@ func (r *OpenAPIv3Reflector) schemaOrReferenceForMessage(message protoreflect.Me
 		// Empty is closer to JSON undefined than null, so ignore this field
 		return nil //&v3.SchemaOrReference{Oneof: &v3.SchemaOrReference_Schema{Schema: &v3.Schema{Type: "null"}}}
 
+	case ".google.protobuf.ListValue":
+		// ListValue is an array of Values, which refer to their own schema.
+		values := message.Fields().ByName("values")
+		return wk.NewGoogleProtobufListValueSchema(r.schemaReferenceForMessage(values.Message()))
+
 	case ".google.protobuf.BoolValue":
-		return wk.NewBooleanSchema()
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewBooleanSchema())
 
 	case ".google.protobuf.BytesValue":
-		return wk.NewBytesSchema()
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewBytesSchema())
 
 	case ".google.protobuf.Int32Value", ".google.protobuf.UInt32Value":
-		return wk.NewIntegerSchema(getValueKind(message))
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewIntegerSchema(getValueKind(message)))
 
-	case ".google.protobuf.StringValue", ".google.protobuf.Int64Value", ".google.protobuf.UInt64Value":
-		return wk.NewStringSchema()
+	case ".google.protobuf.StringValue":
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewStringSchema())
+
+	case ".google.protobuf.Int64Value", ".google.protobuf.UInt64Value":
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewInt64Schema(getValueKind(message)))
 
 	case ".google.protobuf.FloatValue", ".google.protobuf.DoubleValue":
-		return wk.NewNumberSchema(getValueKind(message))
+		return wk.NewGoogleProtobufWrapperSchema(wk.NewNumberSchema(getValueKind(message)))
 
 	default:
 		ref := r.schemaReferenceForMessage(message)
@@ -213,7 +264,7 @@ func (r *OpenAPIv3Reflector) schemaOrReferenceForField(field protoreflect.FieldD
 
 	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind,
 		protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind:
-		kindSchema = wk.NewStringSchema()
+		kindSchema = wk.NewInt64Schema(kind.String())
 
 	case protoreflect.EnumKind:
 		kindSchema = wk.NewEnumSchema(*&r.conf.EnumType, field)
@@ -0,0 +1,157 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	wk "github.com/google/gnostic/cmd/protoc-gen-openapi/generator/wellknown"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// addMethodResponsesV3 adds the responses that are declared with the
+// (openapi.v3.responses) option of a method to its operation. Declared
+// responses replace the generated responses with the same status, except
+// that the generated content is kept if no message is declared.
+func (g *OpenAPIv3Generator) addMethodResponsesV3(d *v3.Document, op *v3.Operation, method *protogen.Method) {
+	methodResponses, _ := proto.GetExtension(method.Desc.Options(), v3.E_Responses).([]*v3.MethodResponse)
+	for _, methodResponse := range methodResponses {
+		response := findResponse(op.Responses, methodResponse.Status)
+		if response == nil {
+			response = &v3.Response{Description: responseDescription(methodResponse.Status)}
+			insertResponse(op.Responses, &v3.NamedResponseOrReference{
+				Name: methodResponse.Status,
+				Value: &v3.ResponseOrReference{
+					Oneof: &v3.ResponseOrReference_Response{Response: response},
+				},
+			})
+		}
+		if methodResponse.Description != "" {
+			response.Description = methodResponse.Description
+		}
+		if methodResponse.Message != "" {
+			if schema := g.schemaOrReferenceForMessageName(d, methodResponse.Message); schema != nil {
+				response.Content = wk.NewApplicationJsonMediaType(schema)
+			} else {
+				log.Printf("unknown message %q in the responses of %s", methodResponse.Message, method.Desc.FullName())
+			}
+		}
+		if len(methodResponse.Headers) > 0 {
+			if response.Headers == nil {
+				response.Headers = &v3.HeadersOrReferences{}
+			}
+			for _, header := range methodResponse.Headers {
+				response.Headers.AdditionalProperties = append(response.Headers.AdditionalProperties, buildResponseHeaderV3(header))
+			}
+		}
+	}
+}
+
+// schemaOrReferenceForMessageName returns a reference to the schema of the
+// message with a fully-qualified name, or nil if there is no such message.
+func (g *OpenAPIv3Generator) schemaOrReferenceForMessageName(d *v3.Document, name string) *v3.SchemaOrReference {
+	fullName := protoreflect.FullName(strings.TrimPrefix(name, "."))
+	if fullName == statusProtoDesc.FullName() {
+		statusSchemaName := g.addStatusSchemaToDocumentV3(d)
+		return &v3.SchemaOrReference{
+			Oneof: &v3.SchemaOrReference_Reference{
+				Reference: &v3.Reference{XRef: "#/components/schemas/" + statusSchemaName}}}
+	}
+	for _, file := range g.plugin.Files {
+		if message := findMessage(file.Messages, fullName); message != nil {
+			return g.reflect.schemaOrReferenceForMessage(message.Desc)
+		}
+	}
+	return nil
+}
+
+// findMessage finds a message with a fully-qualified name in a list of
+// messages and the messages that are nested in them.
+func findMessage(messages []*protogen.Message, fullName protoreflect.FullName) *protogen.Message {
+	for _, message := range messages {
+		if message.Desc.FullName() == fullName {
+			return message
+		}
+		if nested := findMessage(message.Messages, fullName); nested != nil {
+			return nested
+		}
+	}
+	return nil
+}
+
+// findResponse returns the response of an operation with a status, or nil
+// if the operation has no such response.
+func findResponse(responses *v3.Responses, status string) *v3.Response {
+	for _, namedResponse := range responses.ResponseOrReference {
+		if namedResponse.Name == status {
+			return namedResponse.Value.GetResponse()
+		}
+	}
+	return nil
+}
+
+// insertResponse adds a response to the responses of an operation, keeping
+// the default response last.
+func insertResponse(responses *v3.Responses, response *v3.NamedResponseOrReference) {
+	pairs := responses.ResponseOrReference
+	if n := len(pairs); n > 0 && pairs[n-1].Name == "default" {
+		pairs = append(pairs[:n-1], response, pairs[n-1])
+	} else {
+		pairs = append(pairs, response)
+	}
+	responses.ResponseOrReference = pairs
+}
+
+// responseDescription returns the description of a response that is used
+// if none is declared. Descriptions are required in OpenAPI documents.
+func responseDescription(status string) string {
+	if code, err := strconv.Atoi(status); err == nil && http.StatusText(code) != "" {
+		return http.StatusText(code)
+	}
+	return "Default response"
+}
+
+// buildResponseHeaderV3 builds a header of a response from its declaration.
+func buildResponseHeaderV3(header *v3.ResponseHeader) *v3.NamedHeaderOrReference {
+	headerType := header.Type
+	if headerType == "" {
+		headerType = "string"
+	}
+	return &v3.NamedHeaderOrReference{
+		Name: header.Name,
+		Value: &v3.HeaderOrReference{
+			Oneof: &v3.HeaderOrReference_Header{
+				Header: &v3.Header{
+					Description: header.Description,
+					Schema: &v3.SchemaOrReference{
+						Oneof: &v3.SchemaOrReference_Schema{
+							Schema: &v3.Schema{
+								Type:   headerType,
+								Format: header.Format,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// ReadServers reads a YAML file with a list of servers. Each server is
+// written as in the servers list of an OpenAPI document, so it can have a
+// description and variables.
+func ReadServers(filename string) ([]*v3.Server, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	if info.Kind == yaml.DocumentNode && len(info.Content) > 0 {
+		info = info.Content[0]
+	}
+	if info.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s: servers must be a list of servers", filename)
+	}
+	servers := make([]*v3.Server, 0, len(info.Content))
+	for i, value := range info.Content {
+		server, err := v3.NewServer(value, compiler.NewContext(fmt.Sprintf("servers[%d]", i), value, nil))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err.Error())
+		}
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
+
+// hasServers returns true if the servers of the document are given with the
+// servers parameter or with the (openapi.v3.document) options of the input
+// files. These servers replace the servers that are derived from the
+// google.api.default_host options of services.
+func (g *OpenAPIv3Generator) hasServers() bool {
+	if len(g.conf.Servers) > 0 {
+		return true
+	}
+	for _, file := range g.inputFiles {
+		if !file.Generate {
+			continue
+		}
+		if extDocument, ok := proto.GetExtension(file.Desc.Options(), v3.E_Document).(*v3.Document); ok && len(extDocument.GetServers()) > 0 {
+			return true
+		}
+	}
+	return false
+}
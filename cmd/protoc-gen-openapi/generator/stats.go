@@ -0,0 +1,69 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package generator
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+
+	v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Statistics describes the size of a generated document.
+type Statistics struct {
+	Paths      int `json:"paths" yaml:"paths"`
+	Operations int `json:"operations" yaml:"operations"`
+	Schemas    int `json:"schemas" yaml:"schemas"`
+	Bytes      int `json:"bytes" yaml:"bytes"`
+}
+
+// newStatistics counts the paths, operations, and schemas of a document.
+func newStatistics(d *v3.Document, size int) *Statistics {
+	s := &Statistics{
+		Paths:   len(d.GetPaths().GetPath()),
+		Schemas: len(d.GetComponents().GetSchemas().GetAdditionalProperties()),
+		Bytes:   size,
+	}
+	for _, path := range d.GetPaths().GetPath() {
+		item := path.GetValue()
+		for _, op := range []*v3.Operation{item.GetGet(), item.GetPut(), item.GetPost(), item.GetDelete(),
+			item.GetOptions(), item.GetHead(), item.GetPatch(), item.GetTrace()} {
+			if op != nil {
+				s.Operations++
+			}
+		}
+	}
+	return s
+}
+
+// Marshal returns the statistics in a format, "yaml" or "json".
+func (s *Statistics) Marshal(format string) ([]byte, error) {
+	if format == "json" {
+		bytes, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(bytes, '\n'), nil
+	}
+	return yaml.Marshal(s)
+}
+
+// Statistics returns the statistics of the document that was generated by
+// Run, or nil if Run has not generated a document.
+func (g *OpenAPIv3Generator) Statistics() *Statistics {
+	return g.statistics
+}
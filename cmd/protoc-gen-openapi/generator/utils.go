@@ -62,3 +62,12 @@ func getValueField(message protoreflect.MessageDescriptor) protoreflect.FieldDes
 	fields := message.Fields()
 	return fields.ByName("value")
 }
+
+// isDeprecated returns true if a method, message or field has the
+// deprecated option. The options of all of these have a deprecated field.
+func isDeprecated(desc protoreflect.Descriptor) bool {
+	if options, ok := desc.Options().(interface{ GetDeprecated() bool }); ok {
+		return options.GetDeprecated()
+	}
+	return false
+}
@@ -26,6 +26,12 @@ func NewStringSchema() *v3.SchemaOrReference {
 			Schema: &v3.Schema{Type: "string"}}}
 }
 
+func NewInt64Schema(format string) *v3.SchemaOrReference {
+	return &v3.SchemaOrReference{
+		Oneof: &v3.SchemaOrReference_Schema{
+			Schema: &v3.Schema{Type: "string", Format: format}}}
+}
+
 func NewBooleanSchema() *v3.SchemaOrReference {
 	return &v3.SchemaOrReference{
 		Oneof: &v3.SchemaOrReference_Schema{
@@ -79,6 +85,22 @@ func NewListSchema(item_schema *v3.SchemaOrReference) *v3.SchemaOrReference {
 	}
 }
 
+// Wrapper types such as google.protobuf.StringValue are serialized as
+// the wrapped primitive values, or as null when they are not set.
+func NewGoogleProtobufWrapperSchema(value_schema *v3.SchemaOrReference) *v3.SchemaOrReference {
+	if schema := value_schema.GetSchema(); schema != nil {
+		schema.Nullable = true
+	}
+	return value_schema
+}
+
+// google.protobuf.ListValue is serialized as a JSON array of values
+func NewGoogleProtobufListValueSchema(value_ref string) *v3.SchemaOrReference {
+	return NewListSchema(&v3.SchemaOrReference{
+		Oneof: &v3.SchemaOrReference_Reference{
+			Reference: &v3.Reference{XRef: value_ref}}})
+}
+
 // google.api.HttpBody will contain POST body data
 // This is based on how Envoy handles google.api.HttpBody
 func NewGoogleApiHttpBodySchema() *v3.SchemaOrReference {
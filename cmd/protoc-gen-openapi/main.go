@@ -17,6 +17,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"log"
 	"path/filepath"
 	"strings"
 
@@ -29,16 +31,30 @@ var flags flag.FlagSet
 
 func main() {
 	conf := generator.Configuration{
-		Version:         flags.String("version", "0.0.1", "version number text, e.g. 1.2.3"),
-		Title:           flags.String("title", "", "name of the API"),
-		Description:     flags.String("description", "", "description of the API"),
-		Naming:          flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
-		FQSchemaNaming:  flags.Bool("fq_schema_naming", false, `schema naming convention. If "true", generates fully-qualified schema names by prefixing them with the proto message package name`),
-		EnumType:        flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
-		CircularDepth:   flags.Int("depth", 2, "depth of recursion for circular messages"),
-		DefaultResponse: flags.Bool("default_response", true, `add default response. If "true", automatically adds a default response to operations which use the google.rpc.Status message. Useful if you use envoy or grpc-gateway to transcode as they use this type for their default error responses.`),
-		OutputMode:      flags.String("output_mode", "merged", `output generation mode. By default, a single openapi.yaml is generated at the out folder. Use "source_relative' to generate a separate '[inputfile].openapi.yaml' next to each '[inputfile].proto'.`),
+		Version:              flags.String("version", "0.0.1", "version number text, e.g. 1.2.3"),
+		Title:                flags.String("title", "", "name of the API"),
+		Description:          flags.String("description", "", "description of the API"),
+		Naming:               flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
+		FQSchemaNaming:       flags.Bool("fq_schema_naming", false, `schema naming convention. If "true", generates fully-qualified schema names by prefixing them with the proto message package name`),
+		EnumType:             flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
+		CircularDepth:        flags.Int("depth", 2, "depth of recursion for circular messages"),
+		DefaultResponse:      flags.Bool("default_response", true, `add default response. If "true", automatically adds a default response to operations which use the google.rpc.Status message. Useful if you use envoy or grpc-gateway to transcode as they use this type for their default error responses.`),
+		GrpcErrorResponses:   flags.Bool("grpc_error_responses", false, `add responses for the HTTP statuses of gRPC errors. If "true", adds a response referring to the google.rpc.Status message for each HTTP status that envoy or grpc-gateway return for gRPC errors, e.g. 404 for NOT_FOUND and 409 for ABORTED.`),
+		OutputMode:           flags.String("output_mode", "merged", `output generation mode. By default, a single openapi.yaml is generated at the out folder. Use "source_relative' to generate a separate '[inputfile].openapi.yaml' next to each '[inputfile].proto'.`),
+		Format:               flags.String("format", "yaml", `output format. Use "json" to generate openapi.json instead of openapi.yaml`),
+		PathOrder:            flags.String("path_order", "alpha", `order of paths. Use "declaration" to keep paths in the order in which their methods are declared in the proto files`),
+		NestedNaming:         flags.String("nested_naming", "underscore", `naming of the schemas of nested messages. Use "dot" to join them with the names of their parents with ".", e.g. "Message.Sub", or "flatten" to use their own names, e.g. "Sub"`),
+		Sorting:              flags.String("sorting", "alpha", `order of tags, paths, and schemas. Use "source" to keep them in the order of the services, methods, and messages in the proto files, or "none" to keep them in the order in which they are generated`),
+		Examples:             flags.Bool("examples", false, `add examples of request and response bodies. If "true", synthesizes an example of each message from the (openapi.v3.example) options of its fields and representative values of their types`),
+		QueryMessageStyle:    flags.String("query_message_style", "dotted", `encoding of message fields in queries. Use "deepObject" or "form" for a single parameter with that style instead of a parameter for each field with a dotted name`),
+		QueryExplode:         flags.Bool("query_explode", true, `explode repeated fields and form-style message fields in queries. If "false", their values are written as comma-separated lists, e.g. "?ids=1,2"`),
+		OpenAPIv2Annotations: flags.Bool("openapiv2_annotations", false, `read the (grpc.gateway.protoc_gen_openapiv2.options) options of grpc-gateway's protoc-gen-openapiv2. If "true", converts their info, tags, security schemes, and operations to OpenAPI v3, so that protos can be migrated without rewriting their annotations. (openapi.v3) options take precedence`),
 	}
+	servers := flags.String("servers", "", `path of a YAML file with a list of servers, which can have descriptions and variables. The servers replace the servers that are given with (openapi.v3.document) options or derived from google.api.default_host options`)
+	stats := flags.Bool("stats", false, `write statistics of each generated document, the numbers of its paths, operations, schemas, and bytes, to a file next to it, e.g. openapi.stats.yaml`)
+	maxBytes := flags.Int("max_bytes", 10<<20, "warn if a generated document has more bytes than this. Use 0 for no limit")
+	maxSchemas := flags.Int("max_schemas", 500, "warn if a generated document has more schemas than this. Use 0 for no limit")
+	typeMappings := flags.String("type_mappings", "", `path of a YAML file that maps fully-qualified message type names to the schemas that are used for them, e.g. ".mycorp.Money: {$ref: '#/components/schemas/Money'}"`)
 
 	opts := protogen.Options{
 		ParamFunc: flags.Set,
@@ -47,21 +63,75 @@ func main() {
 	opts.Run(func(plugin *protogen.Plugin) error {
 		// Enable "optional" keyword in front of type (e.g. optional string label = 1;)
 		plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		if *conf.Format != "yaml" && *conf.Format != "json" {
+			return fmt.Errorf("unsupported format %q, use \"yaml\" or \"json\"", *conf.Format)
+		}
+		if *conf.PathOrder != "alpha" && *conf.PathOrder != "declaration" {
+			return fmt.Errorf("unsupported path_order %q, use \"alpha\" or \"declaration\"", *conf.PathOrder)
+		}
+		if *conf.NestedNaming != "underscore" && *conf.NestedNaming != "dot" && *conf.NestedNaming != "flatten" {
+			return fmt.Errorf("unsupported nested_naming %q, use \"underscore\", \"dot\", or \"flatten\"", *conf.NestedNaming)
+		}
+		if *conf.Sorting != "alpha" && *conf.Sorting != "source" && *conf.Sorting != "none" {
+			return fmt.Errorf("unsupported sorting %q, use \"alpha\", \"source\", or \"none\"", *conf.Sorting)
+		}
+		switch *conf.QueryMessageStyle {
+		case "dotted", "deepObject", "form":
+		default:
+			return fmt.Errorf("unsupported query_message_style %q, use \"dotted\", \"deepObject\", or \"form\"", *conf.QueryMessageStyle)
+		}
+		if *typeMappings != "" {
+			mappings, err := generator.ReadTypeMappings(*typeMappings)
+			if err != nil {
+				return err
+			}
+			conf.TypeMappings = mappings
+		}
+		if *servers != "" {
+			list, err := generator.ReadServers(*servers)
+			if err != nil {
+				return err
+			}
+			conf.Servers = list
+		}
+		extension := "." + *conf.Format
+		// run generates a document and reports its statistics.
+		run := func(outfileName string, files []*protogen.File) error {
+			outputFile := plugin.NewGeneratedFile(outfileName, "")
+			gen := generator.NewOpenAPIv3Generator(plugin, conf, files)
+			if err := gen.Run(outputFile); err != nil {
+				return err
+			}
+			statistics := gen.Statistics()
+			if *maxBytes > 0 && statistics.Bytes > *maxBytes {
+				log.Printf("warning: %s has %d bytes, more than max_bytes=%d", outfileName, statistics.Bytes, *maxBytes)
+			}
+			if *maxSchemas > 0 && statistics.Schemas > *maxSchemas {
+				log.Printf("warning: %s has %d schemas, more than max_schemas=%d", outfileName, statistics.Schemas, *maxSchemas)
+			}
+			if !*stats {
+				return nil
+			}
+			bytes, err := statistics.Marshal(*conf.Format)
+			if err != nil {
+				return err
+			}
+			statsFile := plugin.NewGeneratedFile(strings.TrimSuffix(outfileName, extension)+".stats"+extension, "")
+			_, err = statsFile.Write(bytes)
+			return err
+		}
 		if *conf.OutputMode == "source_relative" {
 			for _, file := range plugin.Files {
 				if !file.Generate {
 					continue
 				}
-				outfileName := strings.TrimSuffix(file.Desc.Path(), filepath.Ext(file.Desc.Path())) + ".openapi.yaml"
-				outputFile := plugin.NewGeneratedFile(outfileName, "")
-				gen := generator.NewOpenAPIv3Generator(plugin, conf, []*protogen.File{file})
-				if err := gen.Run(outputFile); err != nil {
+				outfileName := strings.TrimSuffix(file.Desc.Path(), filepath.Ext(file.Desc.Path())) + ".openapi" + extension
+				if err := run(outfileName, []*protogen.File{file}); err != nil {
 					return err
 				}
 			}
 		} else {
-			outputFile := plugin.NewGeneratedFile("openapi.yaml", "")
-			return generator.NewOpenAPIv3Generator(plugin, conf, plugin.Files).Run(outputFile)
+			return run("openapi"+extension, plugin.Files)
 		}
 		return nil
 	})
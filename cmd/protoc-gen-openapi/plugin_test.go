@@ -15,6 +15,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -22,8 +23,11 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 var openapiTests = []struct {
@@ -43,6 +47,11 @@ var openapiTests = []struct {
 	{name: "OpenAPIv3 Annotations", path: "examples/tests/openapiv3annotations/", protofile: "message.proto"},
 	{name: "AllOf Wrap Message", path: "examples/tests/allofwrap/", protofile: "message.proto"},
 	{name: "Additional Bindings", path: "examples/tests/additional_bindings/", protofile: "message.proto"},
+	{name: "Deprecated", path: "examples/tests/deprecated/", protofile: "message.proto"},
+	{name: "Responses", path: "examples/tests/responses/", protofile: "message.proto"},
+	{name: "Servers", path: "examples/tests/servers/", protofile: "message.proto"},
+	{name: "Operation options", path: "examples/tests/operationoptions/", protofile: "message.proto"},
+	{name: "Inheritance", path: "examples/tests/inheritance/", protofile: "message.proto"},
 }
 
 // Set this to true to generate/overwrite the fixtures. Make sure you set it back
@@ -291,3 +300,384 @@ func TestOpenAPIDefaultResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAPIGrpcErrorResponses(t *testing.T) {
+	for _, tt := range openapiTests {
+		fixture := path.Join(tt.path, "openapi_grpc_error_responses.yaml")
+		if _, err := os.Stat(fixture); errors.Is(err, os.ErrNotExist) {
+			if !GENERATE_FIXTURES {
+				continue
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with gRPC error responses.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(tt.path, tt.protofile),
+				"--openapi_out=grpc_error_responses=true:.").Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+			if GENERATE_FIXTURES {
+				err := CopyFixture(TEMP_FILE, fixture)
+				if err != nil {
+					t.Fatalf("Can't generate fixture: %+v", err)
+				}
+			} else {
+				// Verify that the generated spec matches our expected version.
+				err = exec.Command("diff", TEMP_FILE, fixture).Run()
+				if err != nil {
+					t.Fatalf("diff failed: %+v", err)
+				}
+			}
+			// if the test succeeded, clean up
+			os.Remove(TEMP_FILE)
+		})
+	}
+}
+
+func TestOpenAPIPathOrder(t *testing.T) {
+	for _, tt := range openapiTests {
+		fixture := path.Join(tt.path, "openapi_path_order_declaration.yaml")
+		if _, err := os.Stat(fixture); errors.Is(err, os.ErrNotExist) {
+			if !GENERATE_FIXTURES {
+				continue
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with paths in declaration order.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(tt.path, tt.protofile),
+				"--openapi_out=path_order=declaration:.").Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+			if GENERATE_FIXTURES {
+				err := CopyFixture(TEMP_FILE, fixture)
+				if err != nil {
+					t.Fatalf("Can't generate fixture: %+v", err)
+				}
+			} else {
+				// Verify that the generated spec matches our expected version.
+				err = exec.Command("diff", TEMP_FILE, fixture).Run()
+				if err != nil {
+					t.Fatalf("diff failed: %+v", err)
+				}
+			}
+			// if the test succeeded, clean up
+			os.Remove(TEMP_FILE)
+		})
+	}
+}
+
+func TestOpenAPITypeMappings(t *testing.T) {
+	dir := "examples/google/example/library/v1/"
+	fixture := path.Join(dir, "openapi_type_mappings.yaml")
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with mapped types.
+	err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "library.proto"),
+		"--openapi_out=type_mappings="+path.Join(dir, "type_mappings.yaml")+":.").Run()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if GENERATE_FIXTURES {
+		err := CopyFixture(TEMP_FILE, fixture)
+		if err != nil {
+			t.Fatalf("Can't generate fixture: %+v", err)
+		}
+	} else {
+		// Verify that the generated spec matches our expected version.
+		err = exec.Command("diff", TEMP_FILE, fixture).Run()
+		if err != nil {
+			t.Fatalf("diff failed: %+v", err)
+		}
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+}
+
+func TestOpenAPINestedNaming(t *testing.T) {
+	dir := "examples/tests/mapfields/"
+	for _, naming := range []string{"dot", "flatten"} {
+		t.Run(naming, func(t *testing.T) {
+			fixture := path.Join(dir, "openapi_nested_naming_"+naming+".yaml")
+			// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with names of nested messages.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(dir, "message.proto"),
+				"--openapi_out=nested_naming="+naming+":.").Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+			if GENERATE_FIXTURES {
+				err := CopyFixture(TEMP_FILE, fixture)
+				if err != nil {
+					t.Fatalf("Can't generate fixture: %+v", err)
+				}
+			} else {
+				// Verify that the generated spec matches our expected version.
+				err = exec.Command("diff", TEMP_FILE, fixture).Run()
+				if err != nil {
+					t.Fatalf("diff failed: %+v", err)
+				}
+			}
+			// if the test succeeded, clean up
+			os.Remove(TEMP_FILE)
+		})
+	}
+}
+
+func TestOpenAPISorting(t *testing.T) {
+	dir := "examples/google/example/library/v1/"
+	for _, sorting := range []string{"source", "none"} {
+		t.Run(sorting, func(t *testing.T) {
+			fixture := path.Join(dir, "openapi_sorting_"+sorting+".yaml")
+			// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec without alphabetical sorting.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(dir, "library.proto"),
+				"--openapi_out=sorting="+sorting+":.").Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+			if GENERATE_FIXTURES {
+				err := CopyFixture(TEMP_FILE, fixture)
+				if err != nil {
+					t.Fatalf("Can't generate fixture: %+v", err)
+				}
+			} else {
+				// Verify that the generated spec matches our expected version.
+				err = exec.Command("diff", TEMP_FILE, fixture).Run()
+				if err != nil {
+					t.Fatalf("diff failed: %+v", err)
+				}
+			}
+			// if the test succeeded, clean up
+			os.Remove(TEMP_FILE)
+		})
+	}
+}
+
+func TestOpenAPIQueryStyles(t *testing.T) {
+	dir := "examples/tests/protobuftypes/"
+	fixture := path.Join(dir, "openapi_query_styles.yaml")
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with styled query parameters.
+	err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "message.proto"),
+		"--openapi_out=query_message_style=deepObject,query_explode=false:.").Run()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if GENERATE_FIXTURES {
+		err := CopyFixture(TEMP_FILE, fixture)
+		if err != nil {
+			t.Fatalf("Can't generate fixture: %+v", err)
+		}
+	} else {
+		// Verify that the generated spec matches our expected version.
+		err = exec.Command("diff", TEMP_FILE, fixture).Run()
+		if err != nil {
+			t.Fatalf("diff failed: %+v", err)
+		}
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+}
+
+func TestOpenAPIServers(t *testing.T) {
+	dir := "examples/tests/servers/"
+	fixture := path.Join(dir, "openapi_servers.yaml")
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with servers from a file.
+	err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "message.proto"),
+		"--openapi_out=servers="+path.Join(dir, "servers.yaml")+":.").Run()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if GENERATE_FIXTURES {
+		err := CopyFixture(TEMP_FILE, fixture)
+		if err != nil {
+			t.Fatalf("Can't generate fixture: %+v", err)
+		}
+	} else {
+		// Verify that the generated spec matches our expected version.
+		err = exec.Command("diff", TEMP_FILE, fixture).Run()
+		if err != nil {
+			t.Fatalf("diff failed: %+v", err)
+		}
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+}
+
+func TestOpenAPIExamples(t *testing.T) {
+	dir := "examples/tests/examples/"
+	fixture := path.Join(dir, "openapi.yaml")
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with examples.
+	err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "message.proto"),
+		"--openapi_out=examples=true:.").Run()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if GENERATE_FIXTURES {
+		err := CopyFixture(TEMP_FILE, fixture)
+		if err != nil {
+			t.Fatalf("Can't generate fixture: %+v", err)
+		}
+	} else {
+		// Verify that the generated spec matches our expected version.
+		err = exec.Command("diff", TEMP_FILE, fixture).Run()
+		if err != nil {
+			t.Fatalf("diff failed: %+v", err)
+		}
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+}
+
+func TestOpenAPIJSONFormat(t *testing.T) {
+	for _, tt := range openapiTests {
+		fixture := path.Join(tt.path, "openapi.yaml")
+		if _, err := os.Stat(fixture); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec as JSON.
+			err := exec.Command("protoc",
+				"-I", "../../",
+				"-I", "../../third_party",
+				"-I", "examples",
+				path.Join(tt.path, tt.protofile),
+				"--openapi_out=naming=proto,format=json:.").Run()
+			if err != nil {
+				t.Fatalf("protoc failed: %+v", err)
+			}
+			// Verify that the generated spec describes the same document as the YAML fixture.
+			var result, expected interface{}
+			bytes, err := os.ReadFile("openapi.json")
+			if err != nil {
+				t.Fatalf("Can't read result: %+v", err)
+			}
+			if err = json.Unmarshal(bytes, &result); err != nil {
+				t.Fatalf("Invalid JSON: %+v", err)
+			}
+			bytes, err = os.ReadFile(fixture)
+			if err != nil {
+				t.Fatalf("Can't read fixture: %+v", err)
+			}
+			if err = yaml.Unmarshal(bytes, &expected); err != nil {
+				t.Fatalf("Invalid YAML: %+v", err)
+			}
+			if !reflect.DeepEqual(normalize(result), normalize(expected)) {
+				t.Fatalf("JSON output differs from %s", fixture)
+			}
+			// if the test succeeded, clean up
+			os.Remove("openapi.json")
+		})
+	}
+}
+
+// normalize converts decoded YAML values to the types produced by decoding JSON.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalize(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalize(value)
+		}
+	case int:
+		return float64(v)
+	}
+	return v
+}
+
+func TestOpenAPIStatistics(t *testing.T) {
+	dir := "examples/tests/responses/"
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec with statistics.
+	output, err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "message.proto"),
+		"--openapi_out=naming=proto,stats=true,max_schemas=2:.").CombinedOutput()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if !strings.Contains(string(output), "warning: openapi.yaml has 4 schemas, more than max_schemas=2") {
+		t.Errorf("Missing warning for max_schemas in output: %s", output)
+	}
+	// Verify that the statistics describe the generated spec.
+	bytes, err := os.ReadFile("openapi.stats.yaml")
+	if err != nil {
+		t.Fatalf("Can't read statistics: %+v", err)
+	}
+	var statistics map[string]int
+	if err = yaml.Unmarshal(bytes, &statistics); err != nil {
+		t.Fatalf("Invalid YAML: %+v", err)
+	}
+	info, err := os.Stat(TEMP_FILE)
+	if err != nil {
+		t.Fatalf("Can't read result: %+v", err)
+	}
+	expected := map[string]int{"paths": 1, "operations": 2, "schemas": 4, "bytes": int(info.Size())}
+	if !reflect.DeepEqual(statistics, expected) {
+		t.Errorf("Unexpected statistics %v, expected %v", statistics, expected)
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+	os.Remove("openapi.stats.yaml")
+}
+
+func TestOpenAPIv2Annotations(t *testing.T) {
+	dir := "examples/tests/openapiv2annotations/"
+	fixture := path.Join(dir, "openapi.yaml")
+	// Run protoc and the protoc-gen-openapi plugin to generate an OpenAPI spec from protoc-gen-openapiv2 options.
+	err := exec.Command("protoc",
+		"-I", "../../",
+		"-I", "../../third_party",
+		"-I", "examples",
+		path.Join(dir, "message.proto"),
+		"--openapi_out=openapiv2_annotations=true:.").Run()
+	if err != nil {
+		t.Fatalf("protoc failed: %+v", err)
+	}
+	if GENERATE_FIXTURES {
+		err := CopyFixture(TEMP_FILE, fixture)
+		if err != nil {
+			t.Fatalf("Can't generate fixture: %+v", err)
+		}
+	} else {
+		// Verify that the generated spec matches our expected version.
+		err = exec.Command("diff", TEMP_FILE, fixture).Run()
+		if err != nil {
+			t.Fatalf("diff failed: %+v", err)
+		}
+	}
+	// if the test succeeded, clean up
+	os.Remove(TEMP_FILE)
+}
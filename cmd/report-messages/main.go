@@ -18,18 +18,18 @@ package main
 import (
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"os"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/google/gnostic/compiler"
 	"github.com/google/gnostic/printer"
 
 	plugins "github.com/google/gnostic/plugins"
 )
 
 func readMessagesFromFileWithName(filename string) *plugins.Messages {
-	data, err := ioutil.ReadFile(filename)
+	data, err := compiler.ReadBytesForFile(filename)
 	if err != nil {
 		fmt.Printf("File error: %v\n", err)
 		os.Exit(1)
@@ -59,6 +59,7 @@ func main() {
 
 	if len(args) != 1 {
 		fmt.Printf("Usage: report-messages <file.pb>\n")
+		fmt.Printf("Use - as the file to read from standard input.\n")
 		return
 	}
 
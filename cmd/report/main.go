@@ -19,19 +19,19 @@ package main
 import (
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/google/gnostic/compiler"
 	"github.com/google/gnostic/printer"
 
 	pb "github.com/google/gnostic/openapiv2"
 )
 
 func readDocumentFromFileWithName(filename string) (*pb.Document, error) {
-	data, err := ioutil.ReadFile(filename)
+	data, err := compiler.ReadBytesForFile(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +227,7 @@ func main() {
 
 	if len(args) != 1 {
 		fmt.Printf("Usage: report <file.pb>\n")
+		fmt.Printf("Use - as the file to read from standard input.\n")
 		return
 	}
 
@@ -16,15 +16,20 @@ package main
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/google/gnostic/compiler"
 	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/gnostic/metrics/export"
 	vocabulary "github.com/google/gnostic/metrics/vocabulary"
 )
 
@@ -54,8 +59,9 @@ func gatherVersionNames(paths []string) []string {
 
 // readVocabularyFromFilename accepts the filename of a Vocabulary pb
 // and parses the data in the file which is then added to a Vocabulary struct.
+// The file named "-" is standard input.
 func readVocabularyFromFilename(filename string) *metrics.Vocabulary {
-	data, err := ioutil.ReadFile(filename)
+	data, err := compiler.ReadBytesForFile(filename)
 	if err != nil {
 		fmt.Printf("File %s error: %v\n", filename, err)
 		os.Exit(1)
@@ -110,6 +116,98 @@ func versionHandler(dir string) ([]*metrics.Vocabulary, []string, string) {
 
 }
 
+// incrementalUnion adds the vocabularies in the given files, or in the
+// files listed on standard input, to the union that is saved in the state
+// file. Only files that are not already in the union are read, so that
+// large unions can be updated quickly.
+func incrementalUnion(state string, rebuild bool, args []string, out string) error {
+	files := args
+	if len(files) == 0 {
+		files = openVocabularyFiles()
+	}
+	union := &metrics.VocabularyUnion{Vocabulary: &metrics.Vocabulary{}}
+	if !rebuild {
+		var err error
+		union, err = vocabulary.ReadUnion(state)
+		if err != nil {
+			return err
+		}
+	}
+	added, err := vocabulary.UpdateUnion(union, files)
+	if err != nil {
+		if !rebuild {
+			err = fmt.Errorf("%v, use -rebuild to recompute the union", err)
+		}
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Added %d of %d vocabularies to %s (%d in total).\n", added, len(files), state, len(union.Sources))
+	if added > 0 || rebuild {
+		if err = vocabulary.WriteUnion(union, state); err != nil {
+			return err
+		}
+	}
+	return writeMessage(out, union.Vocabulary, func() error {
+		return vocabulary.WritePb(union.Vocabulary)
+	})
+}
+
+// writeMessage writes a result to the file named by -out, or to standard
+// output if it is "-". Without -out, write is used to write the result to
+// its usual file.
+func writeMessage(out string, m proto.Message, write func() error) error {
+	if out == "" {
+		return write()
+	}
+	bytes, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if out == "-" {
+		_, err = os.Stdout.Write(bytes)
+		return err
+	}
+	return ioutil.WriteFile(out, bytes, 0644)
+}
+
+// exportVocabularies writes the vocabularies in the named files to standard
+// output as newline-delimited JSON or SQL rows. Each row identifies its file
+// by name and SHA-256 digest, and its time is the modification time of the
+// file, or the current time for standard input.
+func exportVocabularies(files []string, format string) error {
+	if format == "sql" {
+		fmt.Print(export.SQLSchema)
+	}
+	for _, file := range files {
+		data, err := compiler.ReadBytesForFile(file)
+		if err != nil {
+			return err
+		}
+		modTime := time.Now()
+		if file != compiler.StandardInput {
+			info, err := os.Stat(file)
+			if err != nil {
+				return err
+			}
+			modTime = info.ModTime()
+		}
+		v := &metrics.Vocabulary{}
+		if err = proto.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		sum := sha256.Sum256(data)
+		d := &export.Document{Name: file, Digest: hex.EncodeToString(sum[:]), Time: modTime}
+		if format == "sql" {
+			err = export.WriteVocabularySQL(os.Stdout, d, v)
+		} else {
+			err = export.WriteVocabularyJSON(os.Stdout, d, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func main() {
 	unionPtr := flag.Bool("union", false, "generates the union of pb files")
 	intersectionPtr := flag.Bool("intersection", false, "generates the intersection of pb files")
@@ -117,6 +215,10 @@ func main() {
 	versionPtr := flag.Bool("version", false, "generates the difference between versions of pb files")
 	exportPtr := flag.Bool("export", false, "export a given pb file as a csv file")
 	filterCommonPtr := flag.Bool("filter-common", false, "egenerates uniqueness within company")
+	statePtr := flag.String("state", "", "file that holds the accumulated union of previously added pb files")
+	rebuildPtr := flag.Bool("rebuild", false, "discards the accumulated union and rebuilds it from the given pb files")
+	formatPtr := flag.String("format", "csv", "format of -export: csv, ndjson, or sql")
+	outPtr := flag.String("out", "", "file to write the result of -union, -intersection, -difference, -filter-common, or csv -export to, or - for standard output")
 
 	flag.Parse()
 	args := flag.Args()
@@ -137,6 +239,34 @@ func main() {
 		return
 
 	}
+	if *exportPtr && *formatPtr != "csv" {
+		if *formatPtr != "ndjson" && *formatPtr != "sql" {
+			fmt.Printf("Unknown export format %q.\n", *formatPtr)
+			os.Exit(-1)
+		}
+		files := args
+		if len(files) == 0 {
+			files = openVocabularyFiles()
+		}
+		err := exportVocabularies(files, *formatPtr)
+		if err != nil {
+			fmt.Printf("Error: %+v\n", err)
+			os.Exit(-1)
+		}
+		return
+	}
+	if *statePtr != "" || *rebuildPtr {
+		if !*unionPtr || *statePtr == "" {
+			fmt.Printf("The -state option can only be used with -union, and -rebuild requires -state.\n")
+			os.Exit(-1)
+		}
+		err := incrementalUnion(*statePtr, *rebuildPtr, args, *outPtr)
+		if err != nil {
+			fmt.Printf("Error: %+v\n", err)
+			os.Exit(-1)
+		}
+		return
+	}
 	vocabularies := make([]*metrics.Vocabulary, 0)
 	switch arguments := len(args); arguments {
 	case 0:
@@ -149,22 +279,22 @@ func main() {
 
 	if *unionPtr {
 		vocab := vocabulary.Union(vocabularies)
-		err = vocabulary.WritePb(vocab)
+		err = writeMessage(*outPtr, vocab, func() error { return vocabulary.WritePb(vocab) })
 	}
 	if *intersectionPtr {
 		vocab := vocabulary.Intersection(vocabularies)
-		err = vocabulary.WritePb(vocab)
+		err = writeMessage(*outPtr, vocab, func() error { return vocabulary.WritePb(vocab) })
 	}
 	if *differencePtr {
 		vocab := vocabulary.Difference(vocabularies)
-		err = vocabulary.WritePb(vocab)
+		err = writeMessage(*outPtr, vocab, func() error { return vocabulary.WritePb(vocab) })
 	}
 	if *exportPtr {
-		err = vocabulary.WriteCSV(vocabularies[0], "")
+		err = vocabulary.WriteCSV(vocabularies[0], *outPtr)
 	}
 	if *filterCommonPtr {
 		vocab := vocabulary.FilterCommon(vocabularies)
-		err = vocabulary.WriteVocabularyList(vocab)
+		err = writeMessage(*outPtr, vocab, func() error { return vocabulary.WriteVocabularyList(vocab) })
 
 	}
 
@@ -0,0 +1,188 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Aliases can describe trees that are exponentially larger than the
+// documents that contain them ("billion laughs"), so the number of nodes
+// produced by alias expansion is limited. The limit grows with the size
+// of the document but is never smaller than the minimum.
+const (
+	aliasExpansionMinimum = 1000000
+	aliasExpansionRatio   = 10
+)
+
+// expandAliases returns a copy of a node with all aliases replaced by
+// copies of the nodes that they refer to and all merge keys ("<<")
+// applied to the mappings that contain them.
+// Nodes that contain no aliases or merge keys are returned unchanged.
+// If maxNodes is positive, larger results are rejected.
+// Expansion stops when ctx is done.
+func expandAliases(ctx context.Context, node *yaml.Node, maxNodes int) (*yaml.Node, error) {
+	count, found := countNodes(node)
+	if maxNodes > 0 && count > maxNodes {
+		return nil, fmt.Errorf("document is too large (more than %d nodes)", maxNodes)
+	}
+	if !found {
+		return node, nil
+	}
+	limit := count * aliasExpansionRatio
+	if limit < aliasExpansionMinimum {
+		limit = aliasExpansionMinimum
+	}
+	if maxNodes > 0 && limit > maxNodes {
+		limit = maxNodes
+	}
+	e := &aliasExpander{ctx: ctx, active: make(map[*yaml.Node]bool), limit: limit}
+	return e.expand(node)
+}
+
+// countNodes counts the nodes in a tree without following aliases
+// and reports whether the tree contains aliases or merge keys.
+func countNodes(node *yaml.Node) (int, bool) {
+	count := 1
+	found := node.Kind == yaml.AliasNode
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 && isMergeKey(child) {
+			found = true
+		}
+		n, f := countNodes(child)
+		count += n
+		found = found || f
+	}
+	return count, found
+}
+
+func isMergeKey(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Value == "<<" && node.ShortTag() == "!!merge"
+}
+
+// aliasCheckInterval is the number of nodes that are expanded between
+// checks of the context of an expansion.
+const aliasCheckInterval = 1 << 12
+
+type aliasExpander struct {
+	ctx context.Context
+	// active contains the anchored nodes that are currently being expanded.
+	active map[*yaml.Node]bool
+	count  int
+	limit  int
+}
+
+func (e *aliasExpander) expand(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode {
+		if node.Alias == nil {
+			return nil, fmt.Errorf("line %d: unknown anchor '%s' referenced", node.Line, node.Value)
+		}
+		if e.active[node.Alias] {
+			return nil, fmt.Errorf("line %d: alias *%s refers to a node that contains it", node.Line, node.Value)
+		}
+		return e.expand(node.Alias)
+	}
+	e.count++
+	if e.count > e.limit {
+		return nil, fmt.Errorf("document is too large after expanding aliases (more than %d nodes)", e.limit)
+	}
+	if e.count%aliasCheckInterval == 0 {
+		if err := e.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if node.Anchor != "" {
+		e.active[node] = true
+		defer delete(e.active, node)
+	}
+	result := *node
+	result.Anchor = ""
+	result.Content = nil
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			c, err := e.expand(child)
+			if err != nil {
+				return nil, err
+			}
+			result.Content = append(result.Content, c)
+		}
+		return &result, nil
+	}
+	// Explicit keys take precedence over merged ones, so they are added first.
+	merges := make([]*yaml.Node, 0)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if isMergeKey(node.Content[i]) {
+			merges = append(merges, node.Content[i+1])
+			continue
+		}
+		k, err := e.expand(node.Content[i])
+		if err != nil {
+			return nil, err
+		}
+		v, err := e.expand(node.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		result.Content = append(result.Content, k, v)
+	}
+	for _, merge := range merges {
+		m, err := e.expand(merge)
+		if err != nil {
+			return nil, err
+		}
+		switch m.Kind {
+		case yaml.MappingNode:
+			if err := mergeMapping(&result, m); err != nil {
+				return nil, err
+			}
+		case yaml.SequenceNode:
+			// Earlier mappings in a sequence take precedence over later ones.
+			for _, item := range m.Content {
+				if err := mergeMapping(&result, item); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("line %d: merge value must be a mapping or a sequence of mappings", merge.Line)
+		}
+	}
+	return &result, nil
+}
+
+// mergeMapping adds the pairs of a source mapping whose keys are not
+// already present in a destination mapping. Keys are only the same if
+// they have the same tag, so 1 and "1" are different keys.
+func mergeMapping(destination, source *yaml.Node) error {
+	if source.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: merge value must be a mapping or a sequence of mappings", source.Line)
+	}
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		key := source.Content[i]
+		present := false
+		for j := 0; j+1 < len(destination.Content); j += 2 {
+			if destination.Content[j].Value == key.Value && destination.Content[j].ShortTag() == key.ShortTag() {
+				present = true
+				break
+			}
+		}
+		if !present {
+			destination.Content = append(destination.Content, key, source.Content[i+1])
+		}
+	}
+	return nil
+}
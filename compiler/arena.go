@@ -0,0 +1,42 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync/atomic"
+)
+
+// arenaAllocation is read for every map that is compiled, so it is
+// accessed atomically instead of with a mutex.
+var arenaAllocation int32
+
+// SetArenaAllocation sets whether the generated compilers allocate the
+// name-value pairs of each map together in a single block. This replaces
+// an allocation for each pair with one for each map, which reduces garbage
+// collection when many documents are compiled, but a block is kept in
+// memory for as long as any of its pairs is used.
+func SetArenaAllocation(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&arenaAllocation, value)
+}
+
+// GetArenaAllocation returns true if the generated compilers allocate
+// name-value pairs in blocks.
+func GetArenaAllocation() bool {
+	return atomic.LoadInt32(&arenaAllocation) == 1
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FetchAuthentication adds credentials to the requests that fetch remote
+// files, so that descriptions can be read from private registries and
+// repositories. Credentials are keyed by host, and hosts that begin with
+// "*." match any subdomain. When several patterns match a host, the most
+// specific one is used: a host name before any wildcard, and a longer
+// wildcard before a shorter one. Credentials are added to each https
+// request for a matching host, including requests that follow redirects,
+// and are never sent over plain http or to other hosts.
+type FetchAuthentication struct {
+	// Headers are added to the requests for each host.
+	Headers map[string]http.Header
+	// BearerTokens are sent in the Authorization headers of the requests
+	// for each host, as with GitHub personal access tokens.
+	BearerTokens map[string]string
+	// Netrc sends the login names and passwords in a .netrc file with
+	// basic authentication to hosts that have no other Authorization header.
+	Netrc bool
+	// NetrcFile is the .netrc file that is read when Netrc is set. When it
+	// is empty, the file named by $NETRC or ~/.netrc is read if it exists.
+	NetrcFile string
+	// NetrcDefault sends the credentials of the default entry of the .netrc
+	// file to hosts that have no machine entry. Because references can name
+	// any host, the default entry is otherwise ignored.
+	NetrcDefault bool
+}
+
+var fetchAuthentication FetchAuthentication
+var fetchAuthenticationMutex sync.Mutex
+
+// SetFetchAuthentication sets the credentials used to fetch remote files.
+func SetFetchAuthentication(a FetchAuthentication) {
+	fetchAuthenticationMutex.Lock()
+	defer fetchAuthenticationMutex.Unlock()
+	fetchAuthentication = a
+}
+
+// GetFetchAuthentication returns the credentials used to fetch remote files.
+func GetFetchAuthentication() FetchAuthentication {
+	fetchAuthenticationMutex.Lock()
+	defer fetchAuthenticationMutex.Unlock()
+	return fetchAuthentication
+}
+
+// isSet returns true if any credentials are configured.
+func (a FetchAuthentication) isSet() bool {
+	return len(a.Headers) > 0 || len(a.BearerTokens) > 0 || a.Netrc
+}
+
+// transport returns a transport that adds credentials to the requests
+// sent by another transport.
+func (a FetchAuthentication) transport(base http.RoundTripper) (http.RoundTripper, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &authenticatingTransport{base: base, auth: a}
+	if a.Netrc {
+		var err error
+		if t.netrc, err = readNetrc(a.NetrcFile); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+type authenticatingTransport struct {
+	base  http.RoundTripper
+	auth  FetchAuthentication
+	netrc []*netrcMachine
+}
+
+func (t *authenticatingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Credentials would be readable by anyone on the network if they were
+	// sent over plain http, as they would be after a redirect to http.
+	if !strings.EqualFold(request.URL.Scheme, "https") {
+		return t.base.RoundTrip(request)
+	}
+	host := request.URL.Hostname()
+	header := make(http.Header)
+	if pattern, ok := mostSpecificHostPattern(headerPatterns(t.auth.Headers), host); ok {
+		for name, value := range t.auth.Headers[pattern] {
+			header[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+	if pattern, ok := mostSpecificHostPattern(tokenPatterns(t.auth.BearerTokens), host); ok {
+		header.Set("Authorization", "Bearer "+t.auth.BearerTokens[pattern])
+	}
+	if header.Get("Authorization") == "" && request.Header.Get("Authorization") == "" {
+		if m := findNetrcMachine(t.netrc, host, t.auth.NetrcDefault); m != nil {
+			header.Set("Authorization", basicAuthorization(m.login, m.password))
+		}
+	}
+	if len(header) == 0 {
+		return t.base.RoundTrip(request)
+	}
+	// A RoundTripper must not modify its request.
+	r := *request
+	r.Header = make(http.Header, len(request.Header)+len(header))
+	for name, value := range request.Header {
+		r.Header[name] = value
+	}
+	for name, value := range header {
+		r.Header[name] = value
+	}
+	return t.base.RoundTrip(&r)
+}
+
+func headerPatterns(headers map[string]http.Header) []string {
+	patterns := make([]string, 0, len(headers))
+	for pattern := range headers {
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+func tokenPatterns(tokens map[string]string) []string {
+	patterns := make([]string, 0, len(tokens))
+	for pattern := range tokens {
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// mostSpecificHostPattern returns the most specific of the patterns that
+// match a host. A host name is more specific than any wildcard and a longer
+// wildcard is more specific than a shorter one. Patterns that are equally
+// specific are ordered by their text, so that the result doesn't depend on
+// the order of the patterns.
+func mostSpecificHostPattern(patterns []string, host string) (string, bool) {
+	best, found := "", false
+	for _, pattern := range patterns {
+		if !matchHost(pattern, host) {
+			continue
+		}
+		if !found || moreSpecificHostPattern(pattern, best) {
+			best, found = pattern, true
+		}
+	}
+	return best, found
+}
+
+func moreSpecificHostPattern(a, b string) bool {
+	aWildcard, bWildcard := strings.HasPrefix(a, "*."), strings.HasPrefix(b, "*.")
+	if aWildcard != bWildcard {
+		return bWildcard
+	}
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
+
+func basicAuthorization(login, password string) string {
+	r := &http.Request{Header: make(http.Header)}
+	r.SetBasicAuth(login, password)
+	return r.Header.Get("Authorization")
+}
+
+// A netrcMachine contains the credentials for a host in a .netrc file.
+// The default entry has an empty name.
+type netrcMachine struct {
+	name     string
+	login    string
+	password string
+}
+
+// readNetrc reads the credentials in a .netrc file.
+func readNetrc(filename string) ([]*netrcMachine, error) {
+	if filename == "" {
+		filename = os.Getenv("NETRC")
+		if filename == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, nil
+			}
+			filename = filepath.Join(home, ".netrc")
+		}
+		if _, err := os.Stat(filename); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseNetrc(string(bytes)), nil
+}
+
+// parseNetrc parses the machine and default entries of a .netrc file.
+// Macro definitions are skipped.
+func parseNetrc(text string) []*netrcMachine {
+	machines := make([]*netrcMachine, 0)
+	var m *netrcMachine
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		for j := 0; j < len(fields); j++ {
+			next := ""
+			if j+1 < len(fields) {
+				next = fields[j+1]
+			}
+			switch fields[j] {
+			case "machine":
+				m = &netrcMachine{name: next}
+				machines = append(machines, m)
+				j++
+			case "default":
+				m = &netrcMachine{}
+				machines = append(machines, m)
+			case "login":
+				if m != nil {
+					m.login = next
+				}
+				j++
+			case "password":
+				if m != nil {
+					m.password = next
+				}
+				j++
+			case "account":
+				j++
+			case "macdef":
+				// A macro continues until an empty line.
+				m = nil
+				j = len(fields)
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+			}
+		}
+	}
+	return machines
+}
+
+// findNetrcMachine returns the entry for a host, or the default entry if
+// useDefault is set.
+func findNetrcMachine(machines []*netrcMachine, host string, useDefault bool) *netrcMachine {
+	var defaultMachine *netrcMachine
+	for _, m := range machines {
+		if m.name == "" {
+			if useDefault && defaultMachine == nil {
+				defaultMachine = m
+			}
+		} else if strings.EqualFold(m.name, host) {
+			return m
+		}
+	}
+	return defaultMachine
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useTransport sets the transport that fetches remote files and returns
+// a function that restores the previous one.
+func useTransport(transport http.RoundTripper) func() {
+	saved := http.DefaultTransport
+	http.DefaultTransport = transport
+	return func() { http.DefaultTransport = saved }
+}
+
+func TestFetchAuthentication(t *testing.T) {
+	defer ClearCaches()
+	defer SetFetchAuthentication(FetchAuthentication{})
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.Host)
+		fmt.Fprintf(w, "%s|%s|%s", host, r.Header.Get("Authorization"), r.Header.Get("X-Api-Key"))
+	}
+	plain := httptest.NewServer(http.HandlerFunc(echo))
+	defer plain.Close()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			_, port, _ := net.SplitHostPort(r.Host)
+			http.Redirect(w, r, "https://localhost:"+port+"/a.yaml", http.StatusFound)
+		case "/redirect-http":
+			http.Redirect(w, r, plain.URL+"/a.yaml", http.StatusFound)
+		default:
+			echo(w, r)
+		}
+	}))
+	defer server.Close()
+	// The certificate of the test server doesn't name localhost.
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.InsecureSkipVerify = true
+	defer useTransport(transport)()
+
+	dir, err := ioutil.TempDir("", "netrc")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dir)
+	netrc := filepath.Join(dir, "netrc")
+	err = ioutil.WriteFile(netrc, []byte("machine 127.0.0.1 login user password secret\n"), 0600)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	netrcDefault := filepath.Join(dir, "netrc-default")
+	err = ioutil.WriteFile(netrcDefault, []byte("default login anonymous password guest\n"), 0600)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, test := range []struct {
+		auth   FetchAuthentication
+		url    string
+		result string
+	}{
+		{FetchAuthentication{}, server.URL + "/a.yaml", "127.0.0.1||"},
+		{
+			FetchAuthentication{Headers: map[string]http.Header{"127.0.0.1": {"x-api-key": {"key"}}}},
+			server.URL + "/a.yaml",
+			"127.0.0.1||key",
+		},
+		{
+			FetchAuthentication{BearerTokens: map[string]string{"127.0.0.1": "token"}},
+			server.URL + "/a.yaml",
+			"127.0.0.1|Bearer token|",
+		},
+		{
+			FetchAuthentication{BearerTokens: map[string]string{"*.example.com": "token"}},
+			server.URL + "/a.yaml",
+			"127.0.0.1||",
+		},
+		{
+			FetchAuthentication{Netrc: true, NetrcFile: netrc},
+			server.URL + "/a.yaml",
+			"127.0.0.1|Basic dXNlcjpzZWNyZXQ=|",
+		},
+		{
+			FetchAuthentication{Netrc: true, NetrcFile: netrc, BearerTokens: map[string]string{"127.0.0.1": "token"}},
+			server.URL + "/a.yaml",
+			"127.0.0.1|Bearer token|",
+		},
+		{
+			// The default entry is only used when it is asked for.
+			FetchAuthentication{Netrc: true, NetrcFile: netrcDefault},
+			server.URL + "/a.yaml",
+			"127.0.0.1||",
+		},
+		{
+			FetchAuthentication{Netrc: true, NetrcFile: netrcDefault, NetrcDefault: true},
+			server.URL + "/a.yaml",
+			"127.0.0.1|Basic YW5vbnltb3VzOmd1ZXN0|",
+		},
+		{
+			// Credentials are not sent to the host of a redirect.
+			FetchAuthentication{BearerTokens: map[string]string{"127.0.0.1": "token"}},
+			server.URL + "/redirect",
+			"localhost||",
+		},
+		{
+			// Credentials are not sent over plain http.
+			FetchAuthentication{
+				Headers:      map[string]http.Header{"127.0.0.1": {"x-api-key": {"key"}}},
+				BearerTokens: map[string]string{"127.0.0.1": "token"},
+			},
+			plain.URL + "/a.yaml",
+			"127.0.0.1||",
+		},
+		{
+			FetchAuthentication{Netrc: true, NetrcFile: netrc},
+			plain.URL + "/a.yaml",
+			"127.0.0.1||",
+		},
+		{
+			// Credentials are not sent after a redirect from https to http.
+			FetchAuthentication{
+				Headers:      map[string]http.Header{"127.0.0.1": {"x-api-key": {"key"}}},
+				BearerTokens: map[string]string{"127.0.0.1": "token"},
+			},
+			server.URL + "/redirect-http",
+			"127.0.0.1||",
+		},
+	} {
+		ClearCaches()
+		SetFetchAuthentication(test.auth)
+		bytes, err := FetchFile(test.url)
+		if err != nil {
+			t.Errorf("%+v %s: %s", test.auth, test.url, err)
+		} else if string(bytes) != test.result {
+			t.Errorf("%+v %s: got %q, want %q", test.auth, test.url, string(bytes), test.result)
+		}
+	}
+	SetFetchAuthentication(FetchAuthentication{Netrc: true, NetrcFile: filepath.Join(dir, "missing")})
+	if _, err := FetchFile(server.URL + "/b.yaml"); err == nil {
+		t.Errorf("expected an error for a missing netrc file")
+	}
+}
+
+func TestMostSpecificHostPattern(t *testing.T) {
+	patterns := []string{"*.com", "*.example.com", "api.example.com", "*.api.example.com", "*.EXAMPLE.com"}
+	for _, test := range []struct {
+		host    string
+		pattern string
+	}{
+		{"api.example.com", "api.example.com"},
+		{"v1.api.example.com", "*.api.example.com"},
+		{"raw.example.com", "*.EXAMPLE.com"},
+		{"example.org", ""},
+		{"other.com", "*.com"},
+	} {
+		// The result doesn't depend on the order of the patterns.
+		for i := range patterns {
+			rotated := append(append([]string{}, patterns[i:]...), patterns[:i]...)
+			pattern, _ := mostSpecificHostPattern(rotated, test.host)
+			if pattern != test.pattern {
+				t.Errorf("%s %v: got %q, want %q", test.host, rotated, pattern, test.pattern)
+			}
+		}
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	machines := parseNetrc(`
+machine api.example.com
+  login alice
+  password one
+macdef init
+  machine evil.example.com login mallory password two
+
+machine raw.example.com login bob account x password three
+default login anonymous password guest
+`)
+	for _, test := range []struct {
+		host     string
+		login    string
+		password string
+	}{
+		{"api.example.com", "alice", "one"},
+		{"API.example.com", "alice", "one"},
+		{"raw.example.com", "bob", "three"},
+		{"evil.example.com", "anonymous", "guest"},
+		{"other.com", "anonymous", "guest"},
+	} {
+		m := findNetrcMachine(machines, test.host, true)
+		if m == nil || m.login != test.login || m.password != test.password {
+			t.Errorf("%s: got %+v, want %s:%s", test.host, m, test.login, test.password)
+		}
+	}
+	if m := findNetrcMachine(machines, "other.com", false); m != nil {
+		t.Errorf("expected the default entry to be ignored, got %+v", m)
+	}
+	if m := findNetrcMachine(parseNetrc("machine a login b password c\n"), "d", true); m != nil {
+		t.Errorf("expected no credentials for an unknown host, got %+v", m)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// readDocuments reads all of the documents in a YAML stream.
+// Empty documents, such as the ones produced by a trailing "---",
+// are skipped.
+func readDocuments(b []byte) ([]*yaml.Node, error) {
+	documents := make([]*yaml.Node, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if err == io.EOF {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyDocument(&node) {
+			continue
+		}
+		documents = append(documents, &node)
+	}
+}
+
+func isEmptyDocument(node *yaml.Node) bool {
+	if len(node.Content) != 1 {
+		return len(node.Content) == 0
+	}
+	content := node.Content[0]
+	return content.Kind == yaml.ScalarNode && content.ShortTag() == "!!null" && content.Value == ""
+}
+
+// multipleDocumentsError describes a YAML stream that contains more than one document.
+func multipleDocumentsError(filename string, documents []*yaml.Node) error {
+	lines := make([]string, len(documents))
+	for i, document := range documents {
+		lines[i] = fmt.Sprintf("%d", document.Line)
+	}
+	return fmt.Errorf("%s contains %d YAML documents (starting at lines %s), but only one document can be compiled from each file",
+		describeFile(filename), len(documents), strings.Join(lines, ", "))
+}
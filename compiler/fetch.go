@@ -0,0 +1,281 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// DefaultFetchConcurrency is the default number of files that can be
+// downloaded at the same time.
+const DefaultFetchConcurrency = 8
+
+// fetchSemaphore limits the number of concurrent downloads.
+// It is guarded by fileCacheMutex.
+var fetchSemaphore = make(chan struct{}, DefaultFetchConcurrency)
+
+// fetchCalls contains the downloads that are in progress, so that
+// concurrent requests for the same file share a single download.
+// It is guarded by fileCacheMutex.
+var fetchCalls = make(map[string]*fetchCall)
+
+type fetchCall struct {
+	done  chan struct{}
+	bytes []byte
+	err   error
+}
+
+// cachedFile is a downloaded file and the key of the policy that allowed
+// it, since a file that one policy allows might not be allowed by another.
+type cachedFile struct {
+	bytes  []byte
+	policy string
+}
+
+// SetFetchConcurrency sets the number of files that can be downloaded
+// at the same time. Values less than one are treated as one.
+func SetFetchConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	fetchSemaphore = make(chan struct{}, n)
+}
+
+// FetchFile gets a specified file from the local filesystem or a remote location.
+// It is safe to call FetchFile from multiple goroutines; concurrent requests
+// for the same file are combined into a single download.
+func FetchFile(fileurl string) ([]byte, error) {
+	return fetchFile(context.Background(), fileurl)
+}
+
+// FetchFileContext gets a file with the limits and fetch policy of a
+// context and stops waiting for it when the context is done.
+func FetchFileContext(ctx context.Context, fileurl string) ([]byte, error) {
+	return fetchFile(ctx, fileurl)
+}
+
+func fetchFile(ctx context.Context, fileurl string) ([]byte, error) {
+	policy := fetchPolicyFor(ctx)
+	if policy.isSet() {
+		u, err := url.Parse(fileurl)
+		if err != nil {
+			return nil, err
+		}
+		if err = policy.checkURL(u); err != nil {
+			return nil, err
+		}
+	}
+	// Concurrent downloads are only shared by requests with the same policy.
+	callKey := policy.key() + " " + fileurl
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fileCacheMutex.Lock()
+		initializeFileCache()
+		if fileCacheEnable {
+			cached, ok := fileCache[fileurl]
+			if ok && cached.policy == policy.key() {
+				fileCacheMutex.Unlock()
+				if verboseReader {
+					log.Printf("Cache hit %s", fileurl)
+				}
+				return cached.bytes, nil
+			}
+		}
+		if call, ok := fetchCalls[callKey]; ok {
+			fileCacheMutex.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			// A download that was stopped by the context of another
+			// request is tried again.
+			if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+				continue
+			}
+			return call.bytes, call.err
+		}
+		call := &fetchCall{done: make(chan struct{})}
+		fetchCalls[callKey] = call
+		semaphore := fetchSemaphore
+		fileCacheMutex.Unlock()
+
+		if verboseReader {
+			log.Printf("Fetching %s", fileurl)
+		}
+		select {
+		case semaphore <- struct{}{}:
+			call.bytes, call.err = download(ctx, fileurl, policy)
+			<-semaphore
+		case <-ctx.Done():
+			call.err = ctx.Err()
+		}
+
+		fileCacheMutex.Lock()
+		delete(fetchCalls, callKey)
+		if fileCacheEnable && call.err == nil {
+			fileCache[fileurl] = cachedFile{bytes: call.bytes, policy: policy.key()}
+		}
+		fileCacheMutex.Unlock()
+		close(call.done)
+		return call.bytes, call.err
+	}
+}
+
+func download(ctx context.Context, fileurl string, policy FetchPolicy) ([]byte, error) {
+	l := limitsFor(ctx)
+	if policy.MaxResponseBytes > 0 && (l.MaxDocumentBytes == 0 || policy.MaxResponseBytes < l.MaxDocumentBytes) {
+		l.MaxDocumentBytes = policy.MaxResponseBytes
+	}
+	client := policy.client(l.Timeout)
+	if auth := GetFetchAuthentication(); auth.isSet() {
+		transport, err := auth.transport(client.Transport)
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = transport
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fileurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("Error downloading %s: %s", fileurl, response.Status)
+	}
+	if err = checkDocumentBytes(fileurl, response.ContentLength, l); err != nil {
+		return nil, err
+	}
+	body := io.Reader(response.Body)
+	if l.MaxDocumentBytes > 0 {
+		// Read one byte more than the limit to detect larger files.
+		body = io.LimitReader(body, l.MaxDocumentBytes+1)
+	}
+	bytes, err := ioutil.ReadAll(body)
+	if err == nil {
+		err = checkDocumentBytes(fileurl, int64(len(bytes)), l)
+	}
+	return bytes, err
+}
+
+// PrefetchReferences reads the targets of the references in a document
+// and of the references that those targets contain, downloading remote
+// files concurrently. The targets are added to the cache used by the
+// ResolveReferences methods of the generated models, so that resolving
+// the references of a document doesn't download its remote files one at
+// a time and relative references in other files are resolved from the
+// files that contain them. Errors reading targets are collected and
+// returned in an ErrorGroup after all of the targets have been read.
+func PrefetchReferences(filename string) error {
+	return PrefetchReferencesContext(context.Background(), filename)
+}
+
+// PrefetchReferencesContext reads the targets of the references in a
+// document with the limits and fetch policy of a context, and stops when
+// it is done.
+func PrefetchReferencesContext(ctx context.Context, filename string) error {
+	bytes, err := readBytesForFile(ctx, filename)
+	if err != nil {
+		return err
+	}
+	infoCacheMutex.Lock()
+	info, err := readInfoFromBytes(ctx, filename, bytes)
+	infoCacheMutex.Unlock()
+	if err != nil {
+		return err
+	}
+	c := &referenceCollector{seen: make(map[string]bool)}
+	refs := c.collect(info, nil)
+	var errs []error
+	for len(refs) > 0 {
+		var wg sync.WaitGroup
+		for _, file := range remoteFiles(filename, refs) {
+			wg.Add(1)
+			go func(file string) {
+				defer wg.Done()
+				fetchFile(ctx, file)
+			}(file)
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next := make([]string, 0)
+		infoCacheMutex.Lock()
+		for _, ref := range refs {
+			target, err := readInfoForRef(ctx, filename, ref)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if target == nil {
+				continue
+			}
+			next = c.collect(target, next)
+		}
+		infoCacheMutex.Unlock()
+		refs = next
+	}
+	return NewErrorGroupOrNil(errs)
+}
+
+// referenceCollector finds the distinct references in a set of nodes.
+type referenceCollector struct {
+	seen map[string]bool
+}
+
+func (c *referenceCollector) collect(node *yaml.Node, refs []string) []string {
+	if ref := referenceValue(node); ref != nil && !c.seen[ref.Value] {
+		c.seen[ref.Value] = true
+		refs = append(refs, ref.Value)
+	}
+	for _, child := range node.Content {
+		refs = c.collect(child, refs)
+	}
+	return refs
+}
+
+// remoteFiles returns the distinct remote files that contain the targets of references.
+func remoteFiles(basefile string, refs []string) []string {
+	files := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		file := FilenameForRef(basefile, ref)
+		if isRemote(file) && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	models "github.com/google/gnostic-models/compiler"
+)
+
+func TestFetchFileDeduplicatesConcurrentRequests(t *testing.T) {
+	defer ClearCaches()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, "path: %s\n", r.URL.Path)
+	}))
+	defer server.Close()
+	for _, cache := range []bool{true, false} {
+		ClearCaches()
+		if !cache {
+			DisableFileCache()
+		}
+		atomic.StoreInt32(&requests, 0)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				bytes, err := FetchFile(server.URL + "/a.yaml")
+				if err != nil {
+					t.Errorf("%s", err)
+				} else if string(bytes) != "path: /a.yaml\n" {
+					t.Errorf("unexpected result: %q", string(bytes))
+				}
+			}()
+		}
+		wg.Wait()
+		if n := atomic.LoadInt32(&requests); n != 1 {
+			t.Errorf("expected one request, got %d (file cache enabled: %t)", n, cache)
+		}
+		EnableFileCache()
+	}
+}
+
+func TestPrefetchReferences(t *testing.T) {
+	defer ClearCaches()
+	defer SetFetchConcurrency(DefaultFetchConcurrency)
+	var active, maximum, requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			m := atomic.LoadInt32(&maximum)
+			if n <= m || atomic.CompareAndSwapInt32(&maximum, m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, "schema: {type: string, description: %s}\n", r.URL.Path)
+	}))
+	defer server.Close()
+	text := "components:\n"
+	for i := 0; i < 6; i++ {
+		text += fmt.Sprintf("  s%d: {$ref: '%s/%d.yaml#/schema'}\n", i, server.URL, i)
+		text += fmt.Sprintf("  t%d: {$ref: '%s/%d.yaml'}\n", i, server.URL, i)
+	}
+	filename := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(filename, []byte(text), 0644); err != nil {
+		t.Fatalf("%s", err)
+	}
+	ClearCaches()
+	SetFetchConcurrency(2)
+	if err := PrefetchReferences(filename); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 6 {
+		t.Errorf("expected 6 requests, got %d", n)
+	}
+	if n := atomic.LoadInt32(&maximum); n != 2 {
+		t.Errorf("expected 2 concurrent requests, got %d", n)
+	}
+	// The generated models read the prefetched targets from the cache.
+	info, err := models.ReadInfoForRef(filename, server.URL+"/3.yaml#/schema")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(info.Content) != 4 || info.Content[3].Value != "/3.yaml" {
+		t.Errorf("unexpected target: %+v", info)
+	}
+	if n := atomic.LoadInt32(&requests); n != 6 {
+		t.Errorf("expected no more requests, got %d", n)
+	}
+}
+
+func TestPrefetchReferencesErrors(t *testing.T) {
+	defer ClearCaches()
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	text := fmt.Sprintf(`components:
+  a: {$ref: '%s/missing.yaml#/schema'}
+  b: {$ref: 'missing.yaml#/schema'}
+  c: {$ref: 'present.yaml#/schema'}
+`, server.URL)
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(filename, []byte(text), 0644); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "present.yaml"), []byte("schema: {type: string}\n"), 0644); err != nil {
+		t.Fatalf("%s", err)
+	}
+	ClearCaches()
+	err := PrefetchReferences(filename)
+	group, ok := err.(*ErrorGroup)
+	if !ok {
+		t.Fatalf("expected an ErrorGroup, got %v", err)
+	}
+	if len(group.Errors) != 2 {
+		t.Errorf("expected 2 errors, got %v", group.Errors)
+	}
+	for _, s := range []string{"404", "missing.yaml"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected error to mention %q, got %s", s, err)
+		}
+	}
+	// Targets that could be read are still added to the cache.
+	if _, ok := models.GetInfoCache()["present.yaml#/schema"]; !ok {
+		t.Errorf("expected present.yaml#/schema to be cached")
+	}
+}
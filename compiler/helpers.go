@@ -16,12 +16,38 @@ package compiler
 
 import (
 	"github.com/google/gnostic-models/compiler"
+	yaml "gopkg.in/yaml.v3"
 )
 
 // compiler helper functions, usually called from generated code
 
-// UnpackMap gets a *yaml.Node if possible.
-var UnpackMap = compiler.UnpackMap
+// UnpackMap gets a *yaml.Node if it is a MappingNode or a null value,
+// which is read as an empty map. Other nodes are rejected because the
+// generated code reads their contents as pairs of keys and values.
+func UnpackMap(in *yaml.Node) (*yaml.Node, bool) {
+	if in == nil {
+		return nil, false
+	}
+	if in.Kind != yaml.MappingNode && !(in.Kind == yaml.ScalarNode && in.Tag == "!!null") {
+		return nil, false
+	}
+	return in, true
+}
+
+// CopyNode returns a deep copy of a *yaml.Node.
+func CopyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	c := *node
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			c.Content[i] = CopyNode(child)
+		}
+	}
+	return &c
+}
 
 // SortedKeysForMap returns the sorted keys of a yamlv2.MapSlice.
 var SortedKeysForMap = compiler.SortedKeysForMap
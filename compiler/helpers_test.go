@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestUnpackMap(t *testing.T) {
+	for _, test := range []struct {
+		text string
+		ok   bool
+	}{
+		{"{a: 1}", true},
+		{"{}", true},
+		{"null", true},
+		{"~", true},
+		{"[a, 1]", false},
+		{"[a]", false},
+		{"a", false},
+		{"1", false},
+		{"''", false},
+	} {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(test.text), &node); err != nil {
+			t.Fatalf("%s", err)
+		}
+		if _, ok := UnpackMap(node.Content[0]); ok != test.ok {
+			t.Errorf("UnpackMap(%s) returned %t, expected %t", test.text, ok, test.ok)
+		}
+	}
+	if _, ok := UnpackMap(nil); ok {
+		t.Errorf("UnpackMap(nil) returned true")
+	}
+}
+
+func TestCopyNode(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("{a: [1, {b: 2}]}"), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	c := CopyNode(&node)
+	c.Content[0].Content[1].Content[1].Content[1].Value = "3"
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if string(out) != "{a: [1, {b: 2}]}\n" {
+		t.Errorf("CopyNode shares content with its original: %s", out)
+	}
+	if CopyNode(nil) != nil {
+		t.Errorf("CopyNode(nil) returned a node")
+	}
+}
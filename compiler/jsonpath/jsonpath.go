@@ -0,0 +1,434 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpath selects nodes in trees of YAML nodes with JSONPath
+// expressions. It is used to find the targets of overlays and to query
+// compiled documents.
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Path is a parsed JSONPath expression. The supported subset includes
+// the root ($), child names (.name and ['name']), array indices ([0] and
+// [-1]), wildcards (.* and [*]), unions (['a','b']), recursive descent
+// (..name), and filters that test a property of the current node
+// ([?(@.name == 'value')], [?(@.name != 1)], and [?(@.name)]).
+type Path struct {
+	selectors []selector
+}
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectIndex
+	selectWildcard
+	selectFilter
+)
+
+type selector struct {
+	kind selectorKind
+	// names and indices selected by name and index selectors (and unions of them)
+	names   []string
+	indices []int
+	// descendant is true if the selector applies to all descendants
+	descendant bool
+	filter     *filter
+}
+
+// filter tests a property of a node, given as a list of names below "@".
+// If op is empty, the filter tests that the property exists.
+type filter struct {
+	property []string
+	op       string
+	value    string
+}
+
+// Match is a node selected by a path. The parent and index locate the
+// node in the parent's content so that it can be replaced or removed.
+type Match struct {
+	Node   *yaml.Node
+	Parent *yaml.Node
+	Index  int
+	// Tokens are the reference tokens of a JSON pointer to the node from
+	// the root of the search.
+	Tokens []string
+}
+
+// Pointer returns a JSON pointer to a matched node.
+func (m *Match) Pointer() string {
+	return jsonpointer.Format(m.Tokens...)
+}
+
+// Parse parses a JSONPath expression.
+func Parse(s string) (*Path, error) {
+	p := &parser{s: s}
+	if !p.consume("$") {
+		return nil, errors.New("path must begin with $")
+	}
+	result := &Path{}
+	for !p.done() {
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		result.selectors = append(result.selectors, sel)
+	}
+	return result, nil
+}
+
+// Pointer returns a JSON pointer to the node that a path selects, if the
+// path can only select one node. Paths that contain wildcards, unions,
+// filters, recursive descent, or negative indices don't have pointers.
+func (pth *Path) Pointer() (string, bool) {
+	tokens := make([]string, 0, len(pth.selectors))
+	for _, sel := range pth.selectors {
+		if sel.descendant {
+			return "", false
+		}
+		switch {
+		case sel.kind == selectName && len(sel.names) == 1:
+			tokens = append(tokens, sel.names[0])
+		case sel.kind == selectIndex && len(sel.indices) == 1 && sel.indices[0] >= 0:
+			tokens = append(tokens, strconv.Itoa(sel.indices[0]))
+		default:
+			return "", false
+		}
+	}
+	return jsonpointer.Format(tokens...), true
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) selector() (selector, error) {
+	switch {
+	case p.consume(".."):
+		var sel selector
+		var err error
+		if strings.HasPrefix(p.s[p.pos:], "[") {
+			p.consume("[")
+			sel, err = p.bracket()
+		} else {
+			sel, err = p.dotted()
+		}
+		sel.descendant = true
+		return sel, err
+	case p.consume("."):
+		return p.dotted()
+	case p.consume("["):
+		return p.bracket()
+	default:
+		return selector{}, p.errorf("unexpected %q", p.s[p.pos:p.pos+1])
+	}
+}
+
+// dotted parses the selector that follows a "." or "..".
+func (p *parser) dotted() (selector, error) {
+	if p.consume("*") {
+		return selector{kind: selectWildcard}, nil
+	}
+	name := p.name()
+	if name == "" {
+		return selector{}, p.errorf("missing name")
+	}
+	return selector{kind: selectName, names: []string{name}}, nil
+}
+
+func (p *parser) name() string {
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(".[]()=!<> ", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// bracket parses the selector that follows a "[".
+func (p *parser) bracket() (selector, error) {
+	p.skipSpaces()
+	var sel selector
+	switch {
+	case p.consume("*"):
+		sel = selector{kind: selectWildcard}
+	case p.consume("?"):
+		f, err := p.filter()
+		if err != nil {
+			return selector{}, err
+		}
+		sel = selector{kind: selectFilter, filter: f}
+	default:
+		for {
+			p.skipSpaces()
+			if !p.done() && (p.s[p.pos] == '\'' || p.s[p.pos] == '"') {
+				name, err := p.quoted()
+				if err != nil {
+					return selector{}, err
+				}
+				sel.names = append(sel.names, name)
+			} else {
+				index, err := p.integer()
+				if err != nil {
+					return selector{}, err
+				}
+				sel.indices = append(sel.indices, index)
+			}
+			p.skipSpaces()
+			if !p.consume(",") {
+				break
+			}
+		}
+		if len(sel.names) > 0 && len(sel.indices) > 0 {
+			return selector{}, p.errorf("names and indices can't be combined")
+		}
+		if len(sel.indices) > 0 {
+			sel.kind = selectIndex
+		}
+	}
+	p.skipSpaces()
+	if !p.consume("]") {
+		return selector{}, p.errorf("missing ]")
+	}
+	return sel, nil
+}
+
+func (p *parser) quoted() (string, error) {
+	quote := p.s[p.pos]
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) integer() (int, error) {
+	start := p.pos
+	p.consume("-")
+	for !p.done() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	i, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return 0, p.errorf("expected a name or an index")
+	}
+	return i, nil
+}
+
+func (p *parser) filter() (*filter, error) {
+	p.skipSpaces()
+	parenthesized := p.consume("(")
+	p.skipSpaces()
+	if !p.consume("@") {
+		return nil, p.errorf("filters must begin with @")
+	}
+	f := &filter{}
+	for p.consume(".") {
+		name := p.name()
+		if name == "" {
+			return nil, p.errorf("missing name")
+		}
+		f.property = append(f.property, name)
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!="} {
+		if p.consume(op) {
+			f.op = op
+		}
+	}
+	if f.op != "" {
+		p.skipSpaces()
+		if !p.done() && (p.s[p.pos] == '\'' || p.s[p.pos] == '"') {
+			value, err := p.quoted()
+			if err != nil {
+				return nil, err
+			}
+			f.value = value
+		} else {
+			start := p.pos
+			for !p.done() && !strings.ContainsRune(") ]", rune(p.s[p.pos])) {
+				p.pos++
+			}
+			f.value = p.s[start:p.pos]
+		}
+	}
+	p.skipSpaces()
+	if parenthesized && !p.consume(")") {
+		return nil, p.errorf("missing )")
+	}
+	return f, nil
+}
+
+// Find returns the nodes selected by a path in the order that its selectors
+// select them: names and indices in the order that they are listed, so
+// $['b','a'] selects b before a, and wildcards, filters and descendants in
+// the order of the document. Nodes are selected at most once.
+func (pth *Path) Find(root *yaml.Node) []*Match {
+	matches := []*Match{{Node: root}}
+	for _, sel := range pth.selectors {
+		var next []*Match
+		seen := make(map[*yaml.Node]bool)
+		for _, m := range matches {
+			candidates := []*Match{m}
+			if sel.descendant {
+				candidates = descendants(m)
+			}
+			for _, c := range candidates {
+				for _, selected := range sel.apply(c) {
+					if !seen[selected.Node] {
+						seen[selected.Node] = true
+						next = append(next, selected)
+					}
+				}
+			}
+		}
+		matches = next
+	}
+	return matches
+}
+
+// descendants returns a match and all of the matches below it.
+func descendants(m *Match) []*Match {
+	result := []*Match{m}
+	for _, child := range children(m) {
+		result = append(result, descendants(child)...)
+	}
+	return result
+}
+
+// children returns the values of a mapping or the items of a sequence.
+func children(m *Match) []*Match {
+	var result []*Match
+	switch m.Node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(m.Node.Content); i += 2 {
+			result = append(result, m.child(i, m.Node.Content[i-1].Value))
+		}
+	case yaml.SequenceNode:
+		for i := range m.Node.Content {
+			result = append(result, m.child(i, strconv.Itoa(i)))
+		}
+	}
+	return result
+}
+
+// child returns a match for the node at an index in the content of a
+// matched node.
+func (m *Match) child(index int, token string) *Match {
+	tokens := append(m.Tokens[:len(m.Tokens):len(m.Tokens)], token)
+	return &Match{Node: m.Node.Content[index], Parent: m.Node, Index: index, Tokens: tokens}
+}
+
+func (sel *selector) apply(m *Match) []*Match {
+	var result []*Match
+	node := m.Node
+	switch sel.kind {
+	case selectName:
+		if node.Kind == yaml.MappingNode {
+			for _, name := range sel.names {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == name {
+						result = append(result, m.child(i+1, name))
+					}
+				}
+			}
+		}
+	case selectIndex:
+		if node.Kind == yaml.SequenceNode {
+			for _, i := range sel.indices {
+				if i < 0 {
+					i += len(node.Content)
+				}
+				if i >= 0 && i < len(node.Content) {
+					result = append(result, m.child(i, strconv.Itoa(i)))
+				}
+			}
+		}
+	case selectWildcard:
+		result = children(m)
+	case selectFilter:
+		for _, child := range children(m) {
+			if sel.filter.test(child.Node) {
+				result = append(result, child)
+			}
+		}
+	}
+	return result
+}
+
+func (f *filter) test(node *yaml.Node) bool {
+	for _, name := range f.property {
+		var value *yaml.Node
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == name {
+					value = node.Content[i+1]
+				}
+			}
+		}
+		if value == nil {
+			return false
+		}
+		node = value
+	}
+	switch f.op {
+	case "==":
+		return node.Kind == yaml.ScalarNode && node.Value == f.value
+	case "!=":
+		return node.Kind != yaml.ScalarNode || node.Value != f.value
+	default:
+		return true
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const document = `
+openapi: 3.0.0
+tags: [a, b]
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+    post:
+      operationId: createPet
+  /pets/{id}:
+    get:
+      operationId: getPet
+`
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(text)), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return &node
+}
+
+func TestPaths(t *testing.T) {
+	root := parse(t, document).Content[0]
+	for _, test := range []struct {
+		path     string
+		values   []string
+		pointers []string
+	}{
+		{"$.openapi", []string{"3.0.0"}, []string{"/openapi"}},
+		{"$.tags[1]", []string{"b"}, nil},
+		{"$.tags[-1]", []string{"b"}, []string{"/tags/1"}},
+		{"$.tags[0,1]", []string{"a", "b"}, nil},
+		{"$.tags[1,0]", []string{"b", "a"}, []string{"/tags/1", "/tags/0"}},
+		{"$['tags','openapi'][*]", []string{"a", "b"}, nil},
+		{"$['paths']['/pets/{id}','/pets'].*.operationId", []string{"getPet", "createPet"}, nil},
+		{"$.tags[*]", []string{"a", "b"}, nil},
+		{"$['paths']['/pets/{id}'].get.operationId", []string{"getPet"}, nil},
+		{"$..operationId", []string{"createPet", "getPet"}, []string{"/paths/~1pets/post/operationId", "/paths/~1pets~1{id}/get/operationId"}},
+		{"$.paths.*.*.operationId", []string{"createPet", "getPet"}, nil},
+		{"$..parameters[?(@.name == 'offset')].in", []string{"query"}, []string{"/paths/~1pets/get/parameters/1/in"}},
+		{"$..parameters[?@.name != \"offset\"].name", []string{"limit"}, nil},
+		{"$.paths[?(@.post)].post.operationId", []string{"createPet"}, nil},
+		{"$.missing", nil, nil},
+	} {
+		p, err := Parse(test.path)
+		if err != nil {
+			t.Errorf("%s: %s", test.path, err)
+			continue
+		}
+		var values, pointers []string
+		for _, m := range p.Find(root) {
+			values = append(values, m.Node.Value)
+			pointers = append(pointers, m.Pointer())
+		}
+		if strings.Join(values, ",") != strings.Join(test.values, ",") {
+			t.Errorf("%s selected %v, expected %v", test.path, values, test.values)
+		}
+		if test.pointers != nil && strings.Join(pointers, ",") != strings.Join(test.pointers, ",") {
+			t.Errorf("%s selected %v, expected %v", test.path, pointers, test.pointers)
+		}
+	}
+}
+
+func TestInvalidPaths(t *testing.T) {
+	for _, path := range []string{
+		"paths",
+		"$.",
+		"$[1",
+		"$['a]",
+		"$[?(name)]",
+		"$['a', 1]",
+	} {
+		if _, err := Parse(path); err == nil {
+			t.Errorf("%s: expected error", path)
+		}
+	}
+}
+
+func TestPointers(t *testing.T) {
+	for _, test := range []struct {
+		path    string
+		pointer string
+		ok      bool
+	}{
+		{"$", "", true},
+		{"$.paths['/pets'].get.parameters[0]", "/paths/~1pets/get/parameters/0", true},
+		{"$.paths.*.get", "", false},
+		{"$..get", "", false},
+		{"$.tags[-1]", "", false},
+		{"$['a','b']", "", false},
+	} {
+		p, err := Parse(test.path)
+		if err != nil {
+			t.Fatalf("%s: %s", test.path, err)
+		}
+		if pointer, ok := p.Pointer(); pointer != test.pointer || ok != test.ok {
+			t.Errorf("%s: expected %q %t, got %q %t", test.path, test.pointer, test.ok, pointer, ok)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpointer implements JSON pointers as described in RFC 6901.
+// It can parse and format pointers, including pointers written as URI
+// fragments, and resolve pointers in trees of YAML nodes.
+package jsonpointer
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Escape escapes a reference token so that it can be used in a pointer.
+func Escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// Unescape returns the value of an escaped reference token.
+func Unescape(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			b.WriteByte(token[i])
+			continue
+		}
+		if i+1 == len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", fmt.Errorf("invalid escape sequence in %q", token)
+		}
+		if token[i+1] == '0' {
+			b.WriteByte('~')
+		} else {
+			b.WriteByte('/')
+		}
+		i++
+	}
+	return b.String(), nil
+}
+
+// Format returns a pointer that refers to a list of unescaped reference tokens.
+func Format(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(Escape(token))
+	}
+	return b.String()
+}
+
+// Append returns a pointer that refers to a location below another pointer.
+func Append(pointer string, tokens ...string) string {
+	return pointer + Format(tokens...)
+}
+
+// Parse returns the unescaped reference tokens of a pointer.
+// The empty pointer refers to a whole document and has no tokens.
+func Parse(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q: pointers must be empty or start with '/'", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(parts))
+	for i, part := range parts {
+		token, err := Unescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON pointer %q: %s", pointer, err)
+		}
+		tokens[i] = token
+	}
+	return tokens, nil
+}
+
+// ParseFragment returns the unescaped reference tokens of a pointer that
+// is written as a URI fragment, such as "#/definitions/Pet". The leading
+// "#" is optional and percent-encoded characters are decoded.
+func ParseFragment(fragment string) ([]string, error) {
+	fragment = strings.TrimPrefix(fragment, "#")
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON pointer %q: %s", fragment, err)
+	}
+	return Parse(pointer)
+}
+
+// FormatFragment returns a URI fragment for a list of unescaped reference tokens.
+func FormatFragment(tokens ...string) string {
+	var b strings.Builder
+	b.WriteString("#")
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(fragmentEscaper.Replace(Escape(token)))
+	}
+	return b.String()
+}
+
+// fragmentEscaper percent-encodes characters that can't appear in URI fragments.
+var fragmentEscaper = strings.NewReplacer(
+	"%", "%25", " ", "%20", "\"", "%22", "#", "%23",
+	"<", "%3C", ">", "%3E", "[", "%5B", "\\", "%5C",
+	"]", "%5D", "^", "%5E", "`", "%60", "{", "%7B",
+	"|", "%7C", "}", "%7D",
+)
+
+// Resolve returns the node that a pointer refers to in a tree of YAML nodes.
+// Document nodes are skipped, mapping keys are matched by value, and
+// tokens that refer to sequence elements must be array indices.
+func Resolve(node *yaml.Node, pointer string) (*yaml.Node, error) {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveTokens(node, tokens)
+}
+
+// ResolveTokens returns the node that a list of unescaped reference
+// tokens refers to in a tree of YAML nodes.
+func ResolveTokens(node *yaml.Node, tokens []string) (*yaml.Node, error) {
+	for i, token := range tokens {
+		for node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+			node = node.Content[0]
+		}
+		if node == nil {
+			return nil, notFound(tokens[:i+1])
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			var value *yaml.Node
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == token {
+					value = node.Content[j+1]
+					break
+				}
+			}
+			if value == nil {
+				return nil, notFound(tokens[:i+1])
+			}
+			node = value
+		case yaml.SequenceNode:
+			index, err := arrayIndex(token)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", Format(tokens[:i+1]...), err)
+			}
+			if index >= len(node.Content) {
+				return nil, notFound(tokens[:i+1])
+			}
+			node = node.Content[index]
+		default:
+			return nil, notFound(tokens[:i+1])
+		}
+	}
+	for node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	return node, nil
+}
+
+func notFound(tokens []string) error {
+	return fmt.Errorf("%s not found", Format(tokens...))
+}
+
+// arrayIndex parses a reference token that refers to an array element.
+// RFC 6901 only allows decimal numbers without leading zeros.
+func arrayIndex(token string) (int, error) {
+	if token == "-" {
+		return 0, fmt.Errorf("\"-\" refers to a nonexistent array element")
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return index, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"reflect"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// The example document from RFC 6901, section 5.
+const example = `{
+  "foo": ["bar", "baz"],
+  "": 0,
+  "a/b": 1,
+  "c%d": 2,
+  "e^f": 3,
+  "g|h": 4,
+  "i\\j": 5,
+  "k\"l": 6,
+  " ": 7,
+  "m~n": 8
+}`
+
+func TestResolve(t *testing.T) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(example), &document); err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, test := range []struct {
+		pointer  string
+		fragment string
+		expected string
+	}{
+		{"", "#", ""},
+		{"/foo", "#/foo", ""},
+		{"/foo/0", "#/foo/0", "bar"},
+		{"/", "#/", "0"},
+		{"/a~1b", "#/a~1b", "1"},
+		{"/c%d", "#/c%25d", "2"},
+		{"/e^f", "#/e%5Ef", "3"},
+		{"/g|h", "#/g%7Ch", "4"},
+		{"/i\\j", "#/i%5Cj", "5"},
+		{"/k\"l", "#/k%22l", "6"},
+		{"/ ", "#/%20", "7"},
+		{"/m~0n", "#/m~0n", "8"},
+	} {
+		node, err := Resolve(&document, test.pointer)
+		if err != nil {
+			t.Errorf("Resolve(%q): %s", test.pointer, err)
+			continue
+		}
+		if node.Value != test.expected {
+			t.Errorf("Resolve(%q) = %q (expected %q)", test.pointer, node.Value, test.expected)
+		}
+		tokens, err := Parse(test.pointer)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", test.pointer, err)
+			continue
+		}
+		if pointer := Format(tokens...); pointer != test.pointer {
+			t.Errorf("Format(%q) = %q (expected %q)", tokens, pointer, test.pointer)
+		}
+		if fragment := FormatFragment(tokens...); fragment != test.fragment {
+			t.Errorf("FormatFragment(%q) = %q (expected %q)", tokens, fragment, test.fragment)
+		}
+		fragmentTokens, err := ParseFragment(test.fragment)
+		if err != nil {
+			t.Errorf("ParseFragment(%q): %s", test.fragment, err)
+			continue
+		}
+		if !reflect.DeepEqual(fragmentTokens, tokens) {
+			t.Errorf("ParseFragment(%q) = %q (expected %q)", test.fragment, fragmentTokens, tokens)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(example), &document); err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, test := range []struct {
+		pointer string
+		err     string
+	}{
+		{"foo", `invalid JSON pointer "foo": pointers must be empty or start with '/'`},
+		{"/m~2n", `invalid JSON pointer "/m~2n": invalid escape sequence in "m~2n"`},
+		{"/m~", `invalid JSON pointer "/m~": invalid escape sequence in "m~"`},
+		{"/bar", "/bar not found"},
+		{"/foo/2", "/foo/2 not found"},
+		{"/foo/01", `/foo/01: invalid array index "01"`},
+		{"/foo/-", `/foo/-: "-" refers to a nonexistent array element`},
+		{"/foo/0/x", "/foo/0/x not found"},
+	} {
+		_, err := Resolve(&document, test.pointer)
+		if err == nil {
+			t.Errorf("Resolve(%q): expected error", test.pointer)
+		} else if err.Error() != test.err {
+			t.Errorf("Resolve(%q): unexpected error %q (expected %q)", test.pointer, err.Error(), test.err)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	if pointer := Append("/paths", "/pets/{id}", "get"); pointer != "/paths/~1pets~1{id}/get" {
+		t.Errorf("unexpected pointer %q", pointer)
+	}
+}
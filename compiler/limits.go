@@ -0,0 +1,233 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Limits restricts the resources that can be used to read documents,
+// resolve their references, and replace references with copies of their
+// targets. Services that compile untrusted documents
+// should set limits to keep malicious documents from exhausting memory
+// or hanging the process. Zero values mean that there is no limit.
+type Limits struct {
+	// MaxDocumentBytes is the maximum size of a single document or
+	// referenced file.
+	MaxDocumentBytes int64
+	// MaxRefDepth is the maximum length of a chain of references, where
+	// the target of each reference is another reference.
+	MaxRefDepth int
+	// MaxNodes is the maximum number of YAML nodes in a single document,
+	// counted after aliases are expanded. The transforms package also
+	// applies it to the documents that it makes by replacing references
+	// with copies of their targets with DereferenceContext,
+	// ResolveInternalContext, and InlineContext.
+	MaxNodes int
+	// Timeout limits the time used to fetch a remote file, the total
+	// time used by CheckReferences, and, for contexts returned by
+	// WithTimeout, the total time used by a compilation.
+	Timeout time.Duration
+}
+
+var limits Limits
+var limitsMutex sync.Mutex
+
+// SetLimits sets the limits used when reading documents and resolving references.
+func SetLimits(l Limits) {
+	limitsMutex.Lock()
+	defer limitsMutex.Unlock()
+	limits = l
+}
+
+// GetLimits returns the limits used when reading documents and resolving references.
+func GetLimits() Limits {
+	limitsMutex.Lock()
+	defer limitsMutex.Unlock()
+	return limits
+}
+
+type limitsKey struct{}
+
+// WithLimits returns a copy of ctx with limits that the functions that
+// take the context use instead of the limits set with SetLimits, so that
+// concurrent compilations can have different limits.
+func WithLimits(ctx context.Context, l Limits) context.Context {
+	return context.WithValue(ctx, limitsKey{}, l)
+}
+
+// GetLimitsContext returns the limits of a context or, if it has none,
+// the limits set with SetLimits.
+func GetLimitsContext(ctx context.Context) Limits {
+	return limitsFor(ctx)
+}
+
+// limitsFor returns the limits of a context or, if it has none, the
+// limits set with SetLimits.
+func limitsFor(ctx context.Context) Limits {
+	if l, ok := ctx.Value(limitsKey{}).(Limits); ok {
+		return l
+	}
+	return GetLimits()
+}
+
+// WithTimeout returns a copy of ctx that is done when the Timeout of its
+// limits has passed. Compilations with the context stop while they fetch,
+// read, and expand documents, check references, and replace references
+// with copies of their targets, and between their other steps. The
+// ResolveReferences methods of the generated models don't take a context,
+// so compilations are only stopped after they return. When there is no
+// timeout, the copy is only done when ctx is done or the returned function
+// is called.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l := limitsFor(ctx); l.Timeout > 0 {
+		return context.WithTimeout(ctx, l.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func checkDocumentBytes(filename string, size int64, l Limits) error {
+	if l.MaxDocumentBytes > 0 && size > l.MaxDocumentBytes {
+		return fmt.Errorf("%s is too large (more than %d bytes)", describeFile(filename), l.MaxDocumentBytes)
+	}
+	return nil
+}
+
+func describeFile(filename string) string {
+	if filename == "" {
+		return "document"
+	}
+	return filename
+}
+
+// CheckReferences reads the targets of the references in a document and
+// returns an error if resolving them would exceed the current limits.
+// References to other references are followed, and chains of references
+// that are longer than MaxRefDepth or that contain cycles are rejected.
+// The targets that are read are added to the cache used by the
+// ResolveReferences methods of the generated models, so a document that
+// passes this check can be resolved without reading any files again.
+// CheckReferences does nothing when no limits or fetch policy are set.
+// When a fetch policy is set, references to files that the policy doesn't
+// allow are rejected, so that the models never fetch them.
+func CheckReferences(filename string) error {
+	return CheckReferencesContext(context.Background(), filename)
+}
+
+// CheckReferencesContext checks the references in a document with the
+// limits and fetch policy of a context, and stops when it is done.
+func CheckReferencesContext(ctx context.Context, filename string) error {
+	l := limitsFor(ctx)
+	if l == (Limits{}) && !fetchPolicyFor(ctx).isSet() {
+		return nil
+	}
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	bytes, err := readBytesForFile(ctx, filename)
+	if err != nil {
+		return err
+	}
+	info, err := readInfoFromBytes(ctx, filename, bytes)
+	if err != nil {
+		return err
+	}
+	c := &referenceChecker{
+		ctx:     ctx,
+		root:    filename,
+		limits:  l,
+		targets: make(map[string]*yaml.Node),
+	}
+	if l.Timeout > 0 {
+		c.deadline = time.Now().Add(l.Timeout)
+	}
+	if err := c.check(info); err != nil {
+		return err
+	}
+	return nil
+}
+
+type referenceChecker struct {
+	ctx      context.Context
+	root     string
+	limits   Limits
+	deadline time.Time
+	// targets contains the nodes that have been read for each reference.
+	targets map[string]*yaml.Node
+}
+
+func (c *referenceChecker) check(node *yaml.Node) error {
+	if ref := referenceValue(node); ref != nil {
+		if err := c.checkChain(ref); err != nil {
+			return err
+		}
+	}
+	for _, child := range node.Content {
+		if err := c.check(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChain reads the target of a reference and, if the target is
+// another reference, continues with that reference.
+func (c *referenceChecker) checkChain(node *yaml.Node) error {
+	visited := make(map[string]bool)
+	for depth := 1; node != nil; depth++ {
+		ref := node.Value
+		if c.limits.MaxRefDepth > 0 && depth > c.limits.MaxRefDepth {
+			return fmt.Errorf("line %d: reference %s exceeds the maximum reference depth (%d)", node.Line, ref, c.limits.MaxRefDepth)
+		}
+		if visited[ref] {
+			return fmt.Errorf("line %d: reference %s is part of a cycle", node.Line, ref)
+		}
+		visited[ref] = true
+		if !c.deadline.IsZero() && time.Now().After(c.deadline) {
+			return fmt.Errorf("timed out resolving references after %s", c.limits.Timeout)
+		}
+		if err := c.ctx.Err(); err != nil {
+			return err
+		}
+		target, ok := c.targets[ref]
+		if !ok {
+			var err error
+			target, err = readInfoForRef(c.ctx, c.root, ref)
+			if err != nil {
+				return err
+			}
+			c.targets[ref] = target
+		}
+		node = referenceValue(target)
+	}
+	return nil
+}
+
+// referenceValue returns the value of the $ref key of a mapping node.
+func referenceValue(node *yaml.Node) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadInfoFromBytesLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+	for _, test := range []struct {
+		name   string
+		limits Limits
+		input  string
+		err    string
+	}{
+		{
+			name:   "bytes",
+			limits: Limits{MaxDocumentBytes: 10},
+			input:  "a: [1, 2, 3, 4, 5]",
+			err:    "document is too large (more than 10 bytes)",
+		},
+		{
+			name:   "nodes",
+			limits: Limits{MaxNodes: 5},
+			input:  "a: [1, 2, 3, 4, 5]",
+			err:    "document is too large (more than 5 nodes)",
+		},
+		{
+			name:   "nodes after expanding aliases",
+			limits: Limits{MaxNodes: 10},
+			input:  "a: &a [1, 2]\nb: *a\nc: *a\n",
+			err:    "too large after expanding aliases (more than 10 nodes)",
+		},
+		{
+			name:   "within limits",
+			limits: Limits{MaxDocumentBytes: 100, MaxNodes: 100},
+			input:  "a: &a [1, 2]\nb: *a\nc: *a\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			SetLimits(test.limits)
+			_, err := ReadInfoFromBytes("", []byte(test.input))
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("%s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("unexpected error: %q (expected %q)", err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestLimitsForContext(t *testing.T) {
+	defer SetLimits(Limits{})
+	input := []byte("a: [1, 2, 3, 4, 5]")
+	// The limits of a context replace the limits of the process.
+	SetLimits(Limits{MaxNodes: 5})
+	if _, err := ReadInfoFromBytesContext(WithLimits(context.Background(), Limits{}), "", input); err != nil {
+		t.Errorf("%s", err)
+	}
+	SetLimits(Limits{})
+	_, err := ReadInfoFromBytesContext(WithLimits(context.Background(), Limits{MaxNodes: 5}), "", input)
+	if err == nil || !strings.Contains(err.Error(), "more than 5 nodes") {
+		t.Errorf("expected the document to be rejected, got %v", err)
+	}
+}
+
+func TestReadInfoFromBytesStopsWhenDone(t *testing.T) {
+	input := "a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for i, name := range []string{"b", "c", "d", "e"} {
+		p := string("abcde"[i])
+		input += fmt.Sprintf("%s: &%s [*%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s]\n", name, name, p, p, p, p, p, p, p, p, p, p)
+	}
+	ctx, cancel := WithTimeout(WithLimits(context.Background(), Limits{Timeout: time.Hour}))
+	cancel()
+	if _, err := ReadInfoFromBytesContext(ctx, "", []byte(input)); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if _, err := ReadInfoFromBytesContext(context.Background(), "", []byte(input)); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestCheckReferencesLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+	defer ClearCaches()
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"chain.yaml": `
+a: {$ref: "#/b"}
+b: {$ref: "#/c"}
+c: {$ref: "#/d"}
+d: {type: string}
+`,
+		"cycle.yaml": `
+a: {$ref: "#/b"}
+b: {$ref: "#/a"}
+`,
+		"large.yaml": `
+a: {$ref: "other.yaml#/x"}
+`,
+		"other.yaml": `
+x: {type: string, description: a long description}
+`,
+		"bomb.yaml": `
+a: {$ref: "aliases.yaml#/x"}
+`,
+		"aliases.yaml": `
+a: &a [1, 2, 3, 4, 5, 6, 7, 8]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a]
+x: [*b, *b, *b, *b, *b, *b, *b, *b]
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+	for _, test := range []struct {
+		name   string
+		file   string
+		limits Limits
+		err    string
+	}{
+		{
+			name:   "chain within limits",
+			file:   "chain.yaml",
+			limits: Limits{MaxRefDepth: 3},
+		},
+		{
+			name:   "chain too long",
+			file:   "chain.yaml",
+			limits: Limits{MaxRefDepth: 2},
+			err:    "exceeds the maximum reference depth (2)",
+		},
+		{
+			name:   "cycle",
+			file:   "cycle.yaml",
+			limits: Limits{MaxRefDepth: 10},
+			err:    "is part of a cycle",
+		},
+		{
+			name:   "referenced file too large",
+			file:   "large.yaml",
+			limits: Limits{MaxDocumentBytes: 40},
+			err:    "other.yaml is too large (more than 40 bytes)",
+		},
+		{
+			name:   "referenced file too large after expanding aliases",
+			file:   "bomb.yaml",
+			limits: Limits{MaxNodes: 100},
+			err:    "too large after expanding aliases (more than 100 nodes)",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ClearCaches()
+			SetLimits(test.limits)
+			err := CheckReferences(filepath.Join(dir, test.file))
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("%s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("unexpected error: %q (expected %q)", err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestFetchFileTimeout(t *testing.T) {
+	defer SetLimits(Limits{})
+	defer ClearCaches()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	SetLimits(Limits{Timeout: 100 * time.Millisecond})
+	if _, err := FetchFile(server.URL + "/openapi.yaml"); err == nil {
+		t.Fatalf("expected error")
+	}
+}
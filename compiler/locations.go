@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Location is a position in a source document.
+type Location struct {
+	Line   int
+	Column int
+}
+
+// LocationIndex records the positions of the nodes of a source document.
+// Compiled models don't keep the positions of the values that they were
+// built from, so tools that report problems with a model can use an
+// index built from the same YAML tree to point back to the source.
+type LocationIndex struct {
+	pointers  []string
+	locations map[string]Location
+}
+
+// NewLocationIndex returns an index of the positions of the nodes below
+// a node, keyed by JSON pointers relative to that node. The position of
+// a map entry is the position of its key. If the node is nil, the index
+// is empty.
+func NewLocationIndex(node *yaml.Node) *LocationIndex {
+	x := &LocationIndex{locations: make(map[string]Location)}
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node != nil {
+		x.add("", node, node)
+	}
+	return x
+}
+
+func (x *LocationIndex) add(pointer string, position, node *yaml.Node) {
+	x.pointers = append(x.pointers, pointer)
+	x.locations[pointer] = Location{Line: position.Line, Column: position.Column}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			x.add(jsonpointer.Append(pointer, key.Value), key, node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			x.add(jsonpointer.Append(pointer, fmt.Sprintf("%d", i)), item, item)
+		}
+	}
+}
+
+// Location returns the position of the node at a JSON pointer.
+func (x *LocationIndex) Location(pointer string) (Location, bool) {
+	location, ok := x.locations[pointer]
+	return location, ok
+}
+
+// Lookup returns the position of the node at a JSON pointer or, if
+// there is no such node, the position of its closest ancestor. This
+// finds positions for values that were added to a model by compilation,
+// such as default values.
+func (x *LocationIndex) Lookup(pointer string) Location {
+	tokens, err := jsonpointer.Parse(pointer)
+	if err != nil {
+		return x.locations[""]
+	}
+	for n := len(tokens); n >= 0; n-- {
+		if location, ok := x.locations[jsonpointer.Format(tokens[:n]...)]; ok {
+			return location
+		}
+	}
+	return Location{}
+}
+
+// Set records the position of the node at a JSON pointer.
+func (x *LocationIndex) Set(pointer string, location Location) {
+	if _, ok := x.locations[pointer]; !ok {
+		x.pointers = append(x.pointers, pointer)
+	}
+	x.locations[pointer] = location
+}
+
+// Pointers returns the JSON pointers of all indexed nodes in document order.
+func (x *LocationIndex) Pointers() []string {
+	return x.pointers
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"testing"
+)
+
+func TestLocationIndex(t *testing.T) {
+	info, err := ReadInfoFromBytes("", []byte(`openapi: 3.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	index := NewLocationIndex(info)
+	for _, test := range []struct {
+		pointer  string
+		location Location
+		exact    bool
+	}{
+		{"", Location{Line: 1, Column: 1}, true},
+		{"/openapi", Location{Line: 1, Column: 1}, true},
+		{"/paths/~1pets~1{id}", Location{Line: 3, Column: 3}, true},
+		{"/paths/~1pets~1{id}/get/parameters/0", Location{Line: 6, Column: 11}, true},
+		{"/paths/~1pets~1{id}/get/parameters/0/in", Location{Line: 7, Column: 11}, true},
+		{"/paths/~1pets~1{id}/get/parameters/0/required", Location{Line: 6, Column: 11}, false},
+		{"/components", Location{Line: 1, Column: 1}, false},
+	} {
+		location, ok := index.Location(test.pointer)
+		if ok != test.exact {
+			t.Errorf("Location(%q) returned %t, expected %t", test.pointer, ok, test.exact)
+		}
+		if location := index.Lookup(test.pointer); location != test.location {
+			t.Errorf("Lookup(%q) returned %+v, expected %+v", test.pointer, location, test.location)
+		}
+		if ok && location != test.location {
+			t.Errorf("Location(%q) returned %+v, expected %+v", test.pointer, location, test.location)
+		}
+	}
+	if n := len(index.Pointers()); n != 9 {
+		t.Errorf("index contains %d pointers, expected 9", n)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// The generated models represent maps with repeated fields of pairs, which
+// are messages with a name and a value, and values of any type with Any
+// messages that hold their YAML representations.
+
+// Is a message a pair of a name and a value?
+func isPair(d protoreflect.MessageDescriptor) bool {
+	fields := d.Fields()
+	name, value := fields.ByName("name"), fields.ByName("value")
+	return fields.Len() == 2 && name != nil && value != nil &&
+		name.Kind() == protoreflect.StringKind && !name.IsList() && !value.IsList()
+}
+
+// Is a message an Any of a generated model?
+func isAny(d protoreflect.MessageDescriptor) bool {
+	fields := d.Fields()
+	yaml := fields.ByName("yaml")
+	return d.Name() == "Any" && fields.Len() == 2 && fields.ByName("value") != nil &&
+		yaml != nil && yaml.Kind() == protoreflect.StringKind
+}
+
+// Is a field a map of names to values?
+func isMap(field protoreflect.FieldDescriptor) bool {
+	return field.IsList() && field.Message() != nil && isPair(field.Message())
+}
+
+// EqualModels reports whether two messages of a generated model describe
+// the same values. Unlike proto.Equal, maps are equal if they have the same
+// names and values in any order, Any values are compared by the values that
+// their YAML represents, and unset fields are equal to empty values.
+func EqualModels(a, b proto.Message) bool {
+	return equalMessages(proto.MessageV2(a).ProtoReflect(), proto.MessageV2(b).ProtoReflect())
+}
+
+func equalMessages(a, b protoreflect.Message) bool {
+	if a.Descriptor() != b.Descriptor() {
+		return false
+	}
+	if isAny(a.Descriptor()) {
+		return equalYAML(a.Get(a.Descriptor().Fields().ByName("yaml")).String(), b.Get(b.Descriptor().Fields().ByName("yaml")).String())
+	}
+	oneofs := a.Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		if a.WhichOneof(oneofs.Get(i)) != b.WhichOneof(oneofs.Get(i)) {
+			return false
+		}
+	}
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !a.Has(field) && !b.Has(field) {
+			continue
+		}
+		if !equalFields(field, a.Get(field), b.Get(field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFields(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch {
+	case isMap(field):
+		return equalMaps(a.List(), b.List())
+	case field.IsList():
+		if a.List().Len() != b.List().Len() {
+			return false
+		}
+		for i := 0; i < a.List().Len(); i++ {
+			if !equalValues(field, a.List().Get(i), b.List().Get(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return equalValues(field, a, b)
+}
+
+func equalValues(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	if field.Message() != nil {
+		return equalMessages(a.Message(), b.Message())
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func equalMaps(a, b protoreflect.List) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	values := make(map[string]protoreflect.Message)
+	for i := 0; i < b.Len(); i++ {
+		pair := b.Get(i).Message()
+		values[pairName(pair)] = pair
+	}
+	for i := 0; i < a.Len(); i++ {
+		pair := a.Get(i).Message()
+		other, ok := values[pairName(pair)]
+		if !ok || !equalMessages(pair, other) {
+			return false
+		}
+	}
+	return true
+}
+
+func pairName(pair protoreflect.Message) string {
+	return pair.Get(pair.Descriptor().Fields().ByName("name")).String()
+}
+
+// Compare the values of two YAML texts. Texts that can't be read are
+// compared as text.
+func equalYAML(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if yaml.Unmarshal([]byte(a), &x) != nil || yaml.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// MergeModels merges src into dst, which must be messages of the same
+// generated model. Unlike proto.Merge, which appends the pairs of maps,
+// values of maps are merged with the values in dst that have the same
+// names, and other pairs are added. Values of other lists are added unless
+// dst has equal values, so merging a message into itself changes nothing.
+// Any values of src replace those of dst. Other fields are merged as by
+// proto.Merge. Nothing is merged into a nil dst.
+func MergeModels(dst, src proto.Message) {
+	d, s := proto.MessageV2(dst).ProtoReflect(), proto.MessageV2(src).ProtoReflect()
+	if !d.IsValid() || !s.IsValid() {
+		return
+	}
+	mergeMessages(d, s)
+}
+
+func mergeMessages(dst, src protoreflect.Message) {
+	src.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case isMap(field):
+			mergeMaps(dst.Mutable(field).List(), value.List())
+		case field.IsList():
+			list := dst.Mutable(field).List()
+			for i := 0; i < value.List().Len(); i++ {
+				if item := value.List().Get(i); !contains(field, list, item) {
+					list.Append(cloneValue(field, item))
+				}
+			}
+		case field.Message() != nil && dst.Has(field) && !isAny(field.Message()):
+			mergeMessages(dst.Mutable(field).Message(), value.Message())
+		default:
+			// Setting a field of a oneof clears the others.
+			dst.Set(field, cloneValue(field, value))
+		}
+		return true
+	})
+}
+
+// Does a list have a value that is equal to another?
+func contains(field protoreflect.FieldDescriptor, list protoreflect.List, value protoreflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if equalValues(field, list.Get(i), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeMaps(dst, src protoreflect.List) {
+	index := make(map[string]protoreflect.Message)
+	for i := 0; i < dst.Len(); i++ {
+		pair := dst.Get(i).Message()
+		index[pairName(pair)] = pair
+	}
+	for i := 0; i < src.Len(); i++ {
+		pair := src.Get(i).Message()
+		if existing, ok := index[pairName(pair)]; ok {
+			mergeMessages(existing, pair)
+			continue
+		}
+		clone := proto.MessageV2(proto.Clone(proto.MessageV1(pair.Interface()))).ProtoReflect()
+		dst.Append(protoreflect.ValueOfMessage(clone))
+		index[pairName(clone)] = clone
+	}
+}
+
+func cloneValue(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	if field.Message() == nil {
+		return value
+	}
+	clone := proto.Clone(proto.MessageV1(value.Message().Interface()))
+	return protoreflect.ValueOfMessage(proto.MessageV2(clone).ProtoReflect())
+}
+
+// CanonicalBytes returns an encoding of a message of a generated model that
+// is the same for messages that EqualModels considers equal, so that it can
+// be hashed to detect changes. Fields are written in the order of their
+// numbers, the pairs of maps are sorted by name, and Any values are written
+// as JSON with sorted keys. The encoding isn't meant to be decoded.
+func CanonicalBytes(m proto.Message) []byte {
+	var b bytes.Buffer
+	writeCanonicalMessage(&b, proto.MessageV2(m).ProtoReflect())
+	return b.Bytes()
+}
+
+func writeCanonicalMessage(b *bytes.Buffer, m protoreflect.Message) {
+	b.WriteByte('{')
+	if isAny(m.Descriptor()) {
+		writeCanonicalString(b, canonicalYAML(m.Get(m.Descriptor().Fields().ByName("yaml")).String()))
+		b.WriteByte('}')
+		return
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !m.Has(field) {
+			continue
+		}
+		var value bytes.Buffer
+		writeCanonicalField(&value, field, m.Get(field))
+		// Empty messages are equal to unset ones, except in oneofs.
+		if field.ContainingOneof() == nil && !field.IsList() && field.Message() != nil && value.String() == "{}" {
+			continue
+		}
+		fmt.Fprintf(b, "%d=", field.Number())
+		b.Write(value.Bytes())
+	}
+	b.WriteByte('}')
+}
+
+func writeCanonicalField(b *bytes.Buffer, field protoreflect.FieldDescriptor, value protoreflect.Value) {
+	if !field.IsList() {
+		writeCanonicalValue(b, field, value)
+		return
+	}
+	list := value.List()
+	items := make([]protoreflect.Value, list.Len())
+	for i := range items {
+		items[i] = list.Get(i)
+	}
+	if isMap(field) {
+		sort.SliceStable(items, func(i, j int) bool {
+			return pairName(items[i].Message()) < pairName(items[j].Message())
+		})
+	}
+	b.WriteByte('[')
+	for _, item := range items {
+		writeCanonicalValue(b, field, item)
+	}
+	b.WriteByte(']')
+}
+
+func writeCanonicalValue(b *bytes.Buffer, field protoreflect.FieldDescriptor, value protoreflect.Value) {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		writeCanonicalMessage(b, value.Message())
+	case protoreflect.StringKind:
+		writeCanonicalString(b, value.String())
+	case protoreflect.BytesKind:
+		writeCanonicalString(b, string(value.Bytes()))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		b.WriteString(strconv.FormatFloat(value.Float(), 'g', -1, 64) + ";")
+	default:
+		fmt.Fprintf(b, "%v;", value.Interface())
+	}
+}
+
+// Strings are written with their lengths.
+func writeCanonicalString(b *bytes.Buffer, s string) {
+	b.WriteString(strconv.Itoa(len(s)) + ":" + s)
+}
+
+// Get the JSON of the value of a YAML text, or the text if it can't be
+// written as JSON.
+func canonicalYAML(s string) string {
+	var v interface{}
+	if yaml.Unmarshal([]byte(s), &v) != nil {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
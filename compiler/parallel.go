@@ -0,0 +1,91 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync"
+)
+
+// parallelism limits the number of goroutines that the generated
+// compilers use to build the entries of large maps, such as the paths and
+// schemas of OpenAPI v3 documents. Entries are built in the calling
+// goroutine when no more goroutines are available, so compilations that
+// run at the same time share the same limit.
+var parallelism struct {
+	sync.Mutex
+	n       int
+	workers chan struct{}
+}
+
+// SetParallelism sets the number of goroutines that can be used to build
+// messages. Values below 2 build all messages in the calling goroutine,
+// which is the default.
+func SetParallelism(n int) {
+	parallelism.Lock()
+	defer parallelism.Unlock()
+	if n < 2 {
+		parallelism.n = 1
+		parallelism.workers = nil
+		return
+	}
+	parallelism.n = n
+	// The calling goroutine is one of the n.
+	parallelism.workers = make(chan struct{}, n-1)
+}
+
+// GetParallelism returns the number of goroutines that can be used to build messages.
+func GetParallelism() int {
+	parallelism.Lock()
+	defer parallelism.Unlock()
+	if parallelism.n < 2 {
+		return 1
+	}
+	return parallelism.n
+}
+
+// BuildConcurrently calls build for each index from 0 to n-1 and returns
+// when all of the calls have returned. Calls run concurrently when
+// parallelism is enabled, so build must only write to state that belongs
+// to its index. It is called by the generated compilers.
+func BuildConcurrently(n int, build func(i int)) {
+	parallelism.Lock()
+	workers := parallelism.workers
+	parallelism.Unlock()
+	if workers == nil || n < 2 {
+		for i := 0; i < n; i++ {
+			build(i)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case workers <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				build(i)
+			}(i)
+		default:
+			// Building inline instead of waiting for a worker keeps
+			// nested calls from deadlocking.
+			build(i)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBuildConcurrently(t *testing.T) {
+	for _, parallelism := range []int{1, 4} {
+		SetParallelism(parallelism)
+		var running, maxRunning int32
+		calls := make([]int32, 100)
+		var mutex sync.Mutex
+		BuildConcurrently(len(calls), func(i int) {
+			n := atomic.AddInt32(&running, 1)
+			mutex.Lock()
+			if n > maxRunning {
+				maxRunning = n
+			}
+			mutex.Unlock()
+			// Nested calls share the same workers.
+			BuildConcurrently(10, func(int) {})
+			atomic.AddInt32(&calls[i], 1)
+			atomic.AddInt32(&running, -1)
+		})
+		for i, n := range calls {
+			if n != 1 {
+				t.Errorf("parallelism %d: build(%d) was called %d times", parallelism, i, n)
+			}
+		}
+		if int(maxRunning) > parallelism {
+			t.Errorf("parallelism %d: %d builds ran at the same time", parallelism, maxRunning)
+		}
+	}
+	SetParallelism(0)
+	if GetParallelism() != 1 {
+		t.Errorf("unexpected parallelism %d, expected 1", GetParallelism())
+	}
+}
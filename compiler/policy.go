@@ -0,0 +1,238 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FetchPolicy restricts the remote files that can be fetched to read
+// documents and resolve their references. Services that compile untrusted
+// documents should set a policy so that references can't be used to probe
+// internal networks. The zero value allows every fetch.
+type FetchPolicy struct {
+	// AllowedSchemes lists the URL schemes that can be fetched, such as
+	// "https". When it is empty, any scheme supported by net/http is allowed.
+	AllowedSchemes []string
+	// AllowedHosts lists the hosts that can be fetched. Hosts that begin
+	// with "*." match any subdomain, so "*.example.com" matches
+	// "api.example.com" but not "example.com". When it is empty, any host
+	// is allowed.
+	AllowedHosts []string
+	// BlockPrivateAddresses rejects connections to loopback, private,
+	// link-local, and other addresses that aren't publicly routable.
+	// Addresses are checked when connecting, after host names are resolved
+	// and after redirects, and proxies from the environment aren't used.
+	BlockPrivateAddresses bool
+	// MaxResponseBytes is the maximum size of a fetched file. Files are
+	// also limited by Limits.MaxDocumentBytes, which applies to local files
+	// too. Zero means that there is no limit.
+	MaxResponseBytes int64
+	// BlockAll rejects every fetch, so that only local files can be read.
+	BlockAll bool
+}
+
+var fetchPolicy FetchPolicy
+var fetchPolicyMutex sync.Mutex
+
+// SetFetchPolicy sets the policy used to fetch remote files.
+func SetFetchPolicy(p FetchPolicy) {
+	fetchPolicyMutex.Lock()
+	defer fetchPolicyMutex.Unlock()
+	fetchPolicy = p
+}
+
+// GetFetchPolicy returns the policy used to fetch remote files.
+func GetFetchPolicy() FetchPolicy {
+	fetchPolicyMutex.Lock()
+	defer fetchPolicyMutex.Unlock()
+	return fetchPolicy
+}
+
+type fetchPolicyKey struct{}
+
+// WithFetchPolicy returns a copy of ctx with a policy that the functions
+// that take the context use instead of the policy set with SetFetchPolicy,
+// so that concurrent compilations can have different policies.
+func WithFetchPolicy(ctx context.Context, p FetchPolicy) context.Context {
+	return context.WithValue(ctx, fetchPolicyKey{}, p)
+}
+
+// GetFetchPolicyContext returns the policy of a context or, if it has
+// none, the policy set with SetFetchPolicy.
+func GetFetchPolicyContext(ctx context.Context) FetchPolicy {
+	return fetchPolicyFor(ctx)
+}
+
+// fetchPolicyFor returns the policy of a context or, if it has none, the
+// policy set with SetFetchPolicy.
+func fetchPolicyFor(ctx context.Context) FetchPolicy {
+	if p, ok := ctx.Value(fetchPolicyKey{}).(FetchPolicy); ok {
+		return p
+	}
+	return GetFetchPolicy()
+}
+
+// isSet returns true if a policy restricts fetches.
+func (p FetchPolicy) isSet() bool {
+	return len(p.AllowedSchemes) > 0 || len(p.AllowedHosts) > 0 || p.BlockPrivateAddresses || p.MaxResponseBytes > 0 || p.BlockAll
+}
+
+// key identifies the restrictions of a policy, so that files fetched with
+// different policies are cached separately.
+func (p FetchPolicy) key() string {
+	if !p.isSet() {
+		return ""
+	}
+	return fmt.Sprintf("%q %q %t %d %t", p.AllowedSchemes, p.AllowedHosts, p.BlockPrivateAddresses, p.MaxResponseBytes, p.BlockAll)
+}
+
+// checkURL returns an error if a policy doesn't allow a URL to be fetched.
+func (p FetchPolicy) checkURL(u *url.URL) error {
+	if p.BlockAll {
+		return fmt.Errorf("fetching %s is not allowed", u)
+	}
+	if len(p.AllowedSchemes) > 0 && !containsFold(p.AllowedSchemes, u.Scheme) {
+		return fmt.Errorf("fetching %s is not allowed: scheme %q is not allowed", u, u.Scheme)
+	}
+	if len(p.AllowedHosts) > 0 && !p.allowsHost(u.Hostname()) {
+		return fmt.Errorf("fetching %s is not allowed: host %q is not allowed", u, u.Hostname())
+	}
+	return nil
+}
+
+func (p FetchPolicy) allowsHost(host string) bool {
+	for _, allowed := range p.AllowedHosts {
+		if matchHost(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHost returns true if a host matches a pattern, which is a host
+// name or, if it begins with "*.", any subdomain of a host name.
+func matchHost(pattern, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	pattern = strings.ToLower(pattern)
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return host == pattern
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxRedirects is the number of redirects that are followed, as in the
+// default policy of net/http.
+const maxRedirects = 10
+
+// client returns an HTTP client that enforces a policy.
+func (p FetchPolicy) client(timeout time.Duration) *http.Client {
+	client := &http.Client{Timeout: timeout}
+	if !p.isSet() {
+		return client
+	}
+	client.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return p.checkURL(request.URL)
+	}
+	if p.BlockPrivateAddresses {
+		dialer := &net.Dialer{
+			Timeout: 30 * time.Second,
+			Control: func(network, address string, c syscall.RawConn) error {
+				return checkAddress(address)
+			},
+		}
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout: 10 * time.Second,
+		}
+	}
+	return client
+}
+
+// privateNetworks contains the addresses that aren't publicly routable,
+// in addition to the loopback, link-local, and unspecified addresses
+// recognized by net.IP.
+var privateNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"fc00::/7",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// isPrivateIP returns true if an address isn't publicly routable.
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAddress returns an error if a dialed address is private.
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("connections to private address %s are not allowed", host)
+	}
+	return nil
+}
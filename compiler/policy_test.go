@@ -0,0 +1,164 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchPolicy(t *testing.T) {
+	defer ClearCaches()
+	defer SetFetchPolicy(FetchPolicy{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			_, port, _ := net.SplitHostPort(r.Host)
+			http.Redirect(w, r, "http://localhost:"+port+"/a.yaml", http.StatusFound)
+			return
+		}
+		fmt.Fprintf(w, "path: %s\n", r.URL.Path)
+	}))
+	defer server.Close()
+	for _, test := range []struct {
+		policy FetchPolicy
+		path   string
+		err    string
+	}{
+		{FetchPolicy{}, "/a.yaml", ""},
+		{FetchPolicy{AllowedSchemes: []string{"HTTP"}, AllowedHosts: []string{"127.0.0.1"}}, "/a.yaml", ""},
+		{FetchPolicy{AllowedSchemes: []string{"https"}}, "/a.yaml", `scheme "http" is not allowed`},
+		{FetchPolicy{AllowedHosts: []string{"*.example.com"}}, "/a.yaml", `host "127.0.0.1" is not allowed`},
+		{FetchPolicy{AllowedHosts: []string{"127.0.0.1"}}, "/redirect", `host "localhost" is not allowed`},
+		{FetchPolicy{BlockPrivateAddresses: true}, "/a.yaml", "private address 127.0.0.1"},
+		{FetchPolicy{MaxResponseBytes: 8}, "/a.yaml", "too large (more than 8 bytes)"},
+		{FetchPolicy{MaxResponseBytes: 64}, "/a.yaml", ""},
+		{FetchPolicy{BlockAll: true}, "/a.yaml", "is not allowed"},
+	} {
+		ClearCaches()
+		SetFetchPolicy(test.policy)
+		bytes, err := FetchFile(server.URL + test.path)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%+v: %s", test.policy, err)
+			} else if string(bytes) != "path: /a.yaml\n" {
+				t.Errorf("%+v: unexpected result: %q", test.policy, string(bytes))
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%+v: expected an error containing %q, got %v", test.policy, test.err, err)
+		}
+	}
+}
+
+func TestFetchPolicyChecksCache(t *testing.T) {
+	defer ClearCaches()
+	defer SetFetchPolicy(FetchPolicy{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "path: %s\n", r.URL.Path)
+	}))
+	defer server.Close()
+	ClearCaches()
+	if _, err := FetchFile(server.URL + "/a.yaml"); err != nil {
+		t.Fatalf("%s", err)
+	}
+	SetFetchPolicy(FetchPolicy{AllowedHosts: []string{"example.com"}})
+	if _, err := FetchFile(server.URL + "/a.yaml"); err == nil {
+		t.Errorf("expected a cached file to be rejected by the policy")
+	}
+}
+
+func TestFetchPolicyForContext(t *testing.T) {
+	defer ClearCaches()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "path: %s\n", r.URL.Path)
+	}))
+	defer server.Close()
+	ClearCaches()
+	if _, err := FetchFile(server.URL + "/a.yaml"); err != nil {
+		t.Fatalf("%s", err)
+	}
+	// Files fetched with other policies aren't read from the cache.
+	ctx := WithFetchPolicy(context.Background(), FetchPolicy{BlockPrivateAddresses: true})
+	_, err := FetchFileContext(ctx, server.URL+"/a.yaml")
+	if err == nil || !strings.Contains(err.Error(), "private address 127.0.0.1") {
+		t.Errorf("expected the fetch to be rejected, got %v", err)
+	}
+	// The policy of the context replaces the policy of the process.
+	SetFetchPolicy(FetchPolicy{BlockAll: true})
+	defer SetFetchPolicy(FetchPolicy{})
+	ctx = WithFetchPolicy(context.Background(), FetchPolicy{AllowedHosts: []string{"127.0.0.1"}})
+	if _, err := FetchFileContext(ctx, server.URL+"/a.yaml"); err != nil {
+		t.Errorf("%s", err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := FetchFileContext(ctx, server.URL+"/b.yaml"); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestCheckReferencesFetchPolicy(t *testing.T) {
+	defer ClearCaches()
+	defer SetFetchPolicy(FetchPolicy{})
+	dir, err := ioutil.TempDir("", "policy")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "root.yaml")
+	err = ioutil.WriteFile(filename, []byte("a:\n  $ref: 'http://169.254.169.254/latest/meta-data#/x'\n"), 0644)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	ClearCaches()
+	SetFetchPolicy(FetchPolicy{AllowedHosts: []string{"example.com"}})
+	err = CheckReferences(filename)
+	if err == nil || !strings.Contains(err.Error(), `host "169.254.169.254" is not allowed`) {
+		t.Errorf("expected the reference to be rejected, got %v", err)
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		private bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"172.32.0.1", false},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"8.8.8.8", false},
+		{"::1", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"2001:4860:4860::8888", false},
+	} {
+		if got := isPrivateIP(net.ParseIP(test.address)); got != test.private {
+			t.Errorf("isPrivateIP(%s) = %t, want %t", test.address, got, test.private)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// PositionTable records the positions of the nodes that messages were built
+// from. Messages don't have fields for their positions, so the generated
+// compilers record them in a table that is kept alongside the messages.
+type PositionTable struct {
+	// Messages can be built concurrently, see SetParallelism.
+	mutex     sync.Mutex
+	positions map[proto.Message]Location
+}
+
+// NewPositionTable returns an empty position table.
+func NewPositionTable() *PositionTable {
+	return &PositionTable{positions: make(map[proto.Message]Location)}
+}
+
+// Position returns the position of the node that a message was built from.
+func (t *PositionTable) Position(message proto.Message) (Location, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	location, ok := t.positions[message]
+	return location, ok
+}
+
+// Len returns the number of messages in the table.
+func (t *PositionTable) Len() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return len(t.positions)
+}
+
+// Position tables are found from the root contexts of compilations, because
+// contexts are shared with other packages and can't refer to them.
+var positionTables = struct {
+	sync.RWMutex
+	tables map[*Context]*PositionTable
+}{tables: make(map[*Context]*PositionTable)}
+
+// TrackPositions records the positions of the messages that are built with a
+// root context, or with contexts below it, in a table. Tracking stops when the
+// returned function is called.
+func TrackPositions(context *Context, table *PositionTable) (stop func()) {
+	positionTables.Lock()
+	positionTables.tables[context] = table
+	positionTables.Unlock()
+	return func() {
+		positionTables.Lock()
+		delete(positionTables.tables, context)
+		positionTables.Unlock()
+	}
+}
+
+// RecordPosition records the position of the node that a message was built
+// from if positions are tracked for its context. It is called by the
+// generated compilers.
+func RecordPosition(context *Context, message proto.Message, node *yaml.Node) {
+	if context == nil || node == nil {
+		return
+	}
+	positionTables.RLock()
+	defer positionTables.RUnlock()
+	if len(positionTables.tables) == 0 {
+		return
+	}
+	for context.Parent != nil {
+		context = context.Parent
+	}
+	if table, ok := positionTables.tables[context]; ok {
+		table.mutex.Lock()
+		table.positions[message] = Location{Line: node.Line, Column: node.Column}
+		table.mutex.Unlock()
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestPositionTable(t *testing.T) {
+	root := NewContext("$root", nil, nil)
+	child := NewContext("child", nil, root)
+	node := &yaml.Node{Line: 3, Column: 5}
+
+	untracked := wrapperspb.String("untracked")
+	RecordPosition(child, untracked, node)
+
+	table := NewPositionTable()
+	stop := TrackPositions(root, table)
+	tracked := wrapperspb.String("tracked")
+	RecordPosition(child, tracked, node)
+	stop()
+	stopped := wrapperspb.String("stopped")
+	RecordPosition(child, stopped, node)
+
+	if location, ok := table.Position(tracked); !ok || location != (Location{Line: 3, Column: 5}) {
+		t.Errorf("unexpected position of tracked message: %+v %t", location, ok)
+	}
+	if _, ok := table.Position(untracked); ok {
+		t.Errorf("unexpected position of message built before tracking started")
+	}
+	if _, ok := table.Position(stopped); ok {
+		t.Errorf("unexpected position of message built after tracking stopped")
+	}
+	if table.Len() != 1 {
+		t.Errorf("table contains %d positions, expected 1", table.Len())
+	}
+}
@@ -15,47 +15,446 @@
 package compiler
 
 import (
-	"github.com/google/gnostic-models/compiler"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	models "github.com/google/gnostic-models/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
 )
 
+var verboseReader = false
+
+var fileCache map[string]cachedFile
+
+var fileCacheEnable = true
+var infoCacheEnable = true
+
+// The info cache is the cache of gnostic-models, which the ResolveReferences
+// methods of the generated models read references from. Its entries are
+// only read and written while infoCacheMutex is held, so the models must
+// resolve references with ResolveReferences (below) while other
+// goroutines read documents.
+
+// These locks are used to synchronize accesses to the fileCache map (above)
+// and the info cache. They are global state and can throw thread-related errors
+// when modified from separate goroutines. The general strategy is to protect
+// all public functions in this file with mutex Lock() calls. As a result, to
+// avoid deadlock, these public functions should not call other public
+// functions, so some public functions have private equivalents.
+// The exception is fetchFile, which only holds fileCacheMutex while it
+// uses the cache so that files can be downloaded concurrently.
+// In the future, we might consider replacing the maps with sync.Map and
+// eliminating these mutexes.
+var fileCacheMutex sync.Mutex
+var infoCacheMutex sync.Mutex
+
+// The SHA-256 digests of the files that have been read, keyed by file name
+// or URL. They record the sources of a compilation for provenance.
+var fileDigests map[string]string
+var fileDigestsMutex sync.Mutex
+
+// StandardInput is the name of the file that is read from standard input.
+// Standard input can only be read once, so its bytes are kept for later
+// reads and are not cleared with the caches.
+const StandardInput = "-"
+
+var stdinBytes []byte
+var stdinErr error
+var stdinOnce sync.Once
+
+func readStandardInput() ([]byte, error) {
+	stdinOnce.Do(func() {
+		stdinBytes, stdinErr = ioutil.ReadAll(os.Stdin)
+	})
+	return stdinBytes, stdinErr
+}
+
+// The ResolveReferences methods of the generated models fetch the files
+// that aren't in the info cache with the reader of gnostic-models, which
+// has its own file cache, so the functions below that configure or clear
+// the file cache also apply to that one.
+
+func initializeFileCache() {
+	if fileCache == nil {
+		fileCache = make(map[string]cachedFile, 0)
+	}
+}
+
 // EnableFileCache turns on file caching.
-var EnableFileCache = compiler.EnableFileCache
+func EnableFileCache() {
+	models.EnableFileCache()
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	fileCacheEnable = true
+}
 
 // EnableInfoCache turns on parsed info caching.
-var EnableInfoCache = compiler.EnableInfoCache
+func EnableInfoCache() {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	models.EnableInfoCache()
+	infoCacheEnable = true
+}
 
 // DisableFileCache turns off file caching.
-var DisableFileCache = compiler.DisableFileCache
+func DisableFileCache() {
+	models.DisableFileCache()
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	fileCacheEnable = false
+}
 
 // DisableInfoCache turns off parsed info caching.
-var DisableInfoCache = compiler.DisableInfoCache
+func DisableInfoCache() {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	models.DisableInfoCache()
+	infoCacheEnable = false
+}
 
 // RemoveFromFileCache removes an entry from the file cache.
-var RemoveFromFileCache = compiler.RemoveFromFileCache
+func RemoveFromFileCache(fileurl string) {
+	models.RemoveFromFileCache(fileurl)
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	if !fileCacheEnable {
+		return
+	}
+	initializeFileCache()
+	delete(fileCache, fileurl)
+}
 
 // RemoveFromInfoCache removes an entry from the info cache.
-var RemoveFromInfoCache = compiler.RemoveFromInfoCache
+func RemoveFromInfoCache(filename string) {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	models.RemoveFromInfoCache(filename)
+}
 
 // GetInfoCache returns the info cache map.
-var GetInfoCache = compiler.GetInfoCache
+var GetInfoCache = models.GetInfoCache
+
+// cachedInfo returns the entry of the info cache for a file or a reference.
+// Entries that the reader of gnostic-models added are expanded when they
+// are read, since that reader doesn't expand aliases.
+func cachedInfo(ctx context.Context, key string) (*yaml.Node, bool, error) {
+	cache := models.GetInfoCache()
+	info, ok := cache[key]
+	if !ok || info == nil {
+		return info, ok, nil
+	}
+	expanded, err := expandAliases(ctx, info, limitsFor(ctx).MaxNodes)
+	if err != nil {
+		return nil, true, err
+	}
+	if expanded != info {
+		cache[key] = expanded
+	}
+	return expanded, true, nil
+}
+
+// A ReferenceResolver is a document of a generated model, which can
+// resolve its references.
+type ReferenceResolver interface {
+	ResolveReferences(root string) (*yaml.Node, error)
+}
+
+// ResolveReferences calls the ResolveReferences method of a document.
+// Unlike calling the method directly, it is safe to call while other
+// goroutines check, prefetch, or resolve references.
+func ResolveReferences(document ReferenceResolver, root string) (*yaml.Node, error) {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	return document.ResolveReferences(root)
+}
 
 // ClearFileCache clears the file cache.
-var ClearFileCache = compiler.ClearFileCache
+func ClearFileCache() {
+	models.ClearFileCache()
+	fileCacheMutex.Lock()
+	defer fileCacheMutex.Unlock()
+	fileCache = make(map[string]cachedFile, 0)
+	fileDigestsMutex.Lock()
+	defer fileDigestsMutex.Unlock()
+	fileDigests = nil
+}
+
+// FileDigests returns the hex-encoded SHA-256 digests of the files that have
+// been read since the file cache was last cleared, keyed by file name or URL.
+func FileDigests() map[string]string {
+	fileDigestsMutex.Lock()
+	defer fileDigestsMutex.Unlock()
+	digests := make(map[string]string, len(fileDigests))
+	for name, digest := range fileDigests {
+		digests[name] = digest
+	}
+	return digests
+}
+
+// A digestRecorder collects the digests of the files read with a context.
+type digestRecorder struct {
+	mutex   sync.Mutex
+	digests map[string]string
+}
+
+type digestRecorderKey struct{}
+
+// WithFileDigests returns a copy of ctx that records the digests of the
+// files that are read with it, and a function that returns the digests
+// recorded so far in the form returned by FileDigests. Unlike FileDigests,
+// the digests only include the files read by a single compilation,
+// including files that were read from the caches.
+func WithFileDigests(ctx context.Context) (context.Context, func() map[string]string) {
+	r := &digestRecorder{digests: make(map[string]string)}
+	return context.WithValue(ctx, digestRecorderKey{}, r), func() map[string]string {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		digests := make(map[string]string, len(r.digests))
+		for name, digest := range r.digests {
+			digests[name] = digest
+		}
+		return digests
+	}
+}
+
+func (r *digestRecorder) record(filename, digest string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.digests[filename] = digest
+}
+
+func recordFileDigest(ctx context.Context, filename string, bytes []byte) {
+	sum := sha256.Sum256(bytes)
+	digest := hex.EncodeToString(sum[:])
+	fileDigestsMutex.Lock()
+	if fileDigests == nil {
+		fileDigests = make(map[string]string)
+	}
+	fileDigests[filename] = digest
+	fileDigestsMutex.Unlock()
+	if r, ok := ctx.Value(digestRecorderKey{}).(*digestRecorder); ok {
+		r.record(filename, digest)
+	}
+}
+
+// recordCachedFileDigest records the digest of a file whose contents were
+// read from a cache with the digest recorded when the file was read. It
+// returns false if the digest is needed but unknown, so that the file is
+// read again.
+func recordCachedFileDigest(ctx context.Context, filename string) bool {
+	r, ok := ctx.Value(digestRecorderKey{}).(*digestRecorder)
+	if !ok {
+		return true
+	}
+	fileDigestsMutex.Lock()
+	digest, ok := fileDigests[filename]
+	fileDigestsMutex.Unlock()
+	if ok {
+		r.record(filename, digest)
+	}
+	return ok
+}
 
 // ClearInfoCache clears the info cache.
-var ClearInfoCache = compiler.ClearInfoCache
+func ClearInfoCache() {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	models.ClearInfoCache()
+}
 
 // ClearCaches clears all caches.
-var ClearCaches = compiler.ClearCaches
+func ClearCaches() {
+	ClearFileCache()
+	ClearInfoCache()
+}
+
+// ReadBytesForFile reads the bytes of a file. The file named "-" is
+// standard input.
+func ReadBytesForFile(filename string) ([]byte, error) {
+	return readBytesForFile(context.Background(), filename)
+}
 
-// FetchFile gets a specified file from the local filesystem or a remote location.
-var FetchFile = compiler.FetchFile
+// ReadBytesForFileContext reads the bytes of a file with the limits and
+// fetch policy of a context. Remote files are fetched with the context.
+func ReadBytesForFileContext(ctx context.Context, filename string) ([]byte, error) {
+	return readBytesForFile(ctx, filename)
+}
 
-// ReadBytesForFile reads the bytes of a file.
-var ReadBytesForFile = compiler.ReadBytesForFile
+func readBytesForFile(ctx context.Context, filename string) ([]byte, error) {
+	if filename == StandardInput {
+		bytes, err := readStandardInput()
+		if err != nil {
+			return nil, err
+		}
+		if l := limitsFor(ctx); l.MaxDocumentBytes > 0 {
+			if err = checkDocumentBytes(filename, int64(len(bytes)), l); err != nil {
+				return nil, err
+			}
+		}
+		recordFileDigest(ctx, filename, bytes)
+		return bytes, nil
+	}
+	// is the filename a url?
+	fileurl, _ := url.Parse(filename)
+	if fileurl.Scheme != "" {
+		// yes, fetch it
+		bytes, err := fetchFile(ctx, filename)
+		if err != nil {
+			return nil, err
+		}
+		recordFileDigest(ctx, filename, bytes)
+		return bytes, nil
+	}
+	// no, it's a local filename
+	if l := limitsFor(ctx); l.MaxDocumentBytes > 0 {
+		fileInfo, err := os.Stat(filename)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkDocumentBytes(filename, fileInfo.Size(), l); err != nil {
+			return nil, err
+		}
+	}
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	recordFileDigest(ctx, filename, bytes)
+	return bytes, nil
+}
 
 // ReadInfoFromBytes unmarshals a file as a *yaml.Node.
-var ReadInfoFromBytes = compiler.ReadInfoFromBytes
+// Aliases and merge keys are expanded so that the returned tree
+// contains only document, mapping, sequence, and scalar nodes.
+// Documents that exceed the current limits are rejected, and so are
+// streams that contain more than one YAML document.
+func ReadInfoFromBytes(filename string, bytes []byte) (*yaml.Node, error) {
+	return ReadInfoFromBytesContext(context.Background(), filename, bytes)
+}
+
+// ReadInfoFromBytesContext unmarshals a file as a *yaml.Node with the
+// limits of a context.
+func ReadInfoFromBytesContext(ctx context.Context, filename string, bytes []byte) (*yaml.Node, error) {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	return readInfoFromBytes(ctx, filename, bytes)
+}
+
+func readInfoFromBytes(ctx context.Context, filename string, bytes []byte) (*yaml.Node, error) {
+	if infoCacheEnable {
+		info, ok, err := cachedInfo(ctx, filename)
+		if ok {
+			if verboseReader {
+				log.Printf("Cache hit info for file %s", filename)
+			}
+			return info, err
+		}
+		if verboseReader {
+			log.Printf("Reading info for file %s", filename)
+		}
+	}
+	l := limitsFor(ctx)
+	if err := checkDocumentBytes(filename, int64(len(bytes)), l); err != nil {
+		return nil, err
+	}
+	documents, err := readDocuments(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) > 1 {
+		return nil, multipleDocumentsError(filename, documents)
+	}
+	node := &yaml.Node{}
+	if len(documents) == 1 {
+		node = documents[0]
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	info, err := expandAliases(ctx, node, l.MaxNodes)
+	if err != nil {
+		return nil, err
+	}
+	if infoCacheEnable && len(filename) > 0 {
+		models.GetInfoCache()[filename] = info
+	}
+	return info, nil
+}
 
 // ReadInfoForRef reads a file and return the fragment needed to resolve a $ref.
-var ReadInfoForRef = compiler.ReadInfoForRef
+func ReadInfoForRef(basefile string, ref string) (*yaml.Node, error) {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	return readInfoForRef(context.Background(), basefile, ref)
+}
+
+// ReadInfoForRefContext reads the fragment needed to resolve a $ref with
+// the limits and fetch policy of a context.
+func ReadInfoForRefContext(ctx context.Context, basefile string, ref string) (*yaml.Node, error) {
+	infoCacheMutex.Lock()
+	defer infoCacheMutex.Unlock()
+	return readInfoForRef(ctx, basefile, ref)
+}
+
+func readInfoForRef(ctx context.Context, basefile string, ref string) (*yaml.Node, error) {
+	if infoCacheEnable {
+		info, ok, err := cachedInfo(ctx, ref)
+		if ok && recordCachedFileDigest(ctx, FilenameForRef(basefile, ref)) {
+			if verboseReader {
+				log.Printf("Cache hit for ref %s#%s", basefile, ref)
+			}
+			return info, err
+		}
+		if verboseReader {
+			log.Printf("Reading info for ref %s#%s", basefile, ref)
+		}
+	}
+	parts := strings.Split(ref, "#")
+	filename := FilenameForRef(basefile, ref)
+	bytes, err := readBytesForFile(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := readInfoFromBytes(ctx, filename, bytes)
+	if err != nil {
+		// Files that break the limits or can't be parsed are not cached,
+		// so that every reference to them reports the error.
+		return nil, err
+	}
+	if info != nil && info.Kind == yaml.DocumentNode {
+		info = info.Content[0]
+	}
+	if info == nil {
+		return nil, NewError(nil, fmt.Sprintf("could not resolve %s", ref))
+	}
+	if len(parts) > 1 {
+		tokens, err := jsonpointer.ParseFragment(parts[1])
+		if err == nil {
+			info, err = jsonpointer.ResolveTokens(info, tokens)
+		}
+		if err != nil {
+			if infoCacheEnable {
+				models.GetInfoCache()[ref] = nil
+			}
+			return nil, NewError(nil, fmt.Sprintf("could not resolve %s", ref))
+		}
+	}
+	if filename != basefile {
+		// References in the target are relative to the file that
+		// contains it, but they will be resolved from the base file.
+		info = rebaseReferences(info, filename, basefile)
+	}
+	if infoCacheEnable {
+		models.GetInfoCache()[ref] = info
+	}
+	return info, nil
+}
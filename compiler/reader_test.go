@@ -0,0 +1,283 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	models "github.com/google/gnostic-models/compiler"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestReadInfoFromBytesExpandsAliases(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "alias",
+			input: `
+a: &x {type: string}
+b: *x
+`,
+			expected: `
+a: {type: string}
+b: {type: string}
+`,
+		},
+		{
+			name: "merge",
+			input: `
+base: &base {type: object, description: base}
+derived:
+  <<: *base
+  description: derived
+`,
+			expected: `
+base: {type: object, description: base}
+derived:
+  description: derived
+  type: object
+`,
+		},
+		{
+			name: "merge sequence",
+			input: `
+one: &one {a: 1, b: 1}
+two: &two {b: 2, c: 2}
+both:
+  <<: [*one, *two]
+`,
+			expected: `
+one: {a: 1, b: 1}
+two: {b: 2, c: 2}
+both: {a: 1, b: 1, c: 2}
+`,
+		},
+		{
+			name:     "quoted merge key",
+			input:    `{"<<": x}`,
+			expected: `{"<<": x}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := ReadInfoFromBytes("", []byte(test.input))
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if _, found := countNodes(info); found {
+				t.Errorf("result contains aliases or merge keys")
+			}
+			var got, expected interface{}
+			if err := info.Decode(&got); err != nil {
+				t.Fatalf("%s", err)
+			}
+			if err := yaml.Unmarshal([]byte(test.expected), &expected); err != nil {
+				t.Fatalf("%s", err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("unexpected result: %+v (expected %+v)", got, expected)
+			}
+		})
+	}
+}
+
+func TestReadInfoFromBytesMergesKeysWithTags(t *testing.T) {
+	info, err := ReadInfoFromBytes("", []byte("base: &base {1: int, '1': str}\nderived: {<<: *base, '1': own}\n"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	derived := info.Content[0].Content[3]
+	var pairs []string
+	for i := 0; i+1 < len(derived.Content); i += 2 {
+		key, value := derived.Content[i], derived.Content[i+1]
+		pairs = append(pairs, key.ShortTag()+" "+key.Value+": "+value.Value)
+	}
+	expected := []string{"!!str 1: own", "!!int 1: int"}
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("unexpected result: %v (expected %v)", pairs, expected)
+	}
+}
+
+func TestReadInfoFromBytesRejectsBadAliases(t *testing.T) {
+	laughs := "a: &a [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]\n"
+	for i := 'b'; i <= 'i'; i++ {
+		p := string(i - 1)
+		laughs += string(i) + ": &" + string(i) + " [*" + strings.Repeat(p+", *", 9) + p + "]\n"
+	}
+	for _, test := range []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "cycle",
+			input: "a: &x {b: *x}",
+			err:   "refers to a node that contains it",
+		},
+		{
+			name:  "billion laughs",
+			input: laughs,
+			err:   "too large after expanding aliases",
+		},
+		{
+			name:  "bad merge",
+			input: "a: {<<: x}",
+			err:   "merge value must be a mapping",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadInfoFromBytes("", []byte(test.input))
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("unexpected error: %q (expected %q)", err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestReadInfoFromBytesWithMultipleDocuments(t *testing.T) {
+	_, err := ReadInfoFromBytes("", []byte("a: 1\n---\nb: 2\n---\n# empty\n---\nc: 3\n"))
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	expected := "document contains 3 YAML documents (starting at lines 1, 2, 6)"
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("unexpected error: %q (expected %q)", err.Error(), expected)
+	}
+	// Empty documents are ignored.
+	info, err := ReadInfoFromBytes("", []byte("---\na: 1\n---\n"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if info.Kind != yaml.DocumentNode || len(info.Content) != 1 || info.Content[0].Kind != yaml.MappingNode {
+		t.Errorf("unexpected result: %+v", info)
+	}
+}
+
+func TestReadInfoFromBytesSharesModelsCache(t *testing.T) {
+	defer ClearCaches()
+	ClearCaches()
+	// Files that the models read are expanded when they are read again.
+	text := []byte("a: &x {type: string}\nb: *x\n")
+	if _, err := models.ReadInfoFromBytes("models.yaml", text); err != nil {
+		t.Fatalf("%s", err)
+	}
+	info, err := ReadInfoFromBytes("models.yaml", nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, found := countNodes(info); found {
+		t.Errorf("result contains aliases")
+	}
+	// Files that are read here are read from the same cache by the models.
+	if cached, err := models.ReadInfoFromBytes("models.yaml", nil); err != nil || cached != info {
+		t.Errorf("expected the models to read the expanded file, got %+v, %v", cached, err)
+	}
+}
+
+func TestFileDigests(t *testing.T) {
+	ClearCaches()
+	filename := "../examples/v3.0/yaml/petstore.yaml"
+	bytes, err := ReadBytesForFile(filename)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sum := sha256.Sum256(bytes)
+	digests := FileDigests()
+	if len(digests) != 1 || digests[filename] != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected digests %v", digests)
+	}
+	ClearCaches()
+	if digests := FileDigests(); len(digests) != 0 {
+		t.Errorf("expected no digests after clearing the caches, got %v", digests)
+	}
+}
+
+func TestFileDigestsForContext(t *testing.T) {
+	defer ClearCaches()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	if err := ioutil.WriteFile(schemas, []byte("a: {type: string}\n"), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sum := sha256.Sum256([]byte("a: {type: string}\n"))
+	ClearCaches()
+	if _, err := ReadBytesForFile("../examples/v3.0/yaml/petstore.yaml"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Files read with other contexts aren't recorded, and files read
+	// from the caches are.
+	for i := 0; i < 2; i++ {
+		ctx, digests := WithFileDigests(context.Background())
+		if _, err := ReadInfoForRefContext(ctx, root, "schemas.yaml#/a"); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if d := digests(); len(d) != 1 || d[schemas] != hex.EncodeToString(sum[:]) {
+			t.Errorf("unexpected digests %v", d)
+		}
+	}
+	// Files are read again if their digests were cleared from the caches.
+	ClearFileCache()
+	ctx, digests := WithFileDigests(context.Background())
+	if _, err := ReadInfoForRefContext(ctx, root, "schemas.yaml#/a"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if d := digests(); len(d) != 1 {
+		t.Errorf("unexpected digests %v", d)
+	}
+}
+
+func TestReadStandardInput(t *testing.T) {
+	f, err := ioutil.TempFile("", "stdin")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString("openapi: 3.0.0\n"); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err = f.Seek(0, 0); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() {
+		os.Stdin = stdin
+		f.Close()
+	}()
+	// Standard input is read once and its bytes are returned again.
+	for i := 0; i < 2; i++ {
+		bytes, err := ReadBytesForFile(StandardInput)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if string(bytes) != "openapi: 3.0.0\n" {
+			t.Errorf("unexpected bytes %q", bytes)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// FilenameForRef returns the name of the file that contains the target of a $ref.
+// Relative references are resolved against the location of the base file,
+// which can be a local path or a URL.
+func FilenameForRef(basefile string, ref string) string {
+	parts := strings.Split(ref, "#")
+	if parts[0] == "" {
+		return basefile
+	}
+	if _, err := url.ParseRequestURI(parts[0]); err == nil {
+		// It is an URL or an absolute path.
+		return parts[0]
+	}
+	if isRemote(basefile) {
+		base, _ := url.Parse(basefile)
+		relative, err := url.Parse(parts[0])
+		if err == nil {
+			return base.ResolveReference(relative).String()
+		}
+	}
+	// It is not an URL, so the file is local
+	basedir, _ := filepath.Split(basefile)
+	return filepath.Join(basedir, parts[0])
+}
+
+// rebaseReferences returns a copy of a node that was read from a file
+// with its relative references rewritten to be relative to a base file.
+// Nodes that contain no references are returned unchanged.
+func rebaseReferences(node *yaml.Node, filename string, basefile string) *yaml.Node {
+	if !containsReferences(node) {
+		return node
+	}
+	result := *node
+	result.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 1 && node.Content[i-1].Value == "$ref" && child.Kind == yaml.ScalarNode {
+			rebased := *child
+			rebased.Value = rebaseRef(child.Value, filename, basefile)
+			result.Content[i] = &rebased
+			continue
+		}
+		result.Content[i] = rebaseReferences(child, filename, basefile)
+	}
+	return &result
+}
+
+func containsReferences(node *yaml.Node) bool {
+	if referenceValue(node) != nil {
+		return true
+	}
+	for _, child := range node.Content {
+		if containsReferences(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebaseRef rewrites a reference found in a file so that it refers to
+// the same target when it is resolved from a base file.
+func rebaseRef(ref string, filename string, basefile string) string {
+	fragment := ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		fragment = ref[i:]
+	}
+	target := FilenameForRef(filename, ref)
+	if target == basefile {
+		return fragment
+	}
+	if isRemote(target) || isRemote(basefile) {
+		// URLs can be resolved from anywhere.
+		return target + fragment
+	}
+	basedir, _ := filepath.Split(basefile)
+	relative, err := filepath.Rel(filepath.Join(basedir, "."), target)
+	if err != nil {
+		return target + fragment
+	}
+	return filepath.ToSlash(relative) + fragment
+}
+
+func isRemote(filename string) bool {
+	u, err := url.Parse(filename)
+	return err == nil && u.Scheme != ""
+}
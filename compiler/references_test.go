@@ -0,0 +1,136 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	models "github.com/google/gnostic-models/compiler"
+)
+
+func TestRebaseRef(t *testing.T) {
+	for _, test := range []struct {
+		ref      string
+		filename string
+		basefile string
+		expected string
+	}{
+		{"b.yaml#/x", "spec/sub/a.yaml", "spec/openapi.yaml", "sub/b.yaml#/x"},
+		{"../common/c.yaml", "spec/sub/a.yaml", "spec/openapi.yaml", "common/c.yaml"},
+		{"#/y", "spec/sub/a.yaml", "spec/openapi.yaml", "sub/a.yaml#/y"},
+		{"../openapi.yaml#/z", "spec/sub/a.yaml", "spec/openapi.yaml", "#/z"},
+		{"/abs/d.yaml#/w", "spec/sub/a.yaml", "spec/openapi.yaml", "/abs/d.yaml#/w"},
+		{"b.yaml#/x", "https://example.com/api/sub/a.yaml", "spec/openapi.yaml", "https://example.com/api/sub/b.yaml#/x"},
+		{"../b.yaml", "https://example.com/api/sub/a.yaml", "https://example.com/api/openapi.yaml", "https://example.com/api/b.yaml"},
+	} {
+		if got := rebaseRef(test.ref, test.filename, test.basefile); got != test.expected {
+			t.Errorf("rebaseRef(%q, %q, %q) = %q (expected %q)", test.ref, test.filename, test.basefile, got, test.expected)
+		}
+	}
+}
+
+func TestNestedRelativeReferences(t *testing.T) {
+	defer ClearCaches()
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"openapi.yaml": `
+pet: {$ref: "schemas/pets/pet.yaml#/Pet"}
+`,
+		"schemas/pets/pet.yaml": `
+Pet: {$ref: "../common.yaml#/Named"}
+`,
+		"schemas/common.yaml": `
+Named:
+  type: object
+  properties:
+    name: {$ref: "#/Name"}
+    tags: {$ref: "tags/tags.yaml"}
+Name: {type: string}
+`,
+		"schemas/tags/tags.yaml": `
+type: array
+`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("%s", err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+	ClearCaches()
+	root := filepath.Join(dir, "openapi.yaml")
+	if err := PrefetchReferences(root); err != nil {
+		t.Fatalf("%s", err)
+	}
+	// Follow the chain of references from the root document, as the
+	// ResolveReferences methods of the generated models do.
+	pet, err := models.ReadInfoForRef(root, "schemas/pets/pet.yaml#/Pet")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	ref := referenceValue(pet)
+	if ref == nil || ref.Value != "schemas/common.yaml#/Named" {
+		t.Fatalf("unexpected target: %+v", pet)
+	}
+	named, err := models.ReadInfoForRef(root, ref.Value)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	properties := named.Content[3]
+	for i, expected := range []string{"schemas/common.yaml#/Name", "schemas/tags/tags.yaml"} {
+		ref := referenceValue(properties.Content[2*i+1])
+		if ref == nil || ref.Value != expected {
+			t.Fatalf("unexpected reference: %+v (expected %s)", ref, expected)
+		}
+		target, err := models.ReadInfoForRef(root, ref.Value)
+		if err != nil || target == nil {
+			t.Fatalf("could not resolve %s: %v", ref.Value, err)
+		}
+	}
+}
+
+func TestReadInfoForRefWithEscapedPointer(t *testing.T) {
+	defer ClearCaches()
+	filename := filepath.Join(t.TempDir(), "openapi.yaml")
+	text := `
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path}
+      - {name: a~b, in: query}
+`
+	if err := os.WriteFile(filename, []byte(text), 0644); err != nil {
+		t.Fatalf("%s", err)
+	}
+	ClearCaches()
+	for ref, expected := range map[string]string{
+		"#/paths/~1pets~1%7Bid%7D/parameters/0/name": "id",
+		"#/paths/~1pets~1{id}/parameters/1/name":     "a~b",
+	} {
+		info, err := ReadInfoForRef(filename, ref)
+		if err != nil {
+			t.Errorf("%s: %s", ref, err)
+		} else if info.Value != expected {
+			t.Errorf("%s: unexpected value %q (expected %q)", ref, info.Value, expected)
+		}
+	}
+	if _, err := ReadInfoForRef(filename, "#/paths/~1pets~1{id}/parameters/2"); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,1089 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"testing"
+
+	"github.com/google/gnostic/compiler"
+	discovery "github.com/google/gnostic/discovery"
+	openapi2 "github.com/google/gnostic/openapiv2"
+	openapi3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const parametersDocument = `{
+  "kind": "discovery#restDescription",
+  "discoveryVersion": "v1",
+  "name": "books",
+  "version": "v1",
+  "rootUrl": "https://books.example.com/",
+  "servicePath": "books/v1/",
+  "basePath": "/books/v1/",
+  "methods": {
+    "list": {
+      "id": "books.list",
+      "path": "books",
+      "httpMethod": "GET",
+      "parameters": {
+        "id": {
+          "type": "string",
+          "location": "query",
+          "repeated": true
+        },
+        "orderBy": {
+          "type": "string",
+          "location": "query",
+          "enum": ["newest", "relevance"]
+        },
+        "projection": {
+          "type": "string",
+          "location": "query",
+          "repeated": true,
+          "enum": ["full", "lite"]
+        }
+      }
+    }
+  }
+}`
+
+// parameters returns the parameters of the operation of a converted document as YAML.
+func parameters(t *testing.T, document interface{ YAMLValue(string) ([]byte, error) }) string {
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(bytes, &root); err != nil {
+		t.Fatalf("%s", err)
+	}
+	var d struct {
+		Paths map[string]map[string]struct {
+			Parameters yaml.Node `yaml:"parameters"`
+		} `yaml:"paths"`
+	}
+	if err := root.Decode(&d); err != nil {
+		t.Fatalf("%s", err)
+	}
+	params := d.Paths["/books"]["get"].Parameters
+	bytes, err = yaml.Marshal(&params)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return string(bytes)
+}
+
+// format formats YAML in the style of the converted parameters.
+func format(t *testing.T, text string) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := yaml.Marshal(&node)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return string(bytes)
+}
+
+func TestParametersV2(t *testing.T) {
+	api, err := discovery.ParseDocument([]byte(parametersDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	d, err := OpenAPIv2(api)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := `- in: query
+  name: id
+  type: array
+  items:
+    type: string
+  collectionFormat: multi
+- in: query
+  name: orderBy
+  type: string
+  enum:
+    - newest
+    - relevance
+- in: query
+  name: projection
+  type: array
+  items:
+    type: string
+    enum:
+      - full
+      - lite
+  collectionFormat: multi
+`
+	if actual := parameters(t, d); actual != format(t, expected) {
+		t.Errorf("unexpected parameters:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestParametersV3(t *testing.T) {
+	api, err := discovery.ParseDocument([]byte(parametersDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	d, err := OpenAPIv3(api)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := `- name: id
+  in: query
+  style: form
+  explode: true
+  schema:
+    type: array
+    items:
+      type: string
+- name: orderBy
+  in: query
+  schema:
+    enum:
+      - newest
+      - relevance
+    type: string
+- name: projection
+  in: query
+  style: form
+  explode: true
+  schema:
+    type: array
+    items:
+      enum:
+        - full
+        - lite
+      type: string
+`
+	if actual := parameters(t, d); actual != format(t, expected) {
+		t.Errorf("unexpected parameters:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+const methodDocument = `{
+  "kind": "discovery#restDescription",
+  "discoveryVersion": "v1",
+  "name": "books",
+  "version": "v1",
+  "rootUrl": "https://books.example.com/",
+  "basePath": "/books/v1/",
+  "schemas": {
+    "Book": {"id": "Book", "type": "object"}
+  },
+  "methods": {
+    "update": {
+      "id": "books.update",
+      "path": "shelves/{shelf}/books/{book}",
+      "httpMethod": "PUT",
+      "etagRequired": true,
+      "parameterOrder": ["shelf", "book"],
+      "parameters": {
+        "mode": {
+          "type": "string",
+          "location": "query",
+          "description": "The update mode.",
+          "enum": ["merge", "replace"],
+          "enumDescriptions": ["Merges the fields.", "Replaces the book."]
+        },
+        "book": {"type": "string", "location": "path", "required": true},
+        "shelf": {"type": "string", "location": "path", "required": true}
+      },
+      "request": {"$ref": "Book"},
+      "response": {"$ref": "Book"}
+    }
+  }
+}`
+
+func TestMethodV3(t *testing.T) {
+	api, err := discovery.ParseDocument([]byte(methodDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	d, err := OpenAPIv3(api)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := d.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := `openapi: "3.0"
+info:
+  title: ""
+  version: v1
+servers:
+  - url: https://books.example.com/books/v1/
+paths:
+  /shelves/{shelf}/books/{book}:
+    put:
+      operationId: books.update
+      parameters:
+        - name: shelf
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: book
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: mode
+          in: query
+          description: |-
+            The update mode.
+
+            Values:
+            - ` + "`merge`" + `: Merges the fields.
+            - ` + "`replace`" + `: Replaces the book.
+          schema:
+            enum:
+              - merge
+              - replace
+            type: string
+        - name: If-Match
+          in: header
+          description: The etag of the resource.
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Book'
+      responses:
+        default:
+          description: Successful operation
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Book'
+components:
+  schemas:
+    Book:
+      type: object
+`
+	if actual := string(bytes); actual != format(t, expected) {
+		t.Errorf("unexpected document:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+const formsDocumentV2 = `
+swagger: "2.0"
+info:
+  title: Uploads
+  version: 1.0.0
+host: uploads.example.com
+basePath: /v1
+schemes: [https, http]
+consumes: [application/json]
+parameters:
+  Upload:
+    name: body
+    in: body
+    required: true
+    schema:
+      $ref: '#/definitions/Upload'
+paths:
+  /uploads:
+    get:
+      parameters:
+        - name: ids
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: multi
+      responses:
+        "200":
+          description: Uploads
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Upload'
+    post:
+      parameters:
+        - $ref: '#/parameters/Upload'
+      responses:
+        default:
+          description: Error
+  /files:
+    post:
+      parameters:
+        - name: file
+          in: formData
+          type: file
+          required: true
+        - name: note
+          in: formData
+          type: string
+      responses:
+        "204":
+          description: Uploaded
+definitions:
+  Upload:
+    type: object
+    properties:
+      name:
+        type: string
+      size:
+        type: integer
+        x-nullable: true
+securityDefinitions:
+  oauth:
+    type: oauth2
+    flow: accessCode
+    authorizationUrl: https://example.com/authorize
+    tokenUrl: https://example.com/token
+    scopes:
+      read: Read uploads
+`
+
+func TestOpenAPIv3FromOpenAPIv2(t *testing.T) {
+	v2, err := openapi2.ParseDocument([]byte(formsDocumentV2))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	v3, err := OpenAPIv3FromOpenAPIv2(v2)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The converted document is valid.
+	bytes, err := v3.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, err = openapi3.ParseDocument(bytes); err != nil {
+		t.Fatalf("%s\n%s", err, bytes)
+	}
+	if len(v3.Servers) != 2 || v3.Servers[0].Url != "https://uploads.example.com/v1" {
+		t.Errorf("unexpected servers %+v", v3.Servers)
+	}
+	uploads := v3.Paths.Path[0].Value
+	ids := uploads.Get.Parameters[0].GetParameter()
+	if ids.Style != "form" || !ids.Explode || ids.Schema.GetSchema().Items == nil {
+		t.Errorf("unexpected parameter %+v", ids)
+	}
+	content := uploads.Get.Responses.ResponseOrReference[0].Value.GetResponse().Content.AdditionalProperties[0]
+	if content.Name != "application/json" ||
+		content.Value.Schema.GetSchema().Items.SchemaOrReference[0].GetReference().XRef != "#/components/schemas/Upload" {
+		t.Errorf("unexpected response content %+v", content)
+	}
+	// References to body parameters become references to request bodies.
+	if ref := uploads.Post.RequestBody.GetReference(); ref == nil || ref.XRef != "#/components/requestBodies/Upload" {
+		t.Errorf("unexpected request body %+v", uploads.Post.RequestBody)
+	}
+	if body := v3.Components.RequestBodies.AdditionalProperties[0].Value.GetRequestBody(); !body.Required ||
+		body.Content.AdditionalProperties[0].Name != "application/json" {
+		t.Errorf("unexpected request body component %+v", body)
+	}
+	// Form data parameters become multipart forms if they include files.
+	files := v3.Paths.Path[1].Value.Post.RequestBody.GetRequestBody()
+	form := files.Content.AdditionalProperties[0]
+	if form.Name != "multipart/form-data" || len(form.Value.Schema.GetSchema().Properties.AdditionalProperties) != 2 ||
+		form.Value.Schema.GetSchema().Required[0] != "file" {
+		t.Errorf("unexpected form %+v", form)
+	}
+	if file := form.Value.Schema.GetSchema().Properties.AdditionalProperties[0].Value.GetSchema(); file.Type != "string" || file.Format != "binary" {
+		t.Errorf("unexpected file property %+v", file)
+	}
+	upload := v3.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	if size := upload.Properties.AdditionalProperties[1].Value.GetSchema(); !size.Nullable || len(size.SpecificationExtension) != 0 {
+		t.Errorf("unexpected nullable property %+v", size)
+	}
+	oauth := v3.Components.SecuritySchemes.AdditionalProperties[0].Value.GetSecurityScheme()
+	if oauth.Flows.AuthorizationCode == nil || oauth.Flows.AuthorizationCode.TokenUrl != "https://example.com/token" {
+		t.Errorf("unexpected security scheme %+v", oauth)
+	}
+}
+
+const formsDocumentV3 = `
+openapi: 3.0.0
+info:
+  title: Uploads
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: us
+  - url: http://us.example.com/v1
+  - url: https://staging.example.com/v1
+paths:
+  /uploads:
+    post:
+      parameters:
+        - name: session
+          in: cookie
+          schema:
+            type: string
+        - name: tags
+          in: query
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Upload'
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Upload'
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Upload'
+  /files:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/File'
+      responses:
+        "204":
+          description: Uploaded
+components:
+  schemas:
+    Upload:
+      type: object
+      nullable: true
+      properties:
+        name:
+          type: string
+    File:
+      type: object
+      required: [file]
+      properties:
+        file:
+          type: string
+          format: binary
+  securitySchemes:
+    basic:
+      type: http
+      scheme: basic
+    bearer:
+      type: http
+      scheme: bearer
+`
+
+func TestOpenAPIv2FromOpenAPIv3(t *testing.T) {
+	v3, err := openapi3.ParseDocument([]byte(formsDocumentV3))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	v2, err := OpenAPIv2FromOpenAPIv3(v3)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The converted document is valid.
+	bytes, err := v2.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, err = openapi2.ParseDocument(bytes); err != nil {
+		t.Fatalf("%s\n%s", err, bytes)
+	}
+	if v2.Host != "us.example.com" || v2.BasePath != "/v1" || len(v2.Schemes) != 2 {
+		t.Errorf("unexpected host %s, base path %s, and schemes %+v", v2.Host, v2.BasePath, v2.Schemes)
+	}
+	uploads := v2.Paths.Path[0].Value.Post
+	// Cookie parameters are dropped and request bodies become body parameters.
+	if len(uploads.Parameters) != 2 {
+		t.Fatalf("unexpected parameters %+v", uploads.Parameters)
+	}
+	if tags := uploads.Parameters[0].GetParameter().GetNonBodyParameter().GetQueryParameterSubSchema(); tags.CollectionFormat != "pipes" {
+		t.Errorf("unexpected parameter %+v", tags)
+	}
+	if body := uploads.Parameters[1].GetParameter().GetBodyParameter(); !body.Required || body.Schema.XRef != "#/definitions/Upload" {
+		t.Errorf("unexpected body parameter %+v", body)
+	}
+	if len(uploads.Consumes) != 1 || len(uploads.Produces) != 2 {
+		t.Errorf("unexpected media types %+v %+v", uploads.Consumes, uploads.Produces)
+	}
+	// Forms become form data parameters.
+	files := v2.Paths.Path[1].Value.Post
+	file := files.Parameters[0].GetParameter().GetNonBodyParameter().GetFormDataParameterSubSchema()
+	if file == nil || file.Type != "file" || !file.Required || files.Consumes[0] != "multipart/form-data" {
+		t.Errorf("unexpected form parameters %+v", files.Parameters)
+	}
+	upload := v2.Definitions.AdditionalProperties[0].Value
+	if len(upload.VendorExtension) != 1 || upload.VendorExtension[0].Name != "x-nullable" {
+		t.Errorf("unexpected schema %+v", upload)
+	}
+	// Bearer authentication can't be described in OpenAPI v2.
+	if definitions := v2.SecurityDefinitions.AdditionalProperties; len(definitions) != 1 || definitions[0].Name != "basic" {
+		t.Errorf("unexpected security definitions %+v", definitions)
+	}
+}
+
+const postmanDocument = `{
+  "info": {
+    "name": "Pets",
+    "description": "A collection of pet requests.",
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "variable": [
+    {"key": "baseUrl", "value": "https://pets.example.com/v1", "description": "The API server."}
+  ],
+  "item": [
+    {
+      "name": "Pets",
+      "description": "Requests for pets.",
+      "item": [
+        {
+          "name": "List pets",
+          "request": {
+            "method": "GET",
+            "header": [{"key": "X-Request-ID", "value": "{{$guid}}"}],
+            "url": {
+              "raw": "{{baseUrl}}/pets?limit=10",
+              "host": ["{{baseUrl}}"],
+              "path": ["pets"],
+              "query": [{"key": "limit", "value": "10", "description": "The number of pets."}]
+            }
+          },
+          "response": [
+            {
+              "name": "Pets",
+              "status": "OK",
+              "code": 200,
+              "header": [{"key": "Content-Type", "value": "application/json; charset=utf-8"}],
+              "body": "[{\"id\": 1, \"name\": \"Rex\", \"tag\": null}]"
+            }
+          ]
+        },
+        {
+          "name": "Get pet",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "{{baseUrl}}/pets/:petId",
+              "host": ["{{baseUrl}}"],
+              "path": ["pets", ":petId"],
+              "variable": [{"key": "petId", "value": "1", "description": "The pet's ID."}]
+            }
+          }
+        },
+        {
+          "name": "Get missing pet",
+          "request": {"method": "GET", "url": "{{baseUrl}}/pets/:petId"},
+          "response": [{"name": "Not found", "code": 404, "body": "not found"}]
+        }
+      ]
+    },
+    {
+      "name": "Create pet",
+      "auth": {"type": "noauth"},
+      "request": {
+        "method": "POST",
+        "url": "https://admin.example.com/pets",
+        "body": {
+          "mode": "raw",
+          "raw": "{\"name\": \"Rex\", \"age\": 2.5}",
+          "options": {"raw": {"language": "json"}}
+        }
+      }
+    }
+  ]
+}`
+
+func TestOpenAPIv3FromPostman(t *testing.T) {
+	v3, err := OpenAPIv3FromPostman([]byte(postmanDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := v3.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The converted document is valid.
+	if _, err = openapi3.ParseDocument(bytes); err != nil {
+		t.Fatalf("%s\n%s", err, bytes)
+	}
+	var expected yaml.Node
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.3
+info:
+  title: Pets
+  description: A collection of pet requests.
+  version: 1.0.0
+servers:
+  - url: '{baseUrl}'
+    variables:
+      baseUrl:
+        default: https://pets.example.com/v1
+        description: The API server.
+paths:
+  /pets:
+    get:
+      tags: [Pets]
+      summary: List pets
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          description: The number of pets.
+          schema: {type: string}
+          example: "10"
+        - name: X-Request-ID
+          in: header
+          schema: {type: string}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    id: {type: integer}
+                    name: {type: string}
+                    tag: {nullable: true}
+              example:
+                - id: 1
+                  name: Rex
+                  tag: null
+    post:
+      summary: Create pet
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                age: {type: number}
+            example:
+              name: Rex
+              age: 2.5
+      responses:
+        default:
+          description: Default response
+      security:
+        - {}
+      servers:
+        - url: https://admin.example.com
+  /pets/{petId}:
+    get:
+      tags: [Pets]
+      summary: Get pet
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          description: The pet's ID.
+          required: true
+          schema: {type: string}
+          example: "1"
+      responses:
+        "404":
+          description: Not found
+          content:
+            text/plain:
+              schema: {type: string}
+              example: not found
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+security:
+  - bearerAuth: []
+tags:
+  - name: Pets
+    description: Requests for pets.
+`), &expected); err != nil {
+		t.Fatalf("%s", err)
+	}
+	expectedBytes := compiler.Marshal(expected.Content[0])
+	actual := compiler.Marshal(v3.ToRawInfo())
+	if string(actual) != string(expectedBytes) {
+		t.Errorf("unexpected document:\n%s\nexpected:\n%s", actual, expectedBytes)
+	}
+}
+
+const ramlDocument = `#%RAML 1.0
+title: Pets
+description: A sample API for pets.
+version: v1
+baseUri: https://{region}.example.com/{version}
+baseUriParameters:
+  region:
+    enum: [us, eu]
+securitySchemes:
+  apiKey:
+    type: Pass Through
+    describedBy:
+      headers:
+        X-API-Key:
+securedBy: [apiKey]
+types:
+  Pet:
+    type: object
+    properties:
+      id: integer
+      name:
+        type: string
+        example: Rex
+      tag?: string | nil
+  Pets: Pet[]
+traits:
+  paged:
+    queryParameters:
+      limit?:
+        type: integer
+        description: The number of pets.
+/pets:
+  get:
+    displayName: List pets
+    is: [paged]
+    responses:
+      200:
+        body: Pets
+  post:
+    securedBy: [null]
+    body:
+      application/json:
+        type: Pet
+        example: {name: Rex}
+  /{petId}:
+    uriParameters:
+      petId:
+        type: integer
+        description: The pet's ID.
+    get:
+      displayName: Get pet
+      responses:
+        404:
+          description: Not found
+`
+
+func TestOpenAPIv3FromRAML(t *testing.T) {
+	v3, err := OpenAPIv3FromRAML([]byte(ramlDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := v3.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The converted document is valid.
+	if _, err = openapi3.ParseDocument(bytes); err != nil {
+		t.Fatalf("%s\n%s", err, bytes)
+	}
+	var expected yaml.Node
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.3
+info:
+  title: Pets
+  description: A sample API for pets.
+  version: v1
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        enum: [us, eu]
+        default: us
+paths:
+  /pets:
+    get:
+      summary: List pets
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          description: The number of pets.
+          schema: {type: integer}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pets'}
+    post:
+      operationId: postPets
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+            example: {name: Rex}
+      responses:
+        default:
+          description: Default response
+      security:
+        - {}
+  /pets/{petId}:
+    get:
+      summary: Get pet
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          description: The pet's ID.
+          required: true
+          schema: {type: integer}
+      responses:
+        "404":
+          description: Not found
+components:
+  schemas:
+    Pet:
+      required: [id, name]
+      type: object
+      properties:
+        id: {type: integer}
+        name: {example: Rex, type: string}
+        tag: {nullable: true, type: string}
+    Pets:
+      type: array
+      items: {$ref: '#/components/schemas/Pet'}
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+security:
+  - apiKey: []
+`), &expected); err != nil {
+		t.Fatalf("%s", err)
+	}
+	expectedBytes := compiler.Marshal(expected.Content[0])
+	actual := compiler.Marshal(v3.ToRawInfo())
+	if string(actual) != string(expectedBytes) {
+		t.Errorf("unexpected document:\n%s\nexpected:\n%s", actual, expectedBytes)
+	}
+}
+
+const blueprintDocument = `FORMAT: 1A
+HOST: https://pets.example.com/v1
+
+# Pets
+
+A sample API for pets.
+
+# Group Pets
+
+Requests for pets.
+
+## Pets [/pets{?limit}]
+
++ Parameters
+    + limit: 10 (number, optional) - The number of pets.
+
+### List pets [GET]
+
++ Response 200 (application/json)
+
+    + Headers
+
+            X-Total-Count: 1
+
+    + Body
+
+            [{"id": 1, "name": "Rex"}]
+
+### Create pet [POST]
+
++ Request (application/json)
+
+    + Attributes (Pet)
+
+    + Body
+
+            {"name": "Rex"}
+
++ Response 201
+
+## Pet [/pets/{petId}]
+
++ Parameters
+    + petId: 1 (number) - The pet's ID.
+
+### Get pet [GET]
+
++ Response 200 (application/json)
+
+    + Attributes (Pet)
+
++ Response 404 (text/plain)
+
+        not found
+
+# Data Structures
+
+## Pet (object)
+
++ id: 1 (number, required)
++ name: Rex (string, required)
++ status (enum[string])
+    + Members
+        + available
+        + sold
+`
+
+func TestOpenAPIv3FromBlueprint(t *testing.T) {
+	v3, err := OpenAPIv3FromBlueprint([]byte(blueprintDocument))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := v3.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The converted document is valid.
+	if _, err = openapi3.ParseDocument(bytes); err != nil {
+		t.Fatalf("%s\n%s", err, bytes)
+	}
+	var expected yaml.Node
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.3
+info:
+  title: Pets
+  description: A sample API for pets.
+  version: 1.0.0
+servers:
+  - url: https://pets.example.com/v1
+paths:
+  /pets:
+    summary: Pets
+    get:
+      tags: [Pets]
+      summary: List pets
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          description: The number of pets.
+          schema: {type: number}
+          example: 10
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Total-Count:
+              schema: {type: string}
+              example: "1"
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    id: {type: integer}
+                    name: {type: string}
+              example:
+                - id: 1
+                  name: Rex
+    post:
+      tags: [Pets]
+      summary: Create pet
+      operationId: createPet
+      parameters:
+        - name: limit
+          in: query
+          description: The number of pets.
+          schema: {type: number}
+          example: 10
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+            example: {name: Rex}
+      responses:
+        "201":
+          description: Created
+  /pets/{petId}:
+    summary: Pet
+    get:
+      tags: [Pets]
+      summary: Get pet
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          description: The pet's ID.
+          required: true
+          schema: {type: number}
+          example: 1
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+        "404":
+          description: Not Found
+          content:
+            text/plain:
+              schema: {type: string}
+              example: not found
+components:
+  schemas:
+    Pet:
+      required: [id, name]
+      type: object
+      properties:
+        id: {example: 1, type: number}
+        name: {example: Rex, type: string}
+        status:
+          enum: [available, sold]
+          type: string
+tags:
+  - name: Pets
+    description: Requests for pets.
+`), &expected); err != nil {
+		t.Fatalf("%s", err)
+	}
+	expectedBytes := compiler.Marshal(expected.Content[0])
+	actual := compiler.Marshal(v3.ToRawInfo())
+	if string(actual) != string(expectedBytes) {
+		t.Errorf("unexpected document:\n%s\nexpected:\n%s", actual, expectedBytes)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	for _, test := range []struct {
+		name, data, format string
+	}{
+		{"pets.raml", ramlDocument, RAMLFormat},
+		{"api.txt", ramlDocument, RAMLFormat},
+		{"pets.apib", blueprintDocument, BlueprintFormat},
+		{"pets.md", "# Pets\n", ""},
+		{"pets.apib", "# Pets\n", BlueprintFormat},
+		{"pets.postman_collection.json", postmanDocument, PostmanFormat},
+		{"openapi.json", `{"openapi": "3.0.0"}`, ""},
+	} {
+		if format := DetectFormat(test.name, []byte(test.data)); format != test.format {
+			t.Errorf("unexpected format %q of %s, expected %q", format, test.name, test.format)
+		}
+	}
+}
@@ -0,0 +1,711 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// A blueprintSection is a heading of an API Blueprint and the text under it.
+type blueprintSection struct {
+	level       int
+	heading     string
+	description []string
+	items       []*blueprintItem
+}
+
+// A blueprintItem is an item of a list, with its nested items and the other
+// lines that are indented under it.
+type blueprintItem struct {
+	text   string
+	indent int
+	items  []*blueprintItem
+	lines  []string
+}
+
+// Returns the first word of the text of an item, which names its kind.
+func (item *blueprintItem) keyword() string {
+	fields := strings.Fields(item.text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(fields[0], ":")
+}
+
+// Returns the nested item with a keyword, or nil if there is none.
+func (item *blueprintItem) item(keyword string) *blueprintItem {
+	for _, nested := range item.items {
+		if nested.keyword() == keyword {
+			return nested
+		}
+	}
+	return nil
+}
+
+// A blueprintMember is a declaration of a parameter or of a member of a
+// data structure, like "id: 1 (number, required) - The ID of the pet".
+type blueprintMember struct {
+	name, example, description string
+	attributes                 []string
+}
+
+// Returns the type of a member, which is the attribute that isn't a type
+// attribute like "required".
+func (m blueprintMember) typeName() string {
+	for _, attribute := range m.attributes {
+		switch attribute {
+		case "required", "optional", "nullable", "fixed", "fixed-type", "sample", "default":
+		default:
+			return attribute
+		}
+	}
+	return ""
+}
+
+func (m blueprintMember) has(attribute string) bool {
+	for _, a := range m.attributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// blueprintMetadataPattern matches metadata like "FORMAT: 1A".
+	blueprintMetadataPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):\s*(.*)$`)
+	// blueprintItemPattern matches the items of lists.
+	blueprintItemPattern = regexp.MustCompile(`^( *)[+*-]\s+(.*)$`)
+	// blueprintActionPattern matches the headings of actions, like
+	// "Create a Pet [POST]" or "Create a Pet [POST /pets]".
+	blueprintActionPattern = regexp.MustCompile(`^(.*?)\s*\[\s*(GET|PUT|POST|DELETE|OPTIONS|HEAD|PATCH|TRACE)\s*(\S*)\s*\]$`)
+	// blueprintResourcePattern matches the headings of resources, like
+	// "Pets [/pets]".
+	blueprintResourcePattern = regexp.MustCompile(`^(.*?)\s*\[\s*(/\S*)\s*\]$`)
+	// blueprintEndpointPattern matches headings that are a resource with a
+	// single action, like "GET /pets", or a resource with only a URI.
+	blueprintEndpointPattern = regexp.MustCompile(`^(?:(GET|PUT|POST|DELETE|OPTIONS|HEAD|PATCH|TRACE)\s+)?(/\S*)$`)
+	// blueprintExpressionPattern matches the expressions of URI templates.
+	blueprintExpressionPattern = regexp.MustCompile(`{([+#./;?&]?)([^{}]*)}`)
+)
+
+// Reads the metadata and sections of an API Blueprint. Fenced and indented
+// code blocks in items are kept as the lines of the items.
+func parseBlueprint(text string) (map[string]string, []*blueprintSection) {
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\t", "    ").Replace(text), "\n")
+	metadata := map[string]string{}
+	for len(lines) > 0 {
+		m := blueprintMetadataPattern.FindStringSubmatch(lines[0])
+		if m == nil {
+			break
+		}
+		metadata[m[1]] = strings.TrimSpace(m[2])
+		lines = lines[1:]
+	}
+	section := &blueprintSection{}
+	sections := []*blueprintSection{section}
+	var stack []*blueprintItem
+	fenced := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if fenced || trimmed == "" {
+			if len(stack) > 0 {
+				stack[len(stack)-1].lines = append(stack[len(stack)-1].lines, line)
+			} else {
+				section.description = append(section.description, line)
+			}
+			if strings.HasPrefix(trimmed, "```") {
+				fenced = false
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			heading := strings.TrimLeft(trimmed, "#")
+			section = &blueprintSection{
+				level:   len(trimmed) - len(heading),
+				heading: strings.TrimSpace(strings.TrimRight(heading, "#")),
+			}
+			sections = append(sections, section)
+			stack = nil
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = true
+		}
+		m := blueprintItemPattern.FindStringSubmatch(line)
+		switch {
+		case len(stack) > 0 && (fenced || m == nil || isBlueprintCode(stack[len(stack)-1])):
+			stack[len(stack)-1].lines = append(stack[len(stack)-1].lines, line)
+		case m != nil:
+			item := &blueprintItem{text: strings.TrimSpace(m[2]), indent: indent}
+			if len(stack) > 0 {
+				stack[len(stack)-1].items = append(stack[len(stack)-1].items, item)
+			} else {
+				section.items = append(section.items, item)
+			}
+			stack = append(stack, item)
+		default:
+			section.description = append(section.description, line)
+		}
+	}
+	return metadata, sections
+}
+
+// Returns true for items whose lines are code, like bodies and schemas.
+func isBlueprintCode(item *blueprintItem) bool {
+	switch item.keyword() {
+	case "Body", "Schema", "Headers":
+		return true
+	}
+	return false
+}
+
+// Returns the text of lines without fences and common indentation.
+func blueprintText(lines []string) string {
+	var kept []string
+	indent := -1
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			continue
+		}
+		if trimmed != "" {
+			if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+		kept = append(kept, line)
+	}
+	for i, line := range kept {
+		if len(line) >= indent && indent > 0 {
+			kept[i] = line[indent:]
+		}
+	}
+	return strings.Trim(strings.Join(kept, "\n"), "\n ")
+}
+
+// Reads a member declaration.
+func parseBlueprintMember(text string) blueprintMember {
+	var m blueprintMember
+	if i := strings.Index(text, " - "); i >= 0 {
+		text, m.description = text[:i], strings.TrimSpace(text[i+3:])
+	}
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, ")") {
+		if i := strings.LastIndex(text, "("); i >= 0 {
+			for _, attribute := range strings.Split(text[i+1:len(text)-1], ",") {
+				m.attributes = append(m.attributes, strings.TrimSpace(attribute))
+			}
+			text = strings.TrimSpace(text[:i])
+		}
+	}
+	m.name = text
+	if i := strings.Index(text, ":"); i >= 0 {
+		m.name, m.example = strings.TrimSpace(text[:i]), strings.Trim(strings.TrimSpace(text[i+1:]), "`")
+	}
+	m.name = strings.Trim(m.name, "`*_")
+	return m
+}
+
+// A blueprintConverter holds the state of a conversion from API Blueprint
+// to OpenAPI.
+type blueprintConverter struct {
+	importer
+	// tag is the name of the current group.
+	tag string
+	// resourceName, resourceURI, and resourceParameters describe the
+	// current resource.
+	resourceName       string
+	resourceURI        string
+	resourceParameters *blueprintItem
+}
+
+// OpenAPIv3FromBlueprint converts an API Blueprint to OpenAPI v3. Actions
+// become operations that are tagged with the names of their groups, data
+// structures become schemas, and requests and responses keep their
+// examples, with schemas from their attributes, from their JSON schemas, or
+// inferred from examples in JSON.
+func OpenAPIv3FromBlueprint(data []byte) (*openapi3.Document, error) {
+	metadata, sections := parseBlueprint(string(data))
+	c := &blueprintConverter{importer: newImporter(&openapi3.Info{})}
+	isBlueprint := strings.HasPrefix(metadata["FORMAT"], "1A")
+	if host := metadata["HOST"]; host != "" {
+		c.document.Servers = []*openapi3.Server{{Url: host}}
+	}
+	dataStructures := 0
+	for _, section := range sections {
+		heading := section.heading
+		description := blueprintText(section.description)
+		if dataStructures > 0 && section.level <= dataStructures {
+			dataStructures = 0
+		}
+		if m := blueprintEndpointPattern.FindStringSubmatch(heading); m != nil {
+			isBlueprint = true
+			c.resource("", m[2], section, description)
+			if m[1] != "" {
+				c.action("", m[1], "", section, description)
+			}
+			continue
+		}
+		if m := blueprintActionPattern.FindStringSubmatch(heading); m != nil {
+			isBlueprint = true
+			c.action(m[1], m[2], m[3], section, description)
+			continue
+		}
+		if m := blueprintResourcePattern.FindStringSubmatch(heading); m != nil {
+			isBlueprint = true
+			c.resource(m[1], m[2], section, description)
+			continue
+		}
+		switch {
+		case section.level == 0:
+		case strings.HasPrefix(heading, "Group "):
+			c.tag = strings.TrimSpace(strings.TrimPrefix(heading, "Group "))
+			c.resourceName, c.resourceURI, c.resourceParameters = "", "", nil
+			c.document.Tags = append(c.document.Tags, &openapi3.Tag{Name: c.tag, Description: description})
+		case heading == "Data Structures":
+			dataStructures = section.level
+		case dataStructures > 0:
+			m := parseBlueprintMember(heading)
+			c.addSchema(m.name, c.schema(m, section.items))
+		case c.document.Info.Title == "" && section.level == 1:
+			c.document.Info.Title = heading
+			c.document.Info.Description = description
+		}
+	}
+	if !isBlueprint {
+		return nil, errors.New("no API Blueprint")
+	}
+	if c.document.Info.Title == "" {
+		c.document.Info.Title = "API"
+	}
+	c.addDefaultResponses()
+	return c.document, nil
+}
+
+// Starts a resource. Resources with names and attributes declare schemas
+// named after them.
+func (c *blueprintConverter) resource(name, uri string, section *blueprintSection, description string) {
+	c.resourceName, c.resourceURI, c.resourceParameters = name, uri, nil
+	for _, item := range section.items {
+		switch item.keyword() {
+		case "Parameters":
+			c.resourceParameters = item
+		case "Attributes":
+			if name != "" {
+				c.addSchema(name, c.schema(parseBlueprintMember(item.text), item.items))
+			}
+		}
+	}
+	path, _ := blueprintPath(uri)
+	pathItem := c.pathItem(path)
+	if pathItem.Summary == "" {
+		pathItem.Summary = name
+	}
+	if pathItem.Description == "" {
+		pathItem.Description = description
+	}
+}
+
+// Adds an operation for an action. Actions without URIs have the URIs of
+// their resources.
+func (c *blueprintConverter) action(name, method, uri string, section *blueprintSection, description string) {
+	method = strings.ToLower(method)
+	if uri == "" {
+		uri = c.resourceURI
+	}
+	path, query := blueprintPath(uri)
+	op := c.operation(method, path)
+	if op == nil {
+		op = &openapi3.Operation{
+			Summary:     name,
+			Description: description,
+			OperationId: c.operationID(name, method, path),
+			Responses:   &openapi3.Responses{},
+		}
+		if c.tag != "" {
+			op.Tags = []string{c.tag}
+		}
+		c.addOperation(method, path, op)
+	}
+	// Parameters of actions take precedence over those of resources.
+	declarations := map[string]*blueprintItem{}
+	for _, parameters := range []*blueprintItem{c.resourceParameters, (&blueprintItem{items: section.items}).item("Parameters")} {
+		if parameters == nil {
+			continue
+		}
+		for _, item := range parameters.items {
+			declarations[parseBlueprintMember(item.text).name] = item
+		}
+	}
+	var parameters []*openapi3.Parameter
+	for _, m := range ramlParameterPattern.FindAllStringSubmatch(path, -1) {
+		parameters = append(parameters, c.parameter(m[1], "path", declarations[m[1]]))
+	}
+	for _, name := range query {
+		parameters = append(parameters, c.parameter(name, "query", declarations[name]))
+	}
+	for _, item := range section.items {
+		switch item.keyword() {
+		case "Request":
+			mediaType, headers, media := c.payload(item, strings.TrimSpace(strings.TrimPrefix(item.text, "Request")))
+			for _, header := range headers {
+				if !strings.EqualFold(header[0], "Content-Type") && !hasParameter(parameters, header[0], "header") {
+					parameters = append(parameters, &openapi3.Parameter{
+						Name:    header[0],
+						In:      "header",
+						Schema:  stringSchema(),
+						Example: &openapi3.Any{Yaml: yamlString(header[1])},
+					})
+				}
+			}
+			if media == nil {
+				continue
+			}
+			if op.RequestBody == nil {
+				op.RequestBody = &openapi3.RequestBodyOrReference{
+					Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: &openapi3.RequestBody{Content: &openapi3.MediaTypes{}}},
+				}
+			}
+			addBlueprintMediaType(op.RequestBody.GetRequestBody().Content, mediaType, media)
+		case "Response":
+			c.addResponse(op, item)
+		}
+	}
+	for _, p := range parameters {
+		if !hasParameter(parametersOf(op), p.Name, p.In) {
+			op.Parameters = append(op.Parameters, &openapi3.ParameterOrReference{
+				Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: p},
+			})
+		}
+	}
+}
+
+func parametersOf(op *openapi3.Operation) []*openapi3.Parameter {
+	var parameters []*openapi3.Parameter
+	for _, p := range op.Parameters {
+		if p.GetParameter() != nil {
+			parameters = append(parameters, p.GetParameter())
+		}
+	}
+	return parameters
+}
+
+// Returns the path of a URI template and the names of its query parameters.
+func blueprintPath(uri string) (string, []string) {
+	var query []string
+	path := blueprintExpressionPattern.ReplaceAllStringFunc(uri, func(expression string) string {
+		m := blueprintExpressionPattern.FindStringSubmatch(expression)
+		var names []string
+		for _, name := range strings.Split(m[2], ",") {
+			// Modifiers like "*" and ":3" don't change the names.
+			name = strings.TrimSuffix(strings.SplitN(strings.TrimSpace(name), ":", 2)[0], "*")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+		switch m[1] {
+		case "?", "&":
+			query = append(query, names...)
+			return ""
+		case "#":
+			return ""
+		}
+		for i, name := range names {
+			names[i] = "{" + name + "}"
+		}
+		return strings.Join(names, "/")
+	})
+	if i := strings.Index(path, "?"); i >= 0 {
+		for _, pair := range strings.Split(path[i+1:], "&") {
+			if name := strings.SplitN(pair, "=", 2)[0]; name != "" {
+				query = append(query, name)
+			}
+		}
+		path = path[:i]
+	}
+	if path == "" {
+		path = "/"
+	}
+	return path, query
+}
+
+// Returns a parameter for a name in a URI template and its declaration.
+// Declared parameters are required unless they are optional, and others
+// are strings that are only required in paths.
+func (c *blueprintConverter) parameter(name, in string, item *blueprintItem) *openapi3.Parameter {
+	if item == nil {
+		return &openapi3.Parameter{Name: name, In: in, Required: in == "path", Schema: stringSchema()}
+	}
+	m := parseBlueprintMember(item.text)
+	p := &openapi3.Parameter{
+		Name:        name,
+		In:          in,
+		Description: m.description,
+		Required:    in == "path" || !m.has("optional"),
+		Schema:      c.schema(m, item.items),
+	}
+	if s := p.Schema.GetSchema(); s != nil {
+		p.Example, s.Example = s.Example, nil
+		s.Description = ""
+	}
+	return p
+}
+
+// Returns the media type, headers, and body of a request or response, or a
+// nil body if it has none. Bodies without media types are JSON if they
+// are valid JSON.
+func (c *blueprintConverter) payload(item *blueprintItem, text string) (string, [][2]string, *openapi3.MediaType) {
+	mediaType := ""
+	if m := parseBlueprintMember(text); len(m.attributes) > 0 {
+		mediaType = strings.Join(m.attributes, ", ")
+	}
+	var headers [][2]string
+	if h := item.item("Headers"); h != nil {
+		for _, line := range strings.Split(blueprintText(h.lines), "\n") {
+			if i := strings.Index(line, ":"); i > 0 {
+				name, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+				headers = append(headers, [2]string{name, value})
+				if mediaType == "" && strings.EqualFold(name, "Content-Type") {
+					mediaType = value
+				}
+			}
+		}
+	}
+	body := ""
+	if b := item.item("Body"); b != nil {
+		body = blueprintText(b.lines)
+	} else if len(item.items) == 0 {
+		// Bodies can be the only content of requests and responses.
+		body = blueprintText(item.lines)
+	}
+	var schema *openapi3.SchemaOrReference
+	if s := item.item("Schema"); s != nil {
+		schema = jsonSchema(blueprintText(s.lines))
+	} else if a := item.item("Attributes"); a != nil {
+		schema = c.schema(parseBlueprintMember(a.text), a.items)
+	}
+	if body == "" && schema == nil {
+		if mediaType == "" {
+			return "", headers, nil
+		}
+		return mediaType, headers, &openapi3.MediaType{}
+	}
+	if mediaType == "" {
+		mediaType = "text/plain"
+		if body == "" || json.Valid([]byte(body)) {
+			mediaType = "application/json"
+		}
+	}
+	media := &openapi3.MediaType{}
+	if body != "" {
+		media = exampleMediaType(body, strings.Contains(mediaType, "json"))
+	}
+	if schema != nil {
+		media.Schema = schema
+	}
+	return mediaType, headers, media
+}
+
+func addBlueprintMediaType(content *openapi3.MediaTypes, mediaType string, media *openapi3.MediaType) {
+	for _, pair := range content.AdditionalProperties {
+		if pair.Name == mediaType {
+			return
+		}
+	}
+	content.AdditionalProperties = append(content.AdditionalProperties, &openapi3.NamedMediaType{Name: mediaType, Value: media})
+}
+
+// Adds a response to an operation, or adds its media type to an earlier
+// response with the same status code.
+func (c *blueprintConverter) addResponse(op *openapi3.Operation, item *blueprintItem) {
+	text := strings.TrimSpace(strings.TrimPrefix(item.text, "Response"))
+	code := "200"
+	if fields := strings.Fields(text); len(fields) > 0 && !strings.HasPrefix(fields[0], "(") {
+		code = fields[0]
+	}
+	mediaType, headers, media := c.payload(item, text)
+	var response *openapi3.Response
+	for _, pair := range op.Responses.ResponseOrReference {
+		if pair.Name == code {
+			response = pair.Value.GetResponse()
+		}
+	}
+	if response == nil {
+		response = &openapi3.Response{}
+		status, _ := strconv.Atoi(code)
+		if response.Description = http.StatusText(status); response.Description == "" {
+			response.Description = "Response"
+		}
+		op.Responses.ResponseOrReference = append(op.Responses.ResponseOrReference,
+			&openapi3.NamedResponseOrReference{
+				Name:  code,
+				Value: &openapi3.ResponseOrReference{Oneof: &openapi3.ResponseOrReference_Response{Response: response}},
+			})
+	}
+	for _, header := range headers {
+		if strings.EqualFold(header[0], "Content-Type") {
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = &openapi3.HeadersOrReferences{}
+		}
+		response.Headers.AdditionalProperties = append(response.Headers.AdditionalProperties,
+			&openapi3.NamedHeaderOrReference{
+				Name: header[0],
+				Value: &openapi3.HeaderOrReference{Oneof: &openapi3.HeaderOrReference_Header{Header: &openapi3.Header{
+					Schema:  stringSchema(),
+					Example: &openapi3.Any{Yaml: yamlString(header[1])},
+				}}},
+			})
+	}
+	if media != nil {
+		if response.Content == nil {
+			response.Content = &openapi3.MediaTypes{}
+		}
+		addBlueprintMediaType(response.Content, mediaType, media)
+	}
+}
+
+// Adds a schema to the components of the document.
+func (c *blueprintConverter) addSchema(name string, schema *openapi3.SchemaOrReference) {
+	if c.document.Components == nil {
+		c.document.Components = &openapi3.Components{}
+	}
+	if c.document.Components.Schemas == nil {
+		c.document.Components.Schemas = &openapi3.SchemasOrReferences{}
+	}
+	c.document.Components.Schemas.AdditionalProperties = append(c.document.Components.Schemas.AdditionalProperties,
+		&openapi3.NamedSchemaOrReference{Name: name, Value: schema})
+}
+
+// Returns a schema for a member or parameter and its nested items, which
+// are the members of objects, the items of arrays, or the values of enums.
+func (c *blueprintConverter) schema(m blueprintMember, items []*blueprintItem) *openapi3.SchemaOrReference {
+	typeName := m.typeName()
+	if typeName == "" {
+		typeName = "string"
+		if len(items) > 0 {
+			typeName = "object"
+		}
+	}
+	s := &openapi3.Schema{Description: m.description, Nullable: m.has("nullable")}
+	var base *openapi3.SchemaOrReference
+	switch {
+	case typeName == "string" || typeName == "number" || typeName == "integer" || typeName == "boolean":
+		s.Type = typeName
+	case typeName == "object":
+		s.Type = "object"
+	case strings.HasPrefix(typeName, "array"):
+		s.Type = "array"
+		itemType := strings.TrimSuffix(strings.TrimPrefix(typeName, "array["), "]")
+		if itemType == "array" {
+			itemType = ""
+		}
+		items := c.schema(blueprintMember{attributes: []string{itemType}}, nil)
+		if len(m.attributes) == 0 || itemType == "" {
+			items = schemaValue(&openapi3.Schema{})
+		}
+		s.Items = &openapi3.ItemsItem{SchemaOrReference: []*openapi3.SchemaOrReference{items}}
+	case strings.HasPrefix(typeName, "enum"):
+		s.Type = strings.TrimSuffix(strings.TrimPrefix(typeName, "enum["), "]")
+		if s.Type == "enum" {
+			s.Type = "string"
+		}
+		values := items
+		if members := (&blueprintItem{items: items}).item("Members"); members != nil {
+			values = members.items
+		}
+		for _, item := range values {
+			switch item.keyword() {
+			case "Default", "Sample":
+				continue
+			}
+			s.Enum = append(s.Enum, &openapi3.Any{Yaml: string(compiler.Marshal(blueprintValue(parseBlueprintMember(item.text).name, s.Type)))})
+		}
+	default:
+		base = &openapi3.SchemaOrReference{
+			Oneof: &openapi3.SchemaOrReference_Reference{Reference: &openapi3.Reference{XRef: componentsSchemasPrefix + typeName}},
+		}
+	}
+	if m.example != "" && s.Type != "object" && s.Type != "array" {
+		s.Example = &openapi3.Any{Yaml: string(compiler.Marshal(blueprintValue(m.example, s.Type)))}
+	}
+	for _, item := range items {
+		switch item.keyword() {
+		case "Default":
+			value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(item.text, "Default")), ":"))
+			s.Default = defaultType(blueprintValue(value, s.Type))
+		case "Members", "Sample", "Include", "Properties", "Items":
+		default:
+			if s.Type != "object" && base == nil {
+				continue
+			}
+			member := parseBlueprintMember(item.text)
+			if member.name == "" {
+				continue
+			}
+			if s.Properties == nil {
+				s.Properties = &openapi3.Properties{}
+			}
+			if member.has("required") {
+				s.Required = append(s.Required, member.name)
+			}
+			s.Properties.AdditionalProperties = append(s.Properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: member.name, Value: c.schema(member, item.items)})
+		}
+	}
+	if base == nil {
+		return schemaValue(s)
+	}
+	// Members of types that inherit from named types are added to them.
+	if s.Properties != nil {
+		s.Type = "object"
+	}
+	if s.Properties == nil && s.Description == "" && !s.Nullable && s.Example == nil {
+		return base
+	}
+	s.AllOf = []*openapi3.SchemaOrReference{base}
+	return schemaValue(s)
+}
+
+// Returns a node for a value of a type. Values that aren't of their types
+// are strings.
+func blueprintValue(value, typeName string) *yaml.Node {
+	var node yaml.Node
+	if typeName == "number" || typeName == "integer" || typeName == "boolean" {
+		if yaml.Unmarshal([]byte(value), &node) == nil && len(node.Content) > 0 {
+			switch tag := node.Content[0].Tag; {
+			case tag == "!!bool" && typeName == "boolean", tag == "!!int", tag == "!!float" && typeName == "number":
+				return node.Content[0]
+			}
+		}
+	}
+	return compiler.NewScalarNodeForString(value)
+}
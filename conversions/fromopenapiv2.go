@@ -0,0 +1,891 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	openapi2 "github.com/google/gnostic/openapiv2"
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// Prefixes of local references to the named objects of OpenAPI documents.
+const (
+	definitionsPrefix         = "#/definitions/"
+	parametersPrefix          = "#/parameters/"
+	responsesPrefix           = "#/responses/"
+	componentsSchemasPrefix   = "#/components/schemas/"
+	componentsParamsPrefix    = "#/components/parameters/"
+	componentsBodiesPrefix    = "#/components/requestBodies/"
+	componentsResponsesPrefix = "#/components/responses/"
+)
+
+const defaultMediaType = "application/json"
+
+// A v2Converter holds the state of a conversion from OpenAPI v2 to v3.
+type v2Converter struct {
+	document *openapi2.Document
+	// parameterDefinitions are the parameter definitions of the document,
+	// which are needed to convert references to body and form parameters.
+	parameterDefinitions map[string]*openapi2.Parameter
+}
+
+// OpenAPIv3FromOpenAPIv2 converts an OpenAPI v2 document to OpenAPI v3.
+// References to definitions, parameters, and responses are rewritten to
+// refer to the corresponding components. Body and form data parameters
+// become request bodies with the media types that operations consume, and
+// schemas of responses get the media types that operations produce.
+func OpenAPIv3FromOpenAPIv2(d *openapi2.Document) (*openapi3.Document, error) {
+	if d == nil {
+		return nil, errors.New("no OpenAPI v2 document")
+	}
+	c := &v2Converter{document: d, parameterDefinitions: make(map[string]*openapi2.Parameter)}
+	if d.Parameters != nil {
+		for _, pair := range d.Parameters.AdditionalProperties {
+			c.parameterDefinitions[pair.Name] = pair.Value
+		}
+	}
+	v3 := &openapi3.Document{
+		Openapi:                "3.0.3",
+		Info:                   c.info(d.Info),
+		Servers:                c.servers(),
+		Paths:                  &openapi3.Paths{},
+		Components:             c.components(),
+		Security:               c.securityRequirements(d.Security),
+		ExternalDocs:           c.externalDocs(d.ExternalDocs),
+		SpecificationExtension: c.extensions(d.VendorExtension),
+	}
+	for _, tag := range d.Tags {
+		v3.Tags = append(v3.Tags, &openapi3.Tag{
+			Name:                   tag.Name,
+			Description:            tag.Description,
+			ExternalDocs:           c.externalDocs(tag.ExternalDocs),
+			SpecificationExtension: c.extensions(tag.VendorExtension),
+		})
+	}
+	if d.Paths != nil {
+		v3.Paths.SpecificationExtension = c.extensions(d.Paths.VendorExtension)
+		for _, pair := range d.Paths.Path {
+			v3.Paths.Path = append(v3.Paths.Path, &openapi3.NamedPathItem{
+				Name:  pair.Name,
+				Value: c.pathItem(pair.Value),
+			})
+		}
+	}
+	return v3, nil
+}
+
+// v3Reference rewrites a reference to a named object of an OpenAPI v2
+// document as a reference to the corresponding component.
+func v3Reference(ref string) string {
+	for _, r := range []struct{ from, to string }{
+		{definitionsPrefix, componentsSchemasPrefix},
+		{parametersPrefix, componentsParamsPrefix},
+		{responsesPrefix, componentsResponsesPrefix},
+	} {
+		if i := strings.Index(ref, r.from); i >= 0 {
+			return ref[:i] + r.to + ref[i+len(r.from):]
+		}
+	}
+	return ref
+}
+
+func (c *v2Converter) extensions(extensions []*openapi2.NamedAny) []*openapi3.NamedAny {
+	var result []*openapi3.NamedAny
+	for _, e := range extensions {
+		result = append(result, &openapi3.NamedAny{Name: e.Name, Value: c.any(e.Value)})
+	}
+	return result
+}
+
+func (c *v2Converter) any(a *openapi2.Any) *openapi3.Any {
+	if a == nil {
+		return nil
+	}
+	return &openapi3.Any{Value: a.Value, Yaml: a.Yaml}
+}
+
+func (c *v2Converter) anys(values []*openapi2.Any) []*openapi3.Any {
+	var result []*openapi3.Any
+	for _, value := range values {
+		result = append(result, c.any(value))
+	}
+	return result
+}
+
+// defaultValue converts a default value, which can only be a scalar in
+// the OpenAPI v3 models.
+func (c *v2Converter) defaultValue(a *openapi2.Any) *openapi3.DefaultType {
+	if a == nil {
+		return nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(a.Yaml), &node); err != nil || len(node.Content) != 1 {
+		return nil
+	}
+	value := node.Content[0]
+	if value.Kind != yaml.ScalarNode {
+		return nil
+	}
+	switch value.Tag {
+	case "!!bool":
+		if b, err := strconv.ParseBool(value.Value); err == nil {
+			return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_Boolean{Boolean: b}}
+		}
+	case "!!int", "!!float":
+		if f, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_Number{Number: f}}
+		}
+	}
+	return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_String_{String_: value.Value}}
+}
+
+func (c *v2Converter) externalDocs(docs *openapi2.ExternalDocs) *openapi3.ExternalDocs {
+	if docs == nil {
+		return nil
+	}
+	return &openapi3.ExternalDocs{
+		Description:            docs.Description,
+		Url:                    docs.Url,
+		SpecificationExtension: c.extensions(docs.VendorExtension),
+	}
+}
+
+func (c *v2Converter) info(info *openapi2.Info) *openapi3.Info {
+	if info == nil {
+		return &openapi3.Info{}
+	}
+	v3 := &openapi3.Info{
+		Title:                  info.Title,
+		Description:            info.Description,
+		TermsOfService:         info.TermsOfService,
+		Version:                info.Version,
+		SpecificationExtension: c.extensions(info.VendorExtension),
+	}
+	if contact := info.Contact; contact != nil {
+		v3.Contact = &openapi3.Contact{
+			Name:                   contact.Name,
+			Url:                    contact.Url,
+			Email:                  contact.Email,
+			SpecificationExtension: c.extensions(contact.VendorExtension),
+		}
+	}
+	if license := info.License; license != nil {
+		v3.License = &openapi3.License{
+			Name:                   license.Name,
+			Url:                    license.Url,
+			SpecificationExtension: c.extensions(license.VendorExtension),
+		}
+	}
+	return v3
+}
+
+// servers builds a server for each scheme of the document from its host
+// and base path. Documents without a host get a server with a relative URL.
+func (c *v2Converter) servers() []*openapi3.Server {
+	d := c.document
+	if d.Host == "" && d.BasePath == "" {
+		return nil
+	}
+	if d.Host == "" {
+		return []*openapi3.Server{{Url: d.BasePath}}
+	}
+	schemes := d.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	var servers []*openapi3.Server
+	for _, scheme := range schemes {
+		servers = append(servers, &openapi3.Server{Url: scheme + "://" + d.Host + d.BasePath})
+	}
+	return servers
+}
+
+func (c *v2Converter) components() *openapi3.Components {
+	d := c.document
+	components := &openapi3.Components{}
+	if d.Definitions != nil {
+		components.Schemas = &openapi3.SchemasOrReferences{}
+		for _, pair := range d.Definitions.AdditionalProperties {
+			components.Schemas.AdditionalProperties = append(components.Schemas.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: pair.Name, Value: c.schemaOrReference(pair.Value)})
+		}
+	}
+	if d.Parameters != nil {
+		for _, pair := range d.Parameters.AdditionalProperties {
+			if body := pair.Value.GetBodyParameter(); body != nil {
+				if components.RequestBodies == nil {
+					components.RequestBodies = &openapi3.RequestBodiesOrReferences{}
+				}
+				components.RequestBodies.AdditionalProperties = append(components.RequestBodies.AdditionalProperties,
+					&openapi3.NamedRequestBodyOrReference{
+						Name: pair.Name,
+						Value: &openapi3.RequestBodyOrReference{
+							Oneof: &openapi3.RequestBodyOrReference_RequestBody{
+								RequestBody: c.requestBody(body, c.document.Consumes),
+							},
+						},
+					})
+			} else if parameter := c.parameter(pair.Value.GetNonBodyParameter()); parameter != nil {
+				// Form data parameters can't be components, so they are
+				// copied into the operations that refer to them.
+				if components.Parameters == nil {
+					components.Parameters = &openapi3.ParametersOrReferences{}
+				}
+				components.Parameters.AdditionalProperties = append(components.Parameters.AdditionalProperties,
+					&openapi3.NamedParameterOrReference{
+						Name: pair.Name,
+						Value: &openapi3.ParameterOrReference{
+							Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: parameter},
+						},
+					})
+			}
+		}
+	}
+	if d.Responses != nil {
+		components.Responses = &openapi3.ResponsesOrReferences{}
+		for _, pair := range d.Responses.AdditionalProperties {
+			components.Responses.AdditionalProperties = append(components.Responses.AdditionalProperties,
+				&openapi3.NamedResponseOrReference{
+					Name: pair.Name,
+					Value: &openapi3.ResponseOrReference{
+						Oneof: &openapi3.ResponseOrReference_Response{Response: c.response(pair.Value, d.Produces)},
+					},
+				})
+		}
+	}
+	if d.SecurityDefinitions != nil {
+		components.SecuritySchemes = &openapi3.SecuritySchemesOrReferences{}
+		for _, pair := range d.SecurityDefinitions.AdditionalProperties {
+			components.SecuritySchemes.AdditionalProperties = append(components.SecuritySchemes.AdditionalProperties,
+				&openapi3.NamedSecuritySchemeOrReference{
+					Name: pair.Name,
+					Value: &openapi3.SecuritySchemeOrReference{
+						Oneof: &openapi3.SecuritySchemeOrReference_SecurityScheme{SecurityScheme: c.securityScheme(pair.Value)},
+					},
+				})
+		}
+	}
+	return components
+}
+
+func (c *v2Converter) schemaOrReference(schema *openapi2.Schema) *openapi3.SchemaOrReference {
+	if schema == nil {
+		return nil
+	}
+	if schema.XRef != "" {
+		return &openapi3.SchemaOrReference{
+			Oneof: &openapi3.SchemaOrReference_Reference{Reference: &openapi3.Reference{XRef: v3Reference(schema.XRef)}},
+		}
+	}
+	return &openapi3.SchemaOrReference{Oneof: &openapi3.SchemaOrReference_Schema{Schema: c.schema(schema)}}
+}
+
+func (c *v2Converter) schemasOrReferences(schemas []*openapi2.Schema) []*openapi3.SchemaOrReference {
+	var result []*openapi3.SchemaOrReference
+	for _, schema := range schemas {
+		result = append(result, c.schemaOrReference(schema))
+	}
+	return result
+}
+
+// schema converts a schema. Schemas with a list of types become nullable
+// if "null" is one of them and otherwise get the first type, and the
+// x-nullable extension becomes the nullable field.
+func (c *v2Converter) schema(schema *openapi2.Schema) *openapi3.Schema {
+	v3 := &openapi3.Schema{
+		Title:            schema.Title,
+		Description:      schema.Description,
+		Format:           schema.Format,
+		Default:          c.defaultValue(schema.Default),
+		MultipleOf:       schema.MultipleOf,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		MaxProperties:    schema.MaxProperties,
+		MinProperties:    schema.MinProperties,
+		Required:         schema.Required,
+		Enum:             c.anys(schema.Enum),
+		AllOf:            c.schemasOrReferences(schema.AllOf),
+		ReadOnly:         schema.ReadOnly,
+		ExternalDocs:     c.externalDocs(schema.ExternalDocs),
+		Example:          c.any(schema.Example),
+	}
+	if schema.Type != nil {
+		for _, t := range schema.Type.Value {
+			if t == "null" {
+				v3.Nullable = true
+			} else if v3.Type == "" {
+				v3.Type = t
+			}
+		}
+	}
+	if schema.Items != nil && len(schema.Items.Schema) > 0 {
+		v3.Items = &openapi3.ItemsItem{SchemaOrReference: c.schemasOrReferences(schema.Items.Schema[:1])}
+	}
+	if schema.Properties != nil {
+		v3.Properties = &openapi3.Properties{}
+		for _, pair := range schema.Properties.AdditionalProperties {
+			v3.Properties.AdditionalProperties = append(v3.Properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: pair.Name, Value: c.schemaOrReference(pair.Value)})
+		}
+	}
+	if additional := schema.AdditionalProperties; additional != nil {
+		if s := additional.GetSchema(); s != nil {
+			v3.AdditionalProperties = &openapi3.AdditionalPropertiesItem{
+				Oneof: &openapi3.AdditionalPropertiesItem_SchemaOrReference{SchemaOrReference: c.schemaOrReference(s)},
+			}
+		} else {
+			v3.AdditionalProperties = &openapi3.AdditionalPropertiesItem{
+				Oneof: &openapi3.AdditionalPropertiesItem_Boolean{Boolean: additional.GetBoolean()},
+			}
+		}
+	}
+	if schema.Discriminator != "" {
+		v3.Discriminator = &openapi3.Discriminator{PropertyName: schema.Discriminator}
+	}
+	if xml := schema.Xml; xml != nil {
+		v3.Xml = &openapi3.Xml{
+			Name:                   xml.Name,
+			Namespace:              xml.Namespace,
+			Prefix:                 xml.Prefix,
+			Attribute:              xml.Attribute,
+			Wrapped:                xml.Wrapped,
+			SpecificationExtension: c.extensions(xml.VendorExtension),
+		}
+	}
+	for _, e := range schema.VendorExtension {
+		if e.Name == "x-nullable" && strings.TrimSpace(e.Value.GetYaml()) == "true" {
+			v3.Nullable = true
+			continue
+		}
+		v3.SpecificationExtension = append(v3.SpecificationExtension, &openapi3.NamedAny{Name: e.Name, Value: c.any(e.Value)})
+	}
+	return v3
+}
+
+// fileSchema converts the schema of a file to a binary string.
+func (c *v2Converter) fileSchema(schema *openapi2.FileSchema) *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Schema{
+			Schema: &openapi3.Schema{
+				Type:        "string",
+				Format:      "binary",
+				Title:       schema.Title,
+				Description: schema.Description,
+				ReadOnly:    schema.ReadOnly,
+				Example:     c.any(schema.Example),
+			},
+		},
+	}
+}
+
+// A simpleSchema has the fields that describe values of non-body
+// parameters, headers, and items of their arrays.
+type simpleSchema struct {
+	Type             string
+	Format           string
+	Items            *openapi2.PrimitivesItems
+	Default          *openapi2.Any
+	Maximum          float64
+	ExclusiveMaximum bool
+	Minimum          float64
+	ExclusiveMinimum bool
+	MaxLength        int64
+	MinLength        int64
+	Pattern          string
+	MaxItems         int64
+	MinItems         int64
+	UniqueItems      bool
+	Enum             []*openapi2.Any
+	MultipleOf       float64
+}
+
+func (c *v2Converter) primitiveSchema(s *simpleSchema) *openapi3.SchemaOrReference {
+	schema := &openapi3.Schema{
+		Type:             s.Type,
+		Format:           s.Format,
+		Default:          c.defaultValue(s.Default),
+		Maximum:          s.Maximum,
+		ExclusiveMaximum: s.ExclusiveMaximum,
+		Minimum:          s.Minimum,
+		ExclusiveMinimum: s.ExclusiveMinimum,
+		MaxLength:        s.MaxLength,
+		MinLength:        s.MinLength,
+		Pattern:          s.Pattern,
+		MaxItems:         s.MaxItems,
+		MinItems:         s.MinItems,
+		UniqueItems:      s.UniqueItems,
+		Enum:             c.anys(s.Enum),
+		MultipleOf:       s.MultipleOf,
+	}
+	if s.Type == "file" {
+		schema.Type = "string"
+		schema.Format = "binary"
+	}
+	if items := s.Items; items != nil {
+		schema.Items = &openapi3.ItemsItem{
+			SchemaOrReference: []*openapi3.SchemaOrReference{c.primitiveSchema(&simpleSchema{
+				Type:             items.Type,
+				Format:           items.Format,
+				Items:            items.Items,
+				Default:          items.Default,
+				Maximum:          items.Maximum,
+				ExclusiveMaximum: items.ExclusiveMaximum,
+				Minimum:          items.Minimum,
+				ExclusiveMinimum: items.ExclusiveMinimum,
+				MaxLength:        items.MaxLength,
+				MinLength:        items.MinLength,
+				Pattern:          items.Pattern,
+				MaxItems:         items.MaxItems,
+				MinItems:         items.MinItems,
+				UniqueItems:      items.UniqueItems,
+				Enum:             items.Enum,
+				MultipleOf:       items.MultipleOf,
+			})},
+		}
+	}
+	return &openapi3.SchemaOrReference{Oneof: &openapi3.SchemaOrReference_Schema{Schema: schema}}
+}
+
+// setStyle sets the style and explode fields of a parameter from the
+// collection format of an array. Tab-separated values have no equivalent
+// and are left with the default style.
+func setStyle(p *openapi3.Parameter, collectionFormat string) {
+	switch collectionFormat {
+	case "", "csv":
+		if p.In == "query" {
+			p.Style = "form"
+		} else {
+			p.Style = "simple"
+		}
+	case "multi":
+		p.Style = "form"
+		p.Explode = true
+	case "ssv":
+		p.Style = "spaceDelimited"
+	case "pipes":
+		p.Style = "pipeDelimited"
+	}
+}
+
+// parameter converts a query, path, or header parameter. It returns nil
+// for form data parameters, which become properties of request bodies.
+func (c *v2Converter) parameter(p *openapi2.NonBodyParameter) *openapi3.Parameter {
+	if p == nil {
+		return nil
+	}
+	var parameter *openapi3.Parameter
+	var collectionFormat string
+	var extensions []*openapi2.NamedAny
+	var isArray bool
+	if q := p.GetQueryParameterSubSchema(); q != nil {
+		parameter = &openapi3.Parameter{
+			Name:            q.Name,
+			In:              "query",
+			Description:     q.Description,
+			Required:        q.Required,
+			AllowEmptyValue: q.AllowEmptyValue,
+			Schema: c.primitiveSchema(&simpleSchema{
+				Type: q.Type, Format: q.Format, Items: q.Items, Default: q.Default,
+				Maximum: q.Maximum, ExclusiveMaximum: q.ExclusiveMaximum,
+				Minimum: q.Minimum, ExclusiveMinimum: q.ExclusiveMinimum,
+				MaxLength: q.MaxLength, MinLength: q.MinLength, Pattern: q.Pattern,
+				MaxItems: q.MaxItems, MinItems: q.MinItems, UniqueItems: q.UniqueItems,
+				Enum: q.Enum, MultipleOf: q.MultipleOf,
+			}),
+		}
+		collectionFormat, extensions, isArray = q.CollectionFormat, q.VendorExtension, q.Type == "array"
+	} else if h := p.GetHeaderParameterSubSchema(); h != nil {
+		parameter = &openapi3.Parameter{
+			Name:        h.Name,
+			In:          "header",
+			Description: h.Description,
+			Required:    h.Required,
+			Schema: c.primitiveSchema(&simpleSchema{
+				Type: h.Type, Format: h.Format, Items: h.Items, Default: h.Default,
+				Maximum: h.Maximum, ExclusiveMaximum: h.ExclusiveMaximum,
+				Minimum: h.Minimum, ExclusiveMinimum: h.ExclusiveMinimum,
+				MaxLength: h.MaxLength, MinLength: h.MinLength, Pattern: h.Pattern,
+				MaxItems: h.MaxItems, MinItems: h.MinItems, UniqueItems: h.UniqueItems,
+				Enum: h.Enum, MultipleOf: h.MultipleOf,
+			}),
+		}
+		collectionFormat, extensions, isArray = h.CollectionFormat, h.VendorExtension, h.Type == "array"
+	} else if path := p.GetPathParameterSubSchema(); path != nil {
+		parameter = &openapi3.Parameter{
+			Name:        path.Name,
+			In:          "path",
+			Description: path.Description,
+			Required:    true,
+			Schema: c.primitiveSchema(&simpleSchema{
+				Type: path.Type, Format: path.Format, Items: path.Items, Default: path.Default,
+				Maximum: path.Maximum, ExclusiveMaximum: path.ExclusiveMaximum,
+				Minimum: path.Minimum, ExclusiveMinimum: path.ExclusiveMinimum,
+				MaxLength: path.MaxLength, MinLength: path.MinLength, Pattern: path.Pattern,
+				MaxItems: path.MaxItems, MinItems: path.MinItems, UniqueItems: path.UniqueItems,
+				Enum: path.Enum, MultipleOf: path.MultipleOf,
+			}),
+		}
+		collectionFormat, extensions, isArray = path.CollectionFormat, path.VendorExtension, path.Type == "array"
+	} else {
+		return nil
+	}
+	if isArray {
+		setStyle(parameter, collectionFormat)
+	}
+	parameter.SpecificationExtension = c.extensions(extensions)
+	return parameter
+}
+
+// formProperty converts a form data parameter to a property of the schema
+// of a request body.
+func (c *v2Converter) formProperty(f *openapi2.FormDataParameterSubSchema) *openapi3.NamedSchemaOrReference {
+	property := c.primitiveSchema(&simpleSchema{
+		Type: f.Type, Format: f.Format, Items: f.Items, Default: f.Default,
+		Maximum: f.Maximum, ExclusiveMaximum: f.ExclusiveMaximum,
+		Minimum: f.Minimum, ExclusiveMinimum: f.ExclusiveMinimum,
+		MaxLength: f.MaxLength, MinLength: f.MinLength, Pattern: f.Pattern,
+		MaxItems: f.MaxItems, MinItems: f.MinItems, UniqueItems: f.UniqueItems,
+		Enum: f.Enum, MultipleOf: f.MultipleOf,
+	})
+	property.GetSchema().Description = f.Description
+	return &openapi3.NamedSchemaOrReference{Name: f.Name, Value: property}
+}
+
+func (c *v2Converter) requestBody(body *openapi2.BodyParameter, consumes []string) *openapi3.RequestBody {
+	if len(consumes) == 0 {
+		consumes = []string{defaultMediaType}
+	}
+	requestBody := &openapi3.RequestBody{
+		Description:            body.Description,
+		Required:               body.Required,
+		Content:                &openapi3.MediaTypes{},
+		SpecificationExtension: c.extensions(body.VendorExtension),
+	}
+	for _, mediaType := range consumes {
+		requestBody.Content.AdditionalProperties = append(requestBody.Content.AdditionalProperties,
+			&openapi3.NamedMediaType{
+				Name:  mediaType,
+				Value: &openapi3.MediaType{Schema: c.schemaOrReference(body.Schema)},
+			})
+	}
+	return requestBody
+}
+
+// formRequestBody builds a request body for form data parameters. Forms
+// use the form media types that operations consume, or if there are none,
+// multipart/form-data for forms with files and URL encoding for others.
+func (c *v2Converter) formRequestBody(form []*openapi2.FormDataParameterSubSchema, consumes []string) *openapi3.RequestBody {
+	schema := &openapi3.Schema{Type: "object", Properties: &openapi3.Properties{}}
+	hasFile := false
+	required := false
+	for _, f := range form {
+		schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties, c.formProperty(f))
+		if f.Required {
+			schema.Required = append(schema.Required, f.Name)
+			required = true
+		}
+		if f.Type == "file" {
+			hasFile = true
+		}
+	}
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		} else {
+			mediaTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+	requestBody := &openapi3.RequestBody{Required: required, Content: &openapi3.MediaTypes{}}
+	for _, mediaType := range mediaTypes {
+		requestBody.Content.AdditionalProperties = append(requestBody.Content.AdditionalProperties,
+			&openapi3.NamedMediaType{
+				Name: mediaType,
+				Value: &openapi3.MediaType{
+					Schema: &openapi3.SchemaOrReference{Oneof: &openapi3.SchemaOrReference_Schema{Schema: schema}},
+				},
+			})
+	}
+	return requestBody
+}
+
+func (c *v2Converter) header(header *openapi2.Header) *openapi3.HeaderOrReference {
+	return &openapi3.HeaderOrReference{
+		Oneof: &openapi3.HeaderOrReference_Header{
+			Header: &openapi3.Header{
+				Description: header.Description,
+				Schema: c.primitiveSchema(&simpleSchema{
+					Type: header.Type, Format: header.Format, Items: header.Items, Default: header.Default,
+					Maximum: header.Maximum, ExclusiveMaximum: header.ExclusiveMaximum,
+					Minimum: header.Minimum, ExclusiveMinimum: header.ExclusiveMinimum,
+					MaxLength: header.MaxLength, MinLength: header.MinLength, Pattern: header.Pattern,
+					MaxItems: header.MaxItems, MinItems: header.MinItems, UniqueItems: header.UniqueItems,
+					Enum: header.Enum, MultipleOf: header.MultipleOf,
+				}),
+				SpecificationExtension: c.extensions(header.VendorExtension),
+			},
+		},
+	}
+}
+
+// response converts a response. Its schema is given to each media type
+// that is produced, and its examples to the media types they are keyed by.
+func (c *v2Converter) response(response *openapi2.Response, produces []string) *openapi3.Response {
+	v3 := &openapi3.Response{
+		Description:            response.Description,
+		SpecificationExtension: c.extensions(response.VendorExtension),
+	}
+	if response.Headers != nil {
+		v3.Headers = &openapi3.HeadersOrReferences{}
+		for _, pair := range response.Headers.AdditionalProperties {
+			v3.Headers.AdditionalProperties = append(v3.Headers.AdditionalProperties,
+				&openapi3.NamedHeaderOrReference{Name: pair.Name, Value: c.header(pair.Value)})
+		}
+	}
+	if response.Schema == nil {
+		return v3
+	}
+	var schema *openapi3.SchemaOrReference
+	if s := response.Schema.GetSchema(); s != nil {
+		schema = c.schemaOrReference(s)
+	} else if f := response.Schema.GetFileSchema(); f != nil {
+		schema = c.fileSchema(f)
+	}
+	if len(produces) == 0 {
+		produces = []string{defaultMediaType}
+	}
+	v3.Content = &openapi3.MediaTypes{}
+	for _, mediaType := range produces {
+		media := &openapi3.MediaType{Schema: schema}
+		if response.Examples != nil {
+			for _, example := range response.Examples.AdditionalProperties {
+				if example.Name == mediaType {
+					media.Example = c.any(example.Value)
+				}
+			}
+		}
+		v3.Content.AdditionalProperties = append(v3.Content.AdditionalProperties,
+			&openapi3.NamedMediaType{Name: mediaType, Value: media})
+	}
+	return v3
+}
+
+func (c *v2Converter) securityScheme(item *openapi2.SecurityDefinitionsItem) *openapi3.SecurityScheme {
+	scopes := func(s *openapi2.Oauth2Scopes) *openapi3.Strings {
+		v3 := &openapi3.Strings{}
+		if s != nil {
+			for _, pair := range s.AdditionalProperties {
+				v3.AdditionalProperties = append(v3.AdditionalProperties, &openapi3.NamedString{Name: pair.Name, Value: pair.Value})
+			}
+		}
+		return v3
+	}
+	if s := item.GetBasicAuthenticationSecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "http", Scheme: "basic", Description: s.Description,
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	} else if s := item.GetApiKeySecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "apiKey", Name: s.Name, In: s.In, Description: s.Description,
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	} else if s := item.GetOauth2ImplicitSecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "oauth2", Description: s.Description,
+			Flows: &openapi3.OauthFlows{Implicit: &openapi3.OauthFlow{
+				AuthorizationUrl: s.AuthorizationUrl, Scopes: scopes(s.Scopes)}},
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	} else if s := item.GetOauth2PasswordSecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "oauth2", Description: s.Description,
+			Flows: &openapi3.OauthFlows{Password: &openapi3.OauthFlow{
+				TokenUrl: s.TokenUrl, Scopes: scopes(s.Scopes)}},
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	} else if s := item.GetOauth2ApplicationSecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "oauth2", Description: s.Description,
+			Flows: &openapi3.OauthFlows{ClientCredentials: &openapi3.OauthFlow{
+				TokenUrl: s.TokenUrl, Scopes: scopes(s.Scopes)}},
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	} else if s := item.GetOauth2AccessCodeSecurity(); s != nil {
+		return &openapi3.SecurityScheme{Type: "oauth2", Description: s.Description,
+			Flows: &openapi3.OauthFlows{AuthorizationCode: &openapi3.OauthFlow{
+				AuthorizationUrl: s.AuthorizationUrl, TokenUrl: s.TokenUrl, Scopes: scopes(s.Scopes)}},
+			SpecificationExtension: c.extensions(s.VendorExtension)}
+	}
+	return &openapi3.SecurityScheme{}
+}
+
+func (c *v2Converter) securityRequirements(requirements []*openapi2.SecurityRequirement) []*openapi3.SecurityRequirement {
+	var result []*openapi3.SecurityRequirement
+	for _, requirement := range requirements {
+		v3 := &openapi3.SecurityRequirement{}
+		for _, pair := range requirement.AdditionalProperties {
+			v3.AdditionalProperties = append(v3.AdditionalProperties,
+				&openapi3.NamedStringArray{Name: pair.Name, Value: &openapi3.StringArray{Value: pair.Value.GetValue()}})
+		}
+		result = append(result, v3)
+	}
+	return result
+}
+
+// A parameterList holds the converted parameters of a path or operation,
+// with the body and form data parameters that become its request body.
+type parameterList struct {
+	parameters []*openapi3.ParameterOrReference
+	body       *openapi3.RequestBodyOrReference
+	bodyParam  *openapi2.BodyParameter
+	form       []*openapi2.FormDataParameterSubSchema
+}
+
+func (c *v2Converter) parameters(items []*openapi2.ParametersItem) *parameterList {
+	list := &parameterList{}
+	add := func(p *openapi2.Parameter) {
+		if body := p.GetBodyParameter(); body != nil {
+			list.bodyParam = body
+		} else if f := p.GetNonBodyParameter().GetFormDataParameterSubSchema(); f != nil {
+			list.form = append(list.form, f)
+		} else if parameter := c.parameter(p.GetNonBodyParameter()); parameter != nil {
+			list.parameters = append(list.parameters, &openapi3.ParameterOrReference{
+				Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: parameter},
+			})
+		}
+	}
+	for _, item := range items {
+		if p := item.GetParameter(); p != nil {
+			add(p)
+			continue
+		}
+		ref := item.GetJsonReference().GetXRef()
+		if strings.HasPrefix(ref, parametersPrefix) {
+			name := strings.TrimPrefix(ref, parametersPrefix)
+			if p, ok := c.parameterDefinitions[name]; ok {
+				if p.GetBodyParameter() != nil {
+					list.body = &openapi3.RequestBodyOrReference{
+						Oneof: &openapi3.RequestBodyOrReference_Reference{
+							Reference: &openapi3.Reference{XRef: componentsBodiesPrefix + name},
+						},
+					}
+					list.bodyParam = nil
+					continue
+				}
+				if f := p.GetNonBodyParameter().GetFormDataParameterSubSchema(); f != nil {
+					list.form = append(list.form, f)
+					continue
+				}
+			}
+		}
+		list.parameters = append(list.parameters, &openapi3.ParameterOrReference{
+			Oneof: &openapi3.ParameterOrReference_Reference{Reference: &openapi3.Reference{XRef: v3Reference(ref)}},
+		})
+	}
+	return list
+}
+
+func (c *v2Converter) pathItem(item *openapi2.PathItem) *openapi3.PathItem {
+	pathParameters := c.parameters(item.Parameters)
+	v3 := &openapi3.PathItem{
+		XRef:                   item.XRef,
+		Parameters:             pathParameters.parameters,
+		SpecificationExtension: c.extensions(item.VendorExtension),
+	}
+	v3.Get = c.operation(item.Get, pathParameters)
+	v3.Put = c.operation(item.Put, pathParameters)
+	v3.Post = c.operation(item.Post, pathParameters)
+	v3.Delete = c.operation(item.Delete, pathParameters)
+	v3.Options = c.operation(item.Options, pathParameters)
+	v3.Head = c.operation(item.Head, pathParameters)
+	v3.Patch = c.operation(item.Patch, pathParameters)
+	return v3
+}
+
+// operation converts an operation. Body and form data parameters of its
+// path are used if the operation doesn't have its own.
+func (c *v2Converter) operation(op *openapi2.Operation, pathParameters *parameterList) *openapi3.Operation {
+	if op == nil {
+		return nil
+	}
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = c.document.Consumes
+	}
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = c.document.Produces
+	}
+	list := c.parameters(op.Parameters)
+	v3 := &openapi3.Operation{
+		Tags:                   op.Tags,
+		Summary:                op.Summary,
+		Description:            op.Description,
+		ExternalDocs:           c.externalDocs(op.ExternalDocs),
+		OperationId:            op.OperationId,
+		Parameters:             list.parameters,
+		Responses:              &openapi3.Responses{},
+		Deprecated:             op.Deprecated,
+		Security:               c.securityRequirements(op.Security),
+		SpecificationExtension: c.extensions(op.VendorExtension),
+	}
+	if list.body == nil && list.bodyParam == nil && len(list.form) == 0 {
+		list = pathParameters
+	}
+	if list.body != nil {
+		v3.RequestBody = list.body
+	} else if list.bodyParam != nil {
+		v3.RequestBody = &openapi3.RequestBodyOrReference{
+			Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: c.requestBody(list.bodyParam, consumes)},
+		}
+	} else if len(list.form) > 0 {
+		v3.RequestBody = &openapi3.RequestBodyOrReference{
+			Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: c.formRequestBody(list.form, consumes)},
+		}
+	}
+	if op.Responses != nil {
+		v3.Responses.SpecificationExtension = c.extensions(op.Responses.VendorExtension)
+		for _, pair := range op.Responses.ResponseCode {
+			var response *openapi3.ResponseOrReference
+			if r := pair.Value.GetResponse(); r != nil {
+				response = &openapi3.ResponseOrReference{
+					Oneof: &openapi3.ResponseOrReference_Response{Response: c.response(r, produces)},
+				}
+			} else {
+				response = &openapi3.ResponseOrReference{
+					Oneof: &openapi3.ResponseOrReference_Reference{
+						Reference: &openapi3.Reference{XRef: v3Reference(pair.Value.GetJsonReference().GetXRef())},
+					},
+				}
+			}
+			if pair.Name == "default" {
+				v3.Responses.Default = response
+			} else {
+				v3.Responses.ResponseOrReference = append(v3.Responses.ResponseOrReference,
+					&openapi3.NamedResponseOrReference{Name: pair.Name, Value: response})
+			}
+		}
+	}
+	return v3
+}
@@ -0,0 +1,904 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	openapi2 "github.com/google/gnostic/openapiv2"
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// A v3Converter holds the state of a conversion from OpenAPI v3 to v2.
+type v3Converter struct {
+	document *openapi3.Document
+	// schemas and requestBodies are the components of the document, which
+	// are needed to convert form request bodies that refer to them.
+	schemas       map[string]*openapi3.SchemaOrReference
+	requestBodies map[string]*openapi3.RequestBody
+}
+
+// OpenAPIv2FromOpenAPIv3 converts an OpenAPI v3 document to OpenAPI v2.
+// The host, base path, and schemes are taken from the servers of the
+// document, request bodies become body or form data parameters, and
+// references to components are rewritten to refer to the corresponding
+// named objects.
+//
+// OpenAPI v2 is less expressive, so some parts of documents are dropped:
+// cookie parameters, oneOf, anyOf, and not schemas, callbacks, links, and
+// security schemes other than basic authentication, API keys, and OAuth 2.
+// Only one media type of each request body and response keeps its schema.
+func OpenAPIv2FromOpenAPIv3(d *openapi3.Document) (*openapi2.Document, error) {
+	if d == nil {
+		return nil, errors.New("no OpenAPI v3 document")
+	}
+	c := &v3Converter{
+		document:      d,
+		schemas:       make(map[string]*openapi3.SchemaOrReference),
+		requestBodies: make(map[string]*openapi3.RequestBody),
+	}
+	if components := d.Components; components != nil {
+		if components.Schemas != nil {
+			for _, pair := range components.Schemas.AdditionalProperties {
+				c.schemas[pair.Name] = pair.Value
+			}
+		}
+		if components.RequestBodies != nil {
+			for _, pair := range components.RequestBodies.AdditionalProperties {
+				if body := pair.Value.GetRequestBody(); body != nil {
+					c.requestBodies[pair.Name] = body
+				}
+			}
+		}
+	}
+	v2 := &openapi2.Document{
+		Swagger:         "2.0",
+		Info:            c.info(d.Info),
+		Paths:           &openapi2.Paths{},
+		Security:        c.securityRequirements(d.Security),
+		ExternalDocs:    c.externalDocs(d.ExternalDocs),
+		VendorExtension: c.extensions(d.SpecificationExtension),
+	}
+	c.setServers(v2)
+	c.setComponents(v2)
+	for _, tag := range d.Tags {
+		v2.Tags = append(v2.Tags, &openapi2.Tag{
+			Name:            tag.Name,
+			Description:     tag.Description,
+			ExternalDocs:    c.externalDocs(tag.ExternalDocs),
+			VendorExtension: c.extensions(tag.SpecificationExtension),
+		})
+	}
+	if d.Paths != nil {
+		v2.Paths.VendorExtension = c.extensions(d.Paths.SpecificationExtension)
+		for _, pair := range d.Paths.Path {
+			v2.Paths.Path = append(v2.Paths.Path, &openapi2.NamedPathItem{
+				Name:  pair.Name,
+				Value: c.pathItem(pair.Value),
+			})
+		}
+	}
+	return v2, nil
+}
+
+// v2Reference rewrites a reference to a component of an OpenAPI v3
+// document as a reference to the corresponding named object. Request
+// bodies become body parameters with the same names.
+func v2Reference(ref string) string {
+	for _, r := range []struct{ from, to string }{
+		{componentsSchemasPrefix, definitionsPrefix},
+		{componentsParamsPrefix, parametersPrefix},
+		{componentsBodiesPrefix, parametersPrefix},
+		{componentsResponsesPrefix, responsesPrefix},
+	} {
+		if i := strings.Index(ref, r.from); i >= 0 {
+			return ref[:i] + r.to + ref[i+len(r.from):]
+		}
+	}
+	return ref
+}
+
+func (c *v3Converter) extensions(extensions []*openapi3.NamedAny) []*openapi2.NamedAny {
+	var result []*openapi2.NamedAny
+	for _, e := range extensions {
+		result = append(result, &openapi2.NamedAny{Name: e.Name, Value: c.any(e.Value)})
+	}
+	return result
+}
+
+func (c *v3Converter) any(a *openapi3.Any) *openapi2.Any {
+	if a == nil {
+		return nil
+	}
+	return &openapi2.Any{Value: a.Value, Yaml: a.Yaml}
+}
+
+func (c *v3Converter) anys(values []*openapi3.Any) []*openapi2.Any {
+	var result []*openapi2.Any
+	for _, value := range values {
+		result = append(result, c.any(value))
+	}
+	return result
+}
+
+func (c *v3Converter) defaultValue(d *openapi3.DefaultType) *openapi2.Any {
+	if d == nil {
+		return nil
+	}
+	switch v := d.Oneof.(type) {
+	case *openapi3.DefaultType_Boolean:
+		return &openapi2.Any{Yaml: strconv.FormatBool(v.Boolean)}
+	case *openapi3.DefaultType_Number:
+		return &openapi2.Any{Yaml: strconv.FormatFloat(v.Number, 'g', -1, 64)}
+	case *openapi3.DefaultType_String_:
+		return &openapi2.Any{Yaml: strconv.Quote(v.String_)}
+	}
+	return nil
+}
+
+func (c *v3Converter) externalDocs(docs *openapi3.ExternalDocs) *openapi2.ExternalDocs {
+	if docs == nil {
+		return nil
+	}
+	return &openapi2.ExternalDocs{
+		Description:     docs.Description,
+		Url:             docs.Url,
+		VendorExtension: c.extensions(docs.SpecificationExtension),
+	}
+}
+
+func (c *v3Converter) info(info *openapi3.Info) *openapi2.Info {
+	if info == nil {
+		return &openapi2.Info{}
+	}
+	v2 := &openapi2.Info{
+		Title:           info.Title,
+		Description:     info.Description,
+		TermsOfService:  info.TermsOfService,
+		Version:         info.Version,
+		VendorExtension: c.extensions(info.SpecificationExtension),
+	}
+	if contact := info.Contact; contact != nil {
+		v2.Contact = &openapi2.Contact{
+			Name:            contact.Name,
+			Url:             contact.Url,
+			Email:           contact.Email,
+			VendorExtension: c.extensions(contact.SpecificationExtension),
+		}
+	}
+	if license := info.License; license != nil {
+		v2.License = &openapi2.License{
+			Name:            license.Name,
+			Url:             license.Url,
+			VendorExtension: c.extensions(license.SpecificationExtension),
+		}
+	}
+	return v2
+}
+
+// serverURL returns the URL of a server with the default values of its
+// variables.
+func serverURL(server *openapi3.Server) string {
+	u := server.Url
+	if server.Variables != nil {
+		for _, pair := range server.Variables.AdditionalProperties {
+			u = strings.Replace(u, "{"+pair.Name+"}", pair.Value.Default, -1)
+		}
+	}
+	return u
+}
+
+// setServers sets the host and base path of a document from the URL of
+// its first server, and its schemes from the servers with the same host
+// and path.
+func (c *v3Converter) setServers(v2 *openapi2.Document) {
+	if len(c.document.Servers) == 0 {
+		return
+	}
+	first, err := url.Parse(serverURL(c.document.Servers[0]))
+	if err != nil {
+		return
+	}
+	v2.Host = first.Host
+	v2.BasePath = strings.TrimSuffix(first.Path, "/")
+	if first.Host == "" {
+		return
+	}
+	for _, server := range c.document.Servers {
+		u, err := url.Parse(serverURL(server))
+		if err != nil || u.Host != first.Host || strings.TrimSuffix(u.Path, "/") != v2.BasePath {
+			continue
+		}
+		if u.Scheme != "" && !contains(v2.Schemes, u.Scheme) {
+			v2.Schemes = append(v2.Schemes, u.Scheme)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *v3Converter) setComponents(v2 *openapi2.Document) {
+	components := c.document.Components
+	if components == nil {
+		return
+	}
+	if components.Schemas != nil {
+		v2.Definitions = &openapi2.Definitions{}
+		for _, pair := range components.Schemas.AdditionalProperties {
+			v2.Definitions.AdditionalProperties = append(v2.Definitions.AdditionalProperties,
+				&openapi2.NamedSchema{Name: pair.Name, Value: c.schemaOrReference(pair.Value)})
+		}
+	}
+	addParameter := func(name string, p *openapi2.Parameter) {
+		if v2.Parameters == nil {
+			v2.Parameters = &openapi2.ParameterDefinitions{}
+		}
+		v2.Parameters.AdditionalProperties = append(v2.Parameters.AdditionalProperties,
+			&openapi2.NamedParameter{Name: name, Value: p})
+	}
+	if components.Parameters != nil {
+		for _, pair := range components.Parameters.AdditionalProperties {
+			if p := c.parameter(pair.Value.GetParameter()); p != nil {
+				addParameter(pair.Name, p)
+			}
+		}
+	}
+	if components.RequestBodies != nil {
+		for _, pair := range components.RequestBodies.AdditionalProperties {
+			if body := pair.Value.GetRequestBody(); body != nil {
+				schema, _ := c.preferredSchema(body.Content)
+				addParameter(pair.Name, &openapi2.Parameter{
+					Oneof: &openapi2.Parameter_BodyParameter{
+						BodyParameter: &openapi2.BodyParameter{
+							Name:            "body",
+							In:              "body",
+							Description:     body.Description,
+							Required:        body.Required,
+							Schema:          c.schemaOrReference(schema),
+							VendorExtension: c.extensions(body.SpecificationExtension),
+						},
+					},
+				})
+			}
+		}
+	}
+	if components.Responses != nil {
+		for _, pair := range components.Responses.AdditionalProperties {
+			if response := pair.Value.GetResponse(); response != nil {
+				if v2.Responses == nil {
+					v2.Responses = &openapi2.ResponseDefinitions{}
+				}
+				r, _ := c.response(response)
+				v2.Responses.AdditionalProperties = append(v2.Responses.AdditionalProperties,
+					&openapi2.NamedResponse{Name: pair.Name, Value: r})
+			}
+		}
+	}
+	if components.SecuritySchemes != nil {
+		for _, pair := range components.SecuritySchemes.AdditionalProperties {
+			if item := c.securityScheme(pair.Value.GetSecurityScheme()); item != nil {
+				if v2.SecurityDefinitions == nil {
+					v2.SecurityDefinitions = &openapi2.SecurityDefinitions{}
+				}
+				v2.SecurityDefinitions.AdditionalProperties = append(v2.SecurityDefinitions.AdditionalProperties,
+					&openapi2.NamedSecurityDefinitionsItem{Name: pair.Name, Value: item})
+			}
+		}
+	}
+}
+
+func (c *v3Converter) schemaOrReference(schema *openapi3.SchemaOrReference) *openapi2.Schema {
+	if schema == nil {
+		return nil
+	}
+	if ref := schema.GetReference(); ref != nil {
+		return &openapi2.Schema{XRef: v2Reference(ref.XRef)}
+	}
+	return c.schema(schema.GetSchema())
+}
+
+func (c *v3Converter) schemasOrReferences(schemas []*openapi3.SchemaOrReference) []*openapi2.Schema {
+	var result []*openapi2.Schema
+	for _, schema := range schemas {
+		result = append(result, c.schemaOrReference(schema))
+	}
+	return result
+}
+
+// schema converts a schema. Nullable schemas get the x-nullable extension.
+func (c *v3Converter) schema(schema *openapi3.Schema) *openapi2.Schema {
+	if schema == nil {
+		return nil
+	}
+	v2 := &openapi2.Schema{
+		Title:            schema.Title,
+		Description:      schema.Description,
+		Format:           schema.Format,
+		Default:          c.defaultValue(schema.Default),
+		MultipleOf:       schema.MultipleOf,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		MaxProperties:    schema.MaxProperties,
+		MinProperties:    schema.MinProperties,
+		Required:         schema.Required,
+		Enum:             c.anys(schema.Enum),
+		AllOf:            c.schemasOrReferences(schema.AllOf),
+		ReadOnly:         schema.ReadOnly,
+		ExternalDocs:     c.externalDocs(schema.ExternalDocs),
+		Example:          c.any(schema.Example),
+		VendorExtension:  c.extensions(schema.SpecificationExtension),
+	}
+	if schema.Type != "" {
+		v2.Type = &openapi2.TypeItem{Value: []string{schema.Type}}
+	}
+	if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+		v2.Items = &openapi2.ItemsItem{Schema: c.schemasOrReferences(schema.Items.SchemaOrReference[:1])}
+	}
+	if schema.Properties != nil {
+		v2.Properties = &openapi2.Properties{}
+		for _, pair := range schema.Properties.AdditionalProperties {
+			v2.Properties.AdditionalProperties = append(v2.Properties.AdditionalProperties,
+				&openapi2.NamedSchema{Name: pair.Name, Value: c.schemaOrReference(pair.Value)})
+		}
+	}
+	if additional := schema.AdditionalProperties; additional != nil {
+		if s := additional.GetSchemaOrReference(); s != nil {
+			v2.AdditionalProperties = &openapi2.AdditionalPropertiesItem{
+				Oneof: &openapi2.AdditionalPropertiesItem_Schema{Schema: c.schemaOrReference(s)},
+			}
+		} else {
+			v2.AdditionalProperties = &openapi2.AdditionalPropertiesItem{
+				Oneof: &openapi2.AdditionalPropertiesItem_Boolean{Boolean: additional.GetBoolean()},
+			}
+		}
+	}
+	if schema.Discriminator != nil {
+		v2.Discriminator = schema.Discriminator.PropertyName
+	}
+	if xml := schema.Xml; xml != nil {
+		v2.Xml = &openapi2.Xml{
+			Name:            xml.Name,
+			Namespace:       xml.Namespace,
+			Prefix:          xml.Prefix,
+			Attribute:       xml.Attribute,
+			Wrapped:         xml.Wrapped,
+			VendorExtension: c.extensions(xml.SpecificationExtension),
+		}
+	}
+	if schema.Nullable {
+		v2.VendorExtension = append(v2.VendorExtension, &openapi2.NamedAny{Name: "x-nullable", Value: &openapi2.Any{Yaml: "true"}})
+	}
+	return v2
+}
+
+// primitiveSchema returns the fields of a schema that can describe values
+// of non-body parameters and headers. Schemas that are references or
+// objects can't be used for these values, so they are described as strings.
+func (c *v3Converter) primitiveSchema(schemaOrReference *openapi3.SchemaOrReference) *simpleSchema {
+	schema := schemaOrReference.GetSchema()
+	if schema == nil || schema.Type == "object" || schema.Type == "" {
+		return &simpleSchema{Type: "string"}
+	}
+	s := &simpleSchema{
+		Type:             schema.Type,
+		Format:           schema.Format,
+		Default:          c.defaultValue(schema.Default),
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		Enum:             c.anys(schema.Enum),
+		MultipleOf:       schema.MultipleOf,
+	}
+	if schema.Type == "string" && schema.Format == "binary" {
+		s.Type = "file"
+		s.Format = ""
+	}
+	if schema.Type == "array" {
+		var items *openapi3.SchemaOrReference
+		if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+			items = schema.Items.SchemaOrReference[0]
+		}
+		i := c.primitiveSchema(items)
+		s.Items = &openapi2.PrimitivesItems{
+			Type:             i.Type,
+			Format:           i.Format,
+			Items:            i.Items,
+			Default:          i.Default,
+			Maximum:          i.Maximum,
+			ExclusiveMaximum: i.ExclusiveMaximum,
+			Minimum:          i.Minimum,
+			ExclusiveMinimum: i.ExclusiveMinimum,
+			MaxLength:        i.MaxLength,
+			MinLength:        i.MinLength,
+			Pattern:          i.Pattern,
+			MaxItems:         i.MaxItems,
+			MinItems:         i.MinItems,
+			UniqueItems:      i.UniqueItems,
+			Enum:             i.Enum,
+			MultipleOf:       i.MultipleOf,
+		}
+	}
+	return s
+}
+
+// collectionFormat returns the collection format of an array parameter.
+// Because the models can't distinguish an explode field that is false
+// from one that is absent, form style is only exploded if explode is true
+// or the parameter has no style.
+func collectionFormat(p *openapi3.Parameter) string {
+	switch p.Style {
+	case "":
+		if p.In == "query" {
+			return "multi"
+		}
+	case "form":
+		if p.Explode {
+			return "multi"
+		}
+	case "spaceDelimited":
+		return "ssv"
+	case "pipeDelimited":
+		return "pipes"
+	}
+	return "csv"
+}
+
+// parameter converts a query, path, or header parameter. It returns nil
+// for cookie parameters, which can't be described in OpenAPI v2.
+func (c *v3Converter) parameter(p *openapi3.Parameter) *openapi2.Parameter {
+	if p == nil {
+		return nil
+	}
+	s := c.primitiveSchema(p.Schema)
+	var format string
+	if s.Type == "array" {
+		format = collectionFormat(p)
+	}
+	extensions := c.extensions(p.SpecificationExtension)
+	var nonBody *openapi2.NonBodyParameter
+	switch p.In {
+	case "query":
+		nonBody = &openapi2.NonBodyParameter{Oneof: &openapi2.NonBodyParameter_QueryParameterSubSchema{
+			QueryParameterSubSchema: &openapi2.QueryParameterSubSchema{
+				Name: p.Name, In: p.In, Description: p.Description, Required: p.Required,
+				AllowEmptyValue: p.AllowEmptyValue, CollectionFormat: format,
+				Type: s.Type, Format: s.Format, Items: s.Items, Default: s.Default,
+				Maximum: s.Maximum, ExclusiveMaximum: s.ExclusiveMaximum,
+				Minimum: s.Minimum, ExclusiveMinimum: s.ExclusiveMinimum,
+				MaxLength: s.MaxLength, MinLength: s.MinLength, Pattern: s.Pattern,
+				MaxItems: s.MaxItems, MinItems: s.MinItems, UniqueItems: s.UniqueItems,
+				Enum: s.Enum, MultipleOf: s.MultipleOf, VendorExtension: extensions,
+			},
+		}}
+	case "header":
+		nonBody = &openapi2.NonBodyParameter{Oneof: &openapi2.NonBodyParameter_HeaderParameterSubSchema{
+			HeaderParameterSubSchema: &openapi2.HeaderParameterSubSchema{
+				Name: p.Name, In: p.In, Description: p.Description, Required: p.Required,
+				CollectionFormat: format, Type: s.Type, Format: s.Format, Items: s.Items, Default: s.Default,
+				Maximum: s.Maximum, ExclusiveMaximum: s.ExclusiveMaximum,
+				Minimum: s.Minimum, ExclusiveMinimum: s.ExclusiveMinimum,
+				MaxLength: s.MaxLength, MinLength: s.MinLength, Pattern: s.Pattern,
+				MaxItems: s.MaxItems, MinItems: s.MinItems, UniqueItems: s.UniqueItems,
+				Enum: s.Enum, MultipleOf: s.MultipleOf, VendorExtension: extensions,
+			},
+		}}
+	case "path":
+		nonBody = &openapi2.NonBodyParameter{Oneof: &openapi2.NonBodyParameter_PathParameterSubSchema{
+			PathParameterSubSchema: &openapi2.PathParameterSubSchema{
+				Name: p.Name, In: p.In, Description: p.Description, Required: true,
+				CollectionFormat: format, Type: s.Type, Format: s.Format, Items: s.Items, Default: s.Default,
+				Maximum: s.Maximum, ExclusiveMaximum: s.ExclusiveMaximum,
+				Minimum: s.Minimum, ExclusiveMinimum: s.ExclusiveMinimum,
+				MaxLength: s.MaxLength, MinLength: s.MinLength, Pattern: s.Pattern,
+				MaxItems: s.MaxItems, MinItems: s.MinItems, UniqueItems: s.UniqueItems,
+				Enum: s.Enum, MultipleOf: s.MultipleOf, VendorExtension: extensions,
+			},
+		}}
+	default:
+		return nil
+	}
+	return &openapi2.Parameter{Oneof: &openapi2.Parameter_NonBodyParameter{NonBodyParameter: nonBody}}
+}
+
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded"
+}
+
+// preferredSchema returns the schema of the preferred media type of some
+// content, which is the first JSON media type or else the first media type.
+// It also returns the names of all of the media types.
+func (c *v3Converter) preferredSchema(content *openapi3.MediaTypes) (*openapi3.SchemaOrReference, []string) {
+	if content == nil {
+		return nil, nil
+	}
+	var schema *openapi3.SchemaOrReference
+	var mediaTypes []string
+	for _, pair := range content.AdditionalProperties {
+		mediaTypes = append(mediaTypes, pair.Name)
+	}
+	for _, pair := range content.AdditionalProperties {
+		if strings.Contains(pair.Name, "json") {
+			return pair.Value.Schema, mediaTypes
+		}
+	}
+	if len(content.AdditionalProperties) > 0 {
+		schema = content.AdditionalProperties[0].Value.Schema
+	}
+	return schema, mediaTypes
+}
+
+// localSchema returns the schema of a form, following a reference to a
+// schema component.
+func (c *v3Converter) localSchema(schema *openapi3.SchemaOrReference) *openapi3.Schema {
+	for i := 0; schema != nil && i < 8; i++ {
+		if s := schema.GetSchema(); s != nil {
+			return s
+		}
+		ref := schema.GetReference().GetXRef()
+		if !strings.HasPrefix(ref, componentsSchemasPrefix) {
+			return nil
+		}
+		schema = c.schemas[strings.TrimPrefix(ref, componentsSchemasPrefix)]
+	}
+	return nil
+}
+
+// requestBodyParameters converts a request body to parameters. Forms with
+// object schemas become form data parameters, and other bodies become a
+// body parameter. It also returns the media types of the body.
+func (c *v3Converter) requestBodyParameters(body *openapi3.RequestBodyOrReference) ([]*openapi2.ParametersItem, []string) {
+	if body == nil {
+		return nil, nil
+	}
+	if ref := body.GetReference(); ref != nil {
+		var mediaTypes []string
+		if strings.HasPrefix(ref.XRef, componentsBodiesPrefix) {
+			if b, ok := c.requestBodies[strings.TrimPrefix(ref.XRef, componentsBodiesPrefix)]; ok {
+				_, mediaTypes = c.preferredSchema(b.Content)
+			}
+		}
+		return []*openapi2.ParametersItem{{
+			Oneof: &openapi2.ParametersItem_JsonReference{
+				JsonReference: &openapi2.JsonReference{XRef: v2Reference(ref.XRef)},
+			},
+		}}, mediaTypes
+	}
+	requestBody := body.GetRequestBody()
+	schema, mediaTypes := c.preferredSchema(requestBody.Content)
+	var forms []string
+	for _, mediaType := range mediaTypes {
+		if isFormMediaType(mediaType) {
+			forms = append(forms, mediaType)
+		}
+	}
+	if len(forms) > 0 {
+		form := requestBody.Content.AdditionalProperties[0].Value.Schema
+		for _, pair := range requestBody.Content.AdditionalProperties {
+			if pair.Name == forms[0] {
+				form = pair.Value.Schema
+			}
+		}
+		if s := c.localSchema(form); s != nil && s.Properties != nil {
+			return c.formParameters(s), forms
+		}
+	}
+	return []*openapi2.ParametersItem{{
+		Oneof: &openapi2.ParametersItem_Parameter{
+			Parameter: &openapi2.Parameter{
+				Oneof: &openapi2.Parameter_BodyParameter{
+					BodyParameter: &openapi2.BodyParameter{
+						Name:            "body",
+						In:              "body",
+						Description:     requestBody.Description,
+						Required:        requestBody.Required,
+						Schema:          c.schemaOrReference(schema),
+						VendorExtension: c.extensions(requestBody.SpecificationExtension),
+					},
+				},
+			},
+		},
+	}}, mediaTypes
+}
+
+// formParameters converts the properties of the schema of a form to form
+// data parameters.
+func (c *v3Converter) formParameters(schema *openapi3.Schema) []*openapi2.ParametersItem {
+	var items []*openapi2.ParametersItem
+	for _, pair := range schema.Properties.AdditionalProperties {
+		s := c.primitiveSchema(pair.Value)
+		var format string
+		if s.Type == "array" {
+			format = "multi"
+		}
+		var description string
+		if property := pair.Value.GetSchema(); property != nil {
+			description = property.Description
+		}
+		items = append(items, &openapi2.ParametersItem{
+			Oneof: &openapi2.ParametersItem_Parameter{
+				Parameter: &openapi2.Parameter{
+					Oneof: &openapi2.Parameter_NonBodyParameter{
+						NonBodyParameter: &openapi2.NonBodyParameter{
+							Oneof: &openapi2.NonBodyParameter_FormDataParameterSubSchema{
+								FormDataParameterSubSchema: &openapi2.FormDataParameterSubSchema{
+									Name: pair.Name, In: "formData", Description: description,
+									Required: contains(schema.Required, pair.Name), CollectionFormat: format,
+									Type: s.Type, Format: s.Format, Items: s.Items, Default: s.Default,
+									Maximum: s.Maximum, ExclusiveMaximum: s.ExclusiveMaximum,
+									Minimum: s.Minimum, ExclusiveMinimum: s.ExclusiveMinimum,
+									MaxLength: s.MaxLength, MinLength: s.MinLength, Pattern: s.Pattern,
+									MaxItems: s.MaxItems, MinItems: s.MinItems, UniqueItems: s.UniqueItems,
+									Enum: s.Enum, MultipleOf: s.MultipleOf,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	return items
+}
+
+func (c *v3Converter) header(header *openapi3.Header) *openapi2.Header {
+	s := c.primitiveSchema(header.Schema)
+	var format string
+	if s.Type == "array" {
+		format = "csv"
+	}
+	return &openapi2.Header{
+		Description: header.Description, CollectionFormat: format,
+		Type: s.Type, Format: s.Format, Items: s.Items, Default: s.Default,
+		Maximum: s.Maximum, ExclusiveMaximum: s.ExclusiveMaximum,
+		Minimum: s.Minimum, ExclusiveMinimum: s.ExclusiveMinimum,
+		MaxLength: s.MaxLength, MinLength: s.MinLength, Pattern: s.Pattern,
+		MaxItems: s.MaxItems, MinItems: s.MinItems, UniqueItems: s.UniqueItems,
+		Enum: s.Enum, MultipleOf: s.MultipleOf,
+		VendorExtension: c.extensions(header.SpecificationExtension),
+	}
+}
+
+// response converts a response and returns it with the media types that
+// it produces.
+func (c *v3Converter) response(response *openapi3.Response) (*openapi2.Response, []string) {
+	v2 := &openapi2.Response{
+		Description:     response.Description,
+		VendorExtension: c.extensions(response.SpecificationExtension),
+	}
+	if response.Headers != nil {
+		for _, pair := range response.Headers.AdditionalProperties {
+			if header := pair.Value.GetHeader(); header != nil {
+				if v2.Headers == nil {
+					v2.Headers = &openapi2.Headers{}
+				}
+				v2.Headers.AdditionalProperties = append(v2.Headers.AdditionalProperties,
+					&openapi2.NamedHeader{Name: pair.Name, Value: c.header(header)})
+			}
+		}
+	}
+	schema, mediaTypes := c.preferredSchema(response.Content)
+	if schema != nil {
+		v2.Schema = &openapi2.SchemaItem{Oneof: &openapi2.SchemaItem_Schema{Schema: c.schemaOrReference(schema)}}
+	}
+	if response.Content != nil {
+		for _, pair := range response.Content.AdditionalProperties {
+			if pair.Value.Example != nil {
+				if v2.Examples == nil {
+					v2.Examples = &openapi2.Examples{}
+				}
+				v2.Examples.AdditionalProperties = append(v2.Examples.AdditionalProperties,
+					&openapi2.NamedAny{Name: pair.Name, Value: c.any(pair.Value.Example)})
+			}
+		}
+	}
+	return v2, mediaTypes
+}
+
+// securityScheme converts a security scheme. OAuth 2 schemes become
+// definitions of their first flow, and schemes that can't be described in
+// OpenAPI v2 are dropped.
+func (c *v3Converter) securityScheme(scheme *openapi3.SecurityScheme) *openapi2.SecurityDefinitionsItem {
+	if scheme == nil {
+		return nil
+	}
+	extensions := c.extensions(scheme.SpecificationExtension)
+	scopes := func(flow *openapi3.OauthFlow) *openapi2.Oauth2Scopes {
+		v2 := &openapi2.Oauth2Scopes{}
+		if flow.Scopes != nil {
+			for _, pair := range flow.Scopes.AdditionalProperties {
+				v2.AdditionalProperties = append(v2.AdditionalProperties, &openapi2.NamedString{Name: pair.Name, Value: pair.Value})
+			}
+		}
+		return v2
+	}
+	switch scheme.Type {
+	case "http":
+		if strings.ToLower(scheme.Scheme) == "basic" {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_BasicAuthenticationSecurity{
+				BasicAuthenticationSecurity: &openapi2.BasicAuthenticationSecurity{
+					Type: "basic", Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+	case "apiKey":
+		if scheme.In == "query" || scheme.In == "header" {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_ApiKeySecurity{
+				ApiKeySecurity: &openapi2.ApiKeySecurity{
+					Type: "apiKey", Name: scheme.Name, In: scheme.In, Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+	case "oauth2":
+		flows := scheme.Flows
+		if flows == nil {
+			return nil
+		}
+		if flow := flows.Implicit; flow != nil {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_Oauth2ImplicitSecurity{
+				Oauth2ImplicitSecurity: &openapi2.Oauth2ImplicitSecurity{
+					Type: "oauth2", Flow: "implicit", AuthorizationUrl: flow.AuthorizationUrl, Scopes: scopes(flow),
+					Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+		if flow := flows.Password; flow != nil {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_Oauth2PasswordSecurity{
+				Oauth2PasswordSecurity: &openapi2.Oauth2PasswordSecurity{
+					Type: "oauth2", Flow: "password", TokenUrl: flow.TokenUrl, Scopes: scopes(flow),
+					Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+		if flow := flows.ClientCredentials; flow != nil {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_Oauth2ApplicationSecurity{
+				Oauth2ApplicationSecurity: &openapi2.Oauth2ApplicationSecurity{
+					Type: "oauth2", Flow: "application", TokenUrl: flow.TokenUrl, Scopes: scopes(flow),
+					Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+		if flow := flows.AuthorizationCode; flow != nil {
+			return &openapi2.SecurityDefinitionsItem{Oneof: &openapi2.SecurityDefinitionsItem_Oauth2AccessCodeSecurity{
+				Oauth2AccessCodeSecurity: &openapi2.Oauth2AccessCodeSecurity{
+					Type: "oauth2", Flow: "accessCode", AuthorizationUrl: flow.AuthorizationUrl, TokenUrl: flow.TokenUrl,
+					Scopes: scopes(flow), Description: scheme.Description, VendorExtension: extensions,
+				},
+			}}
+		}
+	}
+	return nil
+}
+
+func (c *v3Converter) securityRequirements(requirements []*openapi3.SecurityRequirement) []*openapi2.SecurityRequirement {
+	var result []*openapi2.SecurityRequirement
+	for _, requirement := range requirements {
+		v2 := &openapi2.SecurityRequirement{}
+		for _, pair := range requirement.AdditionalProperties {
+			v2.AdditionalProperties = append(v2.AdditionalProperties,
+				&openapi2.NamedStringArray{Name: pair.Name, Value: &openapi2.StringArray{Value: pair.Value.GetValue()}})
+		}
+		result = append(result, v2)
+	}
+	return result
+}
+
+func (c *v3Converter) parameters(parameters []*openapi3.ParameterOrReference) []*openapi2.ParametersItem {
+	var items []*openapi2.ParametersItem
+	for _, p := range parameters {
+		if ref := p.GetReference(); ref != nil {
+			items = append(items, &openapi2.ParametersItem{
+				Oneof: &openapi2.ParametersItem_JsonReference{
+					JsonReference: &openapi2.JsonReference{XRef: v2Reference(ref.XRef)},
+				},
+			})
+		} else if parameter := c.parameter(p.GetParameter()); parameter != nil {
+			items = append(items, &openapi2.ParametersItem{
+				Oneof: &openapi2.ParametersItem_Parameter{Parameter: parameter},
+			})
+		}
+	}
+	return items
+}
+
+func (c *v3Converter) pathItem(item *openapi3.PathItem) *openapi2.PathItem {
+	return &openapi2.PathItem{
+		XRef:            item.XRef,
+		Get:             c.operation(item.Get),
+		Put:             c.operation(item.Put),
+		Post:            c.operation(item.Post),
+		Delete:          c.operation(item.Delete),
+		Options:         c.operation(item.Options),
+		Head:            c.operation(item.Head),
+		Patch:           c.operation(item.Patch),
+		Parameters:      c.parameters(item.Parameters),
+		VendorExtension: c.extensions(item.SpecificationExtension),
+	}
+}
+
+// operation converts an operation. It consumes the media types of its
+// request body and produces the media types of its responses.
+func (c *v3Converter) operation(op *openapi3.Operation) *openapi2.Operation {
+	if op == nil {
+		return nil
+	}
+	v2 := &openapi2.Operation{
+		Tags:            op.Tags,
+		Summary:         op.Summary,
+		Description:     op.Description,
+		ExternalDocs:    c.externalDocs(op.ExternalDocs),
+		OperationId:     op.OperationId,
+		Parameters:      c.parameters(op.Parameters),
+		Responses:       &openapi2.Responses{},
+		Deprecated:      op.Deprecated,
+		Security:        c.securityRequirements(op.Security),
+		VendorExtension: c.extensions(op.SpecificationExtension),
+	}
+	body, consumes := c.requestBodyParameters(op.RequestBody)
+	v2.Parameters = append(v2.Parameters, body...)
+	v2.Consumes = consumes
+	if op.Responses == nil {
+		return v2
+	}
+	v2.Responses.VendorExtension = c.extensions(op.Responses.SpecificationExtension)
+	addResponse := func(name string, response *openapi3.ResponseOrReference) {
+		value := &openapi2.ResponseValue{}
+		if r := response.GetResponse(); r != nil {
+			v2Response, produces := c.response(r)
+			value.Oneof = &openapi2.ResponseValue_Response{Response: v2Response}
+			for _, mediaType := range produces {
+				if !contains(v2.Produces, mediaType) {
+					v2.Produces = append(v2.Produces, mediaType)
+				}
+			}
+		} else {
+			value.Oneof = &openapi2.ResponseValue_JsonReference{
+				JsonReference: &openapi2.JsonReference{XRef: v2Reference(response.GetReference().GetXRef())},
+			}
+		}
+		v2.Responses.ResponseCode = append(v2.Responses.ResponseCode, &openapi2.NamedResponseValue{Name: name, Value: value})
+	}
+	for _, pair := range op.Responses.ResponseOrReference {
+		addResponse(pair.Name, pair.Value)
+	}
+	if op.Responses.Default != nil {
+		addResponse("default", op.Responses.Default)
+	}
+	return v2
+}
@@ -0,0 +1,654 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// Postman Collection v2.1 JSON is read into these types. Many values in
+// collections can be written in several forms, which are normalized by
+// the UnmarshalJSON methods.
+type postmanCollection struct {
+	Info     postmanInfo        `json:"info"`
+	Item     []*postmanItem     `json:"item"`
+	Variable []*postmanVariable `json:"variable"`
+	Auth     *postmanAuth       `json:"auth"`
+}
+
+type postmanInfo struct {
+	Name        string             `json:"name"`
+	Description postmanDescription `json:"description"`
+	Version     postmanVersion     `json:"version"`
+}
+
+type postmanItem struct {
+	Name        string             `json:"name"`
+	Description postmanDescription `json:"description"`
+	// Items are folders if they contain items, and requests otherwise.
+	Item     []*postmanItem     `json:"item"`
+	Request  *postmanRequest    `json:"request"`
+	Response []*postmanResponse `json:"response"`
+	Auth     *postmanAuth       `json:"auth"`
+}
+
+type postmanRequest struct {
+	Method      string             `json:"method"`
+	URL         postmanURL         `json:"url"`
+	Header      []*postmanKeyValue `json:"header"`
+	Body        *postmanBody       `json:"body"`
+	Description postmanDescription `json:"description"`
+	Auth        *postmanAuth       `json:"auth"`
+}
+
+type postmanURL struct {
+	Raw      string             `json:"raw"`
+	Protocol string             `json:"protocol"`
+	Host     postmanStrings     `json:"host"`
+	Port     string             `json:"port"`
+	Path     postmanStrings     `json:"path"`
+	Query    []*postmanKeyValue `json:"query"`
+	Variable []*postmanKeyValue `json:"variable"`
+}
+
+type postmanKeyValue struct {
+	Key         string             `json:"key"`
+	Value       postmanValue       `json:"value"`
+	Type        string             `json:"type"`
+	Disabled    bool               `json:"disabled"`
+	Description postmanDescription `json:"description"`
+}
+
+type postmanVariable postmanKeyValue
+
+type postmanBody struct {
+	Mode       string             `json:"mode"`
+	Raw        string             `json:"raw"`
+	URLEncoded []*postmanKeyValue `json:"urlencoded"`
+	FormData   []*postmanKeyValue `json:"formdata"`
+	GraphQL    json.RawMessage    `json:"graphql"`
+	Options    struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+type postmanResponse struct {
+	Name   string             `json:"name"`
+	Status string             `json:"status"`
+	Code   int                `json:"code"`
+	Header []*postmanKeyValue `json:"header"`
+	Body   string             `json:"body"`
+}
+
+type postmanAuth struct {
+	Type   string             `json:"type"`
+	APIKey []*postmanKeyValue `json:"apikey"`
+}
+
+// A postmanDescription is a string or an object with the string in its
+// content.
+type postmanDescription string
+
+func (d *postmanDescription) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*d = postmanDescription(s)
+		return nil
+	}
+	var v struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = postmanDescription(v.Content)
+	return nil
+}
+
+// A postmanVersion is a string or an object with major, minor, and patch
+// numbers.
+type postmanVersion string
+
+func (version *postmanVersion) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*version = postmanVersion(s)
+		return nil
+	}
+	var v struct {
+		Major, Minor, Patch int
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*version = postmanVersion(strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch))
+	return nil
+}
+
+// postmanStrings are a list of strings or a string with parts separated by
+// dots (for hosts) or slashes (for paths), which are kept as one string.
+type postmanStrings []string
+
+func (s *postmanStrings) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*s = postmanStrings{one}
+		return nil
+	}
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return err
+	}
+	*s = nil
+	for _, part := range parts {
+		// Path segments can also be objects with their strings in values.
+		var segment struct {
+			Value string `json:"value"`
+		}
+		if json.Unmarshal(part, &one) == nil {
+			*s = append(*s, one)
+		} else if json.Unmarshal(part, &segment) == nil {
+			*s = append(*s, segment.Value)
+		}
+	}
+	return nil
+}
+
+// A postmanValue is the value of a variable, header, or parameter, which is
+// usually a string but can be any JSON value.
+type postmanValue string
+
+func (v *postmanValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*v = postmanValue(s)
+		return nil
+	}
+	*v = postmanValue(data)
+	return nil
+}
+
+// A postmanRequest is an object or a string with its URL.
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var url string
+	if json.Unmarshal(data, &url) == nil {
+		*r = postmanRequest{Method: "GET", URL: postmanURL{Raw: url}}
+		return nil
+	}
+	type request postmanRequest
+	return json.Unmarshal(data, (*request)(r))
+}
+
+// A postmanURL is an object or a string with the raw URL.
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if json.Unmarshal(data, &raw) == nil {
+		*u = postmanURL{Raw: raw}
+		return nil
+	}
+	type url postmanURL
+	return json.Unmarshal(data, (*url)(u))
+}
+
+// A postmanConverter holds the state of a conversion from Postman to OpenAPI.
+type postmanConverter struct {
+	importer
+	collection *postmanCollection
+	// variables are the values and descriptions of collection variables.
+	variables map[string]*postmanVariable
+	tags      map[string]bool
+}
+
+// OpenAPIv3FromPostman converts a Postman Collection v2.1 to OpenAPI v3.
+// Requests become operations that are tagged with the names of the folders
+// that contain them, and the hosts of their URLs become servers, with
+// collection variables in the hosts as server variables. Examples of
+// request bodies and saved responses are kept, with schemas that are
+// inferred from the examples in JSON.
+func OpenAPIv3FromPostman(data []byte) (*openapi3.Document, error) {
+	collection := &postmanCollection{}
+	if err := json.Unmarshal(data, collection); err != nil {
+		return nil, err
+	}
+	if collection.Info.Name == "" && len(collection.Item) == 0 {
+		return nil, errors.New("no Postman collection")
+	}
+	c := &postmanConverter{
+		importer: newImporter(&openapi3.Info{
+			Title:       collection.Info.Name,
+			Description: string(collection.Info.Description),
+			Version:     string(collection.Info.Version),
+		}),
+		collection: collection,
+		variables:  map[string]*postmanVariable{},
+		tags:       map[string]bool{},
+	}
+	for _, v := range collection.Variable {
+		c.variables[v.Key] = v
+	}
+	c.document.Security = c.securityRequirements(collection.Auth)
+	c.items(collection.Item, "", nil)
+	c.addDefaultResponses()
+	return c.document, nil
+}
+
+// Converts the requests of a list of items, which are in the folder named
+// tag and which use auth unless they have their own.
+func (c *postmanConverter) items(items []*postmanItem, tag string, auth *postmanAuth) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+		if item.Request == nil {
+			if !c.tags[item.Name] {
+				c.tags[item.Name] = true
+				c.document.Tags = append(c.document.Tags, &openapi3.Tag{
+					Name:        item.Name,
+					Description: string(item.Description),
+				})
+			}
+			c.items(item.Item, item.Name, itemAuth)
+			continue
+		}
+		c.request(item, tag, itemAuth)
+	}
+}
+
+var (
+	// postmanVariablePattern matches references to variables like {{baseUrl}}.
+	postmanVariablePattern = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+	// postmanProtocolPattern matches the protocols of raw URLs.
+	postmanProtocolPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://`)
+)
+
+// Adds an operation for a request, or adds the responses of the request to
+// an operation for the same method and path.
+func (c *postmanConverter) request(item *postmanItem, tag string, auth *postmanAuth) {
+	request := item.Request
+	method := strings.ToLower(request.Method)
+	if method == "" {
+		method = "get"
+	}
+	u := request.URL
+	if u.Raw != "" && len(u.Host) == 0 && len(u.Path) == 0 {
+		u = parsePostmanURL(u.Raw)
+	}
+	server := c.server(u)
+	path, parameters := c.path(u)
+	if op := c.operation(method, path); op != nil {
+		c.addResponses(op, item.Response)
+		return
+	}
+
+	op := &openapi3.Operation{
+		Summary:     item.Name,
+		Description: string(request.Description),
+		OperationId: c.operationID(item.Name, method, path),
+		Responses:   &openapi3.Responses{},
+	}
+	if op.Description == "" {
+		op.Description = string(item.Description)
+	}
+	if tag != "" {
+		op.Tags = []string{tag}
+	}
+	// Requests with hosts that aren't the first server use their own.
+	if server != nil {
+		if len(c.document.Servers) == 0 {
+			c.document.Servers = []*openapi3.Server{server}
+		} else if c.document.Servers[0].Url != server.Url {
+			op.Servers = []*openapi3.Server{server}
+		}
+	}
+	for _, q := range u.Query {
+		if q.Key == "" || hasParameter(parameters, q.Key, "query") {
+			continue
+		}
+		parameters = append(parameters, c.parameter(q, "query"))
+	}
+	contentType := ""
+	for _, h := range request.Header {
+		switch strings.ToLower(h.Key) {
+		case "content-type":
+			contentType = string(h.Value)
+		case "accept", "authorization", "":
+			// Accept headers are described by responses and authorization
+			// by security requirements.
+		default:
+			if !h.Disabled && !hasParameter(parameters, h.Key, "header") {
+				parameters = append(parameters, c.parameter(h, "header"))
+			}
+		}
+	}
+	for _, p := range parameters {
+		op.Parameters = append(op.Parameters, &openapi3.ParameterOrReference{
+			Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: p},
+		})
+	}
+	if body := postmanRequestBody(request.Body, contentType); body != nil {
+		op.RequestBody = &openapi3.RequestBodyOrReference{
+			Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: body},
+		}
+	}
+	if auth != nil && auth.Type == "noauth" && c.document.Security != nil {
+		// Requests without authorization in authorized collections have
+		// an empty requirement, which allows anonymous requests.
+		op.Security = []*openapi3.SecurityRequirement{{}}
+	} else if auth != c.collection.Auth {
+		op.Security = c.securityRequirements(auth)
+	}
+	c.addResponses(op, item.Response)
+
+	c.addOperation(method, path, op)
+}
+
+// Splits a raw URL into its protocol, host, path, and query.
+func parsePostmanURL(raw string) postmanURL {
+	u := postmanURL{Raw: raw}
+	if m := postmanProtocolPattern.FindString(raw); m != "" {
+		u.Protocol = strings.TrimSuffix(m, "://")
+		raw = raw[len(m):]
+	}
+	if i := strings.Index(raw, "#"); i >= 0 {
+		raw = raw[:i]
+	}
+	if i := strings.Index(raw, "?"); i >= 0 {
+		for _, pair := range strings.Split(raw[i+1:], "&") {
+			kv := strings.SplitN(pair, "=", 2)
+			q := &postmanKeyValue{Key: kv[0]}
+			if len(kv) == 2 {
+				q.Value = postmanValue(kv[1])
+			}
+			u.Query = append(u.Query, q)
+		}
+		raw = raw[:i]
+	}
+	host := raw
+	if i := strings.Index(raw, "/"); i >= 0 {
+		host = raw[:i]
+		for _, segment := range strings.Split(strings.Trim(raw[i:], "/"), "/") {
+			if segment != "" {
+				u.Path = append(u.Path, segment)
+			}
+		}
+	}
+	// Ports of hosts that are variables are part of the variables.
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "}}") {
+		host, u.Port = host[:i], host[i+1:]
+	}
+	if host != "" {
+		u.Host = postmanStrings{host}
+	}
+	return u
+}
+
+// Returns a server for the protocol, host, and port of a URL, or nil if the
+// URL has no host.
+func (c *postmanConverter) server(u postmanURL) *openapi3.Server {
+	host := strings.Join(u.Host, ".")
+	if host == "" {
+		return nil
+	}
+	url := host
+	if u.Protocol != "" {
+		url = u.Protocol + "://" + host
+	} else if !strings.HasPrefix(host, "{{") || !strings.HasSuffix(host, "}}") {
+		url = "https://" + host
+	}
+	if u.Port != "" {
+		url += ":" + u.Port
+	}
+	server := &openapi3.Server{}
+	server.Url = postmanVariablePattern.ReplaceAllStringFunc(url, func(s string) string {
+		name := postmanVariablePattern.FindStringSubmatch(s)[1]
+		if server.Variables == nil {
+			server.Variables = &openapi3.ServerVariables{}
+		}
+		for _, v := range server.Variables.AdditionalProperties {
+			if v.Name == name {
+				return "{" + name + "}"
+			}
+		}
+		variable := &openapi3.ServerVariable{}
+		if v, ok := c.variables[name]; ok {
+			variable.Default = string(v.Value)
+			variable.Description = string(v.Description)
+		}
+		server.Variables.AdditionalProperties = append(server.Variables.AdditionalProperties,
+			&openapi3.NamedServerVariable{Name: name, Value: variable})
+		return "{" + name + "}"
+	})
+	return server
+}
+
+// Returns the OpenAPI path of a URL and its path parameters. Segments like
+// :id and {{id}} are path parameters.
+func (c *postmanConverter) path(u postmanURL) (string, []*openapi3.Parameter) {
+	var parameters []*openapi3.Parameter
+	add := func(name string, example postmanValue, description postmanDescription) {
+		if hasParameter(parameters, name, "path") {
+			return
+		}
+		p := &openapi3.Parameter{
+			Name:        name,
+			In:          "path",
+			Description: string(description),
+			Required:    true,
+			Schema:      stringSchema(),
+		}
+		if example != "" && !strings.Contains(string(example), "{{") {
+			p.Example = &openapi3.Any{Yaml: yamlString(string(example))}
+		}
+		parameters = append(parameters, p)
+	}
+	var segments []string
+	for _, segment := range u.Path {
+		if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+			name := segment[1:]
+			var example postmanValue
+			var description postmanDescription
+			for _, v := range u.Variable {
+				if v.Key == name {
+					example, description = v.Value, v.Description
+				}
+			}
+			add(name, example, description)
+			segments = append(segments, "{"+name+"}")
+			continue
+		}
+		segment = postmanVariablePattern.ReplaceAllStringFunc(segment, func(s string) string {
+			name := postmanVariablePattern.FindStringSubmatch(s)[1]
+			var example postmanValue
+			var description postmanDescription
+			if v, ok := c.variables[name]; ok {
+				example, description = v.Value, v.Description
+			}
+			add(name, example, description)
+			return "{" + name + "}"
+		})
+		segments = append(segments, segment)
+	}
+	return "/" + strings.Join(segments, "/"), parameters
+}
+
+// Returns a query or header parameter with the value of a key as its
+// example, unless the value refers to variables.
+func (c *postmanConverter) parameter(kv *postmanKeyValue, in string) *openapi3.Parameter {
+	p := &openapi3.Parameter{
+		Name:        kv.Key,
+		In:          in,
+		Description: string(kv.Description),
+		Schema:      stringSchema(),
+	}
+	if kv.Value != "" && !strings.Contains(string(kv.Value), "{{") {
+		p.Example = &openapi3.Any{Yaml: yamlString(string(kv.Value))}
+	}
+	return p
+}
+
+// Returns the request body of a request, or nil if it has none.
+func postmanRequestBody(body *postmanBody, contentType string) *openapi3.RequestBody {
+	if body == nil {
+		return nil
+	}
+	var media *openapi3.MediaType
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return nil
+		}
+		if contentType == "" {
+			switch body.Options.Raw.Language {
+			case "json":
+				contentType = "application/json"
+			case "xml":
+				contentType = "application/xml"
+			case "html":
+				contentType = "text/html"
+			case "javascript":
+				contentType = "application/javascript"
+			default:
+				contentType = "text/plain"
+			}
+		}
+		media = exampleMediaType(body.Raw, strings.Contains(contentType, "json"))
+	case "urlencoded", "formdata":
+		fields := body.URLEncoded
+		contentType = "application/x-www-form-urlencoded"
+		if body.Mode == "formdata" {
+			fields = body.FormData
+			contentType = "multipart/form-data"
+		}
+		schema := &openapi3.Schema{Type: "object", Properties: &openapi3.Properties{}}
+		for _, field := range fields {
+			property := &openapi3.Schema{Type: "string", Description: string(field.Description)}
+			if field.Type == "file" {
+				property.Format = "binary"
+			} else if field.Value != "" && !strings.Contains(string(field.Value), "{{") {
+				property.Example = &openapi3.Any{Yaml: yamlString(string(field.Value))}
+			}
+			schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: field.Key, Value: schemaValue(property)})
+		}
+		media = &openapi3.MediaType{Schema: schemaValue(schema)}
+	case "graphql":
+		contentType = "application/json"
+		media = exampleMediaType(string(body.GraphQL), true)
+	case "file":
+		contentType = "application/octet-stream"
+		media = &openapi3.MediaType{Schema: schemaValue(&openapi3.Schema{Type: "string", Format: "binary"})}
+	default:
+		return nil
+	}
+	return &openapi3.RequestBody{
+		Content: &openapi3.MediaTypes{
+			AdditionalProperties: []*openapi3.NamedMediaType{{Name: contentType, Value: media}},
+		},
+	}
+}
+
+// Adds the saved responses of a request to an operation. Responses with the
+// same status codes as earlier responses are skipped.
+func (c *postmanConverter) addResponses(op *openapi3.Operation, responses []*postmanResponse) {
+	for _, response := range responses {
+		code := "default"
+		if response.Code != 0 {
+			code = strconv.Itoa(response.Code)
+		}
+		found := false
+		for _, pair := range op.Responses.ResponseOrReference {
+			found = found || pair.Name == code
+		}
+		if found || code == "default" && op.Responses.Default != nil {
+			continue
+		}
+		r := &openapi3.Response{Description: response.Status}
+		if r.Description == "" {
+			r.Description = response.Name
+		}
+		if response.Body != "" {
+			contentType := ""
+			for _, h := range response.Header {
+				if strings.EqualFold(h.Key, "content-type") {
+					contentType = strings.TrimSpace(strings.Split(string(h.Value), ";")[0])
+				}
+			}
+			if contentType == "" {
+				contentType = "text/plain"
+				if json.Valid([]byte(response.Body)) {
+					contentType = "application/json"
+				}
+			}
+			r.Content = &openapi3.MediaTypes{
+				AdditionalProperties: []*openapi3.NamedMediaType{{
+					Name:  contentType,
+					Value: exampleMediaType(response.Body, strings.Contains(contentType, "json")),
+				}},
+			}
+		}
+		value := &openapi3.ResponseOrReference{Oneof: &openapi3.ResponseOrReference_Response{Response: r}}
+		if code == "default" {
+			op.Responses.Default = value
+		} else {
+			op.Responses.ResponseOrReference = append(op.Responses.ResponseOrReference,
+				&openapi3.NamedResponseOrReference{Name: code, Value: value})
+		}
+	}
+}
+
+// Returns security requirements for the authorization of a collection,
+// folder, or request, and adds its security scheme to the document.
+func (c *postmanConverter) securityRequirements(auth *postmanAuth) []*openapi3.SecurityRequirement {
+	if auth == nil {
+		return nil
+	}
+	var name string
+	scheme := &openapi3.SecurityScheme{}
+	switch auth.Type {
+	case "basic":
+		name, scheme.Type, scheme.Scheme = "basicAuth", "http", "basic"
+	case "bearer":
+		name, scheme.Type, scheme.Scheme = "bearerAuth", "http", "bearer"
+	case "apikey":
+		name, scheme.Type, scheme.In, scheme.Name = "apiKeyAuth", "apiKey", "header", "X-API-Key"
+		for _, kv := range auth.APIKey {
+			switch kv.Key {
+			case "key":
+				scheme.Name = string(kv.Value)
+			case "in":
+				scheme.In = string(kv.Value)
+			}
+		}
+		if scheme.Name != "X-API-Key" || scheme.In != "header" {
+			name = identifier(scheme.Name + " " + scheme.In)
+		}
+	default:
+		// Other kinds of authorization aren't converted.
+		return nil
+	}
+	c.addSecurityScheme(name, scheme)
+	return []*openapi3.SecurityRequirement{{
+		AdditionalProperties: []*openapi3.NamedStringArray{{Name: name, Value: &openapi3.StringArray{}}},
+	}}
+}
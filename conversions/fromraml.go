@@ -0,0 +1,667 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// A ramlConverter holds the state of a conversion from RAML to OpenAPI.
+type ramlConverter struct {
+	importer
+	root *yaml.Node
+	// mediaType is the default media type of bodies.
+	mediaType string
+}
+
+// OpenAPIv3FromRAML converts a RAML 1.0 API definition to OpenAPI v3.
+// Resources become paths, types become schemas, and traits that methods
+// use add their parameters and responses to the operations. Resource
+// types, libraries, and included files aren't read, and the parameters of
+// traits aren't replaced.
+func OpenAPIv3FromRAML(data []byte) (*openapi3.Document, error) {
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+	if strings.TrimSpace(string(header)) != "#%RAML 1.0" {
+		return nil, errors.New("no RAML 1.0 API definition")
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("no RAML 1.0 API definition")
+	}
+	root := document.Content[0]
+	c := &ramlConverter{
+		importer: newImporter(&openapi3.Info{
+			Title:       ramlString(root, "title"),
+			Description: ramlString(root, "description"),
+			Version:     ramlString(root, "version"),
+		}),
+		root:      root,
+		mediaType: "application/json",
+	}
+	if mediaType := compiler.MapValueForKey(root, "mediaType"); mediaType != nil {
+		if mediaType.Kind == yaml.SequenceNode && len(mediaType.Content) > 0 {
+			mediaType = mediaType.Content[0]
+		}
+		c.mediaType = mediaType.Value
+	}
+	if server := c.server(); server != nil {
+		c.document.Servers = []*openapi3.Server{server}
+	}
+	if types := compiler.MapValueForKey(root, "types"); types != nil && types.Kind == yaml.MappingNode {
+		schemas := &openapi3.SchemasOrReferences{}
+		for i := 0; i+1 < len(types.Content); i += 2 {
+			schemas.AdditionalProperties = append(schemas.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: types.Content[i].Value, Value: c.schema(types.Content[i+1])})
+		}
+		c.document.Components = &openapi3.Components{Schemas: schemas}
+	}
+	securedBy := compiler.MapValueForKey(root, "securedBy")
+	c.document.Security = c.securityRequirements(securedBy)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if key := root.Content[i].Value; strings.HasPrefix(key, "/") {
+			c.resource(key, root.Content[i+1], nil, nil, securedBy)
+		}
+	}
+	c.addDefaultResponses()
+	return c.document, nil
+}
+
+// ramlParameterPattern matches the parameters of URIs like {id}.
+var ramlParameterPattern = regexp.MustCompile(`{([^{}]+)}`)
+
+// Returns a server for the base URI of the API, with its parameters as
+// server variables.
+func (c *ramlConverter) server() *openapi3.Server {
+	uri := ramlString(c.root, "baseUri")
+	if uri == "" {
+		return nil
+	}
+	uri = strings.Replace(uri, "{version}", c.document.Info.Version, -1)
+	if !strings.Contains(uri, "://") {
+		protocol := "https"
+		if protocols := compiler.MapValueForKey(c.root, "protocols"); protocols != nil && len(protocols.Content) > 0 {
+			protocol = strings.ToLower(protocols.Content[0].Value)
+		}
+		uri = protocol + "://" + uri
+	}
+	server := &openapi3.Server{Url: uri}
+	parameters := compiler.MapValueForKey(c.root, "baseUriParameters")
+	for _, m := range ramlParameterPattern.FindAllStringSubmatch(uri, -1) {
+		name := m[1]
+		if server.Variables == nil {
+			server.Variables = &openapi3.ServerVariables{}
+		}
+		parameter := compiler.MapValueForKey(parameters, name)
+		// Server variables must have defaults.
+		variable := &openapi3.ServerVariable{Default: name, Description: ramlString(parameter, "description")}
+		if enum := compiler.MapValueForKey(parameter, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+			for _, value := range enum.Content {
+				variable.Enum = append(variable.Enum, value.Value)
+			}
+		}
+		for _, key := range []string{"example", "default"} {
+			if value := ramlString(parameter, key); value != "" {
+				variable.Default = value
+			}
+		}
+		if len(variable.Enum) > 0 && variable.Default == name {
+			variable.Default = variable.Enum[0]
+		}
+		server.Variables.AdditionalProperties = append(server.Variables.AdditionalProperties,
+			&openapi3.NamedServerVariable{Name: name, Value: variable})
+	}
+	return server
+}
+
+// Adds the operations of the methods of a resource and its nested resources.
+// URI parameters, traits, and security requirements of resources apply to
+// their nested resources and methods.
+func (c *ramlConverter) resource(path string, node *yaml.Node, parameters []*openapi3.Parameter, traits []*yaml.Node, securedBy *yaml.Node) {
+	if uriParameters := compiler.MapValueForKey(node, "uriParameters"); uriParameters != nil {
+		parameters = append([]*openapi3.Parameter{}, parameters...)
+		for i := 0; i+1 < len(uriParameters.Content); i += 2 {
+			parameters = append(parameters, c.parameter(uriParameters.Content[i].Value, uriParameters.Content[i+1], "path"))
+		}
+	}
+	traits = append(c.traits(compiler.MapValueForKey(node, "is")), traits...)
+	if s := compiler.MapValueForKey(node, "securedBy"); s != nil {
+		securedBy = s
+	}
+	for i := 0; node != nil && i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch key {
+		case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+			c.method(path, key, value, parameters, traits, securedBy)
+		default:
+			if strings.HasPrefix(key, "/") {
+				c.resource(path+key, value, parameters, traits, securedBy)
+			}
+		}
+	}
+}
+
+// Returns the declarations of the traits that a resource or method uses.
+func (c *ramlConverter) traits(is *yaml.Node) []*yaml.Node {
+	var traits []*yaml.Node
+	declarations := compiler.MapValueForKey(c.root, "traits")
+	for i := 0; is != nil && i < len(is.Content); i++ {
+		name := is.Content[i].Value
+		// Traits with parameters are maps from their names to the
+		// parameters.
+		if is.Content[i].Kind == yaml.MappingNode && len(is.Content[i].Content) > 0 {
+			name = is.Content[i].Content[0].Value
+		}
+		if trait := compiler.MapValueForKey(declarations, name); trait != nil {
+			traits = append(traits, trait)
+		}
+	}
+	return traits
+}
+
+// Adds an operation for a method of a resource.
+func (c *ramlConverter) method(path, method string, node *yaml.Node, uriParameters []*openapi3.Parameter, traits []*yaml.Node, securedBy *yaml.Node) {
+	name := ramlString(node, "displayName")
+	op := &openapi3.Operation{
+		Summary:     name,
+		Description: ramlString(node, "description"),
+		OperationId: c.operationID(name, method, path),
+		Responses:   &openapi3.Responses{},
+	}
+	// Path parameters are in the order of the path and are strings if
+	// they aren't declared.
+	var parameters []*openapi3.Parameter
+	for _, m := range ramlParameterPattern.FindAllStringSubmatch(path, -1) {
+		parameter := &openapi3.Parameter{Name: m[1], In: "path", Required: true, Schema: stringSchema()}
+		for _, p := range uriParameters {
+			if p.Name == m[1] {
+				parameter = p
+			}
+		}
+		if !hasParameter(parameters, parameter.Name, "path") {
+			parameters = append(parameters, parameter)
+		}
+	}
+	// Declarations of methods take precedence over those of traits.
+	sources := append([]*yaml.Node{node}, c.traits(compiler.MapValueForKey(node, "is"))...)
+	sources = append(sources, traits...)
+	for _, source := range sources {
+		for _, in := range []string{"query", "header"} {
+			key := "queryParameters"
+			if in == "header" {
+				key = "headers"
+			}
+			declarations := compiler.MapValueForKey(source, key)
+			for i := 0; declarations != nil && i+1 < len(declarations.Content); i += 2 {
+				parameter := c.parameter(declarations.Content[i].Value, declarations.Content[i+1], in)
+				if !hasParameter(parameters, parameter.Name, in) {
+					parameters = append(parameters, parameter)
+				}
+			}
+		}
+		if body := compiler.MapValueForKey(source, "body"); body != nil && op.RequestBody == nil {
+			op.RequestBody = &openapi3.RequestBodyOrReference{
+				Oneof: &openapi3.RequestBodyOrReference_RequestBody{RequestBody: &openapi3.RequestBody{Content: c.content(body)}},
+			}
+		}
+		c.addResponses(op, compiler.MapValueForKey(source, "responses"))
+	}
+	for _, p := range parameters {
+		op.Parameters = append(op.Parameters, &openapi3.ParameterOrReference{
+			Oneof: &openapi3.ParameterOrReference_Parameter{Parameter: p},
+		})
+	}
+	if s := compiler.MapValueForKey(node, "securedBy"); s != nil {
+		securedBy = s
+	}
+	if securedBy != compiler.MapValueForKey(c.root, "securedBy") {
+		op.Security = c.securityRequirements(securedBy)
+	}
+	c.addOperation(method, path, op)
+}
+
+// Returns a parameter for a declaration of a URI parameter, query
+// parameter, or header. Parameters are required unless their names end
+// with "?" or they are declared to be optional.
+func (c *ramlConverter) parameter(name string, node *yaml.Node, in string) *openapi3.Parameter {
+	required := true
+	if strings.HasSuffix(name, "?") {
+		name, required = strings.TrimSuffix(name, "?"), false
+	}
+	if r := compiler.MapValueForKey(node, "required"); r != nil {
+		required = r.Value == "true"
+	}
+	p := &openapi3.Parameter{
+		Name:     name,
+		In:       in,
+		Required: required || in == "path",
+		Schema:   c.schema(node),
+	}
+	// Descriptions and examples belong to parameters instead of their
+	// schemas.
+	if s := p.Schema.GetSchema(); s != nil {
+		p.Description, s.Description = s.Description, ""
+		p.Example, s.Example = s.Example, nil
+		p.Schema = unwrapSchema(p.Schema)
+	}
+	return p
+}
+
+// Returns the media types of a body. Bodies that are type declarations have
+// the default media type.
+func (c *ramlConverter) content(body *yaml.Node) *openapi3.MediaTypes {
+	content := &openapi3.MediaTypes{}
+	isDeclaration := body.Kind != yaml.MappingNode
+	for i := 0; !isDeclaration && i+1 < len(body.Content); i += 2 {
+		isDeclaration = !strings.Contains(body.Content[i].Value, "/")
+	}
+	if isDeclaration {
+		body = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{compiler.NewScalarNodeForString(c.mediaType), body}}
+	}
+	for i := 0; i+1 < len(body.Content); i += 2 {
+		media := &openapi3.MediaType{Schema: c.schema(body.Content[i+1])}
+		if s := media.Schema.GetSchema(); s != nil {
+			media.Example, s.Example = s.Example, nil
+			media.Schema = unwrapSchema(media.Schema)
+		}
+		content.AdditionalProperties = append(content.AdditionalProperties,
+			&openapi3.NamedMediaType{Name: body.Content[i].Value, Value: media})
+	}
+	return content
+}
+
+// Adds responses to an operation. Responses with the same status codes as
+// earlier responses are skipped.
+func (c *ramlConverter) addResponses(op *openapi3.Operation, responses *yaml.Node) {
+	for i := 0; responses != nil && i+1 < len(responses.Content); i += 2 {
+		code, node := responses.Content[i].Value, responses.Content[i+1]
+		found := false
+		for _, pair := range op.Responses.ResponseOrReference {
+			found = found || pair.Name == code
+		}
+		if found {
+			continue
+		}
+		response := &openapi3.Response{Description: ramlString(node, "description")}
+		if response.Description == "" {
+			status, _ := strconv.Atoi(code)
+			response.Description = http.StatusText(status)
+		}
+		if response.Description == "" {
+			response.Description = "Response"
+		}
+		if headers := compiler.MapValueForKey(node, "headers"); headers != nil {
+			response.Headers = &openapi3.HeadersOrReferences{}
+			for j := 0; j+1 < len(headers.Content); j += 2 {
+				p := c.parameter(headers.Content[j].Value, headers.Content[j+1], "header")
+				response.Headers.AdditionalProperties = append(response.Headers.AdditionalProperties,
+					&openapi3.NamedHeaderOrReference{
+						Name: p.Name,
+						Value: &openapi3.HeaderOrReference{Oneof: &openapi3.HeaderOrReference_Header{Header: &openapi3.Header{
+							Description: p.Description,
+							Required:    p.Required,
+							Schema:      p.Schema,
+						}}},
+					})
+			}
+		}
+		if body := compiler.MapValueForKey(node, "body"); body != nil {
+			response.Content = c.content(body)
+		}
+		op.Responses.ResponseOrReference = append(op.Responses.ResponseOrReference,
+			&openapi3.NamedResponseOrReference{
+				Name:  code,
+				Value: &openapi3.ResponseOrReference{Oneof: &openapi3.ResponseOrReference_Response{Response: response}},
+			})
+	}
+}
+
+// Returns a schema for a type declaration, which is a map of facets or a
+// type expression.
+func (c *ramlConverter) schema(node *yaml.Node) *openapi3.SchemaOrReference {
+	if node == nil || node.Tag == "!!null" {
+		return stringSchema()
+	}
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!include" {
+			// Included files aren't read.
+			return schemaValue(&openapi3.Schema{})
+		}
+		return c.typeExpression(node.Value)
+	}
+	if node.Kind != yaml.MappingNode {
+		return schemaValue(&openapi3.Schema{})
+	}
+	s := &openapi3.Schema{}
+	var base *openapi3.SchemaOrReference
+	t := compiler.MapValueForKey(node, "type")
+	if t == nil {
+		t = compiler.MapValueForKey(node, "schema")
+	}
+	switch {
+	case t == nil:
+		// Types are objects if they have properties and strings otherwise.
+		s.Type = "string"
+		if compiler.MapValueForKey(node, "properties") != nil {
+			s.Type = "object"
+		} else if compiler.MapValueForKey(node, "items") != nil {
+			s.Type = "array"
+		}
+	case t.Kind == yaml.SequenceNode:
+		// Types with several base types inherit from all of them.
+		for _, item := range t.Content {
+			s.AllOf = append(s.AllOf, c.schema(item))
+		}
+	case t.Kind == yaml.MappingNode:
+		base = c.schema(t)
+	case strings.HasPrefix(strings.TrimSpace(t.Value), "{"):
+		base = jsonSchema(t.Value)
+	default:
+		base = c.typeExpression(t.Value)
+		// Facets of built-in types are added to their schemas.
+		if b := base.GetSchema(); b != nil && b.Type != "" && b.Type != "array" {
+			s, base = b, nil
+		}
+	}
+	c.addFacets(s, node)
+	if base == nil {
+		return schemaValue(s)
+	}
+	if proto.Equal(s, &openapi3.Schema{}) {
+		return base
+	}
+	s.AllOf = append([]*openapi3.SchemaOrReference{base}, s.AllOf...)
+	return schemaValue(s)
+}
+
+// Returns the base of a schema that only has a base, as schemas for types
+// with facets do after their examples are moved.
+func unwrapSchema(schema *openapi3.SchemaOrReference) *openapi3.SchemaOrReference {
+	if s := schema.GetSchema(); s != nil && len(s.AllOf) == 1 {
+		if proto.Equal(s, &openapi3.Schema{AllOf: s.AllOf}) {
+			return s.AllOf[0]
+		}
+	}
+	return schema
+}
+
+// Adds the facets of a type declaration to a schema.
+func (c *ramlConverter) addFacets(s *openapi3.Schema, node *yaml.Node) {
+	s.Title = ramlString(node, "displayName")
+	s.Description = ramlString(node, "description")
+	s.Pattern = ramlString(node, "pattern")
+	if format := ramlString(node, "format"); format != "" {
+		s.Format = format
+	}
+	for key, value := range map[string]*int64{
+		"minLength": &s.MinLength, "maxLength": &s.MaxLength,
+		"minItems": &s.MinItems, "maxItems": &s.MaxItems,
+		"minProperties": &s.MinProperties, "maxProperties": &s.MaxProperties,
+	} {
+		if n, err := strconv.ParseInt(ramlString(node, key), 10, 64); err == nil {
+			*value = n
+		}
+	}
+	for key, value := range map[string]*float64{"minimum": &s.Minimum, "maximum": &s.Maximum, "multipleOf": &s.MultipleOf} {
+		if n, err := strconv.ParseFloat(ramlString(node, key), 64); err == nil {
+			*value = n
+		}
+	}
+	s.UniqueItems = ramlString(node, "uniqueItems") == "true"
+	if enum := compiler.MapValueForKey(node, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		for _, value := range enum.Content {
+			s.Enum = append(s.Enum, &openapi3.Any{Yaml: string(compiler.Marshal(value))})
+		}
+	}
+	if value := compiler.MapValueForKey(node, "default"); value != nil {
+		s.Default = defaultType(value)
+	}
+	if example := compiler.MapValueForKey(node, "example"); example != nil {
+		s.Example = &openapi3.Any{Yaml: string(compiler.Marshal(example))}
+	} else if examples := compiler.MapValueForKey(node, "examples"); examples != nil && len(examples.Content) > 1 {
+		// Examples are maps from names to values or to declarations with
+		// values.
+		example := examples.Content[1]
+		if value := compiler.MapValueForKey(example, "value"); value != nil {
+			example = value
+		}
+		s.Example = &openapi3.Any{Yaml: string(compiler.Marshal(example))}
+	}
+	if items := compiler.MapValueForKey(node, "items"); items != nil {
+		s.Type = "array"
+		s.Items = &openapi3.ItemsItem{SchemaOrReference: []*openapi3.SchemaOrReference{c.schema(items)}}
+	}
+	if properties := compiler.MapValueForKey(node, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+		s.Properties = &openapi3.Properties{}
+		for i := 0; i+1 < len(properties.Content); i += 2 {
+			name, property := properties.Content[i].Value, properties.Content[i+1]
+			required := true
+			if strings.HasSuffix(name, "?") {
+				name, required = strings.TrimSuffix(name, "?"), false
+			}
+			if r := compiler.MapValueForKey(property, "required"); r != nil {
+				required = r.Value == "true"
+			}
+			if required {
+				s.Required = append(s.Required, name)
+			}
+			s.Properties.AdditionalProperties = append(s.Properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: name, Value: c.schema(property)})
+		}
+	}
+	if additional := ramlString(node, "additionalProperties"); additional == "false" {
+		s.AdditionalProperties = &openapi3.AdditionalPropertiesItem{
+			Oneof: &openapi3.AdditionalPropertiesItem_Boolean{Boolean: false},
+		}
+	}
+	if discriminator := ramlString(node, "discriminator"); discriminator != "" {
+		s.Discriminator = &openapi3.Discriminator{PropertyName: discriminator}
+	}
+}
+
+// Returns a schema for a type expression, like "string", "Pet[]", or
+// "Cat | Dog".
+func (c *ramlConverter) typeExpression(expression string) *openapi3.SchemaOrReference {
+	expression = strings.TrimSpace(expression)
+	if options := splitRAMLUnion(expression); len(options) > 1 {
+		s := &openapi3.Schema{}
+		for _, option := range options {
+			if option == "nil" {
+				s.Nullable = true
+			} else {
+				s.OneOf = append(s.OneOf, c.typeExpression(option))
+			}
+		}
+		if len(s.OneOf) == 1 {
+			// Unions with nil are nullable types.
+			if option := s.OneOf[0].GetSchema(); option != nil {
+				option.Nullable = s.Nullable
+				return s.OneOf[0]
+			}
+			s.AllOf, s.OneOf = s.OneOf, nil
+		}
+		return schemaValue(s)
+	}
+	if strings.HasSuffix(expression, "[]") {
+		return schemaValue(&openapi3.Schema{
+			Type:  "array",
+			Items: &openapi3.ItemsItem{SchemaOrReference: []*openapi3.SchemaOrReference{c.typeExpression(strings.TrimSuffix(expression, "[]"))}},
+		})
+	}
+	if strings.HasPrefix(expression, "(") && strings.HasSuffix(expression, ")") {
+		return c.typeExpression(expression[1 : len(expression)-1])
+	}
+	switch expression {
+	case "string", "number", "integer", "boolean", "object", "array":
+		return schemaValue(&openapi3.Schema{Type: expression})
+	case "date-only":
+		return schemaValue(&openapi3.Schema{Type: "string", Format: "date"})
+	case "datetime":
+		return schemaValue(&openapi3.Schema{Type: "string", Format: "date-time"})
+	case "time-only", "datetime-only":
+		return schemaValue(&openapi3.Schema{Type: "string"})
+	case "file":
+		return schemaValue(&openapi3.Schema{Type: "string", Format: "binary"})
+	case "nil":
+		return schemaValue(&openapi3.Schema{Nullable: true})
+	case "any", "":
+		return schemaValue(&openapi3.Schema{})
+	}
+	return &openapi3.SchemaOrReference{
+		Oneof: &openapi3.SchemaOrReference_Reference{Reference: &openapi3.Reference{XRef: componentsSchemasPrefix + expression}},
+	}
+}
+
+// Splits a type expression into the options of a union.
+func splitRAMLUnion(expression string) []string {
+	var options []string
+	depth, start := 0, 0
+	for i, r := range expression {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				options = append(options, strings.TrimSpace(expression[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(options, strings.TrimSpace(expression[start:]))
+}
+
+// Returns security requirements for a list of the security schemes of the
+// API. Null entries allow anonymous requests.
+func (c *ramlConverter) securityRequirements(securedBy *yaml.Node) []*openapi3.SecurityRequirement {
+	if securedBy == nil {
+		return nil
+	}
+	items := []*yaml.Node{securedBy}
+	if securedBy.Kind == yaml.SequenceNode {
+		items = securedBy.Content
+	}
+	var requirements []*openapi3.SecurityRequirement
+	for _, item := range items {
+		if item.Tag == "!!null" {
+			requirements = append(requirements, &openapi3.SecurityRequirement{})
+			continue
+		}
+		name, scopes := item.Value, []string{}
+		// Schemes with parameters are maps from their names to the
+		// parameters.
+		if item.Kind == yaml.MappingNode && len(item.Content) > 1 {
+			name = item.Content[0].Value
+			if s := compiler.MapValueForKey(item.Content[1], "scopes"); s != nil {
+				for _, scope := range s.Content {
+					scopes = append(scopes, scope.Value)
+				}
+			}
+		}
+		if !c.addRAMLSecurityScheme(name) {
+			continue
+		}
+		requirements = append(requirements, &openapi3.SecurityRequirement{
+			AdditionalProperties: []*openapi3.NamedStringArray{{Name: name, Value: &openapi3.StringArray{Value: scopes}}},
+		})
+	}
+	return requirements
+}
+
+// Adds a security scheme of the API to the document and returns true, or
+// returns false if the scheme isn't declared or has a type that isn't
+// converted.
+func (c *ramlConverter) addRAMLSecurityScheme(name string) bool {
+	if c.schemes[name] {
+		return true
+	}
+	declaration := compiler.MapValueForKey(compiler.MapValueForKey(c.root, "securitySchemes"), name)
+	if declaration == nil {
+		return false
+	}
+	scheme := &openapi3.SecurityScheme{Description: ramlString(declaration, "description")}
+	settings := compiler.MapValueForKey(declaration, "settings")
+	switch ramlString(declaration, "type") {
+	case "Basic Authentication":
+		scheme.Type, scheme.Scheme = "http", "basic"
+	case "Digest Authentication":
+		scheme.Type, scheme.Scheme = "http", "digest"
+	case "OAuth 2.0":
+		scheme.Type, scheme.Flows = "oauth2", &openapi3.OauthFlows{}
+		scopes := &openapi3.Strings{}
+		if s := compiler.MapValueForKey(settings, "scopes"); s != nil {
+			for _, scope := range s.Content {
+				scopes.AdditionalProperties = append(scopes.AdditionalProperties, &openapi3.NamedString{Name: scope.Value})
+			}
+		}
+		authorizationURL, tokenURL := ramlString(settings, "authorizationUri"), ramlString(settings, "accessTokenUri")
+		grants := compiler.MapValueForKey(settings, "authorizationGrants")
+		for i := 0; grants != nil && i < len(grants.Content); i++ {
+			switch grants.Content[i].Value {
+			case "authorization_code":
+				scheme.Flows.AuthorizationCode = &openapi3.OauthFlow{AuthorizationUrl: authorizationURL, TokenUrl: tokenURL, Scopes: scopes}
+			case "implicit":
+				scheme.Flows.Implicit = &openapi3.OauthFlow{AuthorizationUrl: authorizationURL, Scopes: scopes}
+			case "password":
+				scheme.Flows.Password = &openapi3.OauthFlow{TokenUrl: tokenURL, Scopes: scopes}
+			case "client_credentials":
+				scheme.Flows.ClientCredentials = &openapi3.OauthFlow{TokenUrl: tokenURL, Scopes: scopes}
+			}
+		}
+	case "Pass Through":
+		// Pass-through schemes are API keys if they describe one header
+		// or query parameter.
+		describedBy := compiler.MapValueForKey(declaration, "describedBy")
+		headers := compiler.MapValueForKey(describedBy, "headers")
+		query := compiler.MapValueForKey(describedBy, "queryParameters")
+		switch {
+		case headers != nil && len(headers.Content) == 2 && query == nil:
+			scheme.Type, scheme.In, scheme.Name = "apiKey", "header", headers.Content[0].Value
+		case query != nil && len(query.Content) == 2 && headers == nil:
+			scheme.Type, scheme.In, scheme.Name = "apiKey", "query", query.Content[0].Value
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	c.addSecurityScheme(name, scheme)
+	return true
+}
+
+func ramlString(node *yaml.Node, key string) string {
+	value := compiler.MapValueForKey(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+		return ""
+	}
+	return value.Value
+}
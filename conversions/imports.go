@@ -0,0 +1,316 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversions
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+// Formats of descriptions that can be converted to OpenAPI v3.
+const (
+	PostmanFormat   = "postman"
+	RAMLFormat      = "raml"
+	BlueprintFormat = "blueprint"
+)
+
+// DetectFormat returns the format of a description that can be converted to
+// OpenAPI v3, or "" if it isn't in one of these formats. Formats are
+// detected from the headers of descriptions and from the extensions of their
+// names.
+func DetectFormat(name string, data []byte) string {
+	text := strings.TrimLeft(string(data), "\ufeff \t\r\n")
+	switch {
+	case strings.HasPrefix(text, "#%RAML"):
+		return RAMLFormat
+	case strings.HasPrefix(text, "FORMAT: 1A"):
+		return BlueprintFormat
+	case strings.HasPrefix(text, "{") &&
+		(strings.Contains(text, `"_postman_id"`) || strings.Contains(text, "schema.getpostman.com")):
+		return PostmanFormat
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".raml":
+		return RAMLFormat
+	case ".apib", ".apiblueprint":
+		return BlueprintFormat
+	}
+	return ""
+}
+
+// OpenAPIv3FromFormat converts a description in one of the formats that
+// DetectFormat returns to OpenAPI v3.
+func OpenAPIv3FromFormat(format string, data []byte) (*openapi3.Document, error) {
+	switch format {
+	case PostmanFormat:
+		return OpenAPIv3FromPostman(data)
+	case RAMLFormat:
+		return OpenAPIv3FromRAML(data)
+	case BlueprintFormat:
+		return OpenAPIv3FromBlueprint(data)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// An importer builds an OpenAPI v3 document from a description in another
+// format. Importers of each format embed it.
+type importer struct {
+	document *openapi3.Document
+	// operations are the operations of the document, keyed by method and
+	// path, and operationIDs are their IDs.
+	operations   map[string]*openapi3.Operation
+	operationIDs map[string]bool
+	// schemes are the names of the security schemes of the document.
+	schemes map[string]bool
+}
+
+func newImporter(info *openapi3.Info) importer {
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+	return importer{
+		document: &openapi3.Document{
+			Openapi: "3.0.3",
+			Info:    info,
+			Paths:   &openapi3.Paths{},
+		},
+		operations:   map[string]*openapi3.Operation{},
+		operationIDs: map[string]bool{},
+		schemes:      map[string]bool{},
+	}
+}
+
+// Returns the operation for a method and path, or nil if there is none.
+func (im *importer) operation(method, path string) *openapi3.Operation {
+	return im.operations[method+" "+path]
+}
+
+// Adds an operation for a method and path.
+func (im *importer) addOperation(method, path string, op *openapi3.Operation) {
+	im.operations[method+" "+path] = op
+	pathItem := im.pathItem(path)
+	switch method {
+	case "get":
+		pathItem.Get = op
+	case "put":
+		pathItem.Put = op
+	case "post":
+		pathItem.Post = op
+	case "delete":
+		pathItem.Delete = op
+	case "options":
+		pathItem.Options = op
+	case "head":
+		pathItem.Head = op
+	case "patch":
+		pathItem.Patch = op
+	case "trace":
+		pathItem.Trace = op
+	}
+}
+
+// Returns the path item for a path, which is added to the document if it
+// isn't there.
+func (im *importer) pathItem(path string) *openapi3.PathItem {
+	if item := openapi3.FindNamedPathItem(im.document.Paths.Path, path); item != nil {
+		return item
+	}
+	item := &openapi3.PathItem{}
+	im.document.Paths.Path = append(im.document.Paths.Path, &openapi3.NamedPathItem{Name: path, Value: item})
+	return item
+}
+
+// Returns an operation ID made from the name of an operation, or from its
+// method and path if the name is empty or is already used.
+func (im *importer) operationID(name, method, path string) string {
+	id := identifier(name)
+	if id == "" || im.operationIDs[id] {
+		id = identifier(method + " " + path)
+	}
+	base := id
+	for i := 2; im.operationIDs[id]; i++ {
+		id = base + strconv.Itoa(i)
+	}
+	im.operationIDs[id] = true
+	return id
+}
+
+// Adds a security scheme to the document if it has no scheme with its name.
+func (im *importer) addSecurityScheme(name string, scheme *openapi3.SecurityScheme) {
+	if im.schemes[name] {
+		return
+	}
+	im.schemes[name] = true
+	if im.document.Components == nil {
+		im.document.Components = &openapi3.Components{}
+	}
+	if im.document.Components.SecuritySchemes == nil {
+		im.document.Components.SecuritySchemes = &openapi3.SecuritySchemesOrReferences{}
+	}
+	im.document.Components.SecuritySchemes.AdditionalProperties = append(im.document.Components.SecuritySchemes.AdditionalProperties,
+		&openapi3.NamedSecuritySchemeOrReference{
+			Name:  name,
+			Value: &openapi3.SecuritySchemeOrReference{Oneof: &openapi3.SecuritySchemeOrReference_SecurityScheme{SecurityScheme: scheme}},
+		})
+}
+
+// Adds default responses to operations without responses, because
+// operations must have responses.
+func (im *importer) addDefaultResponses() {
+	for _, op := range im.operations {
+		if op.Responses == nil {
+			op.Responses = &openapi3.Responses{}
+		}
+		if len(op.Responses.ResponseOrReference) == 0 && op.Responses.Default == nil {
+			op.Responses.Default = &openapi3.ResponseOrReference{
+				Oneof: &openapi3.ResponseOrReference_Response{Response: &openapi3.Response{Description: "Default response"}},
+			}
+		}
+	}
+}
+
+func hasParameter(parameters []*openapi3.Parameter, name, in string) bool {
+	for _, p := range parameters {
+		if p.In == in && (p.Name == name || in == "header" && strings.EqualFold(p.Name, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the words of a string in lower camel case.
+func identifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word[:1]) + word[1:]
+		} else {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// Returns a media type with an example, and with a schema that is inferred
+// from the example if it is JSON.
+func exampleMediaType(text string, isJSON bool) *openapi3.MediaType {
+	var node yaml.Node
+	if isJSON && json.Valid([]byte(text)) && yaml.Unmarshal([]byte(text), &node) == nil && len(node.Content) > 0 {
+		value := node.Content[0]
+		return &openapi3.MediaType{
+			Schema:  schemaValue(inferSchema(value)),
+			Example: &openapi3.Any{Yaml: string(compiler.Marshal(value))},
+		}
+	}
+	return &openapi3.MediaType{
+		Schema:  stringSchema(),
+		Example: &openapi3.Any{Yaml: yamlString(text)},
+	}
+}
+
+// Returns a schema for the type of a JSON value. The items of arrays are
+// described by their first item.
+func inferSchema(node *yaml.Node) *openapi3.Schema {
+	switch node.Kind {
+	case yaml.MappingNode:
+		schema := &openapi3.Schema{Type: "object"}
+		if len(node.Content) > 0 {
+			schema.Properties = &openapi3.Properties{}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			schema.Properties.AdditionalProperties = append(schema.Properties.AdditionalProperties,
+				&openapi3.NamedSchemaOrReference{Name: node.Content[i].Value, Value: schemaValue(inferSchema(node.Content[i+1]))})
+		}
+		return schema
+	case yaml.SequenceNode:
+		items := &openapi3.Schema{}
+		if len(node.Content) > 0 {
+			items = inferSchema(node.Content[0])
+		}
+		return &openapi3.Schema{Type: "array", Items: &openapi3.ItemsItem{SchemaOrReference: []*openapi3.SchemaOrReference{schemaValue(items)}}}
+	}
+	switch node.Tag {
+	case "!!str":
+		return &openapi3.Schema{Type: "string"}
+	case "!!int":
+		return &openapi3.Schema{Type: "integer"}
+	case "!!float":
+		return &openapi3.Schema{Type: "number"}
+	case "!!bool":
+		return &openapi3.Schema{Type: "boolean"}
+	}
+	return &openapi3.Schema{Nullable: true}
+}
+
+// Returns a schema for a JSON schema, or an empty schema if it can't be
+// read.
+func jsonSchema(text string) *openapi3.SchemaOrReference {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &document); err != nil || len(document.Content) == 0 {
+		return schemaValue(&openapi3.Schema{})
+	}
+	root := document.Content[0]
+	// Keywords of JSON schemas that aren't in OpenAPI schemas are removed.
+	for i := 0; i+1 < len(root.Content); {
+		switch root.Content[i].Value {
+		case "$schema", "id", "$id", "definitions":
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		default:
+			i += 2
+		}
+	}
+	schema, err := openapi3.NewSchemaOrReference(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		return schemaValue(&openapi3.Schema{})
+	}
+	return schema
+}
+
+// Returns a default value for a node.
+func defaultType(node *yaml.Node) *openapi3.DefaultType {
+	switch node.Tag {
+	case "!!bool":
+		return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_Boolean{Boolean: node.Value == "true"}}
+	case "!!int", "!!float":
+		if f, err := strconv.ParseFloat(node.Value, 64); err == nil {
+			return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_Number{Number: f}}
+		}
+	}
+	return &openapi3.DefaultType{Oneof: &openapi3.DefaultType_String_{String_: node.Value}}
+}
+
+func schemaValue(schema *openapi3.Schema) *openapi3.SchemaOrReference {
+	return &openapi3.SchemaOrReference{Oneof: &openapi3.SchemaOrReference_Schema{Schema: schema}}
+}
+
+func stringSchema() *openapi3.SchemaOrReference {
+	return schemaValue(&openapi3.Schema{Type: "string"})
+}
+
+// Returns the YAML for a string value.
+func yamlString(s string) string {
+	bytes, _ := yaml.Marshal(s)
+	return string(bytes)
+}
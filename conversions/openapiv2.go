@@ -34,9 +34,7 @@ func addOpenAPI2SchemaForSchema(d *openapi2.Document, name string, schema *disco
 func buildOpenAPI2SchemaForSchema(schema *discovery.Schema) *openapi2.Schema {
 	s := &openapi2.Schema{}
 
-	if description := schema.Description; description != "" {
-		s.Description = description
-	}
+	s.Description = descriptionWithEnumDescriptions(schema.Description, schema.Enum, schema.EnumDescriptions)
 	if typeName := schema.Type; typeName != "" {
 		s.Type = &openapi2.TypeItem{Value: []string{typeName}}
 	}
@@ -78,18 +76,30 @@ func buildOpenAPI2ParameterForParameter(name string, p *discovery.Parameter) *op
 	location := p.Location
 	switch location {
 	case "query":
+		query := &openapi2.QueryParameterSubSchema{
+			Name:        name,
+			In:          "query",
+			Description: descriptionWithEnumDescriptions(p.Description, p.Enum, p.EnumDescriptions),
+			Required:    p.Required,
+			Type:        typeName,
+			Format:      format,
+			Enum:        buildOpenAPI2EnumForEnum(p.Enum),
+		}
+		// repeated parameters are arrays that are passed as separate values, e.g. ?id=1&id=2
+		if p.Repeated {
+			query.Items = &openapi2.PrimitivesItems{
+				Type:   query.Type,
+				Format: query.Format,
+				Enum:   query.Enum,
+			}
+			query.Type, query.Format, query.Enum = "array", "", nil
+			query.CollectionFormat = "multi"
+		}
 		return &openapi2.Parameter{
 			Oneof: &openapi2.Parameter_NonBodyParameter{
 				NonBodyParameter: &openapi2.NonBodyParameter{
 					Oneof: &openapi2.NonBodyParameter_QueryParameterSubSchema{
-						QueryParameterSubSchema: &openapi2.QueryParameterSubSchema{
-							Name:        name,
-							In:          "query",
-							Description: p.Description,
-							Required:    p.Required,
-							Type:        typeName,
-							Format:      format,
-						},
+						QueryParameterSubSchema: query,
 					},
 				},
 			},
@@ -102,10 +112,11 @@ func buildOpenAPI2ParameterForParameter(name string, p *discovery.Parameter) *op
 						PathParameterSubSchema: &openapi2.PathParameterSubSchema{
 							Name:        name,
 							In:          "path",
-							Description: p.Description,
+							Description: descriptionWithEnumDescriptions(p.Description, p.Enum, p.EnumDescriptions),
 							Required:    p.Required,
 							Type:        typeName,
 							Format:      format,
+							Enum:        buildOpenAPI2EnumForEnum(p.Enum),
 						},
 					},
 				},
@@ -116,6 +127,14 @@ func buildOpenAPI2ParameterForParameter(name string, p *discovery.Parameter) *op
 	}
 }
 
+func buildOpenAPI2EnumForEnum(enum []string) []*openapi2.Any {
+	var values []*openapi2.Any
+	for _, e := range enum {
+		values = append(values, &openapi2.Any{Yaml: e})
+	}
+	return values
+}
+
 func buildOpenAPI2ParameterForRequest(p *discovery.Request) *openapi2.Parameter {
 	return &openapi2.Parameter{
 		Oneof: &openapi2.Parameter_BodyParameter{
@@ -156,14 +175,34 @@ func buildOpenAPI2OperationForMethod(method *discovery.Method) *openapi2.Operati
 	//log.Printf("METHOD %s %s %s %s\n", method.Name, method.path(), method.HTTPMethod, method.ID)
 	//log.Printf("MAP %+v\n", method.JSONMap)
 	parameters := make([]*openapi2.ParametersItem, 0)
-	if method.Parameters != nil {
-		for _, pair := range method.Parameters.AdditionalProperties {
-			parameters = append(parameters, &openapi2.ParametersItem{
-				Oneof: &openapi2.ParametersItem_Parameter{
-					Parameter: buildOpenAPI2ParameterForParameter(pair.Name, pair.Value),
+	for _, pair := range parametersForMethod(method) {
+		parameters = append(parameters, &openapi2.ParametersItem{
+			Oneof: &openapi2.ParametersItem_Parameter{
+				Parameter: buildOpenAPI2ParameterForParameter(pair.Name, pair.Value),
+			},
+		})
+	}
+	// methods that require etags are called with the etag of the resource in an If-Match header
+	if method.EtagRequired {
+		parameters = append(parameters, &openapi2.ParametersItem{
+			Oneof: &openapi2.ParametersItem_Parameter{
+				Parameter: &openapi2.Parameter{
+					Oneof: &openapi2.Parameter_NonBodyParameter{
+						NonBodyParameter: &openapi2.NonBodyParameter{
+							Oneof: &openapi2.NonBodyParameter_HeaderParameterSubSchema{
+								HeaderParameterSubSchema: &openapi2.HeaderParameterSubSchema{
+									Name:        "If-Match",
+									In:          "header",
+									Description: "The etag of the resource.",
+									Required:    true,
+									Type:        "string",
+								},
+							},
+						},
+					},
 				},
-			})
-		}
+			},
+		})
 	}
 	responses := &openapi2.Responses{
 		ResponseCode: []*openapi2.NamedResponseValue{
@@ -27,6 +27,48 @@ func pathForMethod(path string) string {
 	return "/" + strings.Replace(path, "{+", "{", -1)
 }
 
+// parametersForMethod returns the parameters of a method with the parameters
+// that are named in its parameterOrder first, in that order.
+func parametersForMethod(method *discovery.Method) []*discovery.NamedParameter {
+	if method.Parameters == nil {
+		return nil
+	}
+	parameters := make([]*discovery.NamedParameter, 0, len(method.Parameters.AdditionalProperties))
+	ordered := make(map[string]bool)
+	for _, name := range method.ParameterOrder {
+		for _, pair := range method.Parameters.AdditionalProperties {
+			if pair.Name == name && !ordered[name] {
+				ordered[name] = true
+				parameters = append(parameters, pair)
+			}
+		}
+	}
+	for _, pair := range method.Parameters.AdditionalProperties {
+		if !ordered[pair.Name] {
+			parameters = append(parameters, pair)
+		}
+	}
+	return parameters
+}
+
+// descriptionWithEnumDescriptions appends a list of the descriptions of the
+// values of an enum to a description.
+func descriptionWithEnumDescriptions(description string, enum, enumDescriptions []string) string {
+	lines := make([]string, 0)
+	for i, value := range enum {
+		if i < len(enumDescriptions) && enumDescriptions[i] != "" {
+			lines = append(lines, "- `"+value+"`: "+enumDescriptions[i])
+		}
+	}
+	if len(lines) == 0 {
+		return description
+	}
+	if description != "" {
+		description += "\n\n"
+	}
+	return description + "Values:\n" + strings.Join(lines, "\n")
+}
+
 func addOpenAPI3SchemaForSchema(d *openapi3.Document, name string, schema *discovery.Schema) {
 	d.Components.Schemas.AdditionalProperties = append(d.Components.Schemas.AdditionalProperties,
 		&openapi3.NamedSchemaOrReference{
@@ -40,7 +82,7 @@ func buildOpenAPI3SchemaOrReferenceForSchema(schema *discovery.Schema) *openapi3
 		return &openapi3.SchemaOrReference{
 			Oneof: &openapi3.SchemaOrReference_Reference{
 				Reference: &openapi3.Reference{
-					XRef: "#/definitions/" + ref,
+					XRef: "#/components/schemas/" + ref,
 				},
 			},
 		}
@@ -48,9 +90,7 @@ func buildOpenAPI3SchemaOrReferenceForSchema(schema *discovery.Schema) *openapi3
 
 	s := &openapi3.Schema{}
 
-	if description := schema.Description; description != "" {
-		s.Description = description
-	}
+	s.Description = descriptionWithEnumDescriptions(schema.Description, schema.Enum, schema.EnumDescriptions)
 	if typeName := schema.Type; typeName != "" {
 		s.Type = typeName
 	}
@@ -88,20 +128,40 @@ func buildOpenAPI3ParameterForParameter(name string, p *discovery.Parameter) *op
 	location := p.Location
 	switch location {
 	case "query", "path":
-		return &openapi3.Parameter{
+		schema := &openapi3.Schema{
+			Type:   typeName,
+			Format: format,
+		}
+		for _, e := range p.Enum {
+			schema.Enum = append(schema.Enum, &openapi3.Any{Yaml: e})
+		}
+		parameter := &openapi3.Parameter{
 			Name:        name,
 			In:          location,
-			Description: p.Description,
+			Description: descriptionWithEnumDescriptions(p.Description, p.Enum, p.EnumDescriptions),
 			Required:    p.Required,
 			Schema: &openapi3.SchemaOrReference{
+				Oneof: &openapi3.SchemaOrReference_Schema{
+					Schema: schema,
+				},
+			},
+		}
+		// repeated parameters are arrays that are passed as separate values, e.g. ?id=1&id=2
+		if p.Repeated {
+			parameter.Schema = &openapi3.SchemaOrReference{
 				Oneof: &openapi3.SchemaOrReference_Schema{
 					Schema: &openapi3.Schema{
-						Type:   typeName,
-						Format: format,
+						Type: "array",
+						Items: &openapi3.ItemsItem{
+							SchemaOrReference: []*openapi3.SchemaOrReference{parameter.Schema},
+						},
 					},
 				},
-			},
+			}
+			parameter.Style = "form"
+			parameter.Explode = true
 		}
+		return parameter
 	default:
 		return nil
 	}
@@ -121,7 +181,7 @@ func buildOpenAPI3RequestBodyForRequest(request *discovery.Request) *openapi3.Re
 						Schema: &openapi3.SchemaOrReference{
 							Oneof: &openapi3.SchemaOrReference_Reference{
 								Reference: &openapi3.Reference{
-									XRef: "#/definitions/" + ref,
+									XRef: "#/components/schemas/" + ref,
 								},
 							},
 						},
@@ -152,7 +212,7 @@ func buildOpenAPI3ResponseForResponse(response *discovery.Response, hasDataWrapp
 							Schema: &openapi3.SchemaOrReference{
 								Oneof: &openapi3.SchemaOrReference_Reference{
 									Reference: &openapi3.Reference{
-										XRef: "#/definitions/" + ref,
+										XRef: "#/components/schemas/" + ref,
 									},
 								},
 							},
@@ -169,14 +229,30 @@ func buildOpenAPI3OperationForMethod(method *discovery.Method, hasDataWrapper bo
 		return nil
 	}
 	parameters := make([]*openapi3.ParameterOrReference, 0)
-	if method.Parameters != nil {
-		for _, pair := range method.Parameters.AdditionalProperties {
-			parameters = append(parameters, &openapi3.ParameterOrReference{
-				Oneof: &openapi3.ParameterOrReference_Parameter{
-					Parameter: buildOpenAPI3ParameterForParameter(pair.Name, pair.Value),
+	for _, pair := range parametersForMethod(method) {
+		parameters = append(parameters, &openapi3.ParameterOrReference{
+			Oneof: &openapi3.ParameterOrReference_Parameter{
+				Parameter: buildOpenAPI3ParameterForParameter(pair.Name, pair.Value),
+			},
+		})
+	}
+	// methods that require etags are called with the etag of the resource in an If-Match header
+	if method.EtagRequired {
+		parameters = append(parameters, &openapi3.ParameterOrReference{
+			Oneof: &openapi3.ParameterOrReference_Parameter{
+				Parameter: &openapi3.Parameter{
+					Name:        "If-Match",
+					In:          "header",
+					Description: "The etag of the resource.",
+					Required:    true,
+					Schema: &openapi3.SchemaOrReference{
+						Oneof: &openapi3.SchemaOrReference_Schema{
+							Schema: &openapi3.Schema{Type: "string"},
+						},
+					},
 				},
-			})
-		}
+			},
+		})
 	}
 	responses := &openapi3.Responses{
 		ResponseOrReference: []*openapi3.NamedResponseOrReference{
@@ -298,8 +374,10 @@ func OpenAPIv3(api *discovery.Document) (*openapi3.Document, error) {
 			addOpenAPI3PathsForMethod(d, pair.Name, pair.Value, hasDataWrapper)
 		}
 	}
-	for _, pair := range api.Resources.AdditionalProperties {
-		addOpenAPI3PathsForResource(d, pair.Value, hasDataWrapper)
+	if api.Resources != nil {
+		for _, pair := range api.Resources.AdditionalProperties {
+			addOpenAPI3PathsForResource(d, pair.Value, hasDataWrapper)
+		}
 	}
 
 	return d, nil
@@ -0,0 +1,375 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff compares two versions of an OpenAPI v3 document and reports
+// the changes to their paths, operations, and schemas, marking the changes
+// that can break existing clients.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	"github.com/google/gnostic/findings"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Kinds of changes.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// A Change is a difference between two versions of a document.
+type Change struct {
+	// Path is a JSON pointer to the changed value, e.g.
+	// "/paths/~1pets/get/parameters/limit".
+	Path string `json:"path"`
+	// Kind is Added, Removed, or Changed.
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// Breaking is true if the change can break existing clients.
+	Breaking bool `json:"breaking"`
+}
+
+func (c *Change) String() string {
+	s := c.Path + ": " + c.Message
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// HasBreakingChanges returns true if any of the changes can break clients.
+func HasBreakingChanges(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules of the findings of changes.
+const (
+	BreakingChangeRule = "breaking-change"
+	ChangeRule         = "change"
+)
+
+// Findings returns a finding for each change in a source, which is usually
+// the file of the new version. Breaking changes are errors and other
+// changes are informational.
+func Findings(source string, changes []*Change) []*findings.Finding {
+	result := make([]*findings.Finding, 0, len(changes))
+	for _, c := range changes {
+		f := &findings.Finding{
+			Rule:     ChangeRule,
+			Severity: findings.Info,
+			Source:   source,
+			Pointer:  c.Path,
+			Message:  c.Message,
+			Tool:     "diff",
+		}
+		if c.Breaking {
+			f.Rule, f.Severity = BreakingChangeRule, findings.Error
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+type differ struct {
+	old, new *openapi_v3.Document
+	changes  []*Change
+}
+
+func (d *differ) report(path, kind string, breaking bool, format string, args ...interface{}) {
+	d.changes = append(d.changes, &Change{
+		Path:     path,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+// Compare returns the changes from an old version of a document to a new
+// one. Removed paths, operations, response media types, and incompatible
+// schemas are breaking changes, as are new required parameters and request
+// bodies. Schemas of requests are compared with the provider rules of
+// openapi_v3.Compatible and schemas of responses with the consumer rules.
+// Schema components can be used in both, so they are compared with both.
+func Compare(old, new *openapi_v3.Document) []*Change {
+	d := &differ{old: old, new: new}
+	d.comparePaths(old.GetPaths(), new.GetPaths())
+	d.compareComponentSchemas(old.GetComponents().GetSchemas(), new.GetComponents().GetSchemas())
+	return d.changes
+}
+
+func pathItems(paths *openapi_v3.Paths) (map[string]*openapi_v3.PathItem, []string) {
+	items := make(map[string]*openapi_v3.PathItem)
+	var names []string
+	for _, pair := range paths.GetPath() {
+		items[pair.Name] = pair.Value
+		names = append(names, pair.Name)
+	}
+	return items, names
+}
+
+func (d *differ) comparePaths(old, new *openapi_v3.Paths) {
+	oldItems, oldNames := pathItems(old)
+	newItems, newNames := pathItems(new)
+	for _, name := range oldNames {
+		path := jsonpointer.Format("paths", name)
+		if newItem, ok := newItems[name]; ok {
+			d.comparePathItems(path, oldItems[name], newItem)
+		} else {
+			d.report(path, Removed, true, "path was removed")
+		}
+	}
+	for _, name := range newNames {
+		if _, ok := oldItems[name]; !ok {
+			d.report(jsonpointer.Format("paths", name), Added, false, "path was added")
+		}
+	}
+}
+
+func operations(item *openapi_v3.PathItem) []*openapi_v3.Operation {
+	return []*openapi_v3.Operation{
+		item.GetGet(), item.GetPut(), item.GetPost(), item.GetDelete(),
+		item.GetOptions(), item.GetHead(), item.GetPatch(), item.GetTrace(),
+	}
+}
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func (d *differ) comparePathItems(path string, old, new *openapi_v3.PathItem) {
+	oldOperations, newOperations := operations(old), operations(new)
+	for i, method := range methods {
+		oldOperation, newOperation := oldOperations[i], newOperations[i]
+		operationPath := jsonpointer.Append(path, method)
+		switch {
+		case oldOperation != nil && newOperation != nil:
+			d.compareOperations(operationPath, old, new, oldOperation, newOperation)
+		case oldOperation != nil:
+			d.report(operationPath, Removed, true, "operation was removed")
+		case newOperation != nil:
+			d.report(operationPath, Added, false, "operation was added")
+		}
+	}
+}
+
+// parameters returns the parameters of an operation and its path, keyed
+// by their locations and names. References to parameter components are
+// resolved.
+func parameters(document *openapi_v3.Document, item *openapi_v3.PathItem, op *openapi_v3.Operation) (map[string]*openapi_v3.Parameter, []string) {
+	params := make(map[string]*openapi_v3.Parameter)
+	var keys []string
+	add := func(list []*openapi_v3.ParameterOrReference) {
+		for _, p := range list {
+			parameter := resolveParameter(document, p)
+			if parameter == nil {
+				continue
+			}
+			key := parameter.In + "/" + parameter.Name
+			if _, ok := params[key]; !ok {
+				keys = append(keys, key)
+			}
+			params[key] = parameter
+		}
+	}
+	add(item.GetParameters())
+	add(op.GetParameters())
+	return params, keys
+}
+
+func resolveParameter(document *openapi_v3.Document, p *openapi_v3.ParameterOrReference) *openapi_v3.Parameter {
+	if parameter := p.GetParameter(); parameter != nil {
+		return parameter
+	}
+	const prefix = "#/components/parameters/"
+	ref := p.GetReference().GetXRef()
+	if !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+	parameters := document.GetComponents().GetParameters().GetAdditionalProperties()
+	return openapi_v3.FindNamedParameterOrReference(parameters, strings.TrimPrefix(ref, prefix)).GetParameter()
+}
+
+func (d *differ) compareOperations(path string, oldItem, newItem *openapi_v3.PathItem, old, new *openapi_v3.Operation) {
+	oldParams, oldKeys := parameters(d.old, oldItem, old)
+	newParams, newKeys := parameters(d.new, newItem, new)
+	for _, key := range oldKeys {
+		oldParam := oldParams[key]
+		paramPath := jsonpointer.Append(path, "parameters", oldParam.Name)
+		newParam, ok := newParams[key]
+		if !ok {
+			d.report(paramPath, Removed, false, "%s parameter was removed", oldParam.In)
+			continue
+		}
+		if newParam.Required && !oldParam.Required {
+			d.report(paramPath, Changed, true, "parameter became required")
+		}
+		d.compareSchemas(paramPath, oldParam.GetSchema(), newParam.GetSchema(), openapi_v3.ProviderMode)
+	}
+	for _, key := range newKeys {
+		if _, ok := oldParams[key]; !ok {
+			newParam := newParams[key]
+			d.report(jsonpointer.Append(path, "parameters", newParam.Name), Added, newParam.Required,
+				"%s parameter was added", newParam.In)
+		}
+	}
+	d.compareRequestBodies(jsonpointer.Append(path, "requestBody"), old.GetRequestBody().GetRequestBody(), new.GetRequestBody().GetRequestBody())
+	d.compareResponses(jsonpointer.Append(path, "responses"), old.GetResponses(), new.GetResponses())
+}
+
+func (d *differ) compareRequestBodies(path string, old, new *openapi_v3.RequestBody) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		d.report(path, Added, new.Required, "request body was added")
+		return
+	case new == nil:
+		d.report(path, Removed, false, "request body was removed")
+		return
+	}
+	if new.Required && !old.Required {
+		d.report(path, Changed, true, "request body became required")
+	}
+	d.compareContent(jsonpointer.Append(path, "content"), old.GetContent(), new.GetContent(), openapi_v3.ProviderMode)
+}
+
+func responses(r *openapi_v3.Responses) (map[string]*openapi_v3.Response, []string) {
+	result := make(map[string]*openapi_v3.Response)
+	var codes []string
+	if r.GetDefault() != nil {
+		result["default"] = r.GetDefault().GetResponse()
+		codes = append(codes, "default")
+	}
+	for _, pair := range r.GetResponseOrReference() {
+		result[pair.Name] = pair.Value.GetResponse()
+		codes = append(codes, pair.Name)
+	}
+	return result, codes
+}
+
+func (d *differ) compareResponses(path string, old, new *openapi_v3.Responses) {
+	oldResponses, oldCodes := responses(old)
+	newResponses, newCodes := responses(new)
+	for _, code := range oldCodes {
+		responsePath := jsonpointer.Append(path, code)
+		newResponse, ok := newResponses[code]
+		if !ok {
+			d.report(responsePath, Removed, false, "response was removed")
+			continue
+		}
+		if oldResponses[code] != nil && newResponse != nil {
+			d.compareContent(jsonpointer.Append(responsePath, "content"),
+				oldResponses[code].GetContent(), newResponse.GetContent(), openapi_v3.ConsumerMode)
+		}
+	}
+	for _, code := range newCodes {
+		if _, ok := oldResponses[code]; !ok {
+			d.report(jsonpointer.Append(path, code), Added, false, "response was added")
+		}
+	}
+}
+
+// compareContent compares the media types of request or response bodies.
+// Clients may send or accept any of the media types, so removing one is a
+// breaking change.
+func (d *differ) compareContent(path string, old, new *openapi_v3.MediaTypes, mode openapi_v3.CompatibilityMode) {
+	newMediaTypes := openapi_v3.NamedMediaTypeMap(new.GetAdditionalProperties())
+	oldMediaTypes := make(map[string]bool)
+	for _, pair := range old.GetAdditionalProperties() {
+		oldMediaTypes[pair.Name] = true
+		mediaTypePath := jsonpointer.Append(path, pair.Name)
+		newMediaType, ok := newMediaTypes[pair.Name]
+		if !ok {
+			d.report(mediaTypePath, Removed, true, "media type was removed")
+			continue
+		}
+		d.compareSchemas(jsonpointer.Append(mediaTypePath, "schema"), pair.Value.GetSchema(), newMediaType.GetSchema(), mode)
+	}
+	for _, pair := range new.GetAdditionalProperties() {
+		if !oldMediaTypes[pair.Name] {
+			d.report(jsonpointer.Append(path, pair.Name), Added, false, "media type was added")
+		}
+	}
+}
+
+// compareSchemas compares two schemas, which may be references. Schemas
+// that refer to the same component are compared with the components.
+func (d *differ) compareSchemas(path string, old, new *openapi_v3.SchemaOrReference, mode openapi_v3.CompatibilityMode) {
+	oldRef, newRef := old.GetReference().GetXRef(), new.GetReference().GetXRef()
+	switch {
+	case old == nil || new == nil:
+		return
+	case oldRef != "" || newRef != "":
+		if oldRef != newRef {
+			d.report(path, Changed, true, "schema changed from %s to %s", schemaName(old), schemaName(new))
+		}
+		return
+	}
+	for _, i := range openapi_v3.Compatible(old.GetSchema(), new.GetSchema(), mode) {
+		incompatibilityPath := path
+		if i.Path != "" {
+			incompatibilityPath = path + "/" + i.Path
+		}
+		d.report(incompatibilityPath, Changed, true, "%s", i.Message)
+	}
+}
+
+func schemaName(s *openapi_v3.SchemaOrReference) string {
+	if ref := s.GetReference(); ref != nil {
+		return ref.XRef
+	}
+	return "an inline schema"
+}
+
+func (d *differ) compareComponentSchemas(old, new *openapi_v3.SchemasOrReferences) {
+	newSchemas := openapi_v3.NamedSchemaOrReferenceMap(new.GetAdditionalProperties())
+	oldSchemas := make(map[string]bool)
+	for _, pair := range old.GetAdditionalProperties() {
+		oldSchemas[pair.Name] = true
+		path := jsonpointer.Format("components", "schemas", pair.Name)
+		newSchema, ok := newSchemas[pair.Name]
+		if !ok {
+			d.report(path, Removed, true, "schema was removed")
+			continue
+		}
+		// Report each incompatibility once, even if it breaks both
+		// requests and responses.
+		count := len(d.changes)
+		d.compareSchemas(path, pair.Value, newSchema, openapi_v3.ProviderMode)
+		reported := make(map[string]bool)
+		for _, c := range d.changes[count:] {
+			reported[c.Path+c.Message] = true
+		}
+		consumerChanges := &differ{old: d.old, new: d.new}
+		consumerChanges.compareSchemas(path, pair.Value, newSchema, openapi_v3.ConsumerMode)
+		for _, c := range consumerChanges.changes {
+			if !reported[c.Path+c.Message] {
+				d.changes = append(d.changes, c)
+			}
+		}
+	}
+	for _, pair := range new.GetAdditionalProperties() {
+		if !oldSchemas[pair.Name] {
+			d.report(jsonpointer.Format("components", "schemas", pair.Name), Added, false, "schema was added")
+		}
+	}
+}
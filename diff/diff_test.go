@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/gnostic/findings"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+const oldDocument = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: Pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+            application/xml:
+              schema:
+                type: array
+    post:
+      responses:
+        "201":
+          description: Created
+  /pets/{id}:
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+    Owner:
+      type: object
+`
+
+const newDocument = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: string
+        - name: owner
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: Created
+  /owners:
+    get:
+      responses:
+        "200":
+          description: Owners
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: integer
+`
+
+func TestCompare(t *testing.T) {
+	old, err := openapi_v3.ParseDocument([]byte(oldDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	new, err := openapi_v3.ParseDocument([]byte(newDocument))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	changes := Compare(old, new)
+	expected := []struct {
+		path     string
+		kind     string
+		breaking bool
+	}{
+		{"/paths/~1pets/get/parameters/limit", Changed, true},
+		{"/paths/~1pets/get/parameters/owner", Added, true},
+		{"/paths/~1pets/get/responses/200/content/application~1xml", Removed, true},
+		{"/paths/~1pets/post/requestBody", Added, false},
+		{"/paths/~1pets~1{id}", Removed, true},
+		{"/paths/~1owners", Added, false},
+		{"/components/schemas/Pet/properties/name", Changed, true},
+		{"/components/schemas/Owner", Removed, true},
+	}
+	if len(changes) != len(expected) {
+		for _, c := range changes {
+			t.Logf("%s", c)
+		}
+		t.Fatalf("expected %d changes, got %d", len(expected), len(changes))
+	}
+	for i, e := range expected {
+		c := changes[i]
+		if c.Path != e.path || c.Kind != e.kind || c.Breaking != e.breaking {
+			t.Errorf("expected %s %s %t, got %+v", e.path, e.kind, e.breaking, c)
+		}
+	}
+	if !HasBreakingChanges(changes) {
+		t.Errorf("expected breaking changes")
+	}
+	if changes := Compare(old, old); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestFindings(t *testing.T) {
+	changes := []*Change{
+		{Path: "/paths/~1pets", Kind: Removed, Message: "path removed", Breaking: true},
+		{Path: "/paths/~1owners", Kind: Added, Message: "path added"},
+	}
+	result := Findings("pets.yaml", changes)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(result))
+	}
+	if f := result[0]; f.Rule != BreakingChangeRule || f.Severity != findings.Error || f.Pointer != "/paths/~1pets" || f.Source != "pets.yaml" {
+		t.Errorf("unexpected finding %+v", f)
+	}
+	if f := result[1]; f.Rule != ChangeRule || f.Severity != findings.Info {
+		t.Errorf("unexpected finding %+v", f)
+	}
+}
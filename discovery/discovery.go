@@ -59,6 +59,7 @@ func NewAnnotations(in *yaml.Node, context *compiler.Context) (*Annotations, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -68,6 +69,7 @@ func NewAny(in *yaml.Node, context *compiler.Context) (*Any, error) {
 	x := &Any{}
 	bytes := compiler.Marshal(in)
 	x.Yaml = string(bytes)
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -97,6 +99,7 @@ func NewAuth(in *yaml.Node, context *compiler.Context) (*Auth, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -406,6 +409,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -450,6 +454,7 @@ func NewIcons(in *yaml.Node, context *compiler.Context) (*Icons, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -508,6 +513,7 @@ func NewMediaUpload(in *yaml.Node, context *compiler.Context) (*MediaUpload, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -685,6 +691,7 @@ func NewMethod(in *yaml.Node, context *compiler.Context) (*Method, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -700,11 +707,27 @@ func NewMethods(in *yaml.Node, context *compiler.Context) (*Methods, error) {
 		// repeated NamedMethod additional_properties = 1;
 		// MAP: Method
 		x.AdditionalProperties = make([]*NamedMethod, 0)
+		var pairs1 []NamedMethod
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedMethod, 0, n)
+			pairs1 = make([]NamedMethod, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedMethod{}
+				var pair *NamedMethod
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedMethod{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewMethod(v, compiler.NewContext(k, v, context))
@@ -715,6 +738,7 @@ func NewMethods(in *yaml.Node, context *compiler.Context) (*Methods, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -753,6 +777,7 @@ func NewNamedMethod(in *yaml.Node, context *compiler.Context) (*NamedMethod, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -791,6 +816,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -829,6 +855,7 @@ func NewNamedResource(in *yaml.Node, context *compiler.Context) (*NamedResource,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -867,6 +894,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -905,6 +933,7 @@ func NewNamedScope(in *yaml.Node, context *compiler.Context) (*NamedScope, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -934,6 +963,7 @@ func NewOauth2(in *yaml.Node, context *compiler.Context) (*Oauth2, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1120,6 +1150,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1135,11 +1166,27 @@ func NewParameters(in *yaml.Node, context *compiler.Context) (*Parameters, error
 		// repeated NamedParameter additional_properties = 1;
 		// MAP: Parameter
 		x.AdditionalProperties = make([]*NamedParameter, 0)
+		var pairs1 []NamedParameter
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedParameter, 0, n)
+			pairs1 = make([]NamedParameter, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedParameter{}
+				var pair *NamedParameter
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedParameter{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewParameter(v, compiler.NewContext(k, v, context))
@@ -1150,6 +1197,7 @@ func NewParameters(in *yaml.Node, context *compiler.Context) (*Parameters, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1188,6 +1236,7 @@ func NewProtocols(in *yaml.Node, context *compiler.Context) (*Protocols, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1226,6 +1275,7 @@ func NewRequest(in *yaml.Node, context *compiler.Context) (*Request, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1264,6 +1314,7 @@ func NewResource(in *yaml.Node, context *compiler.Context) (*Resource, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1279,11 +1330,27 @@ func NewResources(in *yaml.Node, context *compiler.Context) (*Resources, error)
 		// repeated NamedResource additional_properties = 1;
 		// MAP: Resource
 		x.AdditionalProperties = make([]*NamedResource, 0)
+		var pairs1 []NamedResource
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedResource, 0, n)
+			pairs1 = make([]NamedResource, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedResource{}
+				var pair *NamedResource
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedResource{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewResource(v, compiler.NewContext(k, v, context))
@@ -1294,6 +1361,7 @@ func NewResources(in *yaml.Node, context *compiler.Context) (*Resources, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1323,6 +1391,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1361,6 +1430,7 @@ func NewResumable(in *yaml.Node, context *compiler.Context) (*Resumable, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1556,6 +1626,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1571,11 +1642,27 @@ func NewSchemas(in *yaml.Node, context *compiler.Context) (*Schemas, error) {
 		// repeated NamedSchema additional_properties = 1;
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
+		var pairs1 []NamedSchema
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSchema, 0, n)
+			pairs1 = make([]NamedSchema, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSchema{}
+				var pair *NamedSchema
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSchema{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSchema(v, compiler.NewContext(k, v, context))
@@ -1586,6 +1673,7 @@ func NewSchemas(in *yaml.Node, context *compiler.Context) (*Schemas, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1615,6 +1703,7 @@ func NewScope(in *yaml.Node, context *compiler.Context) (*Scope, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1630,11 +1719,27 @@ func NewScopes(in *yaml.Node, context *compiler.Context) (*Scopes, error) {
 		// repeated NamedScope additional_properties = 1;
 		// MAP: Scope
 		x.AdditionalProperties = make([]*NamedScope, 0)
+		var pairs1 []NamedScope
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedScope, 0, n)
+			pairs1 = make([]NamedScope, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedScope{}
+				var pair *NamedScope
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedScope{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewScope(v, compiler.NewContext(k, v, context))
@@ -1645,6 +1750,7 @@ func NewScopes(in *yaml.Node, context *compiler.Context) (*Scopes, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1683,6 +1789,7 @@ func NewSimple(in *yaml.Node, context *compiler.Context) (*Simple, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1695,5 +1802,116 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 		s, _ := compiler.StringForScalarNode(node)
 		x.Value = append(x.Value, s)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
+
+// NamedMethodMap returns a map from the names of NamedMethod pairs to their values.
+// When names are repeated, the first value is used.
+func NamedMethodMap(pairs []*NamedMethod) map[string]*Method {
+	m := make(map[string]*Method, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedMethod returns the value of the first NamedMethod pair with a name.
+func FindNamedMethod(pairs []*NamedMethod, name string) *Method {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedParameterMap returns a map from the names of NamedParameter pairs to their values.
+// When names are repeated, the first value is used.
+func NamedParameterMap(pairs []*NamedParameter) map[string]*Parameter {
+	m := make(map[string]*Parameter, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedParameter returns the value of the first NamedParameter pair with a name.
+func FindNamedParameter(pairs []*NamedParameter, name string) *Parameter {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedResourceMap returns a map from the names of NamedResource pairs to their values.
+// When names are repeated, the first value is used.
+func NamedResourceMap(pairs []*NamedResource) map[string]*Resource {
+	m := make(map[string]*Resource, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedResource returns the value of the first NamedResource pair with a name.
+func FindNamedResource(pairs []*NamedResource, name string) *Resource {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedSchemaMap returns a map from the names of NamedSchema pairs to their values.
+// When names are repeated, the first value is used.
+func NamedSchemaMap(pairs []*NamedSchema) map[string]*Schema {
+	m := make(map[string]*Schema, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedSchema returns the value of the first NamedSchema pair with a name.
+func FindNamedSchema(pairs []*NamedSchema, name string) *Schema {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedScopeMap returns a map from the names of NamedScope pairs to their values.
+// When names are repeated, the first value is used.
+func NamedScopeMap(pairs []*NamedScope) map[string]*Scope {
+	m := make(map[string]*Scope, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedScope returns the value of the first NamedScope pair with a name.
+func FindNamedScope(pairs []*NamedScope, name string) *Scope {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_v1
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzParseDocument checks that malformed documents are reported as errors
+// instead of causing panics. Run it with:
+//
+//	go test -fuzz=FuzzParseDocument ./discovery
+func FuzzParseDocument(f *testing.F) {
+	for _, pattern := range []string{
+		"../examples/discovery/*.json",
+	} {
+		filenames, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatalf("%s", err)
+		}
+		for _, filename := range filenames {
+			b, err := ioutil.ReadFile(filename)
+			if err != nil {
+				f.Fatalf("%s", err)
+			}
+			f.Add(b)
+		}
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d, err := ParseDocument(b)
+		if err != nil {
+			return
+		}
+		d.ToRawInfo()
+	})
+}
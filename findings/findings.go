@@ -0,0 +1,105 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package findings is a model of the problems that gnostic finds in API
+// descriptions, such as lint findings, compilation errors, and breaking
+// changes, that can be written in the SARIF format of code scanning tools.
+package findings
+
+import (
+	"github.com/google/gnostic/compiler"
+)
+
+// Severities of findings.
+const (
+	Info    = "info"
+	Warning = "warning"
+	Error   = "error"
+	Fatal   = "fatal"
+)
+
+// Finding is a problem in an API description.
+type Finding struct {
+	Rule       string `json:"rule"`                 // the rule that found the problem, e.g. "AIP-122"
+	Severity   string `json:"severity"`             // Info, Warning, Error, or Fatal
+	Source     string `json:"source,omitempty"`     // the file name or URL of the description
+	Pointer    string `json:"pointer"`              // a JSON pointer to the value with the problem
+	Line       int    `json:"line,omitempty"`       // the line of the value in the source, if known
+	Column     int    `json:"column,omitempty"`     // the column of the value in the source, if known
+	Message    string `json:"message"`              // a description of the problem
+	Suggestion string `json:"suggestion,omitempty"` // a way to fix the problem, if any
+	Tool       string `json:"linter"`               // the linter, plugin, or subsystem that found it
+}
+
+// HasErrors returns true if any of the findings are errors.
+func HasErrors(findings []*Finding) bool {
+	for _, f := range findings {
+		if f.Severity == Error || f.Severity == Fatal {
+			return true
+		}
+	}
+	return false
+}
+
+// Locate sets the source of findings and, for findings without positions,
+// the positions of the values that their pointers refer to. Values that
+// aren't in the index, such as those added by compilation, get the
+// position of their closest ancestor.
+func Locate(findings []*Finding, source string, locations *compiler.LocationIndex) {
+	for _, f := range findings {
+		if f.Source == "" {
+			f.Source = source
+		}
+		if f.Line == 0 && locations != nil {
+			location := locations.Lookup(f.Pointer)
+			f.Line, f.Column = location.Line, location.Column
+		}
+	}
+}
+
+// CompilerTool is the tool of the findings of compilation errors.
+const CompilerTool = "gnostic"
+
+// CompilerRule is the rule of the findings of compilation errors.
+const CompilerRule = "compile"
+
+// FromError returns a finding for each error in an error or error group
+// that was returned by the compiler. Errors of the compiler have the
+// positions of the values that they describe.
+func FromError(source string, err error) []*Finding {
+	findings := make([]*Finding, 0)
+	if group, ok := err.(*compiler.ErrorGroup); ok {
+		for _, e := range group.Errors {
+			findings = append(findings, FromError(source, e)...)
+		}
+		return findings
+	}
+	if err == nil {
+		return findings
+	}
+	f := &Finding{
+		Rule:     CompilerRule,
+		Severity: Error,
+		Source:   source,
+		Message:  err.Error(),
+		Tool:     CompilerTool,
+	}
+	if e, ok := err.(*compiler.Error); ok && e.Context != nil {
+		f.Message = e.Context.Description() + " " + e.Message
+		if e.Context.Node != nil {
+			f.Line, f.Column = e.Context.Node.Line, e.Context.Node.Column
+		}
+	}
+	return append(findings, f)
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package findings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+)
+
+func TestFromError(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: "x", Line: 3, Column: 5}
+	context := compiler.NewContext("info", node, compiler.NewContext("$root", nil, nil))
+	err := compiler.NewErrorGroupOrNil([]error{
+		compiler.NewError(context, "has unexpected value"),
+		errors.New("unknown format"),
+	})
+	result := FromError("api.yaml", err)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(result))
+	}
+	if f := result[0]; f.Line != 3 || f.Column != 5 || f.Message != "$root.info has unexpected value" || f.Source != "api.yaml" {
+		t.Errorf("unexpected finding %+v", f)
+	}
+	if f := result[1]; f.Line != 0 || f.Message != "unknown format" || f.Severity != Error {
+		t.Errorf("unexpected finding %+v", f)
+	}
+	if !HasErrors(result) {
+		t.Errorf("expected errors")
+	}
+	if result := FromError("api.yaml", nil); len(result) != 0 {
+		t.Errorf("expected no findings, got %+v", result)
+	}
+}
+
+func TestLocate(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("paths:\n  /pets:\n    get: {}\n"), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	result := []*Finding{
+		{Pointer: "/paths/~1pets/get"},
+		{Pointer: "/paths/~1pets/get/responses"},
+		{Pointer: "/paths", Source: "other.yaml", Line: 9, Column: 1},
+	}
+	Locate(result, "api.yaml", compiler.NewLocationIndex(&node))
+	expected := []Finding{
+		{Pointer: "/paths/~1pets/get", Source: "api.yaml", Line: 3, Column: 5},
+		{Pointer: "/paths/~1pets/get/responses", Source: "api.yaml", Line: 3, Column: 5},
+		{Pointer: "/paths", Source: "other.yaml", Line: 9, Column: 1},
+	}
+	for i, f := range result {
+		if *f != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], *f)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	bytes, err := json.Marshal(&Finding{Rule: "AIP-140", Severity: Error, Message: "a", Tool: "builtin"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `{"rule":"AIP-140","severity":"error","pointer":"","message":"a","linter":"builtin"}`
+	if string(bytes) != expected {
+		t.Errorf("unexpected JSON %s", bytes)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteSARIF(&buffer, []*Finding{
+		{Rule: "AIP-140", Severity: Error, Source: "api.yaml", Pointer: "/paths", Line: 2, Column: 1, Message: "a", Suggestion: "b", Tool: "builtin"},
+		{Rule: "PATH", Severity: Info, Message: "c"},
+		{Rule: "AIP-140", Severity: Warning, Source: "api.yaml", Message: "d"},
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				RuleIndex int
+				Level     string
+				Message   struct{ Text string }
+				Locations []struct {
+					PhysicalLocation *struct {
+						ArtifactLocation struct{ URI string }
+						Region           *struct{ StartLine, StartColumn int }
+					}
+					LogicalLocations []struct{ FullyQualifiedName string }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &log); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "gnostic" {
+		t.Fatalf("unexpected log %s", buffer.String())
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].ID != "PATH" {
+		t.Errorf("unexpected rules %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+	r := run.Results[0]
+	if r.Level != "error" || r.Message.Text != "a\nb" || len(r.Locations) != 1 {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	l := r.Locations[0]
+	if l.PhysicalLocation.ArtifactLocation.URI != "api.yaml" || l.PhysicalLocation.Region.StartLine != 2 || l.LogicalLocations[0].FullyQualifiedName != "/paths" {
+		t.Errorf("unexpected location %+v", l)
+	}
+	if r := run.Results[1]; r.Level != "note" || r.RuleIndex != 1 || len(r.Locations) != 0 {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if r := run.Results[2]; r.Level != "warning" || r.RuleIndex != 0 || r.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("unexpected result %+v", r)
+	}
+}
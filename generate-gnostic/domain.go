@@ -34,6 +34,7 @@ type Domain struct {
 	ObjectTypeRequests    map[string]*TypeRequest // anonymous types implied by type instantiation
 	MapTypeRequests       map[string]string       // "NamedObject" types that will be used to implement ordered maps
 	Version               string                  // OpenAPI Version ("v2" or "v3")
+	ConcurrentMaps        map[string]bool         // "Type.Field" maps with entries that can be built concurrently
 }
 
 // NewDomain creates a domain representation.
@@ -44,6 +45,7 @@ func NewDomain(schema *jsonschema.Schema, version string) *Domain {
 	cc.PropertyNameOverrides = make(map[string]string, 0)
 	cc.ObjectTypeRequests = make(map[string]*TypeRequest, 0)
 	cc.MapTypeRequests = make(map[string]string, 0)
+	cc.ConcurrentMaps = make(map[string]bool, 0)
 	cc.Schema = schema
 	cc.Version = version
 	return cc
@@ -101,6 +101,11 @@ func (domain *Domain) GenerateCompiler(packageName string, license string, impor
 		domain.generateConstructorForType(code, typeName, regexPatterns)
 	}
 
+	// generate lookup functions for each type of pair
+	for _, typeName := range typeNames {
+		domain.generateLookupFunctionsForType(code, typeName)
+	}
+
 	// generate ResolveReferences() methods for each type
 	for _, typeName := range typeNames {
 		domain.generateResolveReferencesMethodsForType(code, typeName)
@@ -513,13 +518,13 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				}
 			} else {
 				mapTypeName := propertyModel.MapType
-				if mapTypeName != "" {
+				if mapTypeName != "" && domain.ConcurrentMaps[typeName+"."+fieldName] {
 					code.Print("// MAP: %s %s", mapTypeName, propertyModel.Pattern)
-					if mapTypeName == "string" {
-						code.Print("x.%s = make([]*NamedString, 0)", fieldName)
-					} else {
-						code.Print("x.%s = make([]*Named%s, 0)", fieldName, mapTypeName)
-					}
+					domain.generateConcurrentMap(code, fieldName, mapTypeName, propertyModel.Pattern, fieldNumber, regexPatterns)
+				} else if mapTypeName != "" {
+					code.Print("// MAP: %s %s", mapTypeName, propertyModel.Pattern)
+					pairTypeName := "Named" + strings.Title(mapTypeName)
+					generateMapArena(code, fieldName, pairTypeName, propertyModel.Pattern, fieldNumber, regexPatterns)
 					code.Print("for i := 0; i < len(m.Content); i += 2 {")
 					code.Print("k, ok := compiler.StringForScalarNode(m.Content[i])")
 					code.Print("if ok {")
@@ -532,7 +537,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						}
 					}
 
-					code.Print("pair := &Named" + strings.Title(mapTypeName) + "{}")
+					generateNewPair(code, fieldName, pairTypeName, fieldNumber)
 					code.Print("pair.Name = k")
 
 					if mapTypeName == "string" {
@@ -592,10 +597,131 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 	}
 
 	// assumes that the return value is in a variable named "x"
+	code.Print("  compiler.RecordPosition(context, x, in)")
 	code.Print("  return x, compiler.NewErrorGroupOrNil(errors)")
 	code.Print("}\n")
 }
 
+// generateConcurrentMap generates code that reads the entries of a map
+// and then builds their values with compiler.BuildConcurrently. Values are
+// built independently, so this is only used for maps of message types.
+func (domain *Domain) generateConcurrentMap(code *printer.Code, fieldName, mapTypeName, pattern string, fieldNumber int, regexPatterns *patternNames) {
+	pairTypeName := "Named" + mapTypeName
+	generateMapArena(code, fieldName, pairTypeName, pattern, fieldNumber, regexPatterns)
+	code.Print("{")
+	code.Print("values := make([]*yaml.Node, 0)")
+	code.Print("for i := 0; i < len(m.Content); i += 2 {")
+	code.Print("k, ok := compiler.StringForScalarNode(m.Content[i])")
+	code.Print("if ok {")
+	code.Print("v := m.Content[i+1]")
+	if pattern != "" {
+		if inline, ok := regexPatterns.SpecialCaseExpression(pattern, "k"); ok {
+			code.Print("if %s {", inline)
+		} else {
+			code.Print("if %s.MatchString(k) {", nameForPattern(regexPatterns, pattern))
+		}
+	}
+	generateNewPair(code, fieldName, pairTypeName, fieldNumber)
+	code.Print("pair.Name = k")
+	code.Print("x.%s = append(x.%s, pair)", fieldName, fieldName)
+	code.Print("values = append(values, v)")
+	if pattern != "" {
+		code.Print("}")
+	}
+	code.Print("}")
+	code.Print("}")
+	code.Print("valueErrors := make([]error, len(values))")
+	code.Print("compiler.BuildConcurrently(len(values), func(i int) {")
+	code.Print("pair, v := x.%s[i], values[i]", fieldName)
+	code.Print("pair.Value, valueErrors[i] = New%s(v, compiler.NewContext(pair.Name, v, context))", mapTypeName)
+	code.Print("})")
+	code.Print("for _, err := range valueErrors {")
+	code.Print("if err != nil {")
+	code.Print("errors = append(errors, err)")
+	code.Print("}")
+	code.Print("}")
+	code.Print("}")
+}
+
+// generateLookupFunctionsForType generates functions that find the values
+// of name-value pairs by name.
+func (domain *Domain) generateLookupFunctionsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if !typeModel.IsPair {
+		return
+	}
+	valueType := "*" + typeModel.PairValueType
+	zeroValue := "nil"
+	if typeModel.PairValueType == "string" {
+		valueType = "string"
+		zeroValue = `""`
+	}
+	code.Print("// %sMap returns a map from the names of %s pairs to their values.", typeName, typeName)
+	code.Print("// When names are repeated, the first value is used.")
+	code.Print("func %sMap(pairs []*%s) map[string]%s {", typeName, typeName, valueType)
+	code.Print("m := make(map[string]%s, len(pairs))", valueType)
+	code.Print("for _, pair := range pairs {")
+	code.Print("if _, ok := m[pair.GetName()]; !ok {")
+	code.Print("m[pair.GetName()] = pair.GetValue()")
+	code.Print("}")
+	code.Print("}")
+	code.Print("return m")
+	code.Print("}\n")
+	code.Print("// Find%s returns the value of the first %s pair with a name.", typeName, typeName)
+	code.Print("func Find%s(pairs []*%s, name string) %s {", typeName, typeName, valueType)
+	code.Print("for _, pair := range pairs {")
+	code.Print("if pair.GetName() == name {")
+	code.Print("return pair.GetValue()")
+	code.Print("}")
+	code.Print("}")
+	code.Print("return %s", zeroValue)
+	code.Print("}\n")
+}
+
+// generateMapArena generates code that creates the slice of pairs of a map.
+// When arena allocation is enabled, the entries that will be added are
+// counted first, and the pairs are allocated together in a block.
+func generateMapArena(code *printer.Code, fieldName, pairTypeName, pattern string, fieldNumber int, regexPatterns *patternNames) {
+	condition := ""
+	if pattern != "" {
+		if inline, ok := regexPatterns.SpecialCaseExpression(pattern, "k"); ok {
+			if inline != "true" {
+				condition = inline
+			}
+		} else {
+			condition = fmt.Sprintf("%s.MatchString(k)", nameForPattern(regexPatterns, pattern))
+		}
+	}
+	code.Print("x.%s = make([]*%s, 0)", fieldName, pairTypeName)
+	code.Print("var pairs%d []%s", fieldNumber, pairTypeName)
+	code.Print("if compiler.GetArenaAllocation() {")
+	code.Print("n := 0")
+	code.Print("for i := 0; i < len(m.Content); i += 2 {")
+	if condition != "" {
+		code.Print("if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && %s {", condition)
+	} else {
+		code.Print("if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {")
+	}
+	code.Print("n++")
+	code.Print("}")
+	code.Print("}")
+	code.Print("x.%s = make([]*%s, 0, n)", fieldName, pairTypeName)
+	code.Print("pairs%d = make([]%s, n)", fieldNumber, pairTypeName)
+	code.Print("}")
+}
+
+// generateNewPair generates code that creates a pair in a variable named
+// "pair", using the next pair of the block allocated by generateMapArena
+// if there is one.
+func generateNewPair(code *printer.Code, fieldName, pairTypeName string, fieldNumber int) {
+	code.Print("var pair *%s", pairTypeName)
+	code.Print("if pairs%d != nil {", fieldNumber)
+	code.Print("pair = &pairs%d[len(x.%s)]", fieldNumber, fieldName)
+	code.Print("} else {")
+	code.Print("pair = &%s{}", pairTypeName)
+	code.Print("}")
+}
+
 // ResolveReferences() methods
 func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code, typeName string) {
 	code.Print("// ResolveReferences resolves references found inside %s objects.", typeName)
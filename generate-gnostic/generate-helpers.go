@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/google/gnostic/printer"
+)
+
+// generateHelpers produces the contents of a Go file that declares helpers
+// for the messages of a model: DeepCopy, Equal, and Merge functions for each
+// message that isn't a pair of a map. The messages are read from the
+// descriptor of the model's .proto file, so that helpers are only declared
+// for messages that the model has.
+func generateHelpers(packageName string, license string, file protoreflect.FileDescriptor) string {
+	code := &printer.Code{}
+	code.Print(license)
+	code.Print("// THIS FILE IS AUTOMATICALLY GENERATED.")
+	code.Print()
+	code.Print("package %s", packageName)
+	code.Print()
+	code.Print("import (")
+	code.Print("\"github.com/golang/protobuf/proto\"")
+	code.Print()
+	code.Print("\"github.com/google/gnostic/compiler\"")
+	code.Print(")")
+	messages := file.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		if isPairMessage(message) {
+			continue
+		}
+		typeName := string(message.Name())
+		code.Print()
+		code.Print("// DeepCopy%s returns a copy of x that shares no values with it.", typeName)
+		code.Print("func DeepCopy%s(x *%s) *%s {", typeName, typeName, typeName)
+		code.Print("if x == nil {")
+		code.Print("return nil")
+		code.Print("}")
+		code.Print("return proto.Clone(x).(*%s)", typeName)
+		code.Print("}")
+		code.Print()
+		code.Print("// Equal%s reports whether a and b are equal, comparing maps without", typeName)
+		code.Print("// regard to order and Any values by their YAML values.")
+		code.Print("func Equal%s(a, b *%s) bool {", typeName, typeName)
+		code.Print("return compiler.EqualModels(a, b)")
+		code.Print("}")
+		code.Print()
+		code.Print("// Merge%s merges src into dst, merging the values of maps with the", typeName)
+		code.Print("// values of dst that have the same names.")
+		code.Print("func Merge%s(dst, src *%s) {", typeName, typeName)
+		code.Print("compiler.MergeModels(dst, src)")
+		code.Print("}")
+	}
+	return code.String()
+}
+
+// isPairMessage returns true for messages that hold the names and values
+// of maps.
+func isPairMessage(message protoreflect.MessageDescriptor) bool {
+	fields := message.Fields()
+	return fields.Len() == 2 && fields.ByName("name") != nil && fields.ByName("value") != nil
+}
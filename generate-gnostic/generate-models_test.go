@@ -0,0 +1,92 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func testDomain() *Domain {
+	domain := NewDomain(nil, "v2")
+	parameter := NewTypeModel()
+	parameter.Name = "Parameter"
+	parameter.Description = "Describes a parameter."
+	parameter.addProperty(&TypeProperty{Name: "name", Type: "string", Description: "The name of the parameter."})
+	parameter.addProperty(&TypeProperty{Name: "in", Type: "string"})
+	parameter.addProperty(&TypeProperty{Name: "maxLength", Type: "int"})
+	parameter.addProperty(&TypeProperty{Name: "$ref", Type: "string"})
+	parameter.addProperty(&TypeProperty{Name: "vendorExtension", Type: "NamedAny", Repeated: true})
+	domain.TypeModels[parameter.Name] = parameter
+	empty := NewTypeModel()
+	empty.Name = "Empty"
+	domain.TypeModels[empty.Name] = empty
+	return domain
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	code := testDomain().generateTypeScript("")
+	expected := `
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+export interface Empty {
+}
+
+// Describes a parameter.
+export interface Parameter {
+  // The name of the parameter.
+  name?: string;
+  in?: string;
+  maxLength?: number;
+  Ref?: string;
+  vendorExtension?: NamedAny[];
+}
+
+`
+	if code != expected {
+		t.Errorf("unexpected TypeScript:\n%s\nexpected:\n%s", code, expected)
+	}
+}
+
+func TestGeneratePython(t *testing.T) {
+	code := testDomain().generatePython("// License\n")
+	expected := `# License
+
+# THIS FILE IS AUTOMATICALLY GENERATED.
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from typing import List, Optional
+
+
+@dataclass
+class Empty:
+    pass
+
+
+@dataclass
+class Parameter:
+    """Describes a parameter."""
+    # The name of the parameter.
+    name: Optional[str] = None
+    in_: Optional[str] = None
+    max_length: Optional[int] = None
+    _ref: Optional[str] = None
+    vendor_extension: List[NamedAny] = field(default_factory=list)
+`
+	if code != expected {
+		t.Errorf("unexpected Python:\n%s\nexpected:\n%s", code, expected)
+	}
+}
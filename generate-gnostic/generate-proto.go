@@ -100,16 +100,7 @@ func (domain *Domain) generateProtoMessage(code *printer.Code, typeName string)
 		if propertyType == "blob" {
 			propertyType = "string"
 		}
-		// adjust the display name to a valid identifier
-		propertyName := propertyModel.Name
-		var displayName = propertyName
-		if displayName == "$ref" {
-			displayName = "_ref"
-		}
-		if displayName == "$schema" {
-			displayName = "_schema"
-		}
-		displayName = camelCaseToSnakeCase(displayName)
+		displayName := propertyModel.ProtoFieldName()
 		// assign a field number to the property
 		fieldNumber++
 		// print the field declaration
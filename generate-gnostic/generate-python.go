@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/printer"
+)
+
+// pythonKeywords are the keywords of Python that can't be used as field names.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// generatePython produces the contents of a Python module that declares a
+// dataclass for each type of the domain. Field names are the names of the
+// fields of the corresponding protocol buffer messages, with a trailing
+// underscore added to names that are Python keywords.
+func (domain *Domain) generatePython(license string) string {
+	code := &printer.Code{}
+	for _, line := range strings.Split(strings.TrimSuffix(license, "\n"), "\n") {
+		code.Print("#%s", strings.TrimPrefix(line, "//"))
+	}
+	code.Print()
+	code.Print("# THIS FILE IS AUTOMATICALLY GENERATED.")
+	code.Print()
+	code.Print("from __future__ import annotations")
+	code.Print()
+	code.Print("from dataclasses import dataclass, field")
+	code.Print("from typing import List, Optional")
+	for _, typeName := range domain.sortedTypeNames() {
+		code.Print()
+		code.Print()
+		domain.generatePythonClass(code, typeName)
+	}
+	return code.String()
+}
+
+func (domain *Domain) generatePythonClass(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	code.Print("@dataclass")
+	code.Print("class %s:", typeName)
+	// Python conventionally indents with four spaces.
+	code.Indent()
+	code.Indent()
+	if typeModel.Description != "" {
+		code.Print("\"\"\"%s\"\"\"", strings.Replace(typeModel.Description, "\"\"\"", "\\\"\\\"\\\"", -1))
+	}
+	if len(typeModel.Properties) == 0 && typeModel.Description == "" {
+		code.Print("pass")
+	}
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.Description != "" {
+			for _, line := range strings.Split(propertyModel.Description, "\n") {
+				code.Print("# %s", line)
+			}
+		}
+		fieldName := propertyModel.ProtoFieldName()
+		if pythonKeywords[fieldName] {
+			fieldName += "_"
+		}
+		propertyType := pythonType(propertyModel.Type)
+		if propertyModel.Repeated {
+			code.Print("%s: List[%s] = field(default_factory=list)", fieldName, propertyType)
+		} else {
+			code.Print("%s: Optional[%s] = None", fieldName, propertyType)
+		}
+	}
+	code.Outdent()
+	code.Outdent()
+}
+
+// pythonType returns the Python type for a property type.
+func pythonType(propertyType string) string {
+	switch propertyType {
+	case "string", "blob":
+		return "str"
+	case "int":
+		return "int"
+	case "float":
+		return "float"
+	case "bool":
+		return "bool"
+	case "google.protobuf.Any":
+		// Any values are represented as JSON objects.
+		return "dict"
+	default:
+		return propertyType
+	}
+}
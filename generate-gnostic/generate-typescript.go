@@ -0,0 +1,74 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/google/gnostic/printer"
+)
+
+// generateTypeScript produces the contents of a TypeScript file that declares
+// an interface for each type of the domain. Interfaces describe the JSON
+// representations of the corresponding protocol buffer messages, so field
+// names are lower camel case and all fields are optional.
+func (domain *Domain) generateTypeScript(license string) string {
+	code := &printer.Code{}
+	code.Print(license)
+	code.Print("// THIS FILE IS AUTOMATICALLY GENERATED.")
+	code.Print()
+	for _, typeName := range domain.sortedTypeNames() {
+		domain.generateTypeScriptInterface(code, typeName)
+	}
+	return code.String()
+}
+
+func (domain *Domain) generateTypeScriptInterface(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if typeModel.Description != "" {
+		code.Print("// %s", typeModel.Description)
+	}
+	code.Print("export interface %s {", typeName)
+	code.Indent()
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.Description != "" {
+			code.Print("// %s", propertyModel.Description)
+		}
+		propertyType := typeScriptType(propertyModel.Type)
+		if propertyModel.Repeated {
+			propertyType += "[]"
+		}
+		fieldName := snakeCaseToCamelCase(propertyModel.ProtoFieldName())
+		code.Print("%s?: %s;", fieldName, propertyType)
+	}
+	code.Outdent()
+	code.Print("}")
+	code.Print()
+}
+
+// typeScriptType returns the TypeScript type for a property type.
+func typeScriptType(propertyType string) string {
+	switch propertyType {
+	case "string", "blob":
+		return "string"
+	case "int", "float":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "google.protobuf.Any":
+		// Any values are represented as JSON objects with a "@type" field.
+		return "{ [key: string]: unknown }"
+	default:
+		return propertyType
+	}
+}
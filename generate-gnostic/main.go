@@ -26,7 +26,10 @@ import (
 	"path"
 	"strings"
 
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	openapiv3 "github.com/google/gnostic-models/openapiv3"
 	"golang.org/x/tools/imports"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/google/gnostic/jsonschema"
 )
@@ -90,7 +93,9 @@ func protoOptions(directoryName string, packageName string) []ProtoOption {
 	}
 }
 
-func generateOpenAPIModel(version string) error {
+// generateOpenAPIModel generates the .proto file and Go compiler for an
+// OpenAPI version and, if requested, TypeScript and Python models.
+func generateOpenAPIModel(version string, generateTypeScript, generatePython bool) error {
 	var input string
 	var filename string
 	var protoPackageName string
@@ -156,6 +161,11 @@ func generateOpenAPIModel(version string) error {
 			"PathItem":      "Path",
 			"ResponseValue": "ResponseCode",
 		}
+		// paths and named schemas are usually the largest parts of descriptions
+		cc.ConcurrentMaps = map[string]bool{
+			"Paths.Path": true,
+			"SchemasOrReferences.AdditionalProperties": true,
+		}
 	case "discovery":
 		cc.TypeNameOverrides = map[string]string{}
 		cc.PropertyNameOverrides = map[string]string{}
@@ -213,7 +223,67 @@ func generateOpenAPIModel(version string) error {
 		return err
 	}
 
-	return ioutil.WriteFile(goFileName, []byte(data), 0644)
+	err = ioutil.WriteFile(goFileName, []byte(data), 0644)
+	if err != nil {
+		return err
+	}
+
+	// generate the TypeScript model
+	if generateTypeScript {
+		log.Printf("Generating TypeScript model")
+		typeScript := cc.generateTypeScript(License)
+		typeScriptFileName := projectRoot + directoryName + "/" + filename + ".ts"
+		err = ioutil.WriteFile(typeScriptFileName, []byte(typeScript), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	// generate the Python model
+	if generatePython {
+		log.Printf("Generating Python model")
+		python := cc.generatePython(License)
+		pythonFileName := projectRoot + directoryName + "/" + filename + ".py"
+		err = ioutil.WriteFile(pythonFileName, []byte(python), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateModelHelpers generates the DeepCopy, Equal, and Merge helpers of the
+// OpenAPI models from the descriptors of their messages.
+func generateModelHelpers() error {
+	models := []struct {
+		file          protoreflect.FileDescriptor
+		directoryName string
+		filename      string
+		packageName   string
+	}{
+		{openapiv2.File_openapiv2_OpenAPIv2_proto, "openapiv2", "OpenAPIv2", "openapi_v2"},
+		{openapiv3.File_openapiv3_OpenAPIv3_proto, "openapiv3", "OpenAPIv3", "openapi_v3"},
+	}
+	for _, model := range models {
+		log.Printf("Generating helpers for %s", model.packageName)
+		goFileName := "./" + model.directoryName + "/" + model.filename + ".helpers.go"
+		helpers := generateHelpers(model.packageName, License, model.file)
+		imports.LocalPrefix = "github.com/google/gnostic"
+		data, err := imports.Process(goFileName, []byte(helpers), &imports.Options{
+			TabWidth:  8,
+			TabIndent: true,
+			Comments:  true,
+			Fragment:  true,
+		})
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(goFileName, data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func usage() string {
@@ -228,6 +298,18 @@ Options:
     Generate Protocol Buffer representation and support code for OpenAPI v3
     Files are read from and written to appropriate locations in the gnostic
     project directory.
+  --discovery
+    Generate Protocol Buffer representation and support code for the
+    Discovery Format.
+  --typescript
+    With --v2, --v3, or --discovery, also generate TypeScript interfaces
+    for the JSON representations of the models.
+  --python
+    With --v2, --v3, or --discovery, also generate Python dataclasses for
+    the models.
+  --helpers
+    Generate the DeepCopy, Equal, and Merge helpers of the OpenAPI v2 and
+    v3 models.
   --extension EXTENSION_SCHEMA [EXTENSIONOPTIONS]
     Generate a gnostic extension that reads a set of OpenAPI extensions.
     EXTENSION_SCHEMA is the json schema for the OpenAPI extensions to be
@@ -240,6 +322,9 @@ Options:
 func main() {
 	var openapiVersion = ""
 	var shouldGenerateExtensions = false
+	var shouldGenerateTypeScript = false
+	var shouldGeneratePython = false
+	var shouldGenerateHelpers = false
 
 	for i, arg := range os.Args {
 		if i == 0 {
@@ -251,6 +336,12 @@ func main() {
 			openapiVersion = "v3"
 		} else if arg == "--discovery" {
 			openapiVersion = "discovery"
+		} else if arg == "--typescript" {
+			shouldGenerateTypeScript = true
+		} else if arg == "--python" {
+			shouldGeneratePython = true
+		} else if arg == "--helpers" {
+			shouldGenerateHelpers = true
 		} else if arg == "--extension" {
 			shouldGenerateExtensions = true
 			break
@@ -261,7 +352,12 @@ func main() {
 	}
 
 	if openapiVersion != "" {
-		err := generateOpenAPIModel(openapiVersion)
+		err := generateOpenAPIModel(openapiVersion, shouldGenerateTypeScript, shouldGeneratePython)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+		}
+	} else if shouldGenerateHelpers {
+		err := generateModelHelpers()
 		if err != nil {
 			fmt.Printf("%+v\n", err)
 		}
@@ -85,6 +85,19 @@ func (typeProperty *TypeProperty) FieldName() string {
 	return strings.Title(snakeCaseToCamelCase(propertyName))
 }
 
+// ProtoFieldName returns the field name to use for a property in .proto files.
+func (typeProperty *TypeProperty) ProtoFieldName() string {
+	// adjust the display name to a valid identifier
+	displayName := typeProperty.Name
+	if displayName == "$ref" {
+		displayName = "_ref"
+	}
+	if displayName == "$schema" {
+		displayName = "_schema"
+	}
+	return camelCaseToSnakeCase(displayName)
+}
+
 // TypeModel models types.
 type TypeModel struct {
 	Name          string          // type name
@@ -15,14 +15,32 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/google/gnostic/lib"
+	workspace "github.com/google/gnostic/workspace"
 )
 
 func isURL(path string) bool {
@@ -294,3 +312,728 @@ func TestDiscoveryJSON(t *testing.T) {
 		"examples/discovery/discovery-v1.json",
 		"testdata/discovery/discovery-v1.text")
 }
+
+// Transformation tests
+
+func testTransformation(t *testing.T, option string, inputFile string, referenceFile string) {
+	outputFile := filepath.Base(referenceFile)
+	os.Remove(outputFile)
+	args := []string{
+		"gnostic",
+		option,
+		"--yaml-out=" + outputFile,
+		inputFile}
+	g := lib.NewGnostic(args)
+	err := g.Main()
+	if err != nil {
+		t.Logf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+		t.FailNow()
+	}
+	err = exec.Command("diff", outputFile, referenceFile).Run()
+	if err != nil {
+		t.Logf("Diff failed: %s vs %s %+v", outputFile, referenceFile, err)
+		t.FailNow()
+	} else {
+		// if the test succeeded, clean up
+		os.Remove(outputFile)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	testTransformation(t,
+		"--flatten",
+		"examples/v3.0/yaml/petstore.yaml",
+		"testdata/v3.0/yaml/petstore-flattened.yaml")
+}
+
+func TestResolve(t *testing.T) {
+	testTransformation(t,
+		"resolve",
+		"examples/v2.0/yaml/petstore-separate/spec/swagger.yaml",
+		"testdata/v2.0/yaml/petstore-separate-resolved.yaml")
+}
+
+func TestResolveMaxNodes(t *testing.T) {
+	// Each schema refers to the next one ten times, so replacing the
+	// references would copy the last schema 10^8 times.
+	text := "openapi: 3.0.0\ninfo: {title: t, version: v}\npaths: {}\ncomponents:\n  schemas:\n"
+	for i := 0; i < 8; i++ {
+		text += fmt.Sprintf("    S%d:\n      properties:\n", i)
+		for j := 0; j < 10; j++ {
+			text += fmt.Sprintf("        p%d: {$ref: \"#/components/schemas/S%d\"}\n", j, i+1)
+		}
+	}
+	text += "    S8: {type: string}\n"
+	source := filepath.Join(t.TempDir(), "fanout.yaml")
+	if err := ioutil.WriteFile(source, []byte(text), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	err := lib.NewGnostic([]string{"gnostic", "resolve", source, "--max-nodes=100000", "--yaml-out=-", "--errors-out=!"}).Main()
+	if err == nil || !strings.Contains(err.Error(), "more than 100000 nodes") {
+		t.Errorf("expected the resolved document to be too large, got %v", err)
+	}
+	err = lib.NewGnostic([]string{"gnostic", source, "--max-nodes=0"}).Main()
+	if err == nil || !strings.Contains(err.Error(), "invalid node limit") {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	for _, test := range []struct {
+		args   []string
+		output string
+	}{
+		{
+			[]string{"examples/v3.0/yaml/petstore.yaml", "$.paths[*].get.operationId"},
+			"listPets\nshowPetById\n",
+		},
+		{
+			[]string{"examples/v3.0/yaml/petstore.yaml", "$.paths['/pets/{petId}','/pets'].get.operationId"},
+			"showPetById\nlistPets\n",
+		},
+		{
+			[]string{"$.paths.*.*.operationId", "examples/v2.0/yaml/petstore.yaml", "--json"},
+			"[\n  \"listPets\",\n  \"createPets\",\n  \"showPetById\"\n]\n",
+		},
+	} {
+		outputFile := "query.out"
+		f, err := os.Create(outputFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stdout := os.Stdout
+		os.Stdout = f
+		err = lib.NewGnostic(append([]string{"gnostic", "query"}, test.args...)).Main()
+		os.Stdout = stdout
+		f.Close()
+		if err != nil {
+			t.Fatalf("Query failed for %v: %+v", test.args, err)
+		}
+		output, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if string(output) != test.output {
+			t.Errorf("Query %v returned %q, want %q", test.args, output, test.output)
+		}
+		os.Remove(outputFile)
+	}
+	err := lib.NewGnostic([]string{"gnostic", "query", "examples/v3.0/yaml/petstore.yaml"}).Main()
+	if err == nil {
+		t.Errorf("Query without an expression succeeded")
+	}
+}
+
+func TestHash(t *testing.T) {
+	digests := make([]string, 0)
+	for _, source := range []string{"examples/v3.0/yaml/petstore.yaml", "examples/v3.0/json/petstore.json"} {
+		outputFile := "hash.out"
+		f, err := os.Create(outputFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stdout := os.Stdout
+		os.Stdout = f
+		err = lib.NewGnostic([]string{"gnostic", "hash", source}).Main()
+		os.Stdout = stdout
+		f.Close()
+		if err != nil {
+			t.Fatalf("Hash failed for %s: %+v", source, err)
+		}
+		output, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		os.Remove(outputFile)
+		fields := strings.Fields(string(output))
+		if len(fields) != 2 || fields[1] != source {
+			t.Fatalf("unexpected output %q", output)
+		}
+		digests = append(digests, fields[0])
+	}
+	if digests[0] != digests[1] {
+		t.Errorf("expected YAML and JSON descriptions to have the same digest, got %v", digests)
+	}
+}
+
+// sarifResults returns the rule IDs and start lines of the results of a SARIF log.
+func sarifResults(t *testing.T, data []byte) []string {
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID    string
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+						Region           struct{ StartLine int }
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &log); err != nil || len(log.Runs) != 1 {
+		t.Fatalf("invalid SARIF log %s: %+v", data, err)
+	}
+	results := make([]string, 0)
+	for _, r := range log.Runs[0].Results {
+		for _, l := range r.Locations {
+			results = append(results, fmt.Sprintf("%s %s:%d", r.RuleID, l.PhysicalLocation.ArtifactLocation.URI, l.PhysicalLocation.Region.StartLine))
+		}
+	}
+	return results
+}
+
+func TestSARIFOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sarif")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	outputFile := filepath.Join(dir, "errors.sarif")
+	source := "examples/errors/petstore-missingversion.yaml"
+	err = lib.NewGnostic([]string{"gnostic", source, "--sarif-out=" + outputFile, "--errors-out=" + filepath.Join(dir, "errors.txt")}).Main()
+	if err == nil {
+		t.Fatalf("expected compilation errors")
+	}
+	data, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if results := sarifResults(t, data); len(results) == 0 || !strings.HasPrefix(results[0], "compile "+source+":") {
+		t.Errorf("unexpected results %v", results)
+	}
+	// Sources without errors have logs without results.
+	err = lib.NewGnostic([]string{"gnostic", "examples/v3.0/yaml/petstore.yaml", "--sarif-out=" + outputFile}).Main()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	data, err = ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if results := sarifResults(t, data); len(results) != 0 {
+		t.Errorf("unexpected results %v", results)
+	}
+}
+
+func TestDiffSARIF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "diff")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	oldFile := filepath.Join(dir, "old.yaml")
+	newFile := filepath.Join(dir, "new.yaml")
+	old := `openapi: 3.0.0
+info: {title: pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {description: pets}
+  /owners:
+    get:
+      responses:
+        "200": {description: owners}
+`
+	if err := ioutil.WriteFile(oldFile, []byte(old), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := ioutil.WriteFile(newFile, []byte(old[:strings.Index(old, "  /owners")]), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	outputFile := filepath.Join(dir, "diff.sarif")
+	f, err := os.Create(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = f
+	err = lib.NewGnostic([]string{"gnostic", "diff", oldFile, newFile, "--format=sarif"}).Main()
+	os.Stdout = stdout
+	f.Close()
+	if err == nil {
+		t.Errorf("expected an error for a breaking change")
+	}
+	data, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The removed path is reported at the paths of the new version.
+	expected := []string{"breaking-change " + filepath.ToSlash(newFile) + ":3"}
+	if results := sarifResults(t, data); !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v", expected, results)
+	}
+}
+
+func TestExtractSchemas(t *testing.T) {
+	testTransformation(t,
+		"--extract-schemas",
+		"testdata/v2.0/yaml/petstore-flattened.yaml",
+		"testdata/v2.0/yaml/petstore-extracted.yaml")
+}
+
+func TestMinify(t *testing.T) {
+	testTransformation(t,
+		"--minify",
+		"examples/v3.0/yaml/petstore.yaml",
+		"testdata/v3.0/yaml/petstore-minified.yaml")
+}
+
+func TestOverlay(t *testing.T) {
+	testTransformation(t,
+		"--overlay=testdata/v3.0/yaml/petstore-overlay.yaml",
+		"examples/v3.0/yaml/petstore.yaml",
+		"testdata/v3.0/yaml/petstore-overlaid.yaml")
+}
+
+func TestPatch(t *testing.T) {
+	testTransformation(t,
+		"--patch=testdata/v3.0/yaml/petstore-patch.json",
+		"examples/v3.0/yaml/petstore.yaml",
+		"testdata/v3.0/yaml/petstore-patched.yaml")
+}
+
+func TestMergePatch(t *testing.T) {
+	testTransformation(t,
+		"--patch=testdata/v3.0/yaml/petstore-merge-patch.yaml",
+		"examples/v3.0/yaml/petstore.yaml",
+		"testdata/v3.0/yaml/petstore-merge-patched.yaml")
+}
+
+func TestMerge(t *testing.T) {
+	testTransformation(t,
+		"--merge=testdata/v3.0/yaml/merge/stores.yaml",
+		"testdata/v3.0/yaml/merge/pets.yaml",
+		"testdata/v3.0/yaml/merge/merged.yaml")
+}
+
+func TestAttestation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "attestation")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	outputFile := filepath.Join(dir, "petstore.pb")
+	attestationFile := filepath.Join(dir, "petstore.intoto.json")
+	args := []string{
+		"gnostic",
+		"examples/v3.0/yaml/petstore.yaml",
+		"--overlay=testdata/v3.0/yaml/petstore-overlay.yaml",
+		"--pb-out=" + outputFile,
+		"--attestation-out=" + attestationFile,
+		"--sign=" + keyFile}
+	g := lib.NewGnostic(args)
+	if err = g.Main(); err != nil {
+		t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+	}
+	payload, err := ioutil.ReadFile(attestationFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var statement struct {
+		Subject []struct {
+			Name   string
+			Digest map[string]string
+		}
+		Predicate struct {
+			BuildDefinition struct {
+				ResolvedDependencies []struct {
+					URI    string
+					Digest map[string]string
+				}
+			}
+		}
+	}
+	if err = json.Unmarshal(payload, &statement); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The subject is the compiled output.
+	output, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sum := sha256.Sum256(output)
+	if len(statement.Subject) != 1 ||
+		statement.Subject[0].Name != outputFile ||
+		statement.Subject[0].Digest["sha256"] != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected subjects %+v", statement.Subject)
+	}
+	// The dependencies are the source and the overlay.
+	dependencies := statement.Predicate.BuildDefinition.ResolvedDependencies
+	if len(dependencies) != 2 ||
+		dependencies[0].URI != "examples/v3.0/yaml/petstore.yaml" ||
+		dependencies[1].URI != "testdata/v3.0/yaml/petstore-overlay.yaml" {
+		t.Errorf("unexpected dependencies %+v", dependencies)
+	}
+	// The signature can be verified with the public key.
+	signature, err := ioutil.ReadFile(attestationFile + ".sig")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	signatureBytes, err := base64.StdEncoding.DecodeString(string(signature))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	digest := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], signatureBytes) {
+		t.Errorf("invalid signature")
+	}
+}
+
+func TestProtoFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "protoformats")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := "examples/v3.0/yaml/petstore.yaml"
+	pbFile := filepath.Join(dir, "petstore.pb")
+	textprotoFile := filepath.Join(dir, "petstore.textproto")
+	jsonpbFile := filepath.Join(dir, "petstore.jsonpb")
+	args := []string{"gnostic", source, "--pb-out=" + pbFile, "--textproto-out=" + textprotoFile, "--jsonpb-out=" + jsonpbFile}
+	if err = lib.NewGnostic(args).Main(); err != nil {
+		t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+	}
+	expected, err := ioutil.ReadFile(pbFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	textproto, err := ioutil.ReadFile(textprotoFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !strings.HasPrefix(string(textproto), "# proto-file: openapiv3/OpenAPIv3.proto\n# proto-message: openapi.v3.Document\n") {
+		t.Errorf("unexpected textproto header:\n%s", textproto)
+	}
+	// Both outputs can be read to produce the same document.
+	for _, name := range []string{textprotoFile, jsonpbFile} {
+		output := filepath.Join(dir, "roundtrip.pb")
+		args := []string{"gnostic", name, "--pb-out=" + output}
+		if err = lib.NewGnostic(args).Main(); err != nil {
+			t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+		}
+		actual, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("%s does not read as the original document", name)
+		}
+	}
+}
+
+func TestCompiledSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compiled")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	pbFile := filepath.Join(dir, "petstore.pb")
+	yamlFile := filepath.Join(dir, "petstore.yaml")
+	args := []string{"gnostic", "examples/v3.0/yaml/petstore.yaml", "--pb-out=" + pbFile, "--yaml-out=" + yamlFile}
+	if err = lib.NewGnostic(args).Main(); err != nil {
+		t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+	}
+	expected, err := ioutil.ReadFile(yamlFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	compiled, err := ioutil.ReadFile(pbFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Binary sources are recognized by their extensions, their contents,
+	// or an option.
+	sniffedFile := filepath.Join(dir, "petstore.bin")
+	if err = ioutil.WriteFile(sniffedFile, compiled, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, source := range [][]string{{pbFile}, {sniffedFile}, {sniffedFile, "--from-pb"}} {
+		output := filepath.Join(dir, "output.yaml")
+		args := append([]string{"gnostic", "--yaml-out=" + output}, source...)
+		if err = lib.NewGnostic(args).Main(); err != nil {
+			t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+		}
+		actual, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("%v does not produce the original document", source)
+		}
+	}
+	// Commands read compiled sources too.
+	outputFile := filepath.Join(dir, "query.out")
+	f, err := os.Create(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = f
+	err = lib.NewGnostic([]string{"gnostic", "query", pbFile, "$.paths[*].get.operationId"}).Main()
+	os.Stdout = stdout
+	f.Close()
+	if err != nil {
+		t.Fatalf("Query failed for %s: %+v", pbFile, err)
+	}
+	output, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(output) != "listPets\nshowPetById\n" {
+		t.Errorf("Query of %s returned %q", pbFile, output)
+	}
+}
+
+func TestStandardInputAndOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pipeline")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	// Write a compiled document to stdout.
+	pbFile := filepath.Join(dir, "petstore.pb")
+	f, err := os.Create(pbFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = f
+	err = lib.NewGnostic([]string{"gnostic", "examples/v3.0/yaml/petstore.yaml", "--pb-out=-"}).Main()
+	os.Stdout = stdout
+	f.Close()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Read it from stdin and write it as YAML in a directory.
+	f, err = os.Open(pbFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer f.Close()
+	stdin := os.Stdin
+	os.Stdin = f
+	err = lib.NewGnostic([]string{"gnostic", "-", "--yaml-out=" + dir}).Main()
+	os.Stdin = stdin
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	actual, err := ioutil.ReadFile(filepath.Join(dir, "stdin.yaml"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !strings.HasPrefix(string(actual), "openapi: \"3.0\"\ninfo:\n    title: OpenAPI Petstore\n") {
+		t.Errorf("unexpected output:\n%s", actual)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	sourceFile := filepath.Join(dir, "api.yaml")
+	schemasFile := filepath.Join(dir, "schemas.yaml")
+	outputFile := filepath.Join(dir, "output.yaml")
+	source := `openapi: 3.0.0
+info:
+  title: TITLE
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: A pet.
+          content:
+            application/json:
+              schema:
+                $ref: "schemas.yaml#/Pet"
+`
+	write := func(name, contents string) {
+		if err := ioutil.WriteFile(name, []byte(contents), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	// Wait until the output contains a value.
+	waitFor := func(value string) {
+		for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(20 * time.Millisecond) {
+			output, _ := ioutil.ReadFile(outputFile)
+			if strings.Contains(string(output), value) {
+				return
+			}
+		}
+		t.Fatalf("timed out waiting for %q in %s", value, outputFile)
+	}
+	write(sourceFile, strings.Replace(source, "TITLE", "First", 1))
+	write(schemasFile, "Pet:\n  description: FIRST\n  type: object\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		args := []string{"gnostic", "resolve", sourceFile, "--yaml-out=" + outputFile}
+		done <- lib.NewGnostic(args).Watch(ctx)
+	}()
+	waitFor("FIRST")
+	// Changes to the source and to referenced files are compiled.
+	write(sourceFile, strings.Replace(source, "TITLE", "Second", 1))
+	waitFor("title: Second")
+	write(schemasFile, "Pet:\n  description: SECOND\n  type: object\n")
+	waitFor("SECOND")
+	cancel()
+	if err = <-done; err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provenance")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	publicKeyFile := filepath.Join(dir, "key.pub")
+	err = ioutil.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0600)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// Compile a copy of a source so that it can be changed.
+	source, err := ioutil.ReadFile("examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sourceFile := filepath.Join(dir, "petstore.yaml")
+	if err = ioutil.WriteFile(sourceFile, source, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	outputFile := filepath.Join(dir, "output.yaml")
+	os.Setenv("SOURCE_DATE_EPOCH", "0")
+	defer os.Unsetenv("SOURCE_DATE_EPOCH")
+	args := []string{"gnostic", sourceFile, "--yaml-out=" + outputFile, "--provenance", "--sign=" + keyFile}
+	if err = lib.NewGnostic(args).Main(); err != nil {
+		t.Fatalf("Compile failed for command %v: %+v", strings.Join(args, " "), err)
+	}
+	output, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sum := sha256.Sum256(source)
+	for _, s := range []string{
+		"x-gnostic-provenance:",
+		"generator: https://github.com/google/gnostic",
+		"timestamp: \"1970-01-01T00:00:00Z\"",
+		"uri: " + sourceFile,
+		"sha256: " + hex.EncodeToString(sum[:]),
+	} {
+		if !strings.Contains(string(output), s) {
+			t.Errorf("expected %q in output:\n%s", s, output)
+		}
+	}
+	// The signed document and its sources can be verified.
+	verify := []string{"gnostic", "verify", outputFile, "--key=" + publicKeyFile}
+	if err = lib.NewGnostic(verify).Main(); err != nil {
+		t.Errorf("Verify failed for command %v: %+v", strings.Join(verify, " "), err)
+	}
+	// A changed document has an invalid signature.
+	if err = ioutil.WriteFile(outputFile, append(output, []byte("x-changed: true\n")...), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = lib.NewGnostic(verify).Main(); err == nil {
+		t.Errorf("expected an error for a changed document")
+	}
+	// A changed source is reported without a key and ignored when offline.
+	if err = ioutil.WriteFile(outputFile, output, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = ioutil.WriteFile(sourceFile, append(source, []byte("x-changed: true\n")...), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = lib.NewGnostic([]string{"gnostic", "verify", outputFile}).Main(); err == nil {
+		t.Errorf("expected an error for a changed source")
+	}
+	if err = lib.NewGnostic([]string{"gnostic", "verify", outputFile, "--offline"}).Main(); err != nil {
+		t.Errorf("unexpected error when offline: %+v", err)
+	}
+}
+
+func TestSignWithoutAttestation(t *testing.T) {
+	g := lib.NewGnostic([]string{"gnostic", "examples/v3.0/yaml/petstore.yaml", "--pb-out=-", "--sign=key.pem"})
+	if err := g.Main(); err == nil {
+		t.Errorf("expected an error for --sign without --attestation-out")
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workspace")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	outputFile := filepath.Join(dir, "workspace.pb")
+	args := []string{
+		"gnostic",
+		"workspace",
+		"examples/v2.0/yaml/petstore-separate/spec/swagger.yaml",
+		"examples/v3.0/yaml/petstore.yaml",
+		"--pb-out=" + outputFile,
+	}
+	if err := lib.NewGnostic(args).Main(); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	data, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ws := &workspace.Workspace{}
+	if err := proto.Unmarshal(data, ws); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(ws.Documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(ws.Documents))
+	}
+	if ws.Documents[0].Model.TypeUrl != "openapi.v2.Document" || ws.Documents[1].Model.TypeUrl != "openapi.v3.Document" {
+		t.Errorf("unexpected models %s and %s", ws.Documents[0].Model.TypeUrl, ws.Documents[1].Model.TypeUrl)
+	}
+	if len(ws.Libraries) != 4 {
+		t.Errorf("expected 4 libraries, got %v", ws.Libraries)
+	}
+	for _, c := range ws.Components {
+		if c.Name == "Pet" && c.Kind != "schemas" {
+			t.Errorf("expected Pet to be a schema, got %q", c.Kind)
+		}
+		if c.Name == "tagsParam" && c.Value.TypeUrl != "openapi.v2.Parameter" {
+			t.Errorf("expected tagsParam to be an openapi.v2.Parameter, got %s", c.Value.TypeUrl)
+		}
+	}
+}
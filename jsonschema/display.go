@@ -240,5 +240,14 @@ func (schema *Schema) describeSchema(indent string) string {
 	if schema.Ref != nil {
 		result += indent + "$ref: " + *(schema.Ref) + "\n"
 	}
+	if schema.Anchor != nil {
+		result += indent + "$anchor: " + *(schema.Anchor) + "\n"
+	}
+	if schema.DynamicAnchor != nil {
+		result += indent + "$dynamicAnchor: " + *(schema.DynamicAnchor) + "\n"
+	}
+	if schema.DynamicRef != nil {
+		result += indent + "$dynamicRef: " + *(schema.DynamicRef) + "\n"
+	}
 	return result
 }
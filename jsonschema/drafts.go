@@ -0,0 +1,364 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Draft identifies a version of the JSON Schema specification.
+type Draft int
+
+const (
+	// draftFromSchema writes schemas as JSONString does: keywords are
+	// spelled as in draft-04, except that the id of a schema is spelled
+	// $id when its $schema names a later draft.
+	draftFromSchema Draft = iota
+	// Draft04 is http://json-schema.org/draft-04/schema#.
+	Draft04
+	// Draft06 is http://json-schema.org/draft-06/schema#.
+	Draft06
+	// Draft07 is http://json-schema.org/draft-07/schema#.
+	Draft07
+	// Draft202012 is https://json-schema.org/draft/2020-12/schema.
+	Draft202012
+)
+
+// Writer writes schemas with the keywords of a draft of JSON Schema.
+//
+// Schemas are modeled with draft-04 keywords, which are converted to the
+// keywords of later drafts: id is written as $id, a true exclusiveMaximum or
+// exclusiveMinimum replaces the number of maximum or minimum, and in 2020-12,
+// definitions are written as $defs, arrays of items as prefixItems followed by
+// additionalItems as items, and dependencies as dependentSchemas and
+// dependentRequired.
+type Writer struct {
+	// Draft selects the spellings of keywords. If it is zero, schemas are
+	// written as JSONString writes them.
+	Draft Draft
+	// YAML selects YAML output instead of JSON.
+	YAML bool
+	// PreserveKeyOrder writes keywords in the order in which they were read,
+	// followed by keywords that were not read in the order that JSONString
+	// uses. Otherwise all keywords are written in that order.
+	PreserveKeyOrder bool
+}
+
+// Node returns a yaml.Node representation of a schema.
+func (w *Writer) Node(schema *Schema) *yaml.Node {
+	return w.schemaNode(schema, w.Draft)
+}
+
+// Write returns the JSON or YAML representation of a schema.
+func (w *Writer) Write(schema *Schema) ([]byte, error) {
+	node := w.Node(schema)
+	if w.YAML {
+		return yaml.Marshal(node)
+	}
+	var b bytes.Buffer
+	if err := renderJSON(&b, node, ""); err != nil {
+		return nil, err
+	}
+	b.WriteString("\n")
+	return b.Bytes(), nil
+}
+
+// A keyword of a schema and the key and value that it is written as. The
+// keyword is the name that the reader records in the key order of a schema.
+type schemaEntry struct {
+	keyword string
+	key     string
+	value   *yaml.Node
+}
+
+func (w *Writer) schemaNode(schema *Schema, draft Draft) *yaml.Node {
+	entries := make([]schemaEntry, 0)
+	add := func(keyword, key string, value *yaml.Node) {
+		entries = append(entries, schemaEntry{keyword: keyword, key: key, value: value})
+	}
+	later := draft >= Draft06
+	if schema.Title != nil {
+		add("title", "title", nodeForString(*schema.Title))
+	}
+	if schema.ID != nil {
+		add("id", idKeyword(schema, draft), nodeForString(*schema.ID))
+	}
+	if schema.Schema != nil {
+		add("$schema", "$schema", nodeForString(*schema.Schema))
+	}
+	if schema.ReadOnly != nil && *schema.ReadOnly {
+		add("readOnly", "readOnly", nodeForBoolean(*schema.ReadOnly))
+	}
+	if schema.WriteOnly != nil && *schema.WriteOnly {
+		add("writeOnly", "writeOnly", nodeForBoolean(*schema.WriteOnly))
+	}
+	if schema.Type != nil {
+		add("type", "type", schema.Type.nodeValue())
+	}
+	if schema.Items != nil {
+		if draft == Draft202012 && schema.Items.SchemaArray != nil {
+			add("items", "prefixItems", schema.Items.nodeValue(w, draft))
+		} else {
+			add("items", "items", schema.Items.nodeValue(w, draft))
+		}
+	}
+	if schema.Description != nil {
+		add("description", "description", nodeForString(*schema.Description))
+	}
+	if schema.Required != nil {
+		add("required", "required", nodeForStringArray(*schema.Required))
+	}
+	if schema.AdditionalProperties != nil {
+		add("additionalProperties", "additionalProperties", schema.AdditionalProperties.nodeValue(w, draft))
+	}
+	if schema.PatternProperties != nil {
+		add("patternProperties", "patternProperties", nodeForNamedSchemaArray(w, draft, schema.PatternProperties))
+	}
+	if schema.Properties != nil {
+		add("properties", "properties", nodeForNamedSchemaArray(w, draft, schema.Properties))
+	}
+	if schema.Dependencies != nil {
+		if draft == Draft202012 {
+			var dependentSchemas, dependentRequired []*NamedSchemaOrStringArray
+			for _, pair := range *schema.Dependencies {
+				if pair.Value.Schema != nil {
+					dependentSchemas = append(dependentSchemas, pair)
+				} else {
+					dependentRequired = append(dependentRequired, pair)
+				}
+			}
+			if len(dependentSchemas) > 0 {
+				add("dependencies", "dependentSchemas", nodeForNamedSchemaOrStringArray(w, draft, dependentSchemas))
+			}
+			if len(dependentRequired) > 0 {
+				add("dependencies", "dependentRequired", nodeForNamedSchemaOrStringArray(w, draft, dependentRequired))
+			}
+		} else {
+			add("dependencies", "dependencies", nodeForNamedSchemaOrStringArray(w, draft, *schema.Dependencies))
+		}
+	}
+	if schema.Ref != nil {
+		add("$ref", "$ref", nodeForString(*schema.Ref))
+	}
+	if schema.Anchor != nil {
+		add("$anchor", "$anchor", nodeForString(*schema.Anchor))
+	}
+	if schema.DynamicAnchor != nil {
+		add("$dynamicAnchor", "$dynamicAnchor", nodeForString(*schema.DynamicAnchor))
+	}
+	if schema.DynamicRef != nil {
+		add("$dynamicRef", "$dynamicRef", nodeForString(*schema.DynamicRef))
+	}
+	if schema.MultipleOf != nil {
+		add("multipleOf", "multipleOf", schema.MultipleOf.nodeValue())
+	}
+	if schema.Maximum != nil {
+		if later && schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum {
+			add("maximum", "exclusiveMaximum", schema.Maximum.nodeValue())
+		} else {
+			add("maximum", "maximum", schema.Maximum.nodeValue())
+		}
+	}
+	if schema.ExclusiveMaximum != nil && !later {
+		add("exclusiveMaximum", "exclusiveMaximum", nodeForBoolean(*schema.ExclusiveMaximum))
+	}
+	if schema.Minimum != nil {
+		if later && schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum {
+			add("minimum", "exclusiveMinimum", schema.Minimum.nodeValue())
+		} else {
+			add("minimum", "minimum", schema.Minimum.nodeValue())
+		}
+	}
+	if schema.ExclusiveMinimum != nil && !later {
+		add("exclusiveMinimum", "exclusiveMinimum", nodeForBoolean(*schema.ExclusiveMinimum))
+	}
+	if schema.MaxLength != nil {
+		add("maxLength", "maxLength", nodeForInt64(*schema.MaxLength))
+	}
+	if schema.MinLength != nil {
+		add("minLength", "minLength", nodeForInt64(*schema.MinLength))
+	}
+	if schema.Pattern != nil {
+		add("pattern", "pattern", nodeForString(*schema.Pattern))
+	}
+	if schema.AdditionalItems != nil {
+		if draft != Draft202012 {
+			add("additionalItems", "additionalItems", schema.AdditionalItems.nodeValue(w, draft))
+		} else if schema.Items != nil && schema.Items.SchemaArray != nil {
+			// additionalItems only applies to arrays of items.
+			add("additionalItems", "items", schema.AdditionalItems.nodeValue(w, draft))
+		}
+	}
+	if schema.MaxItems != nil {
+		add("maxItems", "maxItems", nodeForInt64(*schema.MaxItems))
+	}
+	if schema.MinItems != nil {
+		add("minItems", "minItems", nodeForInt64(*schema.MinItems))
+	}
+	if schema.UniqueItems != nil {
+		add("uniqueItems", "uniqueItems", nodeForBoolean(*schema.UniqueItems))
+	}
+	if schema.MaxProperties != nil {
+		add("maxProperties", "maxProperties", nodeForInt64(*schema.MaxProperties))
+	}
+	if schema.MinProperties != nil {
+		add("minProperties", "minProperties", nodeForInt64(*schema.MinProperties))
+	}
+	if schema.Enumeration != nil {
+		add("enum", "enum", nodeForSchemaEnumArray(schema.Enumeration))
+	}
+	if schema.AllOf != nil {
+		add("allOf", "allOf", nodeForSchemaArray(w, draft, *schema.AllOf))
+	}
+	if schema.AnyOf != nil {
+		add("anyOf", "anyOf", nodeForSchemaArray(w, draft, *schema.AnyOf))
+	}
+	if schema.OneOf != nil {
+		add("oneOf", "oneOf", nodeForSchemaArray(w, draft, *schema.OneOf))
+	}
+	if schema.Not != nil {
+		add("not", "not", w.schemaNode(schema.Not, draft))
+	}
+	if schema.Definitions != nil {
+		if draft == Draft202012 {
+			add("definitions", "$defs", nodeForNamedSchemaArray(w, draft, schema.Definitions))
+		} else {
+			add("definitions", "definitions", nodeForNamedSchemaArray(w, draft, schema.Definitions))
+		}
+	}
+	if schema.Default != nil && draft != draftFromSchema {
+		add("default", "default", schema.Default)
+	}
+	if schema.Format != nil {
+		add("format", "format", nodeForString(*schema.Format))
+	}
+
+	if w.PreserveKeyOrder && len(schema.keys) > 0 {
+		rank := make(map[string]int)
+		for i, keyword := range schema.keys {
+			rank[keyword] = i
+		}
+		position := func(keyword string) int {
+			if i, ok := rank[keyword]; ok {
+				return i
+			}
+			return len(schema.keys)
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return position(entries[i].keyword) < position(entries[j].keyword)
+		})
+	}
+	content := make([]*yaml.Node, 0)
+	for _, entry := range entries {
+		content = appendPair(content, entry.key, entry.value)
+	}
+	return nodeForMapping(content)
+}
+
+// Returns the keyword that the id of a schema is written as.
+func idKeyword(schema *Schema, draft Draft) string {
+	if draft == draftFromSchema {
+		if schema.Schema == nil {
+			return "id"
+		}
+		switch strings.TrimSuffix(*schema.Schema, "#") {
+		case "http://json-schema.org/draft-04/schema", "#", "":
+			return "id"
+		default:
+			return "$id"
+		}
+	}
+	if draft == Draft04 {
+		return "id"
+	}
+	return "$id"
+}
+
+// Writes a yaml.Node as JSON with the indentation used by Render.
+// Unlike Render, strings are escaped and numbers and nulls are unquoted.
+func renderJSON(b *bytes.Buffer, node *yaml.Node, indent string) error {
+	innerIndent := indent + indentation
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 1 {
+			return renderJSON(b, node.Content[0], indent)
+		}
+		b.WriteString("null")
+	case yaml.AliasNode:
+		return renderJSON(b, node.Alias, indent)
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+		b.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			b.WriteString(innerIndent)
+			if err := writeJSONString(b, node.Content[i].Value); err != nil {
+				return err
+			}
+			b.WriteString(": ")
+			if err := renderJSON(b, node.Content[i+1], innerIndent); err != nil {
+				return err
+			}
+			if i < len(node.Content)-2 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		b.WriteString("[\n")
+		for i, item := range node.Content {
+			b.WriteString(innerIndent)
+			if err := renderJSON(b, item, innerIndent); err != nil {
+				return err
+			}
+			if i < len(node.Content)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "]")
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!bool", "!!int", "!!float", "!!null":
+			b.WriteString(node.Value)
+		default:
+			return writeJSONString(b, node.Value)
+		}
+	}
+	return nil
+}
+
+func writeJSONString(b *bytes.Buffer, value string) error {
+	encoder := json.NewEncoder(b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Encode adds a newline.
+	b.Truncate(b.Len() - 1)
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testSchema = `{
+  "type": "object",
+  "id": "http://example.com/pet.json#",
+  "properties": {
+    "age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+    "tags": {"items": [{"type": "string"}], "additionalItems": false}
+  },
+  "dependencies": {"age": ["tags"]},
+  "definitions": {"name": {"type": "string", "default": "Rex \"the dog\""}}
+}`
+
+func readTestSchema(t *testing.T) *Schema {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(testSchema), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return NewSchemaFromObject(&node)
+}
+
+func TestWriterDrafts(t *testing.T) {
+	for _, test := range []struct {
+		writer   *Writer
+		expected string
+	}{
+		{
+			&Writer{Draft: Draft04},
+			`{
+  "id": "http://example.com/pet.json#",
+  "type": "object",
+  "properties": {
+    "age": {
+      "type": "integer",
+      "minimum": 0,
+      "exclusiveMinimum": true
+    },
+    "tags": {
+      "items": [
+        {
+          "type": "string"
+        }
+      ],
+      "additionalItems": false
+    }
+  },
+  "dependencies": {
+    "age": [
+      "tags"
+    ]
+  },
+  "definitions": {
+    "name": {
+      "type": "string",
+      "default": "Rex \"the dog\""
+    }
+  }
+}
+`,
+		},
+		{
+			&Writer{Draft: Draft202012, PreserveKeyOrder: true},
+			`{
+  "type": "object",
+  "$id": "http://example.com/pet.json#",
+  "properties": {
+    "age": {
+      "type": "integer",
+      "exclusiveMinimum": 0
+    },
+    "tags": {
+      "prefixItems": [
+        {
+          "type": "string"
+        }
+      ],
+      "items": false
+    }
+  },
+  "dependentRequired": {
+    "age": [
+      "tags"
+    ]
+  },
+  "$defs": {
+    "name": {
+      "type": "string",
+      "default": "Rex \"the dog\""
+    }
+  }
+}
+`,
+		},
+		{
+			&Writer{Draft: Draft07, YAML: true, PreserveKeyOrder: true},
+			`type: object
+$id: http://example.com/pet.json#
+properties:
+    age:
+        type: integer
+        exclusiveMinimum: 0
+    tags:
+        items:
+            - type: string
+        additionalItems: false
+dependencies:
+    age:
+        - tags
+definitions:
+    name:
+        type: string
+        default: "Rex \"the dog\""
+`,
+		},
+	} {
+		bytes, err := test.writer.Write(readTestSchema(t))
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if string(bytes) != test.expected {
+			t.Errorf("unexpected output for %+v:\n%s\nexpected:\n%s", *test.writer, bytes, test.expected)
+		}
+	}
+}
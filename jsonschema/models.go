@@ -29,6 +29,12 @@ type Schema struct {
 	ReadOnly  *bool
 	WriteOnly *bool
 
+	// https://json-schema.org/draft/2020-12/json-schema-core.html
+	// 8.2.  Base URI, anchors, and dynamic anchors
+	Anchor        *string // $anchor
+	DynamicAnchor *string // $dynamicAnchor
+	DynamicRef    *string // $dynamicRef
+
 	// http://json-schema.org/latest/json-schema-validation.html
 	// 5.1.  Validation keywords for numeric instances (number and integer)
 	MultipleOf       *SchemaNumber
@@ -74,6 +80,9 @@ type Schema struct {
 
 	// 7.  Semantic validation with "format"
 	Format *string
+
+	// keywords in the order in which they were read
+	keys []string
 }
 
 // These helper structs represent "combination" types that generally can
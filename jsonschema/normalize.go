@@ -0,0 +1,166 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+// NormalizeOptions select the constructs that Normalize collapses.
+type NormalizeOptions struct {
+	// ResolveRefs replaces references with the properties of the schemas
+	// that they refer to, as ResolveRefs does. References to objects and
+	// to schemas with oneOf, and references inside oneOf, are kept.
+	ResolveRefs bool
+	// ResolveAllOfs merges the schemas of allOf into the schemas that
+	// contain them, as ResolveAllOfs does.
+	ResolveAllOfs bool
+	// ResolveAnyOfs replaces anyOf with oneOf, as ResolveAnyOfs does.
+	ResolveAnyOfs bool
+	// FlattenOneOfs replaces alternatives of oneOf that only contain a
+	// oneOf with their own alternatives and removes repeated alternatives.
+	FlattenOneOfs bool
+	// CollapseSingletons merges the only schema of an allOf, anyOf, or
+	// oneOf with one element into the schema that contains it.
+	CollapseSingletons bool
+	// SimplifyTypes replaces type arrays with one type by the type and
+	// removes repeated types from type arrays.
+	SimplifyTypes bool
+}
+
+// NormalizeAll selects all normalizations.
+var NormalizeAll = NormalizeOptions{
+	ResolveRefs:        true,
+	ResolveAllOfs:      true,
+	ResolveAnyOfs:      true,
+	FlattenOneOfs:      true,
+	CollapseSingletons: true,
+	SimplifyTypes:      true,
+}
+
+// Normalize simplifies a schema and the schemas that it contains in place.
+// The selected normalizations are applied in the order in which they are
+// declared in NormalizeOptions.
+func (schema *Schema) Normalize(options NormalizeOptions) {
+	if options.ResolveRefs {
+		schema.ResolveRefs()
+	}
+	if options.ResolveAllOfs {
+		schema.ResolveAllOfs()
+	}
+	if options.ResolveAnyOfs {
+		schema.ResolveAnyOfs()
+	}
+	if options.FlattenOneOfs {
+		schema.FlattenOneOfs()
+	}
+	if options.CollapseSingletons {
+		schema.CollapseSingletons()
+	}
+	if options.SimplifyTypes {
+		schema.SimplifyTypes()
+	}
+}
+
+// FlattenOneOfs replaces alternatives of "oneOf" elements that only contain
+// a "oneOf" with their own alternatives, and removes repeated alternatives.
+func (schema *Schema) FlattenOneOfs() {
+	schema.applyToSchemas(
+		func(schema *Schema, context string) {
+			if schema.OneOf == nil {
+				return
+			}
+			oneOfs := make([]*Schema, 0)
+			for _, oneOf := range flattenedOneOfs(*schema.OneOf) {
+				if !schemaIsInArray(oneOf, oneOfs) {
+					oneOfs = append(oneOfs, oneOf)
+				}
+			}
+			schema.OneOf = &oneOfs
+		}, "flattenOneOfs")
+}
+
+// Returns the alternatives of a oneOf with nested oneOfs replaced by their
+// alternatives.
+func flattenedOneOfs(oneOfs []*Schema) []*Schema {
+	result := make([]*Schema, 0)
+	for _, oneOf := range oneOfs {
+		if oneOf.OneOf != nil && oneOf.onlyHasOneOf() {
+			result = append(result, flattenedOneOfs(*oneOf.OneOf)...)
+		} else {
+			result = append(result, oneOf)
+		}
+	}
+	return result
+}
+
+// Returns true if a schema has no members other than "oneOf".
+func (schema *Schema) onlyHasOneOf() bool {
+	s := *schema
+	s.OneOf = nil
+	return s.IsEmpty() && s.ReadOnly == nil && s.WriteOnly == nil
+}
+
+func schemaIsInArray(schema *Schema, array []*Schema) bool {
+	for _, item := range array {
+		if schema.IsEqual(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// CollapseSingletons merges the schemas of "allOf", "anyOf", and "oneOf"
+// elements that have only one schema into the schemas that contain them.
+func (schema *Schema) CollapseSingletons() {
+	schema.applyToSchemas(
+		func(schema *Schema, context string) {
+			if schema.AllOf != nil && len(*schema.AllOf) == 1 {
+				single := (*schema.AllOf)[0]
+				schema.AllOf = nil
+				schema.CopyProperties(single)
+			}
+			if schema.AnyOf != nil && len(*schema.AnyOf) == 1 {
+				single := (*schema.AnyOf)[0]
+				schema.AnyOf = nil
+				schema.CopyProperties(single)
+			}
+			if schema.OneOf != nil && len(*schema.OneOf) == 1 {
+				single := (*schema.OneOf)[0]
+				schema.OneOf = nil
+				schema.CopyProperties(single)
+			}
+		}, "collapseSingletons")
+}
+
+// SimplifyTypes replaces "type" arrays that have one type with that type
+// and removes repeated types from "type" arrays.
+func (schema *Schema) SimplifyTypes() {
+	schema.applyToSchemas(
+		func(schema *Schema, context string) {
+			if schema.Type == nil || schema.Type.StringArray == nil {
+				return
+			}
+			types := make([]string, 0)
+			seen := make(map[string]bool)
+			for _, t := range *schema.Type.StringArray {
+				if !seen[t] {
+					seen[t] = true
+					types = append(types, t)
+				}
+			}
+			if len(types) == 1 {
+				schema.Type = NewStringOrStringArrayWithString(types[0])
+			} else {
+				schema.Type = NewStringOrStringArrayWithStringArray(types)
+			}
+		}, "simplifyTypes")
+}
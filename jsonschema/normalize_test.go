@@ -0,0 +1,105 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func normalizedSchema(t *testing.T, source string, options NormalizeOptions) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	schema := NewSchemaFromObject(&node)
+	schema.Normalize(options)
+	bytes, err := (&Writer{Draft: Draft04, YAML: true}).Write(schema)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return string(bytes)
+}
+
+func TestNormalize(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		options  NormalizeOptions
+		source   string
+		expected string
+	}{
+		{
+			"refs",
+			NormalizeOptions{ResolveRefs: true},
+			`{"id": "http://example.com/refs.json#", "properties": {"a": {"$ref": "#/definitions/name"}, "b": {"$ref": "#/definitions/pet"}}, "definitions": {"name": {"type": "string"}, "pet": {"type": "object"}}}`,
+			`id: http://example.com/refs.json#
+properties:
+    a:
+        type: string
+    b:
+        $ref: '#/definitions/pet'
+definitions:
+    name:
+        type: string
+    pet:
+        type: object
+`,
+		},
+		{
+			"allOfs and anyOfs",
+			NormalizeOptions{ResolveAllOfs: true, ResolveAnyOfs: true},
+			`{"allOf": [{"type": "object"}, {"required": ["name"]}], "properties": {"a": {"anyOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+			`type: object
+required:
+    - name
+properties:
+    a:
+        oneOf:
+            - type: string
+            - type: integer
+`,
+		},
+		{
+			"nested oneOfs",
+			NormalizeOptions{FlattenOneOfs: true},
+			`{"oneOf": [{"type": "string"}, {"oneOf": [{"type": "integer"}, {"type": "string"}]}, {"oneOf": [{"type": "boolean"}], "description": "kept"}]}`,
+			`oneOf:
+    - type: string
+    - type: integer
+    - description: kept
+      oneOf:
+        - type: boolean
+`,
+		},
+		{
+			"singletons and types",
+			NormalizeOptions{CollapseSingletons: true, SimplifyTypes: true},
+			`{"oneOf": [{"type": ["string", "string"]}], "items": {"type": ["integer", "null", "integer"]}}`,
+			`type: string
+items:
+    type:
+        - integer
+        - "null"
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := normalizedSchema(t, test.source, test.options); actual != test.expected {
+				t.Errorf("unexpected schema:\n%s\nexpected:\n%s", actual, test.expected)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"log"
 	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
 )
 
 //
@@ -60,7 +62,10 @@ func (schema *Schema) IsEmpty() bool {
 		(schema.Description == nil) &&
 		(schema.Default == nil) &&
 		(schema.Format == nil) &&
-		(schema.Ref == nil)
+		(schema.Ref == nil) &&
+		(schema.Anchor == nil) &&
+		(schema.DynamicAnchor == nil) &&
+		(schema.DynamicRef == nil)
 }
 
 // IsEqual returns true if two schemas are equal.
@@ -254,6 +259,15 @@ func (schema *Schema) CopyProperties(source *Schema) {
 	if source.Ref != nil {
 		schema.Ref = source.Ref
 	}
+	if source.Anchor != nil {
+		schema.Anchor = source.Anchor
+	}
+	if source.DynamicAnchor != nil {
+		schema.DynamicAnchor = source.DynamicAnchor
+	}
+	if source.DynamicRef != nil {
+		schema.DynamicRef = source.DynamicRef
+	}
 }
 
 // TypeIs returns true if the Type of a Schema includes the specified type
@@ -316,26 +330,28 @@ func (schema *Schema) resolveJSONPointer(ref string) (result *Schema, err error)
 		if documentName == "#" && schema.ID != nil {
 			documentName = *(schema.ID)
 		}
-		path := parts[1]
 		document := schemas[documentName]
-		pathParts := strings.Split(path, "/")
+		tokens, err := jsonpointer.ParseFragment(parts[1])
+		if err != nil {
+			return nil, err
+		}
 
 		// we currently do a very limited (hard-coded) resolution of certain paths and log errors for missed cases
-		if len(pathParts) == 1 {
+		if len(tokens) == 0 {
 			return document, nil
-		} else if len(pathParts) == 3 {
-			switch pathParts[1] {
+		} else if len(tokens) == 2 {
+			switch tokens[0] {
 			case "definitions":
 				dictionary := document.Definitions
 				for _, pair := range *dictionary {
-					if pair.Name == pathParts[2] {
+					if pair.Name == tokens[1] {
 						result = pair.Value
 					}
 				}
 			case "properties":
 				dictionary := document.Properties
 				for _, pair := range *dictionary {
-					if pair.Name == pathParts[2] {
+					if pair.Name == tokens[1] {
 						result = pair.Value
 					}
 				}
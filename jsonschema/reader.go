@@ -71,6 +71,14 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 			k := jsonData.Content[i].Value
 			v := jsonData.Content[i+1]
 
+			switch k {
+			case "$id":
+				k = "id"
+			case "$defs":
+				k = "definitions"
+			}
+			schema.keys = append(schema.keys, k)
+
 			switch k {
 			case "$schema":
 				schema.Schema = schema.stringValue(v)
@@ -149,6 +157,12 @@ func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
 				schema.Format = schema.stringValue(v)
 			case "$ref":
 				schema.Ref = schema.stringValue(v)
+			case "$anchor":
+				schema.Anchor = schema.stringValue(v)
+			case "$dynamicAnchor":
+				schema.DynamicAnchor = schema.stringValue(v)
+			case "$dynamicRef":
+				schema.DynamicRef = schema.stringValue(v)
 			default:
 				fmt.Printf("UNSUPPORTED (%s)\n", k)
 			}
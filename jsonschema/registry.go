@@ -0,0 +1,371 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+)
+
+// Registry loads schemas and resolves the references between them.
+//
+// Schemas are identified by absolute URIs: a schema resource is identified by
+// the URI that it was loaded from and by its $id (or id), resolved against the
+// URI of the resource that contains it. Schemas with $anchor, $dynamicAnchor,
+// or draft-04 "id": "#name" keywords are identified by the URI of their
+// resource with the anchor as the fragment. Resources that are referred to are
+// loaded when the schema that refers to them is added, and each resource is
+// loaded only once, so schemas can refer to each other in cycles.
+type Registry struct {
+	// Loader reads the schema at a URI without a fragment. If it is nil,
+	// files are read and URLs are fetched with compiler.ReadBytesForFile.
+	Loader func(uri string) ([]byte, error)
+
+	resources      map[string]*Schema
+	anchors        map[string]*Schema
+	dynamicAnchors map[string]*Schema
+	bases          map[*Schema]string
+}
+
+// draft04URI identifies the draft-04 meta-schema, which is built in.
+const draft04URI = "http://json-schema.org/draft-04/schema"
+
+// NewRegistry returns a registry that contains the draft-04 meta-schema.
+func NewRegistry() *Registry {
+	r := &Registry{
+		resources:      make(map[string]*Schema),
+		anchors:        make(map[string]*Schema),
+		dynamicAnchors: make(map[string]*Schema),
+		bases:          make(map[*Schema]string),
+	}
+	if base, err := NewBaseSchema(); err == nil {
+		r.register(draft04URI, base, true)
+	}
+	return r
+}
+
+// Add adds a schema to the registry as the resource at a URI, along with the
+// schemas that it contains, and loads the resources that they refer to.
+func (r *Registry) Add(uri string, schema *Schema) error {
+	uri, _ = splitFragment(uri)
+	r.register(uri, schema, true)
+	return r.loadReferences(schema, make(map[*Schema]bool))
+}
+
+// Load returns the schema resource at a URI, reading it if it isn't in the
+// registry. Any fragment of the URI is ignored.
+func (r *Registry) Load(uri string) (*Schema, error) {
+	uri, _ = splitFragment(uri)
+	if schema, ok := r.resources[uri]; ok {
+		return schema, nil
+	}
+	loader := r.Loader
+	if loader == nil {
+		loader = compiler.ReadBytesForFile
+	}
+	bytes, err := loader(uri)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(bytes, &node); err != nil {
+		return nil, fmt.Errorf("%s: %s", uri, err)
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: not a schema", uri)
+	}
+	schema := NewSchemaFromObject(&node)
+	if err := r.Add(uri, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// BaseURI returns the URI that references in a schema are resolved against.
+// It returns false if the schema isn't in the registry.
+func (r *Registry) BaseURI(schema *Schema) (string, bool) {
+	base, ok := r.bases[schema]
+	return base, ok
+}
+
+// Resolve returns the schema that a reference ($ref) in a schema refers to.
+// The fragment of the reference can be empty, a JSON pointer, or an anchor.
+func (r *Registry) Resolve(from *Schema, ref string) (*Schema, error) {
+	base, ok := r.bases[from]
+	if !ok {
+		return nil, fmt.Errorf("unable to resolve %s: schema is not in the registry", ref)
+	}
+	uri, err := resolveURI(base, ref)
+	if err != nil {
+		return nil, err
+	}
+	resourceURI, fragment := splitFragment(uri)
+	resource, err := r.Load(resourceURI)
+	if err != nil {
+		return nil, err
+	}
+	if fragment == "" {
+		return resource, nil
+	}
+	if strings.HasPrefix(fragment, "/") {
+		tokens, err := jsonpointer.ParseFragment(fragment)
+		if err != nil {
+			return nil, err
+		}
+		return resolvePointer(resource, tokens, uri)
+	}
+	if schema, ok := r.anchors[uri]; ok {
+		return schema, nil
+	}
+	return nil, fmt.Errorf("unresolved anchor: %s", uri)
+}
+
+// ResolveDynamic returns the schema that a dynamic reference ($dynamicRef)
+// in a schema refers to. Scope lists the schemas whose evaluation led to the
+// reference, from the outermost to the innermost. If the reference is
+// initially resolved to a schema with a matching $dynamicAnchor, it refers to
+// the outermost resource in the scope that has a $dynamicAnchor with that
+// name. Otherwise it is resolved like a $ref.
+func (r *Registry) ResolveDynamic(from *Schema, ref string, scope []*Schema) (*Schema, error) {
+	target, err := r.Resolve(from, ref)
+	if err != nil {
+		return nil, err
+	}
+	_, name := splitFragment(ref)
+	if name == "" || strings.HasPrefix(name, "/") || target.DynamicAnchor == nil || *target.DynamicAnchor != name {
+		return target, nil
+	}
+	for _, schema := range scope {
+		base, ok := r.bases[schema]
+		if !ok {
+			continue
+		}
+		if dynamic, ok := r.dynamicAnchors[base+"#"+name]; ok {
+			return dynamic, nil
+		}
+	}
+	return target, nil
+}
+
+// Records the base URIs, identifiers, and anchors of a schema and of the
+// schemas that it contains. Schemas that were already registered are skipped.
+func (r *Registry) register(base string, schema *Schema, root bool) {
+	if _, ok := r.bases[schema]; ok {
+		return
+	}
+	if root {
+		r.resources[base] = schema
+	}
+	if schema.ID != nil {
+		if uri, err := resolveURI(base, *schema.ID); err == nil {
+			if resourceURI, fragment := splitFragment(uri); fragment == "" {
+				base = resourceURI
+				if _, ok := r.resources[base]; !ok {
+					r.resources[base] = schema
+				}
+			} else {
+				// A draft-04 id with a fragment is a plain-name anchor.
+				r.anchors[uri] = schema
+			}
+		}
+	}
+	r.bases[schema] = base
+	if schema.Anchor != nil {
+		r.anchors[base+"#"+*schema.Anchor] = schema
+	}
+	if schema.DynamicAnchor != nil {
+		r.anchors[base+"#"+*schema.DynamicAnchor] = schema
+		r.dynamicAnchors[base+"#"+*schema.DynamicAnchor] = schema
+	}
+	for _, child := range schema.subschemas() {
+		r.register(base, child, false)
+	}
+}
+
+// Loads the resources that a schema and the schemas that it contains refer to.
+func (r *Registry) loadReferences(schema *Schema, visited map[*Schema]bool) error {
+	if visited[schema] {
+		return nil
+	}
+	visited[schema] = true
+	for _, ref := range []*string{schema.Ref, schema.DynamicRef} {
+		if ref == nil {
+			continue
+		}
+		uri, err := resolveURI(r.bases[schema], *ref)
+		if err != nil {
+			return err
+		}
+		if _, err := r.Load(uri); err != nil {
+			return err
+		}
+	}
+	for _, child := range schema.subschemas() {
+		if err := r.loadReferences(child, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the schemas that are directly contained in a schema.
+func (schema *Schema) subschemas() []*Schema {
+	result := make([]*Schema, 0)
+	if schema.AdditionalItems != nil && schema.AdditionalItems.Schema != nil {
+		result = append(result, schema.AdditionalItems.Schema)
+	}
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			result = append(result, schema.Items.Schema)
+		}
+		if schema.Items.SchemaArray != nil {
+			result = append(result, *schema.Items.SchemaArray...)
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		result = append(result, schema.AdditionalProperties.Schema)
+	}
+	for _, array := range []*[]*NamedSchema{schema.Properties, schema.PatternProperties, schema.Definitions} {
+		if array != nil {
+			for _, pair := range *array {
+				result = append(result, pair.Value)
+			}
+		}
+	}
+	if schema.Dependencies != nil {
+		for _, pair := range *schema.Dependencies {
+			if pair.Value.Schema != nil {
+				result = append(result, pair.Value.Schema)
+			}
+		}
+	}
+	for _, array := range []*[]*Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		if array != nil {
+			result = append(result, *array...)
+		}
+	}
+	if schema.Not != nil {
+		result = append(result, schema.Not)
+	}
+	return result
+}
+
+// Returns the schema at a JSON pointer in a schema.
+func resolvePointer(schema *Schema, tokens []string, uri string) (*Schema, error) {
+	unresolved := fmt.Errorf("unresolved pointer: %s", uri)
+	for len(tokens) > 0 {
+		keyword := tokens[0]
+		tokens = tokens[1:]
+		var next *Schema
+		switch keyword {
+		case "definitions", "$defs", "properties", "patternProperties":
+			if len(tokens) == 0 {
+				return nil, unresolved
+			}
+			array := schema.Definitions
+			if keyword == "properties" {
+				array = schema.Properties
+			} else if keyword == "patternProperties" {
+				array = schema.PatternProperties
+			}
+			next = namedSchemaArrayElementWithName(array, tokens[0])
+			tokens = tokens[1:]
+		case "dependencies":
+			if len(tokens) == 0 || schema.Dependencies == nil {
+				return nil, unresolved
+			}
+			for _, pair := range *schema.Dependencies {
+				if pair.Name == tokens[0] {
+					next = pair.Value.Schema
+				}
+			}
+			tokens = tokens[1:]
+		case "items", "allOf", "anyOf", "oneOf":
+			var array *[]*Schema
+			switch keyword {
+			case "items":
+				if schema.Items == nil {
+					return nil, unresolved
+				}
+				if schema.Items.SchemaArray == nil {
+					next = schema.Items.Schema
+					break
+				}
+				array = schema.Items.SchemaArray
+			case "allOf":
+				array = schema.AllOf
+			case "anyOf":
+				array = schema.AnyOf
+			case "oneOf":
+				array = schema.OneOf
+			}
+			if next != nil {
+				break
+			}
+			if array == nil || len(tokens) == 0 {
+				return nil, unresolved
+			}
+			index, err := strconv.Atoi(tokens[0])
+			if err != nil || index < 0 || index >= len(*array) {
+				return nil, unresolved
+			}
+			next = (*array)[index]
+			tokens = tokens[1:]
+		case "not":
+			next = schema.Not
+		case "additionalProperties":
+			if schema.AdditionalProperties != nil {
+				next = schema.AdditionalProperties.Schema
+			}
+		case "additionalItems":
+			if schema.AdditionalItems != nil {
+				next = schema.AdditionalItems.Schema
+			}
+		}
+		if next == nil {
+			return nil, unresolved
+		}
+		schema = next
+	}
+	return schema, nil
+}
+
+// Resolves a URI reference against a base URI.
+func resolveURI(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+// Splits a URI into the URI of a resource and a fragment.
+func splitFragment(uri string) (string, string) {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		return uri[:i], uri[i+1:]
+	}
+	return uri, ""
+}
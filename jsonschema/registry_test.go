@@ -0,0 +1,143 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"testing"
+)
+
+var registryFiles = map[string]string{
+	"https://example.com/schemas/pet.json": `{
+  "$id": "https://example.com/schemas/pet.json",
+  "properties": {
+    "name": {"$ref": "common.json#/$defs/name"},
+    "id": {"$ref": "common.json#id"},
+    "owner": {"$ref": "owner.json"}
+  }
+}`,
+	"https://example.com/schemas/common.json": `{
+  "$defs": {
+    "name": {"type": "string"},
+    "id": {"$anchor": "id", "type": "integer"}
+  }
+}`,
+	"https://example.com/schemas/owner.json": `{
+  "properties": {
+    "pets": {"items": {"$ref": "pet.json"}}
+  }
+}`,
+	"https://example.com/schemas/tree.json": `{
+  "$dynamicAnchor": "node",
+  "properties": {
+    "children": {"items": {"$dynamicRef": "#node"}}
+  }
+}`,
+	"https://example.com/schemas/strict-tree.json": `{
+  "$dynamicAnchor": "node",
+  "$ref": "tree.json",
+  "additionalProperties": false
+}`,
+}
+
+func newTestRegistry(loaded *[]string) *Registry {
+	r := NewRegistry()
+	r.Loader = func(uri string) ([]byte, error) {
+		*loaded = append(*loaded, uri)
+		if file, ok := registryFiles[uri]; ok {
+			return []byte(file), nil
+		}
+		return nil, fmt.Errorf("not found: %s", uri)
+	}
+	return r
+}
+
+func TestRegistryResolve(t *testing.T) {
+	var loaded []string
+	r := newTestRegistry(&loaded)
+	pet, err := r.Load("https://example.com/schemas/pet.json")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	// The cycle between pet.json and owner.json is loaded once.
+	if len(loaded) != 3 {
+		t.Errorf("loaded %v, expected three files", loaded)
+	}
+	name, err := r.Resolve(pet.PropertyWithName("name"), "common.json#/$defs/name")
+	if err != nil || !name.TypeIs("string") {
+		t.Errorf("unexpected resolution of pointer: %v %v", name, err)
+	}
+	id, err := r.Resolve(pet.PropertyWithName("name"), "common.json#id")
+	if err != nil || !id.TypeIs("integer") {
+		t.Errorf("unexpected resolution of anchor: %v %v", id, err)
+	}
+	owner := pet.PropertyWithName("owner")
+	target, err := r.Resolve(owner, *owner.Ref)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	pets := target.PropertyWithName("pets")
+	back, err := r.Resolve(pets.Items.Schema, *pets.Items.Schema.Ref)
+	if err != nil || back != pet {
+		t.Errorf("unexpected resolution of cyclic reference: %v %v", back, err)
+	}
+	if _, err := r.Resolve(pet, "common.json#/$defs/missing"); err == nil {
+		t.Errorf("expected an error for a missing definition")
+	}
+	if _, err := r.Resolve(pet, "missing.json"); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestRegistryResolveDynamic(t *testing.T) {
+	var loaded []string
+	r := newTestRegistry(&loaded)
+	strict, err := r.Load("https://example.com/schemas/strict-tree.json")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	tree, err := r.Resolve(strict, *strict.Ref)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	items := tree.PropertyWithName("children").Items.Schema
+
+	// Evaluated from tree.json, the reference refers to tree.json.
+	target, err := r.ResolveDynamic(items, *items.DynamicRef, []*Schema{tree})
+	if err != nil || target != tree {
+		t.Errorf("unexpected resolution from tree.json: %v %v", target, err)
+	}
+	// Evaluated from strict-tree.json, it refers to strict-tree.json.
+	target, err = r.ResolveDynamic(items, *items.DynamicRef, []*Schema{strict, tree})
+	if err != nil || target != strict {
+		t.Errorf("unexpected resolution from strict-tree.json: %v %v", target, err)
+	}
+}
+
+func TestRegistryMetaSchema(t *testing.T) {
+	var loaded []string
+	r := newTestRegistry(&loaded)
+	meta, err := r.Load("http://json-schema.org/draft-04/schema#")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	positive, err := r.Resolve(meta, "#/definitions/positiveInteger")
+	if err != nil || !positive.TypeIs("integer") {
+		t.Errorf("unexpected resolution in meta-schema: %v %v", positive, err)
+	}
+	if len(loaded) != 0 {
+		t.Errorf("unexpectedly loaded %v", loaded)
+	}
+}
@@ -16,7 +16,6 @@ package jsonschema
 
 import (
 	"fmt"
-	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -120,9 +119,9 @@ func (object *SchemaNumber) nodeValue() *yaml.Node {
 	}
 }
 
-func (object *SchemaOrBoolean) nodeValue() *yaml.Node {
+func (object *SchemaOrBoolean) nodeValue(w *Writer, draft Draft) *yaml.Node {
 	if object.Schema != nil {
-		return object.Schema.nodeValue()
+		return w.schemaNode(object.Schema, draft)
 	} else if object.Boolean != nil {
 		return nodeForBoolean(*object.Boolean)
 	} else {
@@ -138,10 +137,10 @@ func nodeForStringArray(array []string) *yaml.Node {
 	return nodeForSequence(content)
 }
 
-func nodeForSchemaArray(array []*Schema) *yaml.Node {
+func nodeForSchemaArray(w *Writer, draft Draft, array []*Schema) *yaml.Node {
 	content := make([]*yaml.Node, 0)
 	for _, item := range array {
-		content = append(content, item.nodeValue())
+		content = append(content, w.schemaNode(item, draft))
 	}
 	return nodeForSequence(content)
 }
@@ -156,9 +155,9 @@ func (object *StringOrStringArray) nodeValue() *yaml.Node {
 	}
 }
 
-func (object *SchemaOrStringArray) nodeValue() *yaml.Node {
+func (object *SchemaOrStringArray) nodeValue(w *Writer, draft Draft) *yaml.Node {
 	if object.Schema != nil {
-		return object.Schema.nodeValue()
+		return w.schemaNode(object.Schema, draft)
 	} else if object.StringArray != nil {
 		return nodeForStringArray(*(object.StringArray))
 	} else {
@@ -166,11 +165,11 @@ func (object *SchemaOrStringArray) nodeValue() *yaml.Node {
 	}
 }
 
-func (object *SchemaOrSchemaArray) nodeValue() *yaml.Node {
+func (object *SchemaOrSchemaArray) nodeValue(w *Writer, draft Draft) *yaml.Node {
 	if object.Schema != nil {
-		return object.Schema.nodeValue()
+		return w.schemaNode(object.Schema, draft)
 	} else if object.SchemaArray != nil {
-		return nodeForSchemaArray(*(object.SchemaArray))
+		return nodeForSchemaArray(w, draft, *(object.SchemaArray))
 	} else {
 		return nil
 	}
@@ -186,18 +185,18 @@ func (object *SchemaEnumValue) nodeValue() *yaml.Node {
 	}
 }
 
-func nodeForNamedSchemaArray(array *[]*NamedSchema) *yaml.Node {
+func nodeForNamedSchemaArray(w *Writer, draft Draft, array *[]*NamedSchema) *yaml.Node {
 	content := make([]*yaml.Node, 0)
 	for _, pair := range *(array) {
-		content = appendPair(content, pair.Name, pair.Value.nodeValue())
+		content = appendPair(content, pair.Name, w.schemaNode(pair.Value, draft))
 	}
 	return nodeForMapping(content)
 }
 
-func nodeForNamedSchemaOrStringArray(array *[]*NamedSchemaOrStringArray) *yaml.Node {
+func nodeForNamedSchemaOrStringArray(w *Writer, draft Draft, array []*NamedSchemaOrStringArray) *yaml.Node {
 	content := make([]*yaml.Node, 0)
-	for _, pair := range *(array) {
-		content = appendPair(content, pair.Name, pair.Value.nodeValue())
+	for _, pair := range array {
+		content = appendPair(content, pair.Name, pair.Value.nodeValue(w, draft))
 	}
 	return nodeForMapping(content)
 }
@@ -263,127 +262,7 @@ func appendPair(nodes []*yaml.Node, name string, value *yaml.Node) []*yaml.Node
 }
 
 func (schema *Schema) nodeValue() *yaml.Node {
-	n := &yaml.Node{Kind: yaml.MappingNode}
-	content := make([]*yaml.Node, 0)
-	if schema.Title != nil {
-		content = appendPair(content, "title", nodeForString(*schema.Title))
-	}
-	if schema.ID != nil {
-		switch strings.TrimSuffix(*schema.Schema, "#") {
-		case "http://json-schema.org/draft-04/schema":
-			fallthrough
-		case "#":
-			fallthrough
-		case "":
-			content = appendPair(content, "id", nodeForString(*schema.ID))
-		default:
-			content = appendPair(content, "$id", nodeForString(*schema.ID))
-		}
-	}
-	if schema.Schema != nil {
-		content = appendPair(content, "$schema", nodeForString(*schema.Schema))
-	}
-	if schema.ReadOnly != nil && *schema.ReadOnly {
-		content = appendPair(content, "readOnly", nodeForBoolean(*schema.ReadOnly))
-	}
-	if schema.WriteOnly != nil && *schema.WriteOnly {
-		content = appendPair(content, "writeOnly", nodeForBoolean(*schema.WriteOnly))
-	}
-	if schema.Type != nil {
-		content = appendPair(content, "type", schema.Type.nodeValue())
-	}
-	if schema.Items != nil {
-		content = appendPair(content, "items", schema.Items.nodeValue())
-	}
-	if schema.Description != nil {
-		content = appendPair(content, "description", nodeForString(*schema.Description))
-	}
-	if schema.Required != nil {
-		content = appendPair(content, "required", nodeForStringArray(*schema.Required))
-	}
-	if schema.AdditionalProperties != nil {
-		content = appendPair(content, "additionalProperties", schema.AdditionalProperties.nodeValue())
-	}
-	if schema.PatternProperties != nil {
-		content = appendPair(content, "patternProperties", nodeForNamedSchemaArray(schema.PatternProperties))
-	}
-	if schema.Properties != nil {
-		content = appendPair(content, "properties", nodeForNamedSchemaArray(schema.Properties))
-	}
-	if schema.Dependencies != nil {
-		content = appendPair(content, "dependencies", nodeForNamedSchemaOrStringArray(schema.Dependencies))
-	}
-	if schema.Ref != nil {
-		content = appendPair(content, "$ref", nodeForString(*schema.Ref))
-	}
-	if schema.MultipleOf != nil {
-		content = appendPair(content, "multipleOf", schema.MultipleOf.nodeValue())
-	}
-	if schema.Maximum != nil {
-		content = appendPair(content, "maximum", schema.Maximum.nodeValue())
-	}
-	if schema.ExclusiveMaximum != nil {
-		content = appendPair(content, "exclusiveMaximum", nodeForBoolean(*schema.ExclusiveMaximum))
-	}
-	if schema.Minimum != nil {
-		content = appendPair(content, "minimum", schema.Minimum.nodeValue())
-	}
-	if schema.ExclusiveMinimum != nil {
-		content = appendPair(content, "exclusiveMinimum", nodeForBoolean(*schema.ExclusiveMinimum))
-	}
-	if schema.MaxLength != nil {
-		content = appendPair(content, "maxLength", nodeForInt64(*schema.MaxLength))
-	}
-	if schema.MinLength != nil {
-		content = appendPair(content, "minLength", nodeForInt64(*schema.MinLength))
-	}
-	if schema.Pattern != nil {
-		content = appendPair(content, "pattern", nodeForString(*schema.Pattern))
-	}
-	if schema.AdditionalItems != nil {
-		content = appendPair(content, "additionalItems", schema.AdditionalItems.nodeValue())
-	}
-	if schema.MaxItems != nil {
-		content = appendPair(content, "maxItems", nodeForInt64(*schema.MaxItems))
-	}
-	if schema.MinItems != nil {
-		content = appendPair(content, "minItems", nodeForInt64(*schema.MinItems))
-	}
-	if schema.UniqueItems != nil {
-		content = appendPair(content, "uniqueItems", nodeForBoolean(*schema.UniqueItems))
-	}
-	if schema.MaxProperties != nil {
-		content = appendPair(content, "maxProperties", nodeForInt64(*schema.MaxProperties))
-	}
-	if schema.MinProperties != nil {
-		content = appendPair(content, "minProperties", nodeForInt64(*schema.MinProperties))
-	}
-	if schema.Enumeration != nil {
-		content = appendPair(content, "enum", nodeForSchemaEnumArray(schema.Enumeration))
-	}
-	if schema.AllOf != nil {
-		content = appendPair(content, "allOf", nodeForSchemaArray(*schema.AllOf))
-	}
-	if schema.AnyOf != nil {
-		content = appendPair(content, "anyOf", nodeForSchemaArray(*schema.AnyOf))
-	}
-	if schema.OneOf != nil {
-		content = appendPair(content, "oneOf", nodeForSchemaArray(*schema.OneOf))
-	}
-	if schema.Not != nil {
-		content = appendPair(content, "not", schema.Not.nodeValue())
-	}
-	if schema.Definitions != nil {
-		content = appendPair(content, "definitions", nodeForNamedSchemaArray(schema.Definitions))
-	}
-	if schema.Default != nil {
-		// m = append(m, yaml.MapItem{Key: "default", Value: *schema.Default})
-	}
-	if schema.Format != nil {
-		content = appendPair(content, "format", nodeForString(*schema.Format))
-	}
-	n.Content = content
-	return n
+	return (&Writer{}).schemaNode(schema, draftFromSchema)
 }
 
 // JSONString returns a json representation of a schema.
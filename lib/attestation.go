@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/google/gnostic/compiler"
+)
+
+const (
+	statementType  = "https://in-toto.io/Statement/v1"
+	provenanceType = "https://slsa.dev/provenance/v1"
+	buildType      = "https://github.com/google/gnostic/compile/v1"
+	builderID      = "https://github.com/google/gnostic"
+)
+
+// An attestation is an in-toto statement with a SLSA provenance predicate.
+// Its subjects are the files written by gnostic and its resolved
+// dependencies are the files that were read to compile them.
+type attestation struct {
+	Type          string              `json:"_type"`
+	Subject       []*attestedResource `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     *provenance         `json:"predicate"`
+}
+
+// An attestedResource is a file that is identified by its SHA-256 digest.
+type attestedResource struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type provenance struct {
+	BuildDefinition *buildDefinition `json:"buildDefinition"`
+	RunDetails      *runDetails      `json:"runDetails"`
+}
+
+type buildDefinition struct {
+	BuildType            string              `json:"buildType"`
+	ExternalParameters   *externalParameters `json:"externalParameters"`
+	ResolvedDependencies []*attestedResource `json:"resolvedDependencies"`
+}
+
+type externalParameters struct {
+	Source    string   `json:"source"`
+	Arguments []string `json:"arguments"`
+}
+
+type runDetails struct {
+	Builder *builder `json:"builder"`
+}
+
+type builder struct {
+	ID string `json:"id"`
+}
+
+// sha256Digest returns the digest of bytes in the form used by in-toto.
+func sha256Digest(bytes []byte) map[string]string {
+	sum := sha256.Sum256(bytes)
+	return map[string]string{"sha256": hex.EncodeToString(sum[:])}
+}
+
+// Write an output file and record its digest for the attestation.
+// Outputs written to stdout or stderr are not attested.
+// Returns the name of the file that was written.
+func (g *Gnostic) writeOutput(name string, bytes []byte, extension string) string {
+	filename := writeFile(name, bytes, g.sourceName, extension)
+	if filename != "" && g.attestationPath != "" {
+		g.artifacts = append(g.artifacts, &attestedResource{Name: filename, Digest: sha256Digest(bytes)})
+	}
+	return filename
+}
+
+// Build an attestation for the files that have been written.
+func (g *Gnostic) buildAttestation() *attestation {
+	digests := compiler.FileDigests()
+	names := make([]string, 0, len(digests))
+	for name := range digests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	dependencies := make([]*attestedResource, 0, len(names))
+	for _, name := range names {
+		dependencies = append(dependencies, &attestedResource{
+			URI:    name,
+			Digest: map[string]string{"sha256": digests[name]},
+		})
+	}
+	subjects := g.artifacts
+	if subjects == nil {
+		subjects = make([]*attestedResource, 0)
+	}
+	return &attestation{
+		Type:          statementType,
+		Subject:       subjects,
+		PredicateType: provenanceType,
+		Predicate: &provenance{
+			BuildDefinition: &buildDefinition{
+				BuildType: buildType,
+				ExternalParameters: &externalParameters{
+					Source:    g.sourceName,
+					Arguments: g.args[1:],
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: &runDetails{Builder: &builder{ID: builderID}},
+		},
+	}
+}
+
+// Write the attestation and, if a key is given, its signature.
+func (g *Gnostic) writeAttestation() error {
+	bytes, err := json.MarshalIndent(g.buildAttestation(), "", "  ")
+	if err != nil {
+		return err
+	}
+	bytes = append(bytes, '\n')
+	var signature []byte
+	if g.signingKeyPath != "" {
+		signature, err = signPayload(g.signingKeyPath, bytes)
+		if err != nil {
+			return err
+		}
+	}
+	filename := writeFile(g.attestationPath, bytes, g.sourceName, "intoto.json")
+	if signature != nil {
+		writeFile(filename+".sig", signature, g.sourceName, "sig")
+	}
+	return nil
+}
+
+// Sign a payload with the private key in a PEM file and return the
+// base64-encoded signature. Signatures are made as cosign makes them, so
+// they can be verified with "cosign verify-blob": ECDSA and RSA keys sign
+// the SHA-256 digest of the payload and Ed25519 keys sign the payload.
+func signPayload(keyPath string, payload []byte) ([]byte, error) {
+	keyBytes, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM-encoded key", keyPath)
+	}
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("unsupported key type %q (keys must be unencrypted)", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", keyPath, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: key can't be used for signing", keyPath)
+	}
+	var signature []byte
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		signature, err = signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(payload)
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(signature)), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// Compile several sources and call each plugin once with all of them, as
+// in "gnostic batch SOURCE... --PLUGIN-out=... [OPTIONS]". Errors are
+// written for the source that has them.
+func (g *Gnostic) batch() error {
+	args := []string{g.args[0]}
+	sources := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		if strings.HasPrefix(arg, "-") && arg != compiler.StandardInput {
+			args = append(args, arg)
+		} else {
+			sources = append(sources, arg)
+		}
+	}
+	g.args = args
+
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return NewUsageError("no input specified")
+	}
+	if len(g.pluginCalls) == 0 {
+		return NewUsageError("batch requires at least one plugin")
+	}
+	if g.binaryOutputPath != "" || g.textOutputPath != "" || g.textProtoOutputPath != "" ||
+		g.jsonpbOutputPath != "" || g.yamlOutputPath != "" || g.jsonOutputPath != "" ||
+		g.sarifOutputPath != "" {
+		return NewUsageError("batch only writes the outputs of plugins")
+	}
+	if g.errorOutputPath == "" {
+		g.errorOutputPath = "="
+	}
+	documents := make([]*pluginDocument, 0, len(sources))
+	for _, source := range sources {
+		g.sourceName = source
+		g.locations = nil
+		bytes, err := compiler.ReadBytesForFile(source)
+		if err != nil {
+			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+			return err
+		}
+		message, err := g.compileSource(bytes)
+		if err != nil {
+			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+			return err
+		}
+		documents = append(documents, g.pluginDocument(message))
+	}
+	g.sourceName = sources[0]
+	return g.callPlugins(documents)
+}
@@ -0,0 +1,273 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+	discovery_v1 "github.com/google/gnostic/discovery"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	"github.com/google/gnostic/transforms"
+)
+
+// cacheFormat is changed when the contents of cache entries change.
+const cacheFormat = "gnostic-cache-v1"
+
+// A cacheEntry describes a compiled document that is stored in a cache
+// directory. The document is stored next to it in binary form.
+type cacheEntry struct {
+	SourceFormat int `json:"sourceFormat"`
+	// Inputs are the SHA-256 digests of the files that were read to
+	// compile the document, keyed by file name or URL. The entry is
+	// only used if all of them are unchanged.
+	Inputs map[string]string `json:"inputs"`
+}
+
+var buildIDOnce sync.Once
+var buildIDValue string
+
+// buildID returns a digest that identifies the build of gnostic, or "" if
+// the build can't be identified. Released versions are identified by their
+// versions and the versions of their dependencies. Development builds all
+// have the version "(devel)", so they are identified by the contents of
+// their executables.
+func buildID() string {
+	buildIDOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		h := sha256.New()
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00", info.Main.Path, info.Main.Version, info.Main.Sum)
+		for _, dep := range info.Deps {
+			fmt.Fprintf(h, "%s\x00%s\x00%s\x00", dep.Path, dep.Version, dep.Sum)
+		}
+		if info.Main.Version == "" || info.Main.Version == "(devel)" {
+			executable, err := os.Executable()
+			if err != nil {
+				return
+			}
+			f, err := os.Open(executable)
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			if _, err = io.Copy(h, f); err != nil {
+				return
+			}
+		}
+		buildIDValue = hex.EncodeToString(h.Sum(nil))
+	})
+	return buildIDValue
+}
+
+// cacheKey returns a digest of the options and source that determine the
+// result of a compilation. Different builds of gnostic use different keys.
+// It returns false if the build can't be identified, since documents
+// compiled by another build might be different, and if credentials are
+// set for fetches, since documents that they fetch shouldn't be stored
+// where other users might read them.
+func (g *Gnostic) cacheKey(source []byte) (string, bool) {
+	id := buildID()
+	if id == "" {
+		return "", false
+	}
+	if auth := compiler.GetFetchAuthentication(); len(auth.Headers) > 0 || len(auth.BearerTokens) > 0 || auth.Netrc {
+		return "", false
+	}
+	h := sha256.New()
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			fmt.Fprintf(h, "%v\x00", value)
+		}
+	}
+	write(cacheFormat, id)
+	sum := sha256.Sum256(source)
+	write(g.sourceName, hex.EncodeToString(sum[:]))
+	write(g.resolveReferences, g.keepCyclicRefs, g.dereference, g.flatten, g.flattenDepth, g.extractSchemas, g.deduplicate, g.minify)
+	write(len(g.mergeNames), g.mergeNames, len(g.overlayNames), g.overlayNames, len(g.patchNames), g.patchNames)
+	for _, handler := range g.extensionHandlers {
+		write(handler.Name)
+	}
+	// Documents that compile within some limits or with some fetch policy
+	// might not with others. Timeouts don't change the documents that
+	// compile, so they aren't part of the key.
+	l := compiler.GetLimitsContext(g.context())
+	write(l.MaxDocumentBytes, l.MaxRefDepth, l.MaxNodes)
+	p := compiler.GetFetchPolicyContext(g.context())
+	write(len(p.AllowedSchemes), p.AllowedSchemes, len(p.AllowedHosts), p.AllowedHosts, p.BlockPrivateAddresses, p.MaxResponseBytes, p.BlockAll)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// Read a compiled document from the cache. Entries whose inputs have
+// changed are ignored.
+func (g *Gnostic) readCache(key string) (proto.Message, bool) {
+	path := filepath.Join(g.cacheDir, key)
+	entryBytes, err := ioutil.ReadFile(path + ".json")
+	if err != nil {
+		return nil, false
+	}
+	entry := &cacheEntry{}
+	if err = json.Unmarshal(entryBytes, entry); err != nil {
+		return nil, false
+	}
+	names := make([]string, 0, len(entry.Inputs))
+	for name := range entry.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bytes, err := compiler.ReadBytesForFileContext(g.context(), name)
+		if err != nil {
+			return nil, false
+		}
+		sum := sha256.Sum256(bytes)
+		if hex.EncodeToString(sum[:]) != entry.Inputs[name] {
+			return nil, false
+		}
+	}
+	documentBytes, err := ioutil.ReadFile(path + ".pb")
+	if err != nil {
+		return nil, false
+	}
+	var message proto.Message
+	switch entry.SourceFormat {
+	case SourceFormatOpenAPI2:
+		message = &openapi_v2.Document{}
+	case SourceFormatOpenAPI3:
+		message = &openapi_v3.Document{}
+	case SourceFormatDiscovery:
+		message = &discovery_v1.Document{}
+	default:
+		return nil, false
+	}
+	if err = proto.Unmarshal(documentBytes, message); err != nil {
+		return nil, false
+	}
+	g.sourceFormat = entry.SourceFormat
+	return message, true
+}
+
+// Write a compiled document to the cache with the digests of the files
+// that were read to compile it. Files are renamed into place so that
+// concurrent runs never read partial entries.
+func (g *Gnostic) writeCache(key string, message proto.Message, inputs map[string]string) error {
+	if err := os.MkdirAll(g.cacheDir, 0755); err != nil {
+		return err
+	}
+	documentBytes, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	entryBytes, err := json.MarshalIndent(&cacheEntry{
+		SourceFormat: g.sourceFormat,
+		Inputs:       inputs,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(g.cacheDir, key)
+	if err = writeCacheFile(path+".pb", documentBytes); err != nil {
+		return err
+	}
+	return writeCacheFile(path+".json", entryBytes)
+}
+
+func writeCacheFile(path string, bytes []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(bytes)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// Compile a JSON or YAML source and resolve and transform it as specified
+// in the options. When a cache directory is set, documents are read from
+// and written to the cache.
+func (g *Gnostic) compileText(bytes []byte) (proto.Message, error) {
+	key, ok := "", false
+	if g.cacheDir != "" {
+		key, ok = g.cacheKey(bytes)
+	}
+	if !ok {
+		message, err := g.readOpenAPIText(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return g.resolveAndTransform(message)
+	}
+	if message, ok := g.readCache(key); ok {
+		g.indexCachedSource(bytes)
+		return message, nil
+	}
+	// Record the files that this compilation reads, and not those read
+	// by other compilations in the same process.
+	ctx := g.ctx
+	var inputs func() map[string]string
+	g.ctx, inputs = compiler.WithFileDigests(g.context())
+	defer func() { g.ctx = ctx }()
+	message, err := g.readOpenAPIText(bytes)
+	if err != nil {
+		return nil, err
+	}
+	message, err = g.resolveAndTransform(message)
+	if err != nil {
+		return nil, err
+	}
+	// Failures to write the cache only make later runs slower.
+	if err = g.writeCache(key, message, inputs()); err != nil {
+		log.Printf("Unable to write to cache %s: %s", g.cacheDir, err)
+	}
+	return message, nil
+}
+
+// Record the source locations of a document that was read from the cache,
+// as readOpenAPIText does, so that plugins receive them.
+func (g *Gnostic) indexCachedSource(bytes []byte) {
+	if len(g.mergeNames) > 0 || len(g.overlayNames) > 0 || len(g.patchNames) > 0 ||
+		g.dereference || g.flatten || g.extractSchemas || g.deduplicate ||
+		g.minify != (transforms.MinifyOptions{}) {
+		return
+	}
+	info, err := compiler.ReadInfoFromBytes(g.sourceName, bytes)
+	if err == nil {
+		g.locations = compiler.NewLocationIndex(info)
+	}
+}
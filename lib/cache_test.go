@@ -0,0 +1,181 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/lib"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestCompileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "api.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	cacheDir := filepath.Join(dir, "cache")
+	outputFile := filepath.Join(dir, "out.yaml")
+	write := func(filename, text string) {
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	write(source, `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: "schemas.yaml#/Pet"
+`)
+	write(schemas, "Pet:\n  description: first\n")
+	compile := func() string {
+		args := []string{"gnostic", "resolve", source, "--cache-dir=" + cacheDir, "--yaml-out=" + outputFile}
+		if err := lib.NewGnostic(args).Main(); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		bytes, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return string(bytes)
+	}
+	if output := compile(); !strings.Contains(output, "description: first") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.pb"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cached document, found %v (%v)", entries, err)
+	}
+	// Replace the cached document to show that it is used.
+	document := &openapi_v3.Document{}
+	bytes, err := ioutil.ReadFile(entries[0])
+	if err == nil {
+		err = proto.Unmarshal(bytes, document)
+	}
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document.Info.Title = "Cached Pets"
+	bytes, err = proto.Marshal(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	write(entries[0], string(bytes))
+	if output := compile(); !strings.Contains(output, "title: Cached Pets") {
+		t.Errorf("expected the cached document to be used: %s", output)
+	}
+	// Changing a referenced file invalidates the entry.
+	write(schemas, "Pet:\n  description: second\n")
+	output := compile()
+	if !strings.Contains(output, "description: second") || !strings.Contains(output, "title: Pets") {
+		t.Errorf("expected the source to be compiled again: %s", output)
+	}
+
+	// The inputs of an entry are the files read by its compilation, even
+	// if they were cached, and not other files read by the process.
+	unrelated := "../examples/v3.0/yaml/petstore.yaml"
+	if _, err := compiler.ReadBytesForFile(unrelated); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	otherCacheDir := filepath.Join(dir, "other")
+	bytes, err = ioutil.ReadFile(source)
+	if err == nil {
+		_, err = lib.Compile(context.Background(), bytes, lib.Options{
+			SourceName:  source,
+			Dereference: true,
+			CacheDir:    otherCacheDir,
+		})
+	}
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	entries, err = filepath.Glob(filepath.Join(otherCacheDir, "*.json"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cache entry, found %v (%v)", entries, err)
+	}
+	var entry struct {
+		Inputs map[string]string
+	}
+	bytes, err = ioutil.ReadFile(entries[0])
+	if err == nil {
+		err = json.Unmarshal(bytes, &entry)
+	}
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, ok := entry.Inputs[schemas]; !ok || len(entry.Inputs) != 1 {
+		t.Errorf("unexpected inputs %v", entry.Inputs)
+	}
+}
+
+func TestCompileCacheKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := "../examples/v3.0/yaml/petstore.yaml"
+	bytes, err := ioutil.ReadFile(source)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, test := range []struct {
+		name    string
+		opts    lib.Options
+		auth    compiler.FetchAuthentication
+		err     string
+		entries int
+	}{
+		{name: "defaults", entries: 1},
+		{name: "limits", opts: lib.Options{Limits: &compiler.Limits{MaxNodes: 10000}}, entries: 2},
+		{name: "timeout", opts: lib.Options{Limits: &compiler.Limits{MaxNodes: 10000, Timeout: time.Minute}}, entries: 2},
+		{name: "smaller limits", opts: lib.Options{Limits: &compiler.Limits{MaxNodes: 5}}, err: "too large", entries: 2},
+		{name: "fetch policy", opts: lib.Options{FetchPolicy: &compiler.FetchPolicy{BlockPrivateAddresses: true}}, entries: 3},
+		{name: "fetch authentication", auth: compiler.FetchAuthentication{BearerTokens: map[string]string{"example.com": "token"}}, entries: 3},
+	} {
+		compiler.SetFetchAuthentication(test.auth)
+		test.opts.SourceName = source
+		test.opts.CacheDir = dir
+		_, err := lib.Compile(context.Background(), bytes, test.opts)
+		compiler.SetFetchAuthentication(compiler.FetchAuthentication{})
+		if test.err == "" && err != nil {
+			t.Fatalf("%s: %+v", test.name, err)
+		}
+		if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.err, err)
+		}
+		entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil || len(entries) != test.entries {
+			t.Errorf("%s: expected %d cache entries, found %v (%v)", test.name, test.entries, entries, err)
+		}
+	}
+}
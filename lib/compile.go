@@ -0,0 +1,135 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/transforms"
+)
+
+// Options control the compilation of API descriptions by Compile. Each
+// option corresponds to a command-line option of gnostic.
+type Options struct {
+	// SourceName is the file name or URL of the description. It is used
+	// in error messages and to find the targets of relative references.
+	// Resolving references reads the source again from this location, so
+	// it must be set when ResolveReferences is used.
+	SourceName string
+	// Merges, Overlays, and Patches name files that are applied to the
+	// source before it is compiled, as with --merge, --overlay, and --patch.
+	Merges   []string
+	Overlays []string
+	Patches  []string
+	// Extensions name the handlers of specification extensions, as with
+	// --x-EXTENSION. A handler named NAME is run as gnostic-x-NAME.
+	Extensions []string
+	// ResolveReferences resolves $ref references, as with --resolve-refs.
+	// Circular references in OpenAPI v3 documents are errors unless
+	// KeepCyclicReferences is set, as with --keep-cyclic-refs.
+	ResolveReferences    bool
+	KeepCyclicReferences bool
+	// Dereference replaces every reference with a copy of its target, as
+	// with "gnostic resolve". References to other files are read relative
+	// to SourceName.
+	Dereference bool
+	// Flatten replaces references to named schemas with copies of the
+	// schemas, following references up to FlattenDepth levels (or without
+	// a limit if FlattenDepth is zero), as with --flatten.
+	Flatten      bool
+	FlattenDepth int
+	// ExtractSchemas moves repeated inline schemas into named schemas, as
+	// with --extract-schemas.
+	ExtractSchemas bool
+	// Deduplicate replaces structurally equal components with one of them,
+	// as with --deduplicate.
+	Deduplicate bool
+	// Minify removes the selected parts of the document, as with --minify.
+	Minify transforms.MinifyOptions
+	// CacheDir names a directory where compiled documents are stored and
+	// reused while their inputs are unchanged, as with --cache-dir.
+	CacheDir string
+	// Limits and FetchPolicy restrict the resources used to read the
+	// source and the files that it references and the remote files that
+	// can be fetched. When they are nil, the settings of the process, from
+	// compiler.SetLimits and compiler.SetFetchPolicy, are used.
+	Limits      *compiler.Limits
+	FetchPolicy *compiler.FetchPolicy
+}
+
+// Compile compiles an API description in JSON or YAML with the same steps
+// that the gnostic command performs before it writes its outputs. Documents
+// that gnostic wrote in a protobuf encoding are read instead of compiled
+// when SourceName has their extension or their contents identify them. The
+// result is an *openapi_v2.Document, an *openapi_v3.Document, or a
+// *discovery_v1.Document, depending on the format of the source. An error
+// in the source is returned as a *compiler.Error, and several errors are
+// returned in a *compiler.ErrorGroup.
+//
+// Fetching files, reading and expanding documents, reading the targets of
+// references, and replacing references with copies of their targets, as
+// with ResolveReferences, Dereference, and Flatten, stop when ctx is done,
+// and ctx is also checked between the steps of compilation. The references
+// to other files that ResolveReferences resolves in OpenAPI v2 documents
+// are resolved by the generated models, which don't check ctx. A
+// compilation that takes longer than the Timeout of its limits is stopped.
+// When ctx is done, its error is returned.
+func Compile(ctx context.Context, source []byte, opts Options) (proto.Message, error) {
+	if opts.Limits != nil {
+		ctx = compiler.WithLimits(ctx, *opts.Limits)
+	}
+	if opts.FetchPolicy != nil {
+		ctx = compiler.WithFetchPolicy(ctx, *opts.FetchPolicy)
+	}
+	ctx, cancel := compiler.WithTimeout(ctx)
+	defer cancel()
+	g := NewGnostic(nil)
+	g.ctx = ctx
+	g.sourceName = opts.SourceName
+	g.mergeNames = opts.Merges
+	g.overlayNames = opts.Overlays
+	g.patchNames = opts.Patches
+	for _, name := range opts.Extensions {
+		g.extensionHandlers = append(g.extensionHandlers, compiler.ExtensionHandler{Name: extensionPrefix + name})
+	}
+	g.resolveReferences = opts.ResolveReferences
+	g.keepCyclicRefs = opts.KeepCyclicReferences
+	g.dereference = opts.Dereference
+	g.flatten = opts.Flatten
+	g.flattenDepth = opts.FlattenDepth
+	g.extractSchemas = opts.ExtractSchemas
+	g.deduplicate = opts.Deduplicate
+	g.minify = opts.Minify
+	g.cacheDir = opts.CacheDir
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Sources are cached by name, so remove any earlier contents of the
+	// source from the cache.
+	if g.sourceName != "" {
+		compiler.RemoveFromInfoCache(g.sourceName)
+	}
+	message, err := g.compileSource(source)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/lib"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestCompile(t *testing.T) {
+	v2, err := ioutil.ReadFile("../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	v3, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	message, err := lib.Compile(context.Background(), v2, lib.Options{
+		SourceName:        "../examples/v2.0/yaml/petstore.yaml",
+		ResolveReferences: true,
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if document, ok := message.(*openapi_v2.Document); !ok || document.Info.Title != "Swagger Petstore" {
+		t.Errorf("unexpected result: %+v", message)
+	}
+	// Flattening replaces references to schemas with copies of them.
+	message, err = lib.Compile(context.Background(), v3, lib.Options{Flatten: true})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, ok := message.(*openapi_v3.Document)
+	if !ok {
+		t.Fatalf("unexpected result: %+v", message)
+	}
+	for _, response := range document.Paths.Path[0].Value.Get.Responses.ResponseOrReference {
+		for _, mediaType := range response.Value.GetResponse().GetContent().GetAdditionalProperties() {
+			if mediaType.Value.Schema.GetReference() != nil {
+				t.Errorf("expected %s response schema to be flattened", response.Name)
+			}
+		}
+	}
+	_, err = lib.Compile(context.Background(), []byte("openapi: 3.0.0\n"), lib.Options{})
+	if _, ok := err.(*compiler.Error); !ok {
+		t.Errorf("expected a compiler error, got %+v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = lib.Compile(ctx, v3, lib.Options{}); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestCompileReferenceOverLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "limits")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer compiler.ClearCaches()
+	// The referenced file is small, but it is too large after its
+	// aliases are expanded.
+	big := "a: &a [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]\n" +
+		"b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]\n" +
+		"x: [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]\n"
+	if err = ioutil.WriteFile(filepath.Join(dir, "big.yaml"), []byte(big), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	source := []byte(`openapi: 3.0.0
+info:
+  title: Limits
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Big:
+      $ref: big.yaml#/x
+`)
+	sourceName := filepath.Join(dir, "openapi.yaml")
+	if err = ioutil.WriteFile(sourceName, source, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	_, err = lib.Compile(context.Background(), source, lib.Options{
+		SourceName:        sourceName,
+		ResolveReferences: true,
+		Limits:            &compiler.Limits{MaxNodes: 1000},
+	})
+	if err == nil || !strings.Contains(err.Error(), "too large after expanding aliases") {
+		t.Errorf("expected an error for the referenced file, got %v", err)
+	}
+}
+
+// slowDescription returns an OpenAPI description whose aliases expand to
+// more than 100,000 nodes, which takes much longer to compile than to read.
+func slowDescription() string {
+	description := "openapi: 3.0.0\ninfo: {title: Slow, version: 1.0.0}\npaths: {}\n" +
+		"x-a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for i, name := range []string{"b", "c", "d", "e"} {
+		previous := string("abcde"[i])
+		description += fmt.Sprintf("x-%s: &%s [*%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s]\n",
+			name, name, previous, previous, previous, previous, previous, previous, previous, previous, previous, previous)
+	}
+	return description
+}
+
+func TestCompileTimeout(t *testing.T) {
+	defer compiler.ClearCaches()
+	source := []byte(slowDescription())
+	start := time.Now()
+	_, err := lib.Compile(context.Background(), source, lib.Options{
+		Limits: &compiler.Limits{Timeout: time.Millisecond},
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("compilation took %s after the timeout", elapsed)
+	}
+	// Without a timeout, the description compiles.
+	if _, err = lib.Compile(context.Background(), source, lib.Options{}); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestCompileFetchPolicy(t *testing.T) {
+	defer compiler.ClearCaches()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	source := []byte(fmt.Sprintf(`openapi: 3.0.0
+info:
+  title: Fetches
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Remote:
+      $ref: %s/schemas.yaml#/Remote
+`, server.URL))
+	sourceName := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := ioutil.WriteFile(sourceName, source, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	opts := lib.Options{
+		SourceName:        sourceName,
+		ResolveReferences: true,
+		FetchPolicy:       &compiler.FetchPolicy{BlockAll: true},
+	}
+	_, err := lib.Compile(context.Background(), source, opts)
+	if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		t.Errorf("expected the fetch to be rejected, got %v", err)
+	}
+	// Fetches stop when the context is done.
+	opts.FetchPolicy = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = lib.Compile(ctx, source, opts)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("compilation took %s after the context was done", elapsed)
+	}
+}
+
+func TestCompileConcurrently(t *testing.T) {
+	defer compiler.ClearCaches()
+	// Checking references adds their targets to the cache that resolving
+	// them reads, so compilations with limits share that cache.
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sourceName := "../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml"
+			source, err := ioutil.ReadFile(sourceName)
+			if err == nil {
+				_, err = lib.Compile(context.Background(), source, lib.Options{
+					SourceName:        sourceName,
+					ResolveReferences: true,
+					Limits:            &compiler.Limits{MaxNodes: 100000},
+				})
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("%+v", err)
+		}
+	}
+}
+
+func TestResolveReferencesV3(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolve")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := []byte(`openapi: 3.0.0
+info: {title: nodes, version: 1.0.0}
+paths:
+  /nodes:
+    get:
+      responses:
+        "200": {$ref: "#/components/responses/Node"}
+components:
+  responses:
+    Node:
+      description: a node
+      content:
+        application/json:
+          schema: {$ref: "#/components/schemas/Node"}
+  schemas:
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`)
+	sourceName := filepath.Join(dir, "nodes.yaml")
+	if err := ioutil.WriteFile(sourceName, source, 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	opts := lib.Options{SourceName: sourceName, ResolveReferences: true}
+	if _, err := lib.Compile(context.Background(), source, opts); err == nil || !strings.Contains(err.Error(), "circular reference") {
+		t.Errorf("expected a circular reference error, got %v", err)
+	}
+	opts.KeepCyclicReferences = true
+	message, err := lib.Compile(context.Background(), source, opts)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response := message.(*openapi_v3.Document).Paths.Path[0].Value.Get.Responses.ResponseOrReference[0].Value.GetResponse()
+	if response.GetDescription() != "a node" {
+		t.Fatalf("expected the response to be resolved, got %v", response)
+	}
+	schema := response.Content.AdditionalProperties[0].Value.Schema.GetSchema()
+	if ref := schema.GetProperties().GetAdditionalProperties()[0].Value.GetReference().GetXRef(); ref != "#/components/schemas/Node" {
+		t.Errorf("expected the cyclic reference to be kept, got %q", ref)
+	}
+}
+
+// fanOut returns a description whose schemas each refer to the next schema
+// ten times, so that replacing its references makes copies of the last
+// schema 10^levels times.
+func fanOut(levels int) string {
+	var b strings.Builder
+	b.WriteString("openapi: 3.0.0\ninfo: {title: t, version: v}\npaths: {}\ncomponents:\n  schemas:\n")
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&b, "    S%d:\n      properties:\n", i)
+		for j := 0; j < 10; j++ {
+			fmt.Fprintf(&b, "        p%d: {$ref: \"#/components/schemas/S%d\"}\n", j, i+1)
+		}
+	}
+	fmt.Fprintf(&b, "    S%d: {type: string}\n", levels)
+	return b.String()
+}
+
+func TestCompileLimitsCopies(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "fanout.yaml")
+	if err := os.WriteFile(source, []byte(fanOut(8)), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	limits := &compiler.Limits{MaxNodes: 100000, Timeout: 3 * time.Second}
+	for _, opts := range []lib.Options{
+		{ResolveReferences: true},
+		{Flatten: true},
+		{Dereference: true},
+	} {
+		opts.SourceName = source
+		opts.Limits = limits
+		bytes, err := os.ReadFile(source)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		_, err = lib.Compile(context.Background(), bytes, opts)
+		if err == nil || !strings.Contains(err.Error(), "more than 100000 nodes") {
+			t.Errorf("%+v: expected the document to be too large, got %v", opts, err)
+		}
+	}
+}
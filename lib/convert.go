@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/conversions"
+)
+
+// Convert a description in another format to OpenAPI v3 and compile it with
+// the remaining options, as in "gnostic convert --from-postman COLLECTION
+// --yaml-out=.". Without a --from option, the format is detected.
+func (g *Gnostic) convert() error {
+	args := []string{g.args[0]}
+	format := ""
+	for _, arg := range g.args[2:] {
+		switch arg {
+		case "--from-postman":
+			format = conversions.PostmanFormat
+		case "--from-raml":
+			format = conversions.RAMLFormat
+		case "--from-blueprint":
+			format = conversions.BlueprintFormat
+		default:
+			args = append(args, arg)
+		}
+	}
+	g.args = args
+
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	err = g.validateOptions()
+	if err != nil {
+		return err
+	}
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	if format == "" {
+		format = conversions.DetectFormat(g.sourceName, bytes)
+	}
+	if format == "" {
+		return NewUsageError(fmt.Sprintf("unable to detect the format of %s, use --from-postman, --from-raml, or --from-blueprint", g.sourceName))
+	}
+	return g.compileConverted(bytes, format)
+}
+
+// Compile a source in another format after converting it to OpenAPI v3.
+func (g *Gnostic) compileConverted(bytes []byte, format string) error {
+	document, err := conversions.OpenAPIv3FromFormat(format, bytes)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	// Outputs are named after the converted description, so that JSON
+	// outputs don't replace collections.
+	sourceName := g.sourceName
+	g.sourceName = sourceName[0:len(sourceName)-len(filepath.Ext(sourceName))] + ".openapi.yaml"
+	// The converted description is compiled like a source, so that it can
+	// be merged, overlaid, and patched.
+	message, err := g.readOpenAPIText(compiler.Marshal(document.ToRawInfo()))
+	if err == nil {
+		err = g.performActions(message)
+	}
+	g.sourceName = sourceName
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	if g.attestationPath != "" {
+		err = g.writeAttestation()
+		if err != nil {
+			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+			return err
+		}
+	}
+	return nil
+}
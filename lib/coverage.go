@@ -0,0 +1,102 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/google/gnostic/compiler"
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/gnostic/metrics/coverage"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Compile sources and print how completely they are documented, as in
+// "gnostic coverage SOURCE... [--format=text|json|pb]".
+func (g *Gnostic) coverage() error {
+	format := "text"
+	sources := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "-") && arg != compiler.StandardInput:
+			return NewUsageError(fmt.Sprintf("unknown option for coverage: %s", arg))
+		default:
+			sources = append(sources, arg)
+		}
+	}
+	if len(sources) == 0 {
+		return NewUsageError("no input specified")
+	}
+	if format != "text" && format != "json" && format != "pb" {
+		return NewUsageError(fmt.Sprintf("unknown format %q", format))
+	}
+	reports := make([]*metrics.DocumentationReport, 0, len(sources))
+	for _, source := range sources {
+		r, err := readCoverage(source)
+		if err != nil {
+			g.sourceName = source
+			writeFile("=", g.errorBytes(err), source, "errors")
+			return err
+		}
+		reports = append(reports, r)
+	}
+	report := coverage.Merge(reports)
+	switch format {
+	case "json":
+		bytes, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		return err
+	case "pb":
+		bytes, err := proto.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(bytes)
+		return err
+	}
+	return coverage.Write(os.Stdout, report)
+}
+
+// Compile a source and measure its documentation.
+func readCoverage(source string) (*metrics.DocumentationReport, error) {
+	bytes, err := compiler.ReadBytesForFile(source)
+	if err != nil {
+		return nil, err
+	}
+	message, err := Compile(context.Background(), bytes, Options{SourceName: source})
+	if err != nil {
+		return nil, err
+	}
+	switch document := message.(type) {
+	case *openapi_v2.Document:
+		return coverage.NewReportV2(source, document), nil
+	case *openapi_v3.Document:
+		return coverage.NewReportV3(source, document), nil
+	}
+	return nil, errors.New("coverage can only be measured for OpenAPI documents")
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/diff"
+	"github.com/google/gnostic/findings"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Compare two versions of a description and print their changes, as in
+// "gnostic diff OLD NEW [--format=text|json|sarif]". Descriptions that are
+// not OpenAPI v3 are converted before they are compared. Exits with an
+// error if any change can break existing clients.
+func (g *Gnostic) diff() error {
+	format := "text"
+	sources := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "-") && arg != compiler.StandardInput:
+			return NewUsageError(fmt.Sprintf("unknown option for diff: %s", arg))
+		default:
+			sources = append(sources, arg)
+		}
+	}
+	if len(sources) != 2 {
+		return NewUsageError("diff requires an old and a new source")
+	}
+	if format != "text" && format != "json" && format != "sarif" {
+		return NewUsageError(fmt.Sprintf("unknown format %q", format))
+	}
+	compiler.ClearCaches()
+	documents := make([]*openapi_v3.Document, 0, 2)
+	var locations *compiler.LocationIndex
+	for _, source := range sources {
+		d := NewGnostic(nil)
+		d.sourceName = source
+		document, err := d.readOpenAPIv3()
+		if err != nil {
+			g.sourceName = source
+			writeFile("=", g.errorBytes(err), source, "errors")
+			return err
+		}
+		documents = append(documents, document)
+		locations = d.locations
+	}
+	changes := diff.Compare(documents[0], documents[1])
+	switch format {
+	case "json":
+		if changes == nil {
+			changes = make([]*diff.Change, 0)
+		}
+		bytes, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		if err != nil {
+			return err
+		}
+	case "sarif":
+		// Changes are reported in the new version, which is usually the one
+		// in the repository that is scanned.
+		result := diff.Findings(sources[1], changes)
+		findings.Locate(result, sources[1], locations)
+		err := findings.WriteSARIF(os.Stdout, result)
+		if err != nil {
+			return err
+		}
+	default:
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+	}
+	if diff.HasBreakingChanges(changes) {
+		return errBreakingChanges
+	}
+	return nil
+}
+
+// errBreakingChanges is returned when "gnostic diff" finds breaking changes.
+var errBreakingChanges = errors.New("breaking changes were found")
+
+// Read the source and convert it to OpenAPI v3.
+func (g *Gnostic) readOpenAPIv3() (*openapi_v3.Document, error) {
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return nil, err
+	}
+	message, err := g.readSource(bytes)
+	if err != nil {
+		return nil, err
+	}
+	message, err = convertMessage(message, "openapi3")
+	if err != nil {
+		return nil, err
+	}
+	return message.(*openapi_v3.Document), nil
+}
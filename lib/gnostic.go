@@ -16,15 +16,18 @@ package lib
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,12 +35,19 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/conversions"
+	"github.com/google/gnostic/findings"
 	discovery_v1 "github.com/google/gnostic/discovery"
 	"github.com/google/gnostic/jsonwriter"
+	"github.com/google/gnostic/merge"
+	lint "github.com/google/gnostic/metrics/lint"
 	openapi_v2 "github.com/google/gnostic/openapiv2"
 	openapi_v3 "github.com/google/gnostic/openapiv3"
 	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/overlay"
+	"github.com/google/gnostic/patch"
 	surface "github.com/google/gnostic/surface"
+	"github.com/google/gnostic/transforms"
 )
 
 // UsageError is a response to invalid command-line inputs
@@ -64,19 +74,24 @@ const (
 	SourceFormatOpenAPI3 = 3
 	// SourceFormatDiscovery represents a Google Discovery document
 	SourceFormatDiscovery = 4
+	// SourceFormatWorkspace represents a workspace of related documents
+	SourceFormatWorkspace = 5
 )
 
 // Determine the version of an OpenAPI description read from JSON or YAML.
 func getOpenAPIVersionFromInfo(info *yaml.Node) int {
+	if info != nil && info.Kind == yaml.DocumentNode {
+		if len(info.Content) == 0 {
+			return SourceFormatUnknown
+		}
+		return getOpenAPIVersionFromInfo(info.Content[0])
+	}
+
 	m, ok := compiler.UnpackMap(info)
 	if !ok {
 		return SourceFormatUnknown
 	}
 
-	if m.Kind == yaml.DocumentNode {
-		return getOpenAPIVersionFromInfo(m.Content[0])
-	}
-
 	swagger, ok := compiler.StringForScalarNode(compiler.MapValueForKey(m, "swagger"))
 	if ok && strings.HasPrefix(swagger, "2.0") {
 		return SourceFormatOpenAPI2
@@ -105,8 +120,16 @@ type pluginCall struct {
 	Invocation string
 }
 
-// Invokes a plugin.
-func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceName string, timePlugins bool, excludeSurface bool) ([]*plugins.Message, error) {
+// A compiled document that is passed to plugins.
+type pluginDocument struct {
+	message      proto.Message
+	sourceFormat int
+	sourceName   string
+	locations    *compiler.LocationIndex
+}
+
+// Invokes a plugin with one or more documents.
+func (p *pluginCall) perform(documents []*pluginDocument, timePlugins bool, excludeSurface bool) ([]*plugins.Message, error) {
 	if p.Name != "" {
 		request := &plugins.Request{}
 
@@ -156,29 +179,16 @@ func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceNam
 
 		request.OutputPath = outputLocation
 
-		request.SourceName = sourceName
-		switch sourceFormat {
-		case SourceFormatOpenAPI2:
-			request.AddModel("openapi.v2.Document", document)
-			if !excludeSurface {
-				// include experimental API surface model
-				surfaceModel, err := surface.NewModelFromOpenAPI2(document.(*openapi_v2.Document), sourceName)
-				if err == nil {
-					request.AddModel("surface.v1.Model", surfaceModel)
-				}
-			}
-		case SourceFormatOpenAPI3:
-			request.AddModel("openapi.v3.Document", document)
-			if !excludeSurface {
-				// include experimental API surface model
-				surfaceModel, err := surface.NewModelFromOpenAPI3(document.(*openapi_v3.Document), sourceName)
-				if err == nil {
-					request.AddModel("surface.v1.Model", surfaceModel)
-				}
-			}
-		case SourceFormatDiscovery:
-			request.AddModel("discovery.v1.Document", document)
-		default:
+		for _, d := range documents {
+			request.Documents = append(request.Documents, d.pluginDocument(excludeSurface))
+		}
+		// The first document is also described by the fields of the request,
+		// and requests for one document only use those fields.
+		request.SourceName = request.Documents[0].SourceName
+		request.Models = request.Documents[0].Models
+		request.SourceLocations = request.Documents[0].SourceLocations
+		if len(request.Documents) == 1 {
+			request.Documents = nil
 		}
 
 		requestBytes, _ := proto.Marshal(request)
@@ -190,7 +200,7 @@ func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceNam
 		output, err := cmd.Output()
 		pluginElapsedTime := time.Since(pluginStartTime)
 		if timePlugins {
-			fmt.Printf("> %s (%s)\n", executableName, pluginElapsedTime)
+			fmt.Fprintf(os.Stderr, "> %s (%s)\n", executableName, pluginElapsedTime)
 		}
 		if err != nil {
 			return nil, err
@@ -211,6 +221,40 @@ func (p *pluginCall) perform(document proto.Message, sourceFormat int, sourceNam
 	return nil, nil
 }
 
+// Build the plugin request document for a compiled document.
+func (d *pluginDocument) pluginDocument(excludeSurface bool) *plugins.Document {
+	document := &plugins.Document{SourceName: d.sourceName}
+	switch d.sourceFormat {
+	case SourceFormatOpenAPI2:
+		document.AddModel("openapi.v2.Document", d.message)
+		if !excludeSurface {
+			// include experimental API surface model
+			surfaceModel, err := surface.NewModelFromOpenAPI2(d.message.(*openapi_v2.Document), d.sourceName)
+			if err == nil {
+				document.AddModel("surface.v1.Model", surfaceModel)
+			}
+		}
+	case SourceFormatOpenAPI3:
+		document.AddModel("openapi.v3.Document", d.message)
+		if !excludeSurface {
+			// include experimental API surface model
+			surfaceModel, err := surface.NewModelFromOpenAPI3(d.message.(*openapi_v3.Document), d.sourceName)
+			if err == nil {
+				document.AddModel("surface.v1.Model", surfaceModel)
+			}
+		}
+	case SourceFormatDiscovery:
+		document.AddModel("discovery.v1.Document", d.message)
+	case SourceFormatWorkspace:
+		document.AddModel("gnostic.workspace.v1.Workspace", d.message)
+	default:
+	}
+	if d.locations != nil {
+		document.AddSourceLocations(d.locations)
+	}
+	return document
+}
+
 func isFile(path string) bool {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
@@ -239,17 +283,24 @@ func isURL(path string) bool {
 	return true
 }
 
-// Write bytes to a named file.
+// Write bytes to a named file and return the name of the file
+// that was written.
 // Certain names have special meaning:
 //   ! writes nothing
 //   - writes to stdout
 //   = writes to stderr
+// These return an empty name.
 // If a directory name is given, the file is written there with
 // a name derived from the source and extension arguments.
-func writeFile(name string, bytes []byte, source string, extension string) {
+func writeFile(name string, bytes []byte, source string, extension string) string {
 	var writer io.Writer
+	filename := ""
+	if source == compiler.StandardInput {
+		// Outputs in directories are named after standard input.
+		source = "stdin"
+	}
 	if name == "!" {
-		return
+		return ""
 	} else if name == "-" {
 		writer = os.Stdout
 	} else if name == "=" {
@@ -259,7 +310,7 @@ func writeFile(name string, bytes []byte, source string, extension string) {
 		// Remove the original source extension.
 		base = base[0 : len(base)-len(filepath.Ext(base))]
 		// Build the path that puts the result in the passed-in directory.
-		filename := name + "/" + base + "." + extension
+		filename = name + "/" + base + "." + extension
 		// Make sure that the necessary output directory exists
 		err := os.MkdirAll(filepath.Dir(filename), os.ModePerm)
 		if err != nil {
@@ -274,16 +325,18 @@ func writeFile(name string, bytes []byte, source string, extension string) {
 		// Remove the original source extension.
 		base = base[0 : len(base)-len(filepath.Ext(base))]
 		// Build the path that puts the result in the passed-in directory.
-		filename := name + "/" + base + "." + extension
+		filename = name + "/" + base + "." + extension
 		file, _ := os.Create(filename)
 		defer file.Close()
 		writer = file
 	} else {
+		filename = name
 		file, _ := os.Create(name)
 		defer file.Close()
 		writer = file
 	}
 	writer.Write(bytes)
+	return filename
 }
 
 // The Gnostic structure holds global state information for gnostic.
@@ -293,16 +346,50 @@ type Gnostic struct {
 	sourceName        string
 	binaryOutputPath  string
 	textOutputPath    string
+	textProtoOutputPath string
+	jsonpbOutputPath  string
 	yamlOutputPath    string
 	jsonOutputPath    string
 	errorOutputPath   string
 	messageOutputPath string
+	attestationPath   string
+	sarifOutputPath   string
+	signingKeyPath    string
+	provenance        bool
+	artifacts         []*attestedResource
 	resolveReferences bool
+	keepCyclicRefs    bool
+	dereference       bool
+	flatten           bool
+	flattenDepth      int
+	extractSchemas    bool
+	deduplicate       bool
+	minify            transforms.MinifyOptions
+	overlayNames      []string
+	patchNames        []string
+	mergeNames        []string
 	pluginCalls       []*pluginCall
 	extensionHandlers []compiler.ExtensionHandler
 	sourceFormat      int
+	sourceEncoding    string
+	watch             bool
+	locations         *compiler.LocationIndex
 	timePlugins       bool
 	excludeSurface    bool
+	fetchAuth         *compiler.FetchAuthentication
+	cacheDir          string
+	// ctx carries the limits and fetch policy of a compilation and
+	// stops it when it is done. It is nil for the gnostic command unless
+	// limits are set with options.
+	ctx context.Context
+}
+
+// context returns the context of a compilation.
+func (g *Gnostic) context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+	return g.ctx
 }
 
 // NewGnostic initializes a structure to store global application state.
@@ -311,10 +398,19 @@ func NewGnostic(args []string) *Gnostic {
 	// Option fields initialize to their default values.
 	g.usage = `
 Usage: gnostic SOURCE [OPTIONS]
-  SOURCE is the filename or URL of an API description.
+  SOURCE is the filename or URL of an API description, or of a document
+  written with --pb-out, --textproto-out, or --jsonpb-out. The encoding of
+  SOURCE is found from its extension or contents unless it is given with
+  --from-pb, --from-textproto, or --from-jsonpb. A SOURCE of - is read
+  from standard input, and a PATH of - writes to standard output.
 Options:
   --pb-out=PATH       Write a binary proto to the specified location.
   --text-out=PATH     Write a text proto to the specified location.
+  --textproto-out=PATH
+                      Write a stable text proto that can be read with
+                      protobuf libraries to the specified location.
+  --jsonpb-out=PATH   Write a stable protobuf JSON encoding of the document
+                      to the specified location.
   --json-out=PATH     Write a json API description to the specified location.
   --yaml-out=PATH     Write a yaml API description to the specified location.
   --errors-out=PATH   Write compilation errors to the specified location.
@@ -328,11 +424,150 @@ Options:
                       PLUGIN must not match any other gnostic option.
   --x-EXTENSION       Use the extension named gnostic-x-EXTENSION
                       to process OpenAPI specification extensions.
-  --resolve-refs      Explicitly resolve $ref references.
-                      This could have problems with recursive definitions.
+  --resolve-refs      Explicitly resolve $ref references. References in
+                      OpenAPI v3 documents to values in the same document,
+                      such as components, are replaced with copies of the
+                      values, and circular references are errors.
+  --keep-cyclic-refs  With --resolve-refs, keep references that would make
+                      a value contain itself instead of reporting them.
+  --flatten[=DEPTH]   Replace references to named schemas with copies of
+                      the schemas, following references in the copies
+                      up to DEPTH levels (default unlimited). Recursive
+                      references are kept.
+  --extract-schemas   Move object schemas that are written inline more
+                      than once into named schemas.
+  --deduplicate       Replace structurally equal components with one of
+                      them and rewrite references to the others, then
+                      move repeated inline schemas into named schemas.
+  --minify[=PARTS]    Remove parts of the document that aren't needed at
+                      runtime. PARTS is a comma-separated list of
+                      descriptions, examples, extensions, and tags (tags
+                      that no operation uses); the default is all of them.
+  --merge=FILE        Merge another OpenAPI document into the source before
+                      compiling it. Can be repeated. Colliding component
+                      names are renamed; other conflicts are errors.
+  --overlay=FILE      Apply an OpenAPI Overlay document to the source
+                      before compiling it. Can be repeated; overlays are
+                      applied in order.
+  --patch=FILE        Apply a JSON Patch (an array of operations) or a
+                      JSON Merge Patch (an object) to the source before
+                      compiling it. Can be repeated; patches are applied
+                      in order, after any overlays.
+  --sarif-out=PATH    Write compilation errors to the specified location as
+                      a SARIF 2.1.0 log for code scanning tools.
+  --attestation-out=PATH
+                      Write an in-toto provenance statement that lists
+                      the SHA-256 digests of the files written by gnostic
+                      and of the files that were read to compile them.
+  --provenance        Add an x-gnostic-provenance extension to JSON and
+                      YAML outputs with the version of gnostic, the time,
+                      and the SHA-256 digests of the files that were read
+                      to compile them. SOURCE_DATE_EPOCH sets the time.
+  --sign=KEYFILE      Sign the attestation with the unencrypted PEM private
+                      key in KEYFILE and write a signature that can be
+                      verified with "cosign verify-blob" next to it. With
+                      --provenance, JSON and YAML outputs are also signed.
+  --fetch-header=HOST=NAME:VALUE
+                      Send a header with the requests that fetch remote
+                      files from HOST. "*.example.com" matches any
+                      subdomain of example.com. Can be repeated.
+  --fetch-token=HOST=TOKEN
+                      Send TOKEN as a bearer token with the requests that
+                      fetch remote files from HOST, as for GitHub raw URLs.
+                      Options can be seen by other users of the system, so
+                      --netrc is safer on shared machines.
+  --netrc[=FILE]      Send the credentials in FILE (default $NETRC or
+                      ~/.netrc) with basic authentication when fetching
+                      remote files.
+  --netrc-default     Also send the credentials of the default entry of
+                      the netrc file to hosts that have no machine entry.
+  --cache-dir=DIR     Store compiled documents in DIR and reuse them while
+                      the source, the files that it references, and the
+                      options that affect compilation are unchanged.
+                      Nothing is cached when fetch credentials are set.
+  --parallel=N        Build the paths and schemas of OpenAPI v3 documents
+                      with up to N goroutines.
+  --max-nodes=N       Reject documents with more than N YAML nodes after
+                      expanding aliases or replacing references.
   --time-plugins      Report plugin runtimes.
+  --watch             Keep running and compile SOURCE again whenever it or a
+                      local file that was read to compile it changes.
   --no-surface        Exclude surface model from calls to plugins.
   --help              Print usage information and exit.
+
+Usage: gnostic convert [--from-postman|--from-raml|--from-blueprint] SOURCE [OPTIONS]
+  Convert a Postman Collection v2.1, RAML 1.0 API definition, or API
+  Blueprint to OpenAPI v3 and compile it with OPTIONS, which are the
+  options above. Without a --from option, the format of SOURCE is
+  detected. Outputs in directories are named as if the source were
+  SOURCE with the extension .openapi.yaml. RAML and API Blueprint
+  sources are also converted without "convert".
+
+Usage: gnostic resolve SOURCE [OPTIONS]
+  Compile SOURCE with OPTIONS after replacing every internal and external
+  $ref with a copy of its target. Circular references are errors.
+
+Usage: gnostic query SOURCE EXPRESSION [--json] [OPTIONS]
+  Compile SOURCE with OPTIONS and print the values selected by the JSONPath
+  EXPRESSION, like '$.paths[*].get.operationId'. Scalars are printed one
+  per line and other values as JSON; with --json, all values are printed
+  in a JSON array. Names and indices are selected in the order that they
+  are listed, so '$.tags[1,0]' prints the second tag first.
+
+Usage: gnostic workspace SOURCE... [OPTIONS]
+  Compile related SOURCEs and the files that they refer to into a
+  gnostic.workspace.v1.Workspace with a shared pool of the components that
+  are referenced from other files, and write it with the --pb-out,
+  --text-out, --textproto-out, --jsonpb-out, and --PLUGIN-out options
+  above. Outputs in directories are named after the first SOURCE.
+
+Usage: gnostic batch SOURCE... --PLUGIN-out=... [OPTIONS]
+  Compile each SOURCE with OPTIONS and call each plugin once with all of
+  the compiled documents, so that plugins can produce outputs that cover
+  several APIs. Plugin parameters apply to all documents.
+
+Usage: gnostic verify DOCUMENT [--key=KEYFILE] [--signature=FILE] [--offline]
+  Verify a document that was written with --provenance. With --key, its
+  signature (by default DOCUMENT.sig) is verified with the PEM public key
+  in KEYFILE. Unless --offline is given, the digests of the sources in its
+  provenance are compared with their current contents. Exits with an
+  error if the signature is invalid or a source has changed.
+
+Usage: gnostic stats SOURCE [OPTIONS]
+  Compile SOURCE with OPTIONS and print the counts of its operations by
+  method and of its schemas and components, the coverage of its
+  descriptions, the operations that use each security scheme, and its
+  largest schemas.
+
+Usage: gnostic hash SOURCE [OPTIONS]
+  Compile SOURCE with OPTIONS and print the SHA-256 digest of its canonical
+  encoding, followed by SOURCE. Descriptions of the same API have the same
+  digest regardless of their formats and the order of their keys.
+
+Usage: gnostic lint SOURCE [--lint-NAME]... [--format=text|json|sarif]
+                         [--no-builtin-rules] [OPTIONS]
+  Compile SOURCE with OPTIONS, run the built-in lint rules and the lint
+  plugins named gnostic-lint-NAME on it, and print their findings: the
+  rule, severity, JSON pointer, and message of each problem, as text,
+  JSON, or a SARIF 2.1.0 log for code scanning tools. Plugins
+  report findings as messages with a code, level, key path, and text.
+  Exits with an error if any finding is an error.
+
+Usage: gnostic diff OLD NEW [--format=text|json|sarif]
+  Compare two versions of an API description and print their changes.
+  Descriptions that are not OpenAPI v3 are converted before they are
+  compared. SARIF results are reported in NEW, and breaking changes are
+  errors. Exits with an error if any change is breaking.
+
+Usage: gnostic coverage SOURCE... [--format=text|json|pb]
+  Compile each SOURCE and print the numbers of operations, parameters, and
+  schema properties that have descriptions, with a score for each source
+  and each tag, and the locations of placeholder descriptions like "TODO".
+  The json and pb formats print a gnostic.metrics.v1.DocumentationReport.
+
+Usage: gnostic serve [--addr=ADDRESS]
+  Serve HTTP endpoints that compile, validate, convert, and compare
+  API descriptions at ADDRESS (default localhost:8080).
 `
 	// Initialize internal structures.
 	g.pluginCalls = make([]*pluginCall, 0)
@@ -366,6 +601,10 @@ func (g *Gnostic) readOptions() error {
 				g.binaryOutputPath = invocation
 			case "text":
 				g.textOutputPath = invocation
+			case "textproto":
+				g.textProtoOutputPath = invocation
+			case "jsonpb":
+				g.jsonpbOutputPath = invocation
 			case "json":
 				g.jsonOutputPath = invocation
 			case "yaml":
@@ -374,6 +613,10 @@ func (g *Gnostic) readOptions() error {
 				g.errorOutputPath = invocation
 			case "messages":
 				g.messageOutputPath = invocation
+			case "attestation":
+				g.attestationPath = invocation
+			case "sarif":
+				g.sarifOutputPath = invocation
 			default:
 				p := &pluginCall{Name: pluginName, Invocation: invocation}
 				g.pluginCalls = append(g.pluginCalls, p)
@@ -382,40 +625,164 @@ func (g *Gnostic) readOptions() error {
 			extensionName := string(m[1])
 			extensionHandler := compiler.ExtensionHandler{Name: extensionPrefix + extensionName}
 			g.extensionHandlers = append(g.extensionHandlers, extensionHandler)
+		} else if arg == "--from-pb" {
+			g.sourceEncoding = sourceEncodingPB
+		} else if arg == "--from-textproto" {
+			g.sourceEncoding = sourceEncodingTextProto
+		} else if arg == "--from-jsonpb" {
+			g.sourceEncoding = sourceEncodingJSONPB
 		} else if arg == "--resolve-refs" {
 			g.resolveReferences = true
+		} else if arg == "--keep-cyclic-refs" {
+			g.keepCyclicRefs = true
+		} else if arg == "--flatten" {
+			g.flatten = true
+		} else if strings.HasPrefix(arg, "--flatten=") {
+			depth, err := strconv.Atoi(strings.TrimPrefix(arg, "--flatten="))
+			if err != nil || depth < 1 {
+				return NewUsageError(fmt.Sprintf("invalid flatten depth: %s", arg))
+			}
+			g.flatten = true
+			g.flattenDepth = depth
+		} else if strings.HasPrefix(arg, "--merge=") {
+			g.mergeNames = append(g.mergeNames, strings.TrimPrefix(arg, "--merge="))
+		} else if strings.HasPrefix(arg, "--overlay=") {
+			g.overlayNames = append(g.overlayNames, strings.TrimPrefix(arg, "--overlay="))
+		} else if strings.HasPrefix(arg, "--patch=") {
+			g.patchNames = append(g.patchNames, strings.TrimPrefix(arg, "--patch="))
+		} else if strings.HasPrefix(arg, "--sign=") {
+			g.signingKeyPath = strings.TrimPrefix(arg, "--sign=")
+		} else if arg == "--provenance" {
+			g.provenance = true
+		} else if arg == "--watch" {
+			g.watch = true
+		} else if arg == "--extract-schemas" {
+			g.extractSchemas = true
+		} else if arg == "--deduplicate" {
+			g.deduplicate = true
+		} else if arg == "--minify" {
+			g.minify = transforms.MinifyOptions{Descriptions: true, Examples: true, Extensions: true, UnusedTags: true}
+		} else if strings.HasPrefix(arg, "--minify=") {
+			for _, part := range strings.Split(strings.TrimPrefix(arg, "--minify="), ",") {
+				switch part {
+				case "descriptions":
+					g.minify.Descriptions = true
+				case "examples":
+					g.minify.Examples = true
+				case "extensions":
+					g.minify.Extensions = true
+				case "tags":
+					g.minify.UnusedTags = true
+				default:
+					return NewUsageError(fmt.Sprintf("invalid minify option: %s", part))
+				}
+			}
 		} else if arg == "--time-plugins" {
 			g.timePlugins = true
 		} else if arg == "--no-surface" {
 			g.excludeSurface = true
+		} else if strings.HasPrefix(arg, "--fetch-header=") {
+			host, header := splitFetchOption(strings.TrimPrefix(arg, "--fetch-header="))
+			parts := strings.SplitN(header, ":", 2)
+			if host == "" || len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				return NewUsageError(fmt.Sprintf("invalid fetch header: %s", arg))
+			}
+			auth := g.fetchAuthentication()
+			if auth.Headers[host] == nil {
+				auth.Headers[host] = make(http.Header)
+			}
+			auth.Headers[host].Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		} else if strings.HasPrefix(arg, "--fetch-token=") {
+			host, token := splitFetchOption(strings.TrimPrefix(arg, "--fetch-token="))
+			if host == "" || token == "" {
+				return NewUsageError(fmt.Sprintf("invalid fetch token: %s", arg))
+			}
+			g.fetchAuthentication().BearerTokens[host] = token
+		} else if strings.HasPrefix(arg, "--cache-dir=") {
+			g.cacheDir = strings.TrimPrefix(arg, "--cache-dir=")
+		} else if strings.HasPrefix(arg, "--parallel=") {
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--parallel="))
+			if err != nil || n < 1 {
+				return NewUsageError(fmt.Sprintf("invalid parallelism: %s", arg))
+			}
+			compiler.SetParallelism(n)
+		} else if strings.HasPrefix(arg, "--max-nodes=") {
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--max-nodes="))
+			if err != nil || n < 1 {
+				return NewUsageError(fmt.Sprintf("invalid node limit: %s", arg))
+			}
+			l := compiler.GetLimitsContext(g.context())
+			l.MaxNodes = n
+			g.ctx = compiler.WithLimits(g.context(), l)
+		} else if arg == "--netrc" {
+			g.fetchAuthentication().Netrc = true
+		} else if arg == "--netrc-default" {
+			auth := g.fetchAuthentication()
+			auth.Netrc = true
+			auth.NetrcDefault = true
+		} else if strings.HasPrefix(arg, "--netrc=") {
+			auth := g.fetchAuthentication()
+			auth.Netrc = true
+			auth.NetrcFile = strings.TrimPrefix(arg, "--netrc=")
 		} else if len(arg) > 2 && arg[0] == '-' && arg[1] == '-' {
 			// try letting the option specify a plugin with no output files (or unwanted output files)
 			// this is useful for calling plugins like linters that only return messages
 			p := &pluginCall{Name: arg[2:len(arg)], Invocation: "!"}
 			g.pluginCalls = append(g.pluginCalls, p)
-		} else if arg[0] == '-' {
+		} else if arg[0] == '-' && arg != compiler.StandardInput {
 			return NewUsageError(fmt.Sprintf("unknown option: %s", arg))
 		} else {
 			g.sourceName = arg
 		}
 	}
+	if g.fetchAuth != nil {
+		compiler.SetFetchAuthentication(*g.fetchAuth)
+	}
 	return nil
 }
 
+// fetchAuthentication returns the credentials set by the fetch options.
+func (g *Gnostic) fetchAuthentication() *compiler.FetchAuthentication {
+	if g.fetchAuth == nil {
+		g.fetchAuth = &compiler.FetchAuthentication{
+			Headers:      make(map[string]http.Header),
+			BearerTokens: make(map[string]string),
+		}
+	}
+	return g.fetchAuth
+}
+
+// splitFetchOption splits the value of a fetch option into a host and
+// the rest of the value, which follows the first "=".
+func splitFetchOption(value string) (string, string) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
 // Validate command-line options.
 func (g *Gnostic) validateOptions() error {
 	if g.binaryOutputPath == "" &&
 		g.textOutputPath == "" &&
+		g.textProtoOutputPath == "" &&
+		g.jsonpbOutputPath == "" &&
 		g.yamlOutputPath == "" &&
 		g.jsonOutputPath == "" &&
 		g.errorOutputPath == "" &&
 		g.messageOutputPath == "" &&
+		g.sarifOutputPath == "" &&
 		len(g.pluginCalls) == 0 {
 		return NewUsageError("missing output directives")
 	}
 	if g.sourceName == "" {
 		return NewUsageError("no input specified")
 	}
+	if g.signingKeyPath != "" && !g.provenance && (g.attestationPath == "" || g.attestationPath == "!" ||
+		g.attestationPath == "-" || g.attestationPath == "=") {
+		return NewUsageError("--sign requires an --attestation-out file or directory or --provenance")
+	}
 	// If we get here and the error output is unspecified, write errors to stderr.
 	if g.errorOutputPath == "" {
 		g.errorOutputPath = "="
@@ -428,12 +795,52 @@ func (g *Gnostic) errorBytes(err error) []byte {
 	return []byte("Errors reading " + g.sourceName + "\n" + err.Error())
 }
 
+// Write compilation errors as a SARIF log. If there are no errors, the log
+// has no results, which tells code scanning tools that earlier problems
+// were fixed.
+func (g *Gnostic) writeSARIFOutput(err error) {
+	var buffer bytes.Buffer
+	if e := findings.WriteSARIF(&buffer, findings.FromError(g.sourceName, err)); e != nil {
+		fmt.Fprintf(os.Stderr, "Error generating sarif output %s\n", e.Error())
+		return
+	}
+	writeFile(g.sarifOutputPath, buffer.Bytes(), g.sourceName, "sarif")
+}
+
 // Read an OpenAPI description from YAML or JSON.
 func (g *Gnostic) readOpenAPIText(bytes []byte) (message proto.Message, err error) {
-	info, err := compiler.ReadInfoFromBytes(g.sourceName, bytes)
+	info, err := compiler.ReadInfoFromBytesContext(g.context(), g.sourceName, bytes)
 	if err != nil {
 		return nil, err
 	}
+	if len(g.mergeNames) > 0 {
+		info, err = g.mergeDocuments(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(g.overlayNames) > 0 {
+		info, err = g.applyOverlays(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(g.patchNames) > 0 {
+		info, err = g.applyPatches(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Values from merged documents, overlays, and patches have positions
+	// in other files, so source locations are only recorded for
+	// unmodified sources.
+	if len(g.mergeNames) == 0 && len(g.overlayNames) == 0 && len(g.patchNames) == 0 {
+		// Record the positions of values in the source for plugins.
+		g.locations = compiler.NewLocationIndex(info)
+	}
+	if err := g.context().Err(); err != nil {
+		return nil, err
+	}
 	// Determine the OpenAPI version.
 	g.sourceFormat = getOpenAPIVersionFromInfo(info)
 	if g.sourceFormat == SourceFormatUnknown {
@@ -465,29 +872,97 @@ func (g *Gnostic) readOpenAPIText(bytes []byte) (message proto.Message, err erro
 	return message, err
 }
 
+// Merge the documents specified in the command-line options into the source.
+func (g *Gnostic) mergeDocuments(info *yaml.Node) (*yaml.Node, error) {
+	documents := []*yaml.Node{info}
+	for _, name := range g.mergeNames {
+		bytes, err := compiler.ReadBytesForFileContext(g.context(), name)
+		if err != nil {
+			return nil, err
+		}
+		document, err := compiler.ReadInfoFromBytesContext(g.context(), name, bytes)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	merged, conflicts, err := merge.Merge(documents...)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, 0)
+	for _, c := range conflicts {
+		errs = append(errs, fmt.Errorf("merging %s: %s", g.mergeNames[c.Document-2], c))
+	}
+	if err = compiler.NewErrorGroupOrNil(errs); err != nil {
+		return nil, err
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}}, nil
+}
+
+// Apply the overlays specified in the command-line options.
+func (g *Gnostic) applyOverlays(info *yaml.Node) (*yaml.Node, error) {
+	for _, name := range g.overlayNames {
+		bytes, err := compiler.ReadBytesForFileContext(g.context(), name)
+		if err != nil {
+			return nil, err
+		}
+		o, err := overlay.ParseOverlay(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		info = o.Apply(info)
+	}
+	return info, nil
+}
+
+// Apply the patches specified in the command-line options.
+func (g *Gnostic) applyPatches(info *yaml.Node) (*yaml.Node, error) {
+	for _, name := range g.patchNames {
+		bytes, err := compiler.ReadBytesForFileContext(g.context(), name)
+		if err != nil {
+			return nil, err
+		}
+		p, err := patch.ParsePatch(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		info, err = p.Apply(info)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+	}
+	return info, nil
+}
+
 func (g *Gnostic) ReadOpenAPIText(bytes []byte) (message proto.Message, err error) {
 	return g.readOpenAPIText(bytes)
 }
 
 // Read an OpenAPI binary file.
 func (g *Gnostic) readOpenAPIBinary(data []byte) (message proto.Message, err error) {
+	return g.readOpenAPIMessage(data, proto.Unmarshal)
+}
+
+// Read a document that was written as a protocol buffer with unmarshal.
+func (g *Gnostic) readOpenAPIMessage(data []byte, unmarshal func([]byte, proto.Message) error) (message proto.Message, err error) {
 	// try to read an OpenAPI v3 document
 	documentV3 := &openapi_v3.Document{}
-	err = proto.Unmarshal(data, documentV3)
+	err = unmarshal(data, documentV3)
 	if err == nil && strings.HasPrefix(documentV3.Openapi, "3.0") {
 		g.sourceFormat = SourceFormatOpenAPI3
 		return documentV3, nil
 	}
 	// if that failed, try to read an OpenAPI v2 document
 	documentV2 := &openapi_v2.Document{}
-	err = proto.Unmarshal(data, documentV2)
+	err = unmarshal(data, documentV2)
 	if err == nil && strings.HasPrefix(documentV2.Swagger, "2.0") {
 		g.sourceFormat = SourceFormatOpenAPI2
 		return documentV2, nil
 	}
 	// if that failed, try to read a Discovery Format document
 	discoveryDocument := &discovery_v1.Document{}
-	err = proto.Unmarshal(data, discoveryDocument)
+	err = unmarshal(data, discoveryDocument)
 	if err == nil { // && strings.HasPrefix(documentV2.Swagger, "2.0") {
 		g.sourceFormat = SourceFormatDiscovery
 		return discoveryDocument, nil
@@ -501,7 +976,7 @@ func (g *Gnostic) writeBinaryOutput(message proto.Message) error {
 	if err != nil {
 		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 	} else {
-		writeFile(g.binaryOutputPath, protoBytes, g.sourceName, "pb")
+		g.writeOutput(g.binaryOutputPath, protoBytes, "pb")
 	}
 	return err
 }
@@ -509,11 +984,11 @@ func (g *Gnostic) writeBinaryOutput(message proto.Message) error {
 // Write a text pb representation.
 func (g *Gnostic) writeTextOutput(message proto.Message) {
 	bytes := []byte(proto.MarshalTextString(message))
-	writeFile(g.textOutputPath, bytes, g.sourceName, "text")
+	g.writeOutput(g.textOutputPath, bytes, "text")
 }
 
 // Write JSON/YAML OpenAPI representations.
-func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
+func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) error {
 	// Convert the OpenAPI document into an exportable MapSlice.
 	var rawInfo *yaml.Node
 	if g.sourceFormat == SourceFormatOpenAPI2 {
@@ -532,6 +1007,12 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 			Content: []*yaml.Node{rawInfo},
 		}
 	}
+	// Optionally describe how the document was generated.
+	if g.provenance {
+		if err := g.addProvenance(rawInfo); err != nil {
+			return err
+		}
+	}
 	// Optionally write description in yaml format.
 	if g.yamlOutputPath != "" {
 		if rawInfo != nil {
@@ -540,7 +1021,9 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 				fmt.Fprintf(os.Stderr, "Error generating yaml output %s\n", err.Error())
 				fmt.Fprintf(os.Stderr, "info %+v", rawInfo)
 			}
-			writeFile(g.yamlOutputPath, bytes, g.sourceName, "yaml")
+			if err = g.writeSignedOutput(g.yamlOutputPath, bytes, "yaml"); err != nil {
+				return err
+			}
 		} else {
 			fmt.Fprintf(os.Stderr, "No yaml output available.\n")
 		}
@@ -556,11 +1039,14 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating json output %s\n", err.Error())
 			}
-			writeFile(g.jsonOutputPath, bytes, g.sourceName, "json")
+			if err = g.writeSignedOutput(g.jsonOutputPath, bytes, "json"); err != nil {
+				return err
+			}
 		} else {
 			fmt.Fprintf(os.Stderr, "No json output available.\n")
 		}
 	}
+	return nil
 }
 
 // Write messages.
@@ -569,26 +1055,131 @@ func (g *Gnostic) writeMessagesOutput(message proto.Message) error {
 	if err != nil {
 		writeFile(g.messageOutputPath, g.errorBytes(err), g.sourceName, "errors")
 	} else {
-		writeFile(g.messageOutputPath, protoBytes, g.sourceName, "messages.pb")
+		g.writeOutput(g.messageOutputPath, protoBytes, "messages.pb")
 	}
 	return err
 }
 
-// Perform all actions specified in the command-line options.
-func (g *Gnostic) performActions(message proto.Message) (err error) {
-	// Optionally resolve internal references.
-	if g.resolveReferences {
+// Apply the transformations specified in the command-line options.
+func (g *Gnostic) transform(message proto.Message) (proto.Message, error) {
+	var ts []transforms.Transformation
+	// Inlining can fail, but transformations can't return errors.
+	var inlineErr error
+	if g.deduplicate {
+		ts = append(ts, transforms.Deduplicate)
+	}
+	if g.extractSchemas {
+		ts = append(ts, transforms.Extract)
+	}
+	if g.flatten {
+		ts = append(ts, func(root *yaml.Node) *yaml.Node {
+			result, err := transforms.InlineContext(g.context(), root, g.flattenDepth)
+			if err != nil {
+				inlineErr = err
+				return root
+			}
+			return result
+		})
+	}
+	if g.minify != (transforms.MinifyOptions{}) {
+		ts = append(ts, func(root *yaml.Node) *yaml.Node {
+			return transforms.Minify(root, g.minify)
+		})
+	}
+	var err error
+	switch g.sourceFormat {
+	case SourceFormatOpenAPI2:
+		message, err = transforms.TransformV2(message.(*openapi_v2.Document), ts...)
+	case SourceFormatOpenAPI3:
+		message, err = transforms.TransformV3(message.(*openapi_v3.Document), ts...)
+	default:
+		return nil, errors.New("transformations can only be applied to OpenAPI documents")
+	}
+	if inlineErr != nil {
+		return nil, inlineErr
+	}
+	return message, err
+}
+
+// Resolve references and transform a document as specified in the options.
+func (g *Gnostic) resolveAndTransform(message proto.Message) (_ proto.Message, err error) {
+	// Optionally resolve internal references. Only OpenAPI documents
+	// contain JSON references; Discovery references name schemas.
+	openAPI := g.sourceFormat == SourceFormatOpenAPI2 || g.sourceFormat == SourceFormatOpenAPI3
+	if g.resolveReferences && openAPI {
+		// Read the targets of references, downloading remote files
+		// concurrently, so that nested relative references are resolved
+		// from the files that contain them.
+		if err = compiler.PrefetchReferencesContext(g.context(), g.sourceName); err != nil {
+			return nil, err
+		}
+		// Check that resolution stays within the compiler's limits.
+		if err = compiler.CheckReferencesContext(g.context(), g.sourceName); err != nil {
+			return nil, err
+		}
 		if g.sourceFormat == SourceFormatOpenAPI2 {
 			document := message.(*openapi_v2.Document)
-			_, err = document.ResolveReferences(g.sourceName)
+			_, err = compiler.ResolveReferences(document, g.sourceName)
 		} else if g.sourceFormat == SourceFormatOpenAPI3 {
 			document := message.(*openapi_v3.Document)
-			_, err = document.ResolveReferences(g.sourceName)
+			if _, err = compiler.ResolveReferences(document, g.sourceName); err == nil {
+				message, err = transforms.ResolveInternalV3Context(g.context(), document, g.keepCyclicRefs)
+				// The resolved document no longer matches the source.
+				g.locations = nil
+			}
 		}
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+	if err := g.context().Err(); err != nil {
+		return nil, err
+	}
+	// Optionally replace references with copies of their targets.
+	if g.dereference {
+		switch g.sourceFormat {
+		case SourceFormatOpenAPI2:
+			message, err = transforms.DereferenceV2Context(g.context(), message.(*openapi_v2.Document), g.sourceName)
+		case SourceFormatOpenAPI3:
+			message, err = transforms.DereferenceV3Context(g.context(), message.(*openapi_v3.Document), g.sourceName)
+		default:
+			err = errors.New("references can only be replaced in OpenAPI documents")
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The dereferenced document no longer matches the source.
+		g.locations = nil
+	}
+	if err := g.context().Err(); err != nil {
+		return nil, err
+	}
+	// Optionally transform the document.
+	if g.flatten || g.extractSchemas || g.deduplicate || g.minify != (transforms.MinifyOptions{}) {
+		message, err = g.transform(message)
+		if err != nil {
+			return nil, err
 		}
+		// The transformed document no longer matches the source.
+		g.locations = nil
+	}
+	if err := g.context().Err(); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// Perform all actions specified in the command-line options.
+func (g *Gnostic) performActions(message proto.Message) (err error) {
+	message, err = g.resolveAndTransform(message)
+	if err != nil {
+		return err
 	}
+	return g.writeOutputs(message)
+}
+
+// Write the outputs and call the plugins specified in the options.
+func (g *Gnostic) writeOutputs(message proto.Message) (err error) {
 	// Optionally write proto in binary format.
 	if g.binaryOutputPath != "" {
 		err = g.writeBinaryOutput(message)
@@ -600,39 +1191,85 @@ func (g *Gnostic) performActions(message proto.Message) (err error) {
 	if g.textOutputPath != "" {
 		g.writeTextOutput(message)
 	}
+	// Optionally write proto in stable text and JSON formats.
+	if g.textProtoOutputPath != "" {
+		err = g.writeTextProtoOutput(message)
+		if err != nil {
+			return err
+		}
+	}
+	if g.jsonpbOutputPath != "" {
+		err = g.writeJSONPBOutput(message)
+		if err != nil {
+			return err
+		}
+	}
 	// Optionally write document in yaml and/or json formats.
 	if g.yamlOutputPath != "" || g.jsonOutputPath != "" {
-		g.writeJSONYAMLOutput(message)
+		err = g.writeJSONYAMLOutput(message)
+		if err != nil {
+			return err
+		}
 	}
 	// Call all specified plugins.
+	return g.callPlugins([]*pluginDocument{g.pluginDocument(message)})
+}
+
+// Describe the compiled source for plugins.
+func (g *Gnostic) pluginDocument(message proto.Message) *pluginDocument {
+	return &pluginDocument{
+		message:      message,
+		sourceFormat: g.sourceFormat,
+		sourceName:   g.sourceName,
+		locations:    g.locations,
+	}
+}
+
+// Call the specified plugins with one or more documents and report
+// their messages.
+func (g *Gnostic) callPlugins(documents []*pluginDocument) error {
 	messages := make([]*plugins.Message, 0)
+	result := make([]*findings.Finding, 0)
 	errors := make([]error, 0)
 	for _, p := range g.pluginCalls {
-		pluginMessages, err := p.perform(message, g.sourceFormat, g.sourceName, g.timePlugins, g.excludeSurface)
+		pluginMessages, err := p.perform(documents, g.timePlugins, g.excludeSurface)
 		if err != nil {
 			// we don't exit or fail here so that we run all plugins even when some have errors
 			errors = append(errors, err)
 		}
 		messages = append(messages, pluginMessages...)
+		result = append(result, lint.FindingsFromMessages(pluginPrefix+p.Name, pluginMessages)...)
 	}
 	if g.messageOutputPath != "" {
-		err = g.writeMessagesOutput(&plugins.Messages{Messages: messages})
+		err := g.writeMessagesOutput(&plugins.Messages{Messages: messages})
 		if err != nil {
 			return err
 		}
 	} else {
-		// Print any messages from the plugins
-		if len(messages) > 0 {
-			for _, message := range messages {
-				fmt.Printf("%+v\n", message)
+		// Print any messages from the plugins with their severities,
+		// keeping them out of any output that is written to stdout.
+		locatePluginFindings(result, documents)
+		writeFindingsText(os.Stderr, result)
+	}
+	return compiler.NewErrorGroupOrNil(errors)
+}
+
+// Set the sources of the findings of plugins and the positions of the
+// findings without them from the documents that the findings describe.
+func locatePluginFindings(result []*findings.Finding, documents []*pluginDocument) {
+	for _, f := range result {
+		d := documents[0]
+		for _, document := range documents {
+			if document.sourceName == f.Source {
+				d = document
 			}
 		}
+		findings.Locate([]*findings.Finding{f}, d.sourceName, d.locations)
 	}
-	return compiler.NewErrorGroupOrNil(errors)
 }
 
 // Main is the main program for Gnostic.
-func (g *Gnostic) Main() error {
+func (g *Gnostic) Main() (err error) {
 	// if help is requested, print usage and immediately exit
 	for _, arg := range g.args {
 		if arg == "--help" {
@@ -641,9 +1278,58 @@ func (g *Gnostic) Main() error {
 		}
 	}
 
+	// "gnostic serve" runs a server instead of reading a source.
+	if len(g.args) > 1 && g.args[1] == "serve" {
+		return g.serve()
+	}
+	// "gnostic convert" reads a source in another format.
+	if len(g.args) > 1 && g.args[1] == "convert" {
+		return g.convert()
+	}
+	// "gnostic coverage" measures the documentation of sources.
+	if len(g.args) > 1 && g.args[1] == "coverage" {
+		return g.coverage()
+	}
+	// "gnostic stats" prints a summary of a source.
+	if len(g.args) > 1 && g.args[1] == "stats" {
+		return g.stats()
+	}
+	// "gnostic hash" prints the canonical digest of a source.
+	if len(g.args) > 1 && g.args[1] == "hash" {
+		return g.hash()
+	}
+	// "gnostic diff" compares two versions of a source.
+	if len(g.args) > 1 && g.args[1] == "diff" {
+		return g.diff()
+	}
+	// "gnostic lint" reports the findings of lint rules and plugins.
+	if len(g.args) > 1 && g.args[1] == "lint" {
+		return g.lint()
+	}
+	// "gnostic batch" calls plugins with several sources at once.
+	if len(g.args) > 1 && g.args[1] == "batch" {
+		return g.batch()
+	}
+	// "gnostic workspace" compiles related sources together.
+	if len(g.args) > 1 && g.args[1] == "workspace" {
+		return g.workspace()
+	}
+	// "gnostic query" prints values selected from a source.
+	if len(g.args) > 1 && g.args[1] == "query" {
+		return g.query()
+	}
+	// "gnostic verify" checks the signature and provenance of a document.
+	if len(g.args) > 1 && g.args[1] == "verify" {
+		return g.verify()
+	}
+	// "gnostic resolve" replaces the references in a source.
+	if len(g.args) > 1 && g.args[1] == "resolve" {
+		g.dereference = true
+		g.args = append([]string{g.args[0]}, g.args[2:]...)
+	}
+
 	compiler.ClearCaches()
 
-	var err error
 	err = g.readOptions()
 	if err != nil {
 		return err
@@ -652,38 +1338,57 @@ func (g *Gnostic) Main() error {
 	if err != nil {
 		return err
 	}
+	// With --watch, compile again whenever the source or the files that
+	// were read to compile it change.
+	if g.watch {
+		return g.watchSource(context.Background())
+	}
+	return g.compile()
+}
+
+// Compile the source, write the outputs, and call the plugins specified
+// in the options.
+func (g *Gnostic) compile() (err error) {
+	// Optionally write the result of compilation for code scanning tools.
+	if g.sarifOutputPath != "" {
+		defer func() {
+			g.writeSARIFOutput(err)
+		}()
+	}
 	// Read the OpenAPI source.
 	bytes, err := compiler.ReadBytesForFile(g.sourceName)
 	if err != nil {
 		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 		return err
 	}
-	extension := strings.ToLower(filepath.Ext(g.sourceName))
-	var message proto.Message
-	if extension == ".json" || extension == ".yaml" {
-		// Try to read the source as JSON/YAML.
-		message, err = g.readOpenAPIText(bytes)
-		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			return err
-		}
-	} else if extension == ".pb" {
-		// Try to read the source as a binary protocol buffer.
-		message, err = g.readOpenAPIBinary(bytes)
-		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			return err
-		}
-	} else {
-		err = errors.New("unknown file extension. 'json', 'yaml', and 'pb' are accepted")
+	// RAML and API Blueprint sources are converted to OpenAPI v3.
+	switch format := conversions.DetectFormat(g.sourceName, bytes); format {
+	case conversions.RAMLFormat, conversions.BlueprintFormat:
+		return g.compileConverted(bytes, format)
+	}
+	if g.sourceEncodingOf(bytes) == "" {
+		err = errors.New("unknown file extension. 'json', 'yaml', 'pb', 'textproto', and 'jsonpb' are accepted")
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	message, err := g.compileSource(bytes)
+	if err != nil {
 		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 		return err
 	}
 	// Perform actions specified by command options.
-	err = g.performActions(message)
+	err = g.writeOutputs(message)
 	if err != nil {
 		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
 		return err
 	}
+	// Optionally attest the outputs.
+	if g.attestationPath != "" {
+		err = g.writeAttestation()
+		if err != nil {
+			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,67 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/gnostic/compiler"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Compile a source and print the digest of its canonical encoding and its
+// name, as in "gnostic hash SOURCE".
+func (g *Gnostic) hash() error {
+	g.args = append(g.args[:1], g.args[2:]...)
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if g.sourceName == "" {
+		return NewUsageError("no input specified")
+	}
+	if g.errorOutputPath == "" {
+		g.errorOutputPath = "="
+	}
+	digest, err := g.readHash()
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stdout, "%s  %s\n", digest, g.sourceName)
+	return err
+}
+
+func (g *Gnostic) readHash() (string, error) {
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return "", err
+	}
+	message, err := g.compileSource(bytes)
+	if err != nil {
+		return "", err
+	}
+	switch document := message.(type) {
+	case *openapi_v2.Document:
+		return openapi_v2.Hash(document), nil
+	case *openapi_v3.Document:
+		return openapi_v3.Hash(document), nil
+	}
+	return "", errors.New("hashes can only be computed for OpenAPI documents")
+}
@@ -0,0 +1,535 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	discovery_v1 "github.com/google/gnostic/discovery"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	"github.com/google/gnostic/transforms"
+)
+
+// TextEdit replaces a range of the text of a description. Lines and
+// columns are one-based, as in compiler.Location, and columns count
+// characters. The range includes Start but not End.
+type TextEdit struct {
+	Start compiler.Location
+	End   compiler.Location
+	Text  string
+}
+
+// Compilation is a compiled description that is updated as its text is
+// edited. Edits within a single path item or component (or, in OpenAPI v2,
+// a single definition) of a YAML description only reparse and recompile
+// that entry, so that editors can compile large descriptions after every
+// change. Other edits, and any edits of descriptions that are compiled
+// with options that transform the whole document, recompile the whole
+// description. Edits of descriptions with YAML anchors and aliases also
+// recompile the whole description, since aliases copy values between
+// entries.
+//
+// Compilations aren't safe for concurrent use.
+type Compilation struct {
+	opts   Options
+	source []byte
+
+	format    int
+	root      *yaml.Node // the YAML tree of the source, if it was read as YAML
+	updatable bool       // true if entries of the source can be recompiled separately
+	message   proto.Message
+	// Errors of compilation are kept with the entries that they were found
+	// in, so that they can be replaced when an entry is recompiled.
+	errs      []error
+	entryErrs map[*yaml.Node][]error // keyed by the value of the entry
+	locations *compiler.LocationIndex
+
+	compilations int // the number of times the whole source was compiled
+}
+
+// NewCompilation returns a compilation that compiles sources with options.
+func NewCompilation(opts Options) *Compilation {
+	return &Compilation{opts: opts}
+}
+
+// Source returns the current text of the description.
+func (c *Compilation) Source() []byte {
+	return c.source
+}
+
+// Locations returns the positions of the values of the description, or nil
+// if its source couldn't be read as YAML.
+func (c *Compilation) Locations() *compiler.LocationIndex {
+	if c.locations == nil && c.root != nil {
+		c.locations = compiler.NewLocationIndex(c.root)
+	}
+	return c.locations
+}
+
+// Compile compiles a new source in full and returns the compiled document,
+// as Compile does.
+func (c *Compilation) Compile(ctx context.Context, source []byte) (proto.Message, error) {
+	c.source = source
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.compile(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.result()
+}
+
+// Apply applies edits to the source in order and returns the compiled
+// document. The compiled document is updated in place, so documents that
+// were returned earlier are changed too.
+func (c *Compilation) Apply(ctx context.Context, edits ...TextEdit) (proto.Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, edit := range edits {
+		source, err := applyTextEdit(c.source, edit)
+		if err != nil {
+			return nil, err
+		}
+		if c.updatable && !c.update(source, edit) {
+			// The whole source is compiled after the remaining edits.
+			c.updatable = false
+		}
+		c.source = source
+		c.locations = nil
+	}
+	if !c.updatable {
+		c.compile(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.result()
+}
+
+// Can entries of documents compiled with these options be recompiled
+// separately?
+func (opts Options) incremental() bool {
+	return len(opts.Merges) == 0 && len(opts.Overlays) == 0 && len(opts.Patches) == 0 &&
+		!opts.ResolveReferences && !opts.Dereference && !opts.Flatten && !opts.ExtractSchemas && !opts.Deduplicate &&
+		opts.Minify == (transforms.MinifyOptions{}) &&
+		opts.CacheDir == ""
+}
+
+// Compile the whole source. Sources that can't be updated incrementally
+// are compiled by Compile.
+func (c *Compilation) compile(ctx context.Context) {
+	c.compilations++
+	c.root, c.updatable, c.message, c.errs, c.entryErrs, c.locations = nil, false, nil, nil, nil, nil
+	g := NewGnostic(nil)
+	g.sourceName = c.opts.SourceName
+	if !c.opts.incremental() || g.sourceEncodingOf(c.source) != sourceEncodingText {
+		message, err := Compile(ctx, c.source, c.opts)
+		c.message = message
+		if err != nil {
+			c.errs = []error{err}
+		}
+		return
+	}
+	if g.sourceName != "" {
+		compiler.RemoveFromInfoCache(g.sourceName)
+	}
+	info, err := compiler.ReadInfoFromBytes(g.sourceName, c.source)
+	if err != nil {
+		c.errs = []error{err}
+		return
+	}
+	c.format = getOpenAPIVersionFromInfo(info)
+	if c.format == SourceFormatUnknown {
+		c.errs = []error{errors.New("unable to identify OpenAPI version")}
+		return
+	}
+	root := info.Content[0]
+	context := compiler.NewContextWithExtensions("$root", root, nil, c.extensionHandlers())
+	switch c.format {
+	case SourceFormatOpenAPI2:
+		c.message, err = openapi_v2.NewDocument(root, context)
+	case SourceFormatOpenAPI3:
+		c.message, err = openapi_v3.NewDocument(root, context)
+	default:
+		c.message, err = discovery_v1.NewDocument(root, context)
+	}
+	c.root = root
+	// Aliases are expanded into copies of their anchored values, which
+	// can't be updated separately.
+	c.updatable = !anchored(root)
+	// Assign errors to the entries that they were found in.
+	c.entryErrs = make(map[*yaml.Node][]error)
+	values := make(map[*yaml.Node]bool)
+	c.eachEntry(func(_ *entryKind, _ *yaml.Node, i int, entries *yaml.Node) {
+		values[entries.Content[i+1]] = true
+	})
+	for _, e := range flattenErrors(err) {
+		if value := entryOfError(e, values); value != nil {
+			c.entryErrs[value] = append(c.entryErrs[value], e)
+		} else {
+			c.errs = append(c.errs, e)
+		}
+	}
+}
+
+// Get the compiled document or the errors of compilation. Errors of
+// entries follow other errors, in the order of the entries.
+func (c *Compilation) result() (proto.Message, error) {
+	errs := append([]error{}, c.errs...)
+	c.eachEntry(func(_ *entryKind, _ *yaml.Node, i int, entries *yaml.Node) {
+		errs = append(errs, c.entryErrs[entries.Content[i+1]]...)
+	})
+	if len(errs) > 0 {
+		return nil, compiler.NewErrorGroupOrNil(errs)
+	}
+	return c.message, nil
+}
+
+// An entryKind describes entries of documents that can be recompiled
+// separately, which are the members of a map in the document.
+type entryKind struct {
+	pointer string // the map that contains the entries
+	paths   bool   // true if only keys that begin with "/" are entries
+	field   string // the repeated field of named values in the compiled map
+	compile func(*yaml.Node, *compiler.Context) (proto.Message, error)
+}
+
+var entryKindsV2 = []*entryKind{
+	{"/paths", true, "path", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewPathItem(n, c)
+	}},
+	{"/definitions", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewSchema(n, c)
+	}},
+	{"/parameters", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewParameter(n, c)
+	}},
+	{"/responses", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewResponse(n, c)
+	}},
+	{"/securityDefinitions", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewSecurityDefinitionsItem(n, c)
+	}},
+}
+
+var entryKindsV3 = []*entryKind{
+	{"/paths", true, "path", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewPathItem(n, c)
+	}},
+	{"/components/schemas", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewSchemaOrReference(n, c)
+	}},
+	{"/components/responses", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewResponseOrReference(n, c)
+	}},
+	{"/components/parameters", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewParameterOrReference(n, c)
+	}},
+	{"/components/examples", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewExampleOrReference(n, c)
+	}},
+	{"/components/requestBodies", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewRequestBodyOrReference(n, c)
+	}},
+	{"/components/headers", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewHeaderOrReference(n, c)
+	}},
+	{"/components/securitySchemes", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewSecuritySchemeOrReference(n, c)
+	}},
+	{"/components/links", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewLinkOrReference(n, c)
+	}},
+	{"/components/callbacks", false, "additional_properties", func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewCallbackOrReference(n, c)
+	}},
+}
+
+// Is a key the name of an entry of a kind?
+func (k *entryKind) accepts(key *yaml.Node) bool {
+	name, ok := compiler.StringForScalarNode(key)
+	return ok && (!k.paths || strings.HasPrefix(name, "/"))
+}
+
+// Call a function with each entry of the source, in order. Entries are
+// only found in maps in the YAML block style.
+func (c *Compilation) eachEntry(f func(kind *entryKind, key *yaml.Node, i int, entries *yaml.Node)) {
+	if c.root == nil {
+		return
+	}
+	kinds := entryKindsV3
+	if c.format == SourceFormatOpenAPI2 {
+		kinds = entryKindsV2
+	} else if c.format != SourceFormatOpenAPI3 {
+		return
+	}
+	for _, kind := range kinds {
+		entries, err := jsonpointer.Resolve(c.root, kind.pointer)
+		if err != nil || entries.Kind != yaml.MappingNode || entries.Style&yaml.FlowStyle != 0 {
+			continue
+		}
+		for i := 0; i+1 < len(entries.Content); i += 2 {
+			if key := entries.Content[i]; kind.accepts(key) {
+				f(kind, key, i, entries)
+			}
+		}
+	}
+}
+
+// Update the entry of the source that contains an edit. Returns false if
+// the edit isn't within a single entry or the edited entry can't be read
+// separately.
+func (c *Compilation) update(source []byte, edit TextEdit) bool {
+	var kind *entryKind
+	var entries *yaml.Node
+	var index, start, end int
+	lines := strings.Split(string(c.source), "\n")
+	c.eachEntry(func(k *entryKind, key *yaml.Node, i int, e *yaml.Node) {
+		if kind != nil {
+			return
+		}
+		s, n, ok := entryLines(lines, key.Line, key.Column)
+		if ok && s <= edit.Start.Line && edit.End.Line <= n {
+			kind, entries, index, start, end = k, e, i, s, n
+		}
+	})
+	if kind == nil {
+		return false
+	}
+	key := entries.Content[index]
+	// The entry must end where it did, so that the edit didn't change
+	// other entries.
+	delta := strings.Count(edit.Text, "\n") - (edit.End.Line - edit.Start.Line)
+	lines = strings.Split(string(source), "\n")
+	_, newEnd, ok := entryLines(lines, start, key.Column)
+	if !ok || newEnd != end+delta {
+		return false
+	}
+	info, err := compiler.ReadInfoFromBytes("", []byte(strings.Join(lines[start-1:newEnd], "\n")))
+	if err != nil || len(info.Content) == 0 || anchored(info) {
+		return false
+	}
+	m := info.Content[0]
+	if m.Kind != yaml.MappingNode || len(m.Content) != 2 || !kind.accepts(m.Content[0]) ||
+		m.Content[0].Line != 1 || m.Content[0].Column != key.Column {
+		return false
+	}
+	newKey, newValue := m.Content[0], m.Content[1]
+	for i := 0; i+1 < len(entries.Content); i += 2 {
+		if i != index && entries.Content[i].Value == newKey.Value {
+			return false
+		}
+	}
+	// Find the compiled entry.
+	list, ok := namedValues(c.message, kind)
+	if !ok {
+		return false
+	}
+	var named protoreflect.Message
+	for i := 0; i < list.Len(); i++ {
+		if m := list.Get(i).Message(); m.Get(m.Descriptor().Fields().ByName("name")).String() == key.Value {
+			named = m
+			break
+		}
+	}
+	if named == nil {
+		return false
+	}
+	// Replace the entry in the tree and move the values that follow it.
+	shiftLines(c.root, end, delta)
+	shiftLines(newKey, 0, start-1)
+	shiftLines(newValue, 0, start-1)
+	oldValue := entries.Content[index+1]
+	entries.Content[index], entries.Content[index+1] = newKey, newValue
+	// Recompile the entry.
+	context := compiler.NewContextWithExtensions("$root", c.root, nil, c.extensionHandlers())
+	tokens, _ := jsonpointer.Parse(kind.pointer)
+	node := c.root
+	for _, token := range tokens {
+		node = compiler.MapValueForKey(node, token)
+		context = compiler.NewContext(token, node, context)
+	}
+	value, err := kind.compile(newValue, compiler.NewContext(newKey.Value, newValue, context))
+	fields := named.Descriptor().Fields()
+	named.Set(fields.ByName("name"), protoreflect.ValueOfString(newKey.Value))
+	named.Set(fields.ByName("value"), protoreflect.ValueOfMessage(proto.MessageV2(value).ProtoReflect()))
+	delete(c.entryErrs, oldValue)
+	if errs := flattenErrors(err); len(errs) > 0 {
+		c.entryErrs[newValue] = errs
+	}
+	return true
+}
+
+func (c *Compilation) extensionHandlers() *[]compiler.ExtensionHandler {
+	handlers := make([]compiler.ExtensionHandler, 0)
+	for _, name := range c.opts.Extensions {
+		handlers = append(handlers, compiler.ExtensionHandler{Name: extensionPrefix + name})
+	}
+	return &handlers
+}
+
+// Get the list of named values of the compiled map that contains entries
+// of a kind. The fields of documents have the JSON names of their keys.
+func namedValues(document proto.Message, kind *entryKind) (protoreflect.List, bool) {
+	if document == nil {
+		return nil, false
+	}
+	tokens, err := jsonpointer.Parse(kind.pointer)
+	if err != nil {
+		return nil, false
+	}
+	m := proto.MessageV2(document).ProtoReflect()
+	for _, token := range tokens {
+		field := m.Descriptor().Fields().ByJSONName(token)
+		if field == nil || field.Message() == nil || !m.Has(field) {
+			return nil, false
+		}
+		m = m.Get(field).Message()
+	}
+	field := m.Descriptor().Fields().ByName(protoreflect.Name(kind.field))
+	if field == nil || !field.IsList() {
+		return nil, false
+	}
+	return m.Get(field).List(), true
+}
+
+// Get the first and last lines of the entry whose key is at a line and
+// column. Entries of maps in the block style end before the next line that
+// isn't indented more than their keys, and include blank lines and
+// comments that precede it.
+func entryLines(lines []string, line, column int) (int, int, bool) {
+	if line < 1 || line > len(lines) || indentation(lines[line-1]) != column-1 {
+		return 0, 0, false
+	}
+	end := line
+	for ; end < len(lines); end++ {
+		next := lines[end]
+		trimmed := strings.TrimSpace(next)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && indentation(next) <= column-1 {
+			break
+		}
+	}
+	return line, end, true
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// Add delta to the lines of the nodes of a tree that are after a line.
+func shiftLines(node *yaml.Node, after, delta int) {
+	if node.Line > after {
+		node.Line += delta
+	}
+	for _, child := range node.Content {
+		shiftLines(child, after, delta)
+	}
+}
+
+// Does a tree have anchored values?
+func anchored(node *yaml.Node) bool {
+	if node.Anchor != "" || node.Kind == yaml.AliasNode {
+		return true
+	}
+	for _, child := range node.Content {
+		if anchored(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the errors of an error or error group.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	group, ok := err.(*compiler.ErrorGroup)
+	if !ok {
+		return []error{err}
+	}
+	errs := make([]error, 0)
+	for _, e := range group.Errors {
+		errs = append(errs, flattenErrors(e)...)
+	}
+	return errs
+}
+
+// Get the value of the entry that an error was found in, if any.
+func entryOfError(err error, values map[*yaml.Node]bool) *yaml.Node {
+	e, ok := err.(*compiler.Error)
+	if !ok {
+		return nil
+	}
+	for context := e.Context; context != nil; context = context.Parent {
+		if values[context.Node] {
+			return context.Node
+		}
+	}
+	return nil
+}
+
+// Apply an edit to a source.
+func applyTextEdit(source []byte, edit TextEdit) ([]byte, error) {
+	start, err := textOffset(source, edit.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := textOffset(source, edit.End)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, fmt.Errorf("edit ends at %d:%d before it starts", edit.End.Line, edit.End.Column)
+	}
+	result := make([]byte, 0, len(source)-(end-start)+len(edit.Text))
+	result = append(result, source[:start]...)
+	result = append(result, edit.Text...)
+	return append(result, source[end:]...), nil
+}
+
+// Get the byte offset of a position in a source.
+func textOffset(source []byte, location compiler.Location) (int, error) {
+	offset := 0
+	for line := 1; line < location.Line; line++ {
+		i := bytes.IndexByte(source[offset:], '\n')
+		if i < 0 {
+			return 0, fmt.Errorf("edit position %d:%d is outside the source", location.Line, location.Column)
+		}
+		offset += i + 1
+	}
+	for column := 1; column < location.Column; column++ {
+		if offset >= len(source) || source[offset] == '\n' {
+			return 0, fmt.Errorf("edit position %d:%d is outside the source", location.Line, location.Column)
+		}
+		_, size := utf8.DecodeRune(source[offset:])
+		offset += size
+	}
+	return offset, nil
+}
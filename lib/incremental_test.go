@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib_test
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/lib"
+)
+
+// Get an edit that replaces the first occurrence of a string in a source.
+func replacement(t *testing.T, source []byte, old, new string) lib.TextEdit {
+	i := strings.Index(string(source), old)
+	if i < 0 {
+		t.Fatalf("%q isn't in the source", old)
+	}
+	location := func(offset int) compiler.Location {
+		before := string(source[:offset])
+		line := strings.Count(before, "\n") + 1
+		column := len([]rune(before[strings.LastIndex(before, "\n")+1:])) + 1
+		return compiler.Location{Line: line, Column: column}
+	}
+	return lib.TextEdit{Start: location(i), End: location(i + len(old)), Text: new}
+}
+
+func TestCompilation(t *testing.T) {
+	source, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	opts := lib.Options{SourceName: "petstore.yaml"}
+	c := lib.NewCompilation(opts)
+	if _, err = c.Compile(context.Background(), source); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	edits := []struct {
+		old, new string
+		invalid  bool
+	}{
+		{"List all pets", "List the pets", false},
+		{"operationId: createPets", "operationId: createPets\n      description: Adds a pet.", false},
+		{"    Pets:\n", "    Pets:\n      bogus: 1\n", true},
+		{"/pets/{petId}:", "/pets/{id}:", true},
+		{"      bogus: 1\n", "", false},
+		{"title: OpenAPI Petstore", "title: Petstore", false},
+		{"    Error:", "    Problem:", false},
+	}
+	for _, e := range edits {
+		message, err := c.Apply(context.Background(), replacement(t, c.Source(), e.old, e.new))
+		// The result must match a compilation of the whole source.
+		expected, expectedErr := lib.Compile(context.Background(), c.Source(), opts)
+		if e.invalid {
+			if err == nil || expectedErr == nil || err.Error() != expectedErr.Error() {
+				t.Errorf("replacing %q: expected %v, got %v", e.old, expectedErr, err)
+			}
+			continue
+		}
+		if err != nil || expectedErr != nil {
+			t.Fatalf("replacing %q: %v, %v", e.old, err, expectedErr)
+		}
+		if !proto.Equal(message, expected) {
+			t.Errorf("replacing %q: compiled documents differ", e.old)
+		}
+		index := compiler.NewLocationIndex(mustParse(t, c.Source()))
+		if !reflect.DeepEqual(c.Locations().Pointers(), index.Pointers()) {
+			t.Errorf("replacing %q: locations differ", e.old)
+		}
+		for _, pointer := range index.Pointers() {
+			if actual, _ := c.Locations().Location(pointer); actual != index.Lookup(pointer) {
+				t.Errorf("replacing %q: %s is at %+v, expected %+v", e.old, pointer, actual, index.Lookup(pointer))
+				break
+			}
+		}
+	}
+	// Edits of invalid text are errors.
+	if _, err = c.Apply(context.Background(), lib.TextEdit{Start: compiler.Location{Line: 1000, Column: 1}}); err == nil {
+		t.Errorf("expected an error for an edit outside the source")
+	}
+}
+
+func mustParse(t *testing.T, source []byte) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal(source, &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return &node
+}
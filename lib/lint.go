@@ -0,0 +1,157 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/findings"
+	lint "github.com/google/gnostic/metrics/lint"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// errLintErrors is returned when the findings of "gnostic lint" include errors.
+var errLintErrors = errors.New("lint errors were found")
+
+// Compile a source, run the built-in lint rules and lint plugins on it, and
+// print their findings, as in "gnostic lint SOURCE --lint-paths". Plugins
+// report findings with the messages of their responses.
+func (g *Gnostic) lint() error {
+	args := []string{g.args[0]}
+	format := "text"
+	builtin := true
+	for _, arg := range g.args[2:] {
+		switch {
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case arg == "--no-builtin-rules":
+			builtin = false
+		default:
+			args = append(args, arg)
+		}
+	}
+	g.args = args
+
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if g.sourceName == "" {
+		return NewUsageError("no input specified")
+	}
+	if format != "text" && format != "json" && format != "sarif" {
+		return NewUsageError(fmt.Sprintf("unknown format %q", format))
+	}
+	if g.errorOutputPath == "" {
+		g.errorOutputPath = "="
+	}
+	result, err := g.lintFindings(builtin)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	err = writeFindings(os.Stdout, result, format)
+	if err != nil {
+		return err
+	}
+	if findings.HasErrors(result) {
+		return errLintErrors
+	}
+	return nil
+}
+
+// Compile the source and collect the findings of the built-in rules and of
+// each plugin, in that order.
+func (g *Gnostic) lintFindings(builtin bool) ([]*findings.Finding, error) {
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return nil, err
+	}
+	message, err := g.compileSource(bytes)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*findings.Finding, 0)
+	if builtin {
+		switch document := message.(type) {
+		case *openapi_v2.Document:
+			result = append(result, lint.LintV2(document)...)
+		case *openapi_v3.Document:
+			result = append(result, lint.LintV3(document)...)
+		default:
+			return nil, errors.New("the built-in rules can only be run on OpenAPI documents")
+		}
+	}
+	documents := []*pluginDocument{g.pluginDocument(message)}
+	for _, p := range g.pluginCalls {
+		messages, err := p.perform(documents, g.timePlugins, g.excludeSurface)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, lint.FindingsFromMessages(pluginPrefix+p.Name, messages)...)
+	}
+	findings.Locate(result, g.sourceName, g.locations)
+	return result, nil
+}
+
+// Write findings in a format: "text", "json", or "sarif".
+func writeFindings(w io.Writer, result []*findings.Finding, format string) error {
+	switch format {
+	case "json":
+		return writeFindingsJSON(w, result)
+	case "sarif":
+		return findings.WriteSARIF(w, result)
+	}
+	return writeFindingsText(w, result)
+}
+
+// Write findings one per line, with their suggestions on the following lines.
+func writeFindingsText(w io.Writer, result []*findings.Finding) error {
+	for _, f := range result {
+		pointer := f.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		_, err := fmt.Fprintf(w, "%s: %s: %s [%s, %s]\n", pointer, f.Severity, f.Message, f.Rule, f.Tool)
+		if err != nil {
+			return err
+		}
+		if f.Suggestion != "" {
+			_, err = fmt.Fprintf(w, "  %s\n", f.Suggestion)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Write findings as a JSON array.
+func writeFindingsJSON(w io.Writer, result []*findings.Finding) error {
+	bytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(bytes, '\n'))
+	return err
+}
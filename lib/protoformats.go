@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+// Write a representation in the protobuf text format. The spacing that
+// prototext deliberately varies between builds is normalized so that it
+// is stable, and comments name the file and message that it was written
+// from so that tools can read it.
+func (g *Gnostic) writeTextProtoOutput(message proto.Message) error {
+	bytes, err := marshalTextProto(message)
+	if err != nil {
+		return err
+	}
+	g.writeOutput(g.textProtoOutputPath, bytes, "textproto")
+	return nil
+}
+
+// Write a representation in the protobuf JSON format, indented so that
+// it is stable.
+func (g *Gnostic) writeJSONPBOutput(message proto.Message) error {
+	bytes, err := marshalJSONPB(message)
+	if err != nil {
+		return err
+	}
+	g.writeOutput(g.jsonpbOutputPath, bytes, "jsonpb")
+	return nil
+}
+
+// Lines of multi-line prototext output start with a field name and a
+// separator that may be followed by an extra space.
+var textProtoFieldRegex = regexp.MustCompile(`(?m)^( *[^ :"]+:)  `)
+
+func marshalTextProto(message proto.Message) ([]byte, error) {
+	m := proto.MessageV2(message)
+	data, err := prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	descriptor := m.ProtoReflect().Descriptor()
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# proto-file: %s\n", descriptor.ParentFile().Path())
+	fmt.Fprintf(&b, "# proto-message: %s\n\n", descriptor.FullName())
+	b.Write(textProtoFieldRegex.ReplaceAll(data, []byte("$1 ")))
+	return b.Bytes(), nil
+}
+
+func marshalJSONPB(message proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(proto.MessageV2(message))
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err = json.Indent(&b, data, "", "  "); err != nil {
+		return nil, err
+	}
+	b.WriteString("\n")
+	return b.Bytes(), nil
+}
+
+func unmarshalTextProto(data []byte, message proto.Message) error {
+	return prototext.Unmarshal(data, proto.MessageV2(message))
+}
+
+func unmarshalJSONPB(data []byte, message proto.Message) error {
+	return protojson.Unmarshal(data, proto.MessageV2(message))
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"os"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// provenanceExtension is the extension that describes how a document was
+// generated, which is added to JSON and YAML outputs with --provenance.
+const provenanceExtension = "x-gnostic-provenance"
+
+// documentProvenance is the value of the provenance extension. It records
+// the version of gnostic that wrote a document, when it was written, and
+// the digests of the files that were read to compile it.
+type documentProvenance struct {
+	Generator string              `yaml:"generator"`
+	Version   string              `yaml:"version"`
+	Timestamp string              `yaml:"timestamp"`
+	Sources   []*provenanceSource `yaml:"sources"`
+}
+
+// A provenanceSource is a file that was read to compile a document.
+type provenanceSource struct {
+	URI    string `yaml:"uri"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// gnosticVersion returns the module version of the running gnostic.
+func gnosticVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// provenanceTimestamp returns the time that is recorded in provenance.
+// SOURCE_DATE_EPOCH can be set to a number of seconds since the epoch
+// for reproducible builds.
+func provenanceTimestamp() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now().UTC()
+}
+
+// buildProvenance describes the compilation of the current source.
+func (g *Gnostic) buildProvenance() *documentProvenance {
+	digests := compiler.FileDigests()
+	uris := make([]string, 0, len(digests))
+	for uri := range digests {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	sources := make([]*provenanceSource, 0, len(uris))
+	for _, uri := range uris {
+		sources = append(sources, &provenanceSource{URI: uri, SHA256: digests[uri]})
+	}
+	return &documentProvenance{
+		Generator: builderID,
+		Version:   gnosticVersion(),
+		Timestamp: provenanceTimestamp().Format(time.RFC3339),
+		Sources:   sources,
+	}
+}
+
+// addProvenance sets the provenance extension of the root of a document,
+// replacing any provenance that was read from the source.
+func (g *Gnostic) addProvenance(root *yaml.Node) error {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	var value yaml.Node
+	if err := value.Encode(g.buildProvenance()); err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == provenanceExtension {
+			root.Content[i+1] = &value
+			return nil
+		}
+	}
+	root.Content = append(root.Content, compiler.NewScalarNodeForString(provenanceExtension), &value)
+	return nil
+}
+
+// readProvenance returns the provenance extension of a document, or nil if
+// it has none.
+func readProvenance(root *yaml.Node) (*documentProvenance, error) {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	value := compiler.MapValueForKey(root, provenanceExtension)
+	if value == nil {
+		return nil, nil
+	}
+	p := &documentProvenance{}
+	if err := value.Decode(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Write an output document and, if it has provenance and a key is given,
+// its signature, in a file with the same name followed by ".sig".
+func (g *Gnostic) writeSignedOutput(name string, bytes []byte, extension string) error {
+	filename := g.writeOutput(name, bytes, extension)
+	if filename == "" || !g.provenance || g.signingKeyPath == "" {
+		return nil
+	}
+	signature, err := signPayload(g.signingKeyPath, bytes)
+	if err != nil {
+		return err
+	}
+	writeFile(filename+".sig", signature, g.sourceName, "sig")
+	return nil
+}
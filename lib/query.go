@@ -0,0 +1,116 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/jsonwriter"
+	"github.com/google/gnostic/query"
+)
+
+// Compile a source and print the values that a JSONPath expression selects,
+// as in "gnostic query SOURCE '$.paths[*].get.operationId'". Scalars are
+// printed one per line and other values are printed as JSON. With --json,
+// all of the values are printed in a JSON array.
+func (g *Gnostic) query() error {
+	args := []string{g.args[0]}
+	expression := ""
+	asJSON := false
+	for _, arg := range g.args[2:] {
+		switch {
+		case expression == "" && strings.HasPrefix(arg, "$"):
+			expression = arg
+		case arg == "--json":
+			asJSON = true
+		default:
+			args = append(args, arg)
+		}
+	}
+	g.args = args
+
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if g.sourceName == "" {
+		return NewUsageError("no input specified")
+	}
+	if expression == "" {
+		return NewUsageError("query requires an expression that begins with $")
+	}
+	if g.errorOutputPath == "" {
+		g.errorOutputPath = "="
+	}
+	output, err := g.queryOutput(expression, asJSON)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	_, err = os.Stdout.Write(output)
+	return err
+}
+
+// Compile the source and format the values that an expression selects.
+func (g *Gnostic) queryOutput(expression string, asJSON bool) ([]byte, error) {
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return nil, err
+	}
+	message, err := g.compileSource(bytes)
+	if err != nil {
+		return nil, err
+	}
+	document, ok := message.(query.Document)
+	if !ok {
+		return nil, errors.New("queries can only be run on OpenAPI and Discovery documents")
+	}
+	results, err := query.Eval(document, expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %s", expression, err)
+	}
+	return formatQueryResults(results, asJSON)
+}
+
+func formatQueryResults(results []*query.Result, asJSON bool) ([]byte, error) {
+	if asJSON {
+		values := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, result := range results {
+			values.Content = append(values.Content, result.Node)
+		}
+		return jsonwriter.Marshal(values)
+	}
+	var b bytes.Buffer
+	for _, result := range results {
+		if result.Node.Kind == yaml.ScalarNode {
+			b.WriteString(result.Node.Value + "\n")
+			continue
+		}
+		value, err := jsonwriter.Marshal(result.Node)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,368 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/conversions"
+	"github.com/google/gnostic/diff"
+	"github.com/google/gnostic/findings"
+	discovery_v1 "github.com/google/gnostic/discovery"
+	"github.com/google/gnostic/jsonwriter"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+const defaultServeAddress = "localhost:8080"
+
+// maxRequestBytes limits the size of request bodies.
+const maxRequestBytes = 32 << 20
+
+// HandlerOptions control how the handlers returned by NewHandlerWithOptions
+// compile posted descriptions.
+type HandlerOptions struct {
+	// Limits restrict the resources used to read posted descriptions and
+	// any files that they reference.
+	Limits compiler.Limits
+	// FetchPolicy restricts the remote files that are fetched while posted
+	// descriptions are compiled.
+	FetchPolicy compiler.FetchPolicy
+}
+
+// DefaultHandlerOptions returns the options used by NewHandler. They limit
+// the size of posted descriptions, including the nodes added by expanding
+// YAML aliases, and don't allow any remote files to be fetched.
+func DefaultHandlerOptions() HandlerOptions {
+	return HandlerOptions{
+		Limits: compiler.Limits{
+			MaxDocumentBytes: maxRequestBytes,
+			MaxRefDepth:      32,
+			MaxNodes:         1 << 20,
+			Timeout:          30 * time.Second,
+		},
+		FetchPolicy: compiler.FetchPolicy{BlockAll: true},
+	}
+}
+
+// Run an HTTP server with the endpoints of NewHandler.
+func (g *Gnostic) serve() error {
+	address := defaultServeAddress
+	for _, arg := range g.args[2:] {
+		if strings.HasPrefix(arg, "--addr=") {
+			address = strings.TrimPrefix(arg, "--addr=")
+		} else {
+			return NewUsageError(fmt.Sprintf("unknown option for serve: %s", arg))
+		}
+	}
+	log.Printf("Serving on %s", address)
+	return http.ListenAndServe(address, NewHandler())
+}
+
+// NewHandler returns an HTTP handler that compiles, validates, converts,
+// and compares API descriptions, so that services can use gnostic without
+// running it as a separate process. Descriptions are posted as request
+// bodies in JSON or YAML.
+//
+//	POST /v1/compile?format=pb|text|json|yaml
+//	  Compile a description and return its protocol buffer or a normalized
+//	  description. Compilation errors are returned with status 422.
+//	POST /v1/validate?format=json|sarif
+//	  Return {"valid": BOOL, "format": FORMAT, "errors": [MESSAGE...]}, or
+//	  a SARIF log with a result for each error.
+//	POST /v1/convert?to=openapi2|openapi3&format=yaml|json|pb
+//	  Convert an OpenAPI or Discovery description to another version of
+//	  OpenAPI. OpenAPI descriptions are converted to the other version by
+//	  default.
+//	POST /v1/diff?format=json|sarif
+//	  Compare the descriptions in {"old": TEXT, "new": TEXT} and return
+//	  {"changes": [CHANGE...], "breaking": BOOL}, or a SARIF log with a
+//	  result for each change. Descriptions that are not OpenAPI v3 are
+//	  converted before they are compared.
+//
+// Other errors are returned as {"error": MESSAGE}.
+//
+// Descriptions are compiled with DefaultHandlerOptions.
+func NewHandler() http.Handler {
+	return NewHandlerWithOptions(DefaultHandlerOptions())
+}
+
+// NewHandlerWithOptions returns a handler like the one returned by
+// NewHandler that compiles descriptions with the given options.
+func NewHandlerWithOptions(opts HandlerOptions) http.Handler {
+	h := &handler{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/compile", h.handleCompile)
+	mux.HandleFunc("/v1/validate", h.handleValidate)
+	mux.HandleFunc("/v1/convert", h.handleConvert)
+	mux.HandleFunc("/v1/diff", h.handleDiff)
+	return mux
+}
+
+type handler struct {
+	opts HandlerOptions
+}
+
+// Compile a description that was read from a request. Compilations that
+// take longer than the Timeout of the handler's limits are stopped.
+func (h *handler) compileBytes(ctx context.Context, bytes []byte) (proto.Message, int, error) {
+	g := NewGnostic(nil)
+	ctx, cancel := compiler.WithTimeout(compiler.WithFetchPolicy(compiler.WithLimits(ctx, h.opts.Limits), h.opts.FetchPolicy))
+	defer cancel()
+	g.ctx = ctx
+	message, err := g.readOpenAPIText(bytes)
+	if h.opts.Limits.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, g.sourceFormat, fmt.Errorf("compilation timed out after %s", h.opts.Limits.Timeout)
+	}
+	return message, g.sourceFormat, err
+}
+
+func sourceFormatName(sourceFormat int) string {
+	switch sourceFormat {
+	case SourceFormatOpenAPI2:
+		return "openapi2"
+	case SourceFormatOpenAPI3:
+		return "openapi3"
+	case SourceFormatDiscovery:
+		return "discovery"
+	}
+	return ""
+}
+
+// errorMessages returns the messages of an error and of the errors in
+// error groups.
+func errorMessages(err error) []string {
+	messages := make([]string, 0)
+	if group, ok := err.(*compiler.ErrorGroup); ok {
+		for _, e := range group.Errors {
+			messages = append(messages, errorMessages(e)...)
+		}
+	} else if err != nil {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeSARIFResponse(w http.ResponseWriter, result []*findings.Finding) {
+	w.Header().Set("Content-Type", "application/sarif+json")
+	w.WriteHeader(http.StatusOK)
+	findings.WriteSARIF(w, result)
+}
+
+func writeErrorResponse(w http.ResponseWriter, status int, err error) {
+	writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+}
+
+// Read the body of a POST request. If the request can't be read, an
+// error response is written and false is returned.
+func readRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrorResponse(w, http.StatusMethodNotAllowed, errors.New("descriptions must be posted"))
+		return nil, false
+	}
+	bytes, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, err)
+		return nil, false
+	}
+	return bytes, true
+}
+
+// Marshal a compiled description in a format named in a request.
+func marshalMessage(message proto.Message, format string) ([]byte, string, error) {
+	switch format {
+	case "pb":
+		bytes, err := proto.Marshal(message)
+		return bytes, "application/x-protobuf", err
+	case "text":
+		return []byte(proto.MarshalTextString(message)), "text/plain", nil
+	case "json", "yaml":
+		var rawInfo *yaml.Node
+		switch document := message.(type) {
+		case *openapi_v2.Document:
+			rawInfo = document.ToRawInfo()
+		case *openapi_v3.Document:
+			rawInfo = document.ToRawInfo()
+		case *discovery_v1.Document:
+			rawInfo = document.ToRawInfo()
+		}
+		rawInfo = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{rawInfo}}
+		if format == "json" {
+			bytes, err := jsonwriter.Marshal(rawInfo)
+			return bytes, "application/json", err
+		}
+		bytes, err := yaml.Marshal(rawInfo)
+		return bytes, "application/yaml", err
+	}
+	return nil, "", fmt.Errorf("unknown format %q", format)
+}
+
+func writeMessageResponse(w http.ResponseWriter, message proto.Message, format string) {
+	bytes, contentType, err := marshalMessage(message, format)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(bytes)
+}
+
+func (h *handler) handleCompile(w http.ResponseWriter, r *http.Request) {
+	bytes, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+	message, _, err := h.compileBytes(r.Context(), bytes)
+	if err != nil {
+		writeJSONResponse(w, http.StatusUnprocessableEntity, map[string][]string{"errors": errorMessages(err)})
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "pb"
+	}
+	writeMessageResponse(w, message, format)
+}
+
+func (h *handler) handleValidate(w http.ResponseWriter, r *http.Request) {
+	bytes, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+	_, sourceFormat, err := h.compileBytes(r.Context(), bytes)
+	if r.URL.Query().Get("format") == "sarif" {
+		writeSARIFResponse(w, findings.FromError("", err))
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, struct {
+		Valid  bool     `json:"valid"`
+		Format string   `json:"format,omitempty"`
+		Errors []string `json:"errors"`
+	}{
+		Valid:  err == nil,
+		Format: sourceFormatName(sourceFormat),
+		Errors: errorMessages(err),
+	})
+}
+
+// Convert a compiled description to a version of OpenAPI.
+func convertMessage(message proto.Message, to string) (proto.Message, error) {
+	switch document := message.(type) {
+	case *openapi_v2.Document:
+		if to == "openapi2" {
+			return document, nil
+		}
+		return conversions.OpenAPIv3FromOpenAPIv2(document)
+	case *openapi_v3.Document:
+		if to == "openapi3" {
+			return document, nil
+		}
+		return conversions.OpenAPIv2FromOpenAPIv3(document)
+	case *discovery_v1.Document:
+		if to == "openapi2" {
+			return conversions.OpenAPIv2(document)
+		}
+		return conversions.OpenAPIv3(document)
+	}
+	return nil, errors.New("unsupported description")
+}
+
+func (h *handler) handleConvert(w http.ResponseWriter, r *http.Request) {
+	bytes, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+	to := r.URL.Query().Get("to")
+	if to != "" && to != "openapi2" && to != "openapi3" {
+		writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("unknown version %q", to))
+		return
+	}
+	message, _, err := h.compileBytes(r.Context(), bytes)
+	if err != nil {
+		writeJSONResponse(w, http.StatusUnprocessableEntity, map[string][]string{"errors": errorMessages(err)})
+		return
+	}
+	converted, err := convertMessage(message, to)
+	if err != nil {
+		writeErrorResponse(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+	writeMessageResponse(w, converted, format)
+}
+
+func (h *handler) handleDiff(w http.ResponseWriter, r *http.Request) {
+	bytes, ok := readRequestBody(w, r)
+	if !ok {
+		return
+	}
+	var request struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}
+	if err := json.Unmarshal(bytes, &request); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	documents := make([]*openapi_v3.Document, 0, 2)
+	for _, text := range []string{request.Old, request.New} {
+		message, _, err := h.compileBytes(r.Context(), []byte(text))
+		if err == nil {
+			message, err = convertMessage(message, "openapi3")
+		}
+		if err != nil {
+			writeJSONResponse(w, http.StatusUnprocessableEntity, map[string][]string{"errors": errorMessages(err)})
+			return
+		}
+		documents = append(documents, message.(*openapi_v3.Document))
+	}
+	changes := diff.Compare(documents[0], documents[1])
+	if r.URL.Query().Get("format") == "sarif" {
+		writeSARIFResponse(w, diff.Findings("", changes))
+		return
+	}
+	if changes == nil {
+		changes = make([]*diff.Change, 0)
+	}
+	writeJSONResponse(w, http.StatusOK, struct {
+		Changes  []*diff.Change `json:"changes"`
+		Breaking bool           `json:"breaking"`
+	}{
+		Changes:  changes,
+		Breaking: diff.HasBreakingChanges(changes),
+	})
+}
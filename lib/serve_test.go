@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/lib"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestServe(t *testing.T) {
+	server := httptest.NewServer(lib.NewHandler())
+	defer server.Close()
+	v2, err := ioutil.ReadFile("../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	v3, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	post := func(path string, body []byte) (int, []byte) {
+		response, err := http.Post(server.URL+path, "application/yaml", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer response.Body.Close()
+		result, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return response.StatusCode, result
+	}
+
+	// Compiled descriptions are returned as protocol buffers.
+	status, result := post("/v1/compile", v3)
+	if status != http.StatusOK {
+		t.Fatalf("compile failed: %d %s", status, result)
+	}
+	document := &openapi_v3.Document{}
+	if err := proto.Unmarshal(result, document); err != nil || document.Info.Title != "OpenAPI Petstore" {
+		t.Errorf("unexpected compiled document %+v %+v", document.Info, err)
+	}
+	status, result = post("/v1/compile", []byte("openapi: 3.0.0\n"))
+	if status != http.StatusUnprocessableEntity || !strings.Contains(string(result), "missing required properties") {
+		t.Errorf("unexpected response to an invalid description: %d %s", status, result)
+	}
+
+	// Validation reports the format and errors of descriptions.
+	var validation struct {
+		Valid  bool
+		Format string
+		Errors []string
+	}
+	_, result = post("/v1/validate", v2)
+	if err := json.Unmarshal(result, &validation); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !validation.Valid || validation.Format != "openapi2" || len(validation.Errors) != 0 {
+		t.Errorf("unexpected validation %s", result)
+	}
+	_, result = post("/v1/validate", []byte("swagger: 2.0\npaths: {}\n"))
+	if err := json.Unmarshal(result, &validation); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if validation.Valid || len(validation.Errors) == 0 {
+		t.Errorf("unexpected validation %s", result)
+	}
+
+	// OpenAPI v2 descriptions are converted to v3 by default.
+	status, result = post("/v1/convert?format=json", v2)
+	if status != http.StatusOK {
+		t.Fatalf("convert failed: %d %s", status, result)
+	}
+	converted, err := openapi_v3.ParseDocument(result)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if converted.Servers[0].Url != "http://petstore.swagger.io/v1" || len(converted.Paths.Path) != 2 {
+		t.Errorf("unexpected converted document %s", result)
+	}
+
+	// Changes are reported between versions of OpenAPI.
+	request, err := json.Marshal(map[string]string{"old": string(v2), "new": string(v3)})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	status, result = post("/v1/diff", request)
+	if status != http.StatusOK {
+		t.Fatalf("diff failed: %d %s", status, result)
+	}
+	var changes struct {
+		Changes  []map[string]interface{}
+		Breaking bool
+	}
+	if err := json.Unmarshal(result, &changes); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if changes.Changes == nil || changes.Breaking {
+		t.Errorf("unexpected changes %s", result)
+	}
+
+	// Descriptions must be posted.
+	response, err := http.Get(server.URL + "/v1/validate")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, response.StatusCode)
+	}
+}
+
+func TestServeLimits(t *testing.T) {
+	post := func(handler http.Handler, body string) []string {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+		response, err := http.Post(server.URL+"/v1/validate", "application/yaml", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer response.Body.Close()
+		var validation struct {
+			Valid  bool
+			Errors []string
+		}
+		if err := json.NewDecoder(response.Body).Decode(&validation); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return validation.Errors
+	}
+	// Aliases can't expand posted descriptions without bound.
+	bomb := "openapi: 3.0.0\na: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for i, name := range []string{"b", "c", "d", "e", "f", "g"} {
+		previous := string("abcdefg"[i])
+		bomb += fmt.Sprintf("%s: &%s [*%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s, *%s]\n",
+			name, name, previous, previous, previous, previous, previous, previous, previous, previous, previous, previous)
+	}
+	errors := post(lib.NewHandler(), bomb)
+	if len(errors) != 1 || !strings.Contains(errors[0], "too large after expanding aliases") {
+		t.Errorf("expected the description to be rejected, got %v", errors)
+	}
+	// Handlers can be created with other limits.
+	opts := lib.DefaultHandlerOptions()
+	opts.Limits.MaxNodes = 5
+	errors = post(lib.NewHandlerWithOptions(opts), "swagger: '2.0'\ninfo: {title: t, version: v}\npaths: {}\n")
+	if len(errors) != 1 || !strings.Contains(errors[0], "more than 5 nodes") {
+		t.Errorf("expected the description to be rejected, got %v", errors)
+	}
+	// Compilations that take too long are stopped.
+	opts = lib.DefaultHandlerOptions()
+	opts.Limits.Timeout = time.Millisecond
+	errors = post(lib.NewHandlerWithOptions(opts), slowDescription())
+	if len(errors) != 1 || !strings.Contains(errors[0], "timed out after 1ms") {
+		t.Errorf("expected the compilation to time out, got %v", errors)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// Encodings of sources. Sources in the protobuf encodings are documents
+// that were compiled by gnostic and written with --pb-out, --textproto-out,
+// or --jsonpb-out.
+const (
+	sourceEncodingText      = "text"
+	sourceEncodingPB        = "pb"
+	sourceEncodingTextProto = "textproto"
+	sourceEncodingJSONPB    = "jsonpb"
+)
+
+// Get the encoding of a source from the --from-ENCODING options, from the
+// extension of its name, or by looking at its contents. Standard input is
+// JSON or YAML unless it looks like a protocol buffer. It returns an empty
+// string if the encoding is unknown.
+func (g *Gnostic) sourceEncodingOf(data []byte) string {
+	if g.sourceEncoding != "" {
+		return g.sourceEncoding
+	}
+	switch strings.ToLower(filepath.Ext(g.sourceName)) {
+	case ".json", ".yaml":
+		return sourceEncodingText
+	case ".pb":
+		return sourceEncodingPB
+	case ".textproto":
+		return sourceEncodingTextProto
+	case ".jsonpb":
+		return sourceEncodingJSONPB
+	}
+	if isBinary(data) {
+		return sourceEncodingPB
+	}
+	if bytes.HasPrefix(data, []byte("# proto-file:")) || bytes.HasPrefix(data, []byte("# proto-message:")) {
+		return sourceEncodingTextProto
+	}
+	if g.sourceName == compiler.StandardInput {
+		return sourceEncodingText
+	}
+	return ""
+}
+
+// Binary protocol buffers are rarely valid UTF-8 text without control
+// characters.
+func isBinary(data []byte) bool {
+	if !utf8.Valid(data) {
+		return true
+	}
+	for _, b := range data {
+		if b < 0x20 && b != '\t' && b != '\n' && b != '\r' {
+			return true
+		}
+	}
+	return false
+}
+
+// Read a source in any encoding without resolving or transforming it.
+func (g *Gnostic) readSource(data []byte) (proto.Message, error) {
+	switch g.sourceEncodingOf(data) {
+	case sourceEncodingPB:
+		return g.readOpenAPIBinary(data)
+	case sourceEncodingTextProto:
+		return g.readOpenAPIMessage(data, unmarshalTextProto)
+	case sourceEncodingJSONPB:
+		return g.readOpenAPIMessage(data, unmarshalJSONPB)
+	}
+	return g.readOpenAPIText(data)
+}
+
+// Compile a source in any encoding and resolve and transform it as
+// specified in the options. JSON and YAML sources are compiled with
+// compileText, which uses the cache.
+func (g *Gnostic) compileSource(data []byte) (proto.Message, error) {
+	switch g.sourceEncodingOf(data) {
+	case sourceEncodingPB, sourceEncodingTextProto, sourceEncodingJSONPB:
+		message, err := g.readSource(data)
+		if err != nil {
+			return nil, err
+		}
+		return g.resolveAndTransform(message)
+	}
+	return g.compileText(data)
+}
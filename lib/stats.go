@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"errors"
+	"os"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/metrics/stats"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// Compile a source and print a summary of its operations, schemas,
+// descriptions, and security schemes, as in "gnostic stats SOURCE".
+func (g *Gnostic) stats() error {
+	g.args = append(g.args[:1], g.args[2:]...)
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if g.sourceName == "" {
+		return NewUsageError("no input specified")
+	}
+	if g.errorOutputPath == "" {
+		g.errorOutputPath = "="
+	}
+	s, err := g.readStats()
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	return s.Write(os.Stdout)
+}
+
+func (g *Gnostic) readStats() (*stats.Stats, error) {
+	bytes, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return nil, err
+	}
+	message, err := g.compileSource(bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch document := message.(type) {
+	case *openapi_v2.Document:
+		return stats.NewStatsV2(document), nil
+	case *openapi_v3.Document:
+		return stats.NewStatsV3(document), nil
+	}
+	return nil, errors.New("stats can only be computed for OpenAPI documents")
+}
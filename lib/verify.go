@@ -0,0 +1,170 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+)
+
+var errVerificationFailed = errors.New("verification failed")
+
+// Verify the signature and provenance of a document that gnostic wrote, as
+// in "gnostic verify DOCUMENT [--key=KEYFILE] [--signature=FILE] [--offline]".
+// With a key, the signature of the document is verified. The digests of
+// the sources in the provenance of the document are compared with the
+// current sources unless --offline is given.
+func (g *Gnostic) verify() error {
+	keyPath := ""
+	signaturePath := ""
+	offline := false
+	sources := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		switch {
+		case strings.HasPrefix(arg, "--key="):
+			keyPath = strings.TrimPrefix(arg, "--key=")
+		case strings.HasPrefix(arg, "--signature="):
+			signaturePath = strings.TrimPrefix(arg, "--signature=")
+		case arg == "--offline":
+			offline = true
+		case strings.HasPrefix(arg, "-") && arg != compiler.StandardInput:
+			return NewUsageError(fmt.Sprintf("unknown option for verify: %s", arg))
+		default:
+			sources = append(sources, arg)
+		}
+	}
+	if len(sources) != 1 {
+		return NewUsageError("verify requires a document")
+	}
+	g.sourceName = sources[0]
+	if signaturePath == "" {
+		signaturePath = g.sourceName + ".sig"
+	}
+	compiler.ClearCaches()
+	payload, err := compiler.ReadBytesForFile(g.sourceName)
+	if err != nil {
+		return err
+	}
+	failed := false
+	if keyPath != "" {
+		signature, err := compiler.ReadBytesForFile(signaturePath)
+		if err != nil {
+			return err
+		}
+		if err = verifySignature(keyPath, payload, signature); err != nil {
+			fmt.Printf("signature: %s\n", err)
+			failed = true
+		} else {
+			fmt.Printf("signature: verified with %s\n", keyPath)
+		}
+	}
+	var root yaml.Node
+	if err = yaml.Unmarshal(payload, &root); err != nil {
+		return err
+	}
+	p, err := readProvenance(&root)
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s: %s", g.sourceName, provenanceExtension, err)
+	}
+	if p == nil {
+		if keyPath == "" {
+			return fmt.Errorf("%s has no %s extension", g.sourceName, provenanceExtension)
+		}
+	} else {
+		fmt.Printf("generator: %s %s\n", p.Generator, p.Version)
+		fmt.Printf("timestamp: %s\n", p.Timestamp)
+		for _, source := range p.Sources {
+			status := "not checked"
+			if !offline {
+				status = sourceStatus(source)
+				if status == "changed" {
+					failed = true
+				}
+			}
+			fmt.Printf("source: %s %s\n", source.URI, status)
+		}
+	}
+	if failed {
+		return errVerificationFailed
+	}
+	return nil
+}
+
+// sourceStatus compares the digest of a source with the digest of its
+// current contents.
+func sourceStatus(source *provenanceSource) string {
+	bytes, err := compiler.ReadBytesForFile(source.URI)
+	if err != nil {
+		return "unavailable"
+	}
+	sum := sha256.Sum256(bytes)
+	if hex.EncodeToString(sum[:]) != source.SHA256 {
+		return "changed"
+	}
+	return "unchanged"
+}
+
+// Verify a base64-encoded signature of a payload with the public key in a
+// PEM file. Signatures are verified as cosign verifies them, like the
+// signatures that signPayload makes.
+func verifySignature(keyPath string, payload, signature []byte) error {
+	keyBytes, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(keyBytes)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return fmt.Errorf("%s: no PEM-encoded public key", keyPath)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%s: %s", keyPath, err)
+	}
+	signatureBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+	digest := sha256.Sum256(payload)
+	valid := false
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], signatureBytes)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signatureBytes) == nil
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, payload, signatureBytes)
+	default:
+		return fmt.Errorf("%s: unsupported key type %T", keyPath, key)
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
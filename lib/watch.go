@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// How often watched files are checked for changes.
+const watchInterval = 100 * time.Millisecond
+
+// The size and modification time of a watched file, which are zero if the
+// file can't be read.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// Watch compiles the source as specified in the arguments of g, and then
+// compiles it again whenever the source or a local file that was read to
+// compile it changes, until ctx is done. Errors are written to the error
+// output and don't stop watching.
+func (g *Gnostic) Watch(ctx context.Context) error {
+	// As with Main, "resolve" replaces the references in the source.
+	if len(g.args) > 1 && g.args[1] == "resolve" {
+		g.dereference = true
+		g.args = append([]string{g.args[0]}, g.args[2:]...)
+	}
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	err = g.validateOptions()
+	if err != nil {
+		return err
+	}
+	return g.watchSource(ctx)
+}
+
+func (g *Gnostic) watchSource(ctx context.Context) error {
+	if g.sourceName == compiler.StandardInput || isURL(g.sourceName) {
+		return NewUsageError("--watch requires a local source")
+	}
+	stamps := make(map[string]fileStamp)
+	for {
+		g.artifacts = nil
+		start := time.Now()
+		if err := g.compile(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to compile %s, waiting for changes\n", g.sourceName)
+		} else {
+			fmt.Fprintf(os.Stderr, "Compiled %s in %s\n", g.sourceName, time.Since(start).Round(100*time.Microsecond))
+		}
+		// Files that are only read by a later compilation are watched
+		// from then on.
+		for _, name := range g.watchedFiles() {
+			if _, ok := stamps[name]; !ok {
+				stamps[name] = stampFile(name)
+			}
+		}
+		if err := waitForChanges(ctx, stamps); err != nil {
+			return nil
+		}
+		// Parsed files are cached by the names of files and of references
+		// to them, so they are all parsed again. Local files are always
+		// read again, but downloaded files are reused from the file cache.
+		compiler.ClearInfoCache()
+	}
+}
+
+// Get the names of the local files that were read to compile the source.
+func (g *Gnostic) watchedFiles() []string {
+	names := []string{g.sourceName}
+	for name := range compiler.FileDigests() {
+		if name != g.sourceName && name != compiler.StandardInput && !isURL(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+func stampFile(name string) fileStamp {
+	info, err := os.Stat(name)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}
+}
+
+// Wait until any of the files have changed, updating their stamps. An error
+// is returned when ctx is done.
+func waitForChanges(ctx context.Context, stamps map[string]fileStamp) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		changed := false
+		for name, stamp := range stamps {
+			if current := stampFile(name); current != stamp {
+				stamps[name] = current
+				changed = true
+			}
+		}
+		if changed {
+			return nil
+		}
+	}
+}
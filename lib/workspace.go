@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	workspace "github.com/google/gnostic/workspace"
+)
+
+// Compile related sources into a workspace and write it with the output
+// options, as in "gnostic workspace SOURCE... [OPTIONS]". Outputs in
+// directories are named after the first source.
+func (g *Gnostic) workspace() error {
+	args := []string{g.args[0]}
+	sources := make([]string, 0)
+	for _, arg := range g.args[2:] {
+		if strings.HasPrefix(arg, "-") && arg != compiler.StandardInput {
+			args = append(args, arg)
+		} else {
+			sources = append(sources, arg)
+		}
+	}
+	g.args = args
+
+	compiler.ClearCaches()
+	err := g.readOptions()
+	if err != nil {
+		return err
+	}
+	if len(sources) > 0 {
+		g.sourceName = sources[0]
+	}
+	err = g.validateOptions()
+	if err != nil {
+		return err
+	}
+	if g.yamlOutputPath != "" || g.jsonOutputPath != "" {
+		return NewUsageError("workspaces can't be written as JSON or YAML descriptions")
+	}
+	g.sourceFormat = SourceFormatWorkspace
+	ws, err := workspace.NewWorkspace(sources)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	err = g.writeOutputs(ws)
+	if err != nil {
+		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
+		return err
+	}
+	return nil
+}
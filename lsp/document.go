@@ -0,0 +1,266 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+)
+
+// A document is the text of an open file and the YAML tree that it is
+// parsed into. JSON documents are parsed as YAML.
+type document struct {
+	uri   string
+	path  string // the file name of the document
+	text  string
+	lines []string
+	root  *yaml.Node // nil if the text can't be parsed
+	index *compiler.LocationIndex
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{
+		uri:   uri,
+		path:  pathForURI(uri),
+		text:  text,
+		lines: strings.Split(text, "\n"),
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err == nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		d.root = node.Content[0]
+	}
+	d.index = compiler.NewLocationIndex(d.root)
+	return d
+}
+
+// Get the file name of a file URI. Other URIs are used as names.
+func pathForURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// Get the URI of a file name.
+func uriForPath(path string) string {
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		return path
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// Is this an OpenAPI v2 document?
+func (d *document) isOpenAPIv2() bool {
+	if d.root == nil || d.root.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		if d.root.Content[i].Value == "swagger" {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the number of characters that a scalar node occupies on its line.
+func width(node *yaml.Node) int {
+	n := utf8.RuneCountInString(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		n += 2
+	}
+	return n
+}
+
+// Get the range of a scalar node. Positions of YAML nodes are one-based.
+func nodeRange(node *yaml.Node) Range {
+	start := Position{Line: node.Line - 1, Character: node.Column - 1}
+	end := start
+	end.Character += width(node)
+	return Range{Start: start, End: end}
+}
+
+// Get the range of the rest of a line from a one-based position, which is
+// used for the positions of problems.
+func (d *document) lineRange(line, column int) Range {
+	if line < 1 {
+		line, column = 1, 1
+	}
+	start := Position{Line: line - 1, Character: column - 1}
+	end := start
+	if line-1 < len(d.lines) {
+		end.Character = utf8.RuneCountInString(strings.TrimRight(d.lines[line-1], " \r"))
+	}
+	if end.Character < start.Character {
+		end.Character = start.Character
+	}
+	return Range{Start: start, End: end}
+}
+
+// Get the range of a problem at a one-based position. The compiler reports
+// invalid properties at the objects that contain them, so these problems
+// are placed at the first of the keys that they name.
+func (d *document) problemRange(line, column int, message string) Range {
+	i := strings.Index(message, "has invalid propert")
+	if i < 0 || d.root == nil {
+		return d.lineRange(line, column)
+	}
+	names := message[i:]
+	if j := strings.Index(names, ": "); j >= 0 {
+		names = names[j+2:]
+	}
+	name := strings.Split(names, ", ")[0]
+	for _, pointer := range d.index.Pointers() {
+		if location, _ := d.index.Location(pointer); location.Line != line || location.Column != column {
+			continue
+		}
+		node, err := jsonpointer.Resolve(d.root, pointer)
+		if err != nil || node.Kind != yaml.MappingNode {
+			continue
+		}
+		for k := 0; k+1 < len(node.Content); k += 2 {
+			if node.Content[k].Value == name {
+				return nodeRange(node.Content[k])
+			}
+		}
+	}
+	return d.lineRange(line, column)
+}
+
+func contains(node *yaml.Node, p Position) bool {
+	r := nodeRange(node)
+	return node.Kind == yaml.ScalarNode && p.Line == r.Start.Line &&
+		p.Character >= r.Start.Character && p.Character <= r.End.Character
+}
+
+// A hit is the key or scalar value at a position.
+type hit struct {
+	pointer string     // the JSON pointer of the value
+	node    *yaml.Node // the key or value
+	key     bool       // true if the node is a key
+	parent  *yaml.Node // the mapping that contains the key or value
+}
+
+// Find the key or scalar value at a position.
+func (d *document) hitAt(p Position) *hit {
+	if d.root == nil {
+		return nil
+	}
+	return find("", d.root, nil, p)
+}
+
+func find(pointer string, node, parent *yaml.Node, p Position) *hit {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			member := jsonpointer.Append(pointer, key.Value)
+			if contains(key, p) {
+				return &hit{pointer: member, node: key, key: true, parent: node}
+			}
+			if h := find(member, value, node, p); h != nil {
+				return h
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if h := find(jsonpointer.Append(pointer, strconv.Itoa(i)), item, node, p); h != nil {
+				return h
+			}
+		}
+	case yaml.ScalarNode:
+		if contains(node, p) {
+			return &hit{pointer: pointer, node: node, parent: parent}
+		}
+	}
+	return nil
+}
+
+// Get the JSON pointer of the object where a key is being typed at a
+// position, and whether a key is being typed there. Objects are found by
+// indentation, so that this works for incomplete documents.
+func (d *document) objectAt(p Position) (string, bool) {
+	if p.Line >= len(d.lines) {
+		return "", false
+	}
+	line := []rune(d.lines[p.Line])
+	if p.Character < len(line) {
+		line = line[:p.Character]
+	}
+	prefix := string(line)
+	trimmed := strings.TrimLeft(prefix, " ")
+	indent := len(prefix) - len(trimmed)
+	item := false
+	if strings.HasPrefix(trimmed, "- ") {
+		indent += 2
+		trimmed = strings.TrimLeft(trimmed[2:], " ")
+		item = true
+	}
+	if strings.ContainsAny(trimmed, ":{}[]#") {
+		return "", false
+	}
+	// The object is the last value on an earlier line that is indented
+	// less than the key.
+	pointer := ""
+	for _, candidate := range d.index.Pointers() {
+		location, _ := d.index.Location(candidate)
+		if location.Line-1 >= p.Line {
+			break
+		}
+		if location.Column-1 < indent {
+			pointer = candidate
+		}
+	}
+	if item {
+		pointer = jsonpointer.Append(pointer, "-")
+	} else if node, err := jsonpointer.Resolve(d.root, pointer); err == nil && node.Kind == yaml.SequenceNode {
+		// Keys are being added to the last item before the position.
+		last := -1
+		for i, item := range node.Content {
+			if item.Line-1 <= p.Line {
+				last = i
+			}
+		}
+		pointer = jsonpointer.Append(pointer, strconv.Itoa(last))
+	}
+	return pointer, true
+}
+
+// Get the keys of the object at a JSON pointer.
+func (d *document) keys(pointer string) map[string]bool {
+	keys := make(map[string]bool)
+	if d.root == nil {
+		return keys
+	}
+	node, err := jsonpointer.Resolve(d.root, pointer)
+	if err != nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = true
+	}
+	return keys
+}
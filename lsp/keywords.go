@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/jsonschema"
+)
+
+// A vocabulary is the JSON schema of a version of OpenAPI, which gives
+// the keywords of each object of a description and their documentation.
+type vocabulary struct {
+	root *jsonschema.Schema
+}
+
+func newVocabulary(schemaBytes func() ([]byte, error)) (*vocabulary, error) {
+	b, err := schemaBytes()
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err = yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	return &vocabulary{root: jsonschema.NewSchemaFromObject(&node)}, nil
+}
+
+// A keyword is a property of an object in a description.
+type keyword struct {
+	name        string
+	kind        string // the type or definition of its values
+	description string
+}
+
+// Follow references to definitions. References to other schemas, like
+// the JSON Schema metaschema, are not followed.
+func (v *vocabulary) resolve(schema *jsonschema.Schema) *jsonschema.Schema {
+	for i := 0; schema != nil && schema.Ref != nil && i < 32; i++ {
+		name := strings.TrimPrefix(*schema.Ref, "#/definitions/")
+		if name == *schema.Ref {
+			return schema
+		}
+		definition := v.root.DefinitionWithName(name)
+		if definition == nil {
+			return schema
+		}
+		schema = definition
+	}
+	return schema
+}
+
+// Get the alternatives of a schema, which are the schema itself or, if it
+// is a oneOf or anyOf, each of the schemas that it combines.
+func (v *vocabulary) alternatives(schema *jsonschema.Schema) []*jsonschema.Schema {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+	var combined []*jsonschema.Schema
+	if schema.OneOf != nil {
+		combined = append(combined, *schema.OneOf...)
+	}
+	if schema.AnyOf != nil {
+		combined = append(combined, *schema.AnyOf...)
+	}
+	if len(combined) == 0 {
+		return []*jsonschema.Schema{schema}
+	}
+	result := make([]*jsonschema.Schema, 0)
+	for _, s := range combined {
+		result = append(result, v.alternatives(s)...)
+	}
+	return result
+}
+
+// Get the schema of the value of a member of an object or array.
+func (v *vocabulary) member(schema *jsonschema.Schema, token string) *jsonschema.Schema {
+	for _, s := range v.alternatives(schema) {
+		if s.Items != nil && s.Items.Schema != nil {
+			return s.Items.Schema
+		}
+		if p := s.PropertyWithName(token); p != nil {
+			return p
+		}
+		if s.PatternProperties != nil {
+			for _, p := range *s.PatternProperties {
+				if r, err := regexp.Compile(p.Name); err == nil && r.MatchString(token) {
+					return p.Value
+				}
+			}
+		}
+		if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			return s.AdditionalProperties.Schema
+		}
+	}
+	return nil
+}
+
+// Get the schema of the value at a path of tokens from the root of a
+// description, or nil if the path isn't described.
+func (v *vocabulary) schemaAt(tokens []string) *jsonschema.Schema {
+	schema := v.root
+	for _, token := range tokens {
+		if schema = v.member(schema, token); schema == nil {
+			return nil
+		}
+	}
+	return schema
+}
+
+// Get the keywords of the objects at a path of tokens, sorted by name.
+func (v *vocabulary) keywords(tokens []string) []*keyword {
+	schema := v.schemaAt(tokens)
+	if schema == nil {
+		return nil
+	}
+	keywords := make(map[string]*keyword)
+	for _, s := range v.alternatives(schema) {
+		if s.Properties == nil {
+			continue
+		}
+		for _, p := range *s.Properties {
+			if _, ok := keywords[p.Name]; !ok {
+				keywords[p.Name] = v.describe(p.Name, p.Value)
+			}
+		}
+	}
+	result := make([]*keyword, 0, len(keywords))
+	for _, k := range keywords {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// Describe the keyword of a property with its schema.
+func (v *vocabulary) describe(name string, schema *jsonschema.Schema) *keyword {
+	k := &keyword{name: name, kind: v.kind(schema)}
+	if schema.Description != nil {
+		k.description = *schema.Description
+	}
+	for _, s := range v.alternatives(schema) {
+		if k.description != "" {
+			break
+		}
+		if s.Description != nil {
+			k.description = *s.Description
+		}
+	}
+	return k
+}
+
+// Get the type of the values of a schema, or the name of the definition
+// that describes them.
+func (v *vocabulary) kind(schema *jsonschema.Schema) string {
+	if schema.Ref != nil {
+		return strings.TrimPrefix(*schema.Ref, "#/definitions/")
+	}
+	if schema.Type != nil && schema.Type.String != nil {
+		if *schema.Type.String == "array" && schema.Items != nil && schema.Items.Schema != nil {
+			return "array of " + v.kind(schema.Items.Schema)
+		}
+		return *schema.Type.String
+	}
+	if schema.OneOf != nil || schema.AnyOf != nil {
+		kinds := make([]string, 0)
+		for _, s := range append(schemas(schema.OneOf), schemas(schema.AnyOf)...) {
+			kinds = append(kinds, v.kind(s))
+		}
+		return strings.Join(kinds, " or ")
+	}
+	return ""
+}
+
+func schemas(s *[]*jsonschema.Schema) []*jsonschema.Schema {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
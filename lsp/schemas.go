@@ -0,0 +1,1262 @@
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package lsp
+
+import (
+	"encoding/base64"
+)
+
+func openAPIv2SchemaBytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(
+`ewogICJ0aXRsZSI6ICJBIEpTT04gU2NoZW1hIGZvciBTd2FnZ2VyIDIuMCBBUEkuIiwKICAiaWQiOiAi
+aHR0cDovL3N3YWdnZXIuaW8vdjIvc2NoZW1hLmpzb24jIiwKICAiJHNjaGVtYSI6ICJodHRwOi8vanNv
+bi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMiLAogICJ0eXBlIjogIm9iamVjdCIsCiAgInJlcXVp
+cmVkIjogWwogICAgInN3YWdnZXIiLAogICAgImluZm8iLAogICAgInBhdGhzIgogIF0sCiAgImFkZGl0
+aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgIl54LSI6
+IHsKICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAgICB9CiAgfSwK
+ICAicHJvcGVydGllcyI6IHsKICAgICJzd2FnZ2VyIjogewogICAgICAidHlwZSI6ICJzdHJpbmciLAog
+ICAgICAiZW51bSI6IFsKICAgICAgICAiMi4wIgogICAgICBdLAogICAgICAiZGVzY3JpcHRpb24iOiAi
+VGhlIFN3YWdnZXIgdmVyc2lvbiBvZiB0aGlzIGRvY3VtZW50LiIKICAgIH0sCiAgICAiaW5mbyI6IHsK
+ICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9pbmZvIgogICAgfSwKICAgICJob3N0IjogewogICAg
+ICAidHlwZSI6ICJzdHJpbmciLAogICAgICAicGF0dGVybiI6ICJeW157fS8gOlxcXFxdKyg/OjpcXGQr
+KT8kIiwKICAgICAgImRlc2NyaXB0aW9uIjogIlRoZSBob3N0IChuYW1lIG9yIGlwKSBvZiB0aGUgQVBJ
+LiBFeGFtcGxlOiAnc3dhZ2dlci5pbyciCiAgICB9LAogICAgImJhc2VQYXRoIjogewogICAgICAidHlw
+ZSI6ICJzdHJpbmciLAogICAgICAicGF0dGVybiI6ICJeLyIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJU
+aGUgYmFzZSBwYXRoIHRvIHRoZSBBUEkuIEV4YW1wbGU6ICcvYXBpJy4iCiAgICB9LAogICAgInNjaGVt
+ZXMiOiB7CiAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2NoZW1lc0xpc3QiCiAgICB9LAogICAg
+ImNvbnN1bWVzIjogewogICAgICAiZGVzY3JpcHRpb24iOiAiQSBsaXN0IG9mIE1JTUUgdHlwZXMgYWNj
+ZXB0ZWQgYnkgdGhlIEFQSS4iLAogICAgICAiYWxsT2YiOiBbCiAgICAgICAgewogICAgICAgICAgIiRy
+ZWYiOiAiIy9kZWZpbml0aW9ucy9tZWRpYVR5cGVMaXN0IgogICAgICAgIH0KICAgICAgXQogICAgfSwK
+ICAgICJwcm9kdWNlcyI6IHsKICAgICAgImRlc2NyaXB0aW9uIjogIkEgbGlzdCBvZiBNSU1FIHR5cGVz
+IHRoZSBBUEkgY2FuIHByb2R1Y2UuIiwKICAgICAgImFsbE9mIjogWwogICAgICAgIHsKICAgICAgICAg
+ICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWVkaWFUeXBlTGlzdCIKICAgICAgICB9CiAgICAgIF0KICAg
+IH0sCiAgICAicGF0aHMiOiB7CiAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcGF0aHMiCiAgICB9
+LAogICAgImRlZmluaXRpb25zIjogewogICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2RlZmluaXRp
+b25zIgogICAgfSwKICAgICJwYXJhbWV0ZXJzIjogewogICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L3BhcmFtZXRlckRlZmluaXRpb25zIgogICAgfSwKICAgICJyZXNwb25zZXMiOiB7CiAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvcmVzcG9uc2VEZWZpbml0aW9ucyIKICAgIH0sCiAgICAic2VjdXJpdHki
+OiB7CiAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2VjdXJpdHkiCiAgICB9LAogICAgInNlY3Vy
+aXR5RGVmaW5pdGlvbnMiOiB7CiAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2VjdXJpdHlEZWZp
+bml0aW9ucyIKICAgIH0sCiAgICAidGFncyI6IHsKICAgICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAi
+aXRlbXMiOiB7CiAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy90YWciCiAgICAgIH0sCiAgICAg
+ICJ1bmlxdWVJdGVtcyI6IHRydWUKICAgIH0sCiAgICAiZXh0ZXJuYWxEb2NzIjogewogICAgICAiJHJl
+ZiI6ICIjL2RlZmluaXRpb25zL2V4dGVybmFsRG9jcyIKICAgIH0KICB9LAogICJkZWZpbml0aW9ucyI6
+IHsKICAgICJpbmZvIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24i
+OiAiR2VuZXJhbCBpbmZvcm1hdGlvbiBhYm91dCB0aGUgQVBJLiIsCiAgICAgICJyZXF1aXJlZCI6IFsK
+ICAgICAgICAidmVyc2lvbiIsCiAgICAgICAgInRpdGxlIgogICAgICBdLAogICAgICAiYWRkaXRpb25h
+bFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJe
+eC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAg
+ICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJ0aXRsZSI6IHsKICAg
+ICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZGVzY3JpcHRpb24iOiAiQSB1bmlxdWUg
+YW5kIHByZWNpc2UgdGl0bGUgb2YgdGhlIEFQSS4iCiAgICAgICAgfSwKICAgICAgICAidmVyc2lvbiI6
+IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZGVzY3JpcHRpb24iOiAiQSBz
+ZW1hbnRpYyB2ZXJzaW9uIG51bWJlciBvZiB0aGUgQVBJLiIKICAgICAgICB9LAogICAgICAgICJkZXNj
+cmlwdGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZGVzY3JpcHRp
+b24iOiAiQSBsb25nZXIgZGVzY3JpcHRpb24gb2YgdGhlIEFQSS4gU2hvdWxkIGJlIGRpZmZlcmVudCBm
+cm9tIHRoZSB0aXRsZS4gIEdpdEh1YiBGbGF2b3JlZCBNYXJrZG93biBpcyBhbGxvd2VkLiIKICAgICAg
+ICB9LAogICAgICAgICJ0ZXJtc09mU2VydmljZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIs
+CiAgICAgICAgICAiZGVzY3JpcHRpb24iOiAiVGhlIHRlcm1zIG9mIHNlcnZpY2UgZm9yIHRoZSBBUEku
+IgogICAgICAgIH0sCiAgICAgICAgImNvbnRhY3QiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL2NvbnRhY3QiCiAgICAgICAgfSwKICAgICAgICAibGljZW5zZSI6IHsKICAgICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvbGljZW5zZSIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAi
+Y29udGFjdCI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkNv
+bnRhY3QgaW5mb3JtYXRpb24gZm9yIHRoZSBvd25lcnMgb2YgdGhlIEFQSS4iLAogICAgICAiYWRkaXRp
+b25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgIm5hbWUi
+OiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIlRo
+ZSBpZGVudGlmeWluZyBuYW1lIG9mIHRoZSBjb250YWN0IHBlcnNvbi9vcmdhbml6YXRpb24uIgogICAg
+ICAgIH0sCiAgICAgICAgInVybCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAg
+ICAiZGVzY3JpcHRpb24iOiAiVGhlIFVSTCBwb2ludGluZyB0byB0aGUgY29udGFjdCBpbmZvcm1hdGlv
+bi4iLAogICAgICAgICAgImZvcm1hdCI6ICJ1cmkiCiAgICAgICAgfSwKICAgICAgICAiZW1haWwiOiB7
+CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIlRoZSBl
+bWFpbCBhZGRyZXNzIG9mIHRoZSBjb250YWN0IHBlcnNvbi9vcmdhbml6YXRpb24uIiwKICAgICAgICAg
+ICJmb3JtYXQiOiAiZW1haWwiCiAgICAgICAgfQogICAgICB9LAogICAgICAicGF0dGVyblByb3BlcnRp
+ZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdmVu
+ZG9yRXh0ZW5zaW9uIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJsaWNlbnNlIjogewogICAg
+ICAidHlwZSI6ICJvYmplY3QiLAogICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgIm5hbWUiCiAgICAg
+IF0sCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicHJvcGVydGllcyI6
+IHsKICAgICAgICAibmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAi
+ZGVzY3JpcHRpb24iOiAiVGhlIG5hbWUgb2YgdGhlIGxpY2Vuc2UgdHlwZS4gSXQncyBlbmNvdXJhZ2Vk
+IHRvIHVzZSBhbiBPU0kgY29tcGF0aWJsZSBsaWNlbnNlLiIKICAgICAgICB9LAogICAgICAgICJ1cmwi
+OiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIlRo
+ZSBVUkwgcG9pbnRpbmcgdG8gdGhlIGxpY2Vuc2UuIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgog
+ICAgICAgIH0KICAgICAgfSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0i
+OiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAg
+ICB9CiAgICAgIH0KICAgIH0sCiAgICAicGF0aHMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAg
+ICAgICJkZXNjcmlwdGlvbiI6ICJSZWxhdGl2ZSBwYXRocyB0byB0aGUgaW5kaXZpZHVhbCBlbmRwb2lu
+dHMuIFRoZXkgbXVzdCBiZSByZWxhdGl2ZSB0byB0aGUgJ2Jhc2VQYXRoJy4iLAogICAgICAicGF0dGVy
+blByb3BlcnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvdmVuZG9yRXh0ZW5zaW9uIgogICAgICAgIH0sCiAgICAgICAgIl4vIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXRoSXRlbSIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJh
+ZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlCiAgICB9LAogICAgImRlZmluaXRpb25zIjogewogICAg
+ICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zY2hlbWEiCiAgICAgIH0sCiAgICAgICJkZXNjcmlwdGlvbiI6
+ICJPbmUgb3IgbW9yZSBKU09OIG9iamVjdHMgZGVzY3JpYmluZyB0aGUgc2NoZW1hcyBiZWluZyBjb25z
+dW1lZCBhbmQgcHJvZHVjZWQgYnkgdGhlIEFQSS4iCiAgICB9LAogICAgInBhcmFtZXRlckRlZmluaXRp
+b25zIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMi
+OiB7CiAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXJhbWV0ZXIiCiAgICAgIH0sCiAgICAg
+ICJkZXNjcmlwdGlvbiI6ICJPbmUgb3IgbW9yZSBKU09OIHJlcHJlc2VudGF0aW9ucyBmb3IgcGFyYW1l
+dGVycyIKICAgIH0sCiAgICAicmVzcG9uc2VEZWZpbml0aW9ucyI6IHsKICAgICAgInR5cGUiOiAib2Jq
+ZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogIiMvZGVm
+aW5pdGlvbnMvcmVzcG9uc2UiCiAgICAgIH0sCiAgICAgICJkZXNjcmlwdGlvbiI6ICJPbmUgb3IgbW9y
+ZSBKU09OIHJlcHJlc2VudGF0aW9ucyBmb3IgcmVzcG9uc2VzIgogICAgfSwKICAgICJleHRlcm5hbERv
+Y3MiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6
+IGZhbHNlLAogICAgICAiZGVzY3JpcHRpb24iOiAiaW5mb3JtYXRpb24gYWJvdXQgZXh0ZXJuYWwgZG9j
+dW1lbnRhdGlvbiIsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAidXJsIgogICAgICBdLAogICAg
+ICAicHJvcGVydGllcyI6IHsKICAgICAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICAgICAidHlwZSI6
+ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAidXJsIjogewogICAgICAgICAgInR5cGUiOiAic3Ry
+aW5nIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgogICAgICAgIH0KICAgICAgfSwKICAgICAgInBh
+dHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiZXhh
+bXBsZXMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGll
+cyI6IHRydWUKICAgIH0sCiAgICAibWltZVR5cGUiOiB7CiAgICAgICJ0eXBlIjogInN0cmluZyIsCiAg
+ICAgICJkZXNjcmlwdGlvbiI6ICJUaGUgTUlNRSB0eXBlIG9mIHRoZSBIVFRQIG1lc3NhZ2UuIgogICAg
+fSwKICAgICJvcGVyYXRpb24iOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJyZXF1aXJl
+ZCI6IFsKICAgICAgICAicmVzcG9uc2VzIgogICAgICBdLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRp
+ZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAg
+ICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJ0YWdzIjogewogICAgICAgICAgInR5
+cGUiOiAiYXJyYXkiLAogICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgICAidHlwZSI6ICJzdHJp
+bmciCiAgICAgICAgICB9LAogICAgICAgICAgInVuaXF1ZUl0ZW1zIjogdHJ1ZQogICAgICAgIH0sCiAg
+ICAgICAgInN1bW1hcnkiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRl
+c2NyaXB0aW9uIjogIkEgYnJpZWYgc3VtbWFyeSBvZiB0aGUgb3BlcmF0aW9uLiIKICAgICAgICB9LAog
+ICAgICAgICJkZXNjcmlwdGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAg
+ICAiZGVzY3JpcHRpb24iOiAiQSBsb25nZXIgZGVzY3JpcHRpb24gb2YgdGhlIG9wZXJhdGlvbiwgR2l0
+SHViIEZsYXZvcmVkIE1hcmtkb3duIGlzIGFsbG93ZWQuIgogICAgICAgIH0sCiAgICAgICAgImV4dGVy
+bmFsRG9jcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXh0ZXJuYWxEb2NzIgog
+ICAgICAgIH0sCiAgICAgICAgIm9wZXJhdGlvbklkIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5n
+IiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6ICJBIHVuaXF1ZSBpZGVudGlmaWVyIG9mIHRoZSBvcGVy
+YXRpb24uIgogICAgICAgIH0sCiAgICAgICAgInByb2R1Y2VzIjogewogICAgICAgICAgImRlc2NyaXB0
+aW9uIjogIkEgbGlzdCBvZiBNSU1FIHR5cGVzIHRoZSBBUEkgY2FuIHByb2R1Y2UuIiwKICAgICAgICAg
+ICJhbGxPZiI6IFsKICAgICAgICAgICAgewogICAgICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlv
+bnMvbWVkaWFUeXBlTGlzdCIKICAgICAgICAgICAgfQogICAgICAgICAgXQogICAgICAgIH0sCiAgICAg
+ICAgImNvbnN1bWVzIjogewogICAgICAgICAgImRlc2NyaXB0aW9uIjogIkEgbGlzdCBvZiBNSU1FIHR5
+cGVzIHRoZSBBUEkgY2FuIGNvbnN1bWUuIiwKICAgICAgICAgICJhbGxPZiI6IFsKICAgICAgICAgICAg
+ewogICAgICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWVkaWFUeXBlTGlzdCIKICAgICAg
+ICAgICAgfQogICAgICAgICAgXQogICAgICAgIH0sCiAgICAgICAgInBhcmFtZXRlcnMiOiB7CiAgICAg
+ICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3BhcmFtZXRlcnNMaXN0IgogICAgICAgIH0sCiAgICAg
+ICAgInJlc3BvbnNlcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcmVzcG9uc2Vz
+IgogICAgICAgIH0sCiAgICAgICAgInNjaGVtZXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL3NjaGVtZXNMaXN0IgogICAgICAgIH0sCiAgICAgICAgImRlcHJlY2F0ZWQiOiB7CiAgICAg
+ICAgICAidHlwZSI6ICJib29sZWFuIiwKICAgICAgICAgICJkZWZhdWx0IjogZmFsc2UKICAgICAgICB9
+LAogICAgICAgICJzZWN1cml0eSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2Vj
+dXJpdHkiCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgInBhdGhJdGVtIjogewogICAgICAidHlw
+ZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBh
+dHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0
+aWVzIjogewogICAgICAgICIkcmVmIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAg
+IH0sCiAgICAgICAgImdldCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb3BlcmF0
+aW9uIgogICAgICAgIH0sCiAgICAgICAgInB1dCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvb3BlcmF0aW9uIgogICAgICAgIH0sCiAgICAgICAgInBvc3QiOiB7CiAgICAgICAgICAiJHJl
+ZiI6ICIjL2RlZmluaXRpb25zL29wZXJhdGlvbiIKICAgICAgICB9LAogICAgICAgICJkZWxldGUiOiB7
+CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29wZXJhdGlvbiIKICAgICAgICB9LAogICAg
+ICAgICJvcHRpb25zIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vcGVyYXRpb24i
+CiAgICAgICAgfSwKICAgICAgICAiaGVhZCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlv
+bnMvb3BlcmF0aW9uIgogICAgICAgIH0sCiAgICAgICAgInBhdGNoIjogewogICAgICAgICAgIiRyZWYi
+OiAiIy9kZWZpbml0aW9ucy9vcGVyYXRpb24iCiAgICAgICAgfSwKICAgICAgICAicGFyYW1ldGVycyI6
+IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcGFyYW1ldGVyc0xpc3QiCiAgICAgICAg
+fQogICAgICB9CiAgICB9LAogICAgInJlc3BvbnNlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwK
+ICAgICAgImRlc2NyaXB0aW9uIjogIlJlc3BvbnNlIG9iamVjdHMgbmFtZXMgY2FuIGVpdGhlciBiZSBh
+bnkgdmFsaWQgSFRUUCBzdGF0dXMgY29kZSBvciAnZGVmYXVsdCcuIiwKICAgICAgIm1pblByb3BlcnRp
+ZXMiOiAxLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Q
+cm9wZXJ0aWVzIjogewogICAgICAgICJeKFswLTldezN9KSR8XihkZWZhdWx0KSQiOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlVmFsdWUiCiAgICAgICAgfSwKICAgICAgICAi
+XngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAg
+ICAgICAgfQogICAgICB9LAogICAgICAibm90IjogewogICAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAg
+ICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICAgInBhdHRlcm5Qcm9wZXJ0
+aWVzIjogewogICAgICAgICAgIl54LSI6IHsKICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9u
+cy92ZW5kb3JFeHRlbnNpb24iCiAgICAgICAgICB9CiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAg
+InJlc3BvbnNlVmFsdWUiOiB7CiAgICAgICJvbmVPZiI6IFsKICAgICAgICB7CiAgICAgICAgICAiJHJl
+ZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlIgogICAgICAgIH0sCiAgICAgICAgewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9qc29uUmVmZXJlbmNlIgogICAgICAgIH0KICAgICAgXQogICAg
+fSwKICAgICJyZXNwb25zZSI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgInJlcXVpcmVk
+IjogWwogICAgICAgICJkZXNjcmlwdGlvbiIKICAgICAgXSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAg
+ICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0s
+CiAgICAgICAgInNjaGVtYSI6IHsKICAgICAgICAgICJvbmVPZiI6IFsKICAgICAgICAgICAgewogICAg
+ICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2NoZW1hIgogICAgICAgICAgICB9LAogICAg
+ICAgICAgICB7CiAgICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9maWxlU2NoZW1hIgog
+ICAgICAgICAgICB9CiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAiaGVhZGVycyI6IHsKICAg
+ICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvaGVhZGVycyIKICAgICAgICB9LAogICAgICAgICJl
+eGFtcGxlcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhhbXBsZXMiCiAgICAg
+ICAgfQogICAgICB9LAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBh
+dHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiaGVh
+ZGVycyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVz
+IjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvaGVhZGVyIgogICAgICB9CiAgICB9LAog
+ICAgImhlYWRlciI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9w
+ZXJ0aWVzIjogZmFsc2UsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAidHlwZSIKICAgICAgXSwK
+ICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgInR5cGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJz
+dHJpbmciLAogICAgICAgICAgImVudW0iOiBbCiAgICAgICAgICAgICJzdHJpbmciLAogICAgICAgICAg
+ICAibnVtYmVyIiwKICAgICAgICAgICAgImludGVnZXIiLAogICAgICAgICAgICAiYm9vbGVhbiIsCiAg
+ICAgICAgICAgICJhcnJheSIKICAgICAgICAgIF0KICAgICAgICB9LAogICAgICAgICJmb3JtYXQiOiB7
+CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAiaXRlbXMiOiB7CiAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ByaW1pdGl2ZXNJdGVtcyIKICAgICAgICB9LAog
+ICAgICAgICJjb2xsZWN0aW9uRm9ybWF0IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9u
+cy9jb2xsZWN0aW9uRm9ybWF0IgogICAgICAgIH0sCiAgICAgICAgImRlZmF1bHQiOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2RlZmF1bHQiCiAgICAgICAgfSwKICAgICAgICAibWF4aW11
+bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWF4aW11bSIKICAgICAgICB9LAog
+ICAgICAgICJleGNsdXNpdmVNYXhpbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9u
+cy9leGNsdXNpdmVNYXhpbXVtIgogICAgICAgIH0sCiAgICAgICAgIm1pbmltdW0iOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbmltdW0iCiAgICAgICAgfSwKICAgICAgICAiZXhjbHVz
+aXZlTWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhjbHVzaXZlTWlu
+aW11bSIKICAgICAgICB9LAogICAgICAgICJtYXhMZW5ndGgiOiB7CiAgICAgICAgICAiJHJlZiI6ICIj
+L2RlZmluaXRpb25zL21heExlbmd0aCIKICAgICAgICB9LAogICAgICAgICJtaW5MZW5ndGgiOiB7CiAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbkxlbmd0aCIKICAgICAgICB9LAogICAgICAg
+ICJwYXR0ZXJuIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXR0ZXJuIgogICAg
+ICAgIH0sCiAgICAgICAgIm1heEl0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9u
+cy9tYXhJdGVtcyIKICAgICAgICB9LAogICAgICAgICJtaW5JdGVtcyI6IHsKICAgICAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvbWluSXRlbXMiCiAgICAgICAgfSwKICAgICAgICAidW5pcXVlSXRlbXMi
+OiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3VuaXF1ZUl0ZW1zIgogICAgICAgIH0s
+CiAgICAgICAgImVudW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2VudW0iCiAg
+ICAgICAgfSwKICAgICAgICAibXVsdGlwbGVPZiI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvbXVsdGlwbGVPZiIKICAgICAgICB9LAogICAgICAgICJkZXNjcmlwdGlvbiI6IHsKICAgICAg
+ICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwYXR0ZXJuUHJvcGVy
+dGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92
+ZW5kb3JFeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgInZlbmRvckV4dGVuc2lv
+biI6IHsKICAgICAgImRlc2NyaXB0aW9uIjogIkFueSBwcm9wZXJ0eSBzdGFydGluZyB3aXRoIHgtIGlz
+IHZhbGlkLiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IHRydWUsCiAgICAgICJhZGRpdGlv
+bmFsSXRlbXMiOiB0cnVlCiAgICB9LAogICAgImJvZHlQYXJhbWV0ZXIiOiB7CiAgICAgICJ0eXBlIjog
+Im9iamVjdCIsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAibmFtZSIsCiAgICAgICAgImluIiwK
+ICAgICAgICAic2NoZW1hIgogICAgICBdLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAg
+ICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdmVuZG9yRXh0ZW5zaW9u
+IgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImRlc2NyaXB0
+aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6
+ICJBIGJyaWVmIGRlc2NyaXB0aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIFRoaXMgY291bGQgY29udGFpbiBl
+eGFtcGxlcyBvZiB1c2UuICBHaXRIdWIgRmxhdm9yZWQgTWFya2Rvd24gaXMgYWxsb3dlZC4iCiAgICAg
+ICAgfSwKICAgICAgICAibmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAg
+ICAiZGVzY3JpcHRpb24iOiAiVGhlIG5hbWUgb2YgdGhlIHBhcmFtZXRlci4iCiAgICAgICAgfSwKICAg
+ICAgICAiaW4iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0
+aW9uIjogIkRldGVybWluZXMgdGhlIGxvY2F0aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIiwKICAgICAgICAg
+ICJlbnVtIjogWwogICAgICAgICAgICAiYm9keSIKICAgICAgICAgIF0KICAgICAgICB9LAogICAgICAg
+ICJyZXF1aXJlZCI6IHsKICAgICAgICAgICJ0eXBlIjogImJvb2xlYW4iLAogICAgICAgICAgImRlc2Ny
+aXB0aW9uIjogIkRldGVybWluZXMgd2hldGhlciBvciBub3QgdGhpcyBwYXJhbWV0ZXIgaXMgcmVxdWly
+ZWQgb3Igb3B0aW9uYWwuIiwKICAgICAgICAgICJkZWZhdWx0IjogZmFsc2UKICAgICAgICB9LAogICAg
+ICAgICJzY2hlbWEiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NjaGVtYSIKICAg
+ICAgICB9CiAgICAgIH0sCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlCiAgICB9LAog
+ICAgImhlYWRlclBhcmFtZXRlclN1YlNjaGVtYSI6IHsKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVz
+IjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAg
+ICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAgICAgICAgfQogICAg
+ICB9LAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAicmVxdWlyZWQiOiB7CiAgICAgICAgICAi
+dHlwZSI6ICJib29sZWFuIiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6ICJEZXRlcm1pbmVzIHdoZXRo
+ZXIgb3Igbm90IHRoaXMgcGFyYW1ldGVyIGlzIHJlcXVpcmVkIG9yIG9wdGlvbmFsLiIsCiAgICAgICAg
+ICAiZGVmYXVsdCI6IGZhbHNlCiAgICAgICAgfSwKICAgICAgICAiaW4iOiB7CiAgICAgICAgICAidHlw
+ZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIkRldGVybWluZXMgdGhlIGxvY2F0
+aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIiwKICAgICAgICAgICJlbnVtIjogWwogICAgICAgICAgICAiaGVh
+ZGVyIgogICAgICAgICAgXQogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAg
+ICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6ICJBIGJyaWVmIGRlc2Ny
+aXB0aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIFRoaXMgY291bGQgY29udGFpbiBleGFtcGxlcyBvZiB1c2Uu
+ICBHaXRIdWIgRmxhdm9yZWQgTWFya2Rvd24gaXMgYWxsb3dlZC4iCiAgICAgICAgfSwKICAgICAgICAi
+bmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZGVzY3JpcHRpb24i
+OiAiVGhlIG5hbWUgb2YgdGhlIHBhcmFtZXRlci4iCiAgICAgICAgfSwKICAgICAgICAidHlwZSI6IHsK
+ICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZW51bSI6IFsKICAgICAgICAgICAg
+InN0cmluZyIsCiAgICAgICAgICAgICJudW1iZXIiLAogICAgICAgICAgICAiYm9vbGVhbiIsCiAgICAg
+ICAgICAgICJpbnRlZ2VyIiwKICAgICAgICAgICAgImFycmF5IgogICAgICAgICAgXQogICAgICAgIH0s
+CiAgICAgICAgImZvcm1hdCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAog
+ICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcHJpbWl0aXZl
+c0l0ZW1zIgogICAgICAgIH0sCiAgICAgICAgImNvbGxlY3Rpb25Gb3JtYXQiOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL2NvbGxlY3Rpb25Gb3JtYXQiCiAgICAgICAgfSwKICAgICAgICAi
+ZGVmYXVsdCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZGVmYXVsdCIKICAgICAg
+ICB9LAogICAgICAgICJtYXhpbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9t
+YXhpbXVtIgogICAgICAgIH0sCiAgICAgICAgImV4Y2x1c2l2ZU1heGltdW0iOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL2V4Y2x1c2l2ZU1heGltdW0iCiAgICAgICAgfSwKICAgICAgICAi
+bWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWluaW11bSIKICAgICAg
+ICB9LAogICAgICAgICJleGNsdXNpdmVNaW5pbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZp
+bml0aW9ucy9leGNsdXNpdmVNaW5pbXVtIgogICAgICAgIH0sCiAgICAgICAgIm1heExlbmd0aCI6IHsK
+ICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWF4TGVuZ3RoIgogICAgICAgIH0sCiAgICAg
+ICAgIm1pbkxlbmd0aCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWluTGVuZ3Ro
+IgogICAgICAgIH0sCiAgICAgICAgInBhdHRlcm4iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL3BhdHRlcm4iCiAgICAgICAgfSwKICAgICAgICAibWF4SXRlbXMiOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL21heEl0ZW1zIgogICAgICAgIH0sCiAgICAgICAgIm1pbkl0ZW1z
+IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9taW5JdGVtcyIKICAgICAgICB9LAog
+ICAgICAgICJ1bmlxdWVJdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdW5p
+cXVlSXRlbXMiCiAgICAgICAgfSwKICAgICAgICAiZW51bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMv
+ZGVmaW5pdGlvbnMvZW51bSIKICAgICAgICB9LAogICAgICAgICJtdWx0aXBsZU9mIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tdWx0aXBsZU9mIgogICAgICAgIH0KICAgICAgfQogICAg
+fSwKICAgICJxdWVyeVBhcmFtZXRlclN1YlNjaGVtYSI6IHsKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0
+aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewog
+ICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAgICAgICAgfQog
+ICAgICB9LAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAicmVxdWlyZWQiOiB7CiAgICAgICAg
+ICAidHlwZSI6ICJib29sZWFuIiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6ICJEZXRlcm1pbmVzIHdo
+ZXRoZXIgb3Igbm90IHRoaXMgcGFyYW1ldGVyIGlzIHJlcXVpcmVkIG9yIG9wdGlvbmFsLiIsCiAgICAg
+ICAgICAiZGVmYXVsdCI6IGZhbHNlCiAgICAgICAgfSwKICAgICAgICAiaW4iOiB7CiAgICAgICAgICAi
+dHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIkRldGVybWluZXMgdGhlIGxv
+Y2F0aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIiwKICAgICAgICAgICJlbnVtIjogWwogICAgICAgICAgICAi
+cXVlcnkiCiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAg
+ICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIkEgYnJpZWYgZGVz
+Y3JpcHRpb24gb2YgdGhlIHBhcmFtZXRlci4gVGhpcyBjb3VsZCBjb250YWluIGV4YW1wbGVzIG9mIHVz
+ZS4gIEdpdEh1YiBGbGF2b3JlZCBNYXJrZG93biBpcyBhbGxvd2VkLiIKICAgICAgICB9LAogICAgICAg
+ICJuYW1lIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJkZXNjcmlwdGlv
+biI6ICJUaGUgbmFtZSBvZiB0aGUgcGFyYW1ldGVyLiIKICAgICAgICB9LAogICAgICAgICJhbGxvd0Vt
+cHR5VmFsdWUiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIiwKICAgICAgICAgICJkZWZhdWx0
+IjogZmFsc2UsCiAgICAgICAgICAiZGVzY3JpcHRpb24iOiAiYWxsb3dzIHNlbmRpbmcgYSBwYXJhbWV0
+ZXIgYnkgbmFtZSBvbmx5IG9yIHdpdGggYW4gZW1wdHkgdmFsdWUuIgogICAgICAgIH0sCiAgICAgICAg
+InR5cGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImVudW0iOiBbCiAg
+ICAgICAgICAgICJzdHJpbmciLAogICAgICAgICAgICAibnVtYmVyIiwKICAgICAgICAgICAgImJvb2xl
+YW4iLAogICAgICAgICAgICAiaW50ZWdlciIsCiAgICAgICAgICAgICJhcnJheSIKICAgICAgICAgIF0K
+ICAgICAgICB9LAogICAgICAgICJmb3JtYXQiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAg
+ICAgICAgfSwKICAgICAgICAiaXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L3ByaW1pdGl2ZXNJdGVtcyIKICAgICAgICB9LAogICAgICAgICJjb2xsZWN0aW9uRm9ybWF0Ijogewog
+ICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9jb2xsZWN0aW9uRm9ybWF0V2l0aE11bHRpIgog
+ICAgICAgIH0sCiAgICAgICAgImRlZmF1bHQiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRp
+b25zL2RlZmF1bHQiCiAgICAgICAgfSwKICAgICAgICAibWF4aW11bSI6IHsKICAgICAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvbWF4aW11bSIKICAgICAgICB9LAogICAgICAgICJleGNsdXNpdmVNYXhp
+bXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGNsdXNpdmVNYXhpbXVtIgog
+ICAgICAgIH0sCiAgICAgICAgIm1pbmltdW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRp
+b25zL21pbmltdW0iCiAgICAgICAgfSwKICAgICAgICAiZXhjbHVzaXZlTWluaW11bSI6IHsKICAgICAg
+ICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhjbHVzaXZlTWluaW11bSIKICAgICAgICB9LAogICAg
+ICAgICJtYXhMZW5ndGgiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21heExlbmd0
+aCIKICAgICAgICB9LAogICAgICAgICJtaW5MZW5ndGgiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL21pbkxlbmd0aCIKICAgICAgICB9LAogICAgICAgICJwYXR0ZXJuIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXR0ZXJuIgogICAgICAgIH0sCiAgICAgICAgIm1heEl0
+ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tYXhJdGVtcyIKICAgICAgICB9
+LAogICAgICAgICJtaW5JdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWlu
+SXRlbXMiCiAgICAgICAgfSwKICAgICAgICAidW5pcXVlSXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3VuaXF1ZUl0ZW1zIgogICAgICAgIH0sCiAgICAgICAgImVudW0iOiB7CiAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2VudW0iCiAgICAgICAgfSwKICAgICAgICAibXVs
+dGlwbGVPZiI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbXVsdGlwbGVPZiIKICAg
+ICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiZm9ybURhdGFQYXJhbWV0ZXJTdWJTY2hlbWEiOiB7CiAg
+ICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRpZXMi
+OiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdmVuZG9y
+RXh0ZW5zaW9uIgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAg
+InJlcXVpcmVkIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIsCiAgICAgICAgICAiZGVzY3Jp
+cHRpb24iOiAiRGV0ZXJtaW5lcyB3aGV0aGVyIG9yIG5vdCB0aGlzIHBhcmFtZXRlciBpcyByZXF1aXJl
+ZCBvciBvcHRpb25hbC4iLAogICAgICAgICAgImRlZmF1bHQiOiBmYWxzZQogICAgICAgIH0sCiAgICAg
+ICAgImluIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJkZXNjcmlwdGlv
+biI6ICJEZXRlcm1pbmVzIHRoZSBsb2NhdGlvbiBvZiB0aGUgcGFyYW1ldGVyLiIsCiAgICAgICAgICAi
+ZW51bSI6IFsKICAgICAgICAgICAgImZvcm1EYXRhIgogICAgICAgICAgXQogICAgICAgIH0sCiAgICAg
+ICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJk
+ZXNjcmlwdGlvbiI6ICJBIGJyaWVmIGRlc2NyaXB0aW9uIG9mIHRoZSBwYXJhbWV0ZXIuIFRoaXMgY291
+bGQgY29udGFpbiBleGFtcGxlcyBvZiB1c2UuICBHaXRIdWIgRmxhdm9yZWQgTWFya2Rvd24gaXMgYWxs
+b3dlZC4iCiAgICAgICAgfSwKICAgICAgICAibmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmlu
+ZyIsCiAgICAgICAgICAiZGVzY3JpcHRpb24iOiAiVGhlIG5hbWUgb2YgdGhlIHBhcmFtZXRlci4iCiAg
+ICAgICAgfSwKICAgICAgICAiYWxsb3dFbXB0eVZhbHVlIjogewogICAgICAgICAgInR5cGUiOiAiYm9v
+bGVhbiIsCiAgICAgICAgICAiZGVmYXVsdCI6IGZhbHNlLAogICAgICAgICAgImRlc2NyaXB0aW9uIjog
+ImFsbG93cyBzZW5kaW5nIGEgcGFyYW1ldGVyIGJ5IG5hbWUgb25seSBvciB3aXRoIGFuIGVtcHR5IHZh
+bHVlLiIKICAgICAgICB9LAogICAgICAgICJ0eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5n
+IiwKICAgICAgICAgICJlbnVtIjogWwogICAgICAgICAgICAic3RyaW5nIiwKICAgICAgICAgICAgIm51
+bWJlciIsCiAgICAgICAgICAgICJib29sZWFuIiwKICAgICAgICAgICAgImludGVnZXIiLAogICAgICAg
+ICAgICAiYXJyYXkiLAogICAgICAgICAgICAiZmlsZSIKICAgICAgICAgIF0KICAgICAgICB9LAogICAg
+ICAgICJmb3JtYXQiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAg
+ICAiaXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ByaW1pdGl2ZXNJdGVt
+cyIKICAgICAgICB9LAogICAgICAgICJjb2xsZWN0aW9uRm9ybWF0IjogewogICAgICAgICAgIiRyZWYi
+OiAiIy9kZWZpbml0aW9ucy9jb2xsZWN0aW9uRm9ybWF0V2l0aE11bHRpIgogICAgICAgIH0sCiAgICAg
+ICAgImRlZmF1bHQiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2RlZmF1bHQiCiAg
+ICAgICAgfSwKICAgICAgICAibWF4aW11bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlv
+bnMvbWF4aW11bSIKICAgICAgICB9LAogICAgICAgICJleGNsdXNpdmVNYXhpbXVtIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGNsdXNpdmVNYXhpbXVtIgogICAgICAgIH0sCiAgICAg
+ICAgIm1pbmltdW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbmltdW0iCiAg
+ICAgICAgfSwKICAgICAgICAiZXhjbHVzaXZlTWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMv
+ZGVmaW5pdGlvbnMvZXhjbHVzaXZlTWluaW11bSIKICAgICAgICB9LAogICAgICAgICJtYXhMZW5ndGgi
+OiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21heExlbmd0aCIKICAgICAgICB9LAog
+ICAgICAgICJtaW5MZW5ndGgiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbkxl
+bmd0aCIKICAgICAgICB9LAogICAgICAgICJwYXR0ZXJuIjogewogICAgICAgICAgIiRyZWYiOiAiIy9k
+ZWZpbml0aW9ucy9wYXR0ZXJuIgogICAgICAgIH0sCiAgICAgICAgIm1heEl0ZW1zIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tYXhJdGVtcyIKICAgICAgICB9LAogICAgICAgICJtaW5J
+dGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWluSXRlbXMiCiAgICAgICAg
+fSwKICAgICAgICAidW5pcXVlSXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L3VuaXF1ZUl0ZW1zIgogICAgICAgIH0sCiAgICAgICAgImVudW0iOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL2VudW0iCiAgICAgICAgfSwKICAgICAgICAibXVsdGlwbGVPZiI6IHsKICAg
+ICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbXVsdGlwbGVPZiIKICAgICAgICB9CiAgICAgIH0K
+ICAgIH0sCiAgICAicGF0aFBhcmFtZXRlclN1YlNjaGVtYSI6IHsKICAgICAgImFkZGl0aW9uYWxQcm9w
+ZXJ0aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjog
+ewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAgICAgICAg
+fQogICAgICB9LAogICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgInJlcXVpcmVkIgogICAgICBdLAog
+ICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAicmVxdWlyZWQiOiB7CiAgICAgICAgICAidHlwZSI6
+ICJib29sZWFuIiwKICAgICAgICAgICJlbnVtIjogWwogICAgICAgICAgICB0cnVlCiAgICAgICAgICBd
+LAogICAgICAgICAgImRlc2NyaXB0aW9uIjogIkRldGVybWluZXMgd2hldGhlciBvciBub3QgdGhpcyBw
+YXJhbWV0ZXIgaXMgcmVxdWlyZWQgb3Igb3B0aW9uYWwuIgogICAgICAgIH0sCiAgICAgICAgImluIjog
+ewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJkZXNjcmlwdGlvbiI6ICJEZXRl
+cm1pbmVzIHRoZSBsb2NhdGlvbiBvZiB0aGUgcGFyYW1ldGVyLiIsCiAgICAgICAgICAiZW51bSI6IFsK
+ICAgICAgICAgICAgInBhdGgiCiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAiZGVzY3JpcHRp
+b24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImRlc2NyaXB0aW9uIjog
+IkEgYnJpZWYgZGVzY3JpcHRpb24gb2YgdGhlIHBhcmFtZXRlci4gVGhpcyBjb3VsZCBjb250YWluIGV4
+YW1wbGVzIG9mIHVzZS4gIEdpdEh1YiBGbGF2b3JlZCBNYXJrZG93biBpcyBhbGxvd2VkLiIKICAgICAg
+ICB9LAogICAgICAgICJuYW1lIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAg
+ICJkZXNjcmlwdGlvbiI6ICJUaGUgbmFtZSBvZiB0aGUgcGFyYW1ldGVyLiIKICAgICAgICB9LAogICAg
+ICAgICJ0eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJlbnVtIjog
+WwogICAgICAgICAgICAic3RyaW5nIiwKICAgICAgICAgICAgIm51bWJlciIsCiAgICAgICAgICAgICJi
+b29sZWFuIiwKICAgICAgICAgICAgImludGVnZXIiLAogICAgICAgICAgICAiYXJyYXkiCiAgICAgICAg
+ICBdCiAgICAgICAgfSwKICAgICAgICAiZm9ybWF0IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5n
+IgogICAgICAgIH0sCiAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0
+aW9ucy9wcmltaXRpdmVzSXRlbXMiCiAgICAgICAgfSwKICAgICAgICAiY29sbGVjdGlvbkZvcm1hdCI6
+IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvY29sbGVjdGlvbkZvcm1hdCIKICAgICAg
+ICB9LAogICAgICAgICJkZWZhdWx0IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9k
+ZWZhdWx0IgogICAgICAgIH0sCiAgICAgICAgIm1heGltdW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIj
+L2RlZmluaXRpb25zL21heGltdW0iCiAgICAgICAgfSwKICAgICAgICAiZXhjbHVzaXZlTWF4aW11bSI6
+IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhjbHVzaXZlTWF4aW11bSIKICAgICAg
+ICB9LAogICAgICAgICJtaW5pbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9t
+aW5pbXVtIgogICAgICAgIH0sCiAgICAgICAgImV4Y2x1c2l2ZU1pbmltdW0iOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL2V4Y2x1c2l2ZU1pbmltdW0iCiAgICAgICAgfSwKICAgICAgICAi
+bWF4TGVuZ3RoIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tYXhMZW5ndGgiCiAg
+ICAgICAgfSwKICAgICAgICAibWluTGVuZ3RoIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0
+aW9ucy9taW5MZW5ndGgiCiAgICAgICAgfSwKICAgICAgICAicGF0dGVybiI6IHsKICAgICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvcGF0dGVybiIKICAgICAgICB9LAogICAgICAgICJtYXhJdGVtcyI6
+IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWF4SXRlbXMiCiAgICAgICAgfSwKICAg
+ICAgICAibWluSXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbkl0ZW1z
+IgogICAgICAgIH0sCiAgICAgICAgInVuaXF1ZUl0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiIy9k
+ZWZpbml0aW9ucy91bmlxdWVJdGVtcyIKICAgICAgICB9LAogICAgICAgICJlbnVtIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9lbnVtIgogICAgICAgIH0sCiAgICAgICAgIm11bHRpcGxl
+T2YiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL211bHRpcGxlT2YiCiAgICAgICAg
+fQogICAgICB9CiAgICB9LAogICAgIm5vbkJvZHlQYXJhbWV0ZXIiOiB7CiAgICAgICJ0eXBlIjogIm9i
+amVjdCIsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAibmFtZSIsCiAgICAgICAgImluIiwKICAg
+ICAgICAidHlwZSIKICAgICAgXSwKICAgICAgIm9uZU9mIjogWwogICAgICAgIHsKICAgICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvaGVhZGVyUGFyYW1ldGVyU3ViU2NoZW1hIgogICAgICAgIH0sCiAg
+ICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9mb3JtRGF0YVBhcmFtZXRlclN1
+YlNjaGVtYSIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlv
+bnMvcXVlcnlQYXJhbWV0ZXJTdWJTY2hlbWEiCiAgICAgICAgfSwKICAgICAgICB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL3BhdGhQYXJhbWV0ZXJTdWJTY2hlbWEiCiAgICAgICAgfQogICAg
+ICBdCiAgICB9LAogICAgInBhcmFtZXRlciI6IHsKICAgICAgIm9uZU9mIjogWwogICAgICAgIHsKICAg
+ICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvYm9keVBhcmFtZXRlciIKICAgICAgICB9LAogICAg
+ICAgIHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbm9uQm9keVBhcmFtZXRlciIKICAg
+ICAgICB9CiAgICAgIF0KICAgIH0sCiAgICAic2NoZW1hIjogewogICAgICAidHlwZSI6ICJvYmplY3Qi
+LAogICAgICAiZGVzY3JpcHRpb24iOiAiQSBkZXRlcm1pbmlzdGljIHZlcnNpb24gb2YgYSBKU09OIFNj
+aGVtYSBvYmplY3QuIiwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7
+CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9
+CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogewogICAgICAgICAg
+InR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImZvcm1hdCI6IHsKICAgICAgICAgICJ0
+eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJ0aXRsZSI6IHsKICAgICAgICAgICIkcmVm
+IjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL3RpdGxl
+IgogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0
+cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvZGVzY3JpcHRpb24i
+CiAgICAgICAgfSwKICAgICAgICAiZGVmYXVsdCI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9q
+c29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL2RlZmF1bHQiCiAgICAgICAg
+fSwKICAgICAgICAibXVsdGlwbGVPZiI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNj
+aGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL211bHRpcGxlT2YiCiAgICAgICAgfSwK
+ICAgICAgICAibWF4aW11bSI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5v
+cmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL21heGltdW0iCiAgICAgICAgfSwKICAgICAgICAi
+ZXhjbHVzaXZlTWF4aW11bSI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5v
+cmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL2V4Y2x1c2l2ZU1heGltdW0iCiAgICAgICAgfSwK
+ICAgICAgICAibWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5v
+cmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL21pbmltdW0iCiAgICAgICAgfSwKICAgICAgICAi
+ZXhjbHVzaXZlTWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5v
+cmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL2V4Y2x1c2l2ZU1pbmltdW0iCiAgICAgICAgfSwK
+ICAgICAgICAibWF4TGVuZ3RoIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0cDovL2pzb24tc2NoZW1h
+Lm9yZy9kcmFmdC0wNC9zY2hlbWEjL2RlZmluaXRpb25zL3Bvc2l0aXZlSW50ZWdlciIKICAgICAgICB9
+LAogICAgICAgICJtaW5MZW5ndGgiOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hl
+bWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvZGVmaW5pdGlvbnMvcG9zaXRpdmVJbnRlZ2VyRGVmYXVsdDAi
+CiAgICAgICAgfSwKICAgICAgICAicGF0dGVybiI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9q
+c29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL3BhdHRlcm4iCiAgICAgICAg
+fSwKICAgICAgICAibWF4SXRlbXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hl
+bWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvZGVmaW5pdGlvbnMvcG9zaXRpdmVJbnRlZ2VyIgogICAgICAg
+IH0sCiAgICAgICAgIm1pbkl0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0cDovL2pzb24tc2No
+ZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL2RlZmluaXRpb25zL3Bvc2l0aXZlSW50ZWdlckRlZmF1bHQw
+IgogICAgICAgIH0sCiAgICAgICAgInVuaXF1ZUl0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0
+cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvdW5pcXVlSXRlbXMi
+CiAgICAgICAgfSwKICAgICAgICAibWF4UHJvcGVydGllcyI6IHsKICAgICAgICAgICIkcmVmIjogImh0
+dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9kZWZpbml0aW9ucy9wb3NpdGl2ZUlu
+dGVnZXIiCiAgICAgICAgfSwKICAgICAgICAibWluUHJvcGVydGllcyI6IHsKICAgICAgICAgICIkcmVm
+IjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9kZWZpbml0aW9ucy9wb3Np
+dGl2ZUludGVnZXJEZWZhdWx0MCIKICAgICAgICB9LAogICAgICAgICJyZXF1aXJlZCI6IHsKICAgICAg
+ICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9kZWZpbml0
+aW9ucy9zdHJpbmdBcnJheSIKICAgICAgICB9LAogICAgICAgICJlbnVtIjogewogICAgICAgICAgIiRy
+ZWYiOiAiaHR0cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvZW51
+bSIKICAgICAgICB9LAogICAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IHsKICAgICAgICAgICJv
+bmVPZiI6IFsKICAgICAgICAgICAgewogICAgICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+c2NoZW1hIgogICAgICAgICAgICB9LAogICAgICAgICAgICB7CiAgICAgICAgICAgICAgInR5cGUiOiAi
+Ym9vbGVhbiIKICAgICAgICAgICAgfQogICAgICAgICAgXSwKICAgICAgICAgICJkZWZhdWx0Ijoge30K
+ICAgICAgICB9LAogICAgICAgICJ0eXBlIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0cDovL2pzb24t
+c2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvdHlwZSIKICAgICAgICB9LAogICAg
+ICAgICJpdGVtcyI6IHsKICAgICAgICAgICJhbnlPZiI6IFsKICAgICAgICAgICAgewogICAgICAgICAg
+ICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2NoZW1hIgogICAgICAgICAgICB9LAogICAgICAgICAg
+ICB7CiAgICAgICAgICAgICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICAgICAgICJtaW5JdGVtcyI6
+IDEsCiAgICAgICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZp
+bml0aW9ucy9zY2hlbWEiCiAgICAgICAgICAgICAgfQogICAgICAgICAgICB9CiAgICAgICAgICBdLAog
+ICAgICAgICAgImRlZmF1bHQiOiB7fQogICAgICAgIH0sCiAgICAgICAgImFsbE9mIjogewogICAgICAg
+ICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICAgIm1pbkl0ZW1zIjogMSwKICAgICAgICAgICJpdGVt
+cyI6IHsKICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zY2hlbWEiCiAgICAgICAgICB9
+CiAgICAgICAgfSwKICAgICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAgICJ0eXBlIjogIm9iamVj
+dCIsCiAgICAgICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvc2NoZW1hIgogICAgICAgICAgfSwKICAgICAgICAgICJkZWZhdWx0Ijoge30K
+ICAgICAgICB9LAogICAgICAgICJkaXNjcmltaW5hdG9yIjogewogICAgICAgICAgInR5cGUiOiAic3Ry
+aW5nIgogICAgICAgIH0sCiAgICAgICAgInJlYWRPbmx5IjogewogICAgICAgICAgInR5cGUiOiAiYm9v
+bGVhbiIsCiAgICAgICAgICAiZGVmYXVsdCI6IGZhbHNlCiAgICAgICAgfSwKICAgICAgICAieG1sIjog
+ewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy94bWwiCiAgICAgICAgfSwKICAgICAgICAi
+ZXh0ZXJuYWxEb2NzIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leHRlcm5hbERv
+Y3MiCiAgICAgICAgfSwKICAgICAgICAiZXhhbXBsZSI6IHt9CiAgICAgIH0sCiAgICAgICJhZGRpdGlv
+bmFsUHJvcGVydGllcyI6IGZhbHNlCiAgICB9LAogICAgImZpbGVTY2hlbWEiOiB7CiAgICAgICJ0eXBl
+IjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJBIGRldGVybWluaXN0aWMgdmVyc2lvbiBv
+ZiBhIEpTT04gU2NoZW1hIG9iamVjdC4iLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAg
+ICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdmVuZG9yRXh0ZW5zaW9u
+IgogICAgICAgIH0KICAgICAgfSwKICAgICAgInJlcXVpcmVkIjogWwogICAgICAgICJ0eXBlIgogICAg
+ICBdLAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAiZm9ybWF0IjogewogICAgICAgICAgInR5
+cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgInRpdGxlIjogewogICAgICAgICAgIiRyZWYi
+OiAiaHR0cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvdGl0bGUi
+CiAgICAgICAgfSwKICAgICAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRw
+Oi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9kZXNjcmlwdGlvbiIK
+ICAgICAgICB9LAogICAgICAgICJkZWZhdWx0IjogewogICAgICAgICAgIiRyZWYiOiAiaHR0cDovL2pz
+b24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvZGVmYXVsdCIKICAgICAgICB9
+LAogICAgICAgICJyZXF1aXJlZCI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVt
+YS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9kZWZpbml0aW9ucy9zdHJpbmdBcnJheSIKICAgICAgICB9LAog
+ICAgICAgICJ0eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJlbnVt
+IjogWwogICAgICAgICAgICAiZmlsZSIKICAgICAgICAgIF0KICAgICAgICB9LAogICAgICAgICJyZWFk
+T25seSI6IHsKICAgICAgICAgICJ0eXBlIjogImJvb2xlYW4iLAogICAgICAgICAgImRlZmF1bHQiOiBm
+YWxzZQogICAgICAgIH0sCiAgICAgICAgImV4dGVybmFsRG9jcyI6IHsKICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvZXh0ZXJuYWxEb2NzIgogICAgICAgIH0sCiAgICAgICAgImV4YW1wbGUiOiB7
+fQogICAgICB9LAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZQogICAgfSwKICAgICJw
+cmltaXRpdmVzSXRlbXMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFs
+UHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAidHlwZSI6IHsK
+ICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZW51bSI6IFsKICAgICAgICAgICAg
+InN0cmluZyIsCiAgICAgICAgICAgICJudW1iZXIiLAogICAgICAgICAgICAiaW50ZWdlciIsCiAgICAg
+ICAgICAgICJib29sZWFuIiwKICAgICAgICAgICAgImFycmF5IgogICAgICAgICAgXQogICAgICAgIH0s
+CiAgICAgICAgImZvcm1hdCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAog
+ICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcHJpbWl0aXZl
+c0l0ZW1zIgogICAgICAgIH0sCiAgICAgICAgImNvbGxlY3Rpb25Gb3JtYXQiOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL2NvbGxlY3Rpb25Gb3JtYXQiCiAgICAgICAgfSwKICAgICAgICAi
+ZGVmYXVsdCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZGVmYXVsdCIKICAgICAg
+ICB9LAogICAgICAgICJtYXhpbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9t
+YXhpbXVtIgogICAgICAgIH0sCiAgICAgICAgImV4Y2x1c2l2ZU1heGltdW0iOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL2V4Y2x1c2l2ZU1heGltdW0iCiAgICAgICAgfSwKICAgICAgICAi
+bWluaW11bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWluaW11bSIKICAgICAg
+ICB9LAogICAgICAgICJleGNsdXNpdmVNaW5pbXVtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZp
+bml0aW9ucy9leGNsdXNpdmVNaW5pbXVtIgogICAgICAgIH0sCiAgICAgICAgIm1heExlbmd0aCI6IHsK
+ICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWF4TGVuZ3RoIgogICAgICAgIH0sCiAgICAg
+ICAgIm1pbkxlbmd0aCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWluTGVuZ3Ro
+IgogICAgICAgIH0sCiAgICAgICAgInBhdHRlcm4iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL3BhdHRlcm4iCiAgICAgICAgfSwKICAgICAgICAibWF4SXRlbXMiOiB7CiAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL21heEl0ZW1zIgogICAgICAgIH0sCiAgICAgICAgIm1pbkl0ZW1z
+IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9taW5JdGVtcyIKICAgICAgICB9LAog
+ICAgICAgICJ1bmlxdWVJdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdW5p
+cXVlSXRlbXMiCiAgICAgICAgfSwKICAgICAgICAiZW51bSI6IHsKICAgICAgICAgICIkcmVmIjogIiMv
+ZGVmaW5pdGlvbnMvZW51bSIKICAgICAgICB9LAogICAgICAgICJtdWx0aXBsZU9mIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tdWx0aXBsZU9mIgogICAgICAgIH0KICAgICAgfSwKICAg
+ICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAg
+ICAic2VjdXJpdHkiOiB7CiAgICAgICJ0eXBlIjogImFycmF5IiwKICAgICAgIml0ZW1zIjogewogICAg
+ICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2VjdXJpdHlSZXF1aXJlbWVudCIKICAgICAgfSwKICAg
+ICAgInVuaXF1ZUl0ZW1zIjogdHJ1ZQogICAgfSwKICAgICJzZWN1cml0eVJlcXVpcmVtZW50Ijogewog
+ICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAg
+ICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICJ0eXBlIjogInN0
+cmluZyIKICAgICAgICB9LAogICAgICAgICJ1bmlxdWVJdGVtcyI6IHRydWUKICAgICAgfQogICAgfSwK
+ICAgICJ4bWwiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVy
+dGllcyI6IGZhbHNlLAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAibmFtZSI6IHsKICAgICAg
+ICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJuYW1lc3BhY2UiOiB7CiAgICAg
+ICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAicHJlZml4IjogewogICAgICAg
+ICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImF0dHJpYnV0ZSI6IHsKICAgICAg
+ICAgICJ0eXBlIjogImJvb2xlYW4iLAogICAgICAgICAgImRlZmF1bHQiOiBmYWxzZQogICAgICAgIH0s
+CiAgICAgICAgIndyYXBwZWQiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIiwKICAgICAgICAg
+ICJkZWZhdWx0IjogZmFsc2UKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwYXR0ZXJuUHJvcGVydGll
+cyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5k
+b3JFeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgInRhZyI6IHsKICAgICAgInR5
+cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJy
+ZXF1aXJlZCI6IFsKICAgICAgICAibmFtZSIKICAgICAgXSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAg
+ICAgICAgIm5hbWUiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAg
+ICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAg
+ICAgICAiZXh0ZXJuYWxEb2NzIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leHRl
+cm5hbERvY3MiCiAgICAgICAgfQogICAgICB9LAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAg
+ICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdmVuZG9yRXh0ZW5z
+aW9uIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJzZWN1cml0eURlZmluaXRpb25zIjogewog
+ICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAg
+ICAgIm9uZU9mIjogWwogICAgICAgICAgewogICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L2Jhc2ljQXV0aGVudGljYXRpb25TZWN1cml0eSIKICAgICAgICAgIH0sCiAgICAgICAgICB7CiAgICAg
+ICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvYXBpS2V5U2VjdXJpdHkiCiAgICAgICAgICB9LAog
+ICAgICAgICAgewogICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29hdXRoMkltcGxpY2l0
+U2VjdXJpdHkiCiAgICAgICAgICB9LAogICAgICAgICAgewogICAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL29hdXRoMlBhc3N3b3JkU2VjdXJpdHkiCiAgICAgICAgICB9LAogICAgICAgICAgewog
+ICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29hdXRoMkFwcGxpY2F0aW9uU2VjdXJpdHki
+CiAgICAgICAgICB9LAogICAgICAgICAgewogICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L29hdXRoMkFjY2Vzc0NvZGVTZWN1cml0eSIKICAgICAgICAgIH0KICAgICAgICBdCiAgICAgIH0KICAg
+IH0sCiAgICAiYmFzaWNBdXRoZW50aWNhdGlvblNlY3VyaXR5IjogewogICAgICAidHlwZSI6ICJvYmpl
+Y3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInJlcXVpcmVkIjog
+WwogICAgICAgICJ0eXBlIgogICAgICBdLAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAidHlw
+ZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZW51bSI6IFsKICAgICAg
+ICAgICAgImJhc2ljIgogICAgICAgICAgXQogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjog
+ewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0KICAgICAgfSwKICAgICAgInBhdHRl
+cm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiYXBpS2V5
+U2VjdXJpdHkiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVy
+dGllcyI6IGZhbHNlLAogICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgInR5cGUiLAogICAgICAgICJu
+YW1lIiwKICAgICAgICAiaW4iCiAgICAgIF0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJ0
+eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJlbnVtIjogWwogICAg
+ICAgICAgICAiYXBpS2V5IgogICAgICAgICAgXQogICAgICAgIH0sCiAgICAgICAgIm5hbWUiOiB7CiAg
+ICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAiaW4iOiB7CiAgICAgICAg
+ICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImVudW0iOiBbCiAgICAgICAgICAgICJoZWFkZXIi
+LAogICAgICAgICAgICAicXVlcnkiCiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAiZGVzY3Jp
+cHRpb24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfQogICAgICB9LAogICAg
+ICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvdmVuZG9yRXh0ZW5zaW9uIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAg
+ICJvYXV0aDJJbXBsaWNpdFNlY3VyaXR5IjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAi
+YWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInJlcXVpcmVkIjogWwogICAgICAgICJ0
+eXBlIiwKICAgICAgICAiZmxvdyIsCiAgICAgICAgImF1dGhvcml6YXRpb25VcmwiCiAgICAgIF0sCiAg
+ICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJ0eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3Ry
+aW5nIiwKICAgICAgICAgICJlbnVtIjogWwogICAgICAgICAgICAib2F1dGgyIgogICAgICAgICAgXQog
+ICAgICAgIH0sCiAgICAgICAgImZsb3ciOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAg
+ICAgICAgImVudW0iOiBbCiAgICAgICAgICAgICJpbXBsaWNpdCIKICAgICAgICAgIF0KICAgICAgICB9
+LAogICAgICAgICJzY29wZXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29hdXRo
+MlNjb3BlcyIKICAgICAgICB9LAogICAgICAgICJhdXRob3JpemF0aW9uVXJsIjogewogICAgICAgICAg
+InR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgogICAgICAgIH0sCiAgICAg
+ICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0KICAg
+ICAgfSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0K
+ICAgIH0sCiAgICAib2F1dGgyUGFzc3dvcmRTZWN1cml0eSI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0
+IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJyZXF1aXJlZCI6IFsK
+ICAgICAgICAidHlwZSIsCiAgICAgICAgImZsb3ciLAogICAgICAgICJ0b2tlblVybCIKICAgICAgXSwK
+ICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgInR5cGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJz
+dHJpbmciLAogICAgICAgICAgImVudW0iOiBbCiAgICAgICAgICAgICJvYXV0aDIiCiAgICAgICAgICBd
+CiAgICAgICAgfSwKICAgICAgICAiZmxvdyI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAg
+ICAgICAgICAiZW51bSI6IFsKICAgICAgICAgICAgInBhc3N3b3JkIgogICAgICAgICAgXQogICAgICAg
+IH0sCiAgICAgICAgInNjb3BlcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb2F1
+dGgyU2NvcGVzIgogICAgICAgIH0sCiAgICAgICAgInRva2VuVXJsIjogewogICAgICAgICAgInR5cGUi
+OiAic3RyaW5nIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgogICAgICAgIH0sCiAgICAgICAgImRl
+c2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0KICAgICAgfSwK
+ICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJl
+ZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0s
+CiAgICAib2F1dGgyQXBwbGljYXRpb25TZWN1cml0eSI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwK
+ICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJyZXF1aXJlZCI6IFsKICAg
+ICAgICAidHlwZSIsCiAgICAgICAgImZsb3ciLAogICAgICAgICJ0b2tlblVybCIKICAgICAgXSwKICAg
+ICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgInR5cGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJp
+bmciLAogICAgICAgICAgImVudW0iOiBbCiAgICAgICAgICAgICJvYXV0aDIiCiAgICAgICAgICBdCiAg
+ICAgICAgfSwKICAgICAgICAiZmxvdyI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAg
+ICAgICAiZW51bSI6IFsKICAgICAgICAgICAgImFwcGxpY2F0aW9uIgogICAgICAgICAgXQogICAgICAg
+IH0sCiAgICAgICAgInNjb3BlcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb2F1
+dGgyU2NvcGVzIgogICAgICAgIH0sCiAgICAgICAgInRva2VuVXJsIjogewogICAgICAgICAgInR5cGUi
+OiAic3RyaW5nIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgogICAgICAgIH0sCiAgICAgICAgImRl
+c2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0KICAgICAgfSwK
+ICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJl
+ZiI6ICIjL2RlZmluaXRpb25zL3ZlbmRvckV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0s
+CiAgICAib2F1dGgyQWNjZXNzQ29kZVNlY3VyaXR5IjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAog
+ICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInJlcXVpcmVkIjogWwogICAg
+ICAgICJ0eXBlIiwKICAgICAgICAiZmxvdyIsCiAgICAgICAgImF1dGhvcml6YXRpb25VcmwiLAogICAg
+ICAgICJ0b2tlblVybCIKICAgICAgXSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgInR5cGUi
+OiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciLAogICAgICAgICAgImVudW0iOiBbCiAgICAgICAg
+ICAgICJvYXV0aDIiCiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAiZmxvdyI6IHsKICAgICAg
+ICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZW51bSI6IFsKICAgICAgICAgICAgImFjY2Vz
+c0NvZGUiCiAgICAgICAgICBdCiAgICAgICAgfSwKICAgICAgICAic2NvcGVzIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vYXV0aDJTY29wZXMiCiAgICAgICAgfSwKICAgICAgICAiYXV0
+aG9yaXphdGlvblVybCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIsCiAgICAgICAgICAiZm9y
+bWF0IjogInVyaSIKICAgICAgICB9LAogICAgICAgICJ0b2tlblVybCI6IHsKICAgICAgICAgICJ0eXBl
+IjogInN0cmluZyIsCiAgICAgICAgICAiZm9ybWF0IjogInVyaSIKICAgICAgICB9LAogICAgICAgICJk
+ZXNjcmlwdGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9CiAgICAgIH0s
+CiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAgIiRy
+ZWYiOiAiIy9kZWZpbml0aW9ucy92ZW5kb3JFeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9CiAgICB9
+LAogICAgIm9hdXRoMlNjb3BlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0
+aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgfQogICAgfSwK
+ICAgICJtZWRpYVR5cGVMaXN0IjogewogICAgICAidHlwZSI6ICJhcnJheSIsCiAgICAgICJpdGVtcyI6
+IHsKICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL21pbWVUeXBlIgogICAgICB9LAogICAgICAi
+dW5pcXVlSXRlbXMiOiB0cnVlCiAgICB9LAogICAgInBhcmFtZXRlcnNMaXN0IjogewogICAgICAidHlw
+ZSI6ICJhcnJheSIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJUaGUgcGFyYW1ldGVycyBuZWVkZWQgdG8g
+c2VuZCBhIHZhbGlkIEFQSSBjYWxsLiIsCiAgICAgICJhZGRpdGlvbmFsSXRlbXMiOiBmYWxzZSwKICAg
+ICAgIml0ZW1zIjogewogICAgICAgICJvbmVPZiI6IFsKICAgICAgICAgIHsKICAgICAgICAgICAgIiRy
+ZWYiOiAiIy9kZWZpbml0aW9ucy9wYXJhbWV0ZXIiCiAgICAgICAgICB9LAogICAgICAgICAgewogICAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2pzb25SZWZlcmVuY2UiCiAgICAgICAgICB9CiAg
+ICAgICAgXQogICAgICB9LAogICAgICAidW5pcXVlSXRlbXMiOiB0cnVlCiAgICB9LAogICAgInNjaGVt
+ZXNMaXN0IjogewogICAgICAidHlwZSI6ICJhcnJheSIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJUaGUg
+dHJhbnNmZXIgcHJvdG9jb2wgb2YgdGhlIEFQSS4iLAogICAgICAiaXRlbXMiOiB7CiAgICAgICAgInR5
+cGUiOiAic3RyaW5nIiwKICAgICAgICAiZW51bSI6IFsKICAgICAgICAgICJodHRwIiwKICAgICAgICAg
+ICJodHRwcyIsCiAgICAgICAgICAid3MiLAogICAgICAgICAgIndzcyIKICAgICAgICBdCiAgICAgIH0s
+CiAgICAgICJ1bmlxdWVJdGVtcyI6IHRydWUKICAgIH0sCiAgICAiY29sbGVjdGlvbkZvcm1hdCI6IHsK
+ICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgImVudW0iOiBbCiAgICAgICAgImNzdiIsCiAgICAg
+ICAgInNzdiIsCiAgICAgICAgInRzdiIsCiAgICAgICAgInBpcGVzIgogICAgICBdLAogICAgICAiZGVm
+YXVsdCI6ICJjc3YiCiAgICB9LAogICAgImNvbGxlY3Rpb25Gb3JtYXRXaXRoTXVsdGkiOiB7CiAgICAg
+ICJ0eXBlIjogInN0cmluZyIsCiAgICAgICJlbnVtIjogWwogICAgICAgICJjc3YiLAogICAgICAgICJz
+c3YiLAogICAgICAgICJ0c3YiLAogICAgICAgICJwaXBlcyIsCiAgICAgICAgIm11bHRpIgogICAgICBd
+LAogICAgICAiZGVmYXVsdCI6ICJjc3YiCiAgICB9LAogICAgInRpdGxlIjogewogICAgICAiJHJlZiI6
+ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy90aXRsZSIK
+ICAgIH0sCiAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVt
+YS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL2Rlc2NyaXB0aW9uIgogICAgfSwKICAgICJk
+ZWZhdWx0IjogewogICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9kZWZhdWx0IgogICAgfSwKICAgICJtdWx0aXBsZU9mIjogewogICAgICAi
+JHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9t
+dWx0aXBsZU9mIgogICAgfSwKICAgICJtYXhpbXVtIjogewogICAgICAiJHJlZiI6ICJodHRwOi8vanNv
+bi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9tYXhpbXVtIgogICAgfSwKICAg
+ICJleGNsdXNpdmVNYXhpbXVtIjogewogICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3Jn
+L2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9leGNsdXNpdmVNYXhpbXVtIgogICAgfSwKICAgICJt
+aW5pbXVtIjogewogICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9taW5pbXVtIgogICAgfSwKICAgICJleGNsdXNpdmVNaW5pbXVtIjogewog
+ICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVy
+dGllcy9leGNsdXNpdmVNaW5pbXVtIgogICAgfSwKICAgICJtYXhMZW5ndGgiOiB7CiAgICAgICIkcmVm
+IjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9kZWZpbml0aW9ucy9wb3Np
+dGl2ZUludGVnZXIiCiAgICB9LAogICAgIm1pbkxlbmd0aCI6IHsKICAgICAgIiRyZWYiOiAiaHR0cDov
+L2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL2RlZmluaXRpb25zL3Bvc2l0aXZlSW50ZWdl
+ckRlZmF1bHQwIgogICAgfSwKICAgICJwYXR0ZXJuIjogewogICAgICAiJHJlZiI6ICJodHRwOi8vanNv
+bi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9wYXR0ZXJuIgogICAgfSwKICAg
+ICJtYXhJdGVtcyI6IHsKICAgICAgIiRyZWYiOiAiaHR0cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0w
+NC9zY2hlbWEjL2RlZmluaXRpb25zL3Bvc2l0aXZlSW50ZWdlciIKICAgIH0sCiAgICAibWluSXRlbXMi
+OiB7CiAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9k
+ZWZpbml0aW9ucy9wb3NpdGl2ZUludGVnZXJEZWZhdWx0MCIKICAgIH0sCiAgICAidW5pcXVlSXRlbXMi
+OiB7CiAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9w
+cm9wZXJ0aWVzL3VuaXF1ZUl0ZW1zIgogICAgfSwKICAgICJlbnVtIjogewogICAgICAiJHJlZiI6ICJo
+dHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9lbnVtIgogICAg
+fSwKICAgICJqc29uUmVmZXJlbmNlIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAicmVx
+dWlyZWQiOiBbCiAgICAgICAgIiRyZWYiCiAgICAgIF0sCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGll
+cyI6IGZhbHNlLAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAiJHJlZiI6IHsKICAgICAgICAg
+ICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJkZXNjcmlwdGlvbiI6IHsKICAgICAg
+ICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9CiAgICAgIH0KICAgIH0KICB9Cn0=`)
+}
+
+func openAPIv3SchemaBytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(
+`ewogICJ0aXRsZSI6ICJBIEpTT04gU2NoZW1hIGZvciBPcGVuQVBJIDMuMC4iLAogICJpZCI6ICJodHRw
+Oi8vb3BlbmFwaXMub3JnL3YzL3NjaGVtYS5qc29uIyIsCiAgIiRzY2hlbWEiOiAiaHR0cDovL2pzb24t
+c2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjIiwKICAidHlwZSI6ICJvYmplY3QiLAogICJkZXNjcmlw
+dGlvbiI6ICJUaGlzIGlzIHRoZSByb290IGRvY3VtZW50IG9iamVjdCBvZiB0aGUgT3BlbkFQSSBkb2N1
+bWVudC4iLAogICJyZXF1aXJlZCI6IFsKICAgICJvcGVuYXBpIiwKICAgICJpbmZvIiwKICAgICJwYXRo
+cyIKICBdLAogICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICJwYXR0ZXJuUHJvcGVydGll
+cyI6IHsKICAgICJeeC0iOiB7CiAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc3BlY2lmaWNhdGlv
+bkV4dGVuc2lvbiIKICAgIH0KICB9LAogICJwcm9wZXJ0aWVzIjogewogICAgIm9wZW5hcGkiOiB7CiAg
+ICAgICJ0eXBlIjogInN0cmluZyIKICAgIH0sCiAgICAiaW5mbyI6IHsKICAgICAgIiRyZWYiOiAiIy9k
+ZWZpbml0aW9ucy9pbmZvIgogICAgfSwKICAgICJzZXJ2ZXJzIjogewogICAgICAidHlwZSI6ICJhcnJh
+eSIsCiAgICAgICJpdGVtcyI6IHsKICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NlcnZlciIK
+ICAgICAgfSwKICAgICAgInVuaXF1ZUl0ZW1zIjogdHJ1ZQogICAgfSwKICAgICJwYXRocyI6IHsKICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXRocyIKICAgIH0sCiAgICAiY29tcG9uZW50cyI6IHsK
+ICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9jb21wb25lbnRzIgogICAgfSwKICAgICJzZWN1cml0
+eSI6IHsKICAgICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAiaXRlbXMiOiB7CiAgICAgICAgIiRyZWYi
+OiAiIy9kZWZpbml0aW9ucy9zZWN1cml0eVJlcXVpcmVtZW50IgogICAgICB9LAogICAgICAidW5pcXVl
+SXRlbXMiOiB0cnVlCiAgICB9LAogICAgInRhZ3MiOiB7CiAgICAgICJ0eXBlIjogImFycmF5IiwKICAg
+ICAgIml0ZW1zIjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvdGFnIgogICAgICB9LAog
+ICAgICAidW5pcXVlSXRlbXMiOiB0cnVlCiAgICB9LAogICAgImV4dGVybmFsRG9jcyI6IHsKICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leHRlcm5hbERvY3MiCiAgICB9CiAgfSwKICAiZGVmaW5pdGlv
+bnMiOiB7CiAgICAiaW5mbyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0
+aW9uIjogIlRoZSBvYmplY3QgcHJvdmlkZXMgbWV0YWRhdGEgYWJvdXQgdGhlIEFQSS4gVGhlIG1ldGFk
+YXRhIE1BWSBiZSB1c2VkIGJ5IHRoZSBjbGllbnRzIGlmIG5lZWRlZCwgYW5kIE1BWSBiZSBwcmVzZW50
+ZWQgaW4gZWRpdGluZyBvciBkb2N1bWVudGF0aW9uIGdlbmVyYXRpb24gdG9vbHMgZm9yIGNvbnZlbmll
+bmNlLiIsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAidGl0bGUiLAogICAgICAgICJ2ZXJzaW9u
+IgogICAgICBdLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRl
+cm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmlu
+aXRpb25zL3NwZWNpZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9LAogICAgICAicHJv
+cGVydGllcyI6IHsKICAgICAgICAidGl0bGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAg
+ICAgICAgfSwKICAgICAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmci
+CiAgICAgICAgfSwKICAgICAgICAidGVybXNPZlNlcnZpY2UiOiB7CiAgICAgICAgICAidHlwZSI6ICJz
+dHJpbmciCiAgICAgICAgfSwKICAgICAgICAiY29udGFjdCI6IHsKICAgICAgICAgICIkcmVmIjogIiMv
+ZGVmaW5pdGlvbnMvY29udGFjdCIKICAgICAgICB9LAogICAgICAgICJsaWNlbnNlIjogewogICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9saWNlbnNlIgogICAgICAgIH0sCiAgICAgICAgInZlcnNp
+b24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfQogICAgICB9CiAgICB9LAog
+ICAgImNvbnRhY3QiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6
+ICJDb250YWN0IGluZm9ybWF0aW9uIGZvciB0aGUgZXhwb3NlZCBBUEkuIiwKICAgICAgImFkZGl0aW9u
+YWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAi
+XngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5z
+aW9uIgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgIm5hbWUi
+OiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAidXJsIjogewog
+ICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAgICJmb3JtYXQiOiAidXJpIgogICAgICAg
+IH0sCiAgICAgICAgImVtYWlsIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIiwKICAgICAgICAg
+ICJmb3JtYXQiOiAiZW1haWwiCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgImxpY2Vuc2UiOiB7
+CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJMaWNlbnNlIGluZm9y
+bWF0aW9uIGZvciB0aGUgZXhwb3NlZCBBUEkuIiwKICAgICAgInJlcXVpcmVkIjogWwogICAgICAgICJu
+YW1lIgogICAgICBdLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBh
+dHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL3NwZWNpZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9LAogICAgICAi
+cHJvcGVydGllcyI6IHsKICAgICAgICAibmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIK
+ICAgICAgICB9LAogICAgICAgICJ1cmwiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAg
+ICAgfQogICAgICB9CiAgICB9LAogICAgInNlcnZlciI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwK
+ICAgICAgImRlc2NyaXB0aW9uIjogIkFuIG9iamVjdCByZXByZXNlbnRpbmcgYSBTZXJ2ZXIuIiwKICAg
+ICAgInJlcXVpcmVkIjogWwogICAgICAgICJ1cmwiCiAgICAgIF0sCiAgICAgICJhZGRpdGlvbmFsUHJv
+cGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAgICAgIl54LSI6
+IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIK
+ICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJ1cmwiOiB7CiAg
+ICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAiZGVzY3JpcHRpb24iOiB7
+CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAidmFyaWFibGVzIjog
+ewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zZXJ2ZXJWYXJpYWJsZXMiCiAgICAgICAg
+fQogICAgICB9CiAgICB9LAogICAgInNlcnZlclZhcmlhYmxlIjogewogICAgICAidHlwZSI6ICJvYmpl
+Y3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiQW4gb2JqZWN0IHJlcHJlc2VudGluZyBhIFNlcnZlciBW
+YXJpYWJsZSBmb3Igc2VydmVyIFVSTCB0ZW1wbGF0ZSBzdWJzdGl0dXRpb24uIiwKICAgICAgInJlcXVp
+cmVkIjogWwogICAgICAgICJkZWZhdWx0IgogICAgICBdLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRp
+ZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAg
+ICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NwZWNpZmljYXRpb25FeHRlbnNpb24iCiAgICAg
+ICAgfQogICAgICB9LAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAiZW51bSI6IHsKICAgICAg
+ICAgICJ0eXBlIjogImFycmF5IiwKICAgICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICAgInR5cGUi
+OiAic3RyaW5nIgogICAgICAgICAgfSwKICAgICAgICAgICJ1bmlxdWVJdGVtcyI6IHRydWUKICAgICAg
+ICB9LAogICAgICAgICJkZWZhdWx0IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAg
+IH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAg
+ICAgIH0KICAgICAgfQogICAgfSwKICAgICJjb21wb25lbnRzIjogewogICAgICAidHlwZSI6ICJvYmpl
+Y3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiSG9sZHMgYSBzZXQgb2YgcmV1c2FibGUgb2JqZWN0cyBm
+b3IgZGlmZmVyZW50IGFzcGVjdHMgb2YgdGhlIE9BUy4gQWxsIG9iamVjdHMgZGVmaW5lZCB3aXRoaW4g
+dGhlIGNvbXBvbmVudHMgb2JqZWN0IHdpbGwgaGF2ZSBubyBlZmZlY3Qgb24gdGhlIEFQSSB1bmxlc3Mg
+dGhleSBhcmUgZXhwbGljaXRseSByZWZlcmVuY2VkIGZyb20gcHJvcGVydGllcyBvdXRzaWRlIHRoZSBj
+b21wb25lbnRzIG9iamVjdC4iLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAg
+ICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3NwZWNpZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9LAog
+ICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAic2NoZW1hcyI6IHsKICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvc2NoZW1hc09yUmVmZXJlbmNlcyIKICAgICAgICB9LAogICAgICAgICJyZXNw
+b25zZXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlc09yUmVmZXJl
+bmNlcyIKICAgICAgICB9LAogICAgICAgICJwYXJhbWV0ZXJzIjogewogICAgICAgICAgIiRyZWYiOiAi
+Iy9kZWZpbml0aW9ucy9wYXJhbWV0ZXJzT3JSZWZlcmVuY2VzIgogICAgICAgIH0sCiAgICAgICAgImV4
+YW1wbGVzIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGFtcGxlc09yUmVmZXJl
+bmNlcyIKICAgICAgICB9LAogICAgICAgICJyZXF1ZXN0Qm9kaWVzIjogewogICAgICAgICAgIiRyZWYi
+OiAiIy9kZWZpbml0aW9ucy9yZXF1ZXN0Qm9kaWVzT3JSZWZlcmVuY2VzIgogICAgICAgIH0sCiAgICAg
+ICAgImhlYWRlcnMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2hlYWRlcnNPclJl
+ZmVyZW5jZXMiCiAgICAgICAgfSwKICAgICAgICAic2VjdXJpdHlTY2hlbWVzIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zZWN1cml0eVNjaGVtZXNPclJlZmVyZW5jZXMiCiAgICAgICAg
+fSwKICAgICAgICAibGlua3MiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2xpbmtz
+T3JSZWZlcmVuY2VzIgogICAgICAgIH0sCiAgICAgICAgImNhbGxiYWNrcyI6IHsKICAgICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvY2FsbGJhY2tzT3JSZWZlcmVuY2VzIgogICAgICAgIH0KICAgICAg
+fQogICAgfSwKICAgICJwYXRocyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2Ny
+aXB0aW9uIjogIkhvbGRzIHRoZSByZWxhdGl2ZSBwYXRocyB0byB0aGUgaW5kaXZpZHVhbCBlbmRwb2lu
+dHMgYW5kIHRoZWlyIG9wZXJhdGlvbnMuIFRoZSBwYXRoIGlzIGFwcGVuZGVkIHRvIHRoZSBVUkwgZnJv
+bSB0aGUgYFNlcnZlciBPYmplY3RgIGluIG9yZGVyIHRvIGNvbnN0cnVjdCB0aGUgZnVsbCBVUkwuICBU
+aGUgUGF0aHMgTUFZIGJlIGVtcHR5LCBkdWUgdG8gQUNMIGNvbnN0cmFpbnRzLiIsCiAgICAgICJhZGRp
+dGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAg
+ICAgIl4vIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXRoSXRlbSIKICAgICAg
+ICB9LAogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NwZWNp
+ZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgInBhdGhJdGVtIjog
+ewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiRGVzY3JpYmVzIHRo
+ZSBvcGVyYXRpb25zIGF2YWlsYWJsZSBvbiBhIHNpbmdsZSBwYXRoLiBBIFBhdGggSXRlbSBNQVkgYmUg
+ZW1wdHksIGR1ZSB0byBBQ0wgY29uc3RyYWludHMuIFRoZSBwYXRoIGl0c2VsZiBpcyBzdGlsbCBleHBv
+c2VkIHRvIHRoZSBkb2N1bWVudGF0aW9uIHZpZXdlciBidXQgdGhleSB3aWxsIG5vdCBrbm93IHdoaWNo
+IG9wZXJhdGlvbnMgYW5kIHBhcmFtZXRlcnMgYXJlIGF2YWlsYWJsZS4iLAogICAgICAiYWRkaXRpb25h
+bFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVzIjogewogICAgICAgICJe
+eC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NwZWNpZmljYXRpb25FeHRlbnNp
+b24iCiAgICAgICAgfQogICAgICB9LAogICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAiJHJlZiI6
+IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJzdW1tYXJ5Ijog
+ewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0aW9u
+IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImdldCI6IHsK
+ICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb3BlcmF0aW9uIgogICAgICAgIH0sCiAgICAg
+ICAgInB1dCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb3BlcmF0aW9uIgogICAg
+ICAgIH0sCiAgICAgICAgInBvc3QiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29w
+ZXJhdGlvbiIKICAgICAgICB9LAogICAgICAgICJkZWxldGUiOiB7CiAgICAgICAgICAiJHJlZiI6ICIj
+L2RlZmluaXRpb25zL29wZXJhdGlvbiIKICAgICAgICB9LAogICAgICAgICJvcHRpb25zIjogewogICAg
+ICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vcGVyYXRpb24iCiAgICAgICAgfSwKICAgICAgICAi
+aGVhZCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvb3BlcmF0aW9uIgogICAgICAg
+IH0sCiAgICAgICAgInBhdGNoIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vcGVy
+YXRpb24iCiAgICAgICAgfSwKICAgICAgICAidHJhY2UiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2Rl
+ZmluaXRpb25zL29wZXJhdGlvbiIKICAgICAgICB9LAogICAgICAgICJzZXJ2ZXJzIjogewogICAgICAg
+ICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3NlcnZlciIKICAgICAgICAgIH0sCiAgICAgICAgICAidW5pcXVlSXRlbXMi
+OiB0cnVlCiAgICAgICAgfSwKICAgICAgICAicGFyYW1ldGVycyI6IHsKICAgICAgICAgICJ0eXBlIjog
+ImFycmF5IiwKICAgICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0
+aW9ucy9wYXJhbWV0ZXJPclJlZmVyZW5jZSIKICAgICAgICAgIH0sCiAgICAgICAgICAidW5pcXVlSXRl
+bXMiOiB0cnVlCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgIm9wZXJhdGlvbiI6IHsKICAgICAg
+InR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkRlc2NyaWJlcyBhIHNpbmdsZSBB
+UEkgb3BlcmF0aW9uIG9uIGEgcGF0aC4iLAogICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgInJlc3Bv
+bnNlcyIKICAgICAgXSwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJw
+YXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9k
+ZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9uIgogICAgICAgIH0KICAgICAgfSwKICAgICAg
+InByb3BlcnRpZXMiOiB7CiAgICAgICAgInRhZ3MiOiB7CiAgICAgICAgICAidHlwZSI6ICJhcnJheSIs
+CiAgICAgICAgICAiaXRlbXMiOiB7CiAgICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICAg
+IH0sCiAgICAgICAgICAidW5pcXVlSXRlbXMiOiB0cnVlCiAgICAgICAgfSwKICAgICAgICAic3VtbWFy
+eSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJkZXNjcmlw
+dGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJleHRl
+cm5hbERvY3MiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2V4dGVybmFsRG9jcyIK
+ICAgICAgICB9LAogICAgICAgICJvcGVyYXRpb25JZCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmlu
+ZyIKICAgICAgICB9LAogICAgICAgICJwYXJhbWV0ZXJzIjogewogICAgICAgICAgInR5cGUiOiAiYXJy
+YXkiLAogICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L3BhcmFtZXRlck9yUmVmZXJlbmNlIgogICAgICAgICAgfSwKICAgICAgICAgICJ1bmlxdWVJdGVtcyI6
+IHRydWUKICAgICAgICB9LAogICAgICAgICJyZXF1ZXN0Qm9keSI6IHsKICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvcmVxdWVzdEJvZHlPclJlZmVyZW5jZSIKICAgICAgICB9LAogICAgICAgICJy
+ZXNwb25zZXMiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlcyIKICAg
+ICAgICB9LAogICAgICAgICJjYWxsYmFja3MiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRp
+b25zL2NhbGxiYWNrc09yUmVmZXJlbmNlcyIKICAgICAgICB9LAogICAgICAgICJkZXByZWNhdGVkIjog
+ewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJzZWN1cml0eSI6
+IHsKICAgICAgICAgICJ0eXBlIjogImFycmF5IiwKICAgICAgICAgICJpdGVtcyI6IHsKICAgICAgICAg
+ICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zZWN1cml0eVJlcXVpcmVtZW50IgogICAgICAgICAgfSwK
+ICAgICAgICAgICJ1bmlxdWVJdGVtcyI6IHRydWUKICAgICAgICB9LAogICAgICAgICJzZXJ2ZXJzIjog
+ewogICAgICAgICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NlcnZlciIKICAgICAgICAgIH0sCiAgICAgICAgICAidW5p
+cXVlSXRlbXMiOiB0cnVlCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgImV4dGVybmFsRG9jcyI6
+IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkFsbG93cyByZWZl
+cmVuY2luZyBhbiBleHRlcm5hbCByZXNvdXJjZSBmb3IgZXh0ZW5kZWQgZG9jdW1lbnRhdGlvbi4iLAog
+ICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgInVybCIKICAgICAgXSwKICAgICAgImFkZGl0aW9uYWxQ
+cm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngt
+IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9u
+IgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImRlc2NyaXB0
+aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgInVybCI6
+IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAi
+cGFyYW1ldGVyIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAi
+RGVzY3JpYmVzIGEgc2luZ2xlIG9wZXJhdGlvbiBwYXJhbWV0ZXIuICBBIHVuaXF1ZSBwYXJhbWV0ZXIg
+aXMgZGVmaW5lZCBieSBhIGNvbWJpbmF0aW9uIG9mIGEgbmFtZSBhbmQgbG9jYXRpb24uIiwKICAgICAg
+InJlcXVpcmVkIjogWwogICAgICAgICJuYW1lIiwKICAgICAgICAiaW4iCiAgICAgIF0sCiAgICAgICJh
+ZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAg
+ICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc3BlY2lmaWNhdGlv
+bkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAg
+ICJuYW1lIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImlu
+IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0
+aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgInJlcXVp
+cmVkIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJkZXBy
+ZWNhdGVkIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJh
+bGxvd0VtcHR5VmFsdWUiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAg
+ICAgICAgInN0eWxlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAg
+ICAgImV4cGxvZGUiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAgICAg
+ICAgImFsbG93UmVzZXJ2ZWQiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0s
+CiAgICAgICAgInNjaGVtYSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2NoZW1h
+T3JSZWZlcmVuY2UiCiAgICAgICAgfSwKICAgICAgICAiZXhhbXBsZSI6IHsKICAgICAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvYW55IgogICAgICAgIH0sCiAgICAgICAgImV4YW1wbGVzIjogewogICAg
+ICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGFtcGxlc09yUmVmZXJlbmNlcyIKICAgICAgICB9
+LAogICAgICAgICJjb250ZW50IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tZWRp
+YVR5cGVzIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJyZXF1ZXN0Qm9keSI6IHsKICAgICAg
+InR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkRlc2NyaWJlcyBhIHNpbmdsZSBy
+ZXF1ZXN0IGJvZHkuIiwKICAgICAgInJlcXVpcmVkIjogWwogICAgICAgICJjb250ZW50IgogICAgICBd
+LAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0
+aWVzIjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Nw
+ZWNpZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9LAogICAgICAicHJvcGVydGllcyI6
+IHsKICAgICAgICAiZGVzY3JpcHRpb24iOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAg
+ICAgfSwKICAgICAgICAiY29udGVudCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+bWVkaWFUeXBlcyIKICAgICAgICB9LAogICAgICAgICJyZXF1aXJlZCI6IHsKICAgICAgICAgICJ0eXBl
+IjogImJvb2xlYW4iCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgIm1lZGlhVHlwZSI6IHsKICAg
+ICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkVhY2ggTWVkaWEgVHlwZSBP
+YmplY3QgcHJvdmlkZXMgc2NoZW1hIGFuZCBleGFtcGxlcyBmb3IgdGhlIG1lZGlhIHR5cGUgaWRlbnRp
+ZmllZCBieSBpdHMga2V5LiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAg
+ICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjog
+IiMvZGVmaW5pdGlvbnMvc3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAg
+ICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJzY2hlbWEiOiB7CiAgICAgICAgICAiJHJlZiI6ICIj
+L2RlZmluaXRpb25zL3NjaGVtYU9yUmVmZXJlbmNlIgogICAgICAgIH0sCiAgICAgICAgImV4YW1wbGUi
+OiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2FueSIKICAgICAgICB9LAogICAgICAg
+ICJleGFtcGxlcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhhbXBsZXNPclJl
+ZmVyZW5jZXMiCiAgICAgICAgfSwKICAgICAgICAiZW5jb2RpbmciOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL2VuY29kaW5ncyIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiZW5j
+b2RpbmciOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJBIHNp
+bmdsZSBlbmNvZGluZyBkZWZpbml0aW9uIGFwcGxpZWQgdG8gYSBzaW5nbGUgc2NoZW1hIHByb3BlcnR5
+LiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3Bl
+cnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+c3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVz
+IjogewogICAgICAgICJjb250ZW50VHlwZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAg
+ICAgICB9LAogICAgICAgICJoZWFkZXJzIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9u
+cy9oZWFkZXJzT3JSZWZlcmVuY2VzIgogICAgICAgIH0sCiAgICAgICAgInN0eWxlIjogewogICAgICAg
+ICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImV4cGxvZGUiOiB7CiAgICAgICAg
+ICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAgICAgICAgImFsbG93UmVzZXJ2ZWQiOiB7CiAg
+ICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJyZXNw
+b25zZXMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJBIGNv
+bnRhaW5lciBmb3IgdGhlIGV4cGVjdGVkIHJlc3BvbnNlcyBvZiBhbiBvcGVyYXRpb24uIFRoZSBjb250
+YWluZXIgbWFwcyBhIEhUVFAgcmVzcG9uc2UgY29kZSB0byB0aGUgZXhwZWN0ZWQgcmVzcG9uc2UuICBU
+aGUgZG9jdW1lbnRhdGlvbiBpcyBub3QgbmVjZXNzYXJpbHkgZXhwZWN0ZWQgdG8gY292ZXIgYWxsIHBv
+c3NpYmxlIEhUVFAgcmVzcG9uc2UgY29kZXMgYmVjYXVzZSB0aGV5IG1heSBub3QgYmUga25vd24gaW4g
+YWR2YW5jZS4gSG93ZXZlciwgZG9jdW1lbnRhdGlvbiBpcyBleHBlY3RlZCB0byBjb3ZlciBhIHN1Y2Nl
+c3NmdWwgb3BlcmF0aW9uIHJlc3BvbnNlIGFuZCBhbnkga25vd24gZXJyb3JzLiAgVGhlIGBkZWZhdWx0
+YCBNQVkgYmUgdXNlZCBhcyBhIGRlZmF1bHQgcmVzcG9uc2Ugb2JqZWN0IGZvciBhbGwgSFRUUCBjb2Rl
+cyAgdGhhdCBhcmUgbm90IGNvdmVyZWQgaW5kaXZpZHVhbGx5IGJ5IHRoZSBzcGVjaWZpY2F0aW9uLiAg
+VGhlIGBSZXNwb25zZXMgT2JqZWN0YCBNVVNUIGNvbnRhaW4gYXQgbGVhc3Qgb25lIHJlc3BvbnNlIGNv
+ZGUsIGFuZCBpdCAgU0hPVUxEIGJlIHRoZSByZXNwb25zZSBmb3IgYSBzdWNjZXNzZnVsIG9wZXJhdGlv
+biBjYWxsLiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVy
+blByb3BlcnRpZXMiOiB7CiAgICAgICAgIl4oWzAtOVhdezN9KSQiOiB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlT3JSZWZlcmVuY2UiCiAgICAgICAgfSwKICAgICAgICAiXngt
+IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9u
+IgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImRlZmF1bHQi
+OiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlT3JSZWZlcmVuY2UiCiAg
+ICAgICAgfQogICAgICB9CiAgICB9LAogICAgInJlc3BvbnNlIjogewogICAgICAidHlwZSI6ICJvYmpl
+Y3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiRGVzY3JpYmVzIGEgc2luZ2xlIHJlc3BvbnNlIGZyb20g
+YW4gQVBJIE9wZXJhdGlvbiwgaW5jbHVkaW5nIGRlc2lnbi10aW1lLCBzdGF0aWMgIGBsaW5rc2AgdG8g
+b3BlcmF0aW9ucyBiYXNlZCBvbiB0aGUgcmVzcG9uc2UuIiwKICAgICAgInJlcXVpcmVkIjogWwogICAg
+ICAgICJkZXNjcmlwdGlvbiIKICAgICAgXSwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFs
+c2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9uIgogICAgICAgIH0KICAg
+ICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAg
+ICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImhlYWRlcnMiOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2hlYWRlcnNPclJlZmVyZW5jZXMiCiAgICAgICAgfSwKICAg
+ICAgICAiY29udGVudCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvbWVkaWFUeXBl
+cyIKICAgICAgICB9LAogICAgICAgICJsaW5rcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvbGlua3NPclJlZmVyZW5jZXMiCiAgICAgICAgfQogICAgICB9CiAgICB9LAogICAgImNhbGxi
+YWNrIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiQSBtYXAg
+b2YgcG9zc2libGUgb3V0LW9mIGJhbmQgY2FsbGJhY2tzIHJlbGF0ZWQgdG8gdGhlIHBhcmVudCBvcGVy
+YXRpb24uIEVhY2ggdmFsdWUgaW4gdGhlIG1hcCBpcyBhIFBhdGggSXRlbSBPYmplY3QgdGhhdCBkZXNj
+cmliZXMgYSBzZXQgb2YgcmVxdWVzdHMgdGhhdCBtYXkgYmUgaW5pdGlhdGVkIGJ5IHRoZSBBUEkgcHJv
+dmlkZXIgYW5kIHRoZSBleHBlY3RlZCByZXNwb25zZXMuIFRoZSBrZXkgdmFsdWUgdXNlZCB0byBpZGVu
+dGlmeSB0aGUgY2FsbGJhY2sgb2JqZWN0IGlzIGFuIGV4cHJlc3Npb24sIGV2YWx1YXRlZCBhdCBydW50
+aW1lLCB0aGF0IGlkZW50aWZpZXMgYSBVUkwgdG8gdXNlIGZvciB0aGUgY2FsbGJhY2sgb3BlcmF0aW9u
+LiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3Bl
+cnRpZXMiOiB7CiAgICAgICAgIl4iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Bh
+dGhJdGVtIgogICAgICAgIH0sCiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVm
+aW5pdGlvbnMvc3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAg
+ICAiZXhhbXBsZSI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjog
+IiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3Bl
+cnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+c3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVz
+IjogewogICAgICAgICJzdW1tYXJ5IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAg
+IH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAg
+ICAgIH0sCiAgICAgICAgInZhbHVlIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9h
+bnkiCiAgICAgICAgfSwKICAgICAgICAiZXh0ZXJuYWxWYWx1ZSI6IHsKICAgICAgICAgICJ0eXBlIjog
+InN0cmluZyIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAibGluayI6IHsKICAgICAgInR5cGUi
+OiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIlRoZSBgTGluayBvYmplY3RgIHJlcHJlc2Vu
+dHMgYSBwb3NzaWJsZSBkZXNpZ24tdGltZSBsaW5rIGZvciBhIHJlc3BvbnNlLiBUaGUgcHJlc2VuY2Ug
+b2YgYSBsaW5rIGRvZXMgbm90IGd1YXJhbnRlZSB0aGUgY2FsbGVyJ3MgYWJpbGl0eSB0byBzdWNjZXNz
+ZnVsbHkgaW52b2tlIGl0LCByYXRoZXIgaXQgcHJvdmlkZXMgYSBrbm93biByZWxhdGlvbnNoaXAgYW5k
+IHRyYXZlcnNhbCBtZWNoYW5pc20gYmV0d2VlbiByZXNwb25zZXMgYW5kIG90aGVyIG9wZXJhdGlvbnMu
+ICBVbmxpa2UgX2R5bmFtaWNfIGxpbmtzIChpLmUuIGxpbmtzIHByb3ZpZGVkICoqaW4qKiB0aGUgcmVz
+cG9uc2UgcGF5bG9hZCksIHRoZSBPQVMgbGlua2luZyBtZWNoYW5pc20gZG9lcyBub3QgcmVxdWlyZSBs
+aW5rIGluZm9ybWF0aW9uIGluIHRoZSBydW50aW1lIHJlc3BvbnNlLiAgRm9yIGNvbXB1dGluZyBsaW5r
+cywgYW5kIHByb3ZpZGluZyBpbnN0cnVjdGlvbnMgdG8gZXhlY3V0ZSB0aGVtLCBhIHJ1bnRpbWUgZXhw
+cmVzc2lvbiBpcyB1c2VkIGZvciBhY2Nlc3NpbmcgdmFsdWVzIGluIGFuIG9wZXJhdGlvbiBhbmQgdXNp
+bmcgdGhlbSBhcyBwYXJhbWV0ZXJzIHdoaWxlIGludm9raW5nIHRoZSBsaW5rZWQgb3BlcmF0aW9uLiIs
+CiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRp
+ZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc3Bl
+Y2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjog
+ewogICAgICAgICJvcGVyYXRpb25SZWYiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAg
+ICAgfSwKICAgICAgICAib3BlcmF0aW9uSWQiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAg
+ICAgICAgfSwKICAgICAgICAicGFyYW1ldGVycyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvYW55c09yRXhwcmVzc2lvbnMiCiAgICAgICAgfSwKICAgICAgICAicmVxdWVzdEJvZHkiOiB7
+CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2FueU9yRXhwcmVzc2lvbiIKICAgICAgICB9
+LAogICAgICAgICJkZXNjcmlwdGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAg
+ICB9LAogICAgICAgICJzZXJ2ZXIiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Nl
+cnZlciIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAiaGVhZGVyIjogewogICAgICAidHlwZSI6
+ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiVGhlIEhlYWRlciBPYmplY3QgZm9sbG93cyB0
+aGUgc3RydWN0dXJlIG9mIHRoZSBQYXJhbWV0ZXIgT2JqZWN0IHdpdGggdGhlIGZvbGxvd2luZyBjaGFu
+Z2VzOiAgMS4gYG5hbWVgIE1VU1QgTk9UIGJlIHNwZWNpZmllZCwgaXQgaXMgZ2l2ZW4gaW4gdGhlIGNv
+cnJlc3BvbmRpbmcgYGhlYWRlcnNgIG1hcC4gMS4gYGluYCBNVVNUIE5PVCBiZSBzcGVjaWZpZWQsIGl0
+IGlzIGltcGxpY2l0bHkgaW4gYGhlYWRlcmAuIDEuIEFsbCB0cmFpdHMgdGhhdCBhcmUgYWZmZWN0ZWQg
+YnkgdGhlIGxvY2F0aW9uIE1VU1QgYmUgYXBwbGljYWJsZSB0byBhIGxvY2F0aW9uIG9mIGBoZWFkZXJg
+IChmb3IgZXhhbXBsZSwgYHN0eWxlYCkuIiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFs
+c2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9uIgogICAgICAgIH0KICAg
+ICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAg
+ICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgInJlcXVpcmVkIjogewogICAgICAg
+ICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJkZXByZWNhdGVkIjogewogICAg
+ICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJhbGxvd0VtcHR5VmFsdWUi
+OiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAgICAgICAgInN0eWxlIjog
+ewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImV4cGxvZGUiOiB7
+CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAgICAgICAgImFsbG93UmVzZXJ2
+ZWQiOiB7CiAgICAgICAgICAidHlwZSI6ICJib29sZWFuIgogICAgICAgIH0sCiAgICAgICAgInNjaGVt
+YSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc2NoZW1hT3JSZWZlcmVuY2UiCiAg
+ICAgICAgfSwKICAgICAgICAiZXhhbXBsZSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlv
+bnMvYW55IgogICAgICAgIH0sCiAgICAgICAgImV4YW1wbGVzIjogewogICAgICAgICAgIiRyZWYiOiAi
+Iy9kZWZpbml0aW9ucy9leGFtcGxlc09yUmVmZXJlbmNlcyIKICAgICAgICB9LAogICAgICAgICJjb250
+ZW50IjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tZWRpYVR5cGVzIgogICAgICAg
+IH0KICAgICAgfQogICAgfSwKICAgICJ0YWciOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAg
+ICJkZXNjcmlwdGlvbiI6ICJBZGRzIG1ldGFkYXRhIHRvIGEgc2luZ2xlIHRhZyB0aGF0IGlzIHVzZWQg
+YnkgdGhlIE9wZXJhdGlvbiBPYmplY3QuIEl0IGlzIG5vdCBtYW5kYXRvcnkgdG8gaGF2ZSBhIFRhZyBP
+YmplY3QgcGVyIHRhZyBkZWZpbmVkIGluIHRoZSBPcGVyYXRpb24gT2JqZWN0IGluc3RhbmNlcy4iLAog
+ICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgIm5hbWUiCiAgICAgIF0sCiAgICAgICJhZGRpdGlvbmFs
+UHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3BlcnRpZXMiOiB7CiAgICAgICAgIl54
+LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvc3BlY2lmaWNhdGlvbkV4dGVuc2lv
+biIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVzIjogewogICAgICAgICJuYW1lIjog
+ewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImRlc2NyaXB0aW9u
+IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgImV4dGVybmFs
+RG9jcyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXh0ZXJuYWxEb2NzIgogICAg
+ICAgIH0KICAgICAgfQogICAgfSwKICAgICJyZWZlcmVuY2UiOiB7CiAgICAgICJ0eXBlIjogIm9iamVj
+dCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJBIHNpbXBsZSBvYmplY3QgdG8gYWxsb3cgcmVmZXJlbmNp
+bmcgb3RoZXIgY29tcG9uZW50cyBpbiB0aGUgc3BlY2lmaWNhdGlvbiwgaW50ZXJuYWxseSBhbmQgZXh0
+ZXJuYWxseS4gIFRoZSBSZWZlcmVuY2UgT2JqZWN0IGlzIGRlZmluZWQgYnkgSlNPTiBSZWZlcmVuY2Ug
+YW5kIGZvbGxvd3MgdGhlIHNhbWUgc3RydWN0dXJlLCBiZWhhdmlvciBhbmQgcnVsZXMuICAgRm9yIHRo
+aXMgc3BlY2lmaWNhdGlvbiwgcmVmZXJlbmNlIHJlc29sdXRpb24gaXMgYWNjb21wbGlzaGVkIGFzIGRl
+ZmluZWQgYnkgdGhlIEpTT04gUmVmZXJlbmNlIHNwZWNpZmljYXRpb24gYW5kIG5vdCBieSB0aGUgSlNP
+TiBTY2hlbWEgc3BlY2lmaWNhdGlvbi4iLAogICAgICAicmVxdWlyZWQiOiBbCiAgICAgICAgIiRyZWYi
+CiAgICAgIF0sCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicHJvcGVy
+dGllcyI6IHsKICAgICAgICAiJHJlZiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAg
+ICB9LAogICAgICAgICJzdW1tYXJ5IjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAg
+IH0sCiAgICAgICAgImRlc2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAg
+ICAgIH0KICAgICAgfQogICAgfSwKICAgICJzY2hlbWEiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIs
+CiAgICAgICJkZXNjcmlwdGlvbiI6ICJUaGUgU2NoZW1hIE9iamVjdCBhbGxvd3MgdGhlIGRlZmluaXRp
+b24gb2YgaW5wdXQgYW5kIG91dHB1dCBkYXRhIHR5cGVzLiBUaGVzZSB0eXBlcyBjYW4gYmUgb2JqZWN0
+cywgYnV0IGFsc28gcHJpbWl0aXZlcyBhbmQgYXJyYXlzLiBUaGlzIG9iamVjdCBpcyBhbiBleHRlbmRl
+ZCBzdWJzZXQgb2YgdGhlIEpTT04gU2NoZW1hIFNwZWNpZmljYXRpb24gV3JpZ2h0IERyYWZ0IDAwLiAg
+Rm9yIG1vcmUgaW5mb3JtYXRpb24gYWJvdXQgdGhlIHByb3BlcnRpZXMsIHNlZSBKU09OIFNjaGVtYSBD
+b3JlIGFuZCBKU09OIFNjaGVtYSBWYWxpZGF0aW9uLiBVbmxlc3Mgc3RhdGVkIG90aGVyd2lzZSwgdGhl
+IHByb3BlcnR5IGRlZmluaXRpb25zIGZvbGxvdyB0aGUgSlNPTiBTY2hlbWEuIiwKICAgICAgImFkZGl0
+aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAg
+ICAiXngtIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0
+ZW5zaW9uIgogICAgICAgIH0KICAgICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgIm51
+bGxhYmxlIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgICJk
+aXNjcmltaW5hdG9yIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9kaXNjcmltaW5h
+dG9yIgogICAgICAgIH0sCiAgICAgICAgInJlYWRPbmx5IjogewogICAgICAgICAgInR5cGUiOiAiYm9v
+bGVhbiIKICAgICAgICB9LAogICAgICAgICJ3cml0ZU9ubHkiOiB7CiAgICAgICAgICAidHlwZSI6ICJi
+b29sZWFuIgogICAgICAgIH0sCiAgICAgICAgInhtbCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVm
+aW5pdGlvbnMveG1sIgogICAgICAgIH0sCiAgICAgICAgImV4dGVybmFsRG9jcyI6IHsKICAgICAgICAg
+ICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXh0ZXJuYWxEb2NzIgogICAgICAgIH0sCiAgICAgICAgImV4
+YW1wbGUiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2FueSIKICAgICAgICB9LAog
+ICAgICAgICJkZXByZWNhdGVkIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9
+LAogICAgICAgICJ0aXRsZSI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5v
+cmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL3RpdGxlIgogICAgICAgIH0sCiAgICAgICAgIm11
+bHRpcGxlT2YiOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0
+LTA0L3NjaGVtYSMvcHJvcGVydGllcy9tdWx0aXBsZU9mIgogICAgICAgIH0sCiAgICAgICAgIm1heGlt
+dW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9tYXhpbXVtIgogICAgICAgIH0sCiAgICAgICAgImV4Y2x1c2l2ZU1heGlt
+dW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9leGNsdXNpdmVNYXhpbXVtIgogICAgICAgIH0sCiAgICAgICAgIm1pbmlt
+dW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9taW5pbXVtIgogICAgICAgIH0sCiAgICAgICAgImV4Y2x1c2l2ZU1pbmlt
+dW0iOiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3Nj
+aGVtYSMvcHJvcGVydGllcy9leGNsdXNpdmVNaW5pbXVtIgogICAgICAgIH0sCiAgICAgICAgIm1heExl
+bmd0aCI6IHsKICAgICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQv
+c2NoZW1hIy9wcm9wZXJ0aWVzL21heExlbmd0aCIKICAgICAgICB9LAogICAgICAgICJtaW5MZW5ndGgi
+OiB7CiAgICAgICAgICAiJHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVt
+YSMvcHJvcGVydGllcy9taW5MZW5ndGgiCiAgICAgICAgfSwKICAgICAgICAicGF0dGVybiI6IHsKICAg
+ICAgICAgICIkcmVmIjogImh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9w
+ZXJ0aWVzL3BhdHRlcm4iCiAgICAgICAgfSwKICAgICAgICAibWF4SXRlbXMiOiB7CiAgICAgICAgICAi
+JHJlZiI6ICJodHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9t
+YXhJdGVtcyIKICAgICAgICB9LAogICAgICAgICJtaW5JdGVtcyI6IHsKICAgICAgICAgICIkcmVmIjog
+Imh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL21pbkl0ZW1z
+IgogICAgICAgIH0sCiAgICAgICAgInVuaXF1ZUl0ZW1zIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0
+cDovL2pzb24tc2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvdW5pcXVlSXRlbXMi
+CiAgICAgICAgfSwKICAgICAgICAibWF4UHJvcGVydGllcyI6IHsKICAgICAgICAgICIkcmVmIjogImh0
+dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL21heFByb3BlcnRp
+ZXMiCiAgICAgICAgfSwKICAgICAgICAibWluUHJvcGVydGllcyI6IHsKICAgICAgICAgICIkcmVmIjog
+Imh0dHA6Ly9qc29uLXNjaGVtYS5vcmcvZHJhZnQtMDQvc2NoZW1hIy9wcm9wZXJ0aWVzL21pblByb3Bl
+cnRpZXMiCiAgICAgICAgfSwKICAgICAgICAicmVxdWlyZWQiOiB7CiAgICAgICAgICAiJHJlZiI6ICJo
+dHRwOi8vanNvbi1zY2hlbWEub3JnL2RyYWZ0LTA0L3NjaGVtYSMvcHJvcGVydGllcy9yZXF1aXJlZCIK
+ICAgICAgICB9LAogICAgICAgICJlbnVtIjogewogICAgICAgICAgIiRyZWYiOiAiaHR0cDovL2pzb24t
+c2NoZW1hLm9yZy9kcmFmdC0wNC9zY2hlbWEjL3Byb3BlcnRpZXMvZW51bSIKICAgICAgICB9LAogICAg
+ICAgICJ0eXBlIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAg
+ImFsbE9mIjogewogICAgICAgICAgInR5cGUiOiAiYXJyYXkiLAogICAgICAgICAgIml0ZW1zIjogewog
+ICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NjaGVtYU9yUmVmZXJlbmNlIgogICAgICAg
+ICAgfSwKICAgICAgICAgICJtaW5JdGVtcyI6IDEKICAgICAgICB9LAogICAgICAgICJvbmVPZiI6IHsK
+ICAgICAgICAgICJ0eXBlIjogImFycmF5IiwKICAgICAgICAgICJpdGVtcyI6IHsKICAgICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zY2hlbWFPclJlZmVyZW5jZSIKICAgICAgICAgIH0sCiAgICAg
+ICAgICAibWluSXRlbXMiOiAxCiAgICAgICAgfSwKICAgICAgICAiYW55T2YiOiB7CiAgICAgICAgICAi
+dHlwZSI6ICJhcnJheSIsCiAgICAgICAgICAiaXRlbXMiOiB7CiAgICAgICAgICAgICIkcmVmIjogIiMv
+ZGVmaW5pdGlvbnMvc2NoZW1hT3JSZWZlcmVuY2UiCiAgICAgICAgICB9LAogICAgICAgICAgIm1pbkl0
+ZW1zIjogMQogICAgICAgIH0sCiAgICAgICAgIm5vdCI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVm
+aW5pdGlvbnMvc2NoZW1hIgogICAgICAgIH0sCiAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgImFu
+eU9mIjogWwogICAgICAgICAgICB7CiAgICAgICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9z
+Y2hlbWFPclJlZmVyZW5jZSIKICAgICAgICAgICAgfSwKICAgICAgICAgICAgewogICAgICAgICAgICAg
+ICJ0eXBlIjogImFycmF5IiwKICAgICAgICAgICAgICAiaXRlbXMiOiB7CiAgICAgICAgICAgICAgICAi
+JHJlZiI6ICIjL2RlZmluaXRpb25zL3NjaGVtYU9yUmVmZXJlbmNlIgogICAgICAgICAgICAgIH0sCiAg
+ICAgICAgICAgICAgIm1pbkl0ZW1zIjogMQogICAgICAgICAgICB9CiAgICAgICAgICBdCiAgICAgICAg
+fSwKICAgICAgICAicHJvcGVydGllcyI6IHsKICAgICAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAg
+ICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvc2NoZW1hT3JSZWZlcmVuY2UiCiAgICAgICAgICB9CiAgICAgICAgfSwKICAgICAgICAiYWRk
+aXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAgICAgICAib25lT2YiOiBbCiAgICAgICAgICAgIHsKICAg
+ICAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NjaGVtYU9yUmVmZXJlbmNlIgogICAgICAg
+ICAgICB9LAogICAgICAgICAgICB7CiAgICAgICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAg
+ICAgICAgfQogICAgICAgICAgXQogICAgICAgIH0sCiAgICAgICAgImRlZmF1bHQiOiB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2RlZmF1bHRUeXBlIgogICAgICAgIH0sCiAgICAgICAgImRl
+c2NyaXB0aW9uIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAg
+ImZvcm1hdCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9CiAgICAgIH0KICAg
+IH0sCiAgICAiZGlzY3JpbWluYXRvciI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRl
+c2NyaXB0aW9uIjogIldoZW4gcmVxdWVzdCBib2RpZXMgb3IgcmVzcG9uc2UgcGF5bG9hZHMgbWF5IGJl
+IG9uZSBvZiBhIG51bWJlciBvZiBkaWZmZXJlbnQgc2NoZW1hcywgYSBgZGlzY3JpbWluYXRvcmAgb2Jq
+ZWN0IGNhbiBiZSB1c2VkIHRvIGFpZCBpbiBzZXJpYWxpemF0aW9uLCBkZXNlcmlhbGl6YXRpb24sIGFu
+ZCB2YWxpZGF0aW9uLiAgVGhlIGRpc2NyaW1pbmF0b3IgaXMgYSBzcGVjaWZpYyBvYmplY3QgaW4gYSBz
+Y2hlbWEgd2hpY2ggaXMgdXNlZCB0byBpbmZvcm0gdGhlIGNvbnN1bWVyIG9mIHRoZSBzcGVjaWZpY2F0
+aW9uIG9mIGFuIGFsdGVybmF0aXZlIHNjaGVtYSBiYXNlZCBvbiB0aGUgdmFsdWUgYXNzb2NpYXRlZCB3
+aXRoIGl0LiAgV2hlbiB1c2luZyB0aGUgZGlzY3JpbWluYXRvciwgX2lubGluZV8gc2NoZW1hcyB3aWxs
+IG5vdCBiZSBjb25zaWRlcmVkLiIsCiAgICAgICJyZXF1aXJlZCI6IFsKICAgICAgICAicHJvcGVydHlO
+YW1lIgogICAgICBdLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBy
+b3BlcnRpZXMiOiB7CiAgICAgICAgInByb3BlcnR5TmFtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0
+cmluZyIKICAgICAgICB9LAogICAgICAgICJtYXBwaW5nIjogewogICAgICAgICAgIiRyZWYiOiAiIy9k
+ZWZpbml0aW9ucy9zdHJpbmdzIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJ4bWwiOiB7CiAg
+ICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJBIG1ldGFkYXRhIG9iamVj
+dCB0aGF0IGFsbG93cyBmb3IgbW9yZSBmaW5lLXR1bmVkIFhNTCBtb2RlbCBkZWZpbml0aW9ucy4gIFdo
+ZW4gdXNpbmcgYXJyYXlzLCBYTUwgZWxlbWVudCBuYW1lcyBhcmUgKm5vdCogaW5mZXJyZWQgKGZvciBz
+aW5ndWxhci9wbHVyYWwgZm9ybXMpIGFuZCB0aGUgYG5hbWVgIHByb3BlcnR5IFNIT1VMRCBiZSB1c2Vk
+IHRvIGFkZCB0aGF0IGluZm9ybWF0aW9uLiBTZWUgZXhhbXBsZXMgZm9yIGV4cGVjdGVkIGJlaGF2aW9y
+LiIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3Bl
+cnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+c3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVz
+IjogewogICAgICAgICJuYW1lIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0s
+CiAgICAgICAgIm5hbWVzcGFjZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9
+LAogICAgICAgICJwcmVmaXgiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwK
+ICAgICAgICAiYXR0cmlidXRlIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9
+LAogICAgICAgICJ3cmFwcGVkIjogewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9
+CiAgICAgIH0KICAgIH0sCiAgICAic2VjdXJpdHlTY2hlbWUiOiB7CiAgICAgICJ0eXBlIjogIm9iamVj
+dCIsCiAgICAgICJkZXNjcmlwdGlvbiI6ICJEZWZpbmVzIGEgc2VjdXJpdHkgc2NoZW1lIHRoYXQgY2Fu
+IGJlIHVzZWQgYnkgdGhlIG9wZXJhdGlvbnMuIFN1cHBvcnRlZCBzY2hlbWVzIGFyZSBIVFRQIGF1dGhl
+bnRpY2F0aW9uLCBhbiBBUEkga2V5IChlaXRoZXIgYXMgYSBoZWFkZXIgb3IgYXMgYSBxdWVyeSBwYXJh
+bWV0ZXIpLCBPQXV0aDIncyBjb21tb24gZmxvd3MgKGltcGxpY2l0LCBwYXNzd29yZCwgYXBwbGljYXRp
+b24gYW5kIGFjY2VzcyBjb2RlKSBhcyBkZWZpbmVkIGluIFJGQzY3NDksIGFuZCBPcGVuSUQgQ29ubmVj
+dCBEaXNjb3ZlcnkuIiwKICAgICAgInJlcXVpcmVkIjogWwogICAgICAgICJ0eXBlIgogICAgICBdLAog
+ICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiBmYWxzZSwKICAgICAgInBhdHRlcm5Qcm9wZXJ0aWVz
+IjogewogICAgICAgICJeeC0iOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NwZWNp
+ZmljYXRpb25FeHRlbnNpb24iCiAgICAgICAgfQogICAgICB9LAogICAgICAicHJvcGVydGllcyI6IHsK
+ICAgICAgICAidHlwZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAg
+ICAgICJkZXNjcmlwdGlvbiI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAog
+ICAgICAgICJuYW1lIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAg
+ICAgImluIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5nIgogICAgICAgIH0sCiAgICAgICAgInNj
+aGVtZSI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgICJiZWFy
+ZXJGb3JtYXQiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfSwKICAgICAgICAi
+Zmxvd3MiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29hdXRoRmxvd3MiCiAgICAg
+ICAgfSwKICAgICAgICAib3BlbklkQ29ubmVjdFVybCI6IHsKICAgICAgICAgICJ0eXBlIjogInN0cmlu
+ZyIKICAgICAgICB9CiAgICAgIH0KICAgIH0sCiAgICAib2F1dGhGbG93cyI6IHsKICAgICAgInR5cGUi
+OiAib2JqZWN0IiwKICAgICAgImRlc2NyaXB0aW9uIjogIkFsbG93cyBjb25maWd1cmF0aW9uIG9mIHRo
+ZSBzdXBwb3J0ZWQgT0F1dGggRmxvd3MuIiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFs
+c2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6IHsKICAgICAgICAiXngtIjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zcGVjaWZpY2F0aW9uRXh0ZW5zaW9uIgogICAgICAgIH0KICAg
+ICAgfSwKICAgICAgInByb3BlcnRpZXMiOiB7CiAgICAgICAgImltcGxpY2l0IjogewogICAgICAgICAg
+IiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vYXV0aEZsb3ciCiAgICAgICAgfSwKICAgICAgICAicGFzc3dv
+cmQiOiB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL29hdXRoRmxvdyIKICAgICAgICB9
+LAogICAgICAgICJjbGllbnRDcmVkZW50aWFscyI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvb2F1dGhGbG93IgogICAgICAgIH0sCiAgICAgICAgImF1dGhvcml6YXRpb25Db2RlIjogewog
+ICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9vYXV0aEZsb3ciCiAgICAgICAgfQogICAgICB9
+CiAgICB9LAogICAgIm9hdXRoRmxvdyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImRl
+c2NyaXB0aW9uIjogIkNvbmZpZ3VyYXRpb24gZGV0YWlscyBmb3IgYSBzdXBwb3J0ZWQgT0F1dGggRmxv
+dyIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IGZhbHNlLAogICAgICAicGF0dGVyblByb3Bl
+cnRpZXMiOiB7CiAgICAgICAgIl54LSI6IHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMv
+c3BlY2lmaWNhdGlvbkV4dGVuc2lvbiIKICAgICAgICB9CiAgICAgIH0sCiAgICAgICJwcm9wZXJ0aWVz
+IjogewogICAgICAgICJhdXRob3JpemF0aW9uVXJsIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5n
+IgogICAgICAgIH0sCiAgICAgICAgInRva2VuVXJsIjogewogICAgICAgICAgInR5cGUiOiAic3RyaW5n
+IgogICAgICAgIH0sCiAgICAgICAgInJlZnJlc2hVcmwiOiB7CiAgICAgICAgICAidHlwZSI6ICJzdHJp
+bmciCiAgICAgICAgfSwKICAgICAgICAic2NvcGVzIjogewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZp
+bml0aW9ucy9zdHJpbmdzIgogICAgICAgIH0KICAgICAgfQogICAgfSwKICAgICJzZWN1cml0eVJlcXVp
+cmVtZW50IjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiZGVzY3JpcHRpb24iOiAiTGlz
+dHMgdGhlIHJlcXVpcmVkIHNlY3VyaXR5IHNjaGVtZXMgdG8gZXhlY3V0ZSB0aGlzIG9wZXJhdGlvbi4g
+VGhlIG5hbWUgdXNlZCBmb3IgZWFjaCBwcm9wZXJ0eSBNVVNUIGNvcnJlc3BvbmQgdG8gYSBzZWN1cml0
+eSBzY2hlbWUgZGVjbGFyZWQgaW4gdGhlIFNlY3VyaXR5IFNjaGVtZXMgdW5kZXIgdGhlIENvbXBvbmVu
+dHMgT2JqZWN0LiAgU2VjdXJpdHkgUmVxdWlyZW1lbnQgT2JqZWN0cyB0aGF0IGNvbnRhaW4gbXVsdGlw
+bGUgc2NoZW1lcyByZXF1aXJlIHRoYXQgYWxsIHNjaGVtZXMgTVVTVCBiZSBzYXRpc2ZpZWQgZm9yIGEg
+cmVxdWVzdCB0byBiZSBhdXRob3JpemVkLiBUaGlzIGVuYWJsZXMgc3VwcG9ydCBmb3Igc2NlbmFyaW9z
+IHdoZXJlIG11bHRpcGxlIHF1ZXJ5IHBhcmFtZXRlcnMgb3IgSFRUUCBoZWFkZXJzIGFyZSByZXF1aXJl
+ZCB0byBjb252ZXkgc2VjdXJpdHkgaW5mb3JtYXRpb24uICBXaGVuIGEgbGlzdCBvZiBTZWN1cml0eSBS
+ZXF1aXJlbWVudCBPYmplY3RzIGlzIGRlZmluZWQgb24gdGhlIE9wZW4gQVBJIG9iamVjdCBvciBPcGVy
+YXRpb24gT2JqZWN0LCBvbmx5IG9uZSBvZiBTZWN1cml0eSBSZXF1aXJlbWVudCBPYmplY3RzIGluIHRo
+ZSBsaXN0IG5lZWRzIHRvIGJlIHNhdGlzZmllZCB0byBhdXRob3JpemUgdGhlIHJlcXVlc3QuIiwKICAg
+ICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogZmFsc2UsCiAgICAgICJwYXR0ZXJuUHJvcGVydGllcyI6
+IHsKICAgICAgICAiXlthLXpBLVowLTlcXC5cXC1fXSskIjogewogICAgICAgICAgInR5cGUiOiAiYXJy
+YXkiLAogICAgICAgICAgIml0ZW1zIjogewogICAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAg
+ICAgICB9LAogICAgICAgICAgInVuaXF1ZUl0ZW1zIjogdHJ1ZQogICAgICAgIH0KICAgICAgfQogICAg
+fSwKICAgICJhbnlPckV4cHJlc3Npb24iOiB7CiAgICAgICJvbmVPZiI6IFsKICAgICAgICB7CiAgICAg
+ICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2FueSIKICAgICAgICB9LAogICAgICAgIHsKICAgICAg
+ICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvZXhwcmVzc2lvbiIKICAgICAgICB9CiAgICAgIF0KICAg
+IH0sCiAgICAiY2FsbGJhY2tPclJlZmVyZW5jZSI6IHsKICAgICAgIm9uZU9mIjogWwogICAgICAgIHsK
+ICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvY2FsbGJhY2siCiAgICAgICAgfSwKICAgICAg
+ICB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3JlZmVyZW5jZSIKICAgICAgICB9CiAg
+ICAgIF0KICAgIH0sCiAgICAiZXhhbXBsZU9yUmVmZXJlbmNlIjogewogICAgICAib25lT2YiOiBbCiAg
+ICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGFtcGxlIgogICAgICAgIH0s
+CiAgICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9yZWZlcmVuY2UiCiAgICAg
+ICAgfQogICAgICBdCiAgICB9LAogICAgImhlYWRlck9yUmVmZXJlbmNlIjogewogICAgICAib25lT2Yi
+OiBbCiAgICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9oZWFkZXIiCiAgICAg
+ICAgfSwKICAgICAgICB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3JlZmVyZW5jZSIK
+ICAgICAgICB9CiAgICAgIF0KICAgIH0sCiAgICAibGlua09yUmVmZXJlbmNlIjogewogICAgICAib25l
+T2YiOiBbCiAgICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9saW5rIgogICAg
+ICAgIH0sCiAgICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9yZWZlcmVuY2Ui
+CiAgICAgICAgfQogICAgICBdCiAgICB9LAogICAgInBhcmFtZXRlck9yUmVmZXJlbmNlIjogewogICAg
+ICAib25lT2YiOiBbCiAgICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9wYXJh
+bWV0ZXIiCiAgICAgICAgfSwKICAgICAgICB7CiAgICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25z
+L3JlZmVyZW5jZSIKICAgICAgICB9CiAgICAgIF0KICAgIH0sCiAgICAicmVxdWVzdEJvZHlPclJlZmVy
+ZW5jZSI6IHsKICAgICAgIm9uZU9mIjogWwogICAgICAgIHsKICAgICAgICAgICIkcmVmIjogIiMvZGVm
+aW5pdGlvbnMvcmVxdWVzdEJvZHkiCiAgICAgICAgfSwKICAgICAgICB7CiAgICAgICAgICAiJHJlZiI6
+ICIjL2RlZmluaXRpb25zL3JlZmVyZW5jZSIKICAgICAgICB9CiAgICAgIF0KICAgIH0sCiAgICAicmVz
+cG9uc2VPclJlZmVyZW5jZSI6IHsKICAgICAgIm9uZU9mIjogWwogICAgICAgIHsKICAgICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvcmVzcG9uc2UiCiAgICAgICAgfSwKICAgICAgICB7CiAgICAgICAg
+ICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3JlZmVyZW5jZSIKICAgICAgICB9CiAgICAgIF0KICAgIH0s
+CiAgICAic2NoZW1hT3JSZWZlcmVuY2UiOiB7CiAgICAgICJvbmVPZiI6IFsKICAgICAgICB7CiAgICAg
+ICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3NjaGVtYSIKICAgICAgICB9LAogICAgICAgIHsKICAg
+ICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcmVmZXJlbmNlIgogICAgICAgIH0KICAgICAgXQog
+ICAgfSwKICAgICJzZWN1cml0eVNjaGVtZU9yUmVmZXJlbmNlIjogewogICAgICAib25lT2YiOiBbCiAg
+ICAgICAgewogICAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zZWN1cml0eVNjaGVtZSIKICAg
+ICAgICB9LAogICAgICAgIHsKICAgICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcmVmZXJlbmNl
+IgogICAgICAgIH0KICAgICAgXQogICAgfSwKICAgICJhbnlzT3JFeHByZXNzaW9ucyI6IHsKICAgICAg
+InR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIk
+cmVmIjogIiMvZGVmaW5pdGlvbnMvYW55T3JFeHByZXNzaW9uIgogICAgICB9CiAgICB9LAogICAgImNh
+bGxiYWNrc09yUmVmZXJlbmNlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0
+aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvY2FsbGJhY2tP
+clJlZmVyZW5jZSIKICAgICAgfQogICAgfSwKICAgICJlbmNvZGluZ3MiOiB7CiAgICAgICJ0eXBlIjog
+Im9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IHsKICAgICAgICAiJHJlZiI6ICIj
+L2RlZmluaXRpb25zL2VuY29kaW5nIgogICAgICB9CiAgICB9LAogICAgImV4YW1wbGVzT3JSZWZlcmVu
+Y2VzIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMi
+OiB7CiAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9leGFtcGxlT3JSZWZlcmVuY2UiCiAgICAg
+IH0KICAgIH0sCiAgICAiaGVhZGVyc09yUmVmZXJlbmNlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0
+IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvaGVhZGVyT3JSZWZlcmVuY2UiCiAgICAgIH0KICAgIH0sCiAgICAibGlua3NPclJlZmVyZW5j
+ZXMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6
+IHsKICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL2xpbmtPclJlZmVyZW5jZSIKICAgICAgfQog
+ICAgfSwKICAgICJtZWRpYVR5cGVzIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRk
+aXRpb25hbFByb3BlcnRpZXMiOiB7CiAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9tZWRpYVR5
+cGUiCiAgICAgIH0KICAgIH0sCiAgICAicGFyYW1ldGVyc09yUmVmZXJlbmNlcyI6IHsKICAgICAgInR5
+cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvcGFyYW1ldGVyT3JSZWZlcmVuY2UiCiAgICAgIH0KICAgIH0sCiAgICAi
+cmVxdWVzdEJvZGllc09yUmVmZXJlbmNlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAg
+ImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5pdGlvbnMvcmVx
+dWVzdEJvZHlPclJlZmVyZW5jZSIKICAgICAgfQogICAgfSwKICAgICJyZXNwb25zZXNPclJlZmVyZW5j
+ZXMiOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6
+IHsKICAgICAgICAiJHJlZiI6ICIjL2RlZmluaXRpb25zL3Jlc3BvbnNlT3JSZWZlcmVuY2UiCiAgICAg
+IH0KICAgIH0sCiAgICAic2NoZW1hc09yUmVmZXJlbmNlcyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0
+IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVmIjogIiMvZGVmaW5p
+dGlvbnMvc2NoZW1hT3JSZWZlcmVuY2UiCiAgICAgIH0KICAgIH0sCiAgICAic2VjdXJpdHlTY2hlbWVz
+T3JSZWZlcmVuY2VzIjogewogICAgICAidHlwZSI6ICJvYmplY3QiLAogICAgICAiYWRkaXRpb25hbFBy
+b3BlcnRpZXMiOiB7CiAgICAgICAgIiRyZWYiOiAiIy9kZWZpbml0aW9ucy9zZWN1cml0eVNjaGVtZU9y
+UmVmZXJlbmNlIgogICAgICB9CiAgICB9LAogICAgInNlcnZlclZhcmlhYmxlcyI6IHsKICAgICAgInR5
+cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjogewogICAgICAgICIkcmVm
+IjogIiMvZGVmaW5pdGlvbnMvc2VydmVyVmFyaWFibGUiCiAgICAgIH0KICAgIH0sCiAgICAic3RyaW5n
+cyI6IHsKICAgICAgInR5cGUiOiAib2JqZWN0IiwKICAgICAgImFkZGl0aW9uYWxQcm9wZXJ0aWVzIjog
+ewogICAgICAgICJ0eXBlIjogInN0cmluZyIKICAgICAgfQogICAgfSwKICAgICJvYmplY3QiOiB7CiAg
+ICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFsUHJvcGVydGllcyI6IHRydWUKICAg
+IH0sCiAgICAiYW55IjogewogICAgICAiYWRkaXRpb25hbFByb3BlcnRpZXMiOiB0cnVlCiAgICB9LAog
+ICAgImV4cHJlc3Npb24iOiB7CiAgICAgICJ0eXBlIjogIm9iamVjdCIsCiAgICAgICJhZGRpdGlvbmFs
+UHJvcGVydGllcyI6IHRydWUKICAgIH0sCiAgICAic3BlY2lmaWNhdGlvbkV4dGVuc2lvbiI6IHsKICAg
+ICAgImRlc2NyaXB0aW9uIjogIkFueSBwcm9wZXJ0eSBzdGFydGluZyB3aXRoIHgtIGlzIHZhbGlkLiIs
+CiAgICAgICJvbmVPZiI6IFsKICAgICAgICB7CiAgICAgICAgICAidHlwZSI6ICJudWxsIgogICAgICAg
+IH0sCiAgICAgICAgewogICAgICAgICAgInR5cGUiOiAibnVtYmVyIgogICAgICAgIH0sCiAgICAgICAg
+ewogICAgICAgICAgInR5cGUiOiAiYm9vbGVhbiIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAg
+ICJ0eXBlIjogInN0cmluZyIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAgICJ0eXBlIjogIm9i
+amVjdCIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAgICJ0eXBlIjogImFycmF5IgogICAgICAg
+IH0KICAgICAgXQogICAgfSwKICAgICJkZWZhdWx0VHlwZSI6IHsKICAgICAgIm9uZU9mIjogWwogICAg
+ICAgIHsKICAgICAgICAgICJ0eXBlIjogIm51bGwiCiAgICAgICAgfSwKICAgICAgICB7CiAgICAgICAg
+ICAidHlwZSI6ICJhcnJheSIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAgICJ0eXBlIjogIm9i
+amVjdCIKICAgICAgICB9LAogICAgICAgIHsKICAgICAgICAgICJ0eXBlIjogIm51bWJlciIKICAgICAg
+ICB9LAogICAgICAgIHsKICAgICAgICAgICJ0eXBlIjogImJvb2xlYW4iCiAgICAgICAgfSwKICAgICAg
+ICB7CiAgICAgICAgICAidHlwZSI6ICJzdHJpbmciCiAgICAgICAgfQogICAgICBdCiAgICB9CiAgfQp9
+Cg==`)
+}
@@ -0,0 +1,490 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run generate-schemas.go
+
+// Package lsp implements a language server for OpenAPI descriptions. It
+// reports the problems that the compiler and the built-in linter find,
+// shows the documentation of keywords and the targets of references,
+// finds the definitions of references, and completes keywords.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	"github.com/google/gnostic/findings"
+	"github.com/google/gnostic/lib"
+	lint "github.com/google/gnostic/metrics/lint"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// The name of the server, which is the source of its diagnostics.
+const serverName = "gnostic"
+
+// Server is a language server. It handles the messages of a client that
+// are read from one stream and writes its responses to another.
+type Server struct {
+	documents    map[string]*document
+	compilations map[string]*lib.Compilation
+	v2           *vocabulary
+	v3           *vocabulary
+
+	out      *bufio.Writer
+	mutex    sync.Mutex // guards out
+	shutdown bool
+}
+
+// NewServer returns a server with no open documents.
+func NewServer() (*Server, error) {
+	v2, err := newVocabulary(openAPIv2SchemaBytes)
+	if err != nil {
+		return nil, err
+	}
+	v3, err := newVocabulary(openAPIv3SchemaBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		documents:    make(map[string]*document),
+		compilations: make(map[string]*lib.Compilation),
+		v2:           v2,
+		v3:           v3,
+	}, nil
+}
+
+// Serve reads messages from r and writes responses and notifications to w
+// until r is closed or the client sends an exit notification.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = bufio.NewWriter(w)
+	in := bufio.NewReader(r)
+	for {
+		body, err := readMessage(in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var m message
+		if err = json.Unmarshal(body, &m); err != nil {
+			if err = s.write(&message{Error: &responseError{Code: codeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if m.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit before shutdown")
+			}
+			return nil
+		}
+		if err = s.handle(&m); err != nil {
+			return err
+		}
+	}
+}
+
+// Read the body of a message, which follows a header with its length.
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// Write a message with a header that gives its length.
+func (s *Server) write(m *message) error {
+	m.JSONRPC = "2.0"
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	if _, err = s.out.Write(body); err != nil {
+		return err
+	}
+	return s.out.Flush()
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(&message{Method: method, Params: b})
+}
+
+// Handle a request or notification. Only errors in writing responses
+// are returned; other errors are sent to the client.
+func (s *Server) handle(m *message) error {
+	result, rerr := s.dispatch(m)
+	if m.ID == nil {
+		// Notifications have no responses.
+		return nil
+	}
+	response := &message{ID: m.ID, Error: rerr}
+	if rerr == nil {
+		if result == nil {
+			// A response must have a result or an error.
+			result = json.RawMessage("null")
+		}
+		response.Result = result
+	}
+	return s.write(response)
+}
+
+func (s *Server) dispatch(m *message) (interface{}, *responseError) {
+	invalid := func(err error) *responseError {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	switch m.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   2, // changes are sent as edits
+				"hoverProvider":      true,
+				"definitionProvider": true,
+				"completionProvider": map[string]interface{}{},
+			},
+			"serverInfo": map[string]string{"name": "gnostic-lsp"},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalid(err)
+		}
+		s.open(p.TextDocument.URI, p.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalid(err)
+		}
+		s.change(p.TextDocument.URI, p)
+		return nil, nil
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalid(err)
+		}
+		delete(s.documents, p.TextDocument.URI)
+		delete(s.compilations, p.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+			URI:         p.TextDocument.URI,
+			Diagnostics: []*Diagnostic{},
+		})
+		return nil, nil
+	case "textDocument/hover", "textDocument/definition", "textDocument/completion":
+		var p textDocumentPositionParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalid(err)
+		}
+		d := s.documents[p.TextDocument.URI]
+		if d == nil {
+			return nil, nil
+		}
+		switch m.Method {
+		case "textDocument/hover":
+			return s.hover(d, p.Position), nil
+		case "textDocument/definition":
+			return s.definition(d, p.Position), nil
+		default:
+			return s.completion(d, p.Position), nil
+		}
+	}
+	if m.ID == nil || strings.HasPrefix(m.Method, "$/") {
+		// Unknown notifications, like "initialized", are ignored.
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "unsupported method " + m.Method}
+}
+
+// Open a document and publish its diagnostics.
+func (s *Server) open(uri, text string) {
+	c := lib.NewCompilation(lib.Options{SourceName: pathForURI(uri)})
+	s.compilations[uri] = c
+	model, err := c.Compile(context.Background(), []byte(text))
+	s.publish(uri, model, err)
+}
+
+// Apply changes to a document and publish its diagnostics. Changes within
+// single paths or components only recompile what they changed.
+func (s *Server) change(uri string, p didChangeParams) {
+	c := s.compilations[uri]
+	if c == nil || len(p.ContentChanges) == 0 {
+		return
+	}
+	var model proto.Message
+	var err error
+	for _, change := range p.ContentChanges {
+		if change.Range == nil {
+			model, err = c.Compile(context.Background(), []byte(change.Text))
+			continue
+		}
+		model, err = c.Apply(context.Background(), lib.TextEdit{
+			Start: compiler.Location{Line: change.Range.Start.Line + 1, Column: change.Range.Start.Character + 1},
+			End:   compiler.Location{Line: change.Range.End.Line + 1, Column: change.Range.End.Character + 1},
+			Text:  change.Text,
+		})
+	}
+	s.publish(uri, model, err)
+}
+
+// Update the document of a compilation and publish its diagnostics.
+func (s *Server) publish(uri string, model proto.Message, err error) {
+	d := newDocument(uri, string(s.compilations[uri].Source()))
+	s.documents[uri] = d
+	s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: s.diagnostics(d, model, err),
+	})
+}
+
+// Lint a compiled document and report its problems, or report the errors
+// of compilation. Problems are placed at the values that they describe.
+func (s *Server) diagnostics(d *document, model proto.Message, err error) []*Diagnostic {
+	var fs []*findings.Finding
+	switch model := model.(type) {
+	case *openapi_v2.Document:
+		fs = lint.LintV2(model)
+	case *openapi_v3.Document:
+		fs = lint.LintV3(model)
+	}
+	if err != nil {
+		fs = findings.FromError(d.path, err)
+	}
+	findings.Locate(fs, d.path, d.index)
+	diagnostics := make([]*Diagnostic, 0, len(fs))
+	for _, f := range fs {
+		diagnostics = append(diagnostics, &Diagnostic{
+			Range:    d.problemRange(f.Line, f.Column, f.Message),
+			Severity: severity(f.Severity),
+			Code:     f.Rule,
+			Source:   serverName,
+			Message:  f.Message,
+		})
+	}
+	return diagnostics
+}
+
+func severity(s string) int {
+	switch s {
+	case findings.Error, findings.Fatal:
+		return SeverityError
+	case findings.Warning:
+		return SeverityWarning
+	}
+	return SeverityInformation
+}
+
+func (s *Server) vocabulary(d *document) *vocabulary {
+	if d.isOpenAPIv2() {
+		return s.v2
+	}
+	return s.v3
+}
+
+// Show the documentation of a keyword or the target of a reference.
+func (s *Server) hover(d *document, p Position) *Hover {
+	h := d.hitAt(p)
+	if h == nil {
+		return nil
+	}
+	r := nodeRange(h.node)
+	if ref := refAt(h); ref != "" {
+		target, location := s.target(d, ref)
+		if target == nil {
+			return nil
+		}
+		text := "`" + ref + "`"
+		if description := describeTarget(target); description != "" {
+			text += "\n\n" + description
+		}
+		text += fmt.Sprintf("\n\nDefined at %s:%d", pathForURI(location.URI), location.Range.Start.Line+1)
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: &r}
+	}
+	if !h.key {
+		return nil
+	}
+	tokens, err := jsonpointer.Parse(h.pointer)
+	if err != nil || len(tokens) == 0 {
+		return nil
+	}
+	v := s.vocabulary(d)
+	schema := v.schemaAt(tokens)
+	if schema == nil {
+		return nil
+	}
+	k := v.describe(h.node.Value, schema)
+	text := "**" + k.name + "**"
+	if k.kind != "" {
+		text += " (" + k.kind + ")"
+	}
+	if k.description != "" {
+		text += "\n\n" + k.description
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: &r}
+}
+
+// Get the reference at a hit, if it is the value of a $ref.
+func refAt(h *hit) string {
+	if h.key || !strings.HasSuffix(h.pointer, "/$ref") {
+		return ""
+	}
+	return h.node.Value
+}
+
+// Describe the target of a reference with its description or title.
+func describeTarget(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	var title string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case "description":
+			return node.Content[i+1].Value
+		case "title":
+			title = node.Content[i+1].Value
+		}
+	}
+	return title
+}
+
+// Find the target of a reference.
+func (s *Server) definition(d *document, p Position) *Location {
+	h := d.hitAt(p)
+	if h == nil {
+		return nil
+	}
+	ref := refAt(h)
+	if ref == "" {
+		return nil
+	}
+	_, location := s.target(d, ref)
+	return location
+}
+
+// Find the node that a reference refers to and its location. Targets in
+// other files are read from open documents or from disk.
+func (s *Server) target(d *document, ref string) (*yaml.Node, *Location) {
+	filename := compiler.FilenameForRef(d.path, ref)
+	var root *yaml.Node
+	var index *compiler.LocationIndex
+	uri := uriForPath(filename)
+	if filename == d.path {
+		uri, root, index = d.uri, d.root, d.index
+	} else if other := s.documents[uri]; other != nil {
+		root, index = other.root, other.index
+	} else {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, nil
+		}
+		var node yaml.Node
+		if err = yaml.Unmarshal(b, &node); err != nil || len(node.Content) == 0 {
+			return nil, nil
+		}
+		root = node.Content[0]
+		index = compiler.NewLocationIndex(root)
+	}
+	if root == nil {
+		return nil, nil
+	}
+	fragment := ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		fragment = ref[i+1:]
+	}
+	tokens, err := jsonpointer.ParseFragment(fragment)
+	if err != nil {
+		return nil, nil
+	}
+	pointer := jsonpointer.Format(tokens...)
+	node, err := jsonpointer.Resolve(root, pointer)
+	if err != nil {
+		return nil, nil
+	}
+	location, ok := index.Location(pointer)
+	if !ok {
+		return nil, nil
+	}
+	start := Position{Line: location.Line - 1, Character: location.Column - 1}
+	return node, &Location{URI: uri, Range: Range{Start: start, End: start}}
+}
+
+// Complete the keywords of the object at a position. Keywords that the
+// object already has are not suggested.
+func (s *Server) completion(d *document, p Position) []*CompletionItem {
+	items := make([]*CompletionItem, 0)
+	pointer, ok := d.objectAt(p)
+	if !ok {
+		return items
+	}
+	tokens, err := jsonpointer.Parse(pointer)
+	if err != nil {
+		return items
+	}
+	existing := d.keys(pointer)
+	for _, k := range s.vocabulary(d).keywords(tokens) {
+		if existing[k.name] {
+			continue
+		}
+		item := &CompletionItem{
+			Label:      k.name,
+			Kind:       completionItemKindProperty,
+			Detail:     k.kind,
+			InsertText: k.name + ": ",
+		}
+		if k.description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: k.description}
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/gnostic/lib"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          $ref: "#/components/responses/Pets"
+components:
+  responses:
+    Pets:
+      description: A list of pets.
+  schemas:
+    Pet:
+      type: object
+      
+`
+
+// Run a session with a server and return the messages that it wrote.
+func session(t *testing.T, requests ...*message) []*message {
+	t.Helper()
+	var in bytes.Buffer
+	for i, r := range requests {
+		if r.Method != "initialized" && !strings.HasPrefix(r.Method, "textDocument/did") && r.Method != "exit" {
+			id := json.RawMessage(fmt.Sprintf("%d", i))
+			r.ID = &id
+		}
+		r.JSONRPC = "2.0"
+		body, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	server, err := NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err = server.Serve(&in, &out); err != nil {
+		t.Fatal(err)
+	}
+	messages := make([]*message, 0)
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var m message
+		if err = json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, &m)
+	}
+	return messages
+}
+
+// Get a change that inserts text at the start of a line.
+func change(uri string, line int, text string) *didChangeParams {
+	p := &didChangeParams{TextDocument: textDocumentIdentifier{URI: uri}}
+	p.ContentChanges = append(p.ContentChanges, contentChange{
+		Range: &Range{Start: Position{Line: line}, End: Position{Line: line}},
+		Text:  text,
+	})
+	return p
+}
+
+func request(method string, params interface{}) *message {
+	b, _ := json.Marshal(params)
+	return &message{Method: method, Params: b}
+}
+
+func position(uri string, line, character int) *textDocumentPositionParams {
+	p := &textDocumentPositionParams{Position: Position{Line: line, Character: character}}
+	p.TextDocument.URI = uri
+	return p
+}
+
+// Get the result of the response to the request with an id.
+func result(t *testing.T, messages []*message, id int, v interface{}) {
+	t.Helper()
+	for _, m := range messages {
+		if m.ID != nil && string(*m.ID) == fmt.Sprintf("%d", id) {
+			if m.Error != nil {
+				t.Fatalf("request %d failed: %s", id, m.Error.Message)
+			}
+			b, _ := json.Marshal(m.Result)
+			if err := json.Unmarshal(b, v); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic-lsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	uri := uriForPath(filepath.Join(dir, "openapi.yaml"))
+	messages := session(t,
+		request("initialize", map[string]interface{}{}),
+		request("initialized", map[string]interface{}{}),
+		request("textDocument/didOpen", &didOpenParams{TextDocument: textDocumentItem{URI: uri, Text: testDocument}}),
+		request("textDocument/hover", position(uri, 7, 8)),
+		request("textDocument/hover", position(uri, 10, 20)),
+		request("textDocument/definition", position(uri, 10, 20)),
+		request("textDocument/completion", position(uri, 18, 6)),
+		request("textDocument/completion", position(uri, 6, 6)),
+		request("textDocument/didChange", change(uri, 17, "      bogus: 1\n")),
+		request("shutdown", nil),
+		request("exit", nil),
+	)
+
+	var capabilities struct {
+		Capabilities struct {
+			HoverProvider bool `json:"hoverProvider"`
+		} `json:"capabilities"`
+	}
+	result(t, messages, 0, &capabilities)
+	if !capabilities.Capabilities.HoverProvider {
+		t.Errorf("expected hover to be supported")
+	}
+
+	published := make([]*publishDiagnosticsParams, 0)
+	for _, m := range messages {
+		if m.Method == "textDocument/publishDiagnostics" {
+			var p publishDiagnosticsParams
+			if err := json.Unmarshal(m.Params, &p); err != nil {
+				t.Fatal(err)
+			}
+			published = append(published, &p)
+		}
+	}
+	if len(published) != 2 || published[0].URI != uri || len(published[0].Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %+v", published)
+	}
+	// The edit adds an invalid property to a schema.
+	if d := published[1].Diagnostics; len(d) != 1 || d[0].Range.Start.Line != 17 {
+		t.Errorf("unexpected diagnostics after an edit: %+v", d)
+	}
+
+	var hover Hover
+	result(t, messages, 3, &hover)
+	if !strings.Contains(hover.Contents.Value, "**operationId** (string)") {
+		t.Errorf("unexpected hover for a keyword: %q", hover.Contents.Value)
+	}
+	result(t, messages, 4, &hover)
+	if !strings.Contains(hover.Contents.Value, "A list of pets.") {
+		t.Errorf("unexpected hover for a reference: %q", hover.Contents.Value)
+	}
+
+	var location Location
+	result(t, messages, 5, &location)
+	if location.URI != uri || location.Range.Start.Line != 13 || location.Range.Start.Character != 4 {
+		t.Errorf("unexpected definition: %+v", location)
+	}
+
+	var items []*CompletionItem
+	result(t, messages, 6, &items)
+	if !hasItem(items, "properties") || hasItem(items, "type") {
+		t.Errorf("unexpected completions for a schema: %d items", len(items))
+	}
+	result(t, messages, 7, &items)
+	if !hasItem(items, "summary") || hasItem(items, "operationId") {
+		t.Errorf("unexpected completions for an operation: %d items", len(items))
+	}
+}
+
+func hasItem(items []*CompletionItem, label string) bool {
+	for _, item := range items {
+		if item.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDiagnostics(t *testing.T) {
+	server, err := NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDocument("file:///openapi.yaml", "openapi: 3.0.0\ninfo:\n  title: Test\n  version: 1.0.0\npaths: {}\nbogus: 1\n")
+	model, err := lib.Compile(context.Background(), []byte(d.text), lib.Options{SourceName: d.path})
+	diagnostics := server.diagnostics(d, model, err)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected one diagnostic, got %d", len(diagnostics))
+	}
+	if diagnostics[0].Severity != SeverityError || diagnostics[0].Range.Start.Line != 5 || diagnostics[0].Range.End.Character != 5 {
+		t.Errorf("unexpected diagnostic: %+v", diagnostics[0])
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Service is an OpenAPI v3 document that is bundled under a namespace.
+type Service struct {
+	// Namespace prefixes the names of the components, tags, and
+	// operations of the document, such as "Library".
+	Namespace string
+	Document  *yaml.Node
+}
+
+// operationMethods are the keys of the operations of path items.
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Bundle combines the OpenAPI v3 documents of services into one, such as
+// the description of the services of an API portal. Unlike Merge, which
+// only renames components when they collide, Bundle puts everything that
+// a document names under the namespace of its service: components are
+// renamed with the namespace and a dot as prefix ("Library.Book") and
+// references to them are updated, and tags and operation IDs are prefixed
+// the same way. Operations without tags are tagged with the namespace so
+// that each service keeps its own group of operations. If the documents
+// have different servers, the servers of each document are moved to its
+// path items. The namespaced documents are then merged with Merge, which
+// reports conflicts such as paths that are described by more than one
+// service.
+func Bundle(services ...*Service) (*yaml.Node, []*Conflict, error) {
+	if len(services) == 0 {
+		return nil, nil, errors.New("no documents to bundle")
+	}
+	roots := make([]*yaml.Node, len(services))
+	namespaces := make(map[string]int)
+	for i, service := range services {
+		root := service.Document
+		if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root == nil || root.Kind != yaml.MappingNode || compiler.MapValueForKey(root, "openapi") == nil {
+			return nil, nil, fmt.Errorf("document %d is not an OpenAPI v3 document", i+1)
+		}
+		if service.Namespace == "" {
+			return nil, nil, fmt.Errorf("document %d has no namespace", i+1)
+		}
+		if j, ok := namespaces[service.Namespace]; ok {
+			return nil, nil, fmt.Errorf("document %d has the same namespace as document %d", i+1, j+1)
+		}
+		namespaces[service.Namespace] = i
+		roots[i] = compiler.CopyNode(root)
+	}
+	moveServers := false
+	for _, root := range roots[1:] {
+		if !equalValues(compiler.MapValueForKey(root, "servers"), compiler.MapValueForKey(roots[0], "servers")) {
+			moveServers = true
+			break
+		}
+	}
+	for i, root := range roots {
+		namespace(root, services[i].Namespace, moveServers)
+	}
+	return Merge(roots...)
+}
+
+// namespace prefixes the names of the components, tags, and operations
+// of a document with a namespace.
+func namespace(root *yaml.Node, namespace string, moveServers bool) {
+	prefixed := func(name string) string {
+		return namespace + "." + name
+	}
+	renames := make(map[string]string)
+	for _, section := range referencedSectionsV3 {
+		components := lookup(root, section)
+		if components == nil || components.Kind != yaml.MappingNode {
+			continue
+		}
+		prefix := "#" + jsonpointer.Format(section...) + "/"
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			key := components.Content[i]
+			renames[prefix+jsonpointer.Escape(key.Value)] = prefix + jsonpointer.Escape(prefixed(key.Value))
+			key.Value = prefixed(key.Value)
+		}
+	}
+	if len(renames) > 0 {
+		renameReferences(root, renames)
+	}
+
+	if tags := compiler.MapValueForKey(root, "tags"); tags != nil {
+		for _, tag := range tags.Content {
+			if name := compiler.MapValueForKey(tag, "name"); name != nil {
+				name.Value = prefixed(name.Value)
+			}
+		}
+	}
+	untagged := false
+	servers := compiler.MapValueForKey(root, "servers")
+	forEachValue(compiler.MapValueForKey(root, "paths"), func(pathItem *yaml.Node) {
+		forEachOperation(pathItem, func(operation *yaml.Node) {
+			if tags := compiler.MapValueForKey(operation, "tags"); tags != nil && len(tags.Content) > 0 {
+				for _, tag := range tags.Content {
+					tag.Value = prefixed(tag.Value)
+				}
+			} else {
+				untagged = true
+				setMapValue(operation, "tags", compiler.NewSequenceNodeForStringArray([]string{namespace}))
+			}
+			prefixOperationIDs(operation, prefixed)
+		})
+		if moveServers && servers != nil && compiler.MapValueForKey(pathItem, "servers") == nil {
+			setMapValue(pathItem, "servers", compiler.CopyNode(servers))
+		}
+	})
+	if moveServers {
+		deleteMapValue(root, "servers")
+	}
+	if untagged {
+		tag := compiler.NewMappingNode()
+		setMapValue(tag, "name", compiler.NewScalarNodeForString(namespace))
+		tags := compiler.MapValueForKey(root, "tags")
+		if tags == nil {
+			tags = compiler.NewSequenceNode()
+			setMapValue(root, "tags", tags)
+		}
+		tags.Content = append(tags.Content, tag)
+	}
+
+	// Operation IDs are also used by links and by the operations of
+	// callbacks, which can be components.
+	if components := compiler.MapValueForKey(root, "components"); components != nil {
+		forEachValue(compiler.MapValueForKey(components, "responses"), func(response *yaml.Node) {
+			prefixLinks(compiler.MapValueForKey(response, "links"), prefixed)
+		})
+		prefixLinks(compiler.MapValueForKey(components, "links"), prefixed)
+		forEachValue(compiler.MapValueForKey(components, "callbacks"), func(callback *yaml.Node) {
+			forEachValue(callback, func(pathItem *yaml.Node) {
+				forEachOperation(pathItem, func(operation *yaml.Node) {
+					prefixOperationIDs(operation, prefixed)
+				})
+			})
+		})
+	}
+}
+
+// prefixOperationIDs prefixes the ID of an operation and the operation IDs
+// that are used in the links of its responses and in its callbacks.
+func prefixOperationIDs(operation *yaml.Node, prefixed func(string) string) {
+	if id := compiler.MapValueForKey(operation, "operationId"); id != nil && id.Kind == yaml.ScalarNode {
+		id.Value = prefixed(id.Value)
+	}
+	forEachValue(compiler.MapValueForKey(operation, "responses"), func(response *yaml.Node) {
+		prefixLinks(compiler.MapValueForKey(response, "links"), prefixed)
+	})
+	forEachValue(compiler.MapValueForKey(operation, "callbacks"), func(callback *yaml.Node) {
+		forEachValue(callback, func(pathItem *yaml.Node) {
+			forEachOperation(pathItem, func(operation *yaml.Node) {
+				prefixOperationIDs(operation, prefixed)
+			})
+		})
+	})
+}
+
+// prefixLinks prefixes the operation IDs of a map of links.
+func prefixLinks(links *yaml.Node, prefixed func(string) string) {
+	forEachValue(links, func(link *yaml.Node) {
+		if id := compiler.MapValueForKey(link, "operationId"); id != nil && id.Kind == yaml.ScalarNode {
+			id.Value = prefixed(id.Value)
+		}
+	})
+}
+
+// forEachValue calls a function with each value of a map that is a map.
+func forEachValue(node *yaml.Node, f func(value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		if node.Content[i].Kind == yaml.MappingNode {
+			f(node.Content[i])
+		}
+	}
+}
+
+// forEachOperation calls a function with each operation of a path item.
+func forEachOperation(pathItem *yaml.Node, f func(operation *yaml.Node)) {
+	for _, method := range operationMethods {
+		if operation := compiler.MapValueForKey(pathItem, method); operation != nil && operation.Kind == yaml.MappingNode {
+			f(operation)
+		}
+	}
+}
+
+// equalValues returns true if two optional nodes are both missing or have
+// the same values.
+func equalValues(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equal(a, b)
+}
+
+// setMapValue sets the value of a key of a map.
+func setMapValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(key), value)
+}
+
+// deleteMapValue removes a key and its value from a map.
+func deleteMapValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
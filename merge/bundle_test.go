@@ -0,0 +1,179 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestBundle(t *testing.T) {
+	bundled, conflicts, err := Bundle(
+		&Service{Namespace: "Library", Document: parse(t, `
+openapi: 3.0.0
+info: {title: Library, version: "1"}
+servers:
+  - url: https://library.example.com
+tags:
+  - name: Books
+paths:
+  /books/{id}:
+    get:
+      tags: [Books]
+      operationId: getBook
+      responses:
+        "200":
+          description: a book
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Book"}
+          links:
+            author:
+              operationId: getBook
+components:
+  schemas:
+    Book:
+      type: object
+      properties:
+        author: {$ref: "#/components/schemas/Author"}
+    Author: {type: string}
+`)},
+		&Service{Namespace: "Store", Document: parse(t, `
+openapi: 3.0.0
+info: {title: Store, version: "1"}
+servers:
+  - url: https://store.example.com
+paths:
+  /orders:
+    servers:
+      - url: https://orders.example.com
+    post:
+      operationId: createOrder
+      responses:
+        "200":
+          description: an order
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Book"}
+components:
+  schemas:
+    Book: {type: object}
+`)})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+	expected, _ := yaml.Marshal(parse(t, `
+openapi: 3.0.0
+info: {title: Library, version: "1"}
+tags:
+  - name: Library.Books
+  - name: Store
+paths:
+  /books/{id}:
+    get:
+      tags: [Library.Books]
+      operationId: Library.getBook
+      responses:
+        "200":
+          description: a book
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Library.Book"}
+          links:
+            author:
+              operationId: Library.getBook
+    servers:
+      - url: https://library.example.com
+  /orders:
+    servers:
+      - url: https://orders.example.com
+    post:
+      operationId: Store.createOrder
+      responses:
+        "200":
+          description: an order
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Store.Book"}
+      tags:
+        - Store
+components:
+  schemas:
+    Library.Book:
+      type: object
+      properties:
+        author: {$ref: "#/components/schemas/Library.Author"}
+    Library.Author: {type: string}
+    Store.Book: {type: object}
+`).Content[0])
+	if actual, _ := yaml.Marshal(bundled); string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestBundleSameServers(t *testing.T) {
+	document := `
+openapi: 3.0.0
+info: {title: API, version: "1"}
+servers:
+  - url: https://api.example.com
+paths: {}
+`
+	bundled, _, err := Bundle(
+		&Service{Namespace: "A", Document: parse(t, document)},
+		&Service{Namespace: "B", Document: parse(t, document)})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected, _ := yaml.Marshal(parse(t, document).Content[0])
+	if actual, _ := yaml.Marshal(bundled); string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestBundleErrors(t *testing.T) {
+	if _, _, err := Bundle(); err == nil {
+		t.Errorf("expected error for no documents")
+	}
+	tests := []struct {
+		services []*Service
+		message  string
+	}{
+		{
+			services: []*Service{{Namespace: "A", Document: parse(t, `swagger: "2.0"`)}},
+			message:  "document 1 is not an OpenAPI v3 document",
+		},
+		{
+			services: []*Service{{Document: parse(t, `openapi: 3.0.0`)}},
+			message:  "document 1 has no namespace",
+		},
+		{
+			services: []*Service{
+				{Namespace: "A", Document: parse(t, `openapi: 3.0.0`)},
+				{Namespace: "A", Document: parse(t, `openapi: 3.0.0`)},
+			},
+			message: "document 2 has the same namespace as document 1",
+		},
+	}
+	for _, test := range tests {
+		if _, _, err := Bundle(test.services...); err == nil || err.Error() != test.message {
+			t.Errorf("unexpected error: %v, expected %s", err, test.message)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package merge combines several OpenAPI documents into one, such as the
+// descriptions of the services that make up an API.
+// It can also move schemas that several documents have in common into a
+// shared document.
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Conflict describes a part of a document that couldn't be merged.
+// The value from the earliest document is kept.
+type Conflict struct {
+	// Pointer is a JSON pointer to the conflicting value in the merged document.
+	Pointer string
+	// Document is the index of the document whose value was dropped.
+	Document int
+	Message  string
+}
+
+func (c *Conflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Pointer, c.Message)
+}
+
+// Sections of documents that contain components that are referenced
+// with $ref. Components in these sections are renamed when their names
+// collide with different components in earlier documents.
+var (
+	referencedSectionsV2 = [][]string{{"definitions"}, {"parameters"}, {"responses"}}
+	referencedSectionsV3 = [][]string{
+		{"components", "schemas"},
+		{"components", "responses"},
+		{"components", "parameters"},
+		{"components", "examples"},
+		{"components", "requestBodies"},
+		{"components", "headers"},
+		{"components", "links"},
+		{"components", "callbacks"},
+	}
+)
+
+// Merge merges OpenAPI documents that use the same version of OpenAPI.
+// The merged document contains the info of the first document, the
+// union of the paths and components of all documents, and the union of
+// their tags and servers. Components that have the same names as
+// different components of earlier documents are renamed by adding a
+// suffix with the (1-based) number of their document, such as "Pet_2",
+// and references to them are updated. Values that can't be merged,
+// such as different operations for the same path and method, are
+// reported as conflicts and the values from earlier documents are kept.
+// References to other files are not changed, so the documents should be
+// in the same directory.
+func Merge(documents ...*yaml.Node) (*yaml.Node, []*Conflict, error) {
+	if len(documents) == 0 {
+		return nil, nil, errors.New("no documents to merge")
+	}
+	roots := make([]*yaml.Node, len(documents))
+	v2 := false
+	for i, document := range documents {
+		root := document
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root.Kind != yaml.MappingNode {
+			return nil, nil, fmt.Errorf("document %d is not an OpenAPI document", i+1)
+		}
+		isV2 := compiler.MapValueForKey(root, "swagger") != nil
+		if i == 0 {
+			v2 = isV2
+		} else if isV2 != v2 {
+			return nil, nil, fmt.Errorf("document %d uses a different version of OpenAPI than document 1", i+1)
+		}
+		roots[i] = root
+	}
+	m := &merger{
+		result:             compiler.CopyNode(roots[0]),
+		referencedSections: referencedSectionsV3,
+	}
+	if v2 {
+		m.referencedSections = referencedSectionsV2
+	}
+	for i := 1; i < len(roots); i++ {
+		m.merge(compiler.CopyNode(roots[i]), i+1)
+	}
+	return m.result, m.conflicts, nil
+}
+
+// MergeV2 merges OpenAPI v2 documents. See Merge.
+func MergeV2(documents ...*openapi_v2.Document) (*openapi_v2.Document, []*Conflict, error) {
+	nodes := make([]*yaml.Node, len(documents))
+	for i, document := range documents {
+		nodes[i] = document.ToRawInfo()
+	}
+	root, conflicts, err := Merge(nodes...)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+	return document, conflicts, err
+}
+
+// MergeV3 merges OpenAPI v3 documents. See Merge.
+func MergeV3(documents ...*openapi_v3.Document) (*openapi_v3.Document, []*Conflict, error) {
+	nodes := make([]*yaml.Node, len(documents))
+	for i, document := range documents {
+		nodes[i] = document.ToRawInfo()
+	}
+	root, conflicts, err := Merge(nodes...)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	return document, conflicts, err
+}
+
+type merger struct {
+	result             *yaml.Node
+	referencedSections [][]string
+	conflicts          []*Conflict
+}
+
+func (m *merger) conflict(pointer string, document int, format string, args ...interface{}) {
+	m.conflicts = append(m.conflicts, &Conflict{
+		Pointer:  pointer,
+		Document: document,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// merge merges a document into the result.
+func (m *merger) merge(root *yaml.Node, document int) {
+	// Rename colliding components before anything is merged so that
+	// references in all parts of the document are updated.
+	renames := make(map[string]string)
+	for _, section := range m.referencedSections {
+		m.renameComponents(root, section, document, renames)
+	}
+	if len(renames) > 0 {
+		renameReferences(root, renames)
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		pointer := jsonpointer.Format(key)
+		existing := compiler.MapValueForKey(m.result, key)
+		switch {
+		case existing == nil:
+			m.result.Content = append(m.result.Content, root.Content[i], value)
+		case key == "paths":
+			m.mergePaths(existing, value, document)
+		case key == "components":
+			m.mergeComponents(existing, value, document)
+		case key == "definitions" || key == "parameters" || key == "responses" ||
+			key == "securityDefinitions":
+			m.mergeMaps(existing, value, pointer, document, key)
+		case key == "tags":
+			m.mergeList(existing, value, "name", pointer, document)
+		case key == "servers":
+			m.mergeList(existing, value, "url", pointer, document)
+		case key == "info" || key == "swagger" || key == "openapi":
+			// the first document's values are used
+		default:
+			if !equal(existing, value) {
+				m.conflict(pointer, document, "document %d has a different value", document)
+			}
+		}
+	}
+}
+
+// renameComponents renames the components of a document that have the
+// same names as different components in the result. Components that are
+// identical to components of the result are removed from the document.
+func (m *merger) renameComponents(root *yaml.Node, section []string, document int, renames map[string]string) {
+	components := lookup(root, section)
+	existing := lookup(m.result, section)
+	if components == nil || existing == nil || components.Kind != yaml.MappingNode {
+		return
+	}
+	prefix := "#" + jsonpointer.Format(section...) + "/"
+	content := make([]*yaml.Node, 0, len(components.Content))
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		key, value := components.Content[i], components.Content[i+1]
+		other := compiler.MapValueForKey(existing, key.Value)
+		if other == nil {
+			content = append(content, key, value)
+			continue
+		}
+		if equal(other, value) {
+			continue
+		}
+		name := fmt.Sprintf("%s_%d", key.Value, document)
+		for n := 2; compiler.MapValueForKey(existing, name) != nil || compiler.MapValueForKey(components, name) != nil; n++ {
+			name = fmt.Sprintf("%s_%d_%d", key.Value, document, n)
+		}
+		renames[prefix+jsonpointer.Escape(key.Value)] = prefix + jsonpointer.Escape(name)
+		content = append(content, compiler.NewScalarNodeForString(name), value)
+	}
+	components.Content = content
+}
+
+// renameReferences updates references to renamed components.
+func renameReferences(node *yaml.Node, renames map[string]string) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value != "$ref" || node.Content[i+1].Kind != yaml.ScalarNode {
+				continue
+			}
+			ref := node.Content[i+1].Value
+			for old, renamed := range renames {
+				if ref == old || strings.HasPrefix(ref, old+"/") {
+					node.Content[i+1].Value = renamed + strings.TrimPrefix(ref, old)
+					break
+				}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		renameReferences(child, renames)
+	}
+}
+
+// mergePaths merges path items, combining the operations of paths that
+// are in more than one document.
+func (m *merger) mergePaths(existing, paths *yaml.Node, document int) {
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		key, value := paths.Content[i], paths.Content[i+1]
+		pointer := jsonpointer.Format("paths", key.Value)
+		pathItem := compiler.MapValueForKey(existing, key.Value)
+		if pathItem == nil {
+			existing.Content = append(existing.Content, key, value)
+			continue
+		}
+		m.mergeMaps(pathItem, value, pointer, document, "path "+key.Value)
+	}
+}
+
+// mergeComponents merges the sections of the components of OpenAPI v3 documents.
+func (m *merger) mergeComponents(existing, components *yaml.Node, document int) {
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		key, value := components.Content[i], components.Content[i+1]
+		section := compiler.MapValueForKey(existing, key.Value)
+		if section == nil {
+			existing.Content = append(existing.Content, key, value)
+		} else {
+			m.mergeMaps(section, value, jsonpointer.Format("components", key.Value), document, key.Value)
+		}
+	}
+}
+
+// mergeMaps adds the entries of a map to an existing map and reports
+// entries with the same keys and different values as conflicts. The
+// kind describes the map in conflicts.
+func (m *merger) mergeMaps(existing, value *yaml.Node, pointer string, document int, kind string) {
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key, entry := value.Content[i], value.Content[i+1]
+		other := compiler.MapValueForKey(existing, key.Value)
+		if other == nil {
+			existing.Content = append(existing.Content, key, entry)
+		} else if !equal(other, entry) {
+			m.conflict(jsonpointer.Append(pointer, key.Value), document,
+				"%s %s is defined differently in document %d", kind, key.Value, document)
+		}
+	}
+}
+
+// mergeList adds the items of a list that aren't already in an existing
+// list. Items are identified by the value of a key, such as the names of
+// tags, and items with the same key and different values are reported
+// as conflicts.
+func (m *merger) mergeList(existing, list *yaml.Node, key string, pointer string, document int) {
+	for _, item := range list.Content {
+		id, _ := compiler.StringForScalarNode(compiler.MapValueForKey(item, key))
+		found := false
+		for j, other := range existing.Content {
+			otherID, _ := compiler.StringForScalarNode(compiler.MapValueForKey(other, key))
+			if otherID != id {
+				continue
+			}
+			found = true
+			if !equal(other, item) {
+				m.conflict(jsonpointer.Append(pointer, fmt.Sprintf("%d", j)), document,
+					"%s %s is defined differently in document %d", key, id, document)
+			}
+			break
+		}
+		if !found {
+			existing.Content = append(existing.Content, item)
+		}
+	}
+}
+
+// lookup returns the node at a path of keys or nil if there is none.
+func lookup(node *yaml.Node, path []string) *yaml.Node {
+	for _, key := range path {
+		node = compiler.MapValueForKey(node, key)
+	}
+	return node
+}
+
+// equal returns true if two nodes have the same values.
+func equal(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode {
+		return a.Value == b.Value && a.ShortTag() == b.ShortTag()
+	}
+	for i := range a.Content {
+		if !equal(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(text)), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return &node
+}
+
+func TestMergeV2(t *testing.T) {
+	merged, conflicts, err := Merge(
+		parse(t, `
+swagger: "2.0"
+info: {title: a, version: "1"}
+host: api.example.com
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          schema: {$ref: "#/definitions/Pet"}
+definitions:
+  Pet: {type: object}
+securityDefinitions:
+  key: {type: apiKey, name: key, in: header}
+`),
+		parse(t, `
+swagger: "2.0"
+info: {title: b, version: "1"}
+host: other.example.com
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: other pets
+  /pets/{id}:
+    get:
+      responses:
+        "200":
+          description: a pet
+          schema: {$ref: "#/definitions/Pet/properties/owner"}
+definitions:
+  Pet:
+    type: object
+    properties:
+      owner: {type: string}
+  Pet_2: {type: string}
+securityDefinitions:
+  key: {type: apiKey, name: key, in: query}
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected, _ := yaml.Marshal(parse(t, `
+swagger: "2.0"
+info: {title: a, version: "1"}
+host: api.example.com
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          schema: {$ref: "#/definitions/Pet"}
+  /pets/{id}:
+    get:
+      responses:
+        "200":
+          description: a pet
+          schema: {$ref: "#/definitions/Pet_2_2/properties/owner"}
+definitions:
+  Pet: {type: object}
+  Pet_2_2:
+    type: object
+    properties:
+      owner: {type: string}
+  Pet_2: {type: string}
+securityDefinitions:
+  key: {type: apiKey, name: key, in: header}
+`).Content[0])
+	if actual, _ := yaml.Marshal(merged); string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+	var messages []string
+	for _, c := range conflicts {
+		messages = append(messages, c.String())
+	}
+	expectedMessages := []string{
+		"/host: document 2 has a different value",
+		"/paths/~1pets/get: path /pets get is defined differently in document 2",
+		"/securityDefinitions/key: securityDefinitions key is defined differently in document 2",
+	}
+	if strings.Join(messages, "\n") != strings.Join(expectedMessages, "\n") {
+		t.Errorf("unexpected conflicts:\n%s\nexpected:\n%s", strings.Join(messages, "\n"), strings.Join(expectedMessages, "\n"))
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	if _, _, err := Merge(); err == nil {
+		t.Errorf("expected error for no documents")
+	}
+	_, _, err := Merge(parse(t, `swagger: "2.0"`), parse(t, `openapi: 3.0.0`))
+	if err == nil || err.Error() != "document 2 uses a different version of OpenAPI than document 1" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// File is an OpenAPI document and the name of the file that it is read
+// from or written to.
+type File struct {
+	Name     string
+	Document *yaml.Node
+}
+
+// Bytes returns the document of a file in YAML.
+func (f *File) Bytes() ([]byte, error) {
+	document := f.Document
+	if document.Kind != yaml.DocumentNode {
+		document = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{document}}
+	}
+	return yaml.Marshal(document)
+}
+
+// ShareFiles compiles the OpenAPI documents in the named files, shares
+// their identical schemas with Share, and compiles the results. It returns
+// the rewritten files followed by the shared file. Files that can't be
+// compiled are reported together in an error group.
+func ShareFiles(sharedName string, names ...string) ([]*File, error) {
+	files := make([]*File, 0, len(names))
+	errs := make([]error, 0)
+	for _, name := range names {
+		root, err := compileFile(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", name, err))
+			continue
+		}
+		files = append(files, &File{Name: name, Document: root})
+	}
+	if err := compiler.NewErrorGroupOrNil(errs); err != nil {
+		return nil, err
+	}
+	shared, files, err := Share(sharedName, files...)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, shared)
+	for _, file := range files {
+		if err := compileNode(file.Document); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", file.Name, err))
+		}
+	}
+	return files, compiler.NewErrorGroupOrNil(errs)
+}
+
+// compileFile reads and compiles an OpenAPI document and returns the
+// YAML representation of the compiled document, in which values are
+// written in the same way in all documents.
+func compileFile(name string) (*yaml.Node, error) {
+	bytes, err := compiler.ReadBytesForFile(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(name, bytes)
+	if err != nil {
+		return nil, err
+	}
+	root := info
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if compiler.MapValueForKey(root, "swagger") != nil {
+		document, err := openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+		if err != nil {
+			return nil, err
+		}
+		return document.ToRawInfo(), nil
+	}
+	document, err := openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		return nil, err
+	}
+	return document.ToRawInfo(), nil
+}
+
+// compileNode checks that a rewritten document can be compiled.
+func compileNode(root *yaml.Node) error {
+	var err error
+	if compiler.MapValueForKey(root, "swagger") != nil {
+		_, err = openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+	} else {
+		_, err = openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+	}
+	return err
+}
+
+// Share moves the named schemas that are identical in two or more documents
+// into a shared document, so that organizations whose APIs copy the same
+// models can publish them once. The shared document is written to the file
+// named sharedName and the schemas of the other documents are replaced by
+// references to it, with paths that are relative to the directories of the
+// documents.
+//
+// Schemas are identical if they have the same values and refer to schemas
+// that are identical, so schemas with different names can be shared.
+// Schemas that are recursive or that refer to other files or to parts of
+// schemas are not shared. Shared schemas have the name that they have in
+// the first document that contains them, with a numeric suffix ("Pet_2")
+// if that name is taken. The documents must use the same version of
+// OpenAPI, and they aren't modified; Share returns the shared file and
+// rewritten copies of the files.
+func Share(sharedName string, files ...*File) (*File, []*File, error) {
+	if len(files) == 0 {
+		return nil, nil, errors.New("no documents to share")
+	}
+	s := &sharer{section: referencedSectionsV3[0]}
+	v2 := false
+	for i, file := range files {
+		root := file.Document
+		if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root == nil || root.Kind != yaml.MappingNode {
+			return nil, nil, fmt.Errorf("document %d is not an OpenAPI document", i+1)
+		}
+		isV2 := compiler.MapValueForKey(root, "swagger") != nil
+		if i == 0 {
+			v2 = isV2
+		} else if isV2 != v2 {
+			return nil, nil, fmt.Errorf("document %d uses a different version of OpenAPI than document 1", i+1)
+		}
+		s.roots = append(s.roots, compiler.CopyNode(root))
+		s.fingerprints = append(s.fingerprints, make(map[string]string))
+	}
+	if v2 {
+		s.section = referencedSectionsV2[0]
+	}
+	s.prefix = "#" + jsonpointer.Format(s.section...) + "/"
+	s.groupSchemas()
+	shared := &File{Name: sharedName, Document: s.sharedDocument(v2)}
+	result := make([]*File, len(files))
+	for i, file := range files {
+		s.replaceSchemas(i, relativeName(file.Name, sharedName))
+		result[i] = &File{Name: file.Name, Document: s.roots[i]}
+	}
+	return shared, result, nil
+}
+
+type sharer struct {
+	section []string
+	prefix  string
+	roots   []*yaml.Node
+	// fingerprints map the names of the schemas of each document to their
+	// fingerprints, or to empty strings if the schemas can't be shared.
+	fingerprints []map[string]string
+	// groups contains the identical schemas that will be shared, in the
+	// order in which they were found.
+	groups []*sharedSchema
+}
+
+// sharedSchema is a schema that is shared by several documents.
+type sharedSchema struct {
+	name  string
+	value *yaml.Node
+	// document is the index of the document that value is from.
+	document int
+	// names are the names of the schema in each document.
+	names map[int][]string
+}
+
+// groupSchemas finds the schemas that are identical in several documents
+// and names them.
+func (s *sharer) groupSchemas() {
+	groups := make(map[string]*sharedSchema)
+	candidates := make([]*sharedSchema, 0)
+	for i, root := range s.roots {
+		schemas := lookup(root, s.section)
+		if schemas == nil || schemas.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(schemas.Content); j += 2 {
+			name := schemas.Content[j].Value
+			fingerprint, ok := s.fingerprint(i, name)
+			if !ok {
+				continue
+			}
+			group, ok := groups[fingerprint]
+			if !ok {
+				group = &sharedSchema{name: name, value: schemas.Content[j+1], document: i, names: make(map[int][]string)}
+				groups[fingerprint] = group
+				candidates = append(candidates, group)
+			}
+			group.names[i] = append(group.names[i], name)
+		}
+	}
+	used := make(map[string]bool)
+	for _, group := range candidates {
+		if len(group.names) < 2 {
+			continue
+		}
+		name := group.name
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", group.name, n)
+		}
+		used[name] = true
+		group.name = name
+		s.groups = append(s.groups, group)
+	}
+}
+
+// fingerprint returns a string that is the same for identical schemas.
+// References to other schemas are replaced with the fingerprints of the
+// schemas, so false is returned for schemas that are recursive or that
+// refer to schemas that can't be shared.
+func (s *sharer) fingerprint(document int, name string) (string, bool) {
+	if fingerprint, ok := s.fingerprints[document][name]; ok {
+		return fingerprint, fingerprint != ""
+	}
+	node := compiler.MapValueForKey(lookup(s.roots[document], s.section), name)
+	if node == nil {
+		return "", false
+	}
+	// Mark the schema as unshareable until it is done so that recursive
+	// references fail.
+	s.fingerprints[document][name] = ""
+	var b strings.Builder
+	if !s.writeCanonical(&b, node, document) {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	fingerprint := hex.EncodeToString(sum[:])
+	s.fingerprints[document][name] = fingerprint
+	return fingerprint, true
+}
+
+// writeCanonical writes a form of a node that doesn't depend on how it
+// is written in YAML or JSON.
+func (s *sharer) writeCanonical(b *strings.Builder, node *yaml.Node, document int) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		b.WriteString(node.ShortTag() + strconv.Quote(node.Value))
+	case yaml.SequenceNode:
+		b.WriteString("[")
+		for _, child := range node.Content {
+			if !s.writeCanonical(b, child, document) {
+				return false
+			}
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	case yaml.MappingNode:
+		b.WriteString("{")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				name := strings.TrimPrefix(value.Value, s.prefix)
+				if name == value.Value || strings.Contains(name, "/") {
+					return false
+				}
+				name, err := jsonpointer.Unescape(name)
+				if err != nil {
+					return false
+				}
+				fingerprint, ok := s.fingerprint(document, name)
+				if !ok {
+					return false
+				}
+				b.WriteString("$ref=" + fingerprint + ",")
+				continue
+			}
+			b.WriteString(strconv.Quote(key.Value) + "=")
+			if !s.writeCanonical(b, value, document) {
+				return false
+			}
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	default:
+		return false
+	}
+	return true
+}
+
+// sharedDocument returns a document that contains the shared schemas.
+func (s *sharer) sharedDocument(v2 bool) *yaml.Node {
+	schemas := compiler.NewMappingNode()
+	for _, group := range s.groups {
+		value := compiler.CopyNode(group.value)
+		renameReferences(value, s.renames(group.document, ""))
+		schemas.Content = append(schemas.Content, compiler.NewScalarNodeForString(group.name), value)
+	}
+	info := compiler.NewMappingNode()
+	info.Content = append(info.Content,
+		compiler.NewScalarNodeForString("title"), compiler.NewScalarNodeForString("Shared schemas"),
+		compiler.NewScalarNodeForString("version"), compiler.NewScalarNodeForString("1.0.0"))
+	root := compiler.NewMappingNode()
+	if v2 {
+		root.Content = append(root.Content, compiler.NewScalarNodeForString("swagger"), compiler.NewScalarNodeForString("2.0"))
+	} else {
+		version := compiler.NewScalarNodeForString("3.0.0")
+		if v := compiler.MapValueForKey(s.roots[0], "openapi"); v != nil {
+			version = compiler.CopyNode(v)
+		}
+		root.Content = append(root.Content, compiler.NewScalarNodeForString("openapi"), version)
+	}
+	root.Content = append(root.Content,
+		compiler.NewScalarNodeForString("info"), info,
+		compiler.NewScalarNodeForString("paths"), compiler.NewMappingNode())
+	section := root
+	for _, key := range s.section {
+		section = mapValue(section, key)
+	}
+	section.Content = schemas.Content
+	return root
+}
+
+// renames maps references to the shared schemas of a document to
+// references to the shared document, which is named by a prefix.
+func (s *sharer) renames(document int, sharedName string) map[string]string {
+	renames := make(map[string]string)
+	for _, group := range s.groups {
+		for _, name := range group.names[document] {
+			renames[s.prefix+jsonpointer.Escape(name)] = sharedName + s.prefix + jsonpointer.Escape(group.name)
+		}
+	}
+	return renames
+}
+
+// replaceSchemas removes the shared schemas from a document and replaces
+// references to them with references to the shared document.
+func (s *sharer) replaceSchemas(document int, sharedName string) {
+	root := s.roots[document]
+	renames := s.renames(document, sharedName)
+	if len(renames) == 0 {
+		return
+	}
+	schemas := lookup(root, s.section)
+	content := make([]*yaml.Node, 0, len(schemas.Content))
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		if _, ok := renames[s.prefix+jsonpointer.Escape(schemas.Content[i].Value)]; !ok {
+			content = append(content, schemas.Content[i], schemas.Content[i+1])
+		}
+	}
+	schemas.Content = content
+	if len(content) == 0 {
+		removeKey(lookup(root, s.section[:len(s.section)-1]), s.section[len(s.section)-1])
+	}
+	renameReferences(root, renames)
+}
+
+// relativeName returns the name of the shared file relative to the
+// directory of a document.
+func relativeName(name, sharedName string) string {
+	if u, err := url.Parse(sharedName); err == nil && u.Scheme != "" {
+		return sharedName
+	}
+	relative, err := filepath.Rel(filepath.Dir(name), sharedName)
+	if err != nil {
+		return sharedName
+	}
+	return filepath.ToSlash(relative)
+}
+
+// mapValue returns the value for a key in a mapping node, creating an
+// empty mapping for it if the key is missing.
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if value := compiler.MapValueForKey(node, key); value != nil {
+		return value
+	}
+	value := compiler.NewMappingNode()
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(key), value)
+	return value
+}
+
+// removeKey removes a key and its value from a mapping node.
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestShare(t *testing.T) {
+	shared, files, err := Share("common/schemas.yaml",
+		&File{Name: "services/pets/openapi.yaml", Document: parse(t, `
+openapi: 3.0.0
+info: {title: pets, version: "1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        owner: {$ref: "#/components/schemas/Owner"}
+    Owner:
+      type: object
+      properties:
+        name: {type: string}
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`)},
+		&File{Name: "services/stores/openapi.yaml", Document: parse(t, `
+openapi: 3.0.0
+info: {title: stores, version: "1"}
+paths:
+  /animals:
+    get:
+      responses:
+        "200":
+          description: animals
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Animal/properties/owner"}
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        name: {type: string}
+    Animal:
+      type: object
+      properties:
+        name: {type: string}
+        owner: {$ref: "#/components/schemas/Person"}
+    Owner: {type: string}
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`)})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := []string{`
+openapi: 3.0.0
+info: {title: pets, version: "1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema: {$ref: "../../common/schemas.yaml#/components/schemas/Pet"}
+components:
+  schemas:
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`, `
+openapi: 3.0.0
+info: {title: stores, version: "1"}
+paths:
+  /animals:
+    get:
+      responses:
+        "200":
+          description: animals
+          content:
+            application/json:
+              schema: {$ref: "../../common/schemas.yaml#/components/schemas/Pet/properties/owner"}
+components:
+  schemas:
+    Owner: {type: string}
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`, `
+openapi: 3.0.0
+info:
+  title: Shared schemas
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        owner: {$ref: "#/components/schemas/Owner"}
+    Owner:
+      type: object
+      properties:
+        name: {type: string}
+`}
+	files = append(files, shared)
+	names := []string{"services/pets/openapi.yaml", "services/stores/openapi.yaml", "common/schemas.yaml"}
+	for i, file := range files {
+		if file.Name != names[i] {
+			t.Errorf("unexpected name %s, expected %s", file.Name, names[i])
+		}
+		actual, _ := yaml.Marshal(file.Document)
+		expected, _ := yaml.Marshal(parse(t, expected[i]).Content[0])
+		if string(actual) != string(expected) {
+			t.Errorf("unexpected %s:\n%s\nexpected:\n%s", file.Name, actual, expected)
+		}
+	}
+}
+
+func TestShareFiles(t *testing.T) {
+	files, err := ShareFiles("shared.yaml",
+		"../examples/v2.0/yaml/petstore.yaml",
+		"../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+	bytes, err := files[2].Bytes()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	var document struct {
+		Definitions map[string]interface{}
+	}
+	if err := yaml.Unmarshal(bytes, &document); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, ok := document.Definitions["Error"]; !ok || len(document.Definitions) != 1 {
+		t.Errorf("unexpected shared definitions: %+v", document.Definitions)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coverage measures how completely API descriptions are
+// documented. It counts the operations, parameters, and schema properties
+// that have descriptions, flags descriptions that are placeholders, and
+// scores each file and each tag of an API.
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	metrics "github.com/google/gnostic/metrics"
+)
+
+// placeholders are descriptions, in lower case and without surrounding
+// punctuation, that are written to be replaced later.
+var placeholders = map[string]bool{
+	"todo":        true,
+	"tbd":         true,
+	"tba":         true,
+	"fixme":       true,
+	"xxx":         true,
+	"placeholder": true,
+	"description": true,
+	"n/a":         true,
+}
+
+// placeholderPrefixes begin placeholders like "TODO: describe this".
+var placeholderPrefixes = []string{"todo", "tbd", "fixme"}
+
+// IsPlaceholder returns true if a description is a placeholder, like
+// "TODO", "TBD: explain limits", or "...". Empty descriptions are missing
+// rather than placeholders.
+func IsPlaceholder(description string) bool {
+	text := strings.ToLower(strings.TrimSpace(description))
+	if text == "" {
+		return false
+	}
+	text = strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	if text == "" || placeholders[text] {
+		return true
+	}
+	for _, prefix := range placeholderPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			rest := []rune(text[len(prefix):])
+			if len(rest) > 0 && !unicode.IsLetter(rest[0]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The kinds of described parts.
+const (
+	operations = iota
+	parameters
+	properties
+)
+
+func newDocumentation(name string) *metrics.Documentation {
+	return &metrics.Documentation{
+		Name:         name,
+		Operations:   &metrics.DescriptionCount{},
+		Parameters:   &metrics.DescriptionCount{},
+		Properties:   &metrics.DescriptionCount{},
+		Placeholders: make([]*metrics.Placeholder, 0),
+	}
+}
+
+func count(d *metrics.Documentation, kind int) *metrics.DescriptionCount {
+	switch kind {
+	case operations:
+		return d.Operations
+	case parameters:
+		return d.Parameters
+	}
+	return d.Properties
+}
+
+// An analyzer measures the documentation of a file and of its tags.
+type analyzer struct {
+	file *metrics.Documentation
+	tags map[string]*metrics.Documentation
+}
+
+func newAnalyzer(name string) *analyzer {
+	return &analyzer{
+		file: newDocumentation(name),
+		tags: make(map[string]*metrics.Documentation),
+	}
+}
+
+// tagged returns the measurements of operations with a list of tags.
+func (a *analyzer) tagged(tags []string) []*metrics.Documentation {
+	if len(tags) == 0 {
+		tags = []string{""}
+	}
+	docs := make([]*metrics.Documentation, 0, len(tags))
+	for _, tag := range tags {
+		d, ok := a.tags[tag]
+		if !ok {
+			d = newDocumentation(tag)
+			a.tags[tag] = d
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// describe counts a part in a list of measurements. A part is described
+// if any of its descriptions is present and isn't a placeholder.
+func describe(docs []*metrics.Documentation, kind int, location string, descriptions ...string) {
+	described := false
+	placeholder := ""
+	for _, description := range descriptions {
+		if IsPlaceholder(description) {
+			if placeholder == "" {
+				placeholder = description
+			}
+		} else if description != "" {
+			described = true
+		}
+	}
+	for _, d := range docs {
+		c := count(d, kind)
+		c.Total++
+		if described {
+			c.Described++
+		} else if placeholder != "" && !hasPlaceholder(d, location) {
+			d.Placeholders = append(d.Placeholders, &metrics.Placeholder{Location: location, Text: placeholder})
+		}
+	}
+}
+
+// hasPlaceholder returns true if a placeholder has been found at a
+// location, such as in the parameters of a path, which are counted for
+// each of its operations.
+func hasPlaceholder(d *metrics.Documentation, location string) bool {
+	for _, p := range d.Placeholders {
+		if p.Location == location {
+			return true
+		}
+	}
+	return false
+}
+
+// report returns the scored measurements of the file and its tags.
+func (a *analyzer) report() *metrics.DocumentationReport {
+	r := &metrics.DocumentationReport{
+		Files: []*metrics.Documentation{a.file},
+		Tags:  make([]*metrics.Documentation, 0, len(a.tags)),
+	}
+	for _, d := range a.tags {
+		r.Tags = append(r.Tags, d)
+	}
+	sortByName(r.Tags)
+	score(r)
+	return r
+}
+
+func sortByName(docs []*metrics.Documentation) {
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Name < docs[j].Name
+	})
+}
+
+func score(r *metrics.DocumentationReport) {
+	for _, docs := range [][]*metrics.Documentation{r.Files, r.Tags} {
+		for _, d := range docs {
+			total := d.Operations.Total + d.Parameters.Total + d.Properties.Total
+			described := d.Operations.Described + d.Parameters.Described + d.Properties.Described
+			d.Score = 100
+			if total > 0 {
+				d.Score = 100 * float64(described) / float64(total)
+			}
+		}
+	}
+}
+
+// Merge combines the reports of several files. Tags with the same names
+// are measured together.
+func Merge(reports []*metrics.DocumentationReport) *metrics.DocumentationReport {
+	merged := &metrics.DocumentationReport{
+		Files: make([]*metrics.Documentation, 0),
+		Tags:  make([]*metrics.Documentation, 0),
+	}
+	tags := make(map[string]*metrics.Documentation)
+	for _, r := range reports {
+		merged.Files = append(merged.Files, r.Files...)
+		for _, tag := range r.Tags {
+			d, ok := tags[tag.Name]
+			if !ok {
+				d = newDocumentation(tag.Name)
+				tags[tag.Name] = d
+				merged.Tags = append(merged.Tags, d)
+			}
+			for kind := operations; kind <= properties; kind++ {
+				count(d, kind).Total += count(tag, kind).Total
+				count(d, kind).Described += count(tag, kind).Described
+			}
+			d.Placeholders = append(d.Placeholders, tag.Placeholders...)
+		}
+	}
+	sortByName(merged.Tags)
+	score(merged)
+	return merged
+}
+
+// Write writes a report as tables that are formatted to be read in a
+// terminal, followed by the locations of placeholders.
+func Write(w io.Writer, r *metrics.DocumentationReport) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "FILE\tOPERATIONS\tPARAMETERS\tPROPERTIES\tSCORE\n")
+	for _, d := range r.Files {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.0f%%\n", d.Name, fraction(d.Operations), fraction(d.Parameters), fraction(d.Properties), d.Score)
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(tw, "\nTAG\tOPERATIONS\tPARAMETERS\tSCORE\n")
+		for _, d := range r.Tags {
+			name := d.Name
+			if name == "" {
+				name = "(untagged)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%.0f%%\n", name, fraction(d.Operations), fraction(d.Parameters), d.Score)
+		}
+	}
+	placeholders := false
+	for _, d := range r.Files {
+		for _, p := range d.Placeholders {
+			if !placeholders {
+				fmt.Fprintf(tw, "\nPLACEHOLDER\tTEXT\n")
+				placeholders = true
+			}
+			fmt.Fprintf(tw, "%s#%s\t%q\n", d.Name, p.Location, p.Text)
+		}
+	}
+	return tw.Flush()
+}
+
+func fraction(c *metrics.DescriptionCount) string {
+	return fmt.Sprintf("%d/%d", c.Described, c.Total)
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"bytes"
+	"testing"
+
+	metrics "github.com/google/gnostic/metrics"
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestIsPlaceholder(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		placeholder bool
+	}{
+		{"", false},
+		{"TODO", true},
+		{" tbd. ", true},
+		{"TODO: describe the limit.", true},
+		{"FIXME(someone)", true},
+		{"...", true},
+		{"N/A", true},
+		{"Description", true},
+		{"Todos for a user.", false},
+		{"The number of pets to return.", false},
+		{"TBDs are tracked elsewhere.", false},
+	} {
+		if got := IsPlaceholder(test.description); got != test.placeholder {
+			t.Errorf("IsPlaceholder(%q) = %t, want %t", test.description, got, test.placeholder)
+		}
+	}
+}
+
+const documentV3 = `
+openapi: 3.0.0
+info:
+  title: Library
+  version: 1.0.0
+paths:
+  /books/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        description: TODO
+        schema:
+          type: string
+    get:
+      tags: [books]
+      summary: Get a book.
+      responses:
+        "200":
+          description: OK
+    delete:
+      tags: [books, admin]
+      description: TBD
+      parameters:
+        - name: force
+          in: query
+          description: Delete the book even if it is borrowed.
+          schema:
+            type: boolean
+      responses:
+        "200":
+          description: OK
+  /authors:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Book:
+      properties:
+        title:
+          type: string
+          description: The title of the book.
+        author:
+          $ref: "#/components/schemas/Author"
+        chapters:
+          type: array
+          items:
+            properties:
+              title:
+                type: string
+                description: "..."
+    Author:
+      properties:
+        name:
+          type: string
+`
+
+const reportV3 = `FILE     OPERATIONS  PARAMETERS  PROPERTIES  SCORE
+library  1/3         1/2         1/4         33%
+
+TAG         OPERATIONS  PARAMETERS  SCORE
+(untagged)  0/1         0/0         0%
+admin       0/1         1/2         33%
+books       1/2         1/3         40%
+
+PLACEHOLDER                                                                  TEXT
+library#/paths/~1books~1{id}/parameters/0                                    "TODO"
+library#/paths/~1books~1{id}/delete                                          "TBD"
+library#/components/schemas/Book/properties/chapters/items/properties/title  "..."
+`
+
+func TestNewReportV3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(documentV3))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	r := NewReportV3("library", document)
+	if len(r.Files) != 1 || len(r.Tags) != 3 {
+		t.Fatalf("got %d files and %d tags, want 1 and 3", len(r.Files), len(r.Tags))
+	}
+	if r.Files[0].Score != 100.0/3 {
+		t.Errorf("got score %f, want 33.3", r.Files[0].Score)
+	}
+	var b bytes.Buffer
+	if err := Write(&b, r); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if b.String() != reportV3 {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), reportV3)
+	}
+}
+
+const documentV2 = `
+swagger: "2.0"
+info:
+  title: Library
+  version: 1.0.0
+paths:
+  /books:
+    get:
+      tags: [books]
+      description: List the books.
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+      responses:
+        "200":
+          description: OK
+definitions:
+  Book:
+    properties:
+      title:
+        type: string
+        description: TODO
+`
+
+func TestMerge(t *testing.T) {
+	document, err := openapiv2.ParseDocument([]byte(documentV2))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	documentv3, err := openapiv3.ParseDocument([]byte(documentV3))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	r := Merge([]*metrics.DocumentationReport{
+		NewReportV2("v2", document),
+		NewReportV3("v3", documentv3),
+	})
+	if len(r.Files) != 2 || r.Files[0].Name != "v2" || r.Files[1].Name != "v3" {
+		t.Fatalf("got files %v", r.Files)
+	}
+	if r.Files[0].Score != 100.0/3 || len(r.Files[0].Placeholders) != 1 {
+		t.Errorf("got score %f and %d placeholders for v2", r.Files[0].Score, len(r.Files[0].Placeholders))
+	}
+	var books *metrics.Documentation
+	for _, tag := range r.Tags {
+		if tag.Name == "books" {
+			books = tag
+		}
+	}
+	if books == nil {
+		t.Fatalf("books tag is missing")
+	}
+	if books.Operations.Total != 3 || books.Operations.Described != 2 || books.Parameters.Total != 4 || books.Score != 300.0/7 || len(books.Placeholders) != 2 {
+		t.Errorf("got books %v", books)
+	}
+}
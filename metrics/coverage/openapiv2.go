@@ -0,0 +1,120 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"strconv"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	metrics "github.com/google/gnostic/metrics"
+	openapi "github.com/google/gnostic/openapiv2"
+)
+
+// NewReportV2 measures the documentation of an OpenAPI v2 description.
+// The name of the description is used as the name of its file.
+func NewReportV2(name string, document *openapi.Document) *metrics.DocumentationReport {
+	a := newAnalyzer(name)
+	file := []*metrics.Documentation{a.file}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			path := pair.Value
+			a.parametersV2(file, jsonpointer.Format("paths", pair.Name), path.Parameters)
+			for _, op := range []struct {
+				method    string
+				operation *openapi.Operation
+			}{
+				{"get", path.Get}, {"put", path.Put}, {"post", path.Post}, {"delete", path.Delete},
+				{"options", path.Options}, {"head", path.Head}, {"patch", path.Patch},
+			} {
+				if op.operation == nil {
+					continue
+				}
+				location := jsonpointer.Format("paths", pair.Name, op.method)
+				tags := a.tagged(op.operation.Tags)
+				describe(append(tags, a.file), operations, location, op.operation.Description, op.operation.Summary)
+				a.parametersV2(append(tags, a.file), location, op.operation.Parameters)
+				// Path parameters are counted once for the file and once
+				// for each operation in the tags.
+				a.parametersV2(tags, jsonpointer.Format("paths", pair.Name), path.Parameters)
+			}
+		}
+	}
+	if document.Parameters != nil {
+		for _, pair := range document.Parameters.AdditionalProperties {
+			describe(file, parameters, jsonpointer.Format("parameters", pair.Name), parameterDescriptionV2(pair.Value))
+		}
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			a.propertiesV2(jsonpointer.Format("definitions", pair.Name), pair.Value)
+		}
+	}
+	return a.report()
+}
+
+func (a *analyzer) parametersV2(docs []*metrics.Documentation, location string, list []*openapi.ParametersItem) {
+	for i, p := range list {
+		if parameter := p.GetParameter(); parameter != nil {
+			describe(docs, parameters, jsonpointer.Append(location, "parameters", strconv.Itoa(i)), parameterDescriptionV2(parameter))
+		}
+	}
+}
+
+func parameterDescriptionV2(parameter *openapi.Parameter) string {
+	if body := parameter.GetBodyParameter(); body != nil {
+		return body.Description
+	}
+	p := parameter.GetNonBodyParameter()
+	for _, description := range []string{
+		p.GetHeaderParameterSubSchema().GetDescription(),
+		p.GetFormDataParameterSubSchema().GetDescription(),
+		p.GetQueryParameterSubSchema().GetDescription(),
+		p.GetPathParameterSubSchema().GetDescription(),
+	} {
+		if description != "" {
+			return description
+		}
+	}
+	return ""
+}
+
+// propertiesV2 measures the properties of a schema and of its inline
+// schemas. Properties that are references are skipped.
+func (a *analyzer) propertiesV2(location string, schema *openapi.Schema) {
+	if schema == nil || schema.XRef != "" {
+		return
+	}
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			if pair.Value.XRef == "" {
+				propertyLocation := jsonpointer.Append(location, "properties", pair.Name)
+				describe([]*metrics.Documentation{a.file}, properties, propertyLocation, pair.Value.Description)
+				a.propertiesV2(propertyLocation, pair.Value)
+			}
+		}
+	}
+	if schema.Items != nil {
+		for i, item := range schema.Items.Schema {
+			itemLocation := jsonpointer.Append(location, "items")
+			if len(schema.Items.Schema) > 1 {
+				itemLocation = jsonpointer.Append(itemLocation, strconv.Itoa(i))
+			}
+			a.propertiesV2(itemLocation, item)
+		}
+	}
+	for i, part := range schema.AllOf {
+		a.propertiesV2(jsonpointer.Append(location, "allOf", strconv.Itoa(i)), part)
+	}
+}
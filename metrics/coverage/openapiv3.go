@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coverage
+
+import (
+	"strconv"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	metrics "github.com/google/gnostic/metrics"
+	openapi "github.com/google/gnostic/openapiv3"
+)
+
+// NewReportV3 measures the documentation of an OpenAPI v3 description.
+// The name of the description is used as the name of its file.
+func NewReportV3(name string, document *openapi.Document) *metrics.DocumentationReport {
+	a := newAnalyzer(name)
+	file := []*metrics.Documentation{a.file}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			path := pair.Value
+			a.parametersV3(file, jsonpointer.Format("paths", pair.Name), path.Parameters)
+			for _, op := range []struct {
+				method    string
+				operation *openapi.Operation
+			}{
+				{"get", path.Get}, {"put", path.Put}, {"post", path.Post}, {"delete", path.Delete},
+				{"options", path.Options}, {"head", path.Head}, {"patch", path.Patch}, {"trace", path.Trace},
+			} {
+				if op.operation == nil {
+					continue
+				}
+				location := jsonpointer.Format("paths", pair.Name, op.method)
+				tags := a.tagged(op.operation.Tags)
+				describe(append(tags, a.file), operations, location, op.operation.Description, op.operation.Summary)
+				a.parametersV3(append(tags, a.file), location, op.operation.Parameters)
+				// Path parameters are counted once for the file and once
+				// for each operation in the tags.
+				a.parametersV3(tags, jsonpointer.Format("paths", pair.Name), path.Parameters)
+			}
+		}
+	}
+	if components := document.Components; components != nil {
+		if components.Parameters != nil {
+			for _, pair := range components.Parameters.AdditionalProperties {
+				if parameter := pair.Value.GetParameter(); parameter != nil {
+					describe(file, parameters, jsonpointer.Format("components", "parameters", pair.Name), parameter.Description)
+				}
+			}
+		}
+		if components.Schemas != nil {
+			for _, pair := range components.Schemas.AdditionalProperties {
+				a.propertiesV3(jsonpointer.Format("components", "schemas", pair.Name), pair.Value)
+			}
+		}
+	}
+	return a.report()
+}
+
+func (a *analyzer) parametersV3(docs []*metrics.Documentation, location string, list []*openapi.ParameterOrReference) {
+	for i, p := range list {
+		if parameter := p.GetParameter(); parameter != nil {
+			describe(docs, parameters, jsonpointer.Append(location, "parameters", strconv.Itoa(i)), parameter.Description)
+		}
+	}
+}
+
+// propertiesV3 measures the properties of a schema and of its inline
+// schemas. Properties that are references are skipped.
+func (a *analyzer) propertiesV3(location string, schemaOrReference *openapi.SchemaOrReference) {
+	schema := schemaOrReference.GetSchema()
+	if schema == nil {
+		return
+	}
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			if property := pair.Value.GetSchema(); property != nil {
+				propertyLocation := jsonpointer.Append(location, "properties", pair.Name)
+				describe([]*metrics.Documentation{a.file}, properties, propertyLocation, property.Description)
+				a.propertiesV3(propertyLocation, pair.Value)
+			}
+		}
+	}
+	if schema.Items != nil {
+		for i, item := range schema.Items.SchemaOrReference {
+			itemLocation := jsonpointer.Append(location, "items")
+			if len(schema.Items.SchemaOrReference) > 1 {
+				itemLocation = jsonpointer.Append(itemLocation, strconv.Itoa(i))
+			}
+			a.propertiesV3(itemLocation, item)
+		}
+	}
+	for i, part := range schema.AllOf {
+		a.propertiesV3(jsonpointer.Append(location, "allOf", strconv.Itoa(i)), part)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export writes vocabularies and document statistics as rows that
+// can be loaded into databases: newline-delimited JSON, which can be loaded
+// into BigQuery, and SQL statements, which can be run with SQLite.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+// A Document identifies the API description that metrics were computed
+// for. Its fields are written in every row of the description.
+type Document struct {
+	// Name is the name or location of the description.
+	Name string
+	// Digest optionally identifies the version of the description, e.g.
+	// with the SHA-256 digest of its file.
+	Digest string
+	// Time is when the metrics were computed.
+	Time time.Time
+}
+
+// A VocabularyRow is the count of a word in a vocabulary.
+type VocabularyRow struct {
+	Document  string `json:"document"`
+	Digest    string `json:"digest,omitempty"`
+	Timestamp string `json:"timestamp"`
+	Group     string `json:"group"`
+	Word      string `json:"word"`
+	Count     int64  `json:"count"`
+}
+
+// A StatisticsRow is a count in the statistics of a document. Counts of
+// values such as operations by method or components by kind have keys.
+type StatisticsRow struct {
+	Document  string `json:"document"`
+	Digest    string `json:"digest,omitempty"`
+	Timestamp string `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Metric    string `json:"metric"`
+	Key       string `json:"key,omitempty"`
+	Count     int64  `json:"count"`
+}
+
+// SQLSchema creates the tables that the SQL statements insert into.
+const SQLSchema = `CREATE TABLE IF NOT EXISTS vocabulary (
+  document TEXT NOT NULL,
+  digest TEXT,
+  timestamp TEXT NOT NULL,
+  "group" TEXT NOT NULL,
+  word TEXT NOT NULL,
+  count INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS statistics (
+  document TEXT NOT NULL,
+  digest TEXT,
+  timestamp TEXT NOT NULL,
+  title TEXT,
+  version TEXT,
+  metric TEXT NOT NULL,
+  key TEXT,
+  count INTEGER NOT NULL
+);
+`
+
+// timestamp formats the time of a document in UTC as RFC 3339, which is
+// understood by both SQLite's date functions and BigQuery.
+func (d *Document) timestamp() string {
+	return d.Time.UTC().Format(time.RFC3339)
+}
+
+// NewVocabularyRows returns the rows of the words of a vocabulary, grouped
+// as schemas, properties, operations, and parameters.
+func NewVocabularyRows(d *Document, v *metrics.Vocabulary) []*VocabularyRow {
+	rows := make([]*VocabularyRow, 0)
+	timestamp := d.timestamp()
+	for _, group := range []struct {
+		name  string
+		words []*metrics.WordCount
+	}{
+		{"schemas", v.Schemas},
+		{"properties", v.Properties},
+		{"operations", v.Operations},
+		{"parameters", v.Parameters},
+	} {
+		for _, word := range group.words {
+			rows = append(rows, &VocabularyRow{
+				Document:  d.Name,
+				Digest:    d.Digest,
+				Timestamp: timestamp,
+				Group:     group.name,
+				Word:      word.Word,
+				Count:     int64(word.Count),
+			})
+		}
+	}
+	return rows
+}
+
+// NewStatisticsRows returns the rows of the statistics of a document. The
+// metrics are named with the JSON names of the statistics.
+func NewStatisticsRows(d *Document, s *statistics.DocumentStatistics) []*StatisticsRow {
+	rows := make([]*StatisticsRow, 0)
+	add := func(metric, key string, count int) {
+		rows = append(rows, &StatisticsRow{
+			Document:  d.Name,
+			Digest:    d.Digest,
+			Timestamp: d.timestamp(),
+			Title:     s.Title,
+			Version:   s.Version,
+			Metric:    metric,
+			Key:       key,
+			Count:     int64(count),
+		})
+	}
+	addCounts := func(metric string, counts map[string]int) {
+		keys := make([]string, 0, len(counts))
+		for key := range counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			add(metric, key, counts[key])
+		}
+	}
+	addCounts("operations", s.Operations)
+	add("definitions", "", s.DefinitionCount)
+	addCounts("parameterTypes", s.ParameterTypes)
+	addCounts("resultTypes", s.ResultTypes)
+	addCounts("definitionFieldTypes", s.DefinitionFieldTypes)
+	addCounts("definitionArrayTypes", s.DefinitionArrayTypes)
+	addCounts("definitionPrimitiveTypes", s.DefinitionPrimitiveTypes)
+	add("anonymousOperations", "", len(s.AnonymousOperations))
+	add("anonymousObjects", "", len(s.AnonymousObjects))
+	addCounts("contentTypes", s.ContentTypes)
+	addCounts("components", s.Components)
+	addCounts("securitySchemeTypes", s.SecuritySchemeTypes)
+	add("callbacks", "", s.CallbackCount)
+	add("links", "", s.LinkCount)
+	return rows
+}
+
+// WriteVocabularyJSON writes the rows of a vocabulary as newline-delimited
+// JSON.
+func WriteVocabularyJSON(w io.Writer, d *Document, v *metrics.Vocabulary) error {
+	encoder := json.NewEncoder(w)
+	for _, row := range NewVocabularyRows(d, v) {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStatisticsJSON writes the rows of the statistics of a document as
+// newline-delimited JSON.
+func WriteStatisticsJSON(w io.Writer, d *Document, s *statistics.DocumentStatistics) error {
+	encoder := json.NewEncoder(w)
+	for _, row := range NewStatisticsRows(d, s) {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteVocabularySQL writes the rows of a vocabulary as SQL statements that
+// insert them into the vocabulary table of SQLSchema.
+func WriteVocabularySQL(w io.Writer, d *Document, v *metrics.Vocabulary) error {
+	for _, row := range NewVocabularyRows(d, v) {
+		_, err := fmt.Fprintf(w, "INSERT INTO vocabulary (document, digest, timestamp, \"group\", word, count) VALUES (%s, %s, %s, %s, %s, %d);\n",
+			sqlString(row.Document), sqlNullableString(row.Digest), sqlString(row.Timestamp),
+			sqlString(row.Group), sqlString(row.Word), row.Count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStatisticsSQL writes the rows of the statistics of a document as SQL
+// statements that insert them into the statistics table of SQLSchema.
+func WriteStatisticsSQL(w io.Writer, d *Document, s *statistics.DocumentStatistics) error {
+	for _, row := range NewStatisticsRows(d, s) {
+		_, err := fmt.Fprintf(w, "INSERT INTO statistics (document, digest, timestamp, title, version, metric, key, count) VALUES (%s, %s, %s, %s, %s, %s, %s, %d);\n",
+			sqlString(row.Document), sqlNullableString(row.Digest), sqlString(row.Timestamp),
+			sqlNullableString(row.Title), sqlNullableString(row.Version),
+			sqlString(row.Metric), sqlNullableString(row.Key), row.Count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqlString quotes a string as an SQL literal.
+func sqlString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// sqlNullableString quotes a string as an SQL literal, or returns NULL if
+// the string is empty.
+func sqlNullableString(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return sqlString(s)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+var document = &Document{
+	Name:   "petstore.yaml",
+	Digest: "e3b0c442",
+	Time:   time.Date(2023, 6, 1, 12, 0, 0, 0, time.FixedZone("PDT", -7*60*60)),
+}
+
+func TestWriteVocabulary(t *testing.T) {
+	v := &metrics.Vocabulary{
+		Schemas:    []*metrics.WordCount{{Word: "Pet", Count: 2}},
+		Operations: []*metrics.WordCount{{Word: "listPets", Count: 1}},
+		Parameters: []*metrics.WordCount{{Word: "pet's id", Count: 1}},
+	}
+
+	var b bytes.Buffer
+	if err := WriteVocabularyJSON(&b, document, v); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `{"document":"petstore.yaml","digest":"e3b0c442","timestamp":"2023-06-01T19:00:00Z","group":"schemas","word":"Pet","count":2}
+{"document":"petstore.yaml","digest":"e3b0c442","timestamp":"2023-06-01T19:00:00Z","group":"operations","word":"listPets","count":1}
+{"document":"petstore.yaml","digest":"e3b0c442","timestamp":"2023-06-01T19:00:00Z","group":"parameters","word":"pet's id","count":1}
+`
+	if b.String() != expected {
+		t.Errorf("unexpected JSON:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := WriteVocabularySQL(&b, &Document{Name: "petstore.yaml", Time: document.Time}, v); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected = `INSERT INTO vocabulary (document, digest, timestamp, "group", word, count) VALUES ('petstore.yaml', NULL, '2023-06-01T19:00:00Z', 'schemas', 'Pet', 2);
+INSERT INTO vocabulary (document, digest, timestamp, "group", word, count) VALUES ('petstore.yaml', NULL, '2023-06-01T19:00:00Z', 'operations', 'listPets', 1);
+INSERT INTO vocabulary (document, digest, timestamp, "group", word, count) VALUES ('petstore.yaml', NULL, '2023-06-01T19:00:00Z', 'parameters', 'pet''s id', 1);
+`
+	if b.String() != expected {
+		t.Errorf("unexpected SQL:\n%s", b.String())
+	}
+}
+
+func TestWriteStatistics(t *testing.T) {
+	s := &statistics.DocumentStatistics{
+		Name:       "petstore.yaml",
+		Title:      "Swagger Petstore",
+		Version:    "3.0.0",
+		Operations: map[string]int{"post": 1, "get": 2},
+		Components: map[string]int{"schemas": 3},
+		LinkCount:  1,
+	}
+	rows := NewStatisticsRows(document, s)
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		if row.Document != "petstore.yaml" || row.Title != "Swagger Petstore" || row.Version != "3.0.0" {
+			t.Errorf("unexpected row %+v", row)
+		}
+		counts[row.Metric+"/"+row.Key] = row.Count
+	}
+	for key, count := range map[string]int64{
+		"operations/get":     2,
+		"operations/post":    1,
+		"components/schemas": 3,
+		"definitions/":       0,
+		"links/":             1,
+	} {
+		if counts[key] != count {
+			t.Errorf("expected %s to be %d, got %d", key, count, counts[key])
+		}
+	}
+	// Keyed counts are sorted by key.
+	if rows[0].Key != "get" || rows[1].Key != "post" {
+		t.Errorf("unexpected order of rows %+v %+v", rows[0], rows[1])
+	}
+
+	var b bytes.Buffer
+	if err := WriteStatisticsSQL(&b, document, s); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	line, err := b.ReadString('\n')
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "INSERT INTO statistics (document, digest, timestamp, title, version, metric, key, count) VALUES ('petstore.yaml', 'e3b0c442', '2023-06-01T19:00:00Z', 'Swagger Petstore', '3.0.0', 'operations', 'get', 2);\n"
+	if line != expected {
+		t.Errorf("unexpected SQL:\n%s", line)
+	}
+}
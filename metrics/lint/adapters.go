@@ -0,0 +1,141 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+)
+
+// An Adapter reads the output of a linter and converts it to lint results,
+// so that the results of different linters can be aggregated.
+type Adapter interface {
+	// Name returns the name of the linter, which is the tool of its results.
+	Name() string
+	// Parse converts the output of the linter to lint results.
+	Parse(output []byte) ([]*LintResult, error)
+}
+
+var adapters = map[string]Adapter{}
+
+func registerAdapter(a Adapter) {
+	adapters[a.Name()] = a
+}
+
+func init() {
+	registerAdapter(openAPIValidatorAdapter{})
+	registerAdapter(spectralAdapter{})
+	registerAdapter(redoclyAdapter{})
+	registerAdapter(vacuumAdapter{})
+}
+
+// AdapterForName returns the adapter for the linter with a name, or nil if
+// there is no adapter for the linter.
+func AdapterForName(name string) Adapter {
+	return adapters[name]
+}
+
+// AdapterNames returns the names of the linters that have adapters.
+func AdapterNames() []string {
+	names := make([]string, 0, len(adapters))
+	for name := range adapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadResults reads the output of a linter from a file and converts it to
+// lint results with the linter's adapter.
+func ReadResults(name, filename string) ([]*LintResult, error) {
+	adapter := AdapterForName(name)
+	if adapter == nil {
+		return nil, fmt.Errorf("unknown linter %q, expected one of %s", name, strings.Join(AdapterNames(), ", "))
+	}
+	output, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	results, err := adapter.Parse(output)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return results, nil
+}
+
+var errUnrecognizedOutput = errors.New("unrecognized linter output")
+
+// severityForName maps the names that linters use for severities to
+// severities.
+func severityForName(name string) Severity {
+	switch strings.ToLower(name) {
+	case "error":
+		return Severity_ERROR
+	case "warn", "warning":
+		return Severity_WARNING
+	case "info", "information":
+		return Severity_INFO
+	case "hint":
+		return Severity_HINT
+	}
+	return Severity_UNKNOWN
+}
+
+// pointerForFragment converts the fragment of a reference, such as
+// "#/paths/~1pets", to a JSON pointer.
+func pointerForFragment(fragment string) string {
+	tokens, err := jsonpointer.ParseFragment(fragment)
+	if err != nil {
+		return ""
+	}
+	return jsonpointer.Format(tokens...)
+}
+
+// legacyMessages converts lint results to the messages of the Linter
+// message that earlier versions of this package wrote.
+func legacyMessages(results []*LintResult) []*Message {
+	messages := make([]*Message, 0, len(results))
+	for _, r := range results {
+		m := &Message{
+			Type:    legacyType(r.Severity),
+			Message: r.Message,
+			Line:    r.Line,
+		}
+		if tokens, err := jsonpointer.Parse(r.Pointer); err == nil && len(tokens) > 0 {
+			m.Keys = tokens
+		}
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+func legacyType(s Severity) string {
+	switch s {
+	case Severity_ERROR:
+		return "Error"
+	case Severity_WARNING:
+		return "Warning"
+	case Severity_INFO:
+		return "Info"
+	case Severity_HINT:
+		return "Hint"
+	}
+	return ""
+}
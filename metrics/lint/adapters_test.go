@@ -0,0 +1,123 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAdapters(t *testing.T) {
+	tests := []struct {
+		linter string
+		output string
+		want   []*LintResult
+	}{
+		{
+			linter: "openapi-validator",
+			output: `{
+  "errors": {"paths-ibm": [{"path": ["paths", "/pets/{id}", "get"], "message": "Path parameter must be defined.", "line": 12}]},
+  "warnings": {"operation-ids": [{"path": "paths./pets.get.operationId", "message": "operationIds should follow naming convention", "line": 8}]}
+}`,
+			want: []*LintResult{
+				{Tool: "openapi-validator", Rule: "paths-ibm", Severity: Severity_ERROR, Message: "Path parameter must be defined.", Pointer: "/paths/~1pets~1{id}/get", Line: 12},
+				{Tool: "openapi-validator", Rule: "operation-ids", Severity: Severity_WARNING, Message: "operationIds should follow naming convention", Pointer: "/paths/~1pets/get/operationId", Line: 8},
+			},
+		},
+		{
+			linter: "spectral",
+			output: `petstore.yaml:3:6 warning info-contact Info object must have "contact" object.
+petstore.yaml:10:9 error operation-operationId Operation must have "operationId".
+`,
+			want: []*LintResult{
+				{Tool: "spectral", Rule: "info-contact", Severity: Severity_WARNING, Message: `Info object must have "contact" object.`, Source: "petstore.yaml", Line: 3, Column: 6},
+				{Tool: "spectral", Rule: "operation-operationId", Severity: Severity_ERROR, Message: `Operation must have "operationId".`, Source: "petstore.yaml", Line: 10, Column: 9},
+			},
+		},
+		{
+			linter: "spectral",
+			output: `[{"code": "operation-tags", "path": ["paths", "/pets", "get"], "message": "Operation must have non-empty \"tags\" array.", "severity": 1,
+  "range": {"start": {"line": 9, "character": 8}, "end": {"line": 20, "character": 0}}, "source": "petstore.yaml"}]`,
+			want: []*LintResult{
+				{Tool: "spectral", Rule: "operation-tags", Severity: Severity_WARNING, Message: `Operation must have non-empty "tags" array.`, Source: "petstore.yaml", Pointer: "/paths/~1pets/get", Line: 10, Column: 9},
+			},
+		},
+		{
+			linter: "redocly",
+			output: `{"totals": {"errors": 1, "warnings": 0, "ignored": 0}, "version": "1.0.0", "problems": [
+  {"ruleId": "operation-4xx-response", "severity": "error", "message": "Operation must have at least one 4XX response.",
+   "location": [{"source": {"ref": "petstore.yaml"}, "pointer": "#/paths/~1pets/get/responses", "reportOnKey": true}]}]}`,
+			want: []*LintResult{
+				{Tool: "redocly", Rule: "operation-4xx-response", Severity: Severity_ERROR, Message: "Operation must have at least one 4XX response.", Source: "petstore.yaml", Pointer: "/paths/~1pets/get/responses"},
+			},
+		},
+		{
+			linter: "vacuum",
+			output: `{"generated": "2023-05-01T00:00:00Z", "resultSet": {"results": [
+  {"message": "Tags must be defined", "range": {"start": {"line": 12, "character": 6}, "end": {"line": 12, "character": 10}},
+   "path": "$.paths['/pets'].get", "ruleId": "operation-tags", "ruleSeverity": "warn"},
+  {"message": "Descriptions must be present", "range": {"start": {"line": 0, "character": 0}},
+   "path": "$..description", "ruleId": "description-duplication", "ruleSeverity": "info"}]}}`,
+			want: []*LintResult{
+				{Tool: "vacuum", Rule: "operation-tags", Severity: Severity_WARNING, Message: "Tags must be defined", Pointer: "/paths/~1pets/get", Line: 12, Column: 7},
+				{Tool: "vacuum", Rule: "description-duplication", Severity: Severity_INFO, Message: "Descriptions must be present"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.linter, func(t *testing.T) {
+			results, err := AdapterForName(test.linter).Parse([]byte(test.output))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if len(results) != len(test.want) {
+				t.Fatalf("got %d results, want %d: %v", len(results), len(test.want), results)
+			}
+			for i := range results {
+				if !proto.Equal(results[i], test.want[i]) {
+					t.Errorf("result %d:\ngot  %v\nwant %v", i, results[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAdapterErrors(t *testing.T) {
+	for _, name := range []string{"redocly", "vacuum"} {
+		if _, err := AdapterForName(name).Parse([]byte(`{"problem": "unknown"}`)); err == nil {
+			t.Errorf("%s: expected an error for unrecognized output", name)
+		}
+	}
+	if results, err := AdapterForName("spectral").Parse([]byte("No results with a severity of 'error' found!")); err != nil || len(results) != 0 {
+		t.Errorf("spectral: expected no results, got %v, %v", results, err)
+	}
+	if _, err := AdapterForName("spectral").Parse([]byte("Error running spectral")); err == nil {
+		t.Errorf("spectral: expected an error for unrecognized output")
+	}
+	if _, err := ReadResults("unknown", "results.json"); err == nil {
+		t.Errorf("expected an error for an unknown linter")
+	}
+}
+
+func TestLegacyMessages(t *testing.T) {
+	messages := legacyMessages([]*LintResult{
+		{Severity: Severity_WARNING, Message: "message", Pointer: "/paths/~1pets/get", Line: 3},
+	})
+	want := &Message{Type: "Warning", Message: "message", Keys: []string{"paths", "/pets", "get"}, Line: 3}
+	if len(messages) != 1 || !proto.Equal(messages[0], want) {
+		t.Errorf("got %v, want %v", messages, want)
+	}
+}
@@ -15,9 +15,6 @@
 package linter
 
 import (
-	"fmt"
-	"os"
-
 	rules "github.com/google/gnostic/metrics/rules"
 	openapi_v3 "github.com/google/gnostic/openapiv3"
 )
@@ -43,7 +40,6 @@ func processParametersV3(components *openapi_v3.Components, path []string) []rul
 func processOperationV3(operation *openapi_v3.Operation, path []string) []rules.Field {
 	parameters := make([]rules.Field, 0)
 	for _, item := range operation.Parameters {
-		fmt.Fprintf(os.Stderr, "%+v\n", item)
 		switch t := item.Oneof.(type) {
 		case *openapi_v3.ParameterOrReference_Parameter:
 			parameters = append(parameters, rules.Field{Name: t.Parameter.Name, Path: path})
@@ -66,7 +62,6 @@ func gatherParameters(document *openapi_v3.Document) []rules.Field {
 
 	if document.Paths != nil {
 		for _, pair := range document.Paths.Path {
-			fmt.Fprintf(os.Stderr, "%+v\n", pair)
 			v := pair.Value
 			path := []string{"paths", pair.Name}
 			if v.Get != nil {
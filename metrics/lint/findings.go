@@ -0,0 +1,96 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	"github.com/google/gnostic/findings"
+	rules "github.com/google/gnostic/metrics/rules"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+)
+
+// BuiltinLinter is the name of the linter of the findings of the built-in rules.
+const BuiltinLinter = "builtin"
+
+// builtinRules are the rules that are run on the parameters of documents.
+var builtinRules = []struct {
+	id     string
+	driver func(rules.Field) []rules.MessageType
+}{
+	{"AIP-122", rules.AIP122Driver},
+	{"AIP-140", rules.AIP140Driver},
+}
+
+// LintV2 runs the built-in rules on an OpenAPI v2 document.
+func LintV2(document *openapi_v2.Document) []*findings.Finding {
+	return lintFields(gatherParametersV2(document))
+}
+
+// LintV3 runs the built-in rules on an OpenAPI v3 document.
+func LintV3(document *openapi_v3.Document) []*findings.Finding {
+	return lintFields(gatherParameters(document))
+}
+
+func lintFields(fields []rules.Field) []*findings.Finding {
+	result := make([]*findings.Finding, 0)
+	for _, field := range fields {
+		for _, rule := range builtinRules {
+			for _, m := range rule.driver(field) {
+				result = append(result, &findings.Finding{
+					Rule:       rule.id,
+					Severity:   strings.ToLower(m.Message[0]),
+					Pointer:    jsonpointer.Format(m.Path...),
+					Message:    strings.TrimSpace(strings.TrimPrefix(m.Message[1], "Message: ")),
+					Suggestion: strings.TrimSpace(strings.TrimPrefix(m.Message[2], "Suggestion: ")),
+					Tool:       BuiltinLinter,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// FindingsFromMessages converts the messages that a lint plugin returned to
+// findings. The codes of the messages are the rules and their key paths are
+// converted to JSON pointers, unless the messages have locations.
+func FindingsFromMessages(linter string, messages []*plugins.Message) []*findings.Finding {
+	result := make([]*findings.Finding, 0, len(messages))
+	for _, m := range messages {
+		severity := strings.ToLower(m.Level.String())
+		if m.Level == plugins.Message_UNKNOWN {
+			severity = findings.Info
+		}
+		f := &findings.Finding{
+			Rule:     m.Code,
+			Severity: severity,
+			Source:   m.SourceName,
+			Pointer:  jsonpointer.Format(m.Keys...),
+			Message:  m.Text,
+			Tool:     linter,
+		}
+		if m.Location != nil {
+			if m.Location.Pointer != "" {
+				f.Pointer = m.Location.Pointer
+			}
+			f.Line, f.Column = int(m.Location.Line), int(m.Location.Column)
+		}
+		result = append(result, f)
+	}
+	return result
+}
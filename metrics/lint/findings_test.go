@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/gnostic/findings"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+)
+
+func TestLintV3(t *testing.T) {
+	document, err := openapi_v3.ParseDocument([]byte(`
+openapi: 3.0.0
+info: {title: pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      parameters:
+      - {name: pet_name, in: query, schema: {type: string}}
+      responses:
+        "200": {description: pets}
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := []*findings.Finding{{
+		Rule:       "AIP-122",
+		Severity:   "error",
+		Pointer:    "/paths/~1pets/get/parameters/name",
+		Message:    `Parameters must not use the suffix "_name"`,
+		Suggestion: "Rename field pet_name to pet",
+		Tool:       BuiltinLinter,
+	}}
+	if result := LintV3(document); !reflect.DeepEqual(result, expected) {
+		t.Errorf("unexpected findings %+v", result)
+	}
+}
+
+func TestFindingsFromMessages(t *testing.T) {
+	result := FindingsFromMessages("gnostic-lint-paths", []*plugins.Message{
+		{Level: plugins.Message_WARNING, Code: "PATH", Text: "a path", Keys: []string{"paths", "/pets"}},
+		{Code: "DOCUMENT", Text: "a document"},
+		{Level: plugins.Message_ERROR, Code: "TAG", Text: "a tag", Keys: []string{"tags"}, SourceName: "stores.yaml",
+			Location: &plugins.SourceLocation{Pointer: "/tags/0", Line: 7, Column: 5}},
+	})
+	expected := []*findings.Finding{
+		{Rule: "PATH", Severity: "warning", Pointer: "/paths/~1pets", Message: "a path", Tool: "gnostic-lint-paths"},
+		{Rule: "DOCUMENT", Severity: "info", Pointer: "", Message: "a document", Tool: "gnostic-lint-paths"},
+		{Rule: "TAG", Severity: "error", Source: "stores.yaml", Pointer: "/tags/0", Line: 7, Column: 5, Message: "a tag", Tool: "gnostic-lint-paths"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("unexpected findings %+v", result)
+	}
+	if !findings.HasErrors(result) {
+		t.Errorf("expected errors")
+	}
+	if findings.HasErrors(result[:2]) {
+		t.Errorf("expected no errors")
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.23.4
+// source: metrics/lint/lintresults.proto
+
+package linter
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Severity is the severity of a lint result. The severities of linters are
+// mapped to the closest of these.
+type Severity int32
+
+const (
+	Severity_UNKNOWN Severity = 0
+	Severity_HINT    Severity = 1
+	Severity_INFO    Severity = 2
+	Severity_WARNING Severity = 3
+	Severity_ERROR   Severity = 4
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "HINT",
+		2: "INFO",
+		3: "WARNING",
+		4: "ERROR",
+	}
+	Severity_value = map[string]int32{
+		"UNKNOWN": 0,
+		"HINT":    1,
+		"INFO":    2,
+		"WARNING": 3,
+		"ERROR":   4,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_metrics_lint_lintresults_proto_enumTypes[0].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_metrics_lint_lintresults_proto_enumTypes[0]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_metrics_lint_lintresults_proto_rawDescGZIP(), []int{0}
+}
+
+// LintResult is a problem that a linter found in an API description.
+type LintResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The linter that found the problem, such as "spectral" or "redocly".
+	Tool string `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	// The rule that found the problem, if the linter reports it.
+	Rule     string   `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	Severity Severity `protobuf:"varint,3,opt,name=severity,proto3,enum=gnostic.lint.v1.Severity" json:"severity,omitempty"`
+	// A description of the problem.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// The file name or URL of the description, if the linter reports it.
+	Source string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	// A JSON pointer to the value with the problem, if the linter reports
+	// its location in the description.
+	Pointer string `protobuf:"bytes,6,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	// The position of the value in the source, counting from 1, if the
+	// linter reports it.
+	Line   int32 `protobuf:"varint,7,opt,name=line,proto3" json:"line,omitempty"`
+	Column int32 `protobuf:"varint,8,opt,name=column,proto3" json:"column,omitempty"`
+}
+
+func (x *LintResult) Reset() {
+	*x = LintResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_lint_lintresults_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LintResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintResult) ProtoMessage() {}
+
+func (x *LintResult) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_lint_lintresults_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintResult.ProtoReflect.Descriptor instead.
+func (*LintResult) Descriptor() ([]byte, []int) {
+	return file_metrics_lint_lintresults_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LintResult) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *LintResult) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *LintResult) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_UNKNOWN
+}
+
+func (x *LintResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LintResult) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LintResult) GetPointer() string {
+	if x != nil {
+		return x.Pointer
+	}
+	return ""
+}
+
+func (x *LintResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *LintResult) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+// LintResults are the results of one or more linters.
+type LintResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*LintResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *LintResults) Reset() {
+	*x = LintResults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_lint_lintresults_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LintResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintResults) ProtoMessage() {}
+
+func (x *LintResults) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_lint_lintresults_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintResults.ProtoReflect.Descriptor instead.
+func (*LintResults) Descriptor() ([]byte, []int) {
+	return file_metrics_lint_lintresults_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LintResults) GetResults() []*LintResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_metrics_lint_lintresults_proto protoreflect.FileDescriptor
+
+var file_metrics_lint_lintresults_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x6c, 0x69, 0x6e, 0x74, 0x2f, 0x6c,
+	0x69, 0x6e, 0x74, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0f, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x6c, 0x69, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x22, 0xe3, 0x01, 0x0a, 0x0a, 0x4c, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x2e, 0x6c, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0x44, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x2e, 0x6c, 0x69, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x2a, 0x43, 0x0a,
+	0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x4e, 0x54, 0x10, 0x01,
+	0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41,
+	0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x04, 0x42, 0x17, 0x5a, 0x15, 0x2e, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f,
+	0x6c, 0x69, 0x6e, 0x74, 0x3b, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_metrics_lint_lintresults_proto_rawDescOnce sync.Once
+	file_metrics_lint_lintresults_proto_rawDescData = file_metrics_lint_lintresults_proto_rawDesc
+)
+
+func file_metrics_lint_lintresults_proto_rawDescGZIP() []byte {
+	file_metrics_lint_lintresults_proto_rawDescOnce.Do(func() {
+		file_metrics_lint_lintresults_proto_rawDescData = protoimpl.X.CompressGZIP(file_metrics_lint_lintresults_proto_rawDescData)
+	})
+	return file_metrics_lint_lintresults_proto_rawDescData
+}
+
+var file_metrics_lint_lintresults_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_metrics_lint_lintresults_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_metrics_lint_lintresults_proto_goTypes = []interface{}{
+	(Severity)(0),       // 0: gnostic.lint.v1.Severity
+	(*LintResult)(nil),  // 1: gnostic.lint.v1.LintResult
+	(*LintResults)(nil), // 2: gnostic.lint.v1.LintResults
+}
+var file_metrics_lint_lintresults_proto_depIdxs = []int32{
+	0, // 0: gnostic.lint.v1.LintResult.severity:type_name -> gnostic.lint.v1.Severity
+	1, // 1: gnostic.lint.v1.LintResults.results:type_name -> gnostic.lint.v1.LintResult
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_metrics_lint_lintresults_proto_init() }
+func file_metrics_lint_lintresults_proto_init() {
+	if File_metrics_lint_lintresults_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_metrics_lint_lintresults_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LintResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_lint_lintresults_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LintResults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_metrics_lint_lintresults_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_metrics_lint_lintresults_proto_goTypes,
+		DependencyIndexes: file_metrics_lint_lintresults_proto_depIdxs,
+		EnumInfos:         file_metrics_lint_lintresults_proto_enumTypes,
+		MessageInfos:      file_metrics_lint_lintresults_proto_msgTypes,
+	}.Build()
+	File_metrics_lint_lintresults_proto = out.File
+	file_metrics_lint_lintresults_proto_rawDesc = nil
+	file_metrics_lint_lintresults_proto_goTypes = nil
+	file_metrics_lint_lintresults_proto_depIdxs = nil
+}
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"strings"
 
+	"github.com/google/gnostic/compiler/jsonpointer"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -65,115 +65,76 @@ func writePb(v *Linter) {
 	}
 }
 
-// addToMessages creates a new Message struct given a message type, message, path
-// and line. The new struct is then returned.
-func addToMessages(mtype string, message string, path []string, line int) *Message {
-	temp := &Message{
-		Type:    mtype,
-		Message: message,
-		Keys:    path,
-		Line:    int32(line),
-	}
-	return temp
-}
+// openAPIValidatorAdapter converts the JSON output of IBM's openapi-validator,
+// which lists errors and warnings by the validations that found them.
+type openAPIValidatorAdapter struct{}
 
-// fillMessageProtoStructureIBM is used to create a slice of messages
-// from the results of IBM's openapi-validator output.
-func fillMessageProtoStructureIBM(lint IBMLint) []*Message {
-	messages := make([]*Message, 0)
-	for _, v := range lint.LinterErrors.Parameters {
-		temp := addToMessages("Error", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterErrors.PathsIBM {
-		temp := addToMessages("Error", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterErrors.Paths {
-		temp := addToMessages("Error", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterErrors.Schemas {
-		temp := addToMessages("Error", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterErrors.FormData {
-		temp := addToMessages("Error", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterErrors.WalkerIBM {
-		temp := addToMessages("Error", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.OperationID {
-		temp := addToMessages("Warning", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.OperationsShared {
-		temp := addToMessages("Warning", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.Refs {
-		temp := addToMessages("Warning", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.Schemas {
-		temp := addToMessages("Warning", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.PathsIBM {
-		temp := addToMessages("Warning", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.WalkerIBM {
-		temp := addToMessages("Warning", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.CircularIBM {
-		temp := addToMessages("Warning", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.Operations {
-		temp := addToMessages("Warning", v.Message, strings.Split(v.Path, "."), v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.Responses {
-		temp := addToMessages("Warning", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	for _, v := range lint.LinterWarnings.ParametersIBM {
-		temp := addToMessages("Warning", v.Message, v.Path, v.Line)
-		messages = append(messages, temp)
-	}
-	return messages
+func (openAPIValidatorAdapter) Name() string {
+	return "openapi-validator"
 }
 
-// openAndReadJSON takes the name of the filename that contains the linter results
-// from the openapi-validator and parses it into the linter struct
-func openAndReadJSON(filename string) IBMLint {
-	jsonFile, err := os.Open(filename)
-	if err != nil {
-		fmt.Println(err)
-	}
-	defer jsonFile.Close()
-
-	byteValue, _ := ioutil.ReadAll(jsonFile)
+func (a openAPIValidatorAdapter) Parse(output []byte) ([]*LintResult, error) {
 	var lint IBMLint
+	if err := json.Unmarshal(output, &lint); err != nil {
+		return nil, err
+	}
+	results := make([]*LintResult, 0)
+	add := func(rule string, severity Severity, messages []EMessage) {
+		for _, v := range messages {
+			results = append(results, a.result(rule, severity, v.Message, v.Path, v.Line))
+		}
+	}
+	addDotted := func(rule string, severity Severity, messages []WMessage) {
+		for _, v := range messages {
+			results = append(results, a.result(rule, severity, v.Message, strings.Split(v.Path, "."), v.Line))
+		}
+	}
+	add("parameters-ibm", Severity_ERROR, lint.LinterErrors.Parameters)
+	add("paths-ibm", Severity_ERROR, lint.LinterErrors.PathsIBM)
+	addDotted("paths", Severity_ERROR, lint.LinterErrors.Paths)
+	add("schema-ibm", Severity_ERROR, lint.LinterErrors.Schemas)
+	addDotted("form-data", Severity_ERROR, lint.LinterErrors.FormData)
+	add("walker-ibm", Severity_ERROR, lint.LinterErrors.WalkerIBM)
+	addDotted("operation-ids", Severity_WARNING, lint.LinterWarnings.OperationID)
+	addDotted("operations-shared", Severity_WARNING, lint.LinterWarnings.OperationsShared)
+	addDotted("refs", Severity_WARNING, lint.LinterWarnings.Refs)
+	add("schema-ibm", Severity_WARNING, lint.LinterWarnings.Schemas)
+	add("paths-ibm", Severity_WARNING, lint.LinterWarnings.PathsIBM)
+	add("walker-ibm", Severity_WARNING, lint.LinterWarnings.WalkerIBM)
+	addDotted("circular-references-ibm", Severity_WARNING, lint.LinterWarnings.CircularIBM)
+	addDotted("operation", Severity_WARNING, lint.LinterWarnings.Operations)
+	add("responses", Severity_WARNING, lint.LinterWarnings.Responses)
+	add("parameters-ibm", Severity_WARNING, lint.LinterWarnings.ParametersIBM)
+	return results, nil
+}
 
-	json.Unmarshal(byteValue, &lint)
-
-	return lint
+func (a openAPIValidatorAdapter) result(rule string, severity Severity, message string, path []string, line int) *LintResult {
+	r := &LintResult{
+		Tool:     a.Name(),
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		Line:     int32(line),
+	}
+	if len(path) > 0 && path[0] != "" {
+		r.Pointer = jsonpointer.Format(path...)
+	}
+	return r
 }
 
 // LintOpenapiValidator functions serves as a linter results translater. The function takes the filename
 // which contains the json results of IBM's openapi-validator and creates a new instance of
 // the linter struct using the JSON data.
+//
+// Deprecated: Use ReadResults to read the results of openapi-validator as
+// lint results.
 func LintOpenAPIValidator(filename string) {
-	lint := openAndReadJSON(filename)
-	messages := fillMessageProtoStructureIBM(lint)
-
+	results, err := ReadResults(openAPIValidatorAdapter{}.Name(), filename)
+	if err != nil {
+		fmt.Println(err)
+	}
 	linterResult := &Linter{
-		Messages: messages,
+		Messages: legacyMessages(results),
 	}
 
 	writePb(linterResult)
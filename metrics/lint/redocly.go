@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"encoding/json"
+)
+
+// redoclyAdapter converts the JSON output of "redocly lint --format=json",
+// which locates problems with references to the values that have them.
+type redoclyAdapter struct{}
+
+func (redoclyAdapter) Name() string {
+	return "redocly"
+}
+
+type redoclyOutput struct {
+	Problems *[]struct {
+		RuleID   string `json:"ruleId"`
+		Severity string `json:"severity"`
+		Message  string `json:"message"`
+		Location []struct {
+			Source struct {
+				Ref string `json:"ref"`
+			} `json:"source"`
+			Pointer string `json:"pointer"`
+		} `json:"location"`
+	} `json:"problems"`
+}
+
+func (a redoclyAdapter) Parse(output []byte) ([]*LintResult, error) {
+	var v redoclyOutput
+	if err := json.Unmarshal(output, &v); err != nil {
+		return nil, err
+	}
+	if v.Problems == nil {
+		return nil, errUnrecognizedOutput
+	}
+	results := make([]*LintResult, 0, len(*v.Problems))
+	for _, p := range *v.Problems {
+		r := &LintResult{
+			Tool:     a.Name(),
+			Rule:     p.RuleID,
+			Severity: severityForName(p.Severity),
+			Message:  p.Message,
+		}
+		if len(p.Location) > 0 {
+			r.Source = p.Location[0].Source.Ref
+			r.Pointer = pointerForFragment(p.Location[0].Pointer)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -2,59 +2,135 @@ package linter
 
 import (
 	"bufio"
-	"log"
-	"os"
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
 )
 
-// parseOutput creates a new Message struct given a message type, message, path
-// and line. The new struct is then returned.
-func parseOutput(output []string) []*Message {
-	messages := make([]*Message, 0)
-	for _, line := range output {
-		array := regexp.MustCompile("[]: *]").Split(line, 6)
-		line, _ := strconv.ParseInt(array[1], 0, 64)
-		temp := &Message{
-			Type:    array[3],
-			Message: array[5],
-			Line:    int32(line),
-		}
-		messages = append(messages, temp)
-	}
-	return messages
+// spectralAdapter converts the output of Stoplight's spectral, which can be
+// the text that "spectral lint -f text" writes or the JSON that
+// "spectral lint -f json" writes. Vacuum writes the same JSON with
+// "vacuum spectral-report".
+type spectralAdapter struct{}
+
+func (spectralAdapter) Name() string {
+	return "spectral"
 }
 
-// Text takes the name of the filename that contains the linter results
-// from the spectral linter and parses it into a string slice
-func openAndReadText(filename string) []string {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
+func (a spectralAdapter) Parse(output []byte) ([]*LintResult, error) {
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return a.parseJSON(trimmed)
 	}
-	defer file.Close()
+	return a.parseText(output)
+}
 
-	output := make([]string, 0)
+// spectralTextLine matches lines like
+// "openapi.yaml:3:6 warning info-contact Info object must have "contact" object."
+var spectralTextLine = regexp.MustCompile(`^(.*):(\d+):(\d+) +(\w+) +(\S+) +(.*)$`)
 
-	scanner := bufio.NewScanner(file)
+func (a spectralAdapter) parseText(output []byte) ([]*LintResult, error) {
+	results := make([]*LintResult, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
 	for scanner.Scan() {
-		output = append(output, scanner.Text())
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "No results") {
+			continue
+		}
+		m := spectralTextLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("%v: %q", errUnrecognizedOutput, line)
+		}
+		row, _ := strconv.Atoi(m[2])
+		column, _ := strconv.Atoi(m[3])
+		results = append(results, &LintResult{
+			Tool:     a.Name(),
+			Rule:     m[5],
+			Severity: severityForName(m[4]),
+			Message:  m[6],
+			Source:   m[1],
+			Line:     int32(row),
+			Column:   int32(column),
+		})
 	}
+	return results, scanner.Err()
+}
+
+// spectralResult is a result in the JSON output of spectral. Its positions
+// count from 0 and its severities are numbered from 0 (error) to 3 (hint).
+type spectralResult struct {
+	Code     json.RawMessage `json:"code"`
+	Path     []interface{}   `json:"path"`
+	Message  string          `json:"message"`
+	Severity int             `json:"severity"`
+	Source   string          `json:"source"`
+	Range    struct {
+		Start struct {
+			Line      int `json:"line"`
+			Character int `json:"character"`
+		} `json:"start"`
+	} `json:"range"`
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+var spectralSeverities = []Severity{Severity_ERROR, Severity_WARNING, Severity_INFO, Severity_HINT}
+
+func (a spectralAdapter) parseJSON(output []byte) ([]*LintResult, error) {
+	var list []spectralResult
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, err
+	}
+	results := make([]*LintResult, 0, len(list))
+	for _, v := range list {
+		r := &LintResult{
+			Tool:    a.Name(),
+			Rule:    jsonText(v.Code),
+			Message: v.Message,
+			Source:  v.Source,
+			Line:    int32(v.Range.Start.Line + 1),
+			Column:  int32(v.Range.Start.Character + 1),
+		}
+		if v.Severity >= 0 && v.Severity < len(spectralSeverities) {
+			r.Severity = spectralSeverities[v.Severity]
+		}
+		if len(v.Path) > 0 {
+			tokens := make([]string, len(v.Path))
+			for i, token := range v.Path {
+				tokens[i] = fmt.Sprint(token)
+			}
+			r.Pointer = jsonpointer.Format(tokens...)
+		}
+		results = append(results, r)
 	}
-	return output
+	return results, nil
+}
+
+// jsonText returns the text of a JSON string or number.
+func jsonText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
 }
 
 // LintSpectral functions serves as a linter results translater. The function takes the filename
 // which contains the text results of Stoplights's spectral and creates a new instance of
 // the linter struct using the text data.
+//
+// Deprecated: Use ReadResults to read the results of spectral as lint
+// results.
 func LintSpectral(filename string) {
-	output := openAndReadText(filename)
-	messages := parseOutput(output)
+	results, err := ReadResults(spectralAdapter{}.Name(), filename)
+	if err != nil {
+		fmt.Println(err)
+	}
 	linterResult := &Linter{
-		Messages: messages,
+		Messages: legacyMessages(results),
 	}
 	writePb(linterResult)
 }
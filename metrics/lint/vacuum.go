@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpath"
+)
+
+// vacuumAdapter converts the JSON report that "vacuum report" writes, which
+// locates results with JSONPath expressions. Its positions count lines
+// from 1 and characters from 0.
+type vacuumAdapter struct{}
+
+func (vacuumAdapter) Name() string {
+	return "vacuum"
+}
+
+type vacuumOutput struct {
+	ResultSet *struct {
+		Results []struct {
+			Message string `json:"message"`
+			Range   struct {
+				Start struct {
+					Line      int `json:"line"`
+					Character int `json:"character"`
+				} `json:"start"`
+			} `json:"range"`
+			Path         string `json:"path"`
+			RuleID       string `json:"ruleId"`
+			RuleSeverity string `json:"ruleSeverity"`
+		} `json:"results"`
+	} `json:"resultSet"`
+}
+
+func (a vacuumAdapter) Parse(output []byte) ([]*LintResult, error) {
+	trimmed := strings.TrimSpace(string(output))
+	if strings.HasPrefix(trimmed, "[") {
+		// vacuum writes spectral's JSON with "vacuum spectral-report".
+		results, err := spectralAdapter{}.parseJSON([]byte(trimmed))
+		for _, r := range results {
+			r.Tool = a.Name()
+		}
+		return results, err
+	}
+	var v vacuumOutput
+	if err := json.Unmarshal(output, &v); err != nil {
+		return nil, err
+	}
+	if v.ResultSet == nil {
+		return nil, errUnrecognizedOutput
+	}
+	results := make([]*LintResult, 0, len(v.ResultSet.Results))
+	for _, result := range v.ResultSet.Results {
+		r := &LintResult{
+			Tool:     a.Name(),
+			Rule:     result.RuleID,
+			Severity: severityForName(result.RuleSeverity),
+			Message:  result.Message,
+			Line:     int32(result.Range.Start.Line),
+		}
+		if r.Line > 0 {
+			r.Column = int32(result.Range.Start.Character + 1)
+		}
+		if path, err := jsonpath.Parse(result.Path); err == nil {
+			r.Pointer, _ = path.Pointer()
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"github.com/google/gnostic/metrics/coverage"
+	openapi "github.com/google/gnostic/openapiv2"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+// NewStatsV2 summarizes an OpenAPI v2 description.
+func NewStatsV2(document *openapi.Document) *Stats {
+	s := newStats(statistics.NewDocumentStatistics("", document), coverage.NewReportV2("", document))
+	s.OpenAPI = document.Swagger
+	s.Version = document.GetInfo().GetVersion()
+	if document.SecurityDefinitions != nil {
+		for _, pair := range document.SecurityDefinitions.AdditionalProperties {
+			s.SecuritySchemes = append(s.SecuritySchemes, &SecuritySchemeUsage{
+				Name: pair.Name,
+				Type: securityTypeV2(pair.Value),
+			})
+		}
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			s.Paths++
+			path := pair.Value
+			for _, operation := range []*openapi.Operation{
+				path.Get, path.Put, path.Post, path.Delete, path.Options, path.Head, path.Patch,
+			} {
+				if operation == nil {
+					continue
+				}
+				security := operation.Security
+				if security == nil {
+					security = document.Security
+				}
+				s.useSecurity(securityNamesV2(security))
+			}
+		}
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			s.Schemas++
+			s.addSchemaSize(pair.Name, propertiesV2(pair.Value))
+		}
+	}
+	return s
+}
+
+// propertiesV2 counts the properties of a schema and of its inline
+// schemas.
+func propertiesV2(schema *openapi.Schema) int {
+	if schema == nil || schema.XRef != "" {
+		return 0
+	}
+	count := 0
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			count++
+			count += propertiesV2(pair.Value)
+		}
+	}
+	if schema.Items != nil {
+		for _, item := range schema.Items.Schema {
+			count += propertiesV2(item)
+		}
+	}
+	for _, part := range schema.AllOf {
+		count += propertiesV2(part)
+	}
+	return count
+}
+
+func securityTypeV2(item *openapi.SecurityDefinitionsItem) string {
+	switch {
+	case item.GetBasicAuthenticationSecurity() != nil:
+		return "basic"
+	case item.GetApiKeySecurity() != nil:
+		return "apiKey"
+	}
+	return "oauth2"
+}
+
+func securityNamesV2(requirements []*openapi.SecurityRequirement) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, requirement := range requirements {
+		for _, pair := range requirement.AdditionalProperties {
+			if !seen[pair.Name] {
+				seen[pair.Name] = true
+				names = append(names, pair.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"github.com/google/gnostic/metrics/coverage"
+	openapi "github.com/google/gnostic/openapiv3"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+// NewStatsV3 summarizes an OpenAPI v3 description.
+func NewStatsV3(document *openapi.Document) *Stats {
+	s := newStats(statistics.NewDocumentStatisticsV3("", document), coverage.NewReportV3("", document))
+	s.OpenAPI = document.Openapi
+	s.Version = document.GetInfo().GetVersion()
+	components := document.Components
+	if components != nil && components.SecuritySchemes != nil {
+		for _, pair := range components.SecuritySchemes.AdditionalProperties {
+			s.SecuritySchemes = append(s.SecuritySchemes, &SecuritySchemeUsage{
+				Name: pair.Name,
+				Type: pair.Value.GetSecurityScheme().GetType(),
+			})
+		}
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			s.Paths++
+			path := pair.Value
+			for _, operation := range []*openapi.Operation{
+				path.Get, path.Put, path.Post, path.Delete, path.Options, path.Head, path.Patch, path.Trace,
+			} {
+				if operation == nil {
+					continue
+				}
+				security := operation.Security
+				if security == nil {
+					security = document.Security
+				}
+				s.useSecurity(securityNamesV3(security))
+			}
+		}
+	}
+	if components != nil && components.Schemas != nil {
+		for _, pair := range components.Schemas.AdditionalProperties {
+			s.Schemas++
+			s.addSchemaSize(pair.Name, propertiesV3(pair.Value))
+		}
+	}
+	return s
+}
+
+// propertiesV3 counts the properties of a schema and of its inline
+// schemas.
+func propertiesV3(schemaOrReference *openapi.SchemaOrReference) int {
+	schema := schemaOrReference.GetSchema()
+	if schema == nil {
+		return 0
+	}
+	count := 0
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			count++
+			count += propertiesV3(pair.Value)
+		}
+	}
+	if schema.Items != nil {
+		for _, item := range schema.Items.SchemaOrReference {
+			count += propertiesV3(item)
+		}
+	}
+	for _, part := range schema.AllOf {
+		count += propertiesV3(part)
+	}
+	return count
+}
+
+func securityNamesV3(requirements []*openapi.SecurityRequirement) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, requirement := range requirements {
+		for _, pair := range requirement.AdditionalProperties {
+			if !seen[pair.Name] {
+				seen[pair.Name] = true
+				names = append(names, pair.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats summarizes API descriptions for people who want a quick
+// look at the size and quality of a description. It adds description
+// coverage, security scheme usage, and schema sizes to the document
+// statistics of gnostic-analyze.
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
+)
+
+// maxLargestSchemas is the number of schemas listed in LargestSchemas.
+const maxLargestSchemas = 5
+
+// methods are the methods of operations in the order that they are listed.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Stats summarizes an API description.
+type Stats struct {
+	Title   string
+	Version string
+	// OpenAPI is the OpenAPI version of the description.
+	OpenAPI string
+	Paths   int
+	// Operations counts the operations of each method, all of the
+	// operations ("total"), and the operations without ids ("anonymous").
+	Operations map[string]int
+	// Schemas counts the named schemas of the description.
+	Schemas int
+	// Components counts the components of each kind, including schemas.
+	// It is only set for OpenAPI v3 descriptions.
+	Components map[string]int
+	// Documentation is the description coverage of operations, parameters,
+	// and properties, as it is measured by "gnostic coverage".
+	Documentation *metrics.Documentation
+	// SecuritySchemes lists the security schemes by name.
+	SecuritySchemes []*SecuritySchemeUsage
+	// LargestSchemas lists the named schemas with the most properties.
+	LargestSchemas []*SchemaSize
+}
+
+// Coverage counts the values of a kind that have descriptions.
+type Coverage struct {
+	Kind      string
+	Described int
+	Total     int
+}
+
+// Percent returns the percentage of values that have descriptions. Kinds
+// without values are fully covered.
+func (c *Coverage) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return 100 * float64(c.Described) / float64(c.Total)
+}
+
+// SecuritySchemeUsage counts the operations that use a security scheme,
+// either directly or through the default security of the description.
+type SecuritySchemeUsage struct {
+	Name       string
+	Type       string
+	Operations int
+}
+
+// SchemaSize counts the properties of a named schema, including the
+// properties of its inline object, array, and allOf schemas.
+type SchemaSize struct {
+	Name       string
+	Properties int
+}
+
+func newStats(s *statistics.DocumentStatistics, r *metrics.DocumentationReport) *Stats {
+	return &Stats{
+		Title:           s.Title,
+		Operations:      s.Operations,
+		Components:      s.Components,
+		Documentation:   r.Files[0],
+		SecuritySchemes: make([]*SecuritySchemeUsage, 0),
+		LargestSchemas:  make([]*SchemaSize, 0),
+	}
+}
+
+// useSecurity counts an operation that uses the named security schemes.
+func (s *Stats) useSecurity(names []string) {
+	for _, name := range names {
+		for _, scheme := range s.SecuritySchemes {
+			if scheme.Name == name {
+				scheme.Operations++
+			}
+		}
+	}
+}
+
+// addSchemaSize records the size of a named schema and keeps the largest.
+func (s *Stats) addSchemaSize(name string, properties int) {
+	s.LargestSchemas = append(s.LargestSchemas, &SchemaSize{Name: name, Properties: properties})
+	sort.SliceStable(s.LargestSchemas, func(i, j int) bool {
+		return s.LargestSchemas[i].Properties > s.LargestSchemas[j].Properties
+	})
+	if len(s.LargestSchemas) > maxLargestSchemas {
+		s.LargestSchemas = s.LargestSchemas[:maxLargestSchemas]
+	}
+}
+
+// OperationCount returns the number of operations in the description.
+func (s *Stats) OperationCount() int {
+	return s.Operations["total"]
+}
+
+// Descriptions returns the description coverage of operations,
+// parameters, and properties.
+func (s *Stats) Descriptions() []*Coverage {
+	d := s.Documentation
+	return []*Coverage{
+		newCoverage("operations", d.GetOperations()),
+		newCoverage("parameters", d.GetParameters()),
+		newCoverage("properties", d.GetProperties()),
+	}
+}
+
+func newCoverage(kind string, c *metrics.DescriptionCount) *Coverage {
+	return &Coverage{Kind: kind, Described: int(c.GetDescribed()), Total: int(c.GetTotal())}
+}
+
+// DescriptionCoverage returns the description coverage of all of the
+// operations, parameters, and properties. Its percentage is the score of
+// "gnostic coverage".
+func (s *Stats) DescriptionCoverage() *Coverage {
+	total := &Coverage{Kind: "total"}
+	for _, c := range s.Descriptions() {
+		total.Described += c.Described
+		total.Total += c.Total
+	}
+	return total
+}
+
+// Write writes a summary that is formatted to be read in a terminal.
+func (s *Stats) Write(w io.Writer) error {
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	title := s.Title
+	if s.Version != "" {
+		title += " " + s.Version
+	}
+	fmt.Fprintf(tw, "%s (OpenAPI %s)\n\n", title, s.OpenAPI)
+	fmt.Fprintf(tw, "Paths\t%d\n", s.Paths)
+	fmt.Fprintf(tw, "Operations\t%d\n", s.OperationCount())
+	for _, method := range methods {
+		if n := s.Operations[method]; n > 0 {
+			fmt.Fprintf(tw, "  %s\t%d\n", strings.ToUpper(method), n)
+		}
+	}
+	if n := s.Operations["anonymous"]; n > 0 {
+		fmt.Fprintf(tw, "  without ids\t%d\n", n)
+	}
+	fmt.Fprintf(tw, "Schemas\t%d\n", s.Schemas)
+	// Schemas are counted above.
+	kinds := make([]string, 0)
+	for _, kind := range sortedKeys(s.Components) {
+		if kind != "schemas" {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) > 0 {
+		fmt.Fprintf(tw, "Components\t\n")
+		for _, kind := range kinds {
+			fmt.Fprintf(tw, "  %s\t%d\n", kind, s.Components[kind])
+		}
+	}
+	coverage := s.DescriptionCoverage()
+	fmt.Fprintf(tw, "\nDescription coverage\t%s\n", coverage.String())
+	for _, c := range s.Descriptions() {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.Kind, c.String())
+	}
+	if n := len(s.Documentation.GetPlaceholders()); n > 0 {
+		fmt.Fprintf(tw, "  placeholders\t%d\n", n)
+	}
+	if len(s.SecuritySchemes) > 0 {
+		fmt.Fprintf(tw, "\nSecurity schemes\t\n")
+		for _, scheme := range s.SecuritySchemes {
+			fmt.Fprintf(tw, "  %s (%s)\t%s\n", scheme.Name, scheme.Type, plural(scheme.Operations, "operation"))
+		}
+	}
+	if len(s.LargestSchemas) > 0 {
+		fmt.Fprintf(tw, "\nLargest schemas\t\n")
+		for _, schema := range s.LargestSchemas {
+			fmt.Fprintf(tw, "  %s\t%s\n", schema.Name, plural(schema.Properties, "property"))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// Remove the padding of lines that only have headings.
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func (c *Coverage) String() string {
+	return fmt.Sprintf("%.0f%% (%d of %d)", c.Percent(), c.Described, c.Total)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	if strings.HasSuffix(word, "y") {
+		return fmt.Sprintf("%d %sies", n, strings.TrimSuffix(word, "y"))
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/gnostic/metrics/coverage"
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+)
+
+const documentV3 = `
+openapi: 3.0.0
+info:
+  title: Library
+  version: 1.0.0
+security:
+  - key: []
+paths:
+  /books:
+    get:
+      operationId: listBooks
+      summary: List books.
+      parameters:
+        - name: author
+          in: query
+          description: The author of the books.
+          schema:
+            type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+    post:
+      security:
+        - oauth: [write]
+      responses:
+        "200":
+          description: OK
+  /books/{id}:
+    patch:
+      operationId: updateBook
+      security:
+        - key: []
+        - oauth: [write]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    Page:
+      name: page
+      in: query
+      description: A page token.
+      schema:
+        type: string
+  securitySchemes:
+    key:
+      type: apiKey
+      name: key
+      in: query
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/auth
+          scopes:
+            write: Write books.
+  schemas:
+    Book:
+      description: A book.
+      properties:
+        title:
+          type: string
+          description: The title of the book.
+        author:
+          $ref: "#/components/schemas/Author"
+        chapters:
+          type: array
+          items:
+            properties:
+              title:
+                type: string
+              pages:
+                type: integer
+    Author:
+      allOf:
+        - $ref: "#/components/schemas/Person"
+        - properties:
+            books:
+              type: integer
+    Person:
+      properties:
+        name:
+          type: string
+`
+
+const statsV3 = `Library 1.0.0 (OpenAPI 3.0.0)
+
+Paths              2
+Operations         3
+  GET              1
+  POST             1
+  PATCH            1
+  without ids      1
+Schemas            3
+Components
+  parameters       1
+  securitySchemes  2
+
+Description coverage  31% (4 of 13)
+  operations          33% (1 of 3)
+  parameters          50% (2 of 4)
+  properties          17% (1 of 6)
+
+Security schemes
+  key (apiKey)    2 operations
+  oauth (oauth2)  2 operations
+
+Largest schemas
+  Book           5 properties
+  Author         1 property
+  Person         1 property
+`
+
+func TestStatsV3(t *testing.T) {
+	document, err := openapiv3.ParseDocument([]byte(documentV3))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	s := NewStatsV3(document)
+	if s.OperationCount() != 3 {
+		t.Errorf("got %d operations, want 3", s.OperationCount())
+	}
+	if c := s.DescriptionCoverage(); c.Described != 4 || c.Total != 13 {
+		t.Errorf("got coverage of %d of %d, want 4 of 13", c.Described, c.Total)
+	}
+	var b bytes.Buffer
+	if err := s.Write(&b); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if b.String() != statsV3 {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), statsV3)
+	}
+}
+
+func TestStatsV2(t *testing.T) {
+	bytes, err := ioutil.ReadFile("../../examples/v2.0/yaml/petstore-expanded.yaml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	document, err := openapiv2.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	s := NewStatsV2(document)
+	if s.Paths != 2 || s.OperationCount() != 4 || s.Operations["delete"] != 1 {
+		t.Errorf("got %d paths and operations %v", s.Paths, s.Operations)
+	}
+	if s.Schemas != 3 {
+		t.Errorf("got %d schemas, want 3", s.Schemas)
+	}
+	want := []*SchemaSize{{"NewPet", 2}, {"Error", 2}, {"Pet", 1}}
+	if len(s.LargestSchemas) != len(want) {
+		t.Fatalf("got %d largest schemas, want %d", len(s.LargestSchemas), len(want))
+	}
+	for i, size := range s.LargestSchemas {
+		if *size != *want[i] {
+			t.Errorf("got largest schema %+v, want %+v", size, want[i])
+		}
+	}
+	// The description coverage is the score of "gnostic coverage".
+	score := coverage.NewReportV2("", document).Files[0].Score
+	if c := s.DescriptionCoverage(); c.Percent() != score {
+		t.Errorf("got description coverage of %.0f%%, want %.0f%%", c.Percent(), score)
+	}
+}
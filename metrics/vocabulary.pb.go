@@ -349,6 +349,121 @@ func (x *VersionHistory) GetVersions() []*Version {
 	return nil
 }
 
+// A VocabularySource identifies a vocabulary that was added to a union.
+type VocabularySource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the file that contained the vocabulary.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The SHA-256 digest of the file contents.
+	Digest string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *VocabularySource) Reset() {
+	*x = VocabularySource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_vocabulary_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VocabularySource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VocabularySource) ProtoMessage() {}
+
+func (x *VocabularySource) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_vocabulary_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VocabularySource.ProtoReflect.Descriptor instead.
+func (*VocabularySource) Descriptor() ([]byte, []int) {
+	return file_metrics_vocabulary_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VocabularySource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VocabularySource) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+// A VocabularyUnion is the accumulated union of a set of vocabularies.
+// It is updated incrementally as new vocabularies are added.
+type VocabularyUnion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vocabulary *Vocabulary         `protobuf:"bytes,1,opt,name=vocabulary,proto3" json:"vocabulary,omitempty"`
+	Sources    []*VocabularySource `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *VocabularyUnion) Reset() {
+	*x = VocabularyUnion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_vocabulary_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VocabularyUnion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VocabularyUnion) ProtoMessage() {}
+
+func (x *VocabularyUnion) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_vocabulary_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VocabularyUnion.ProtoReflect.Descriptor instead.
+func (*VocabularyUnion) Descriptor() ([]byte, []int) {
+	return file_metrics_vocabulary_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VocabularyUnion) GetVocabulary() *Vocabulary {
+	if x != nil {
+		return x.Vocabulary
+	}
+	return nil
+}
+
+func (x *VocabularyUnion) GetSources() []*VocabularySource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
 var File_metrics_vocabulary_proto protoreflect.FileDescriptor
 
 var file_metrics_vocabulary_proto_rawDesc = []byte{
@@ -402,10 +517,23 @@ var file_metrics_vocabulary_proto_rawDesc = []byte{
 	0x12, 0x37, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x6d, 0x65, 0x74,
 	0x72, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x1e, 0x5a, 0x1c, 0x2e, 0x2f, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x3b, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3e, 0x0a, 0x10, 0x56, 0x6f, 0x63,
+	0x61, 0x62, 0x75, 0x6c, 0x61, 0x72, 0x79, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x91, 0x01, 0x0a, 0x0f, 0x56, 0x6f,
+	0x63, 0x61, 0x62, 0x75, 0x6c, 0x61, 0x72, 0x79, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a,
+	0x0a, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x75, 0x6c, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x63, 0x61, 0x62, 0x75, 0x6c, 0x61, 0x72,
+	0x79, 0x52, 0x0a, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x75, 0x6c, 0x61, 0x72, 0x79, 0x12, 0x3e, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x63, 0x61, 0x62, 0x75, 0x6c, 0x61, 0x72, 0x79, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x42, 0x1e, 0x5a,
+	0x1c, 0x2e, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x3b, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -420,28 +548,32 @@ func file_metrics_vocabulary_proto_rawDescGZIP() []byte {
 	return file_metrics_vocabulary_proto_rawDescData
 }
 
-var file_metrics_vocabulary_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_metrics_vocabulary_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_metrics_vocabulary_proto_goTypes = []interface{}{
-	(*WordCount)(nil),      // 0: gnostic.metrics.v1.WordCount
-	(*Vocabulary)(nil),     // 1: gnostic.metrics.v1.Vocabulary
-	(*VocabularyList)(nil), // 2: gnostic.metrics.v1.VocabularyList
-	(*Version)(nil),        // 3: gnostic.metrics.v1.Version
-	(*VersionHistory)(nil), // 4: gnostic.metrics.v1.VersionHistory
+	(*WordCount)(nil),        // 0: gnostic.metrics.v1.WordCount
+	(*Vocabulary)(nil),       // 1: gnostic.metrics.v1.Vocabulary
+	(*VocabularyList)(nil),   // 2: gnostic.metrics.v1.VocabularyList
+	(*Version)(nil),          // 3: gnostic.metrics.v1.Version
+	(*VersionHistory)(nil),   // 4: gnostic.metrics.v1.VersionHistory
+	(*VocabularySource)(nil), // 5: gnostic.metrics.v1.VocabularySource
+	(*VocabularyUnion)(nil),  // 6: gnostic.metrics.v1.VocabularyUnion
 }
 var file_metrics_vocabulary_proto_depIdxs = []int32{
-	0, // 0: gnostic.metrics.v1.Vocabulary.schemas:type_name -> gnostic.metrics.v1.WordCount
-	0, // 1: gnostic.metrics.v1.Vocabulary.properties:type_name -> gnostic.metrics.v1.WordCount
-	0, // 2: gnostic.metrics.v1.Vocabulary.operations:type_name -> gnostic.metrics.v1.WordCount
-	0, // 3: gnostic.metrics.v1.Vocabulary.parameters:type_name -> gnostic.metrics.v1.WordCount
-	1, // 4: gnostic.metrics.v1.VocabularyList.vocabularies:type_name -> gnostic.metrics.v1.Vocabulary
-	1, // 5: gnostic.metrics.v1.Version.new_terms:type_name -> gnostic.metrics.v1.Vocabulary
-	1, // 6: gnostic.metrics.v1.Version.deleted_terms:type_name -> gnostic.metrics.v1.Vocabulary
-	3, // 7: gnostic.metrics.v1.VersionHistory.versions:type_name -> gnostic.metrics.v1.Version
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: gnostic.metrics.v1.Vocabulary.schemas:type_name -> gnostic.metrics.v1.WordCount
+	0,  // 1: gnostic.metrics.v1.Vocabulary.properties:type_name -> gnostic.metrics.v1.WordCount
+	0,  // 2: gnostic.metrics.v1.Vocabulary.operations:type_name -> gnostic.metrics.v1.WordCount
+	0,  // 3: gnostic.metrics.v1.Vocabulary.parameters:type_name -> gnostic.metrics.v1.WordCount
+	1,  // 4: gnostic.metrics.v1.VocabularyList.vocabularies:type_name -> gnostic.metrics.v1.Vocabulary
+	1,  // 5: gnostic.metrics.v1.Version.new_terms:type_name -> gnostic.metrics.v1.Vocabulary
+	1,  // 6: gnostic.metrics.v1.Version.deleted_terms:type_name -> gnostic.metrics.v1.Vocabulary
+	3,  // 7: gnostic.metrics.v1.VersionHistory.versions:type_name -> gnostic.metrics.v1.Version
+	1,  // 8: gnostic.metrics.v1.VocabularyUnion.vocabulary:type_name -> gnostic.metrics.v1.Vocabulary
+	5,  // 9: gnostic.metrics.v1.VocabularyUnion.sources:type_name -> gnostic.metrics.v1.VocabularySource
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_metrics_vocabulary_proto_init() }
@@ -510,6 +642,30 @@ func file_metrics_vocabulary_proto_init() {
 				return nil
 			}
 		}
+		file_metrics_vocabulary_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VocabularySource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_vocabulary_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VocabularyUnion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -517,7 +673,7 @@ func file_metrics_vocabulary_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_metrics_vocabulary_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,128 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vocabulary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	metrics "github.com/google/gnostic/metrics"
+)
+
+// ReadUnion reads an accumulated union from a protocol buffer file.
+// If the file does not exist, an empty union is returned.
+func ReadUnion(filename string) (*metrics.VocabularyUnion, error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return &metrics.VocabularyUnion{Vocabulary: &metrics.Vocabulary{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	u := &metrics.VocabularyUnion{}
+	if err = proto.Unmarshal(data, u); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	if u.Vocabulary == nil {
+		u.Vocabulary = &metrics.Vocabulary{}
+	}
+	return u, nil
+}
+
+// WriteUnion writes an accumulated union to a protocol buffer file.
+// The file is replaced atomically so that an interrupted update
+// leaves the previous union in place.
+func WriteUnion(u *metrics.VocabularyUnion, filename string) error {
+	bytes, err := proto.Marshal(u)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// UpdateUnion adds the vocabularies in the named files to an accumulated
+// union and returns the number of files that were added. Files that are
+// already in the union are skipped. A file that has changed since it was
+// added is an error, because its previous counts can't be removed from
+// the union; the union must be rebuilt from all of its files instead.
+func UpdateUnion(u *metrics.VocabularyUnion, filenames []string) (int, error) {
+	digests := make(map[string]string)
+	for _, source := range u.Sources {
+		digests[source.Name] = source.Digest
+	}
+	var vocab Vocabulary
+	vocab.schemas = make(map[string]int)
+	vocab.operationID = make(map[string]int)
+	vocab.parameters = make(map[string]int)
+	vocab.properties = make(map[string]int)
+
+	sources := u.Sources
+	added := 0
+	for _, filename := range filenames {
+		name := filepath.Clean(filename)
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return 0, err
+		}
+		sum := sha256.Sum256(data)
+		digest := hex.EncodeToString(sum[:])
+		if previous, ok := digests[name]; ok {
+			if previous != digest {
+				return 0, fmt.Errorf("%s has changed since it was added to the union", name)
+			}
+			continue
+		}
+		v := &metrics.Vocabulary{}
+		if err = proto.Unmarshal(data, v); err != nil {
+			return 0, fmt.Errorf("%s: %v", name, err)
+		}
+		if added == 0 {
+			vocab.unpackageVocabulary(u.Vocabulary)
+		}
+		vocab.unpackageVocabulary(v)
+		digests[name] = digest
+		sources = append(sources, &metrics.VocabularySource{Name: name, Digest: digest})
+		added++
+	}
+	if added > 0 {
+		u.Sources = sources
+		u.Vocabulary = &metrics.Vocabulary{
+			Name:       u.Vocabulary.GetName(),
+			Properties: fillProtoStructure(vocab.properties),
+			Schemas:    fillProtoStructure(vocab.schemas),
+			Operations: fillProtoStructure(vocab.operationID),
+			Parameters: fillProtoStructure(vocab.parameters),
+		}
+	}
+	return added, nil
+}
@@ -38,15 +38,20 @@ type Vocabulary struct {
 
 // WriteCSV converts a Vocabulary pb file to a user-friendly readable CSV file.
 // The format of the CSV file is as follows: "group","word","frequency"
+// The filename "-" writes to standard output.
 func WriteCSV(v *metrics.Vocabulary, filename string) error {
 	if filename == "" {
 		filename = "vocabulary-operation.csv"
 	}
-	f4, ferror := os.Create(filename)
-	if ferror != nil {
-		return ferror
+	f4 := os.Stdout
+	if filename != "-" {
+		f, ferror := os.Create(filename)
+		if ferror != nil {
+			return ferror
+		}
+		defer f.Close()
+		f4 = f
 	}
-	defer f4.Close()
 
 	for _, s := range v.Schemas {
 		temp := fmt.Sprintf("%s,\"%s\",%d\n", "schemas", s.Word, int(s.Count))
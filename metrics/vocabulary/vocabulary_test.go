@@ -18,9 +18,11 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/protobuf/proto"
 
 	discovery "github.com/google/gnostic/discovery"
 	metrics "github.com/google/gnostic/metrics"
@@ -306,3 +308,82 @@ func TestSampleVocabularyDiscovery(t *testing.T) {
 		&reference,
 	)
 }
+
+func writeTestVocabulary(t *testing.T, filename string, v *metrics.Vocabulary) {
+	bytes, err := proto.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal failed: %+v", err)
+	}
+	if err = ioutil.WriteFile(filename, bytes, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %+v", err)
+	}
+}
+
+func TestIncrementalVocabularyUnion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vocabulary")
+	if err != nil {
+		t.Fatalf("TempDir failed: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v1 := &metrics.Vocabulary{
+		Schemas:    fillTestProtoStructure([]string{"heelo", "random"}, []int{1, 2}),
+		Operations: fillTestProtoStructure([]string{"print"}, []int{11}),
+	}
+	v2 := &metrics.Vocabulary{
+		Schemas:    fillTestProtoStructure([]string{"random", "status"}, []int{6, 1}),
+		Properties: fillTestProtoStructure([]string{"cat"}, []int{4}),
+	}
+	v3 := &metrics.Vocabulary{
+		Parameters: fillTestProtoStructure([]string{"id"}, []int{3}),
+		Operations: fillTestProtoStructure([]string{"print"}, []int{1}),
+	}
+	f1 := filepath.Join(dir, "v1.pb")
+	f2 := filepath.Join(dir, "v2.pb")
+	f3 := filepath.Join(dir, "v3.pb")
+	writeTestVocabulary(t, f1, v1)
+	writeTestVocabulary(t, f2, v2)
+	writeTestVocabulary(t, f3, v3)
+	state := filepath.Join(dir, "state.pb")
+
+	// The first update creates the union.
+	u, err := ReadUnion(state)
+	if err != nil {
+		t.Fatalf("ReadUnion failed: %+v", err)
+	}
+	added, err := UpdateUnion(u, []string{f1, f2})
+	if err != nil {
+		t.Fatalf("UpdateUnion failed: %+v", err)
+	}
+	if added != 2 {
+		t.Errorf("Added %d vocabularies, expected 2", added)
+	}
+	if err = WriteUnion(u, state); err != nil {
+		t.Fatalf("WriteUnion failed: %+v", err)
+	}
+
+	// The second update only adds the new vocabulary.
+	u, err = ReadUnion(state)
+	if err != nil {
+		t.Fatalf("ReadUnion failed: %+v", err)
+	}
+	added, err = UpdateUnion(u, []string{f1, f2, f3, f3})
+	if err != nil {
+		t.Fatalf("UpdateUnion failed: %+v", err)
+	}
+	if added != 1 {
+		t.Errorf("Added %d vocabularies, expected 1", added)
+	}
+	if len(u.Sources) != 3 {
+		t.Errorf("Union has %d sources, expected 3", len(u.Sources))
+	}
+	if reference := Union([]*metrics.Vocabulary{v1, v2, v3}); !proto.Equal(u.Vocabulary, reference) {
+		t.Errorf("Incremental union %v does not match %v", u.Vocabulary, reference)
+	}
+
+	// Changing a vocabulary that was already added is an error.
+	writeTestVocabulary(t, f2, v3)
+	if _, err = UpdateUnion(u, []string{f2}); err == nil {
+		t.Errorf("UpdateUnion succeeded with a changed vocabulary")
+	}
+}
@@ -64,6 +64,7 @@ func NewAdditionalPropertiesItem(in *yaml.Node, context *compiler.Context) (*Add
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -73,6 +74,7 @@ func NewAny(in *yaml.Node, context *compiler.Context) (*Any, error) {
 	x := &Any{}
 	bytes := compiler.Marshal(in)
 	x.Yaml = string(bytes)
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -149,12 +151,28 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 		// repeated NamedAny vendor_extension = 5;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -178,6 +196,7 @@ func NewApiKeySecurity(in *yaml.Node, context *compiler.Context) (*ApiKeySecurit
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -230,12 +249,28 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 		// repeated NamedAny vendor_extension = 3;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -259,6 +294,7 @@ func NewBasicAuthenticationSecurity(in *yaml.Node, context *compiler.Context) (*
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -338,12 +374,28 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 		// repeated NamedAny vendor_extension = 6;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -367,6 +419,7 @@ func NewBodyParameter(in *yaml.Node, context *compiler.Context) (*BodyParameter,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -416,12 +469,28 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// repeated NamedAny vendor_extension = 4;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -445,6 +514,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -460,11 +530,27 @@ func NewDefault(in *yaml.Node, context *compiler.Context) (*Default, error) {
 		// repeated NamedAny additional_properties = 1;
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedAny{}
+				var pair *NamedAny
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedAny{}
+				}
 				pair.Name = k
 				result := &Any{}
 				handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -487,6 +573,7 @@ func NewDefault(in *yaml.Node, context *compiler.Context) (*Default, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -502,11 +589,27 @@ func NewDefinitions(in *yaml.Node, context *compiler.Context) (*Definitions, err
 		// repeated NamedSchema additional_properties = 1;
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
+		var pairs1 []NamedSchema
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSchema, 0, n)
+			pairs1 = make([]NamedSchema, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSchema{}
+				var pair *NamedSchema
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSchema{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSchema(v, compiler.NewContext(k, v, context))
@@ -517,6 +620,7 @@ func NewDefinitions(in *yaml.Node, context *compiler.Context) (*Definitions, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -712,12 +816,28 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// repeated NamedAny vendor_extension = 16;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs16 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs16 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs16 != nil {
+						pair = &pairs16[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -741,6 +861,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -756,11 +877,27 @@ func NewExamples(in *yaml.Node, context *compiler.Context) (*Examples, error) {
 		// repeated NamedAny additional_properties = 1;
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedAny{}
+				var pair *NamedAny
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedAny{}
+				}
 				pair.Name = k
 				result := &Any{}
 				handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -783,6 +920,7 @@ func NewExamples(in *yaml.Node, context *compiler.Context) (*Examples, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -829,12 +967,28 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// repeated NamedAny vendor_extension = 3;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -858,6 +1012,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -975,12 +1130,28 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 		// repeated NamedAny vendor_extension = 10;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs10 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs10 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs10 != nil {
+						pair = &pairs10[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1004,6 +1175,7 @@ func NewFileSchema(in *yaml.Node, context *compiler.Context) (*FileSchema, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1263,12 +1435,28 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 		// repeated NamedAny vendor_extension = 23;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs23 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs23 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs23 != nil {
+						pair = &pairs23[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1292,6 +1480,7 @@ func NewFormDataParameterSubSchema(in *yaml.Node, context *compiler.Context) (*F
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1515,12 +1704,28 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// repeated NamedAny vendor_extension = 19;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs19 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs19 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs19 != nil {
+						pair = &pairs19[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1544,6 +1749,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1794,12 +2000,28 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 		// repeated NamedAny vendor_extension = 22;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs22 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs22 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs22 != nil {
+						pair = &pairs22[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1823,6 +2045,7 @@ func NewHeaderParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Hea
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1838,11 +2061,27 @@ func NewHeaders(in *yaml.Node, context *compiler.Context) (*Headers, error) {
 		// repeated NamedHeader additional_properties = 1;
 		// MAP: Header
 		x.AdditionalProperties = make([]*NamedHeader, 0)
+		var pairs1 []NamedHeader
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedHeader, 0, n)
+			pairs1 = make([]NamedHeader, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedHeader{}
+				var pair *NamedHeader
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedHeader{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewHeader(v, compiler.NewContext(k, v, context))
@@ -1853,6 +2092,7 @@ func NewHeaders(in *yaml.Node, context *compiler.Context) (*Headers, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1935,12 +2175,28 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// repeated NamedAny vendor_extension = 7;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs7 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs7 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs7 != nil {
+						pair = &pairs7[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1964,6 +2220,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1983,6 +2240,7 @@ func NewItemsItem(in *yaml.Node, context *compiler.Context) (*ItemsItem, error)
 		}
 		x.Schema = append(x.Schema, y)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2020,6 +2278,7 @@ func NewJsonReference(in *yaml.Node, context *compiler.Context) (*JsonReference,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2066,12 +2325,28 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// repeated NamedAny vendor_extension = 3;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2095,6 +2370,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2133,6 +2409,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2171,6 +2448,7 @@ func NewNamedHeader(in *yaml.Node, context *compiler.Context) (*NamedHeader, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2209,6 +2487,7 @@ func NewNamedParameter(in *yaml.Node, context *compiler.Context) (*NamedParamete
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2247,6 +2526,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2285,6 +2565,7 @@ func NewNamedResponse(in *yaml.Node, context *compiler.Context) (*NamedResponse,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2323,6 +2604,7 @@ func NewNamedResponseValue(in *yaml.Node, context *compiler.Context) (*NamedResp
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2361,6 +2643,7 @@ func NewNamedSchema(in *yaml.Node, context *compiler.Context) (*NamedSchema, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2399,6 +2682,7 @@ func NewNamedSecurityDefinitionsItem(in *yaml.Node, context *compiler.Context) (
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2437,6 +2721,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2475,6 +2760,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2547,6 +2833,7 @@ func NewNonBodyParameter(in *yaml.Node, context *compiler.Context) (*NonBodyPara
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2641,12 +2928,28 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 		// repeated NamedAny vendor_extension = 7;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs7 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs7 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs7 != nil {
+						pair = &pairs7[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2670,6 +2973,7 @@ func NewOauth2AccessCodeSecurity(in *yaml.Node, context *compiler.Context) (*Oau
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2755,12 +3059,28 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 		// repeated NamedAny vendor_extension = 6;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2784,6 +3104,7 @@ func NewOauth2ApplicationSecurity(in *yaml.Node, context *compiler.Context) (*Oa
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2869,12 +3190,28 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// repeated NamedAny vendor_extension = 6;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2898,6 +3235,7 @@ func NewOauth2ImplicitSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2983,12 +3321,28 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 		// repeated NamedAny vendor_extension = 6;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3012,6 +3366,7 @@ func NewOauth2PasswordSecurity(in *yaml.Node, context *compiler.Context) (*Oauth
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3027,17 +3382,34 @@ func NewOauth2Scopes(in *yaml.Node, context *compiler.Context) (*Oauth2Scopes, e
 		// repeated NamedString additional_properties = 1;
 		// MAP: string
 		x.AdditionalProperties = make([]*NamedString, 0)
+		var pairs1 []NamedString
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedString, 0, n)
+			pairs1 = make([]NamedString, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedString{}
+				var pair *NamedString
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedString{}
+				}
 				pair.Name = k
 				pair.Value, _ = compiler.StringForScalarNode(v)
 				x.AdditionalProperties = append(x.AdditionalProperties, pair)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3202,12 +3574,28 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// repeated NamedAny vendor_extension = 13;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs13 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs13 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs13 != nil {
+						pair = &pairs13[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3231,6 +3619,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3275,6 +3664,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3290,11 +3680,27 @@ func NewParameterDefinitions(in *yaml.Node, context *compiler.Context) (*Paramet
 		// repeated NamedParameter additional_properties = 1;
 		// MAP: Parameter
 		x.AdditionalProperties = make([]*NamedParameter, 0)
+		var pairs1 []NamedParameter
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedParameter, 0, n)
+			pairs1 = make([]NamedParameter, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedParameter{}
+				var pair *NamedParameter
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedParameter{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewParameter(v, compiler.NewContext(k, v, context))
@@ -3305,6 +3711,7 @@ func NewParameterDefinitions(in *yaml.Node, context *compiler.Context) (*Paramet
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3349,6 +3756,7 @@ func NewParametersItem(in *yaml.Node, context *compiler.Context) (*ParametersIte
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3459,12 +3867,28 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// repeated NamedAny vendor_extension = 10;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs10 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs10 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs10 != nil {
+						pair = &pairs10[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3488,6 +3912,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3744,12 +4169,28 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 		// repeated NamedAny vendor_extension = 22;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs22 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs22 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs22 != nil {
+						pair = &pairs22[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3773,6 +4214,7 @@ func NewPathParameterSubSchema(in *yaml.Node, context *compiler.Context) (*PathP
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3795,12 +4237,28 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// repeated NamedAny vendor_extension = 1;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs1 != nil {
+						pair = &pairs1[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3826,12 +4284,28 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// repeated NamedPathItem path = 2;
 		// MAP: PathItem ^/
 		x.Path = make([]*NamedPathItem, 0)
+		var pairs2 []NamedPathItem
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "/") {
+					n++
+				}
+			}
+			x.Path = make([]*NamedPathItem, 0, n)
+			pairs2 = make([]NamedPathItem, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "/") {
-					pair := &NamedPathItem{}
+					var pair *NamedPathItem
+					if pairs2 != nil {
+						pair = &pairs2[len(x.Path)]
+					} else {
+						pair = &NamedPathItem{}
+					}
 					pair.Name = k
 					var err error
 					pair.Value, err = NewPathItem(v, compiler.NewContext(k, v, context))
@@ -3843,6 +4317,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4051,12 +4526,28 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 		// repeated NamedAny vendor_extension = 18;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs18 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs18 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs18 != nil {
+						pair = &pairs18[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4080,6 +4571,7 @@ func NewPrimitivesItems(in *yaml.Node, context *compiler.Context) (*PrimitivesIt
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4095,11 +4587,27 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 		// repeated NamedSchema additional_properties = 1;
 		// MAP: Schema
 		x.AdditionalProperties = make([]*NamedSchema, 0)
+		var pairs1 []NamedSchema
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSchema, 0, n)
+			pairs1 = make([]NamedSchema, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSchema{}
+				var pair *NamedSchema
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSchema{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSchema(v, compiler.NewContext(k, v, context))
@@ -4110,6 +4618,7 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4369,12 +4878,28 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 		// repeated NamedAny vendor_extension = 23;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs23 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs23 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs23 != nil {
+						pair = &pairs23[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4398,6 +4923,7 @@ func NewQueryParameterSubSchema(in *yaml.Node, context *compiler.Context) (*Quer
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4462,12 +4988,28 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// repeated NamedAny vendor_extension = 5;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4491,6 +5033,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4506,11 +5049,27 @@ func NewResponseDefinitions(in *yaml.Node, context *compiler.Context) (*Response
 		// repeated NamedResponse additional_properties = 1;
 		// MAP: Response
 		x.AdditionalProperties = make([]*NamedResponse, 0)
+		var pairs1 []NamedResponse
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedResponse, 0, n)
+			pairs1 = make([]NamedResponse, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedResponse{}
+				var pair *NamedResponse
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedResponse{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewResponse(v, compiler.NewContext(k, v, context))
@@ -4521,6 +5080,7 @@ func NewResponseDefinitions(in *yaml.Node, context *compiler.Context) (*Response
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4565,6 +5125,7 @@ func NewResponseValue(in *yaml.Node, context *compiler.Context) (*ResponseValue,
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4587,12 +5148,28 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// repeated NamedResponseValue response_code = 1;
 		// MAP: ResponseValue ^([0-9]{3})$|^(default)$
 		x.ResponseCode = make([]*NamedResponseValue, 0)
+		var pairs1 []NamedResponseValue
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && pattern2.MatchString(k) {
+					n++
+				}
+			}
+			x.ResponseCode = make([]*NamedResponseValue, 0, n)
+			pairs1 = make([]NamedResponseValue, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if pattern2.MatchString(k) {
-					pair := &NamedResponseValue{}
+					var pair *NamedResponseValue
+					if pairs1 != nil {
+						pair = &pairs1[len(x.ResponseCode)]
+					} else {
+						pair = &NamedResponseValue{}
+					}
 					pair.Name = k
 					var err error
 					pair.Value, err = NewResponseValue(v, compiler.NewContext(k, v, context))
@@ -4606,12 +5183,28 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// repeated NamedAny vendor_extension = 2;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs2 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs2 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs2 != nil {
+						pair = &pairs2[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4635,6 +5228,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4961,12 +5555,28 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// repeated NamedAny vendor_extension = 31;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs31 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs31 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs31 != nil {
+						pair = &pairs31[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4990,6 +5600,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5034,6 +5645,7 @@ func NewSchemaItem(in *yaml.Node, context *compiler.Context) (*SchemaItem, error
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5049,11 +5661,27 @@ func NewSecurityDefinitions(in *yaml.Node, context *compiler.Context) (*Security
 		// repeated NamedSecurityDefinitionsItem additional_properties = 1;
 		// MAP: SecurityDefinitionsItem
 		x.AdditionalProperties = make([]*NamedSecurityDefinitionsItem, 0)
+		var pairs1 []NamedSecurityDefinitionsItem
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSecurityDefinitionsItem, 0, n)
+			pairs1 = make([]NamedSecurityDefinitionsItem, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSecurityDefinitionsItem{}
+				var pair *NamedSecurityDefinitionsItem
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSecurityDefinitionsItem{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSecurityDefinitionsItem(v, compiler.NewContext(k, v, context))
@@ -5064,6 +5692,7 @@ func NewSecurityDefinitions(in *yaml.Node, context *compiler.Context) (*Security
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5164,6 +5793,7 @@ func NewSecurityDefinitionsItem(in *yaml.Node, context *compiler.Context) (*Secu
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5179,11 +5809,27 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 		// repeated NamedStringArray additional_properties = 1;
 		// MAP: StringArray
 		x.AdditionalProperties = make([]*NamedStringArray, 0)
+		var pairs1 []NamedStringArray
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedStringArray, 0, n)
+			pairs1 = make([]NamedStringArray, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedStringArray{}
+				var pair *NamedStringArray
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedStringArray{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewStringArray(v, compiler.NewContext(k, v, context))
@@ -5194,6 +5840,7 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5206,6 +5853,7 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 		s, _ := compiler.StringForScalarNode(node)
 		x.Value = append(x.Value, s)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5261,12 +5909,28 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// repeated NamedAny vendor_extension = 4;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -5290,6 +5954,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5318,6 +5983,7 @@ func NewTypeItem(in *yaml.Node, context *compiler.Context) (*TypeItem, error) {
 		message := fmt.Sprintf("has unexpected value for string array: %+v (%T)", in, in)
 		errors = append(errors, compiler.NewError(context, message))
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5333,11 +5999,27 @@ func NewVendorExtension(in *yaml.Node, context *compiler.Context) (*VendorExtens
 		// repeated NamedAny additional_properties = 1;
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedAny{}
+				var pair *NamedAny
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedAny{}
+				}
 				pair.Name = k
 				result := &Any{}
 				handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -5360,6 +6042,7 @@ func NewVendorExtension(in *yaml.Node, context *compiler.Context) (*VendorExtens
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -5427,12 +6110,28 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// repeated NamedAny vendor_extension = 6;
 		// MAP: Any ^x-
 		x.VendorExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.VendorExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.VendorExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -5456,9 +6155,230 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
+// NamedAnyMap returns a map from the names of NamedAny pairs to their values.
+// When names are repeated, the first value is used.
+func NamedAnyMap(pairs []*NamedAny) map[string]*Any {
+	m := make(map[string]*Any, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedAny returns the value of the first NamedAny pair with a name.
+func FindNamedAny(pairs []*NamedAny, name string) *Any {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedHeaderMap returns a map from the names of NamedHeader pairs to their values.
+// When names are repeated, the first value is used.
+func NamedHeaderMap(pairs []*NamedHeader) map[string]*Header {
+	m := make(map[string]*Header, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedHeader returns the value of the first NamedHeader pair with a name.
+func FindNamedHeader(pairs []*NamedHeader, name string) *Header {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedParameterMap returns a map from the names of NamedParameter pairs to their values.
+// When names are repeated, the first value is used.
+func NamedParameterMap(pairs []*NamedParameter) map[string]*Parameter {
+	m := make(map[string]*Parameter, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedParameter returns the value of the first NamedParameter pair with a name.
+func FindNamedParameter(pairs []*NamedParameter, name string) *Parameter {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedPathItemMap returns a map from the names of NamedPathItem pairs to their values.
+// When names are repeated, the first value is used.
+func NamedPathItemMap(pairs []*NamedPathItem) map[string]*PathItem {
+	m := make(map[string]*PathItem, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedPathItem returns the value of the first NamedPathItem pair with a name.
+func FindNamedPathItem(pairs []*NamedPathItem, name string) *PathItem {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedResponseMap returns a map from the names of NamedResponse pairs to their values.
+// When names are repeated, the first value is used.
+func NamedResponseMap(pairs []*NamedResponse) map[string]*Response {
+	m := make(map[string]*Response, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedResponse returns the value of the first NamedResponse pair with a name.
+func FindNamedResponse(pairs []*NamedResponse, name string) *Response {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedResponseValueMap returns a map from the names of NamedResponseValue pairs to their values.
+// When names are repeated, the first value is used.
+func NamedResponseValueMap(pairs []*NamedResponseValue) map[string]*ResponseValue {
+	m := make(map[string]*ResponseValue, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedResponseValue returns the value of the first NamedResponseValue pair with a name.
+func FindNamedResponseValue(pairs []*NamedResponseValue, name string) *ResponseValue {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedSchemaMap returns a map from the names of NamedSchema pairs to their values.
+// When names are repeated, the first value is used.
+func NamedSchemaMap(pairs []*NamedSchema) map[string]*Schema {
+	m := make(map[string]*Schema, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedSchema returns the value of the first NamedSchema pair with a name.
+func FindNamedSchema(pairs []*NamedSchema, name string) *Schema {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedSecurityDefinitionsItemMap returns a map from the names of NamedSecurityDefinitionsItem pairs to their values.
+// When names are repeated, the first value is used.
+func NamedSecurityDefinitionsItemMap(pairs []*NamedSecurityDefinitionsItem) map[string]*SecurityDefinitionsItem {
+	m := make(map[string]*SecurityDefinitionsItem, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedSecurityDefinitionsItem returns the value of the first NamedSecurityDefinitionsItem pair with a name.
+func FindNamedSecurityDefinitionsItem(pairs []*NamedSecurityDefinitionsItem, name string) *SecurityDefinitionsItem {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedStringMap returns a map from the names of NamedString pairs to their values.
+// When names are repeated, the first value is used.
+func NamedStringMap(pairs []*NamedString) map[string]string {
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedString returns the value of the first NamedString pair with a name.
+func FindNamedString(pairs []*NamedString, name string) string {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
+
+// NamedStringArrayMap returns a map from the names of NamedStringArray pairs to their values.
+// When names are repeated, the first value is used.
+func NamedStringArrayMap(pairs []*NamedStringArray) map[string]*StringArray {
+	m := make(map[string]*StringArray, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedStringArray returns the value of the first NamedStringArray pair with a name.
+func FindNamedStringArray(pairs []*NamedStringArray, name string) *StringArray {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
 var (
 	pattern0 = regexp.MustCompile("^x-")
 	pattern1 = regexp.MustCompile("^/")
@@ -0,0 +1,1023 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package openapi_v2
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// DeepCopyAdditionalPropertiesItem returns a copy of x that shares no values with it.
+func DeepCopyAdditionalPropertiesItem(x *AdditionalPropertiesItem) *AdditionalPropertiesItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*AdditionalPropertiesItem)
+}
+
+// EqualAdditionalPropertiesItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualAdditionalPropertiesItem(a, b *AdditionalPropertiesItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeAdditionalPropertiesItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeAdditionalPropertiesItem(dst, src *AdditionalPropertiesItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyAny returns a copy of x that shares no values with it.
+func DeepCopyAny(x *Any) *Any {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Any)
+}
+
+// EqualAny reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualAny(a, b *Any) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeAny merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeAny(dst, src *Any) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyApiKeySecurity returns a copy of x that shares no values with it.
+func DeepCopyApiKeySecurity(x *ApiKeySecurity) *ApiKeySecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ApiKeySecurity)
+}
+
+// EqualApiKeySecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualApiKeySecurity(a, b *ApiKeySecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeApiKeySecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeApiKeySecurity(dst, src *ApiKeySecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyBasicAuthenticationSecurity returns a copy of x that shares no values with it.
+func DeepCopyBasicAuthenticationSecurity(x *BasicAuthenticationSecurity) *BasicAuthenticationSecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*BasicAuthenticationSecurity)
+}
+
+// EqualBasicAuthenticationSecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualBasicAuthenticationSecurity(a, b *BasicAuthenticationSecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeBasicAuthenticationSecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeBasicAuthenticationSecurity(dst, src *BasicAuthenticationSecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyBodyParameter returns a copy of x that shares no values with it.
+func DeepCopyBodyParameter(x *BodyParameter) *BodyParameter {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*BodyParameter)
+}
+
+// EqualBodyParameter reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualBodyParameter(a, b *BodyParameter) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeBodyParameter merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeBodyParameter(dst, src *BodyParameter) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyContact returns a copy of x that shares no values with it.
+func DeepCopyContact(x *Contact) *Contact {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Contact)
+}
+
+// EqualContact reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualContact(a, b *Contact) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeContact merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeContact(dst, src *Contact) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDefault returns a copy of x that shares no values with it.
+func DeepCopyDefault(x *Default) *Default {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Default)
+}
+
+// EqualDefault reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDefault(a, b *Default) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDefault merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDefault(dst, src *Default) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDefinitions returns a copy of x that shares no values with it.
+func DeepCopyDefinitions(x *Definitions) *Definitions {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Definitions)
+}
+
+// EqualDefinitions reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDefinitions(a, b *Definitions) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDefinitions merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDefinitions(dst, src *Definitions) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDocument returns a copy of x that shares no values with it.
+func DeepCopyDocument(x *Document) *Document {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Document)
+}
+
+// EqualDocument reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDocument(a, b *Document) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDocument merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDocument(dst, src *Document) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExamples returns a copy of x that shares no values with it.
+func DeepCopyExamples(x *Examples) *Examples {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Examples)
+}
+
+// EqualExamples reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExamples(a, b *Examples) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExamples merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExamples(dst, src *Examples) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExternalDocs returns a copy of x that shares no values with it.
+func DeepCopyExternalDocs(x *ExternalDocs) *ExternalDocs {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ExternalDocs)
+}
+
+// EqualExternalDocs reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExternalDocs(a, b *ExternalDocs) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExternalDocs merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExternalDocs(dst, src *ExternalDocs) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyFileSchema returns a copy of x that shares no values with it.
+func DeepCopyFileSchema(x *FileSchema) *FileSchema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*FileSchema)
+}
+
+// EqualFileSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualFileSchema(a, b *FileSchema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeFileSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeFileSchema(dst, src *FileSchema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyFormDataParameterSubSchema returns a copy of x that shares no values with it.
+func DeepCopyFormDataParameterSubSchema(x *FormDataParameterSubSchema) *FormDataParameterSubSchema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*FormDataParameterSubSchema)
+}
+
+// EqualFormDataParameterSubSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualFormDataParameterSubSchema(a, b *FormDataParameterSubSchema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeFormDataParameterSubSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeFormDataParameterSubSchema(dst, src *FormDataParameterSubSchema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeader returns a copy of x that shares no values with it.
+func DeepCopyHeader(x *Header) *Header {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Header)
+}
+
+// EqualHeader reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeader(a, b *Header) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeader merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeader(dst, src *Header) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeaderParameterSubSchema returns a copy of x that shares no values with it.
+func DeepCopyHeaderParameterSubSchema(x *HeaderParameterSubSchema) *HeaderParameterSubSchema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*HeaderParameterSubSchema)
+}
+
+// EqualHeaderParameterSubSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeaderParameterSubSchema(a, b *HeaderParameterSubSchema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeaderParameterSubSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeaderParameterSubSchema(dst, src *HeaderParameterSubSchema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeaders returns a copy of x that shares no values with it.
+func DeepCopyHeaders(x *Headers) *Headers {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Headers)
+}
+
+// EqualHeaders reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeaders(a, b *Headers) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeaders merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeaders(dst, src *Headers) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyInfo returns a copy of x that shares no values with it.
+func DeepCopyInfo(x *Info) *Info {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Info)
+}
+
+// EqualInfo reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualInfo(a, b *Info) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeInfo merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeInfo(dst, src *Info) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyItemsItem returns a copy of x that shares no values with it.
+func DeepCopyItemsItem(x *ItemsItem) *ItemsItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ItemsItem)
+}
+
+// EqualItemsItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualItemsItem(a, b *ItemsItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeItemsItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeItemsItem(dst, src *ItemsItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyJsonReference returns a copy of x that shares no values with it.
+func DeepCopyJsonReference(x *JsonReference) *JsonReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*JsonReference)
+}
+
+// EqualJsonReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualJsonReference(a, b *JsonReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeJsonReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeJsonReference(dst, src *JsonReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyLicense returns a copy of x that shares no values with it.
+func DeepCopyLicense(x *License) *License {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*License)
+}
+
+// EqualLicense reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualLicense(a, b *License) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeLicense merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeLicense(dst, src *License) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyNonBodyParameter returns a copy of x that shares no values with it.
+func DeepCopyNonBodyParameter(x *NonBodyParameter) *NonBodyParameter {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*NonBodyParameter)
+}
+
+// EqualNonBodyParameter reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualNonBodyParameter(a, b *NonBodyParameter) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeNonBodyParameter merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeNonBodyParameter(dst, src *NonBodyParameter) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauth2AccessCodeSecurity returns a copy of x that shares no values with it.
+func DeepCopyOauth2AccessCodeSecurity(x *Oauth2AccessCodeSecurity) *Oauth2AccessCodeSecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Oauth2AccessCodeSecurity)
+}
+
+// EqualOauth2AccessCodeSecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauth2AccessCodeSecurity(a, b *Oauth2AccessCodeSecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauth2AccessCodeSecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauth2AccessCodeSecurity(dst, src *Oauth2AccessCodeSecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauth2ApplicationSecurity returns a copy of x that shares no values with it.
+func DeepCopyOauth2ApplicationSecurity(x *Oauth2ApplicationSecurity) *Oauth2ApplicationSecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Oauth2ApplicationSecurity)
+}
+
+// EqualOauth2ApplicationSecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauth2ApplicationSecurity(a, b *Oauth2ApplicationSecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauth2ApplicationSecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauth2ApplicationSecurity(dst, src *Oauth2ApplicationSecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauth2ImplicitSecurity returns a copy of x that shares no values with it.
+func DeepCopyOauth2ImplicitSecurity(x *Oauth2ImplicitSecurity) *Oauth2ImplicitSecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Oauth2ImplicitSecurity)
+}
+
+// EqualOauth2ImplicitSecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauth2ImplicitSecurity(a, b *Oauth2ImplicitSecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauth2ImplicitSecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauth2ImplicitSecurity(dst, src *Oauth2ImplicitSecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauth2PasswordSecurity returns a copy of x that shares no values with it.
+func DeepCopyOauth2PasswordSecurity(x *Oauth2PasswordSecurity) *Oauth2PasswordSecurity {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Oauth2PasswordSecurity)
+}
+
+// EqualOauth2PasswordSecurity reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauth2PasswordSecurity(a, b *Oauth2PasswordSecurity) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauth2PasswordSecurity merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauth2PasswordSecurity(dst, src *Oauth2PasswordSecurity) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauth2Scopes returns a copy of x that shares no values with it.
+func DeepCopyOauth2Scopes(x *Oauth2Scopes) *Oauth2Scopes {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Oauth2Scopes)
+}
+
+// EqualOauth2Scopes reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauth2Scopes(a, b *Oauth2Scopes) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauth2Scopes merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauth2Scopes(dst, src *Oauth2Scopes) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOperation returns a copy of x that shares no values with it.
+func DeepCopyOperation(x *Operation) *Operation {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Operation)
+}
+
+// EqualOperation reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOperation(a, b *Operation) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOperation merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOperation(dst, src *Operation) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParameter returns a copy of x that shares no values with it.
+func DeepCopyParameter(x *Parameter) *Parameter {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Parameter)
+}
+
+// EqualParameter reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParameter(a, b *Parameter) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParameter merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParameter(dst, src *Parameter) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParameterDefinitions returns a copy of x that shares no values with it.
+func DeepCopyParameterDefinitions(x *ParameterDefinitions) *ParameterDefinitions {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ParameterDefinitions)
+}
+
+// EqualParameterDefinitions reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParameterDefinitions(a, b *ParameterDefinitions) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParameterDefinitions merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParameterDefinitions(dst, src *ParameterDefinitions) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParametersItem returns a copy of x that shares no values with it.
+func DeepCopyParametersItem(x *ParametersItem) *ParametersItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ParametersItem)
+}
+
+// EqualParametersItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParametersItem(a, b *ParametersItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParametersItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParametersItem(dst, src *ParametersItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPathItem returns a copy of x that shares no values with it.
+func DeepCopyPathItem(x *PathItem) *PathItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*PathItem)
+}
+
+// EqualPathItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPathItem(a, b *PathItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePathItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePathItem(dst, src *PathItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPathParameterSubSchema returns a copy of x that shares no values with it.
+func DeepCopyPathParameterSubSchema(x *PathParameterSubSchema) *PathParameterSubSchema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*PathParameterSubSchema)
+}
+
+// EqualPathParameterSubSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPathParameterSubSchema(a, b *PathParameterSubSchema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePathParameterSubSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePathParameterSubSchema(dst, src *PathParameterSubSchema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPaths returns a copy of x that shares no values with it.
+func DeepCopyPaths(x *Paths) *Paths {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Paths)
+}
+
+// EqualPaths reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPaths(a, b *Paths) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePaths merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePaths(dst, src *Paths) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPrimitivesItems returns a copy of x that shares no values with it.
+func DeepCopyPrimitivesItems(x *PrimitivesItems) *PrimitivesItems {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*PrimitivesItems)
+}
+
+// EqualPrimitivesItems reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPrimitivesItems(a, b *PrimitivesItems) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePrimitivesItems merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePrimitivesItems(dst, src *PrimitivesItems) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyProperties returns a copy of x that shares no values with it.
+func DeepCopyProperties(x *Properties) *Properties {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Properties)
+}
+
+// EqualProperties reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualProperties(a, b *Properties) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeProperties merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeProperties(dst, src *Properties) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyQueryParameterSubSchema returns a copy of x that shares no values with it.
+func DeepCopyQueryParameterSubSchema(x *QueryParameterSubSchema) *QueryParameterSubSchema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*QueryParameterSubSchema)
+}
+
+// EqualQueryParameterSubSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualQueryParameterSubSchema(a, b *QueryParameterSubSchema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeQueryParameterSubSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeQueryParameterSubSchema(dst, src *QueryParameterSubSchema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponse returns a copy of x that shares no values with it.
+func DeepCopyResponse(x *Response) *Response {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Response)
+}
+
+// EqualResponse reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponse(a, b *Response) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponse merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponse(dst, src *Response) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponseDefinitions returns a copy of x that shares no values with it.
+func DeepCopyResponseDefinitions(x *ResponseDefinitions) *ResponseDefinitions {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ResponseDefinitions)
+}
+
+// EqualResponseDefinitions reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponseDefinitions(a, b *ResponseDefinitions) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponseDefinitions merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponseDefinitions(dst, src *ResponseDefinitions) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponseValue returns a copy of x that shares no values with it.
+func DeepCopyResponseValue(x *ResponseValue) *ResponseValue {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ResponseValue)
+}
+
+// EqualResponseValue reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponseValue(a, b *ResponseValue) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponseValue merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponseValue(dst, src *ResponseValue) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponses returns a copy of x that shares no values with it.
+func DeepCopyResponses(x *Responses) *Responses {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Responses)
+}
+
+// EqualResponses reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponses(a, b *Responses) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponses merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponses(dst, src *Responses) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySchema returns a copy of x that shares no values with it.
+func DeepCopySchema(x *Schema) *Schema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Schema)
+}
+
+// EqualSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSchema(a, b *Schema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSchema(dst, src *Schema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySchemaItem returns a copy of x that shares no values with it.
+func DeepCopySchemaItem(x *SchemaItem) *SchemaItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SchemaItem)
+}
+
+// EqualSchemaItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSchemaItem(a, b *SchemaItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSchemaItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSchemaItem(dst, src *SchemaItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecurityDefinitions returns a copy of x that shares no values with it.
+func DeepCopySecurityDefinitions(x *SecurityDefinitions) *SecurityDefinitions {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecurityDefinitions)
+}
+
+// EqualSecurityDefinitions reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecurityDefinitions(a, b *SecurityDefinitions) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecurityDefinitions merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecurityDefinitions(dst, src *SecurityDefinitions) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecurityDefinitionsItem returns a copy of x that shares no values with it.
+func DeepCopySecurityDefinitionsItem(x *SecurityDefinitionsItem) *SecurityDefinitionsItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecurityDefinitionsItem)
+}
+
+// EqualSecurityDefinitionsItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecurityDefinitionsItem(a, b *SecurityDefinitionsItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecurityDefinitionsItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecurityDefinitionsItem(dst, src *SecurityDefinitionsItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecurityRequirement returns a copy of x that shares no values with it.
+func DeepCopySecurityRequirement(x *SecurityRequirement) *SecurityRequirement {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecurityRequirement)
+}
+
+// EqualSecurityRequirement reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecurityRequirement(a, b *SecurityRequirement) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecurityRequirement merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecurityRequirement(dst, src *SecurityRequirement) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyStringArray returns a copy of x that shares no values with it.
+func DeepCopyStringArray(x *StringArray) *StringArray {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*StringArray)
+}
+
+// EqualStringArray reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualStringArray(a, b *StringArray) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeStringArray merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeStringArray(dst, src *StringArray) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyTag returns a copy of x that shares no values with it.
+func DeepCopyTag(x *Tag) *Tag {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Tag)
+}
+
+// EqualTag reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualTag(a, b *Tag) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeTag merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeTag(dst, src *Tag) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyTypeItem returns a copy of x that shares no values with it.
+func DeepCopyTypeItem(x *TypeItem) *TypeItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*TypeItem)
+}
+
+// EqualTypeItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualTypeItem(a, b *TypeItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeTypeItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeTypeItem(dst, src *TypeItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyVendorExtension returns a copy of x that shares no values with it.
+func DeepCopyVendorExtension(x *VendorExtension) *VendorExtension {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*VendorExtension)
+}
+
+// EqualVendorExtension reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualVendorExtension(a, b *VendorExtension) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeVendorExtension merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeVendorExtension(dst, src *VendorExtension) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyXml returns a copy of x that shares no values with it.
+func DeepCopyXml(x *Xml) *Xml {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Xml)
+}
+
+// EqualXml reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualXml(a, b *Xml) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeXml merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeXml(dst, src *Xml) {
+	compiler.MergeModels(dst, src)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v2
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtensions(t *testing.T) {
+	document, err := ParseDocument([]byte(`
+swagger: "2.0"
+info:
+  title: Extensions
+  version: 1.0.0
+  x-audience: public
+paths: {}
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	info := document.Info
+	var audience string
+	if ok, err := DecodeExtension(info, "x-audience", &audience); !ok || err != nil || audience != "public" {
+		t.Errorf("unexpected x-audience %q (%t, %v)", audience, ok, err)
+	}
+	if ok, _ := DecodeExtension(info, "x-missing", &audience); ok {
+		t.Errorf("unexpected x-missing extension")
+	}
+
+	if err := SetExtension(info, "x-audience", "internal"); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := SetExtension(info, "x-owners", []string{"a", "b"}); err != nil {
+		t.Fatalf("%s", err)
+	}
+	var owners []string
+	if ok, err := DecodeExtension(info, "x-owners", &owners); !ok || err != nil || !reflect.DeepEqual(owners, []string{"a", "b"}) {
+		t.Errorf("unexpected x-owners %v (%t, %v)", owners, ok, err)
+	}
+	bytes, err := document.YAMLValue("")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !strings.Contains(string(bytes), "x-audience: internal\n") || !strings.Contains(string(bytes), "x-owners:\n") {
+		t.Errorf("extensions are missing from the document:\n%s", bytes)
+	}
+
+	if !DeleteExtension(info, "x-audience") || DeleteExtension(info, "x-audience") {
+		t.Errorf("unexpected result of deleting x-audience")
+	}
+	if len(info.VendorExtension) != 1 || GetExtension(info, "x-owners") == nil {
+		t.Errorf("unexpected extensions after deleting x-audience: %v", info.VendorExtension)
+	}
+
+	if err := SetExtension(info, "audience", "public"); err == nil {
+		t.Errorf("expected an error for an extension name without x- prefix")
+	}
+	if err := SetExtension(&Any{}, "x-audience", "public"); err == nil {
+		t.Errorf("expected an error for an object without extensions")
+	}
+}
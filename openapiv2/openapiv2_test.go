@@ -36,3 +36,25 @@ func TestParseDocument(t *testing.T) {
 		t.Errorf("unexpected value for Title: %s (expected %s)", d.Info.Title, title)
 	}
 }
+
+func TestHelpers(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ParseDocument(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := DeepCopyDocument(d)
+	definitions := c.Definitions.AdditionalProperties
+	definitions[0], definitions[1] = definitions[1], definitions[0]
+	if !EqualDocument(c, d) {
+		t.Errorf("expected documents with reordered definitions to be equal")
+	}
+	// Merging a document into itself changes nothing.
+	MergeDocument(c, d)
+	if len(c.Definitions.AdditionalProperties) != len(d.Definitions.AdditionalProperties) || !EqualDocument(c, d) {
+		t.Errorf("expected a document merged with itself to be unchanged")
+	}
+}
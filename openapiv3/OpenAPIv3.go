@@ -64,6 +64,7 @@ func NewAdditionalPropertiesItem(in *yaml.Node, context *compiler.Context) (*Add
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -73,6 +74,7 @@ func NewAny(in *yaml.Node, context *compiler.Context) (*Any, error) {
 	x := &Any{}
 	bytes := compiler.Marshal(in)
 	x.Yaml = string(bytes)
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -117,6 +119,7 @@ func NewAnyOrExpression(in *yaml.Node, context *compiler.Context) (*AnyOrExpress
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -139,12 +142,28 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 		// repeated NamedPathItem path = 1;
 		// MAP: PathItem ^
 		x.Path = make([]*NamedPathItem, 0)
+		var pairs1 []NamedPathItem
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.Path = make([]*NamedPathItem, 0, n)
+			pairs1 = make([]NamedPathItem, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if true {
-					pair := &NamedPathItem{}
+					var pair *NamedPathItem
+					if pairs1 != nil {
+						pair = &pairs1[len(x.Path)]
+					} else {
+						pair = &NamedPathItem{}
+					}
 					pair.Name = k
 					var err error
 					pair.Value, err = NewPathItem(v, compiler.NewContext(k, v, context))
@@ -158,12 +177,28 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 		// repeated NamedAny specification_extension = 2;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs2 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs2 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs2 != nil {
+						pair = &pairs2[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -187,6 +222,7 @@ func NewCallback(in *yaml.Node, context *compiler.Context) (*Callback, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -231,6 +267,7 @@ func NewCallbackOrReference(in *yaml.Node, context *compiler.Context) (*Callback
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -246,11 +283,27 @@ func NewCallbacksOrReferences(in *yaml.Node, context *compiler.Context) (*Callba
 		// repeated NamedCallbackOrReference additional_properties = 1;
 		// MAP: CallbackOrReference
 		x.AdditionalProperties = make([]*NamedCallbackOrReference, 0)
+		var pairs1 []NamedCallbackOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedCallbackOrReference, 0, n)
+			pairs1 = make([]NamedCallbackOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedCallbackOrReference{}
+				var pair *NamedCallbackOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedCallbackOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewCallbackOrReference(v, compiler.NewContext(k, v, context))
@@ -261,6 +314,7 @@ func NewCallbacksOrReferences(in *yaml.Node, context *compiler.Context) (*Callba
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -364,12 +418,28 @@ func NewComponents(in *yaml.Node, context *compiler.Context) (*Components, error
 		// repeated NamedAny specification_extension = 10;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs10 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs10 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs10 != nil {
+						pair = &pairs10[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -393,6 +463,7 @@ func NewComponents(in *yaml.Node, context *compiler.Context) (*Components, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -442,12 +513,28 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 		// repeated NamedAny specification_extension = 4;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -471,6 +558,7 @@ func NewContact(in *yaml.Node, context *compiler.Context) (*Contact, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -501,6 +589,7 @@ func NewDefaultType(in *yaml.Node, context *compiler.Context) (*DefaultType, err
 		// since the oneof matched one of its possibilities, discard any matching errors
 		errors = make([]error, 0)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -547,12 +636,28 @@ func NewDiscriminator(in *yaml.Node, context *compiler.Context) (*Discriminator,
 		// repeated NamedAny specification_extension = 3;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -576,6 +681,7 @@ func NewDiscriminator(in *yaml.Node, context *compiler.Context) (*Discriminator,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -697,12 +803,28 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 		// repeated NamedAny specification_extension = 9;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs9 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs9 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs9 != nil {
+						pair = &pairs9[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -726,6 +848,7 @@ func NewDocument(in *yaml.Node, context *compiler.Context) (*Document, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -793,12 +916,28 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 		// repeated NamedAny specification_extension = 6;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -822,6 +961,7 @@ func NewEncoding(in *yaml.Node, context *compiler.Context) (*Encoding, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -837,11 +977,27 @@ func NewEncodings(in *yaml.Node, context *compiler.Context) (*Encodings, error)
 		// repeated NamedEncoding additional_properties = 1;
 		// MAP: Encoding
 		x.AdditionalProperties = make([]*NamedEncoding, 0)
+		var pairs1 []NamedEncoding
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedEncoding, 0, n)
+			pairs1 = make([]NamedEncoding, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedEncoding{}
+				var pair *NamedEncoding
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedEncoding{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewEncoding(v, compiler.NewContext(k, v, context))
@@ -852,6 +1008,7 @@ func NewEncodings(in *yaml.Node, context *compiler.Context) (*Encodings, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -910,12 +1067,28 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 		// repeated NamedAny specification_extension = 5;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -939,6 +1112,7 @@ func NewExample(in *yaml.Node, context *compiler.Context) (*Example, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -983,6 +1157,7 @@ func NewExampleOrReference(in *yaml.Node, context *compiler.Context) (*ExampleOr
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -998,11 +1173,27 @@ func NewExamplesOrReferences(in *yaml.Node, context *compiler.Context) (*Example
 		// repeated NamedExampleOrReference additional_properties = 1;
 		// MAP: ExampleOrReference
 		x.AdditionalProperties = make([]*NamedExampleOrReference, 0)
+		var pairs1 []NamedExampleOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedExampleOrReference, 0, n)
+			pairs1 = make([]NamedExampleOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedExampleOrReference{}
+				var pair *NamedExampleOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedExampleOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewExampleOrReference(v, compiler.NewContext(k, v, context))
@@ -1013,6 +1204,7 @@ func NewExamplesOrReferences(in *yaml.Node, context *compiler.Context) (*Example
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1028,11 +1220,27 @@ func NewExpression(in *yaml.Node, context *compiler.Context) (*Expression, error
 		// repeated NamedAny additional_properties = 1;
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedAny{}
+				var pair *NamedAny
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedAny{}
+				}
 				pair.Name = k
 				result := &Any{}
 				handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1055,6 +1263,7 @@ func NewExpression(in *yaml.Node, context *compiler.Context) (*Expression, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1101,12 +1310,28 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 		// repeated NamedAny specification_extension = 3;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1130,6 +1355,7 @@ func NewExternalDocs(in *yaml.Node, context *compiler.Context) (*ExternalDocs, e
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1251,12 +1477,28 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 		// repeated NamedAny specification_extension = 12;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs12 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs12 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs12 != nil {
+						pair = &pairs12[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1280,6 +1522,7 @@ func NewHeader(in *yaml.Node, context *compiler.Context) (*Header, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1324,6 +1567,7 @@ func NewHeaderOrReference(in *yaml.Node, context *compiler.Context) (*HeaderOrRe
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1339,11 +1583,27 @@ func NewHeadersOrReferences(in *yaml.Node, context *compiler.Context) (*HeadersO
 		// repeated NamedHeaderOrReference additional_properties = 1;
 		// MAP: HeaderOrReference
 		x.AdditionalProperties = make([]*NamedHeaderOrReference, 0)
+		var pairs1 []NamedHeaderOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedHeaderOrReference, 0, n)
+			pairs1 = make([]NamedHeaderOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedHeaderOrReference{}
+				var pair *NamedHeaderOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedHeaderOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewHeaderOrReference(v, compiler.NewContext(k, v, context))
@@ -1354,6 +1614,7 @@ func NewHeadersOrReferences(in *yaml.Node, context *compiler.Context) (*HeadersO
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1445,12 +1706,28 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 		// repeated NamedAny specification_extension = 8;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs8 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs8 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs8 != nil {
+						pair = &pairs8[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1474,6 +1751,7 @@ func NewInfo(in *yaml.Node, context *compiler.Context) (*Info, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1493,6 +1771,7 @@ func NewItemsItem(in *yaml.Node, context *compiler.Context) (*ItemsItem, error)
 		}
 		x.SchemaOrReference = append(x.SchemaOrReference, y)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1539,12 +1818,28 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 		// repeated NamedAny specification_extension = 3;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1568,6 +1863,7 @@ func NewLicense(in *yaml.Node, context *compiler.Context) (*License, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1644,12 +1940,28 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 		// repeated NamedAny specification_extension = 7;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs7 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs7 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs7 != nil {
+						pair = &pairs7[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1673,6 +1985,7 @@ func NewLink(in *yaml.Node, context *compiler.Context) (*Link, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1717,6 +2030,7 @@ func NewLinkOrReference(in *yaml.Node, context *compiler.Context) (*LinkOrRefere
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1732,11 +2046,27 @@ func NewLinksOrReferences(in *yaml.Node, context *compiler.Context) (*LinksOrRef
 		// repeated NamedLinkOrReference additional_properties = 1;
 		// MAP: LinkOrReference
 		x.AdditionalProperties = make([]*NamedLinkOrReference, 0)
+		var pairs1 []NamedLinkOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedLinkOrReference, 0, n)
+			pairs1 = make([]NamedLinkOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedLinkOrReference{}
+				var pair *NamedLinkOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedLinkOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewLinkOrReference(v, compiler.NewContext(k, v, context))
@@ -1747,6 +2077,7 @@ func NewLinksOrReferences(in *yaml.Node, context *compiler.Context) (*LinksOrRef
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1805,12 +2136,28 @@ func NewMediaType(in *yaml.Node, context *compiler.Context) (*MediaType, error)
 		// repeated NamedAny specification_extension = 5;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -1834,6 +2181,7 @@ func NewMediaType(in *yaml.Node, context *compiler.Context) (*MediaType, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1849,11 +2197,27 @@ func NewMediaTypes(in *yaml.Node, context *compiler.Context) (*MediaTypes, error
 		// repeated NamedMediaType additional_properties = 1;
 		// MAP: MediaType
 		x.AdditionalProperties = make([]*NamedMediaType, 0)
+		var pairs1 []NamedMediaType
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedMediaType, 0, n)
+			pairs1 = make([]NamedMediaType, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedMediaType{}
+				var pair *NamedMediaType
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedMediaType{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewMediaType(v, compiler.NewContext(k, v, context))
@@ -1864,6 +2228,7 @@ func NewMediaTypes(in *yaml.Node, context *compiler.Context) (*MediaTypes, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1902,6 +2267,7 @@ func NewNamedAny(in *yaml.Node, context *compiler.Context) (*NamedAny, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1940,6 +2306,7 @@ func NewNamedCallbackOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -1978,6 +2345,7 @@ func NewNamedEncoding(in *yaml.Node, context *compiler.Context) (*NamedEncoding,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2016,6 +2384,7 @@ func NewNamedExampleOrReference(in *yaml.Node, context *compiler.Context) (*Name
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2054,6 +2423,7 @@ func NewNamedHeaderOrReference(in *yaml.Node, context *compiler.Context) (*Named
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2092,6 +2462,7 @@ func NewNamedLinkOrReference(in *yaml.Node, context *compiler.Context) (*NamedLi
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2130,6 +2501,7 @@ func NewNamedMediaType(in *yaml.Node, context *compiler.Context) (*NamedMediaTyp
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2168,6 +2540,7 @@ func NewNamedParameterOrReference(in *yaml.Node, context *compiler.Context) (*Na
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2206,6 +2579,7 @@ func NewNamedPathItem(in *yaml.Node, context *compiler.Context) (*NamedPathItem,
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2244,6 +2618,7 @@ func NewNamedRequestBodyOrReference(in *yaml.Node, context *compiler.Context) (*
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2282,6 +2657,7 @@ func NewNamedResponseOrReference(in *yaml.Node, context *compiler.Context) (*Nam
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2320,6 +2696,7 @@ func NewNamedSchemaOrReference(in *yaml.Node, context *compiler.Context) (*Named
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2358,6 +2735,7 @@ func NewNamedSecuritySchemeOrReference(in *yaml.Node, context *compiler.Context)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2396,6 +2774,7 @@ func NewNamedServerVariable(in *yaml.Node, context *compiler.Context) (*NamedSer
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2434,6 +2813,7 @@ func NewNamedString(in *yaml.Node, context *compiler.Context) (*NamedString, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2472,6 +2852,7 @@ func NewNamedStringArray(in *yaml.Node, context *compiler.Context) (*NamedString
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2530,12 +2911,28 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 		// repeated NamedAny specification_extension = 5;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2559,6 +2956,7 @@ func NewOauthFlow(in *yaml.Node, context *compiler.Context) (*OauthFlow, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2617,12 +3015,28 @@ func NewOauthFlows(in *yaml.Node, context *compiler.Context) (*OauthFlows, error
 		// repeated NamedAny specification_extension = 5;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2646,6 +3060,7 @@ func NewOauthFlows(in *yaml.Node, context *compiler.Context) (*OauthFlows, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2661,11 +3076,27 @@ func NewObject(in *yaml.Node, context *compiler.Context) (*Object, error) {
 		// repeated NamedAny additional_properties = 1;
 		// MAP: Any
 		x.AdditionalProperties = make([]*NamedAny, 0)
+		var pairs1 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedAny, 0, n)
+			pairs1 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedAny{}
+				var pair *NamedAny
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedAny{}
+				}
 				pair.Name = k
 				result := &Any{}
 				handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2688,6 +3119,7 @@ func NewObject(in *yaml.Node, context *compiler.Context) (*Object, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -2847,12 +3279,28 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 		// repeated NamedAny specification_extension = 13;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs13 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs13 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs13 != nil {
+						pair = &pairs13[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -2876,6 +3324,7 @@ func NewOperation(in *yaml.Node, context *compiler.Context) (*Operation, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3021,12 +3470,28 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 		// repeated NamedAny specification_extension = 14;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs14 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs14 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs14 != nil {
+						pair = &pairs14[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3050,6 +3515,7 @@ func NewParameter(in *yaml.Node, context *compiler.Context) (*Parameter, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3094,6 +3560,7 @@ func NewParameterOrReference(in *yaml.Node, context *compiler.Context) (*Paramet
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3109,11 +3576,27 @@ func NewParametersOrReferences(in *yaml.Node, context *compiler.Context) (*Param
 		// repeated NamedParameterOrReference additional_properties = 1;
 		// MAP: ParameterOrReference
 		x.AdditionalProperties = make([]*NamedParameterOrReference, 0)
+		var pairs1 []NamedParameterOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedParameterOrReference, 0, n)
+			pairs1 = make([]NamedParameterOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedParameterOrReference{}
+				var pair *NamedParameterOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedParameterOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewParameterOrReference(v, compiler.NewContext(k, v, context))
@@ -3124,6 +3607,7 @@ func NewParametersOrReferences(in *yaml.Node, context *compiler.Context) (*Param
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3277,12 +3761,28 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 		// repeated NamedAny specification_extension = 14;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs14 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs14 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs14 != nil {
+						pair = &pairs14[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3306,6 +3806,7 @@ func NewPathItem(in *yaml.Node, context *compiler.Context) (*PathItem, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3328,31 +3829,72 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 		// repeated NamedPathItem path = 1;
 		// MAP: PathItem ^/
 		x.Path = make([]*NamedPathItem, 0)
-		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
-			if ok {
-				v := m.Content[i+1]
-				if strings.HasPrefix(k, "/") {
-					pair := &NamedPathItem{}
-					pair.Name = k
-					var err error
-					pair.Value, err = NewPathItem(v, compiler.NewContext(k, v, context))
-					if err != nil {
-						errors = append(errors, err)
+		var pairs1 []NamedPathItem
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "/") {
+					n++
+				}
+			}
+			x.Path = make([]*NamedPathItem, 0, n)
+			pairs1 = make([]NamedPathItem, n)
+		}
+		{
+			values := make([]*yaml.Node, 0)
+			for i := 0; i < len(m.Content); i += 2 {
+				k, ok := compiler.StringForScalarNode(m.Content[i])
+				if ok {
+					v := m.Content[i+1]
+					if strings.HasPrefix(k, "/") {
+						var pair *NamedPathItem
+						if pairs1 != nil {
+							pair = &pairs1[len(x.Path)]
+						} else {
+							pair = &NamedPathItem{}
+						}
+						pair.Name = k
+						x.Path = append(x.Path, pair)
+						values = append(values, v)
 					}
-					x.Path = append(x.Path, pair)
+				}
+			}
+			valueErrors := make([]error, len(values))
+			compiler.BuildConcurrently(len(values), func(i int) {
+				pair, v := x.Path[i], values[i]
+				pair.Value, valueErrors[i] = NewPathItem(v, compiler.NewContext(pair.Name, v, context))
+			})
+			for _, err := range valueErrors {
+				if err != nil {
+					errors = append(errors, err)
 				}
 			}
 		}
 		// repeated NamedAny specification_extension = 2;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs2 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs2 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs2 != nil {
+						pair = &pairs2[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3376,6 +3918,7 @@ func NewPaths(in *yaml.Node, context *compiler.Context) (*Paths, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3391,11 +3934,27 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 		// repeated NamedSchemaOrReference additional_properties = 1;
 		// MAP: SchemaOrReference
 		x.AdditionalProperties = make([]*NamedSchemaOrReference, 0)
+		var pairs1 []NamedSchemaOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSchemaOrReference, 0, n)
+			pairs1 = make([]NamedSchemaOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSchemaOrReference{}
+				var pair *NamedSchemaOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSchemaOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSchemaOrReference(v, compiler.NewContext(k, v, context))
@@ -3406,6 +3965,7 @@ func NewProperties(in *yaml.Node, context *compiler.Context) (*Properties, error
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3452,6 +4012,7 @@ func NewReference(in *yaml.Node, context *compiler.Context) (*Reference, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3467,11 +4028,27 @@ func NewRequestBodiesOrReferences(in *yaml.Node, context *compiler.Context) (*Re
 		// repeated NamedRequestBodyOrReference additional_properties = 1;
 		// MAP: RequestBodyOrReference
 		x.AdditionalProperties = make([]*NamedRequestBodyOrReference, 0)
+		var pairs1 []NamedRequestBodyOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedRequestBodyOrReference, 0, n)
+			pairs1 = make([]NamedRequestBodyOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedRequestBodyOrReference{}
+				var pair *NamedRequestBodyOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedRequestBodyOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewRequestBodyOrReference(v, compiler.NewContext(k, v, context))
@@ -3482,6 +4059,7 @@ func NewRequestBodiesOrReferences(in *yaml.Node, context *compiler.Context) (*Re
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3537,12 +4115,28 @@ func NewRequestBody(in *yaml.Node, context *compiler.Context) (*RequestBody, err
 		// repeated NamedAny specification_extension = 4;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3566,6 +4160,7 @@ func NewRequestBody(in *yaml.Node, context *compiler.Context) (*RequestBody, err
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3610,6 +4205,7 @@ func NewRequestBodyOrReference(in *yaml.Node, context *compiler.Context) (*Reque
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3674,12 +4270,28 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 		// repeated NamedAny specification_extension = 5;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs5 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs5 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs5 != nil {
+						pair = &pairs5[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3703,6 +4315,7 @@ func NewResponse(in *yaml.Node, context *compiler.Context) (*Response, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3747,6 +4360,7 @@ func NewResponseOrReference(in *yaml.Node, context *compiler.Context) (*Response
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3778,12 +4392,28 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// repeated NamedResponseOrReference response_or_reference = 2;
 		// MAP: ResponseOrReference ^([0-9X]{3})$
 		x.ResponseOrReference = make([]*NamedResponseOrReference, 0)
+		var pairs2 []NamedResponseOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && pattern3.MatchString(k) {
+					n++
+				}
+			}
+			x.ResponseOrReference = make([]*NamedResponseOrReference, 0, n)
+			pairs2 = make([]NamedResponseOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if pattern3.MatchString(k) {
-					pair := &NamedResponseOrReference{}
+					var pair *NamedResponseOrReference
+					if pairs2 != nil {
+						pair = &pairs2[len(x.ResponseOrReference)]
+					} else {
+						pair = &NamedResponseOrReference{}
+					}
 					pair.Name = k
 					var err error
 					pair.Value, err = NewResponseOrReference(v, compiler.NewContext(k, v, context))
@@ -3797,12 +4427,28 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 		// repeated NamedAny specification_extension = 3;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs3 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs3 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs3 != nil {
+						pair = &pairs3[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -3826,6 +4472,7 @@ func NewResponses(in *yaml.Node, context *compiler.Context) (*Responses, error)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -3841,11 +4488,27 @@ func NewResponsesOrReferences(in *yaml.Node, context *compiler.Context) (*Respon
 		// repeated NamedResponseOrReference additional_properties = 1;
 		// MAP: ResponseOrReference
 		x.AdditionalProperties = make([]*NamedResponseOrReference, 0)
+		var pairs1 []NamedResponseOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedResponseOrReference, 0, n)
+			pairs1 = make([]NamedResponseOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedResponseOrReference{}
+				var pair *NamedResponseOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedResponseOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewResponseOrReference(v, compiler.NewContext(k, v, context))
@@ -3856,6 +4519,7 @@ func NewResponsesOrReferences(in *yaml.Node, context *compiler.Context) (*Respon
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4241,12 +4905,28 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 		// repeated NamedAny specification_extension = 36;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs36 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs36 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs36 != nil {
+						pair = &pairs36[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4270,6 +4950,7 @@ func NewSchema(in *yaml.Node, context *compiler.Context) (*Schema, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4314,6 +4995,7 @@ func NewSchemaOrReference(in *yaml.Node, context *compiler.Context) (*SchemaOrRe
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4329,21 +5011,47 @@ func NewSchemasOrReferences(in *yaml.Node, context *compiler.Context) (*SchemasO
 		// repeated NamedSchemaOrReference additional_properties = 1;
 		// MAP: SchemaOrReference
 		x.AdditionalProperties = make([]*NamedSchemaOrReference, 0)
-		for i := 0; i < len(m.Content); i += 2 {
-			k, ok := compiler.StringForScalarNode(m.Content[i])
-			if ok {
-				v := m.Content[i+1]
-				pair := &NamedSchemaOrReference{}
-				pair.Name = k
-				var err error
-				pair.Value, err = NewSchemaOrReference(v, compiler.NewContext(k, v, context))
+		var pairs1 []NamedSchemaOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSchemaOrReference, 0, n)
+			pairs1 = make([]NamedSchemaOrReference, n)
+		}
+		{
+			values := make([]*yaml.Node, 0)
+			for i := 0; i < len(m.Content); i += 2 {
+				k, ok := compiler.StringForScalarNode(m.Content[i])
+				if ok {
+					v := m.Content[i+1]
+					var pair *NamedSchemaOrReference
+					if pairs1 != nil {
+						pair = &pairs1[len(x.AdditionalProperties)]
+					} else {
+						pair = &NamedSchemaOrReference{}
+					}
+					pair.Name = k
+					x.AdditionalProperties = append(x.AdditionalProperties, pair)
+					values = append(values, v)
+				}
+			}
+			valueErrors := make([]error, len(values))
+			compiler.BuildConcurrently(len(values), func(i int) {
+				pair, v := x.AdditionalProperties[i], values[i]
+				pair.Value, valueErrors[i] = NewSchemaOrReference(v, compiler.NewContext(pair.Name, v, context))
+			})
+			for _, err := range valueErrors {
 				if err != nil {
 					errors = append(errors, err)
 				}
-				x.AdditionalProperties = append(x.AdditionalProperties, pair)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4359,11 +5067,27 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 		// repeated NamedStringArray additional_properties = 1;
 		// MAP: StringArray
 		x.AdditionalProperties = make([]*NamedStringArray, 0)
+		var pairs1 []NamedStringArray
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedStringArray, 0, n)
+			pairs1 = make([]NamedStringArray, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedStringArray{}
+				var pair *NamedStringArray
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedStringArray{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewStringArray(v, compiler.NewContext(k, v, context))
@@ -4374,6 +5098,7 @@ func NewSecurityRequirement(in *yaml.Node, context *compiler.Context) (*Security
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4474,12 +5199,28 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 		// repeated NamedAny specification_extension = 9;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs9 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs9 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs9 != nil {
+						pair = &pairs9[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4503,6 +5244,7 @@ func NewSecurityScheme(in *yaml.Node, context *compiler.Context) (*SecuritySchem
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4547,6 +5289,7 @@ func NewSecuritySchemeOrReference(in *yaml.Node, context *compiler.Context) (*Se
 		err := compiler.NewError(context, message)
 		errors = []error{err}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4562,11 +5305,27 @@ func NewSecuritySchemesOrReferences(in *yaml.Node, context *compiler.Context) (*
 		// repeated NamedSecuritySchemeOrReference additional_properties = 1;
 		// MAP: SecuritySchemeOrReference
 		x.AdditionalProperties = make([]*NamedSecuritySchemeOrReference, 0)
+		var pairs1 []NamedSecuritySchemeOrReference
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedSecuritySchemeOrReference, 0, n)
+			pairs1 = make([]NamedSecuritySchemeOrReference, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedSecuritySchemeOrReference{}
+				var pair *NamedSecuritySchemeOrReference
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedSecuritySchemeOrReference{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewSecuritySchemeOrReference(v, compiler.NewContext(k, v, context))
@@ -4577,6 +5336,7 @@ func NewSecuritySchemesOrReferences(in *yaml.Node, context *compiler.Context) (*
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4632,12 +5392,28 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 		// repeated NamedAny specification_extension = 4;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4661,6 +5437,7 @@ func NewServer(in *yaml.Node, context *compiler.Context) (*Server, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4718,12 +5495,28 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 		// repeated NamedAny specification_extension = 4;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4747,6 +5540,7 @@ func NewServerVariable(in *yaml.Node, context *compiler.Context) (*ServerVariabl
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4762,11 +5556,27 @@ func NewServerVariables(in *yaml.Node, context *compiler.Context) (*ServerVariab
 		// repeated NamedServerVariable additional_properties = 1;
 		// MAP: ServerVariable
 		x.AdditionalProperties = make([]*NamedServerVariable, 0)
+		var pairs1 []NamedServerVariable
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedServerVariable, 0, n)
+			pairs1 = make([]NamedServerVariable, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedServerVariable{}
+				var pair *NamedServerVariable
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedServerVariable{}
+				}
 				pair.Name = k
 				var err error
 				pair.Value, err = NewServerVariable(v, compiler.NewContext(k, v, context))
@@ -4777,6 +5587,7 @@ func NewServerVariables(in *yaml.Node, context *compiler.Context) (*ServerVariab
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4807,6 +5618,7 @@ func NewSpecificationExtension(in *yaml.Node, context *compiler.Context) (*Speci
 		// since the oneof matched one of its possibilities, discard any matching errors
 		errors = make([]error, 0)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4819,6 +5631,7 @@ func NewStringArray(in *yaml.Node, context *compiler.Context) (*StringArray, err
 		s, _ := compiler.StringForScalarNode(node)
 		x.Value = append(x.Value, s)
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4834,17 +5647,34 @@ func NewStrings(in *yaml.Node, context *compiler.Context) (*Strings, error) {
 		// repeated NamedString additional_properties = 1;
 		// MAP: string
 		x.AdditionalProperties = make([]*NamedString, 0)
+		var pairs1 []NamedString
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if _, ok := compiler.StringForScalarNode(m.Content[i]); ok {
+					n++
+				}
+			}
+			x.AdditionalProperties = make([]*NamedString, 0, n)
+			pairs1 = make([]NamedString, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
-				pair := &NamedString{}
+				var pair *NamedString
+				if pairs1 != nil {
+					pair = &pairs1[len(x.AdditionalProperties)]
+				} else {
+					pair = &NamedString{}
+				}
 				pair.Name = k
 				pair.Value, _ = compiler.StringForScalarNode(v)
 				x.AdditionalProperties = append(x.AdditionalProperties, pair)
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4900,12 +5730,28 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 		// repeated NamedAny specification_extension = 4;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs4 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs4 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs4 != nil {
+						pair = &pairs4[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -4929,6 +5775,7 @@ func NewTag(in *yaml.Node, context *compiler.Context) (*Tag, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
@@ -4996,12 +5843,28 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 		// repeated NamedAny specification_extension = 6;
 		// MAP: Any ^x-
 		x.SpecificationExtension = make([]*NamedAny, 0)
+		var pairs6 []NamedAny
+		if compiler.GetArenaAllocation() {
+			n := 0
+			for i := 0; i < len(m.Content); i += 2 {
+				if k, ok := compiler.StringForScalarNode(m.Content[i]); ok && strings.HasPrefix(k, "x-") {
+					n++
+				}
+			}
+			x.SpecificationExtension = make([]*NamedAny, 0, n)
+			pairs6 = make([]NamedAny, n)
+		}
 		for i := 0; i < len(m.Content); i += 2 {
 			k, ok := compiler.StringForScalarNode(m.Content[i])
 			if ok {
 				v := m.Content[i+1]
 				if strings.HasPrefix(k, "x-") {
-					pair := &NamedAny{}
+					var pair *NamedAny
+					if pairs6 != nil {
+						pair = &pairs6[len(x.SpecificationExtension)]
+					} else {
+						pair = &NamedAny{}
+					}
 					pair.Name = k
 					result := &Any{}
 					handled, resultFromExt, err := compiler.CallExtension(context, v, k)
@@ -5025,9 +5888,362 @@ func NewXml(in *yaml.Node, context *compiler.Context) (*Xml, error) {
 			}
 		}
 	}
+	compiler.RecordPosition(context, x, in)
 	return x, compiler.NewErrorGroupOrNil(errors)
 }
 
+// NamedAnyMap returns a map from the names of NamedAny pairs to their values.
+// When names are repeated, the first value is used.
+func NamedAnyMap(pairs []*NamedAny) map[string]*Any {
+	m := make(map[string]*Any, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedAny returns the value of the first NamedAny pair with a name.
+func FindNamedAny(pairs []*NamedAny, name string) *Any {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedCallbackOrReferenceMap returns a map from the names of NamedCallbackOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedCallbackOrReferenceMap(pairs []*NamedCallbackOrReference) map[string]*CallbackOrReference {
+	m := make(map[string]*CallbackOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedCallbackOrReference returns the value of the first NamedCallbackOrReference pair with a name.
+func FindNamedCallbackOrReference(pairs []*NamedCallbackOrReference, name string) *CallbackOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedEncodingMap returns a map from the names of NamedEncoding pairs to their values.
+// When names are repeated, the first value is used.
+func NamedEncodingMap(pairs []*NamedEncoding) map[string]*Encoding {
+	m := make(map[string]*Encoding, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedEncoding returns the value of the first NamedEncoding pair with a name.
+func FindNamedEncoding(pairs []*NamedEncoding, name string) *Encoding {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedExampleOrReferenceMap returns a map from the names of NamedExampleOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedExampleOrReferenceMap(pairs []*NamedExampleOrReference) map[string]*ExampleOrReference {
+	m := make(map[string]*ExampleOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedExampleOrReference returns the value of the first NamedExampleOrReference pair with a name.
+func FindNamedExampleOrReference(pairs []*NamedExampleOrReference, name string) *ExampleOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedHeaderOrReferenceMap returns a map from the names of NamedHeaderOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedHeaderOrReferenceMap(pairs []*NamedHeaderOrReference) map[string]*HeaderOrReference {
+	m := make(map[string]*HeaderOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedHeaderOrReference returns the value of the first NamedHeaderOrReference pair with a name.
+func FindNamedHeaderOrReference(pairs []*NamedHeaderOrReference, name string) *HeaderOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedLinkOrReferenceMap returns a map from the names of NamedLinkOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedLinkOrReferenceMap(pairs []*NamedLinkOrReference) map[string]*LinkOrReference {
+	m := make(map[string]*LinkOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedLinkOrReference returns the value of the first NamedLinkOrReference pair with a name.
+func FindNamedLinkOrReference(pairs []*NamedLinkOrReference, name string) *LinkOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedMediaTypeMap returns a map from the names of NamedMediaType pairs to their values.
+// When names are repeated, the first value is used.
+func NamedMediaTypeMap(pairs []*NamedMediaType) map[string]*MediaType {
+	m := make(map[string]*MediaType, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedMediaType returns the value of the first NamedMediaType pair with a name.
+func FindNamedMediaType(pairs []*NamedMediaType, name string) *MediaType {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedParameterOrReferenceMap returns a map from the names of NamedParameterOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedParameterOrReferenceMap(pairs []*NamedParameterOrReference) map[string]*ParameterOrReference {
+	m := make(map[string]*ParameterOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedParameterOrReference returns the value of the first NamedParameterOrReference pair with a name.
+func FindNamedParameterOrReference(pairs []*NamedParameterOrReference, name string) *ParameterOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedPathItemMap returns a map from the names of NamedPathItem pairs to their values.
+// When names are repeated, the first value is used.
+func NamedPathItemMap(pairs []*NamedPathItem) map[string]*PathItem {
+	m := make(map[string]*PathItem, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedPathItem returns the value of the first NamedPathItem pair with a name.
+func FindNamedPathItem(pairs []*NamedPathItem, name string) *PathItem {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedRequestBodyOrReferenceMap returns a map from the names of NamedRequestBodyOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedRequestBodyOrReferenceMap(pairs []*NamedRequestBodyOrReference) map[string]*RequestBodyOrReference {
+	m := make(map[string]*RequestBodyOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedRequestBodyOrReference returns the value of the first NamedRequestBodyOrReference pair with a name.
+func FindNamedRequestBodyOrReference(pairs []*NamedRequestBodyOrReference, name string) *RequestBodyOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedResponseOrReferenceMap returns a map from the names of NamedResponseOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedResponseOrReferenceMap(pairs []*NamedResponseOrReference) map[string]*ResponseOrReference {
+	m := make(map[string]*ResponseOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedResponseOrReference returns the value of the first NamedResponseOrReference pair with a name.
+func FindNamedResponseOrReference(pairs []*NamedResponseOrReference, name string) *ResponseOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedSchemaOrReferenceMap returns a map from the names of NamedSchemaOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedSchemaOrReferenceMap(pairs []*NamedSchemaOrReference) map[string]*SchemaOrReference {
+	m := make(map[string]*SchemaOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedSchemaOrReference returns the value of the first NamedSchemaOrReference pair with a name.
+func FindNamedSchemaOrReference(pairs []*NamedSchemaOrReference, name string) *SchemaOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedSecuritySchemeOrReferenceMap returns a map from the names of NamedSecuritySchemeOrReference pairs to their values.
+// When names are repeated, the first value is used.
+func NamedSecuritySchemeOrReferenceMap(pairs []*NamedSecuritySchemeOrReference) map[string]*SecuritySchemeOrReference {
+	m := make(map[string]*SecuritySchemeOrReference, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedSecuritySchemeOrReference returns the value of the first NamedSecuritySchemeOrReference pair with a name.
+func FindNamedSecuritySchemeOrReference(pairs []*NamedSecuritySchemeOrReference, name string) *SecuritySchemeOrReference {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedServerVariableMap returns a map from the names of NamedServerVariable pairs to their values.
+// When names are repeated, the first value is used.
+func NamedServerVariableMap(pairs []*NamedServerVariable) map[string]*ServerVariable {
+	m := make(map[string]*ServerVariable, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedServerVariable returns the value of the first NamedServerVariable pair with a name.
+func FindNamedServerVariable(pairs []*NamedServerVariable, name string) *ServerVariable {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
+// NamedStringMap returns a map from the names of NamedString pairs to their values.
+// When names are repeated, the first value is used.
+func NamedStringMap(pairs []*NamedString) map[string]string {
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedString returns the value of the first NamedString pair with a name.
+func FindNamedString(pairs []*NamedString, name string) string {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
+
+// NamedStringArrayMap returns a map from the names of NamedStringArray pairs to their values.
+// When names are repeated, the first value is used.
+func NamedStringArrayMap(pairs []*NamedStringArray) map[string]*StringArray {
+	m := make(map[string]*StringArray, len(pairs))
+	for _, pair := range pairs {
+		if _, ok := m[pair.GetName()]; !ok {
+			m[pair.GetName()] = pair.GetValue()
+		}
+	}
+	return m
+}
+
+// FindNamedStringArray returns the value of the first NamedStringArray pair with a name.
+func FindNamedStringArray(pairs []*NamedStringArray, name string) *StringArray {
+	for _, pair := range pairs {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return nil
+}
+
 var (
 	pattern0 = regexp.MustCompile("^")
 	pattern1 = regexp.MustCompile("^x-")
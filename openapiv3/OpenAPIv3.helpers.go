@@ -0,0 +1,1263 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED.
+
+package openapi_v3
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
+)
+
+// DeepCopyAdditionalPropertiesItem returns a copy of x that shares no values with it.
+func DeepCopyAdditionalPropertiesItem(x *AdditionalPropertiesItem) *AdditionalPropertiesItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*AdditionalPropertiesItem)
+}
+
+// EqualAdditionalPropertiesItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualAdditionalPropertiesItem(a, b *AdditionalPropertiesItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeAdditionalPropertiesItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeAdditionalPropertiesItem(dst, src *AdditionalPropertiesItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyAny returns a copy of x that shares no values with it.
+func DeepCopyAny(x *Any) *Any {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Any)
+}
+
+// EqualAny reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualAny(a, b *Any) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeAny merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeAny(dst, src *Any) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyAnyOrExpression returns a copy of x that shares no values with it.
+func DeepCopyAnyOrExpression(x *AnyOrExpression) *AnyOrExpression {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*AnyOrExpression)
+}
+
+// EqualAnyOrExpression reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualAnyOrExpression(a, b *AnyOrExpression) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeAnyOrExpression merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeAnyOrExpression(dst, src *AnyOrExpression) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyCallback returns a copy of x that shares no values with it.
+func DeepCopyCallback(x *Callback) *Callback {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Callback)
+}
+
+// EqualCallback reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualCallback(a, b *Callback) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeCallback merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeCallback(dst, src *Callback) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyCallbackOrReference returns a copy of x that shares no values with it.
+func DeepCopyCallbackOrReference(x *CallbackOrReference) *CallbackOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*CallbackOrReference)
+}
+
+// EqualCallbackOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualCallbackOrReference(a, b *CallbackOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeCallbackOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeCallbackOrReference(dst, src *CallbackOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyCallbacksOrReferences returns a copy of x that shares no values with it.
+func DeepCopyCallbacksOrReferences(x *CallbacksOrReferences) *CallbacksOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*CallbacksOrReferences)
+}
+
+// EqualCallbacksOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualCallbacksOrReferences(a, b *CallbacksOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeCallbacksOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeCallbacksOrReferences(dst, src *CallbacksOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyComponents returns a copy of x that shares no values with it.
+func DeepCopyComponents(x *Components) *Components {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Components)
+}
+
+// EqualComponents reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualComponents(a, b *Components) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeComponents merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeComponents(dst, src *Components) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyContact returns a copy of x that shares no values with it.
+func DeepCopyContact(x *Contact) *Contact {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Contact)
+}
+
+// EqualContact reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualContact(a, b *Contact) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeContact merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeContact(dst, src *Contact) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDefaultType returns a copy of x that shares no values with it.
+func DeepCopyDefaultType(x *DefaultType) *DefaultType {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*DefaultType)
+}
+
+// EqualDefaultType reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDefaultType(a, b *DefaultType) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDefaultType merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDefaultType(dst, src *DefaultType) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDiscriminator returns a copy of x that shares no values with it.
+func DeepCopyDiscriminator(x *Discriminator) *Discriminator {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Discriminator)
+}
+
+// EqualDiscriminator reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDiscriminator(a, b *Discriminator) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDiscriminator merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDiscriminator(dst, src *Discriminator) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyDocument returns a copy of x that shares no values with it.
+func DeepCopyDocument(x *Document) *Document {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Document)
+}
+
+// EqualDocument reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualDocument(a, b *Document) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeDocument merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeDocument(dst, src *Document) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyEncoding returns a copy of x that shares no values with it.
+func DeepCopyEncoding(x *Encoding) *Encoding {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Encoding)
+}
+
+// EqualEncoding reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualEncoding(a, b *Encoding) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeEncoding merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeEncoding(dst, src *Encoding) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyEncodings returns a copy of x that shares no values with it.
+func DeepCopyEncodings(x *Encodings) *Encodings {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Encodings)
+}
+
+// EqualEncodings reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualEncodings(a, b *Encodings) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeEncodings merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeEncodings(dst, src *Encodings) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExample returns a copy of x that shares no values with it.
+func DeepCopyExample(x *Example) *Example {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Example)
+}
+
+// EqualExample reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExample(a, b *Example) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExample merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExample(dst, src *Example) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExampleOrReference returns a copy of x that shares no values with it.
+func DeepCopyExampleOrReference(x *ExampleOrReference) *ExampleOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ExampleOrReference)
+}
+
+// EqualExampleOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExampleOrReference(a, b *ExampleOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExampleOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExampleOrReference(dst, src *ExampleOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExamplesOrReferences returns a copy of x that shares no values with it.
+func DeepCopyExamplesOrReferences(x *ExamplesOrReferences) *ExamplesOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ExamplesOrReferences)
+}
+
+// EqualExamplesOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExamplesOrReferences(a, b *ExamplesOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExamplesOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExamplesOrReferences(dst, src *ExamplesOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExpression returns a copy of x that shares no values with it.
+func DeepCopyExpression(x *Expression) *Expression {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Expression)
+}
+
+// EqualExpression reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExpression(a, b *Expression) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExpression merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExpression(dst, src *Expression) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyExternalDocs returns a copy of x that shares no values with it.
+func DeepCopyExternalDocs(x *ExternalDocs) *ExternalDocs {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ExternalDocs)
+}
+
+// EqualExternalDocs reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualExternalDocs(a, b *ExternalDocs) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeExternalDocs merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeExternalDocs(dst, src *ExternalDocs) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeader returns a copy of x that shares no values with it.
+func DeepCopyHeader(x *Header) *Header {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Header)
+}
+
+// EqualHeader reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeader(a, b *Header) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeader merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeader(dst, src *Header) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeaderOrReference returns a copy of x that shares no values with it.
+func DeepCopyHeaderOrReference(x *HeaderOrReference) *HeaderOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*HeaderOrReference)
+}
+
+// EqualHeaderOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeaderOrReference(a, b *HeaderOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeaderOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeaderOrReference(dst, src *HeaderOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyHeadersOrReferences returns a copy of x that shares no values with it.
+func DeepCopyHeadersOrReferences(x *HeadersOrReferences) *HeadersOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*HeadersOrReferences)
+}
+
+// EqualHeadersOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualHeadersOrReferences(a, b *HeadersOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeHeadersOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeHeadersOrReferences(dst, src *HeadersOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyInfo returns a copy of x that shares no values with it.
+func DeepCopyInfo(x *Info) *Info {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Info)
+}
+
+// EqualInfo reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualInfo(a, b *Info) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeInfo merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeInfo(dst, src *Info) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyItemsItem returns a copy of x that shares no values with it.
+func DeepCopyItemsItem(x *ItemsItem) *ItemsItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ItemsItem)
+}
+
+// EqualItemsItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualItemsItem(a, b *ItemsItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeItemsItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeItemsItem(dst, src *ItemsItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyLicense returns a copy of x that shares no values with it.
+func DeepCopyLicense(x *License) *License {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*License)
+}
+
+// EqualLicense reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualLicense(a, b *License) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeLicense merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeLicense(dst, src *License) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyLink returns a copy of x that shares no values with it.
+func DeepCopyLink(x *Link) *Link {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Link)
+}
+
+// EqualLink reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualLink(a, b *Link) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeLink merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeLink(dst, src *Link) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyLinkOrReference returns a copy of x that shares no values with it.
+func DeepCopyLinkOrReference(x *LinkOrReference) *LinkOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*LinkOrReference)
+}
+
+// EqualLinkOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualLinkOrReference(a, b *LinkOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeLinkOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeLinkOrReference(dst, src *LinkOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyLinksOrReferences returns a copy of x that shares no values with it.
+func DeepCopyLinksOrReferences(x *LinksOrReferences) *LinksOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*LinksOrReferences)
+}
+
+// EqualLinksOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualLinksOrReferences(a, b *LinksOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeLinksOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeLinksOrReferences(dst, src *LinksOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyMediaType returns a copy of x that shares no values with it.
+func DeepCopyMediaType(x *MediaType) *MediaType {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*MediaType)
+}
+
+// EqualMediaType reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualMediaType(a, b *MediaType) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeMediaType merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeMediaType(dst, src *MediaType) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyMediaTypes returns a copy of x that shares no values with it.
+func DeepCopyMediaTypes(x *MediaTypes) *MediaTypes {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*MediaTypes)
+}
+
+// EqualMediaTypes reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualMediaTypes(a, b *MediaTypes) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeMediaTypes merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeMediaTypes(dst, src *MediaTypes) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauthFlow returns a copy of x that shares no values with it.
+func DeepCopyOauthFlow(x *OauthFlow) *OauthFlow {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*OauthFlow)
+}
+
+// EqualOauthFlow reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauthFlow(a, b *OauthFlow) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauthFlow merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauthFlow(dst, src *OauthFlow) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOauthFlows returns a copy of x that shares no values with it.
+func DeepCopyOauthFlows(x *OauthFlows) *OauthFlows {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*OauthFlows)
+}
+
+// EqualOauthFlows reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOauthFlows(a, b *OauthFlows) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOauthFlows merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOauthFlows(dst, src *OauthFlows) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyObject returns a copy of x that shares no values with it.
+func DeepCopyObject(x *Object) *Object {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Object)
+}
+
+// EqualObject reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualObject(a, b *Object) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeObject merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeObject(dst, src *Object) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyOperation returns a copy of x that shares no values with it.
+func DeepCopyOperation(x *Operation) *Operation {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Operation)
+}
+
+// EqualOperation reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualOperation(a, b *Operation) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeOperation merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeOperation(dst, src *Operation) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParameter returns a copy of x that shares no values with it.
+func DeepCopyParameter(x *Parameter) *Parameter {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Parameter)
+}
+
+// EqualParameter reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParameter(a, b *Parameter) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParameter merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParameter(dst, src *Parameter) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParameterOrReference returns a copy of x that shares no values with it.
+func DeepCopyParameterOrReference(x *ParameterOrReference) *ParameterOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ParameterOrReference)
+}
+
+// EqualParameterOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParameterOrReference(a, b *ParameterOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParameterOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParameterOrReference(dst, src *ParameterOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyParametersOrReferences returns a copy of x that shares no values with it.
+func DeepCopyParametersOrReferences(x *ParametersOrReferences) *ParametersOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ParametersOrReferences)
+}
+
+// EqualParametersOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualParametersOrReferences(a, b *ParametersOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeParametersOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeParametersOrReferences(dst, src *ParametersOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPathItem returns a copy of x that shares no values with it.
+func DeepCopyPathItem(x *PathItem) *PathItem {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*PathItem)
+}
+
+// EqualPathItem reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPathItem(a, b *PathItem) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePathItem merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePathItem(dst, src *PathItem) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyPaths returns a copy of x that shares no values with it.
+func DeepCopyPaths(x *Paths) *Paths {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Paths)
+}
+
+// EqualPaths reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualPaths(a, b *Paths) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergePaths merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergePaths(dst, src *Paths) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyProperties returns a copy of x that shares no values with it.
+func DeepCopyProperties(x *Properties) *Properties {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Properties)
+}
+
+// EqualProperties reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualProperties(a, b *Properties) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeProperties merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeProperties(dst, src *Properties) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyReference returns a copy of x that shares no values with it.
+func DeepCopyReference(x *Reference) *Reference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Reference)
+}
+
+// EqualReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualReference(a, b *Reference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeReference(dst, src *Reference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyRequestBodiesOrReferences returns a copy of x that shares no values with it.
+func DeepCopyRequestBodiesOrReferences(x *RequestBodiesOrReferences) *RequestBodiesOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*RequestBodiesOrReferences)
+}
+
+// EqualRequestBodiesOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualRequestBodiesOrReferences(a, b *RequestBodiesOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeRequestBodiesOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeRequestBodiesOrReferences(dst, src *RequestBodiesOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyRequestBody returns a copy of x that shares no values with it.
+func DeepCopyRequestBody(x *RequestBody) *RequestBody {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*RequestBody)
+}
+
+// EqualRequestBody reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualRequestBody(a, b *RequestBody) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeRequestBody merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeRequestBody(dst, src *RequestBody) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyRequestBodyOrReference returns a copy of x that shares no values with it.
+func DeepCopyRequestBodyOrReference(x *RequestBodyOrReference) *RequestBodyOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*RequestBodyOrReference)
+}
+
+// EqualRequestBodyOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualRequestBodyOrReference(a, b *RequestBodyOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeRequestBodyOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeRequestBodyOrReference(dst, src *RequestBodyOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponse returns a copy of x that shares no values with it.
+func DeepCopyResponse(x *Response) *Response {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Response)
+}
+
+// EqualResponse reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponse(a, b *Response) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponse merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponse(dst, src *Response) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponseOrReference returns a copy of x that shares no values with it.
+func DeepCopyResponseOrReference(x *ResponseOrReference) *ResponseOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ResponseOrReference)
+}
+
+// EqualResponseOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponseOrReference(a, b *ResponseOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponseOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponseOrReference(dst, src *ResponseOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponses returns a copy of x that shares no values with it.
+func DeepCopyResponses(x *Responses) *Responses {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Responses)
+}
+
+// EqualResponses reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponses(a, b *Responses) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponses merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponses(dst, src *Responses) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyResponsesOrReferences returns a copy of x that shares no values with it.
+func DeepCopyResponsesOrReferences(x *ResponsesOrReferences) *ResponsesOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ResponsesOrReferences)
+}
+
+// EqualResponsesOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualResponsesOrReferences(a, b *ResponsesOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeResponsesOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeResponsesOrReferences(dst, src *ResponsesOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySchema returns a copy of x that shares no values with it.
+func DeepCopySchema(x *Schema) *Schema {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Schema)
+}
+
+// EqualSchema reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSchema(a, b *Schema) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSchema merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSchema(dst, src *Schema) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySchemaOrReference returns a copy of x that shares no values with it.
+func DeepCopySchemaOrReference(x *SchemaOrReference) *SchemaOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SchemaOrReference)
+}
+
+// EqualSchemaOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSchemaOrReference(a, b *SchemaOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSchemaOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSchemaOrReference(dst, src *SchemaOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySchemasOrReferences returns a copy of x that shares no values with it.
+func DeepCopySchemasOrReferences(x *SchemasOrReferences) *SchemasOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SchemasOrReferences)
+}
+
+// EqualSchemasOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSchemasOrReferences(a, b *SchemasOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSchemasOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSchemasOrReferences(dst, src *SchemasOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecurityRequirement returns a copy of x that shares no values with it.
+func DeepCopySecurityRequirement(x *SecurityRequirement) *SecurityRequirement {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecurityRequirement)
+}
+
+// EqualSecurityRequirement reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecurityRequirement(a, b *SecurityRequirement) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecurityRequirement merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecurityRequirement(dst, src *SecurityRequirement) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecurityScheme returns a copy of x that shares no values with it.
+func DeepCopySecurityScheme(x *SecurityScheme) *SecurityScheme {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecurityScheme)
+}
+
+// EqualSecurityScheme reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecurityScheme(a, b *SecurityScheme) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecurityScheme merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecurityScheme(dst, src *SecurityScheme) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecuritySchemeOrReference returns a copy of x that shares no values with it.
+func DeepCopySecuritySchemeOrReference(x *SecuritySchemeOrReference) *SecuritySchemeOrReference {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecuritySchemeOrReference)
+}
+
+// EqualSecuritySchemeOrReference reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecuritySchemeOrReference(a, b *SecuritySchemeOrReference) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecuritySchemeOrReference merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecuritySchemeOrReference(dst, src *SecuritySchemeOrReference) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySecuritySchemesOrReferences returns a copy of x that shares no values with it.
+func DeepCopySecuritySchemesOrReferences(x *SecuritySchemesOrReferences) *SecuritySchemesOrReferences {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SecuritySchemesOrReferences)
+}
+
+// EqualSecuritySchemesOrReferences reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSecuritySchemesOrReferences(a, b *SecuritySchemesOrReferences) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSecuritySchemesOrReferences merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSecuritySchemesOrReferences(dst, src *SecuritySchemesOrReferences) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyServer returns a copy of x that shares no values with it.
+func DeepCopyServer(x *Server) *Server {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Server)
+}
+
+// EqualServer reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualServer(a, b *Server) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeServer merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeServer(dst, src *Server) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyServerVariable returns a copy of x that shares no values with it.
+func DeepCopyServerVariable(x *ServerVariable) *ServerVariable {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ServerVariable)
+}
+
+// EqualServerVariable reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualServerVariable(a, b *ServerVariable) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeServerVariable merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeServerVariable(dst, src *ServerVariable) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyServerVariables returns a copy of x that shares no values with it.
+func DeepCopyServerVariables(x *ServerVariables) *ServerVariables {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*ServerVariables)
+}
+
+// EqualServerVariables reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualServerVariables(a, b *ServerVariables) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeServerVariables merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeServerVariables(dst, src *ServerVariables) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopySpecificationExtension returns a copy of x that shares no values with it.
+func DeepCopySpecificationExtension(x *SpecificationExtension) *SpecificationExtension {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*SpecificationExtension)
+}
+
+// EqualSpecificationExtension reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualSpecificationExtension(a, b *SpecificationExtension) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeSpecificationExtension merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeSpecificationExtension(dst, src *SpecificationExtension) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyStringArray returns a copy of x that shares no values with it.
+func DeepCopyStringArray(x *StringArray) *StringArray {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*StringArray)
+}
+
+// EqualStringArray reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualStringArray(a, b *StringArray) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeStringArray merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeStringArray(dst, src *StringArray) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyStrings returns a copy of x that shares no values with it.
+func DeepCopyStrings(x *Strings) *Strings {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Strings)
+}
+
+// EqualStrings reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualStrings(a, b *Strings) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeStrings merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeStrings(dst, src *Strings) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyTag returns a copy of x that shares no values with it.
+func DeepCopyTag(x *Tag) *Tag {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Tag)
+}
+
+// EqualTag reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualTag(a, b *Tag) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeTag merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeTag(dst, src *Tag) {
+	compiler.MergeModels(dst, src)
+}
+
+// DeepCopyXml returns a copy of x that shares no values with it.
+func DeepCopyXml(x *Xml) *Xml {
+	if x == nil {
+		return nil
+	}
+	return proto.Clone(x).(*Xml)
+}
+
+// EqualXml reports whether a and b are equal, comparing maps without
+// regard to order and Any values by their YAML values.
+func EqualXml(a, b *Xml) bool {
+	return compiler.EqualModels(a, b)
+}
+
+// MergeXml merges src into dst, merging the values of maps with the
+// values of dst that have the same names.
+func MergeXml(dst, src *Xml) {
+	compiler.MergeModels(dst, src)
+}
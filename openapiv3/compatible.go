@@ -0,0 +1,279 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+)
+
+// CompatibilityMode selects the rules used to compare two versions of a schema.
+type CompatibilityMode int
+
+const (
+	// ProviderMode compares schemas of values that an API receives, such as
+	// request bodies and parameters. The new schema must accept every value
+	// that the old schema accepted, so that existing clients keep working.
+	ProviderMode CompatibilityMode = iota
+	// ConsumerMode compares schemas of values that an API returns, such as
+	// response bodies. Every value accepted by the new schema must also be
+	// accepted by the old schema, so that existing clients can read it.
+	ConsumerMode
+)
+
+// Incompatibility describes a change to a schema that can break existing clients.
+type Incompatibility struct {
+	// Path is the location of the change in the schema, such as "properties/name".
+	Path    string
+	Message string
+}
+
+func (i *Incompatibility) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Compatible compares two versions of a schema and returns the changes that
+// make the new version incompatible with the old one in the specified mode.
+// It returns nil if the new version is compatible.
+//
+// References are not resolved, so schemas that refer to different
+// components are reported as incompatible. Because the models don't record
+// whether numeric constraints are present, zero values are treated as absent.
+func Compatible(old, new *Schema, mode CompatibilityMode) []*Incompatibility {
+	c := &compatibilityChecker{mode: mode}
+	c.compareSchemas("", old, new)
+	return c.incompatibilities
+}
+
+type compatibilityChecker struct {
+	mode              CompatibilityMode
+	incompatibilities []*Incompatibility
+}
+
+func (c *compatibilityChecker) report(path string, format string, args ...interface{}) {
+	c.incompatibilities = append(c.incompatibilities, &Incompatibility{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// message chooses between a message for provider mode and one for consumer mode.
+func (c *compatibilityChecker) message(provider, consumer string) string {
+	if c.mode == ProviderMode {
+		return provider
+	}
+	return consumer
+}
+
+// widerAndNarrower returns the schema that must accept every value accepted
+// by the other one, followed by the other one.
+func (c *compatibilityChecker) widerAndNarrower(old, new *Schema) (*Schema, *Schema) {
+	if c.mode == ProviderMode {
+		return new, old
+	}
+	return old, new
+}
+
+func joinPath(path string, elements ...string) string {
+	for _, element := range elements {
+		element = jsonpointer.Escape(element)
+		if path == "" {
+			path = element
+		} else {
+			path = path + "/" + element
+		}
+	}
+	return path
+}
+
+func (c *compatibilityChecker) compareSchemaOrReferences(path string, old, new *SchemaOrReference) {
+	oldReference, newReference := old.GetReference(), new.GetReference()
+	switch {
+	case oldReference != nil && newReference != nil:
+		if oldReference.XRef != newReference.XRef {
+			c.report(path, "reference changed from %s to %s", oldReference.XRef, newReference.XRef)
+		}
+	case oldReference != nil:
+		c.report(path, "reference %s was replaced by a schema", oldReference.XRef)
+	case newReference != nil:
+		c.report(path, "schema was replaced by reference %s", newReference.XRef)
+	default:
+		c.compareSchemas(path, old.GetSchema(), new.GetSchema())
+	}
+}
+
+func (c *compatibilityChecker) compareSchemas(path string, old, new *Schema) {
+	if old == nil || new == nil {
+		return
+	}
+	wider, narrower := c.widerAndNarrower(old, new)
+
+	if wider.Type != "" && narrower.Type != wider.Type &&
+		!(wider.Type == "number" && narrower.Type == "integer") {
+		c.report(path, "type changed from %s to %s", typeName(old.Type), typeName(new.Type))
+	}
+	if narrower.Nullable && !wider.Nullable {
+		c.report(path, "nullable changed from %t to %t", old.Nullable, new.Nullable)
+	}
+	if wider.Format != "" && narrower.Format != wider.Format {
+		c.report(path, "format changed from %q to %q", old.Format, new.Format)
+	}
+	c.compareEnums(path, wider, narrower)
+	c.compareConstraints(path, old, new, wider, narrower)
+	c.compareObjects(path, old, new, wider, narrower)
+
+	if old.Items != nil && new.Items != nil &&
+		len(old.Items.SchemaOrReference) > 0 && len(new.Items.SchemaOrReference) > 0 {
+		c.compareSchemaOrReferences(joinPath(path, "items"), old.Items.SchemaOrReference[0], new.Items.SchemaOrReference[0])
+	}
+	c.compareCompositions(joinPath(path, "allOf"), old.AllOf, new.AllOf)
+	c.compareCompositions(joinPath(path, "oneOf"), old.OneOf, new.OneOf)
+	c.compareCompositions(joinPath(path, "anyOf"), old.AnyOf, new.AnyOf)
+}
+
+func typeName(t string) string {
+	if t == "" {
+		return "any"
+	}
+	return t
+}
+
+func (c *compatibilityChecker) compareEnums(path string, wider, narrower *Schema) {
+	if len(wider.Enum) == 0 {
+		return
+	}
+	if len(narrower.Enum) == 0 {
+		c.report(path, c.message("enum was added", "enum was removed"))
+		return
+	}
+	values := make(map[string]bool)
+	for _, value := range wider.Enum {
+		values[strings.TrimSpace(value.Yaml)] = true
+	}
+	for _, value := range narrower.Enum {
+		v := strings.TrimSpace(value.Yaml)
+		if !values[v] {
+			c.report(path, c.message("enum value %s was removed", "enum value %s was added"), v)
+		}
+	}
+}
+
+func (c *compatibilityChecker) compareConstraints(path string, old, new, wider, narrower *Schema) {
+	if wider.Maximum != 0 && (narrower.Maximum == 0 || narrower.Maximum > wider.Maximum ||
+		(narrower.Maximum == wider.Maximum && wider.ExclusiveMaximum && !narrower.ExclusiveMaximum)) {
+		c.report(path, "maximum changed from %v to %v", old.Maximum, new.Maximum)
+	}
+	if wider.Minimum != 0 && (narrower.Minimum < wider.Minimum ||
+		(narrower.Minimum == wider.Minimum && wider.ExclusiveMinimum && !narrower.ExclusiveMinimum)) {
+		c.report(path, "minimum changed from %v to %v", old.Minimum, new.Minimum)
+	}
+	if wider.MultipleOf != 0 && (narrower.MultipleOf == 0 || math.Mod(narrower.MultipleOf, wider.MultipleOf) != 0) {
+		c.report(path, "multipleOf changed from %v to %v", old.MultipleOf, new.MultipleOf)
+	}
+	for _, limit := range []struct {
+		name      string
+		old, new  int64
+		wider     int64
+		narrower  int64
+		isMaximum bool
+	}{
+		{"maxLength", old.MaxLength, new.MaxLength, wider.MaxLength, narrower.MaxLength, true},
+		{"minLength", old.MinLength, new.MinLength, wider.MinLength, narrower.MinLength, false},
+		{"maxItems", old.MaxItems, new.MaxItems, wider.MaxItems, narrower.MaxItems, true},
+		{"minItems", old.MinItems, new.MinItems, wider.MinItems, narrower.MinItems, false},
+		{"maxProperties", old.MaxProperties, new.MaxProperties, wider.MaxProperties, narrower.MaxProperties, true},
+		{"minProperties", old.MinProperties, new.MinProperties, wider.MinProperties, narrower.MinProperties, false},
+	} {
+		if limit.isMaximum && limit.wider != 0 && (limit.narrower == 0 || limit.narrower > limit.wider) ||
+			!limit.isMaximum && limit.narrower < limit.wider {
+			c.report(path, "%s changed from %d to %d", limit.name, limit.old, limit.new)
+		}
+	}
+	if wider.Pattern != "" && narrower.Pattern != wider.Pattern {
+		c.report(path, "pattern changed from %q to %q", old.Pattern, new.Pattern)
+	}
+	if wider.UniqueItems && !narrower.UniqueItems {
+		c.report(path, "uniqueItems changed from %t to %t", old.UniqueItems, new.UniqueItems)
+	}
+}
+
+func (c *compatibilityChecker) compareObjects(path string, old, new, wider, narrower *Schema) {
+	narrowerRequired := make(map[string]bool)
+	for _, name := range narrower.Required {
+		narrowerRequired[name] = true
+	}
+	for _, name := range wider.Required {
+		if !narrowerRequired[name] {
+			c.report(path, c.message("property %q became required", "property %q is no longer required"), name)
+		}
+	}
+
+	oldProperties := namedSchemas(old.Properties)
+	newProperties := namedSchemas(new.Properties)
+	widerProperties := namedSchemas(wider.Properties)
+	if forbidsAdditionalProperties(wider) {
+		if narrower.AdditionalProperties == nil || narrower.AdditionalProperties.GetBoolean() ||
+			narrower.AdditionalProperties.GetSchemaOrReference() != nil {
+			c.report(path, c.message("additional properties are no longer allowed", "additional properties are now allowed"))
+		}
+		if narrower.Properties != nil {
+			for _, pair := range narrower.Properties.AdditionalProperties {
+				if _, ok := widerProperties[pair.Name]; !ok {
+					c.report(path, c.message("property %q was removed", "property %q was added"), pair.Name)
+				}
+			}
+		}
+	}
+	if old.Properties != nil {
+		for _, pair := range old.Properties.AdditionalProperties {
+			if newProperty, ok := newProperties[pair.Name]; ok {
+				c.compareSchemaOrReferences(joinPath(path, "properties", pair.Name), oldProperties[pair.Name], newProperty)
+			}
+		}
+	}
+	oldAdditional := old.AdditionalProperties.GetSchemaOrReference()
+	newAdditional := new.AdditionalProperties.GetSchemaOrReference()
+	if oldAdditional != nil && newAdditional != nil {
+		c.compareSchemaOrReferences(joinPath(path, "additionalProperties"), oldAdditional, newAdditional)
+	}
+}
+
+func forbidsAdditionalProperties(schema *Schema) bool {
+	additional := schema.AdditionalProperties
+	return additional != nil && additional.GetSchemaOrReference() == nil && !additional.GetBoolean()
+}
+
+func namedSchemas(properties *Properties) map[string]*SchemaOrReference {
+	schemas := make(map[string]*SchemaOrReference)
+	if properties != nil {
+		for _, pair := range properties.AdditionalProperties {
+			schemas[pair.Name] = pair.Value
+		}
+	}
+	return schemas
+}
+
+func (c *compatibilityChecker) compareCompositions(path string, old, new []*SchemaOrReference) {
+	if len(old) != len(new) {
+		c.report(path, "number of schemas changed from %d to %d", len(old), len(new))
+		return
+	}
+	for i := range old {
+		c.compareSchemaOrReferences(joinPath(path, fmt.Sprintf("%d", i)), old[i], new[i])
+	}
+}
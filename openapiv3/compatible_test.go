@@ -0,0 +1,132 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/gnostic/compiler"
+)
+
+func parseSchema(t *testing.T, text string) *Schema {
+	info, err := compiler.ReadInfoFromBytes("", []byte(text))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	root := info.Content[0]
+	schema, err := NewSchema(root, compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return schema
+}
+
+func TestCompatible(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		old      string
+		new      string
+		provider []string
+		consumer []string
+	}{
+		{
+			name: "unchanged",
+			old:  "{type: object, properties: {name: {type: string}}}",
+			new:  "{type: object, properties: {name: {type: string}}}",
+		},
+		{
+			name:     "required property added",
+			old:      "{type: object, properties: {name: {type: string}}}",
+			new:      "{type: object, properties: {name: {type: string}}, required: [name]}",
+			provider: []string{`property "name" became required`},
+		},
+		{
+			name:     "required property removed",
+			old:      "{type: object, required: [name]}",
+			new:      "{type: object}",
+			consumer: []string{`property "name" is no longer required`},
+		},
+		{
+			name:     "type narrowed",
+			old:      "{type: number}",
+			new:      "{type: integer}",
+			provider: []string{"type changed from number to integer"},
+		},
+		{
+			name:     "type widened",
+			old:      "{type: integer}",
+			new:      "{type: number}",
+			consumer: []string{"type changed from integer to number"},
+		},
+		{
+			name:     "enum shrinkage",
+			old:      "{type: string, enum: [a, b, c]}",
+			new:      "{type: string, enum: [a, b]}",
+			provider: []string{"enum value c was removed"},
+		},
+		{
+			name:     "enum growth",
+			old:      "{type: string, enum: [a]}",
+			new:      "{type: string, enum: [a, b]}",
+			consumer: []string{"enum value b was added"},
+		},
+		{
+			name:     "nested property",
+			old:      "{type: object, properties: {pet: {type: object, properties: {a/b: {type: string, maxLength: 10}}}}}",
+			new:      "{type: object, properties: {pet: {type: object, properties: {a/b: {type: string, maxLength: 5}}}}}",
+			provider: []string{"properties/pet/properties/a~1b: maxLength changed from 10 to 5"},
+		},
+		{
+			name:     "items",
+			old:      "{type: array, items: {type: string}, minItems: 1}",
+			new:      "{type: array, items: {type: string, format: uuid}, minItems: 2}",
+			provider: []string{"minItems changed from 1 to 2", `items: format changed from "" to "uuid"`},
+		},
+		{
+			name:     "closed object",
+			old:      "{type: object, properties: {a: {type: string}}, additionalProperties: false}",
+			new:      "{type: object, properties: {a: {type: string}, b: {type: string}}, additionalProperties: false}",
+			consumer: []string{`property "b" was added`},
+		},
+		{
+			name:     "references",
+			old:      "{type: object, properties: {pet: {$ref: '#/components/schemas/Pet'}}}",
+			new:      "{type: object, properties: {pet: {$ref: '#/components/schemas/Animal'}}}",
+			provider: []string{"properties/pet: reference changed from #/components/schemas/Pet to #/components/schemas/Animal"},
+			consumer: []string{"properties/pet: reference changed from #/components/schemas/Pet to #/components/schemas/Animal"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			old := parseSchema(t, test.old)
+			new := parseSchema(t, test.new)
+			for _, mode := range []struct {
+				mode     CompatibilityMode
+				expected []string
+			}{
+				{ProviderMode, test.provider},
+				{ConsumerMode, test.consumer},
+			} {
+				var got []string
+				for _, incompatibility := range Compatible(old, new, mode.mode) {
+					got = append(got, incompatibility.String())
+				}
+				if !reflect.DeepEqual(got, mode.expected) {
+					t.Errorf("unexpected result in mode %d: %q (expected %q)", mode.mode, got, mode.expected)
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package openapi_v3
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 
 	"github.com/google/gnostic/compiler"
@@ -34,3 +36,58 @@ func ParseDocument(b []byte) (*Document, error) {
 	root := info.Content[0]
 	return NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, nil))
 }
+
+// ParseDocumentWithLocations reads an OpenAPI v3 description from a YAML/JSON
+// representation and returns it with an index of the positions of its values
+// in the source, keyed by JSON pointers.
+func ParseDocumentWithLocations(b []byte) (*Document, *compiler.LocationIndex, error) {
+	info, err := compiler.ReadInfoFromBytes("", b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(info.Content) < 1 {
+		return nil, nil, errors.New("document has no content")
+	}
+
+	root := info.Content[0]
+	document, err := NewDocument(root, compiler.NewContextWithExtensions("$root", root, nil, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, compiler.NewLocationIndex(root), nil
+}
+
+// ParseDocumentWithPositions reads an OpenAPI v3 description from a YAML/JSON
+// representation and returns it with a table of the positions in the source
+// of the messages that were built from it.
+func ParseDocumentWithPositions(b []byte) (*Document, *compiler.PositionTable, error) {
+	info, err := compiler.ReadInfoFromBytes("", b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(info.Content) < 1 {
+		return nil, nil, errors.New("document has no content")
+	}
+
+	root := info.Content[0]
+	context := compiler.NewContextWithExtensions("$root", root, nil, nil)
+	positions := compiler.NewPositionTable()
+	stop := compiler.TrackPositions(context, positions)
+	defer stop()
+	document, err := NewDocument(root, context)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, positions, nil
+}
+
+// Hash returns a SHA-256 digest, in hexadecimal, of the canonical encoding
+// of a document. Documents that describe the same API have the same digest,
+// regardless of the order of the entries of their maps and the formatting
+// of their sources, so digests can be compared to detect changes.
+func Hash(document *Document) string {
+	sum := sha256.Sum256(compiler.CanonicalBytes(document))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// extensionsField returns the list of specification extensions of an object.
+func extensionsField(object proto.Message) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
+	m := object.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("specification_extension")
+	if field == nil || !field.IsList() {
+		return nil, nil, fmt.Errorf("%s objects have no specification extensions", m.Descriptor().Name())
+	}
+	return m, field, nil
+}
+
+// GetExtension returns the value of the specification extension of an
+// object with a name, such as "x-order", or nil if the object has no such
+// extension.
+func GetExtension(object proto.Message, name string) *Any {
+	m, field, err := extensionsField(object)
+	if err != nil {
+		return nil
+	}
+	list := m.Get(field).List()
+	for i := 0; i < list.Len(); i++ {
+		if extension := list.Get(i).Message().Interface().(*NamedAny); extension.Name == name {
+			return extension.Value
+		}
+	}
+	return nil
+}
+
+// DecodeExtension decodes the value of the specification extension of an
+// object with a name into the value pointed to by v, as yaml.Unmarshal
+// does. It returns false if the object has no such extension.
+func DecodeExtension(object proto.Message, name string, v interface{}) (bool, error) {
+	extension := GetExtension(object, name)
+	if extension == nil {
+		return false, nil
+	}
+	if err := yaml.Unmarshal([]byte(extension.Yaml), v); err != nil {
+		return true, fmt.Errorf("invalid value of %s: %s", name, err)
+	}
+	return true, nil
+}
+
+// SetExtension sets the specification extension of an object with a name,
+// replacing any existing extension with the same name. The value is
+// marshaled to YAML, so it can be a Go value or a *yaml.Node.
+func SetExtension(object proto.Message, name string, value interface{}) error {
+	if !strings.HasPrefix(name, "x-") {
+		return fmt.Errorf("invalid extension name %q, names of specification extensions start with \"x-\"", name)
+	}
+	m, field, err := extensionsField(object)
+	if err != nil {
+		return err
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	any := &Any{Yaml: string(bytes)}
+	list := m.Mutable(field).List()
+	for i := 0; i < list.Len(); i++ {
+		if extension := list.Get(i).Message().Interface().(*NamedAny); extension.Name == name {
+			extension.Value = any
+			return nil
+		}
+	}
+	list.Append(protoreflect.ValueOfMessage((&NamedAny{Name: name, Value: any}).ProtoReflect()))
+	return nil
+}
+
+// DeleteExtension removes the specification extension of an object with a
+// name. It returns false if the object has no such extension.
+func DeleteExtension(object proto.Message, name string) bool {
+	m, field, err := extensionsField(object)
+	if err != nil {
+		return false
+	}
+	list := m.Get(field).List()
+	for i := 0; i < list.Len(); i++ {
+		if list.Get(i).Message().Interface().(*NamedAny).Name != name {
+			continue
+		}
+		mutable := m.Mutable(field).List()
+		for j := i; j+1 < mutable.Len(); j++ {
+			mutable.Set(j, mutable.Get(j+1))
+		}
+		mutable.Truncate(mutable.Len() - 1)
+		return true
+	}
+	return false
+}
@@ -15,8 +15,14 @@
 package openapi_v3
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/google/gnostic/compiler"
 )
 
 func TestParseDocument(t *testing.T) {
@@ -61,3 +67,245 @@ func TestParseDocument_Empty(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDocumentWithPositions(t *testing.T) {
+	d, positions, err := ParseDocumentWithPositions([]byte(`openapi: 3.0.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, test := range []struct {
+		name     string
+		position compiler.Location
+		message  proto.Message
+	}{
+		{"document", compiler.Location{Line: 1, Column: 1}, d},
+		{"info", compiler.Location{Line: 3, Column: 3}, d.Info},
+		{"path", compiler.Location{Line: 7, Column: 5}, d.Paths.Path[0].Value},
+		{"operation", compiler.Location{Line: 8, Column: 7}, d.Paths.Path[0].Value.Get},
+	} {
+		position, ok := positions.Position(test.message)
+		if !ok || position != test.position {
+			t.Errorf("unexpected position of %s: %+v, expected %+v", test.name, position, test.position)
+		}
+	}
+}
+
+// largeDocument returns a description with many paths and schemas. When
+// invalid is set, some of them have unknown properties.
+func largeDocument(invalid bool) []byte {
+	var b strings.Builder
+	b.WriteString("openapi: 3.0.0\ninfo:\n  title: pets\n  version: 1.0.0\npaths:\n")
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&b, "  /pets%d:\n    get:\n      responses:\n        \"200\":\n          description: pets\n", i)
+		if invalid && i%10 == 0 {
+			b.WriteString("      unknown: value\n")
+		}
+	}
+	b.WriteString("components:\n  schemas:\n")
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&b, "    Pet%d:\n      type: object\n", i)
+		if invalid && i%10 == 0 {
+			b.WriteString("      unknown: value\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+func TestParseDocumentConcurrently(t *testing.T) {
+	expected, expectedPositions, err := ParseDocumentWithPositions(largeDocument(false))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	_, expectedErr := ParseDocument(largeDocument(true))
+	if expectedErr == nil {
+		t.Fatalf("expected errors")
+	}
+
+	compiler.SetParallelism(8)
+	defer compiler.SetParallelism(1)
+	d, positions, err := ParseDocumentWithPositions(largeDocument(false))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !proto.Equal(d, expected) {
+		t.Errorf("documents built concurrently differ")
+	}
+	if positions.Len() != expectedPositions.Len() {
+		t.Errorf("unexpected number of positions %d, expected %d", positions.Len(), expectedPositions.Len())
+	}
+	for i, path := range d.Paths.Path {
+		position, _ := positions.Position(path.Value)
+		expectedPosition, _ := expectedPositions.Position(expected.Paths.Path[i].Value)
+		if position != expectedPosition {
+			t.Errorf("unexpected position of %s: %+v, expected %+v", path.Name, position, expectedPosition)
+		}
+	}
+	// Errors are reported in the order of the document.
+	if _, err := ParseDocument(largeDocument(true)); err == nil || err.Error() != expectedErr.Error() {
+		t.Errorf("unexpected errors:\n%v\nexpected:\n%v", err, expectedErr)
+	}
+}
+
+func TestParseDocumentWithArenaAllocation(t *testing.T) {
+	source := largeDocument(false)
+	expected, err := ParseDocument(source)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	allocs := testing.AllocsPerRun(10, func() { ParseDocument(source) })
+
+	compiler.SetArenaAllocation(true)
+	defer compiler.SetArenaAllocation(false)
+	d, err := ParseDocument(source)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !proto.Equal(d, expected) {
+		t.Errorf("documents built with arena allocation differ")
+	}
+	if arenaAllocs := testing.AllocsPerRun(10, func() { ParseDocument(source) }); arenaAllocs >= allocs {
+		t.Errorf("arena allocation made %.0f allocations, expected fewer than %.0f", arenaAllocs, allocs)
+	}
+}
+
+func TestNamedPathItemMap(t *testing.T) {
+	d, err := ParseDocument(largeDocument(false))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	paths := NamedPathItemMap(d.Paths.Path)
+	if len(paths) != len(d.Paths.Path) {
+		t.Errorf("unexpected number of paths %d, expected %d", len(paths), len(d.Paths.Path))
+	}
+	if paths["/pets7"] != d.Paths.Path[7].Value {
+		t.Errorf("unexpected value for /pets7")
+	}
+	if FindNamedPathItem(d.Paths.Path, "/pets7") != d.Paths.Path[7].Value {
+		t.Errorf("unexpected value found for /pets7")
+	}
+	if FindNamedPathItem(d.Paths.Path, "/unknown") != nil {
+		t.Errorf("unexpected value found for /unknown")
+	}
+	if schema := FindNamedSchemaOrReference(d.Components.Schemas.AdditionalProperties, "Pet3"); schema.GetSchema().GetType() != "object" {
+		t.Errorf("unexpected schema found for Pet3: %v", schema)
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	b, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ParseDocument(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := DeepCopyDocument(d)
+	if c == d || c.Paths == d.Paths || !EqualDocument(c, d) {
+		t.Fatalf("expected an equal copy")
+	}
+	// Maps are equal regardless of their order.
+	schemas := c.Components.Schemas.AdditionalProperties
+	schemas[0], schemas[1] = schemas[1], schemas[0]
+	if proto.Equal(c, d) || !EqualDocument(c, d) {
+		t.Errorf("expected documents with reordered schemas to be equal")
+	}
+	// Any values are compared by their values.
+	a := &Any{Yaml: "{a: 1, b: [x]}"}
+	if !EqualAny(a, &Any{Yaml: "b:\n- x\na: 1\n"}) || EqualAny(a, &Any{Yaml: "{a: 2, b: [x]}"}) {
+		t.Errorf("unexpected comparison of Any values")
+	}
+	c.Components.Schemas.AdditionalProperties[0].Value.GetSchema().Description = "changed"
+	if EqualDocument(c, d) {
+		t.Errorf("expected documents with different schemas to differ")
+	}
+
+	// Merging adds new entries of maps and merges existing ones.
+	src := &Document{
+		Info: &Info{Description: "Merged"},
+		Components: &Components{
+			Schemas: &SchemasOrReferences{
+				AdditionalProperties: []*NamedSchemaOrReference{
+					{Name: "Pet", Value: &SchemaOrReference{Oneof: &SchemaOrReference_Schema{Schema: &Schema{Description: "A pet."}}}},
+					{Name: "Owner", Value: &SchemaOrReference{Oneof: &SchemaOrReference_Schema{Schema: &Schema{Type: "object"}}}},
+				},
+			},
+		},
+	}
+	merged := DeepCopyDocument(d)
+	MergeDocument(merged, src)
+	if merged.Info.Title != d.Info.Title || merged.Info.Description != "Merged" {
+		t.Errorf("unexpected info: %+v", merged.Info)
+	}
+	names := make([]string, 0)
+	for _, pair := range merged.Components.Schemas.AdditionalProperties {
+		names = append(names, pair.Name)
+	}
+	if strings.Join(names, ",") != "Pet,Pets,Error,Owner" {
+		t.Errorf("unexpected schemas: %v", names)
+	}
+	pet := merged.Components.Schemas.AdditionalProperties[0].Value.GetSchema()
+	if pet.Description != "A pet." || len(pet.Required) != 2 || pet.Properties == nil {
+		t.Errorf("unexpected merged schema: %+v", pet)
+	}
+	// The merged values are copies.
+	src.Components.Schemas.AdditionalProperties[1].Value.GetSchema().Type = "string"
+	if merged.Components.Schemas.AdditionalProperties[3].Value.GetSchema().Type != "object" {
+		t.Errorf("expected merged values to be copied")
+	}
+	if !EqualDocument(d, DeepCopyDocument(d)) || DeepCopyDocument(nil) != nil {
+		t.Errorf("unexpected copies")
+	}
+}
+
+func TestHash(t *testing.T) {
+	yamlBytes, err := ioutil.ReadFile("../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonBytes, err := ioutil.ReadFile("../examples/v3.0/json/petstore.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := ParseDocument(yamlBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ParseDocument(jsonBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The formats of sources and the order of map entries don't matter.
+	if Hash(a) != Hash(b) {
+		t.Errorf("expected YAML and JSON sources to have the same hash")
+	}
+	schemas := b.Components.Schemas.AdditionalProperties
+	schemas[0], schemas[2] = schemas[2], schemas[0]
+	if Hash(a) != Hash(b) {
+		t.Errorf("expected reordered schemas to have the same hash")
+	}
+	extension := func(yaml string) *Document {
+		return &Document{SpecificationExtension: []*NamedAny{{Name: "x-value", Value: &Any{Yaml: yaml}}}}
+	}
+	if Hash(extension("{b: 1, a: [x]}")) != Hash(extension("a:\n- x\nb: 1\n")) ||
+		Hash(&Document{Openapi: "3.0", Info: &Info{}}) != Hash(&Document{Openapi: "3.0"}) {
+		t.Errorf("expected equal values to have the same hash")
+	}
+	b.Components.Schemas.AdditionalProperties[0].Value.GetSchema().Description = "changed"
+	if Hash(a) == Hash(b) {
+		t.Errorf("expected changed documents to have different hashes")
+	}
+	if h := Hash(a); len(h) != 64 || h != Hash(DeepCopyDocument(a)) {
+		t.Errorf("unexpected hash %q", h)
+	}
+}
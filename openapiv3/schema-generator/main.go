@@ -19,6 +19,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -57,10 +58,15 @@ func ReadSection(text string, level int) (section *Section) {
 	section = &Section{Level: level, Text: text}
 	lines := strings.Split(string(text), "\n")
 	subsection := ""
+	// lines in fenced code blocks, such as YAML comments, are not titles
+	inCode := false
 	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
 		if i == 0 && titlePattern.Match([]byte(line)) {
 			section.Title = line
-		} else if subtitlePattern.Match([]byte(line)) {
+		} else if !inCode && subtitlePattern.Match([]byte(line)) {
 			// we've found a subsection title.
 			// if there's a subsection that we've already been reading, save it
 			if len(subsection) != 0 {
@@ -127,6 +133,16 @@ func (s *Section) NiceTitle() string {
 	}
 }
 
+// ChildWithTitle returns the subsection with a specified title, or nil if there is none.
+func (s *Section) ChildWithTitle(title string) *Section {
+	for _, child := range s.Children {
+		if child.NiceTitle() == title {
+			return child
+		}
+	}
+	return nil
+}
+
 // replace markdown links with their link text (removing the URL part)
 func removeMarkdownLinks(input string) (output string) {
 	markdownLink := regexp.MustCompile("\\[([^\\]\\[]*)\\]\\(([^\\)]*)\\)") // matches [link title](link url)
@@ -134,135 +150,181 @@ func removeMarkdownLinks(input string) (output string) {
 	return
 }
 
-// extract the fixed fields from a table in a section
-func parseFixedFields(input string, schemaObject *SchemaObject) {
-	lines := strings.Split(input, "\n")
-	for _, line := range lines {
-
-		// replace escaped bars with "OR", assuming these are used to describe union types
-		line = strings.Replace(line, " \\| ", " OR ", -1)
-
-		// split the table on the remaining bars
-		parts := strings.Split(line, "|")
-		if len(parts) > 1 {
-			fieldName := strings.Trim(stripLink(parts[0]), " ")
-			if fieldName != "Field Name" && fieldName != "---" {
+// TableFormat describes the tables of fields in the specification.
+type TableFormat struct {
+	FixedFieldsTitle     string   // title of sections with tables of fixed fields
+	PatternedFieldsTitle string   // title of sections with tables of patterned fields
+	NameColumns          []string // headers of the columns of field names and patterns
+	TypeColumn           string   // header of the column of field types
+	DescriptionColumn    string   // header of the column of field descriptions
+	ValidityColumns      []string // headers of columns that limit where fields are valid
+}
 
-				if len(parts) == 3 || len(parts) == 4 {
-					// this is what we expect
-				} else {
-					log.Printf("ERROR: %+v", parts)
-				}
+// DefaultTableFormat is the format of the tables of the OpenAPI 3.0 and 3.1 specifications.
+var DefaultTableFormat = TableFormat{
+	FixedFieldsTitle:     "Fixed Fields",
+	PatternedFieldsTitle: "Patterned Fields",
+	NameColumns:          []string{"Field Name", "Field Pattern"},
+	TypeColumn:           "Type",
+	DescriptionColumn:    "Description",
+	ValidityColumns:      []string{"Validity", "Applies To"},
+}
 
-				typeName := parts[1]
-				typeName = strings.Replace(typeName, "{expression}", "Expression", -1)
-				typeName = strings.Trim(typeName, " ")
-				typeName = strings.Replace(typeName, "`", "", -1)
-				typeName = removeMarkdownLinks(typeName)
-				typeName = strings.Replace(typeName, " ", "", -1)
-				typeName = strings.Replace(typeName, "Object", "", -1)
-				isArray := false
-				if typeName[0] == '[' && typeName[len(typeName)-1] == ']' {
-					typeName = typeName[1 : len(typeName)-1]
-					isArray = true
-				}
-				isMap := false
-				mapPattern := regexp.MustCompile("^Mapstring,\\[(.*)\\]$")
-				if matches := mapPattern.FindSubmatch([]byte(typeName)); matches != nil {
-					typeName = string(matches[1])
-					isMap = true
-				} else {
-					// match map[string,<typename>]
-					mapPattern2 := regexp.MustCompile("^Map\\[string,(.+)\\]$")
-					if matches := mapPattern2.FindSubmatch([]byte(typeName)); matches != nil {
-						typeName = string(matches[1])
-						isMap = true
-					}
-				}
-				description := strings.Trim(parts[len(parts)-1], " ")
-				description = removeMarkdownLinks(description)
-				description = strings.Replace(description, "\n", " ", -1)
+// a row of a table, with cells keyed by the headers of their columns
+type tableRow map[string]string
 
-				requiredLabel1 := "**Required.** "
-				requiredLabel2 := "**REQUIRED**."
-				if strings.Contains(description, requiredLabel1) ||
-					strings.Contains(description, requiredLabel2) {
-					// only include required values if their "Validity" is "Any" or if no validity is specified
-					valid := true
-					if len(parts) == 4 {
-						validity := parts[2]
-						if strings.Contains(validity, "Any") {
-							valid = true
-						} else {
-							valid = false
-						}
-					}
-					if valid {
-						schemaObject.RequiredFields = append(schemaObject.RequiredFields, fieldName)
-					}
-					description = strings.Replace(description, requiredLabel1, "", -1)
-					description = strings.Replace(description, requiredLabel2, "", -1)
-				}
-				schemaField := SchemaObjectField{
-					Name:        fieldName,
-					Type:        typeName,
-					IsArray:     isArray,
-					IsMap:       isMap,
-					Description: description,
-				}
-				schemaObject.FixedFields = append(schemaObject.FixedFields, schemaField)
-			}
+func (row tableRow) cell(columns ...string) string {
+	for _, column := range columns {
+		if value, ok := row[column]; ok {
+			return value
 		}
 	}
+	return ""
 }
 
-// extract the patterned fields from a table in a section
-func parsePatternedFields(input string, schemaObject *SchemaObject) {
+// read the rows of the tables in a section. Tables are found by their header and
+// delimiter rows, so other text that contains bars is ignored.
+func readTableRows(input string) []tableRow {
+	delimiterPattern := regexp.MustCompile("^[ |:-]*-[ |:-]*$")
+	splitRow := func(line string) []string {
+		// replace escaped bars with "OR", assuming these are used to describe union types
+		line = strings.Replace(line, " \\| ", " OR ", -1)
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(strings.TrimSuffix(line, "|"), "|")
+		cells := strings.Split(line, "|")
+		for i, cell := range cells {
+			cells[i] = strings.Trim(cell, " ")
+		}
+		return cells
+	}
+	rows := make([]tableRow, 0)
 	lines := strings.Split(input, "\n")
-	for _, line := range lines {
+	var headers []string
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+		}
+		if inCode || !strings.Contains(line, "|") {
+			headers = nil
+			continue
+		}
+		if headers == nil {
+			// a table starts with a header row that is followed by a delimiter row
+			if i+1 < len(lines) && delimiterPattern.MatchString(lines[i+1]) {
+				headers = splitRow(line)
+			}
+			continue
+		}
+		if delimiterPattern.MatchString(line) {
+			continue
+		}
+		cells := splitRow(line)
+		if len(cells) != len(headers) {
+			log.Printf("ERROR: %+v", cells)
+		}
+		row := make(tableRow)
+		for j, header := range headers {
+			if j < len(cells) {
+				row[header] = cells[j]
+			}
+		}
+		// descriptions are in the last column
+		row[headers[len(headers)-1]] = cells[len(cells)-1]
+		rows = append(rows, row)
+	}
+	return rows
+}
 
-		line = strings.Replace(line, " \\| ", " OR ", -1)
+// parse the type of a field, which is written like "[Server Object]",
+// "Map[`string`, [Path Item Object] \| [Reference Object]]", or "`string`"
+func parseFieldType(typeName string) (name string, isArray, isMap bool) {
+	typeName = strings.Replace(typeName, "{expression}", "Expression", -1)
+	typeName = strings.Replace(typeName, "`", "", -1)
+	typeName = removeMarkdownLinks(typeName)
+	typeName = strings.Replace(typeName, " ", "", -1)
+	typeName = strings.Replace(typeName, "Object", "", -1)
+	// remove unbalanced closing brackets
+	for strings.HasSuffix(typeName, "]") && strings.Count(typeName, "]") > strings.Count(typeName, "[") {
+		typeName = typeName[0 : len(typeName)-1]
+	}
+	if typeName[0] == '[' && typeName[len(typeName)-1] == ']' {
+		typeName = typeName[1 : len(typeName)-1]
+		isArray = true
+	}
+	mapPattern := regexp.MustCompile("^Mapstring,\\[(.*)\\]$")
+	if matches := mapPattern.FindSubmatch([]byte(typeName)); matches != nil {
+		typeName = string(matches[1])
+		isMap = true
+	} else {
+		// match map[string,<typename>]
+		mapPattern2 := regexp.MustCompile("^Map\\[string,(.+)\\]$")
+		if matches := mapPattern2.FindSubmatch([]byte(typeName)); matches != nil {
+			typeName = string(matches[1])
+			isMap = true
+		}
+	}
+	return typeName, isArray, isMap
+}
 
-		parts := strings.Split(line, "|")
-		if len(parts) > 1 {
-			fieldName := strings.Trim(stripLink(parts[0]), " ")
-			fieldName = removeMarkdownLinks(fieldName)
-			if fieldName == "HTTP Status Code" {
-				fieldName = "^([0-9X]{3})$"
-			}
-			if fieldName != "Field Pattern" && fieldName != "---" {
-				typeName := parts[1]
-				typeName = strings.Trim(typeName, " ")
-				typeName = strings.Replace(typeName, "`", "", -1)
-				typeName = removeMarkdownLinks(typeName)
-				typeName = strings.Replace(typeName, " ", "", -1)
-				typeName = strings.Replace(typeName, "Object", "", -1)
-				typeName = strings.Replace(typeName, "{expression}", "Expression", -1)
-				isArray := false
-				if typeName[0] == '[' && typeName[len(typeName)-1] == ']' {
-					typeName = typeName[1 : len(typeName)-1]
-					isArray = true
-				}
-				isMap := false
-				mapPattern := regexp.MustCompile("^Mapstring,\\[(.*)\\]$")
-				if matches := mapPattern.FindSubmatch([]byte(typeName)); matches != nil {
-					typeName = string(matches[1])
-					isMap = true
-				}
-				description := strings.Trim(parts[len(parts)-1], " ")
-				description = removeMarkdownLinks(description)
-				description = strings.Replace(description, "\n", " ", -1)
+// parse the description of a field
+func parseFieldDescription(description string) string {
+	description = removeMarkdownLinks(description)
+	return strings.Replace(description, "\n", " ", -1)
+}
 
-				schemaField := SchemaObjectField{
-					Name:        fieldName,
-					Type:        typeName,
-					IsArray:     isArray,
-					IsMap:       isMap,
-					Description: description,
+// extract the fixed fields from a table in a section
+func parseFixedFields(input string, schemaObject *SchemaObject, format *TableFormat) {
+	for _, row := range readTableRows(input) {
+		fieldName := strings.Trim(stripLink(row.cell(format.NameColumns...)), " ")
+		typeName, isArray, isMap := parseFieldType(row.cell(format.TypeColumn))
+		description := parseFieldDescription(row.cell(format.DescriptionColumn))
+
+		requiredLabel1 := "**Required.** "
+		requiredLabel2 := "**REQUIRED**."
+		if strings.Contains(description, requiredLabel1) ||
+			strings.Contains(description, requiredLabel2) {
+			// only include required values if their "Validity" is "Any" or if no validity is specified
+			valid := true
+			for _, column := range format.ValidityColumns {
+				if validity, ok := row[column]; ok && !strings.Contains(validity, "Any") {
+					valid = false
 				}
-				schemaObject.PatternedFields = append(schemaObject.PatternedFields, schemaField)
 			}
+			if valid {
+				schemaObject.RequiredFields = append(schemaObject.RequiredFields, fieldName)
+			}
+			description = strings.Replace(description, requiredLabel1, "", -1)
+			description = strings.Replace(description, requiredLabel2, "", -1)
+		}
+		schemaField := SchemaObjectField{
+			Name:        fieldName,
+			Type:        typeName,
+			IsArray:     isArray,
+			IsMap:       isMap,
+			Description: description,
 		}
+		schemaObject.FixedFields = append(schemaObject.FixedFields, schemaField)
+	}
+}
+
+// extract the patterned fields from a table in a section
+func parsePatternedFields(input string, schemaObject *SchemaObject, format *TableFormat) {
+	for _, row := range readTableRows(input) {
+		fieldName := strings.Trim(stripLink(row.cell(format.NameColumns...)), " ")
+		fieldName = removeMarkdownLinks(fieldName)
+		if fieldName == "HTTP Status Code" {
+			fieldName = "^([0-9X]{3})$"
+		}
+		typeName, isArray, isMap := parseFieldType(row.cell(format.TypeColumn))
+		schemaField := SchemaObjectField{
+			Name:        fieldName,
+			Type:        typeName,
+			IsArray:     isArray,
+			IsMap:       isMap,
+			Description: parseFieldDescription(row.cell(format.DescriptionColumn)),
+		}
+		schemaObject.PatternedFields = append(schemaObject.PatternedFields, schemaField)
 	}
 }
 
@@ -288,6 +350,7 @@ type SchemaObject struct {
 
 // SchemaModel is a collection of schemas.
 type SchemaModel struct {
+	Version string // the major and minor version of the specification, e.g. "3.1"
 	Objects []SchemaObject
 }
 
@@ -300,8 +363,24 @@ func (m *SchemaModel) objectWithID(id string) *SchemaObject {
 	return nil
 }
 
+// SpecificationFormat describes the structure of an OpenAPI specification document.
+type SpecificationFormat struct {
+	SpecificationTitle string         // title of the section that contains the specification
+	SchemaTitle        string         // title of the subsection that describes the objects of the schema
+	ObjectAnchor       *regexp.Regexp // matches the titles of objects and captures their ids
+	Tables             TableFormat
+}
+
+// DefaultSpecificationFormat is the format of the OpenAPI 3.0 and 3.1 specifications.
+var DefaultSpecificationFormat = SpecificationFormat{
+	SpecificationTitle: "Specification",
+	SchemaTitle:        "Schema",
+	ObjectAnchor:       regexp.MustCompile("^#### <a name=\"(.*)Object\""),
+	Tables:             DefaultTableFormat,
+}
+
 // NewSchemaModel returns a new SchemaModel.
-func NewSchemaModel(filename string) (schemaModel *SchemaModel, err error) {
+func NewSchemaModel(filename string, format *SpecificationFormat) (schemaModel *SchemaModel, err error) {
 	b, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
@@ -312,12 +391,17 @@ func NewSchemaModel(filename string) (schemaModel *SchemaModel, err error) {
 	document.Display("")
 
 	// read object names and their details
-	specification := document.Children[4] // fragile! the section title is "Specification"
-	schema := specification.Children[8]   // fragile! the section title is "Schema"
-	anchor := regexp.MustCompile("^#### <a name=\"(.*)Object\"")
+	specification := document.ChildWithTitle(format.SpecificationTitle)
+	if specification == nil {
+		return nil, fmt.Errorf("%s has no section titled %q", filename, format.SpecificationTitle)
+	}
+	schema := specification.ChildWithTitle(format.SchemaTitle)
+	if schema == nil {
+		return nil, fmt.Errorf("%s has no section titled %q in %q", filename, format.SchemaTitle, format.SpecificationTitle)
+	}
 	schemaObjects := make([]SchemaObject, 0)
 	for _, section := range schema.Children {
-		if matches := anchor.FindSubmatch([]byte(section.Title)); matches != nil {
+		if matches := format.ObjectAnchor.FindSubmatch([]byte(section.Title)); matches != nil {
 
 			id := string(matches[1])
 
@@ -342,15 +426,15 @@ func NewSchemaModel(filename string) (schemaModel *SchemaModel, err error) {
 
 			// look for fixed fields
 			for _, child := range section.Children {
-				if child.NiceTitle() == "Fixed Fields" {
-					parseFixedFields(child.Text, &schemaObject)
+				if child.NiceTitle() == format.Tables.FixedFieldsTitle {
+					parseFixedFields(child.Text, &schemaObject, &format.Tables)
 				}
 			}
 
 			// look for patterned fields
 			for _, child := range section.Children {
-				if child.NiceTitle() == "Patterned Fields" {
-					parsePatternedFields(child.Text, &schemaObject)
+				if child.NiceTitle() == format.Tables.PatternedFieldsTitle {
+					parsePatternedFields(child.Text, &schemaObject, &format.Tables)
 				}
 			}
 
@@ -358,6 +442,10 @@ func NewSchemaModel(filename string) (schemaModel *SchemaModel, err error) {
 		}
 	}
 
+	versionPattern := regexp.MustCompile("(?m)^#+ Version ([0-9]+\\.[0-9]+)")
+	if matches := versionPattern.FindSubmatch(b); matches != nil {
+		return &SchemaModel{Version: string(matches[1]), Objects: schemaObjects}, nil
+	}
 	return &SchemaModel{Objects: schemaObjects}, nil
 }
 
@@ -618,17 +706,41 @@ func arrayOfSchema() *jsonschema.Schema {
 }
 
 func main() {
+	input := flag.String("spec", "3.1.0.md", "the Markdown text of the OpenAPI specification")
+	output := flag.String("out", "schema.json", "where to write the JSON schema")
+	modelOutput := flag.String("model", "model.json", "where to write the model of the specification (for debugging), or empty to skip it")
+	format := DefaultSpecificationFormat
+	flag.StringVar(&format.SpecificationTitle, "specification-title", format.SpecificationTitle, "the title of the section that contains the specification")
+	flag.StringVar(&format.SchemaTitle, "schema-title", format.SchemaTitle, "the title of the section that describes the objects of the schema")
+	objectAnchor := flag.String("object-anchor", format.ObjectAnchor.String(), "a regular expression that matches the titles of objects and captures their ids")
+	flag.StringVar(&format.Tables.FixedFieldsTitle, "fixed-fields-title", format.Tables.FixedFieldsTitle, "the title of sections with tables of fixed fields")
+	flag.StringVar(&format.Tables.PatternedFieldsTitle, "patterned-fields-title", format.Tables.PatternedFieldsTitle, "the title of sections with tables of patterned fields")
+	nameColumns := flag.String("name-columns", strings.Join(format.Tables.NameColumns, ","), "the comma-separated headers of columns of field names")
+	flag.StringVar(&format.Tables.TypeColumn, "type-column", format.Tables.TypeColumn, "the header of the column of field types")
+	flag.StringVar(&format.Tables.DescriptionColumn, "description-column", format.Tables.DescriptionColumn, "the header of the column of field descriptions")
+	validityColumns := flag.String("validity-columns", strings.Join(format.Tables.ValidityColumns, ","), "the comma-separated headers of columns that limit where fields are valid")
+	flag.Parse()
+	var err error
+	format.ObjectAnchor, err = regexp.Compile(*objectAnchor)
+	if err != nil {
+		log.Fatalf("Invalid object anchor: %s", err)
+	}
+	format.Tables.NameColumns = strings.Split(*nameColumns, ",")
+	format.Tables.ValidityColumns = strings.Split(*validityColumns, ",")
+
 	// read and parse the text specification into a model structure
-	model, err := NewSchemaModel("3.1.0.md")
+	model, err := NewSchemaModel(*input, &format)
 	if err != nil {
 		panic(err)
 	}
 
 	// write the model as JSON (for debugging)
-	modelJSON, _ := json.MarshalIndent(model, "", "  ")
-	err = ioutil.WriteFile("model.json", modelJSON, 0644)
-	if err != nil {
-		panic(err)
+	if *modelOutput != "" {
+		modelJSON, _ := json.MarshalIndent(model, "", "  ")
+		err = ioutil.WriteFile(*modelOutput, modelJSON, 0644)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// build the top-level schema using the "OAS" model
@@ -640,7 +752,11 @@ func main() {
 	schema := buildSchemaWithModel(oasModel)
 
 	// manually set a few fields
-	schema.Title = stringptr("A JSON Schema for OpenAPI 3.0.")
+	version := model.Version
+	if version == "" {
+		version = "3.0"
+	}
+	schema.Title = stringptr("A JSON Schema for OpenAPI " + version + ".")
 	schema.ID = stringptr("http://openapis.org/v3/schema.json#")
 	schema.Schema = stringptr("http://json-schema.org/draft-04/schema#")
 
@@ -850,8 +966,7 @@ func main() {
 	}
 
 	// write the updated schema
-	output := schema.JSONString()
-	err = ioutil.WriteFile("schema.json", []byte(output), 0644)
+	err = ioutil.WriteFile(*output, []byte(schema.JSONString()), 0644)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestNewSchemaModel(t *testing.T) {
+	for _, test := range []struct {
+		filename string
+		version  string
+		objects  int
+	}{
+		{"3.0.1.md", "3.0", 30},
+		{"3.1.0.md", "3.1", 30},
+	} {
+		model, err := NewSchemaModel(test.filename, &DefaultSpecificationFormat)
+		if err != nil {
+			t.Fatalf("%s: %s", test.filename, err)
+		}
+		if model.Version != test.version {
+			t.Errorf("%s: expected version %s, got %s", test.filename, test.version, model.Version)
+		}
+		if len(model.Objects) != test.objects {
+			t.Errorf("%s: expected %d objects, got %d", test.filename, test.objects, len(model.Objects))
+		}
+		if model.objectWithID("oas") == nil {
+			t.Errorf("%s: missing OpenAPI object", test.filename)
+		}
+	}
+}
+
+func TestParseFieldType(t *testing.T) {
+	for _, test := range []struct {
+		text    string
+		name    string
+		isArray bool
+		isMap   bool
+	}{
+		{"`string`", "string", false, false},
+		{"[[Server Object](#serverObject)]", "Server", true, false},
+		{"Map[`string`, [Path Item Object](#pathItemObject) OR [Reference Object](#referenceObject)] ]", "PathItemORReference", false, true},
+		{"Map[ `string`, [Example Object](#exampleObject) OR [Reference Object](#referenceObject)]", "ExampleORReference", false, true},
+	} {
+		name, isArray, isMap := parseFieldType(test.text)
+		if name != test.name || isArray != test.isArray || isMap != test.isMap {
+			t.Errorf("%s: expected %s %t %t, got %s %t %t", test.text, test.name, test.isArray, test.isMap, name, isArray, isMap)
+		}
+	}
+}
+
+func TestReadTableRows(t *testing.T) {
+	rows := readTableRows(`
+Field Name | Type | Applies To | Description
+---|:---:|---|---
+name | `+"`string`"+` | `+"`apiKey`"+` | **REQUIRED**. The name of the header.
+in | `+"`string`"+` | Any | The location \| of the key.
+
+Text with a | bar is not a table.
+`)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %+v", len(rows), rows)
+	}
+	if rows[0]["Field Name"] != "name" || rows[0]["Applies To"] != "`apiKey`" {
+		t.Errorf("unexpected row: %+v", rows[0])
+	}
+	if rows[1]["Description"] != "The location OR of the key." {
+		t.Errorf("unexpected row: %+v", rows[1])
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package overlay applies OpenAPI Overlay documents to API descriptions.
+//
+// An overlay is a list of actions, each of which selects nodes of a
+// description with a JSONPath expression and then updates or removes
+// them. Overlays let users keep changes, such as environment-specific
+// servers or descriptions, outside of their base description.
+// See https://github.com/OAI/Overlay-Specification.
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpath"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Overlay is a parsed Overlay document.
+type Overlay struct {
+	// Version is the version of the Overlay specification used by the document.
+	Version string
+	Title   string
+	// Extends optionally identifies the description that the overlay applies to.
+	Extends string
+	Actions []*Action
+}
+
+// Action updates or removes the nodes selected by a JSONPath target.
+type Action struct {
+	Target      string
+	Description string
+	// Update is merged into each of the selected nodes.
+	Update *yaml.Node
+	// Remove removes the selected nodes. When it is set, Update is ignored.
+	Remove bool
+
+	path *jsonpath.Path
+}
+
+// ReadOverlay reads an Overlay document from a file or URL.
+func ReadOverlay(filename string) (*Overlay, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	o, err := ParseOverlay(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return o, nil
+}
+
+// ParseOverlay reads an Overlay document from a YAML/JSON representation.
+func ParseOverlay(b []byte) (*Overlay, error) {
+	info, err := compiler.ReadInfoFromBytes("", b)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Content) < 1 || info.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("overlay must be an object")
+	}
+	root := info.Content[0]
+	o := &Overlay{}
+	var ok bool
+	if o.Version, ok = compiler.StringForScalarNode(compiler.MapValueForKey(root, "overlay")); !ok {
+		return nil, errors.New("overlay is missing the overlay version")
+	}
+	if !strings.HasPrefix(o.Version, "1.") {
+		return nil, fmt.Errorf("unsupported overlay version %s", o.Version)
+	}
+	o.Title, _ = compiler.StringForScalarNode(compiler.MapValueForKey(compiler.MapValueForKey(root, "info"), "title"))
+	o.Extends, _ = compiler.StringForScalarNode(compiler.MapValueForKey(root, "extends"))
+	actions := compiler.MapValueForKey(root, "actions")
+	if actions == nil || actions.Kind != yaml.SequenceNode || len(actions.Content) == 0 {
+		return nil, errors.New("overlay has no actions")
+	}
+	for i, node := range actions.Content {
+		action := &Action{}
+		if action.Target, ok = compiler.StringForScalarNode(compiler.MapValueForKey(node, "target")); !ok {
+			return nil, fmt.Errorf("actions[%d] has no target", i)
+		}
+		if action.path, err = jsonpath.Parse(action.Target); err != nil {
+			return nil, fmt.Errorf("actions[%d] has an invalid target %q: %s", i, action.Target, err)
+		}
+		action.Description, _ = compiler.StringForScalarNode(compiler.MapValueForKey(node, "description"))
+		action.Update = compiler.MapValueForKey(node, "update")
+		action.Remove, _ = compiler.BoolForScalarNode(compiler.MapValueForKey(node, "remove"))
+		o.Actions = append(o.Actions, action)
+	}
+	return o, nil
+}
+
+// Apply applies the actions of an overlay, in order, to a copy of an API
+// description and returns the copy. Objects in updates are merged
+// into the selected objects, arrays in updates are appended to the
+// selected arrays, and other values replace the selected values.
+// Actions that don't select any nodes have no effect.
+// Apply can be used as a transforms.Transformation.
+func (o *Overlay) Apply(root *yaml.Node) *yaml.Node {
+	root = compiler.CopyNode(root)
+	document := root
+	if document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		document = document.Content[0]
+	}
+	for _, action := range o.Actions {
+		matches := action.path.Find(document)
+		if action.Remove {
+			remove(matches)
+		} else if action.Update != nil {
+			for _, m := range matches {
+				update(m, action.Update)
+			}
+		}
+	}
+	return root
+}
+
+func update(m *jsonpath.Match, value *yaml.Node) {
+	switch {
+	case m.Node.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+		merge(m.Node, value)
+	case m.Node.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+		for _, item := range value.Content {
+			m.Node.Content = append(m.Node.Content, compiler.CopyNode(item))
+		}
+	case m.Node.Kind == yaml.SequenceNode:
+		m.Node.Content = append(m.Node.Content, compiler.CopyNode(value))
+	case m.Parent != nil:
+		m.Parent.Content[m.Index] = compiler.CopyNode(value)
+	}
+}
+
+// merge merges the entries of a mapping into another mapping.
+func merge(target, value *yaml.Node) {
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key := value.Content[i].Value
+		found := false
+		for j := 0; j+1 < len(target.Content); j += 2 {
+			if target.Content[j].Value == key {
+				update(&jsonpath.Match{Node: target.Content[j+1], Parent: target, Index: j + 1}, value.Content[i+1])
+				found = true
+				break
+			}
+		}
+		if !found {
+			target.Content = append(target.Content, compiler.CopyNode(value.Content[i]), compiler.CopyNode(value.Content[i+1]))
+		}
+	}
+}
+
+// remove removes selected nodes from their parents.
+func remove(matches []*jsonpath.Match) {
+	removed := make(map[*yaml.Node]bool)
+	isParent := make(map[*yaml.Node]bool)
+	var parents []*yaml.Node
+	for _, m := range matches {
+		if m.Parent == nil {
+			continue
+		}
+		if !isParent[m.Parent] {
+			isParent[m.Parent] = true
+			parents = append(parents, m.Parent)
+		}
+		removed[m.Node] = true
+	}
+	for _, parent := range parents {
+		content := make([]*yaml.Node, 0, len(parent.Content))
+		switch parent.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(parent.Content); i += 2 {
+				if !removed[parent.Content[i+1]] {
+					content = append(content, parent.Content[i], parent.Content[i+1])
+				}
+			}
+		case yaml.SequenceNode:
+			for _, item := range parent.Content {
+				if !removed[item] {
+					content = append(content, item)
+				}
+			}
+		}
+		parent.Content = content
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const document = `
+openapi: 3.0.0
+tags: [a, b]
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+    post:
+      operationId: createPet
+  /pets/{id}:
+    get:
+      operationId: getPet
+`
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(text)), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return &node
+}
+
+func TestApply(t *testing.T) {
+	o, err := ParseOverlay([]byte(`
+overlay: 1.0.0
+info:
+  title: test
+  version: 1.0.0
+actions:
+  - target: $.paths['/pets'].post
+    remove: true
+  - target: $.tags
+    update: [c]
+  - target: $..parameters[?(@.name == 'limit')]
+    update:
+      in: header
+      required: true
+  - target: $.openapi
+    update: 3.0.3
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	root := parse(t, document)
+	result := o.Apply(root)
+	expected, _ := yaml.Marshal(parse(t, `
+openapi: 3.0.3
+tags: [a, b, c]
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: header
+          required: true
+        - name: offset
+          in: query
+  /pets/{id}:
+    get:
+      operationId: getPet
+`))
+	actual, _ := yaml.Marshal(result)
+	if string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+	original, _ := yaml.Marshal(root)
+	if unchanged, _ := yaml.Marshal(parse(t, document)); string(original) != string(unchanged) {
+		t.Errorf("Apply modified its argument")
+	}
+}
+
+func TestParseOverlayErrors(t *testing.T) {
+	for _, test := range []struct {
+		text string
+		err  string
+	}{
+		{"info: {}", "missing the overlay version"},
+		{"overlay: 2.0.0\nactions: [{target: $}]", "unsupported overlay version 2.0.0"},
+		{"overlay: 1.0.0", "overlay has no actions"},
+		{"overlay: 1.0.0\nactions: [{remove: true}]", "actions[0] has no target"},
+		{"overlay: 1.0.0\nactions: [{target: paths}]", `actions[0] has an invalid target "paths"`},
+	} {
+		_, err := ParseOverlay([]byte(test.text))
+		if err == nil {
+			t.Errorf("%q: expected error", test.text)
+		} else if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%q: unexpected error %q (expected %q)", test.text, err.Error(), test.err)
+		}
+	}
+}
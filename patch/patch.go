@@ -0,0 +1,429 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package patch applies JSON Patch (RFC 6902) and JSON Merge Patch
+// (RFC 7396) documents to API descriptions.
+//
+// A JSON Patch is a list of operations that add, remove, replace, move,
+// copy, or test values at locations given by JSON pointers. A merge patch
+// is an object that is merged into a description, where null values
+// remove members. Both are simpler alternatives to overlays for small
+// changes that are known in advance.
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Patch is a parsed JSON Patch or JSON Merge Patch.
+type Patch struct {
+	// Operations are the operations of a JSON Patch.
+	Operations []*Operation
+	// Merge is the object of a JSON Merge Patch. It is nil for JSON Patches.
+	Merge *yaml.Node
+}
+
+// Operation is a JSON Patch operation.
+type Operation struct {
+	// Op is one of "add", "remove", "replace", "move", "copy", or "test".
+	Op   string
+	Path string
+	// From is the source location of "move" and "copy" operations.
+	From string
+	// Value is the value of "add", "replace", and "test" operations.
+	Value *yaml.Node
+
+	path []string
+	from []string
+}
+
+// ReadPatch reads a patch from a file or URL.
+func ReadPatch(filename string) (*Patch, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ParsePatch(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return p, nil
+}
+
+// ParsePatch reads a patch from a YAML/JSON representation. Arrays are
+// read as JSON Patches and objects are read as JSON Merge Patches.
+func ParsePatch(b []byte) (*Patch, error) {
+	info, err := compiler.ReadInfoFromBytes("", b)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Content) < 1 {
+		return nil, errors.New("patch is empty")
+	}
+	root := info.Content[0]
+	switch root.Kind {
+	case yaml.MappingNode:
+		return &Patch{Merge: root}, nil
+	case yaml.SequenceNode:
+		p := &Patch{}
+		for i, node := range root.Content {
+			operation, err := parseOperation(node)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %s", i, err)
+			}
+			p.Operations = append(p.Operations, operation)
+		}
+		return p, nil
+	default:
+		return nil, errors.New("patch must be an array of operations or a merge patch object")
+	}
+}
+
+func parseOperation(node *yaml.Node) (*Operation, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, errors.New("operation must be an object")
+	}
+	operation := &Operation{}
+	var ok bool
+	if operation.Op, ok = compiler.StringForScalarNode(compiler.MapValueForKey(node, "op")); !ok {
+		return nil, errors.New("operation has no op")
+	}
+	if operation.Path, ok = compiler.StringForScalarNode(compiler.MapValueForKey(node, "path")); !ok {
+		return nil, errors.New("operation has no path")
+	}
+	var err error
+	if operation.path, err = jsonpointer.Parse(operation.Path); err != nil {
+		return nil, fmt.Errorf("invalid path: %s", err)
+	}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value = compiler.MapValueForKey(node, "value"); operation.Value == nil {
+			return nil, fmt.Errorf("%s operation has no value", operation.Op)
+		}
+	case "move", "copy":
+		if operation.From, ok = compiler.StringForScalarNode(compiler.MapValueForKey(node, "from")); !ok {
+			return nil, fmt.Errorf("%s operation has no from", operation.Op)
+		}
+		if operation.from, err = jsonpointer.Parse(operation.From); err != nil {
+			return nil, fmt.Errorf("invalid from: %s", err)
+		}
+	case "remove":
+	default:
+		return nil, fmt.Errorf("unknown op %q", operation.Op)
+	}
+	return operation, nil
+}
+
+// Apply applies a patch to a copy of an API description and returns the
+// copy. The operations of a JSON Patch are applied in order, and if any of
+// them fails, the patch is not applied and an error is returned.
+func (p *Patch) Apply(root *yaml.Node) (*yaml.Node, error) {
+	root = compiler.CopyNode(root)
+	document := root
+	if document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		document = document.Content[0]
+	}
+	if p.Merge != nil {
+		*document = *mergePatch(document, p.Merge)
+		return root, nil
+	}
+	for i, operation := range p.Operations {
+		var err error
+		if document, err = operation.apply(document); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %s", i, operation.Op, operation.Path, err)
+		}
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root.Content[0] = document
+		return root, nil
+	}
+	return document, nil
+}
+
+// ApplyV2 applies a patch to an OpenAPI v2 document. The result is
+// compiled again, so patches that make the document invalid are errors.
+func (p *Patch) ApplyV2(document *openapi_v2.Document) (*openapi_v2.Document, error) {
+	root, err := p.Apply(document.ToRawInfo())
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+// ApplyV3 applies a patch to an OpenAPI v3 document. The result is
+// compiled again, so patches that make the document invalid are errors.
+func (p *Patch) ApplyV3(document *openapi_v3.Document) (*openapi_v3.Document, error) {
+	root, err := p.Apply(document.ToRawInfo())
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+// apply applies an operation to a document and returns the document,
+// which is replaced by operations on the whole document.
+func (o *Operation) apply(document *yaml.Node) (*yaml.Node, error) {
+	switch o.Op {
+	case "add":
+		return add(document, o.path, compiler.CopyNode(o.Value))
+	case "remove":
+		if _, err := remove(document, o.path); err != nil {
+			return nil, err
+		}
+		return document, nil
+	case "replace":
+		return replace(document, o.path, compiler.CopyNode(o.Value))
+	case "move":
+		if isPrefix(o.from, o.path) && len(o.from) < len(o.path) {
+			return nil, errors.New("a value can't be moved into one of its children")
+		}
+		value, err := remove(document, o.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, o.path, value)
+	case "copy":
+		value, err := jsonpointer.ResolveTokens(document, o.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, o.path, compiler.CopyNode(value))
+	case "test":
+		value, err := jsonpointer.ResolveTokens(document, o.path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, o.Value) {
+			return nil, errors.New("test failed")
+		}
+		return document, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", o.Op)
+}
+
+// add adds a value to the object or array that contains a location.
+// Existing members of objects are replaced, and values are inserted into
+// arrays before the specified index or appended if the index is "-".
+func add(document *yaml.Node, tokens []string, value *yaml.Node) (*yaml.Node, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, err := jsonpointer.ResolveTokens(document, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := tokens[len(tokens)-1]
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == token {
+				parent.Content[i+1] = value
+				return document, nil
+			}
+		}
+		parent.Content = append(parent.Content, compiler.NewScalarNodeForString(token), value)
+	case yaml.SequenceNode:
+		index := len(parent.Content)
+		if token != "-" {
+			if index, err = arrayIndex(tokens, len(parent.Content)); err != nil {
+				return nil, err
+			}
+		}
+		parent.Content = append(parent.Content, nil)
+		copy(parent.Content[index+1:], parent.Content[index:])
+		parent.Content[index] = value
+	default:
+		return nil, fmt.Errorf("%s is not an object or array", jsonpointer.Format(tokens[:len(tokens)-1]...))
+	}
+	return document, nil
+}
+
+// replace replaces the value at a location, which must exist.
+func replace(document *yaml.Node, tokens []string, value *yaml.Node) (*yaml.Node, error) {
+	if _, err := jsonpointer.ResolveTokens(document, tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, _ := jsonpointer.ResolveTokens(document, tokens[:len(tokens)-1])
+	token := tokens[len(tokens)-1]
+	if parent.Kind == yaml.SequenceNode {
+		index, err := arrayIndex(tokens, len(parent.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		parent.Content[index] = value
+		return document, nil
+	}
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == token {
+			parent.Content[i+1] = value
+		}
+	}
+	return document, nil
+}
+
+// remove removes the value at a location and returns it.
+func remove(document *yaml.Node, tokens []string) (*yaml.Node, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("the whole document can't be removed")
+	}
+	parent, err := jsonpointer.ResolveTokens(document, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := tokens[len(tokens)-1]
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == token {
+				value := parent.Content[i+1]
+				parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+				return value, nil
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := arrayIndex(tokens, len(parent.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		value := parent.Content[index]
+		parent.Content = append(parent.Content[:index], parent.Content[index+1:]...)
+		return value, nil
+	}
+	return nil, fmt.Errorf("%s not found", jsonpointer.Format(tokens...))
+}
+
+// arrayIndex returns the array index that is the last token of a
+// location, which must not be greater than max.
+func arrayIndex(tokens []string, max int) (int, error) {
+	token := tokens[len(tokens)-1]
+	// RFC 6901 only allows decimal numbers without leading zeros.
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index > max {
+		return 0, fmt.Errorf("%s not found", jsonpointer.Format(tokens...))
+	}
+	return index, nil
+}
+
+func isPrefix(prefix, tokens []string) bool {
+	if len(prefix) > len(tokens) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != tokens[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mergePatch applies a JSON Merge Patch to a value and returns the result.
+func mergePatch(target, patch *yaml.Node) *yaml.Node {
+	if patch.Kind != yaml.MappingNode {
+		return compiler.CopyNode(patch)
+	}
+	if target == nil || target.Kind != yaml.MappingNode {
+		target = compiler.NewMappingNode()
+	}
+	for i := 0; i+1 < len(patch.Content); i += 2 {
+		key, value := patch.Content[i].Value, patch.Content[i+1]
+		index := -1
+		for j := 0; j+1 < len(target.Content); j += 2 {
+			if target.Content[j].Value == key {
+				index = j
+				break
+			}
+		}
+		switch {
+		case isNull(value) && index >= 0:
+			target.Content = append(target.Content[:index], target.Content[index+2:]...)
+		case isNull(value):
+		case index >= 0:
+			target.Content[index+1] = mergePatch(target.Content[index+1], value)
+		default:
+			target.Content = append(target.Content, compiler.NewScalarNodeForString(key), mergePatch(nil, value))
+		}
+	}
+	return target
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// equal returns true if two nodes represent the same JSON value.
+// Members of objects can be in any order, and numbers are compared
+// by their values.
+func equal(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case yaml.MappingNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := 0; i+1 < len(a.Content); i += 2 {
+			value := compiler.MapValueForKey(b, a.Content[i].Value)
+			if value == nil || !equal(a.Content[i+1], value) {
+				return false
+			}
+		}
+		return true
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !equal(a.Content[i], b.Content[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		var x, y interface{}
+		if a.Decode(&x) != nil || b.Decode(&y) != nil {
+			return false
+		}
+		return reflect.DeepEqual(number(x), number(y))
+	}
+}
+
+// number converts numbers to float64 so that they can be compared.
+func number(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	}
+	return v
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(text)), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return &node
+}
+
+func format(t *testing.T, node *yaml.Node) string {
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return string(b)
+}
+
+func TestJSONPatch(t *testing.T) {
+	// Most of these are the examples in Appendix A of RFC 6902.
+	for _, test := range []struct {
+		document string
+		patch    string
+		result   string
+		err      string
+	}{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`, ""},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`, ""},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`, ""},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`, ""},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`, ""},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`, ""},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`, ""},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`, ""},
+		{`{"foo": {"bar": 1}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}]`, `{"foo": {"bar": 1}, "baz": {"bar": 1}}`, ""},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`, ""},
+		{`{"foo": {"a": 1, "b": 2}}`, `[{"op": "test", "path": "/foo", "value": {"b": 2, "a": 1}}]`, `{"foo": {"a": 1, "b": 2}}`, ""},
+		{`{"foo": "bar"}`, `[{"op": "replace", "path": "", "value": {"baz": 1}}]`, `{"baz": 1}`, ""},
+		{`{"~1": {"a/b": 1}}`, `[{"op": "remove", "path": "/~01/a~1b"}]`, `{"~1": {}}`, ""},
+		// errors
+		{`{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`, "", "operation 0 (test /baz): test failed"},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, "", "operation 0 (add /baz/bat): /baz not found"},
+		{`{"foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "qux"}]`, "", "operation 0 (replace /baz): /baz not found"},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": "qux"}]`, "", "operation 0 (add /foo/2): /foo/2 not found"},
+		{`{"foo": ["bar"]}`, `[{"op": "remove", "path": "/foo/01"}]`, "", "operation 0 (remove /foo/01): invalid array index \"01\""},
+		{`{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`, "", "operation 0 (move /foo/bar/baz): a value can't be moved into one of its children"},
+		// a failed operation leaves the document unchanged
+		{`{"foo": "bar"}`, `[{"op": "remove", "path": "/foo"}, {"op": "test", "path": "/foo", "value": "bar"}]`, "", "operation 1 (test /foo): /foo not found"},
+	} {
+		p, err := ParsePatch([]byte(test.patch))
+		if err != nil {
+			t.Errorf("%s: %s", test.patch, err)
+			continue
+		}
+		document := parse(t, test.document)
+		original := format(t, document)
+		result, err := p.Apply(document)
+		if format(t, document) != original {
+			t.Errorf("%s modified the document", test.patch)
+		}
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s returned error %v, expected %q", test.patch, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", test.patch, err)
+			continue
+		}
+		if !equal(result.Content[0], parse(t, test.result).Content[0]) {
+			t.Errorf("%s returned %s, expected %s", test.patch, format(t, result), test.result)
+		}
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// These are the examples in Appendix A of RFC 7396.
+	for _, test := range []struct {
+		document string
+		patch    string
+		result   string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	} {
+		p, err := ParsePatch([]byte(test.patch))
+		if err != nil {
+			t.Errorf("%s: %s", test.patch, err)
+			continue
+		}
+		result, err := p.Apply(parse(t, test.document))
+		if err != nil {
+			t.Errorf("%s: %s", test.patch, err)
+			continue
+		}
+		if !equal(result.Content[0], parse(t, test.result).Content[0]) {
+			t.Errorf("%s returned %s, expected %s", test.patch, format(t, result), test.result)
+		}
+	}
+}
+
+func TestParsePatchErrors(t *testing.T) {
+	for _, test := range []struct {
+		patch string
+		err   string
+	}{
+		{`"patch"`, "patch must be an array of operations or a merge patch object"},
+		{`[1]`, "operation 0: operation must be an object"},
+		{`[{"path": "/a"}]`, "operation 0: operation has no op"},
+		{`[{"op": "add", "value": 1}]`, "operation 0: operation has no path"},
+		{`[{"op": "add", "path": "a", "value": 1}]`, "operation 0: invalid path: " + pointerError("a")},
+		{`[{"op": "add", "path": "/a"}]`, "operation 0: add operation has no value"},
+		{`[{"op": "copy", "path": "/a"}]`, "operation 0: copy operation has no from"},
+		{`[{"op": "update", "path": "/a"}]`, "operation 0: unknown op \"update\""},
+	} {
+		_, err := ParsePatch([]byte(test.patch))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s returned error %v, expected %q", test.patch, err, test.err)
+		}
+	}
+}
+
+func pointerError(pointer string) string {
+	_, err := jsonpointer.Parse(pointer)
+	return err.Error()
+}
+
+func TestApplyV3(t *testing.T) {
+	document, err := openapi_v3.ParseDocument([]byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	p, err := ParsePatch([]byte(`[{"op": "replace", "path": "/info/title", "value": "Pet Store"}]`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	patched, err := p.ApplyV3(document)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if patched.Info.Title != "Pet Store" || document.Info.Title != "Pets" {
+		t.Errorf("title is %q, expected %q", patched.Info.Title, "Pet Store")
+	}
+	// Patches that make the document invalid are errors.
+	p, err = ParsePatch([]byte(`{"info": null}`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, err = p.ApplyV3(document); err == nil {
+		t.Errorf("patch that removes the info object was applied")
+	}
+}
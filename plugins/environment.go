@@ -1,6 +1,7 @@
 package gnostic_plugin_v1
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
 
+	"github.com/google/gnostic/compiler"
 	discovery "github.com/google/gnostic/discovery"
 	openapiv2 "github.com/google/gnostic/openapiv2"
 	openapiv3 "github.com/google/gnostic/openapiv3"
@@ -55,7 +57,9 @@ When it is run from gnostic, the -plugin option is specified and gnostic
 writes a binary request to stdin and waits for a binary response on stdout.
 
 This program can also be run standalone using the other flags listed below.
-When the -plugin option is specified, these flags are ignored.`)
+When the -plugin option is specified, these flags are ignored. More API
+descriptions can be given as arguments after the flags to run the plugin on
+several documents at once.`)
 			fmt.Fprintf(os.Stderr, "\n\nUsage:\n")
 			flag.PrintDefaults()
 		}
@@ -91,55 +95,76 @@ When the -plugin option is specified, these flags are ignored.`)
 
 	} else {
 		// Handle invocation from the command line.
-
-		// Read the input document.
-		apiData, err := ioutil.ReadFile(*input)
-		if len(apiData) == 0 {
-			env.RespondAndExitIfError(fmt.Errorf("no input data"))
-		}
-
+		// Arguments after the flags name more input documents.
+		inputs := append([]string{*input}, flag.Args()...)
 		env.Request = &Request{}
 		env.Request.OutputPath = *output
-		env.Request.SourceName = path.Base(*input)
-
-		// First try to unmarshal OpenAPI v2.
-		documentv2 := &openapiv2.Document{}
-		err = proto.Unmarshal(apiData, documentv2)
-		if err == nil {
-			env.Request.AddModel("openapi.v2.Document", documentv2)
-			sourceName := guessSourceName(*input)
-			// include experimental API surface model
-			surfaceModel, err := surface.NewModelFromOpenAPI2(documentv2, sourceName)
-			if err == nil {
-				env.Request.AddModel("surface.v1.Model", surfaceModel)
+		for _, input := range inputs {
+			var document *Document
+			document, err = env.readDocument(input)
+			if document != nil {
+				env.Request.Documents = append(env.Request.Documents, document)
+			}
+			if err != nil {
+				break
 			}
-			return env, err
 		}
-		// If that failed, ignore deserialization errors and try to unmarshal OpenAPI v3.
-		documentv3 := &openapiv3.Document{}
-		err = proto.Unmarshal(apiData, documentv3)
+		// The first document is also described by the fields of the request.
+		if len(env.Request.Documents) > 0 {
+			env.Request.SourceName = env.Request.Documents[0].SourceName
+			env.Request.Models = env.Request.Documents[0].Models
+		}
+		if len(inputs) == 1 {
+			env.Request.Documents = nil
+		}
+	}
+	return env, err
+}
+
+// Read a document in binary protocol buffer form from a file.
+func (env *Environment) readDocument(input string) (*Document, error) {
+	apiData, err := ioutil.ReadFile(input)
+	if len(apiData) == 0 {
+		env.RespondAndExitIfError(fmt.Errorf("no input data"))
+	}
+
+	document := &Document{SourceName: path.Base(input)}
+
+	// First try to unmarshal OpenAPI v2.
+	documentv2 := &openapiv2.Document{}
+	err = proto.Unmarshal(apiData, documentv2)
+	if err == nil {
+		document.AddModel("openapi.v2.Document", documentv2)
+		sourceName := guessSourceName(input)
+		// include experimental API surface model
+		surfaceModel, err := surface.NewModelFromOpenAPI2(documentv2, sourceName)
 		if err == nil {
-			env.Request.AddModel("openapi.v3.Document", documentv3)
-			sourceName := guessSourceName(*input)
-			// include experimental API surface model
-			surfaceModel, err := surface.NewModelFromOpenAPI3(documentv3, sourceName)
-			if err == nil {
-				env.Request.AddModel("surface.v1.Model", surfaceModel)
-			}
-			return env, err
+			document.AddModel("surface.v1.Model", surfaceModel)
 		}
-		// If that failed, ignore deserialization errors and try to unmarshal a Discovery document.
-		discoveryDocument := &discovery.Document{}
-		err = proto.Unmarshal(apiData, discoveryDocument)
+		return document, err
+	}
+	// If that failed, ignore deserialization errors and try to unmarshal OpenAPI v3.
+	documentv3 := &openapiv3.Document{}
+	err = proto.Unmarshal(apiData, documentv3)
+	if err == nil {
+		document.AddModel("openapi.v3.Document", documentv3)
+		sourceName := guessSourceName(input)
+		// include experimental API surface model
+		surfaceModel, err := surface.NewModelFromOpenAPI3(documentv3, sourceName)
 		if err == nil {
-			env.Request.AddModel("discovery.v1.Document", discoveryDocument)
-			return env, err
+			document.AddModel("surface.v1.Model", surfaceModel)
 		}
-		// If we get here, we don't know what we got
-		err = errors.New("Unrecognized format for input")
-		return env, err
+		return document, err
 	}
-	return env, err
+	// If that failed, ignore deserialization errors and try to unmarshal a Discovery document.
+	discoveryDocument := &discovery.Document{}
+	err = proto.Unmarshal(apiData, discoveryDocument)
+	if err == nil {
+		document.AddModel("discovery.v1.Document", discoveryDocument)
+		return document, err
+	}
+	// If we get here, we don't know what we got
+	return nil, errors.New("Unrecognized format for input")
 }
 
 // RespondAndExitIfError checks an error and if it is non-nil, records it and serializes and returns the response and then exits.
@@ -177,6 +202,9 @@ func HandleResponse(response *Response, outputLocation string) error {
 	case outputLocation == "-":
 		writer = os.Stdout
 		for _, file := range response.Files {
+			if file.Directory {
+				continue
+			}
 			writer.Write([]byte("\n\n" + file.Name + " -------------------- \n"))
 			writer.Write(file.Data)
 		}
@@ -187,23 +215,106 @@ func HandleResponse(response *Response, outputLocation string) error {
 			os.Mkdir(outputLocation, 0755)
 		}
 		for _, file := range response.Files {
-			p := outputLocation + "/" + file.Name
-			dir := path.Dir(p)
-			os.MkdirAll(dir, 0755)
-			f, _ := os.Create(p)
-			defer f.Close()
-			f.Write(file.Data)
+			err := writeResponseFile(outputLocation+"/"+file.Name, file)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// Write a file of a response, or create it if it is a directory.
+func writeResponseFile(p string, file *File) error {
+	if file.Directory {
+		return os.MkdirAll(p, 0755)
+	}
+	os.MkdirAll(path.Dir(p), 0755)
+	if file.SkipIfUnchanged {
+		data, err := ioutil.ReadFile(p)
+		if err == nil && bytes.Equal(data, file.Data) {
+			return nil
+		}
+	}
+	if err := ioutil.WriteFile(p, file.Data, 0644); err != nil {
+		return err
+	}
+	if file.Executable {
+		// WriteFile doesn't change the permissions of existing files.
+		return os.Chmod(p, 0755)
+	}
+	return nil
+}
+
 func (request *Request) AddModel(modelType string, model proto.Message) error {
 	modelBytes, err := proto.Marshal(model)
 	request.Models = append(request.Models, &any.Any{TypeUrl: modelType, Value: modelBytes})
 	return err
 }
 
+// AddSourceLocations adds the positions of the nodes of the source document to a request.
+func (request *Request) AddSourceLocations(index *compiler.LocationIndex) {
+	request.SourceLocations = append(request.SourceLocations, sourceLocations(index)...)
+}
+
+// LocationIndex returns an index of the source locations in a request.
+// The index is empty if the request has no source locations.
+func (request *Request) LocationIndex() *compiler.LocationIndex {
+	return locationIndex(request.SourceLocations)
+}
+
+// AllDocuments returns the documents of a request. Requests for a single
+// document return one document made from their source name, models, and
+// source locations.
+func (request *Request) AllDocuments() []*Document {
+	if len(request.Documents) > 0 {
+		return request.Documents
+	}
+	return []*Document{{
+		SourceName:      request.SourceName,
+		Models:          request.Models,
+		SourceLocations: request.SourceLocations,
+	}}
+}
+
+func (document *Document) AddModel(modelType string, model proto.Message) error {
+	modelBytes, err := proto.Marshal(model)
+	document.Models = append(document.Models, &any.Any{TypeUrl: modelType, Value: modelBytes})
+	return err
+}
+
+// AddSourceLocations adds the positions of the nodes of the source document to a document.
+func (document *Document) AddSourceLocations(index *compiler.LocationIndex) {
+	document.SourceLocations = append(document.SourceLocations, sourceLocations(index)...)
+}
+
+// LocationIndex returns an index of the source locations in a document.
+// The index is empty if the document has no source locations.
+func (document *Document) LocationIndex() *compiler.LocationIndex {
+	return locationIndex(document.SourceLocations)
+}
+
+func sourceLocations(index *compiler.LocationIndex) []*SourceLocation {
+	locations := make([]*SourceLocation, 0)
+	for _, pointer := range index.Pointers() {
+		location, _ := index.Location(pointer)
+		locations = append(locations, &SourceLocation{
+			Pointer: pointer,
+			Line:    int32(location.Line),
+			Column:  int32(location.Column),
+		})
+	}
+	return locations
+}
+
+func locationIndex(locations []*SourceLocation) *compiler.LocationIndex {
+	index := compiler.NewLocationIndex(nil)
+	for _, location := range locations {
+		index.Set(location.Pointer, compiler.Location{Line: int(location.Line), Column: int(location.Column)})
+	}
+	return index
+}
+
 func isFile(path string) bool {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
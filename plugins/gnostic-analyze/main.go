@@ -21,6 +21,11 @@
 //  - The parameter types used and their frequencies.
 //  - The response types used and their frequencies.
 //  - The types used in definition objects and arrays and their frequencies.
+// For OpenAPI v3 descriptions, it also counts
+//  - The content types used in requests and responses.
+//  - The number of components of each kind.
+//  - The types of security schemes.
+//  - The number of callbacks and links.
 // Results are returned in a JSON structure.
 package main
 
@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+// CorpusStatistics aggregates the statistics of a collection of API descriptions.
+type CorpusStatistics struct {
+	APIs                        int            `json:"apis"`
+	Versions                    map[string]int `json:"versions"`
+	APIsWithAnonymousOperations int            `json:"apisWithAnonymousOperations"`
+	APIsWithAnonymousObjects    int            `json:"apisWithAnonymousObjects"`
+	APIsWithAnonymousAnything   int            `json:"apisWithAnonymousAnything"`
+	Operations                  map[string]int `json:"operations"`
+	DefinitionCount             int            `json:"definitions"`
+	ParameterTypes              map[string]int `json:"parameterTypes"`
+	ResultTypes                 map[string]int `json:"resultTypes"`
+	DefinitionFieldTypes        map[string]int `json:"definitionFieldTypes"`
+	DefinitionArrayTypes        map[string]int `json:"definitionArrayTypes"`
+	DefinitionPrimitiveTypes    map[string]int `json:"definitionPrimitiveTypes"`
+	ContentTypes                map[string]int `json:"contentTypes"`
+	Components                  map[string]int `json:"components"`
+	SecuritySchemeTypes         map[string]int `json:"securitySchemeTypes"`
+	CallbackCount               int            `json:"callbacks"`
+	LinkCount                   int            `json:"links"`
+}
+
+// NewCorpusStatistics aggregates the statistics of API descriptions. Counts
+// are summed over all descriptions, and versions are counted per description.
+func NewCorpusStatistics(documents []DocumentStatistics) *CorpusStatistics {
+	c := &CorpusStatistics{
+		Versions:                 make(map[string]int, 0),
+		Operations:               make(map[string]int, 0),
+		ParameterTypes:           make(map[string]int, 0),
+		ResultTypes:              make(map[string]int, 0),
+		DefinitionFieldTypes:     make(map[string]int, 0),
+		DefinitionArrayTypes:     make(map[string]int, 0),
+		DefinitionPrimitiveTypes: make(map[string]int, 0),
+		ContentTypes:             make(map[string]int, 0),
+		Components:               make(map[string]int, 0),
+		SecuritySchemeTypes:      make(map[string]int, 0),
+	}
+	for _, api := range documents {
+		c.APIs++
+		if api.Version != "" {
+			c.Versions[api.Version]++
+		}
+		if api.Operations["anonymous"] != 0 {
+			c.APIsWithAnonymousOperations++
+		}
+		if len(api.AnonymousObjects) > 0 {
+			c.APIsWithAnonymousObjects++
+		}
+		if len(api.AnonymousOperations) > 0 || len(api.AnonymousObjects) > 0 {
+			c.APIsWithAnonymousAnything++
+		}
+		c.DefinitionCount += api.DefinitionCount
+		c.CallbackCount += api.CallbackCount
+		c.LinkCount += api.LinkCount
+		addCounts(c.Operations, api.Operations)
+		addCounts(c.ParameterTypes, api.ParameterTypes)
+		addCounts(c.ResultTypes, api.ResultTypes)
+		addCounts(c.DefinitionFieldTypes, api.DefinitionFieldTypes)
+		addCounts(c.DefinitionArrayTypes, api.DefinitionArrayTypes)
+		addCounts(c.DefinitionPrimitiveTypes, api.DefinitionPrimitiveTypes)
+		addCounts(c.ContentTypes, api.ContentTypes)
+		addCounts(c.Components, api.Components)
+		addCounts(c.SecuritySchemeTypes, api.SecuritySchemeTypes)
+	}
+	return c
+}
+
+func addCounts(total map[string]int, counts map[string]int) {
+	for k, v := range counts {
+		total[k] += v
+	}
+}
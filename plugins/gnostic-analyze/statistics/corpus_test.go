@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestCorpusStatistics(t *testing.T) {
+	bytes, err := os.ReadFile("../../../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	documentv2, err := openapiv2.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err = os.ReadFile("../../../examples/v3.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	documentv3, err := openapiv3.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	s2 := NewDocumentStatistics("v2.0/petstore.yaml", documentv2)
+	s3 := NewDocumentStatisticsV3("v3.0/petstore.yaml", documentv3)
+	c := NewCorpusStatistics([]DocumentStatistics{*s2, *s3})
+
+	if c.APIs != 2 {
+		t.Errorf("unexpected number of APIs: %d", c.APIs)
+	}
+	if expected := map[string]int{s2.Version: 1, s3.Version: 1}; !reflect.DeepEqual(c.Versions, expected) {
+		t.Errorf("unexpected versions %v, expected %v", c.Versions, expected)
+	}
+	for method, count := range c.Operations {
+		if count != s2.Operations[method]+s3.Operations[method] {
+			t.Errorf("unexpected number of %s operations: %d", method, count)
+		}
+	}
+	if c.DefinitionCount != s2.DefinitionCount+s3.DefinitionCount {
+		t.Errorf("unexpected number of definitions: %d", c.DefinitionCount)
+	}
+	if len(c.Components) == 0 || !reflect.DeepEqual(c.Components, s3.Components) {
+		t.Errorf("unexpected components %v, expected %v", c.Components, s3.Components)
+	}
+}
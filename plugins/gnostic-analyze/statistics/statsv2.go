@@ -25,6 +25,7 @@ import (
 type DocumentStatistics struct {
 	Name                     string         `json:"name"`
 	Title                    string         `json:"title"`
+	Version                  string         `json:"version,omitempty"`
 	Operations               map[string]int `json:"operations"`
 	DefinitionCount          int            `json:"definitions"`
 	ParameterTypes           map[string]int `json:"parameterTypes"`
@@ -34,6 +35,11 @@ type DocumentStatistics struct {
 	DefinitionPrimitiveTypes map[string]int `json:"definitionPrimitiveTypes"`
 	AnonymousOperations      []string       `json:"anonymousOperations"`
 	AnonymousObjects         []string       `json:"anonymousObjects"`
+	ContentTypes             map[string]int `json:"contentTypes,omitempty"`
+	Components               map[string]int `json:"components,omitempty"`
+	SecuritySchemeTypes      map[string]int `json:"securitySchemeTypes,omitempty"`
+	CallbackCount            int            `json:"callbacks,omitempty"`
+	LinkCount                int            `json:"links,omitempty"`
 }
 
 // NewDocumentStatistics builds a new DocumentStatistics object.
@@ -207,6 +213,7 @@ func (s *DocumentStatistics) analyzeDefinition(path string, definition *openapi.
 // This should be called exactly once per DocumentStatistics object.
 func (s *DocumentStatistics) analyzeDocument(source string, document *openapi.Document) {
 	s.Name = source
+	s.Version = document.Swagger
 
 	s.Title = document.Info.Title
 	for _, pair := range document.Paths.Path {
@@ -15,10 +15,12 @@
 package statistics
 
 import (
+	"strings"
+
 	openapi "github.com/google/gnostic/openapiv3"
 )
 
-// NewDocumentStatistics builds a new DocumentStatistics object.
+// NewDocumentStatisticsV3 builds a new DocumentStatistics object for an OpenAPI v3 document.
 func NewDocumentStatisticsV3(source string, document *openapi.Document) *DocumentStatistics {
 	s := &DocumentStatistics{}
 	s.Operations = make(map[string]int, 0)
@@ -29,12 +31,54 @@ func NewDocumentStatisticsV3(source string, document *openapi.Document) *Documen
 	s.DefinitionPrimitiveTypes = make(map[string]int, 0)
 	s.AnonymousOperations = make([]string, 0)
 	s.AnonymousObjects = make([]string, 0)
-	// TODO
-	//s.analyzeDocumentV3(source, document)
+	s.ContentTypes = make(map[string]int, 0)
+	s.Components = make(map[string]int, 0)
+	s.SecuritySchemeTypes = make(map[string]int, 0)
+	s.analyzeDocumentV3(source, document)
 	return s
 }
 
-/*
+func (s *DocumentStatistics) addContentType(name string) {
+	s.ContentTypes[name] = s.ContentTypes[name] + 1
+}
+
+func (s *DocumentStatistics) addComponents(kind string, count int) {
+	if count > 0 {
+		s.Components[kind] = s.Components[kind] + count
+	}
+}
+
+func (s *DocumentStatistics) addSecuritySchemeType(name string) {
+	s.SecuritySchemeTypes[name] = s.SecuritySchemeTypes[name] + 1
+}
+
+func (s *DocumentStatistics) analyzePathItemV3(path string, pathItem *openapi.PathItem) {
+	if pathItem.Get != nil {
+		s.analyzeOperationV3("get", path+"/get", pathItem.Get)
+	}
+	if pathItem.Put != nil {
+		s.analyzeOperationV3("put", path+"/put", pathItem.Put)
+	}
+	if pathItem.Post != nil {
+		s.analyzeOperationV3("post", path+"/post", pathItem.Post)
+	}
+	if pathItem.Delete != nil {
+		s.analyzeOperationV3("delete", path+"/delete", pathItem.Delete)
+	}
+	if pathItem.Options != nil {
+		s.analyzeOperationV3("options", path+"/options", pathItem.Options)
+	}
+	if pathItem.Head != nil {
+		s.analyzeOperationV3("head", path+"/head", pathItem.Head)
+	}
+	if pathItem.Patch != nil {
+		s.analyzeOperationV3("patch", path+"/patch", pathItem.Patch)
+	}
+	if pathItem.Trace != nil {
+		s.analyzeOperationV3("trace", path+"/trace", pathItem.Trace)
+	}
+}
+
 func (s *DocumentStatistics) analyzeOperationV3(method string, path string, operation *openapi.Operation) {
 	s.addOperation(method)
 	s.addOperation("total")
@@ -42,33 +86,100 @@ func (s *DocumentStatistics) analyzeOperationV3(method string, path string, oper
 		s.addOperation("anonymous")
 		s.AnonymousOperations = append(s.AnonymousOperations, path)
 	}
-	for _, parametersItem := range operation.Parameters {
-		p := parametersItem.GetParameter()
+	for _, parameter := range operation.Parameters {
+		p := parameter.GetParameter()
 		if p != nil {
-			typeName := typeNameForSchemaOrReferenceV3(p.Schema)
-			s.addParameterType(path+"/"+p.Name, typeName)
+			if p.Schema != nil {
+				s.addParameterType(path+"/"+p.Name, typeForSchemaOrReferenceV3(p.Schema))
+			}
+			s.analyzeContentV3(p.Content)
+		}
+		r := parameter.GetReference()
+		if r != nil {
+			s.addParameterType(path+"/"+referenceNameV3(r), "reference")
 		}
 	}
 
-	for _, pair := range *(operation.Responses.Responses) {
-		value := pair.Value
-		response := value.GetResponse()
-		if response != nil {
-			responseSchema := response.Schema
-			responseSchemaSchema := responseSchema.GetSchema()
-			if responseSchemaSchema != nil {
-				s.addResultType(path+"/responses/"+pair.Name, typeForSchema(responseSchemaSchema))
+	if operation.RequestBody != nil {
+		requestBody := operation.RequestBody.GetRequestBody()
+		if requestBody != nil && requestBody.Content != nil {
+			for _, pair := range requestBody.Content.AdditionalProperties {
+				s.addContentType(pair.Name)
+				if pair.Value.Schema != nil {
+					s.addParameterType(path+"/requestBody", typeForSchemaOrReferenceV3(pair.Value.Schema))
+				}
 			}
-			responseFileSchema := responseSchema.GetFileSchema()
-			if responseFileSchema != nil {
-				s.addResultType(path+"/responses/"+pair.Name, typeForFileSchema(responseFileSchema))
+		}
+		if operation.RequestBody.GetReference() != nil {
+			s.addParameterType(path+"/requestBody", "reference")
+		}
+	}
+
+	if operation.Responses != nil {
+		if operation.Responses.Default != nil {
+			s.analyzeResponseV3(path+"/responses/default", operation.Responses.Default)
+		}
+		for _, pair := range operation.Responses.ResponseOrReference {
+			s.analyzeResponseV3(path+"/responses/"+pair.Name, pair.Value)
+		}
+	}
+
+	if operation.Callbacks != nil {
+		for _, pair := range operation.Callbacks.AdditionalProperties {
+			s.CallbackCount++
+			callback := pair.Value.GetCallback()
+			if callback != nil {
+				for _, expression := range callback.Path {
+					s.analyzeCallbackPathItemV3(expression.Value)
+				}
 			}
 		}
-		ref := value.GetJsonReference()
-		if ref != nil {
+	}
+}
+
+// Callback operations describe requests sent by the API provider,
+// so only their content types are counted.
+func (s *DocumentStatistics) analyzeCallbackPathItemV3(pathItem *openapi.PathItem) {
+	for _, operation := range []*openapi.Operation{
+		pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete,
+		pathItem.Options, pathItem.Head, pathItem.Patch, pathItem.Trace,
+	} {
+		if operation == nil {
+			continue
+		}
+		if operation.RequestBody != nil && operation.RequestBody.GetRequestBody() != nil {
+			s.analyzeContentV3(operation.RequestBody.GetRequestBody().Content)
 		}
 	}
+}
 
+func (s *DocumentStatistics) analyzeResponseV3(path string, value *openapi.ResponseOrReference) {
+	response := value.GetResponse()
+	if response != nil {
+		if response.Content != nil {
+			for _, pair := range response.Content.AdditionalProperties {
+				s.addContentType(pair.Name)
+				if pair.Value.Schema != nil {
+					s.addResultType(path, typeForSchemaOrReferenceV3(pair.Value.Schema))
+				}
+			}
+		}
+		if response.Links != nil {
+			s.LinkCount += len(response.Links.AdditionalProperties)
+		}
+	}
+	if value.GetReference() != nil {
+		s.addResultType(path, "reference")
+	}
+}
+
+func (s *DocumentStatistics) analyzeContentV3(content *openapi.MediaTypes) {
+	if content == nil {
+		return
+	}
+	for _, pair := range content.AdditionalProperties {
+		s.addContentType(pair.Name)
+	}
 }
 
 // Analyze a definition in an OpenAPI description.
@@ -81,8 +192,7 @@ func (s *DocumentStatistics) analyzeDefinitionV3(path string, definition *openap
 	case "object":
 		if definition.Properties != nil {
 			for _, pair := range definition.Properties.AdditionalProperties {
-				propertySchema := pair.Value
-				propertyType := typeForSchemaV3(propertySchema)
+				propertyType := typeForSchemaOrReferenceV3(pair.Value)
 				s.addDefinitionFieldType(path+"/"+pair.Name, propertyType)
 			}
 		}
@@ -93,35 +203,151 @@ func (s *DocumentStatistics) analyzeDefinitionV3(path string, definition *openap
 	}
 }
 
+// Analyze the components of an OpenAPI description.
+// Count each kind of component, classify the security schemes and
+// analyze schemas the same way that v2 definitions are analyzed.
+func (s *DocumentStatistics) analyzeComponentsV3(components *openapi.Components) {
+	if components.Schemas != nil {
+		s.addComponents("schemas", len(components.Schemas.AdditionalProperties))
+		for _, pair := range components.Schemas.AdditionalProperties {
+			definition := pair.Value.GetSchema()
+			if definition != nil {
+				s.analyzeDefinitionV3("components/schemas/"+pair.Name, definition)
+			}
+		}
+	}
+	if components.Responses != nil {
+		s.addComponents("responses", len(components.Responses.AdditionalProperties))
+		for _, pair := range components.Responses.AdditionalProperties {
+			response := pair.Value.GetResponse()
+			if response != nil {
+				s.analyzeContentV3(response.Content)
+				if response.Links != nil {
+					s.LinkCount += len(response.Links.AdditionalProperties)
+				}
+			}
+		}
+	}
+	if components.Parameters != nil {
+		s.addComponents("parameters", len(components.Parameters.AdditionalProperties))
+	}
+	if components.Examples != nil {
+		s.addComponents("examples", len(components.Examples.AdditionalProperties))
+	}
+	if components.RequestBodies != nil {
+		s.addComponents("requestBodies", len(components.RequestBodies.AdditionalProperties))
+		for _, pair := range components.RequestBodies.AdditionalProperties {
+			requestBody := pair.Value.GetRequestBody()
+			if requestBody != nil {
+				s.analyzeContentV3(requestBody.Content)
+			}
+		}
+	}
+	if components.Headers != nil {
+		s.addComponents("headers", len(components.Headers.AdditionalProperties))
+	}
+	if components.SecuritySchemes != nil {
+		s.addComponents("securitySchemes", len(components.SecuritySchemes.AdditionalProperties))
+		for _, pair := range components.SecuritySchemes.AdditionalProperties {
+			securityScheme := pair.Value.GetSecurityScheme()
+			if securityScheme != nil {
+				s.addSecuritySchemeType(securityScheme.Type)
+			}
+		}
+	}
+	if components.Links != nil {
+		s.addComponents("links", len(components.Links.AdditionalProperties))
+		s.LinkCount += len(components.Links.AdditionalProperties)
+	}
+	if components.Callbacks != nil {
+		s.addComponents("callbacks", len(components.Callbacks.AdditionalProperties))
+		s.CallbackCount += len(components.Callbacks.AdditionalProperties)
+	}
+}
+
 // Analyze an OpenAPI description.
 // Collect information about types used in the API.
 // This should be called exactly once per DocumentStatistics object.
 func (s *DocumentStatistics) analyzeDocumentV3(source string, document *openapi.Document) {
 	s.Name = source
+	s.Version = document.Openapi
 
-	s.Title = document.Info.Title
-	for _, pair := range document.Paths.Path {
-		path := pair.Value
-		if path.Get != nil {
-			s.analyzeOperation("get", "paths"+pair.Name+"/get", path.Get)
-		}
-		if path.Post != nil {
-			s.analyzeOperation("post", "paths"+pair.Name+"/post", path.Post)
+	if document.Info != nil {
+		s.Title = document.Info.Title
+	}
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			s.analyzePathItemV3("paths"+pair.Name, pair.Value)
 		}
-		if path.Put != nil {
-			s.analyzeOperation("put", "paths"+pair.Name+"/put", path.Put)
+	}
+	if document.Components != nil {
+		s.analyzeComponentsV3(document.Components)
+	}
+}
+
+// helpers
+
+// Return the name of the component that a reference refers to.
+func referenceNameV3(reference *openapi.Reference) string {
+	return reference.XRef[strings.LastIndex(reference.XRef, "/")+1:]
+}
+
+func typeNameForSchemaV3(schema *openapi.Schema) string {
+	typeName := "object" // default type
+	if schema.Type != "" {
+		typeName = schema.Type
+	}
+	return typeName
+}
+
+// Return a type name to use for a schema or reference.
+func typeForSchemaOrReferenceV3(schemaOrReference *openapi.SchemaOrReference) string {
+	if schemaOrReference.GetReference() != nil {
+		return "reference"
+	}
+	if schema := schemaOrReference.GetSchema(); schema != nil {
+		return typeForSchemaV3(schema)
+	}
+	return "object"
+}
+
+// Return a type name to use for a schema.
+func typeForSchemaV3(schema *openapi.Schema) string {
+	if len(schema.Enum) > 0 {
+		enumType := typeNameForSchemaV3(schema)
+		return "enum-of-" + enumType
+	}
+	typeName := typeNameForSchemaV3(schema)
+	if typeName == "array" {
+		if schema.Items != nil {
+			// items contains an array of schemas
+			itemType := ""
+			for i, itemSchema := range schema.Items.SchemaOrReference {
+				if i > 0 {
+					itemType += "|"
+				}
+				itemType += typeForSchemaOrReferenceV3(itemSchema)
+			}
+			return "array-of-" + itemType
 		}
-		if path.Delete != nil {
-			s.analyzeOperation("delete", "paths"+pair.Name+"/delete", path.Delete)
+		return "array-of-object"
+	} else if typeName == "object" {
+		// this object might be representable with a map
+		// but not if it has properties
+		if (schema.Properties != nil) && (len(schema.Properties.AdditionalProperties) > 0) {
+			return typeName
 		}
-	}
-	if document.Components.Schemas != nil {
-		for _, pair := range document.Components.Schemas.AdditionalProperties {
-			definition := pair.Value
-			if definition.GetSchema() != nil {
-				s.analyzeDefinition("definitions/"+pair.Name, definition.GetSchema())
+		if schema.AdditionalProperties != nil {
+			if schema.AdditionalProperties.GetSchemaOrReference() != nil {
+				additionalPropertiesSchemaType := typeForSchemaOrReferenceV3(schema.AdditionalProperties.GetSchemaOrReference())
+				return "map-of-" + additionalPropertiesSchemaType
+			}
+			if schema.AdditionalProperties.GetBoolean() == false {
+				// no additional properties are allowed, so we're not sure what to do if we get here...
+				return typeName
 			}
 		}
+		return "map-of-object"
 	}
+	return typeName
 }
-*/
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	openapiv3 "github.com/google/gnostic/openapiv3"
+)
+
+func TestDocumentStatisticsV3(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/statistics.yaml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	document, err := openapiv3.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	s := NewDocumentStatisticsV3("statistics.yaml", document)
+
+	for _, test := range []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{"operations", s.Operations, map[string]int{"get": 2, "post": 1, "total": 3, "anonymous": 1}},
+		{"anonymous operations", s.AnonymousOperations, []string{"paths/items/post"}},
+		{"parameter types", s.ParameterTypes, map[string]int{"reference": 3, "object": 1, "string": 1}},
+		{"result types", s.ResultTypes, map[string]int{"array-of-reference": 1, "reference": 2}},
+		{"anonymous objects", s.AnonymousObjects, []string{"paths/items/get/filter"}},
+		{"content types", s.ContentTypes, map[string]int{
+			"application/json":         3,
+			"application/xml":          1,
+			"text/plain":               1,
+			"application/problem+json": 1,
+		}},
+		{"components", s.Components, map[string]int{
+			"schemas":         2,
+			"responses":       1,
+			"parameters":      1,
+			"securitySchemes": 3,
+			"links":           1,
+			"callbacks":       1,
+		}},
+		{"security scheme types", s.SecuritySchemeTypes, map[string]int{"apiKey": 1, "oauth2": 1, "http": 1}},
+		{"definition field types", s.DefinitionFieldTypes, map[string]int{"string": 1, "array-of-string": 1}},
+		{"definition primitive types", s.DefinitionPrimitiveTypes, map[string]int{"string": 1}},
+		{"definitions", s.DefinitionCount, 2},
+		{"callbacks", s.CallbackCount, 2},
+		{"links", s.LinkCount, 3},
+	} {
+		if !reflect.DeepEqual(test.got, test.expected) {
+			t.Errorf("unexpected %s %v, expected %v", test.name, test.got, test.expected)
+		}
+	}
+}
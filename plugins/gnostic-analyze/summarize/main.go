@@ -17,19 +17,25 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"time"
 
+	"github.com/google/gnostic/metrics/export"
 	"github.com/google/gnostic/plugins/gnostic-analyze/statistics"
 )
 
 // Results are collected in this global slice.
 var stats []statistics.DocumentStatistics
 
+// The modification times of the summary files are the times of the results.
+var times []time.Time
+
 // walker is called for each summary file found.
 func walker(p string, info os.FileInfo, err error) error {
 	if err != nil {
@@ -49,6 +55,28 @@ func walker(p string, info os.FileInfo, err error) error {
 		return err
 	}
 	stats = append(stats, s)
+	times = append(times, info.ModTime())
+	return nil
+}
+
+// exportStatistics writes the statistics of each description as rows for
+// a database.
+func exportStatistics(sql bool) error {
+	if sql {
+		fmt.Print(export.SQLSchema)
+	}
+	for i := range stats {
+		d := &export.Document{Name: stats[i].Name, Time: times[i]}
+		var err error
+		if sql {
+			err = export.WriteStatisticsSQL(os.Stdout, d, &stats[i])
+		} else {
+			err = export.WriteStatisticsJSON(os.Stdout, d, &stats[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -81,30 +109,38 @@ func (p pairList) Less(i, j int) bool { return p[i].Value < p[j].Value }
 func (p pairList) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 
 func main() {
+	jsonOutput := flag.Bool("json", false, "write the aggregated statistics as JSON")
+	ndjsonOutput := flag.Bool("ndjson", false, "write the statistics of each description as newline-delimited JSON rows")
+	sqlOutput := flag.Bool("sql", false, "write the statistics of each description as SQL statements")
+	flag.Parse()
+
 	// Collect all statistics in the current directory and its subdirectories.
 	stats = make([]statistics.DocumentStatistics, 0)
 	filepath.Walk(".", walker)
 
+	if *ndjsonOutput || *sqlOutput {
+		if err := exportStatistics(*sqlOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Compute some interesting properties.
-	apisWithAnonymousOperations := 0
-	apisWithAnonymousObjects := 0
-	apisWithAnonymousAnything := 0
-	opFrequencies := make(map[string]int, 0)
-	parameterTypeFrequencies := make(map[string]int, 0)
-	resultTypeFrequencies := make(map[string]int, 0)
-	definitionFieldTypeFrequencies := make(map[string]int, 0)
-	definitionArrayTypeFrequencies := make(map[string]int, 0)
-	definitionPrimitiveTypeFrequencies := make(map[string]int, 0)
+	corpus := statistics.NewCorpusStatistics(stats)
 
-	for _, api := range stats {
-		if api.Operations["anonymous"] != 0 {
-			apisWithAnonymousOperations++
-		}
-		if len(api.AnonymousObjects) > 0 {
-			apisWithAnonymousObjects++
+	if *jsonOutput {
+		bytes, err := json.MarshalIndent(corpus, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("%s\n", bytes)
+		return
+	}
+
+	for _, api := range stats {
 		if len(api.AnonymousOperations) > 0 {
-			apisWithAnonymousAnything++
 			if len(api.AnonymousObjects) > 0 {
 				fmt.Printf("%s has anonymous operations and objects\n", api.Name)
 			} else {
@@ -112,48 +148,39 @@ func main() {
 			}
 		} else {
 			if len(api.AnonymousObjects) > 0 {
-				apisWithAnonymousAnything++
 				fmt.Printf("%s has anonymous objects\n", api.Name)
 			} else {
 				fmt.Printf("%s has no anonymous operations or objects\n", api.Name)
 			}
 		}
-		for k, v := range api.Operations {
-			opFrequencies[k] += v
-		}
-		for k, v := range api.ParameterTypes {
-			parameterTypeFrequencies[k] += v
-		}
-		for k, v := range api.ResultTypes {
-			resultTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionFieldTypes {
-			definitionFieldTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionArrayTypes {
-			definitionArrayTypeFrequencies[k] += v
-		}
-		for k, v := range api.DefinitionPrimitiveTypes {
-			definitionPrimitiveTypeFrequencies[k] += v
-		}
 	}
 
 	// Report the results.
 	fmt.Printf("\n")
-	fmt.Printf("Collected information on %d APIs.\n\n", len(stats))
-	fmt.Printf("APIs with anonymous operations: %d\n", apisWithAnonymousOperations)
-	fmt.Printf("APIs with anonymous objects: %d\n", apisWithAnonymousObjects)
-	fmt.Printf("APIs with anonymous anything: %d\n", apisWithAnonymousAnything)
+	fmt.Printf("Collected information on %d APIs.\n\n", corpus.APIs)
+	fmt.Printf("APIs with anonymous operations: %d\n", corpus.APIsWithAnonymousOperations)
+	fmt.Printf("APIs with anonymous objects: %d\n", corpus.APIsWithAnonymousObjects)
+	fmt.Printf("APIs with anonymous anything: %d\n", corpus.APIsWithAnonymousAnything)
+	fmt.Printf("\nVersion frequencies:\n")
+	printFrequencies(corpus.Versions)
 	fmt.Printf("\nOperation frequencies:\n")
-	printFrequencies(opFrequencies)
+	printFrequencies(corpus.Operations)
 	fmt.Printf("\nParameter type frequencies:\n")
-	printFrequencies(parameterTypeFrequencies)
+	printFrequencies(corpus.ParameterTypes)
 	fmt.Printf("\nResult type frequencies:\n")
-	printFrequencies(resultTypeFrequencies)
+	printFrequencies(corpus.ResultTypes)
 	fmt.Printf("\nDefinition object field type frequencies:\n")
-	printFrequencies(definitionFieldTypeFrequencies)
+	printFrequencies(corpus.DefinitionFieldTypes)
 	fmt.Printf("\nDefinition array type frequencies:\n")
-	printFrequencies(definitionArrayTypeFrequencies)
+	printFrequencies(corpus.DefinitionArrayTypes)
 	fmt.Printf("\nDefinition primitive type frequencies:\n")
-	printFrequencies(definitionPrimitiveTypeFrequencies)
+	printFrequencies(corpus.DefinitionPrimitiveTypes)
+	fmt.Printf("\nContent type frequencies:\n")
+	printFrequencies(corpus.ContentTypes)
+	fmt.Printf("\nComponent frequencies:\n")
+	printFrequencies(corpus.Components)
+	fmt.Printf("\nSecurity scheme type frequencies:\n")
+	printFrequencies(corpus.SecuritySchemeTypes)
+	fmt.Printf("\nCallbacks: %d\n", corpus.CallbackCount)
+	fmt.Printf("Links: %d\n", corpus.LinkCount)
 }
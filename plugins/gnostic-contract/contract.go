@@ -0,0 +1,306 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	"github.com/google/gnostic/jsonwriter"
+	"github.com/google/gnostic/plugins/internal/examples"
+	surface "github.com/google/gnostic/surface"
+)
+
+// An operation is the request that a contract test sends and the responses
+// that it accepts.
+type operation struct {
+	name        string
+	method      string
+	path        string
+	query       url.Values
+	header      map[string]string
+	contentType string
+	body        string
+	// responses maps status codes (and "default") to the JSON schemas of
+	// their bodies, which are empty for responses without JSON bodies.
+	responses map[string]string
+}
+
+type generator struct {
+	model *surface.Model
+	root  *yaml.Node
+	v3    bool
+}
+
+// generate returns a Go test file that checks an API handler against the
+// operations of a document and its surface model.
+func generate(model *surface.Model, root *yaml.Node, packageName string) ([]byte, error) {
+	g := &generator{model: model, root: root, v3: compiler.MapValueForKey(root, "openapi") != nil}
+	var operations []*operation
+	names := map[string]bool{}
+	for _, method := range model.Methods {
+		op := g.buildOperation(method)
+		if op == nil {
+			continue
+		}
+		op.name = "TestContract" + method.Name
+		for i := 2; names[op.name]; i++ {
+			op.name = "TestContract" + method.Name + strconv.Itoa(i)
+		}
+		names[op.name] = true
+		operations = append(operations, op)
+	}
+	schemas := g.namedSchemas()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gnostic-contract. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	fmt.Fprintf(&b, "import (\n")
+	for _, name := range []string{"encoding/json", "fmt", "io", "io/ioutil", "math", "net/http", "net/http/httptest", "net/url", "reflect", "sort", "strconv", "strings", "testing"} {
+		fmt.Fprintf(&b, "%q\n", name)
+	}
+	fmt.Fprintf(&b, ")\n\n")
+	fmt.Fprintf(&b, "%s\n", helpers)
+	fmt.Fprintf(&b, "// contractSchemas contains the named schemas of the API, keyed by the\n")
+	fmt.Fprintf(&b, "// references to them.\n")
+	fmt.Fprintf(&b, "var contractSchemas = map[string]string{\n")
+	for _, name := range sortedKeys(schemas) {
+		fmt.Fprintf(&b, "%q: %q,\n", name, schemas[name])
+	}
+	fmt.Fprintf(&b, "}\n")
+	for _, op := range operations {
+		fmt.Fprintf(&b, "\nfunc %s(t *testing.T) {\n", op.name)
+		fmt.Fprintf(&b, "checkContract(t, &contractRequest{\n")
+		fmt.Fprintf(&b, "Method: %q,\n", op.method)
+		fmt.Fprintf(&b, "Path: %q,\n", op.path)
+		if len(op.query) > 0 {
+			fmt.Fprintf(&b, "Query: url.Values{\n")
+			for _, name := range sortedKeys(op.query) {
+				fmt.Fprintf(&b, "%q: {%q},\n", name, op.query.Get(name))
+			}
+			fmt.Fprintf(&b, "},\n")
+		}
+		if len(op.header) > 0 {
+			fmt.Fprintf(&b, "Header: http.Header{\n")
+			for _, name := range sortedKeys(op.header) {
+				fmt.Fprintf(&b, "%q: {%q},\n", name, op.header[name])
+			}
+			fmt.Fprintf(&b, "},\n")
+		}
+		if op.contentType != "" {
+			fmt.Fprintf(&b, "ContentType: %q,\n", op.contentType)
+			fmt.Fprintf(&b, "Body: %q,\n", op.body)
+		}
+		fmt.Fprintf(&b, "}, map[string]string{\n")
+		for _, code := range sortedKeys(op.responses) {
+			fmt.Fprintf(&b, "%q: %q,\n", code, op.responses[code])
+		}
+		fmt.Fprintf(&b, "})\n")
+		fmt.Fprintf(&b, "}\n")
+	}
+	return format.Source(b.Bytes())
+}
+
+// Returns the request and responses of a method, or nil if the operation of
+// the method isn't in the document.
+func (g *generator) buildOperation(method *surface.Method) *operation {
+	pathItem := g.resolve(compiler.MapValueForKey(compiler.MapValueForKey(g.root, "paths"), method.Path))
+	node := compiler.MapValueForKey(pathItem, strings.ToLower(method.Method))
+	if node == nil {
+		return nil
+	}
+	op := &operation{
+		method:    method.Method,
+		path:      method.Path,
+		query:     url.Values{},
+		header:    map[string]string{},
+		responses: map[string]string{},
+	}
+	form := url.Values{}
+	for _, field := range g.parameters(method) {
+		value := g.parameterValue(field)
+		switch field.Position {
+		case surface.Position_PATH:
+			op.path = strings.Replace(op.path, "{"+field.Name+"}", url.PathEscape(value), -1)
+		case surface.Position_QUERY:
+			op.query.Set(field.Name, value)
+		case surface.Position_HEADER:
+			op.header[textproto.CanonicalMIMEHeaderKey(field.Name)] = value
+		case surface.Position_FORMDATA:
+			form.Set(field.Name, value)
+		}
+	}
+	if len(form) > 0 {
+		op.contentType, op.body = "application/x-www-form-urlencoded", form.Encode()
+	}
+	if schema, contentType := g.requestBodySchema(pathItem, node); schema != nil {
+		if value := examples.Value(g.root, schema); value != nil {
+			op.contentType, op.body = contentType, compactJSON(value)
+		}
+	}
+	responses := g.resolve(compiler.MapValueForKey(node, "responses"))
+	for i := 0; responses != nil && i+1 < len(responses.Content); i += 2 {
+		code := responses.Content[i].Value
+		if strings.HasPrefix(code, "x-") {
+			continue
+		}
+		op.responses[code] = ""
+		if schema := g.responseSchema(g.resolve(responses.Content[i+1])); schema != nil {
+			op.responses[code] = compactJSON(schema)
+		}
+	}
+	return op
+}
+
+// Returns the fields of the parameters type of a method.
+func (g *generator) parameters(method *surface.Method) []*surface.Field {
+	for _, t := range g.model.Types {
+		if t.Name == method.ParametersTypeName {
+			return t.Fields
+		}
+	}
+	return nil
+}
+
+// Returns a value for a parameter from its default, its enum values, or its
+// type.
+func (g *generator) parameterValue(field *surface.Field) string {
+	if field.DefaultValue != "" {
+		return field.DefaultValue
+	}
+	if len(field.EnumValues) > 0 {
+		return field.EnumValues[0]
+	}
+	for _, t := range g.model.Types {
+		if t.Name == field.Type && t.Kind == surface.TypeKind_ENUM && len(t.EnumValues) > 0 {
+			return t.EnumValues[0]
+		}
+	}
+	switch field.Type {
+	case "integer":
+		return "1"
+	case "number":
+		return "1.5"
+	case "boolean":
+		return "true"
+	}
+	return "example"
+}
+
+// Returns the schema and the content type of the JSON request body of an
+// operation, or nil if it has none.
+func (g *generator) requestBodySchema(pathItem, node *yaml.Node) (*yaml.Node, string) {
+	if g.v3 {
+		return jsonContentSchema(g.resolve(compiler.MapValueForKey(node, "requestBody")))
+	}
+	// Body parameters in OpenAPI v2 can be in operations or in path items.
+	for _, parameters := range []*yaml.Node{compiler.MapValueForKey(node, "parameters"), compiler.MapValueForKey(pathItem, "parameters")} {
+		for i := 0; parameters != nil && i < len(parameters.Content); i++ {
+			parameter := g.resolve(parameters.Content[i])
+			if in := compiler.MapValueForKey(parameter, "in"); in != nil && in.Value == "body" {
+				return compiler.MapValueForKey(parameter, "schema"), "application/json"
+			}
+		}
+	}
+	return nil, ""
+}
+
+// Returns the schema of the JSON body of a response, or nil if it has none.
+func (g *generator) responseSchema(response *yaml.Node) *yaml.Node {
+	if g.v3 {
+		schema, _ := jsonContentSchema(response)
+		return schema
+	}
+	return compiler.MapValueForKey(response, "schema")
+}
+
+// Returns the schema and the media type of the first JSON content of an
+// OpenAPI v3 request body or response.
+func jsonContentSchema(node *yaml.Node) (*yaml.Node, string) {
+	content := compiler.MapValueForKey(node, "content")
+	for i := 0; content != nil && i+1 < len(content.Content); i += 2 {
+		mediaType := content.Content[i].Value
+		if schema := compiler.MapValueForKey(content.Content[i+1], "schema"); schema != nil && strings.Contains(mediaType, "json") {
+			return schema, mediaType
+		}
+	}
+	return nil, ""
+}
+
+// Returns the named schemas of the document as JSON, keyed by the
+// references to them.
+func (g *generator) namedSchemas() map[string]string {
+	tokens := []string{"definitions"}
+	if g.v3 {
+		tokens = []string{"components", "schemas"}
+	}
+	schemas := map[string]string{}
+	node, err := jsonpointer.ResolveTokens(g.root, tokens)
+	if err != nil || node.Kind != yaml.MappingNode {
+		return schemas
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		schemas["#"+jsonpointer.Append(jsonpointer.Format(tokens...), name)] = compactJSON(node.Content[i+1])
+	}
+	return schemas
+}
+
+func (g *generator) resolve(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	return examples.Resolve(g.root, node)
+}
+
+// Returns the JSON encoding of a node on a single line.
+func compactJSON(node *yaml.Node) string {
+	data, err := jsonwriter.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]string:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case url.Values:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// helpers are added to each generated test file. They send requests to the
+// handler under test and validate responses with the schemas of the API.
+const helpers = `// contractHandler is the handler of the API that the contract tests call.
+// Set it in another file of the package, for example in an init function.
+// The tests are skipped if it isn't set.
+var contractHandler http.Handler
+
+// A contractRequest is sent by a contract test.
+type contractRequest struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Header      http.Header
+	ContentType string
+	Body        string
+}
+
+// checkContract sends a request to a test server for contractHandler and
+// checks that the status of the response is documented and that its body
+// matches the schema of the response. Responses are keyed by status code,
+// status range (like "2XX"), or "default", and their schemas are empty if
+// their bodies aren't checked.
+func checkContract(t *testing.T, request *contractRequest, responses map[string]string) {
+	t.Helper()
+	if contractHandler == nil {
+		t.Skip("contractHandler is not set")
+	}
+	server := httptest.NewServer(contractHandler)
+	defer server.Close()
+
+	u := server.URL + request.Path
+	if len(request.Query) > 0 {
+		u += "?" + request.Query.Encode()
+	}
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+	req, err := http.NewRequest(request.Method, u, body)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for name, values := range request.Header {
+		req.Header[name] = values
+	}
+	if request.ContentType != "" {
+		req.Header.Set("Content-Type", request.ContentType)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	schema, ok := responses[strconv.Itoa(resp.StatusCode)]
+	if !ok {
+		schema, ok = responses[strconv.Itoa(resp.StatusCode/100)+"XX"]
+	}
+	if !ok {
+		schema, ok = responses["default"]
+	}
+	if !ok {
+		t.Fatalf("%s %s returned undocumented status %d", request.Method, request.Path, resp.StatusCode)
+	}
+	if schema == "" || len(data) == 0 {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("%s %s returned invalid JSON: %s", request.Method, request.Path, err)
+	}
+	for _, message := range validateContract(parseContractSchema(schema), value, "") {
+		t.Errorf("%s %s returned an invalid response: %s", request.Method, request.Path, message)
+	}
+}
+
+func parseContractSchema(text string) map[string]interface{} {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &schema); err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// validateContract returns messages that describe how a value doesn't match
+// a schema. References to schemas that aren't named schemas of the API
+// aren't followed, and values are assumed to match them.
+func validateContract(schema map[string]interface{}, value interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, ok := contractSchemas[ref]
+		if !ok {
+			return nil
+		}
+		return validateContract(parseContractSchema(target), value, path)
+	}
+	if value == nil && (schema["nullable"] == true || schema["x-nullable"] == true) {
+		return nil
+	}
+	location := path
+	if location == "" {
+		location = "/"
+	}
+	var messages []string
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			if s, ok := s.(map[string]interface{}); ok {
+				messages = append(messages, validateContract(s, value, path)...)
+			}
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		options, ok := schema[key].([]interface{})
+		if !ok {
+			continue
+		}
+		matched := false
+		for _, s := range options {
+			if s, ok := s.(map[string]interface{}); ok && len(validateContract(s, value, path)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			messages = append(messages, fmt.Sprintf("%s: value doesn't match any schema of %s", location, key))
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			messages = append(messages, fmt.Sprintf("%s: %v is not one of %v", location, value, enum))
+		}
+	}
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, t := range t {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+	}
+	if len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if contractTypeMatches(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return append(messages, fmt.Sprintf("%s: expected %s, got %s", location, strings.Join(types, " or "), contractTypeName(value)))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if name, ok := name.(string); ok {
+					if _, ok := v[name]; !ok {
+						messages = append(messages, fmt.Sprintf("%s: missing required property %q", location, name))
+					}
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if s, ok := properties[name].(map[string]interface{}); ok {
+				messages = append(messages, validateContract(s, v[name], path+"/"+name)...)
+			} else if s, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+				messages = append(messages, validateContract(s, v[name], path+"/"+name)...)
+			} else if schema["additionalProperties"] == false {
+				messages = append(messages, fmt.Sprintf("%s: unexpected property %q", location, name))
+			}
+		}
+	case []interface{}:
+		if s, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				messages = append(messages, validateContract(s, item, path+"/"+strconv.Itoa(i))...)
+			}
+		}
+	}
+	return messages
+}
+
+// contractTypeMatches returns true if a value decoded from JSON has a type.
+// Unknown types match all values.
+func contractTypeMatches(t string, value interface{}) bool {
+	switch t {
+	case "null", "boolean", "string", "number", "integer", "array", "object":
+	default:
+		return true
+	}
+	switch v := value.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case float64:
+		return t == "number" || t == "integer" && v == math.Trunc(v)
+	case []interface{}:
+		return t == "array"
+	case map[string]interface{}:
+		return t == "object"
+	}
+	return false
+}
+
+func contractTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+`
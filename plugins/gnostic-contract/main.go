@@ -0,0 +1,94 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-contract is a plugin that generates Go tests that check that an
+// API handler serves the responses of its API description.
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+	surface "github.com/google/gnostic/surface"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	base := filepath.Base(env.Request.SourceName)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	packageName := packageNameForFile(base)
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "package" {
+			packageName = parameter.Value
+		}
+	}
+
+	var root *yaml.Node
+	var model *surface.Model
+	for _, m := range env.Request.Models {
+		switch m.TypeUrl {
+		case "openapi.v2.Document":
+			documentv2 := &openapiv2.Document{}
+			err = proto.Unmarshal(m.Value, documentv2)
+			env.RespondAndExitIfError(err)
+			root = documentv2.ToRawInfo()
+		case "openapi.v3.Document":
+			documentv3 := &openapiv3.Document{}
+			err = proto.Unmarshal(m.Value, documentv3)
+			env.RespondAndExitIfError(err)
+			root = documentv3.ToRawInfo()
+		case "surface.v1.Model":
+			model = &surface.Model{}
+			err = proto.Unmarshal(m.Value, model)
+			env.RespondAndExitIfError(err)
+		}
+	}
+
+	if root != nil && model != nil {
+		data, err := generate(model, root, packageName)
+		env.RespondAndExitIfError(err)
+		env.Response.Files = append(env.Response.Files, &plugins.File{
+			Name: filepath.Join(filepath.Dir(env.Request.SourceName), base+"_contract_test.go"),
+			Data: data,
+		})
+	}
+
+	env.RespondAndExit()
+}
+
+// Returns a Go package name made from the letters and digits of a file name.
+func packageNameForFile(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return -1
+	}, name)
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "api" + name
+	}
+	return name
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-examples is a plugin that generates example values for the schemas
+// and responses of an API description.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/jsonwriter"
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/examples"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	mode := "fixtures"
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "mode" {
+			mode = parameter.Value
+		}
+	}
+	if mode != "fixtures" && mode != "document" {
+		env.RespondAndExitIfError(fmt.Errorf("unsupported mode %q, use \"fixtures\" or \"document\"", mode))
+	}
+
+	var root *yaml.Node
+	for _, model := range env.Request.Models {
+		switch model.TypeUrl {
+		case "openapi.v2.Document":
+			documentv2 := &openapiv2.Document{}
+			err = proto.Unmarshal(model.Value, documentv2)
+			env.RespondAndExitIfError(err)
+			root = documentv2.ToRawInfo()
+		case "openapi.v3.Document":
+			documentv3 := &openapiv3.Document{}
+			err = proto.Unmarshal(model.Value, documentv3)
+			env.RespondAndExitIfError(err)
+			root = documentv3.ToRawInfo()
+		}
+	}
+
+	if root != nil {
+		dir := filepath.Dir(env.Request.SourceName)
+		results := examples.Generate(root, mode == "document")
+		if mode == "document" {
+			// The document is written in the format of its source.
+			base := filepath.Base(env.Request.SourceName)
+			ext := filepath.Ext(base)
+			file := &plugins.File{Name: filepath.Join(dir, strings.TrimSuffix(base, ext)+".examples"+ext)}
+			if ext == ".json" {
+				file.Data, err = jsonwriter.Marshal(root)
+				env.RespondAndExitIfError(err)
+			} else {
+				file.Data = compiler.Marshal(root)
+			}
+			env.Response.Files = append(env.Response.Files, file)
+		} else {
+			names := strings.NewReplacer("/", "_", "\\", "_")
+			for _, example := range results {
+				kind := "schemas"
+				if strings.HasPrefix(example.Pointer, "/paths/") {
+					kind = "responses"
+				}
+				data, err := jsonwriter.Marshal(example.Value)
+				env.RespondAndExitIfError(err)
+				env.Response.Files = append(env.Response.Files, &plugins.File{
+					Name: filepath.Join(dir, "examples", kind, names.Replace(example.Name)+".json"),
+					Data: data,
+				})
+			}
+		}
+	}
+
+	env.RespondAndExit()
+}
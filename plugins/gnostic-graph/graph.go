@@ -0,0 +1,254 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Node is an operation, path, or component in the reference graph of an
+// API description.
+type Node struct {
+	// ID is a JSON pointer to the node in the description.
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
+	// References contains the IDs of the components that the node refers
+	// to, in the order of their first references.
+	References []string `json:"references"`
+	// ReferencedBy is the number of other nodes that refer to the node.
+	// Nodes that are referenced by many nodes are highly coupled.
+	ReferencedBy int `json:"referencedBy"`
+}
+
+// Graph is the graph of the references between the parts of an API
+// description.
+type Graph struct {
+	Title string  `json:"title"`
+	Nodes []*Node `json:"nodes"`
+}
+
+// A section contains components of a kind.
+type section struct {
+	path []string
+	kind string
+}
+
+// sectionsV2 and sectionsV3 list the parts of OpenAPI documents that
+// contain components that can be referenced with $ref.
+var sectionsV2 = []section{
+	{[]string{"definitions"}, "schema"},
+	{[]string{"parameters"}, "parameter"},
+	{[]string{"responses"}, "response"},
+}
+
+var sectionsV3 = []section{
+	{[]string{"components", "schemas"}, "schema"},
+	{[]string{"components", "responses"}, "response"},
+	{[]string{"components", "parameters"}, "parameter"},
+	{[]string{"components", "examples"}, "example"},
+	{[]string{"components", "requestBodies"}, "requestBody"},
+	{[]string{"components", "headers"}, "header"},
+	{[]string{"components", "securitySchemes"}, "securityScheme"},
+	{[]string{"components", "links"}, "link"},
+	{[]string{"components", "callbacks"}, "callback"},
+}
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+type grapher struct {
+	root     *yaml.Node
+	sections []section
+	graph    *Graph
+	nodes    map[string]*Node
+	// edges contains the references that have been added, as pairs of
+	// node IDs.
+	edges map[[2]string]bool
+}
+
+// graph builds the reference graph of a document. Only local references
+// (those beginning with "#") are followed; references to other files and
+// references that can't be resolved are ignored.
+func graph(root *yaml.Node, sections []section) *Graph {
+	g := &grapher{
+		root:     root,
+		sections: sections,
+		graph:    &Graph{Nodes: []*Node{}},
+		nodes:    make(map[string]*Node),
+		edges:    make(map[[2]string]bool),
+	}
+	if title := compiler.MapValueForKey(compiler.MapValueForKey(root, "info"), "title"); title != nil {
+		g.graph.Title = title.Value
+	}
+	// Operations come first, in the order of the document, and components
+	// follow in the order of their sections. Paths are added when they
+	// have references of their own.
+	if paths := compiler.MapValueForKey(root, "paths"); paths != nil && paths.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			path := paths.Content[i].Value
+			item := paths.Content[i+1]
+			for j := 0; item.Kind == yaml.MappingNode && j+1 < len(item.Content); j += 2 {
+				if method := item.Content[j].Value; methods[method] {
+					g.node(jsonpointer.Format("paths", path, method), "operation", strings.ToUpper(method)+" "+path)
+				}
+			}
+		}
+	}
+	for _, s := range sections {
+		node, err := jsonpointer.ResolveTokens(root, s.path)
+		if err != nil || node.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			g.node(jsonpointer.Append(jsonpointer.Format(s.path...), name), s.kind, name)
+		}
+	}
+	g.walk(root, nil)
+	return g.graph
+}
+
+func (g *grapher) node(id, kind, label string) *Node {
+	if node, ok := g.nodes[id]; ok {
+		return node
+	}
+	node := &Node{ID: id, Kind: kind, Label: label, References: []string{}}
+	g.nodes[id] = node
+	g.graph.Nodes = append(g.graph.Nodes, node)
+	return node
+}
+
+func (g *grapher) walk(node *yaml.Node, tokens []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "$ref" && value.Kind == yaml.ScalarNode {
+				g.reference(value.Value, tokens)
+				continue
+			}
+			g.walk(value, append(tokens[:len(tokens):len(tokens)], key))
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			g.walk(item, append(tokens[:len(tokens):len(tokens)], strconv.Itoa(i)))
+		}
+	}
+}
+
+// reference adds an edge for a reference found at a location in the
+// document.
+func (g *grapher) reference(ref string, tokens []string) {
+	if !strings.HasPrefix(ref, "#") {
+		return
+	}
+	targetTokens, err := jsonpointer.ParseFragment(ref)
+	if err == nil {
+		_, err = jsonpointer.ResolveTokens(g.root, targetTokens)
+	}
+	if err != nil {
+		return
+	}
+	target := g.nodes[g.componentFor(targetTokens)]
+	source := g.nodeFor(tokens)
+	if target == nil || source == nil {
+		return
+	}
+	edge := [2]string{source.ID, target.ID}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	source.References = append(source.References, target.ID)
+	if source != target {
+		target.ReferencedBy++
+	}
+}
+
+// nodeFor returns the node that contains a location, or nil if the
+// location is not inside of a component or path. References from the
+// parts of paths that aren't operations, like shared parameters, belong
+// to nodes for the paths.
+func (g *grapher) nodeFor(tokens []string) *Node {
+	if component := g.componentFor(tokens); component != "" {
+		return g.nodes[component]
+	}
+	if len(tokens) < 2 || tokens[0] != "paths" {
+		return nil
+	}
+	if len(tokens) > 2 && methods[tokens[2]] {
+		return g.nodes[jsonpointer.Format(tokens[:3]...)]
+	}
+	return g.node(jsonpointer.Format(tokens[:2]...), "path", tokens[1])
+}
+
+// componentFor returns the component that contains a location, or ""
+// if the location is not inside of a component.
+func (g *grapher) componentFor(tokens []string) string {
+	for _, s := range g.sections {
+		if len(tokens) <= len(s.path) {
+			continue
+		}
+		matches := true
+		for i := range s.path {
+			if tokens[i] != s.path[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return jsonpointer.Format(tokens[:len(s.path)+1]...)
+		}
+	}
+	return ""
+}
+
+// shapes are the shapes of the kinds of nodes in DOT graphs. Other kinds
+// of nodes are notes.
+var shapes = map[string]string{
+	"operation": "box",
+	"path":      "box",
+	"schema":    "ellipse",
+}
+
+// DOT returns a GraphViz description of the graph.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(g.Title))
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		shape := shapes[node.Kind]
+		if shape == "" {
+			shape = "note"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", strconv.Quote(node.ID), strconv.Quote(node.Label), shape)
+	}
+	for _, node := range g.Nodes {
+		for _, target := range node.References {
+			fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(node.ID), strconv.Quote(target))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
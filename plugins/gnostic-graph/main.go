@@ -0,0 +1,79 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-graph is a plugin that writes the graph of the references in an
+// API description as GraphViz DOT or as a JSON adjacency list.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	format := "dot"
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "format" {
+			format = parameter.Value
+		}
+	}
+	if format != "dot" && format != "json" {
+		env.RespondAndExitIfError(fmt.Errorf("unsupported format %q, use \"dot\" or \"json\"", format))
+	}
+
+	var g *Graph
+	for _, model := range env.Request.Models {
+		switch model.TypeUrl {
+		case "openapi.v2.Document":
+			documentv2 := &openapiv2.Document{}
+			err = proto.Unmarshal(model.Value, documentv2)
+			if err == nil {
+				g = graph(documentv2.ToRawInfo(), sectionsV2)
+			}
+		case "openapi.v3.Document":
+			documentv3 := &openapiv3.Document{}
+			err = proto.Unmarshal(model.Value, documentv3)
+			if err == nil {
+				g = graph(documentv3.ToRawInfo(), sectionsV3)
+			}
+		}
+	}
+
+	if g != nil {
+		file := &plugins.File{}
+		if format == "json" {
+			file.Name = filepath.Join(filepath.Dir(env.Request.SourceName), "graph.json")
+			file.Data, err = json.MarshalIndent(g, "", "  ")
+			env.RespondAndExitIfError(err)
+			file.Data = append(file.Data, []byte("\n")...)
+		} else {
+			file.Name = filepath.Join(filepath.Dir(env.Request.SourceName), "graph.dot")
+			file.Data = []byte(g.DOT())
+		}
+		env.Response.Files = append(env.Response.Files, file)
+	}
+
+	env.RespondAndExit()
+}
@@ -0,0 +1,454 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/clientgen"
+)
+
+// The generated package.
+type javaPackage struct {
+	Package string
+	*clientgen.API
+}
+
+// A generated class.
+type javaClass struct {
+	Package string
+	Imports []string
+	*clientgen.Type
+}
+
+// generate returns the files of a Maven project with a client of an API.
+// The names of the files are relative to the root of the project.
+func generate(api *clientgen.API, packageName string) ([]*plugins.File, error) {
+	p := &javaPackage{Package: packageName, API: api}
+	sources := path.Join("src/main/java", strings.ReplaceAll(packageName, ".", "/"))
+	var files []*plugins.File
+	add := func(name, template string, data interface{}) error {
+		var b bytes.Buffer
+		if err := templates.ExecuteTemplate(&b, template, data); err != nil {
+			return err
+		}
+		files = append(files, &plugins.File{Name: name, Data: b.Bytes()})
+		return nil
+	}
+	if err := add("pom.xml", "pom", p); err != nil {
+		return nil, err
+	}
+	for _, t := range api.Types {
+		c := &javaClass{Package: packageName, Imports: typeImports(t), Type: t}
+		if err := add(path.Join(sources, t.Name+".java"), t.Kind, c); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(path.Join(sources, "ApiException.java"), "exception", p); err != nil {
+		return nil, err
+	}
+	if err := add(path.Join(sources, "Client.java"), "client", p); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// typeImports returns the imports of the class of a type.
+func typeImports(t *clientgen.Type) []string {
+	imports := make(map[string]bool)
+	switch t.Kind {
+	case clientgen.Struct:
+		imports["com.fasterxml.jackson.annotation.JsonInclude"] = true
+		imports["com.fasterxml.jackson.annotation.JsonProperty"] = true
+		imports["java.util.Objects"] = true
+		for _, f := range t.Fields {
+			addImports(imports, f.Type)
+		}
+	case clientgen.Enum:
+		imports["com.fasterxml.jackson.annotation.JsonCreator"] = true
+		imports["com.fasterxml.jackson.annotation.JsonValue"] = true
+	case clientgen.Array:
+		imports["java.util.ArrayList"] = true
+		addImports(imports, t.Element)
+	case clientgen.Map:
+		imports["java.util.HashMap"] = true
+		addImports(imports, t.Element)
+	}
+	return sortedImports(imports)
+}
+
+// clientImports returns the imports of the client class.
+func clientImports(api *clientgen.API) []string {
+	imports := make(map[string]bool)
+	for _, name := range []string{
+		"com.fasterxml.jackson.core.type.TypeReference",
+		"com.fasterxml.jackson.databind.ObjectMapper",
+		"java.io.IOException",
+		"java.net.URI",
+		"java.net.URLEncoder",
+		"java.net.http.HttpClient",
+		"java.net.http.HttpRequest",
+		"java.net.http.HttpResponse",
+		"java.nio.charset.StandardCharsets",
+		"java.util.LinkedHashMap",
+		"java.util.Map",
+	} {
+		imports[name] = true
+	}
+	for _, m := range api.Methods {
+		for _, p := range m.Parameters {
+			addImports(imports, p.Type)
+		}
+		addImports(imports, m.Result)
+	}
+	return sortedImports(imports)
+}
+
+// addImports adds the imports of the types that are used in a type.
+func addImports(imports map[string]bool, typeName string) {
+	names := strings.FieldsFunc(typeName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, name := range names {
+		switch name {
+		case "List":
+			imports["java.util.List"] = true
+		case "Map":
+			imports["java.util.Map"] = true
+		case "JsonNode":
+			imports["com.fasterxml.jackson.databind.JsonNode"] = true
+		}
+	}
+}
+
+func sortedImports(imports map[string]bool) []string {
+	var names []string
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"comment":  comment,
+	"quote":    strconv.Quote,
+	"path":     javaPath,
+	"accessor": accessor,
+	"imports":  clientImports,
+}).Parse(javaTemplates))
+
+// comment returns the lines of a description as a documentation comment.
+func comment(indent, description string) string {
+	lines := clientgen.Lines(description)
+	if len(lines) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "*/", "*&#47;")
+		b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
+
+// javaPath returns a Java expression that concatenates the literals and
+// escaped path parameters of a method.
+func javaPath(parts []*clientgen.PathPart) string {
+	var terms []string
+	for _, part := range parts {
+		if part.Parameter != nil {
+			terms = append(terms, "escape("+part.Parameter.ParameterName+")")
+		} else {
+			terms = append(terms, strconv.Quote(part.Literal))
+		}
+	}
+	if len(terms) == 0 {
+		return `""`
+	}
+	return strings.Join(terms, " + ")
+}
+
+// accessor returns the name of the getter or setter of a field. Getters
+// can't be named getClass, which is a method of every object.
+func accessor(prefix string, f *clientgen.Field) string {
+	name := prefix + clientgen.UpperCamel(strings.TrimSuffix(f.FieldName, "_"))
+	if name == "getClass" {
+		name += "_"
+	}
+	return name
+}
+
+const javaTemplates = `
+{{- define "pom" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<!-- Code generated by gnostic-java-generator. DO NOT EDIT. -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+
+  <groupId>{{.Package}}</groupId>
+  <artifactId>{{.Package}}</artifactId>
+  <version>1.0.0-SNAPSHOT</version>
+  <packaging>jar</packaging>
+
+  <properties>
+    <maven.compiler.release>11</maven.compiler.release>
+    <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+  </properties>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>2.15.2</version>
+    </dependency>
+  </dependencies>
+</project>
+{{end}}
+
+{{- define "header" -}}
+// Code generated by gnostic-java-generator. DO NOT EDIT.
+
+package {{.Package}};
+{{if .Imports}}
+{{range .Imports}}import {{.}};
+{{end}}{{end}}
+{{comment "" .Description -}}
+{{end}}
+
+{{- define "struct" -}}
+{{template "header" .}}@JsonInclude(JsonInclude.Include.NON_NULL)
+public class {{.Name}} {
+{{- range .Fields}}
+    @JsonProperty({{quote .Name}})
+    private {{.Type}} {{.FieldName}};
+{{- end}}
+{{range .Fields}}
+    public {{.Type}} {{accessor "get" .}}() {
+        return {{.FieldName}};
+    }
+
+    public void {{accessor "set" .}}({{.Type}} {{.FieldName}}) {
+        this.{{.FieldName}} = {{.FieldName}};
+    }
+{{end}}
+    @Override
+    public boolean equals(Object o) {
+        if (this == o) {
+            return true;
+        }
+        if (o == null || getClass() != o.getClass()) {
+            return false;
+        }
+{{- if .Fields}}
+        {{.Name}} that = ({{.Name}}) o;
+        return {{range $i, $f := .Fields}}{{if $i}}
+            && {{end}}Objects.equals(this.{{$f.FieldName}}, that.{{$f.FieldName}}){{end}};
+{{- else}}
+        return true;
+{{- end}}
+    }
+
+    @Override
+    public int hashCode() {
+        return Objects.hash({{range $i, $f := .Fields}}{{if $i}}, {{end}}{{$f.FieldName}}{{end}});
+    }
+}
+{{end}}
+
+{{- define "enum" -}}
+{{template "header" .}}public enum {{.Name}} {
+{{- range $i, $c := .Cases}}{{if $i}},{{end}}
+    {{$c.Name}}({{quote $c.Value}}){{end}};
+
+    private final String value;
+
+    {{.Name}}(String value) {
+        this.value = value;
+    }
+
+    @JsonValue
+    public String getValue() {
+        return value;
+    }
+
+    @JsonCreator
+    public static {{.Name}} fromValue(String value) {
+        for ({{.Name}} c : values()) {
+            if (c.value.equals(value)) {
+                return c;
+            }
+        }
+        throw new IllegalArgumentException("unexpected value " + value);
+    }
+
+    @Override
+    public String toString() {
+        return value;
+    }
+}
+{{end}}
+
+{{- define "array" -}}
+{{template "header" .}}public class {{.Name}} extends ArrayList<{{.Element}}> {
+}
+{{end}}
+
+{{- define "map" -}}
+{{template "header" .}}public class {{.Name}} extends HashMap<String, {{.Element}}> {
+}
+{{end}}
+
+{{- define "exception" -}}
+// Code generated by gnostic-java-generator. DO NOT EDIT.
+
+package {{.Package}};
+
+/**
+ * An error response of {{.Name}}.
+ */
+public class ApiException extends RuntimeException {
+    private final int statusCode;
+    private final byte[] body;
+
+    public ApiException(int statusCode, byte[] body) {
+        super("unexpected status code " + statusCode);
+        this.statusCode = statusCode;
+        this.body = body;
+    }
+
+    /**
+     * Returns the HTTP status code of the response.
+     */
+    public int getStatusCode() {
+        return statusCode;
+    }
+
+    /**
+     * Returns the body of the response.
+     */
+    public byte[] getBody() {
+        return body;
+    }
+}
+{{end}}
+
+{{- define "client" -}}
+// Code generated by gnostic-java-generator. DO NOT EDIT.
+
+package {{.Package}};
+
+{{range imports .API}}import {{.}};
+{{end}}
+/**
+ * A client of {{.Name}}.
+ */
+public class Client {
+    private final URI baseUri;
+    private final HttpClient httpClient;
+    private final ObjectMapper mapper = new ObjectMapper();
+    private final Map<String, String> headers = new LinkedHashMap<>();
+
+    public Client(URI baseUri) {
+        this(baseUri, HttpClient.newHttpClient());
+    }
+
+    public Client(URI baseUri, HttpClient httpClient) {
+        this.baseUri = baseUri;
+        this.httpClient = httpClient;
+    }
+
+    /**
+     * Sets a header that is sent with every request, for example for authentication.
+     */
+    public void setHeader(String name, String value) {
+        headers.put(name, value);
+    }
+{{range .Methods}}
+{{comment "    " .Description}}    public {{or .Result "void"}} {{.Name}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{$p.Type}} {{$p.ParameterName}}{{end}}) throws IOException, InterruptedException {
+        StringBuilder query = new StringBuilder();
+{{- range .In "query"}}
+        addQuery(query, {{quote .Name}}, {{.ParameterName}});
+{{- end}}
+        HttpRequest.Builder request = newRequest({{path .Path}}, query);
+{{- range .In "header"}}
+{{- if .Required}}
+        request.header({{quote .Name}}, String.valueOf({{.ParameterName}}));
+{{- else}}
+        if ({{.ParameterName}} != null) {
+            request.header({{quote .Name}}, String.valueOf({{.ParameterName}}));
+        }
+{{- end}}
+{{- end}}
+{{- if .Body}}
+        request.header("Content-Type", "application/json");
+        request.method({{quote .HTTPMethod}}, HttpRequest.BodyPublishers.ofByteArray(mapper.writeValueAsBytes({{.Body.ParameterName}})));
+{{- else}}
+        request.method({{quote .HTTPMethod}}, HttpRequest.BodyPublishers.noBody());
+{{- end}}
+{{- if .Result}}
+        return send(request, new TypeReference<{{.Result}}>() {});
+{{- else}}
+        send(request, null);
+{{- end}}
+    }
+{{end}}
+    private HttpRequest.Builder newRequest(String path, StringBuilder query) {
+        HttpRequest.Builder request = HttpRequest.newBuilder(URI.create(baseUri + path + query));
+        headers.forEach(request::header);
+        return request;
+    }
+
+    private <T> T send(HttpRequest.Builder request, TypeReference<T> type) throws IOException, InterruptedException {
+        HttpResponse<byte[]> response = httpClient.send(request.build(), HttpResponse.BodyHandlers.ofByteArray());
+        if (response.statusCode() < 200 || response.statusCode() >= 300) {
+            throw new ApiException(response.statusCode(), response.body());
+        }
+        if (type == null) {
+            return null;
+        }
+        return mapper.readValue(response.body(), type);
+    }
+
+    private static void addQuery(StringBuilder query, String name, Object value) {
+        if (value == null) {
+            return;
+        }
+        if (value instanceof Iterable) {
+            for (Object element : (Iterable<?>) value) {
+                addQuery(query, name, element);
+            }
+            return;
+        }
+        query.append(query.length() == 0 ? "?" : "&").append(escape(name)).append("=").append(escape(value));
+    }
+
+    private static String escape(Object value) {
+        return URLEncoder.encode(String.valueOf(value), StandardCharsets.UTF_8).replace("+", "%20");
+    }
+}
+{{end}}
+`
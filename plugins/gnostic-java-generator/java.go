@@ -0,0 +1,116 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/plugins/internal/clientgen"
+)
+
+// Java keywords and literals, which get underscore suffixes when they are
+// used as names.
+var keywords = map[string]bool{}
+
+func init() {
+	for _, keyword := range strings.Fields(`
+		abstract assert boolean break byte case catch char class const continue
+		default do double else enum extends final finally float for goto if
+		implements import instanceof int interface long native new package
+		private protected public return short static strictfp super switch
+		synchronized this throw throws transient try void volatile while
+		true false null var record yield`) {
+		keywords[keyword] = true
+	}
+}
+
+// Names of the classes that are generated for every API or that are
+// used by generated classes.
+var reservedTypeNames = map[string]bool{}
+
+func init() {
+	for _, name := range strings.Fields(`
+		ApiException ArrayList Boolean Client Double Error Exception Float
+		HashMap HttpClient HttpRequest HttpResponse IOException Integer
+		Iterable JsonCreator JsonInclude JsonNode JsonProperty JsonValue
+		LinkedHashMap List Long Map Object ObjectMapper Objects Override
+		StandardCharsets String StringBuilder TypeReference URI URLEncoder`) {
+		reservedTypeNames[name] = true
+	}
+}
+
+// java describes Java types and names to clientgen.
+type java struct{}
+
+func (java) Scalar(typeName, format string) string {
+	switch typeName {
+	case "integer":
+		if format == "int32" {
+			return "Integer"
+		}
+		return "Long"
+	case "number":
+		if format == "float" {
+			return "Float"
+		}
+		return "Double"
+	case "boolean":
+		return "Boolean"
+	}
+	return "String"
+}
+
+func (java) Array(element string) string {
+	return "List<" + element + ">"
+}
+
+func (java) Map(value string) string {
+	return "Map<String, " + value + ">"
+}
+
+func (java) Any() string {
+	return "JsonNode"
+}
+
+func (java) TypeName(name string) string {
+	name = clientgen.UpperCamel(name)
+	if reservedTypeNames[name] {
+		name += "Model"
+	}
+	return name
+}
+
+func (java) MemberName(name string) string {
+	return escape(clientgen.LowerCamel(name))
+}
+
+func (java) CaseName(value, name string) string {
+	if name == "" {
+		name = value
+	}
+	return clientgen.UpperSnake(name)
+}
+
+func (java) Locals() []string {
+	return []string{"query", "request"}
+}
+
+// escape adds underscore suffixes to keywords.
+func escape(name string) string {
+	if keywords[name] {
+		return name + "_"
+	}
+	return name
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-java-generator is a plugin that generates a Maven project with a
+// client of an API and the classes of its requests and responses.
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/clientgen"
+	surface "github.com/google/gnostic/surface"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	base := filepath.Base(env.Request.SourceName)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	packageName := packageNameForFile(base)
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "package" {
+			packageName = parameter.Value
+		}
+	}
+
+	for _, m := range env.Request.Models {
+		if m.TypeUrl != "surface.v1.Model" {
+			continue
+		}
+		model := &surface.Model{}
+		err = proto.Unmarshal(m.Value, model)
+		env.RespondAndExitIfError(err)
+		api, warnings := clientgen.NewAPI(model, java{})
+		for _, warning := range warnings {
+			env.Response.Messages = append(env.Response.Messages, &plugins.Message{
+				Level: plugins.Message_WARNING,
+				Code:  "UNSUPPORTED",
+				Text:  warning,
+			})
+		}
+		files, err := generate(api, packageName)
+		env.RespondAndExitIfError(err)
+		env.Response.Files = append(env.Response.Files, files...)
+	}
+
+	env.RespondAndExit()
+}
+
+// Returns a Java package name made from the letters and digits of a file name.
+func packageNameForFile(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return -1
+	}, name)
+	if name == "" || name[0] >= '0' && name[0] <= '9' || keywords[name] {
+		name = "api" + name
+	}
+	return name
+}
@@ -21,11 +21,13 @@ import (
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
 
 	openapiv2 "github.com/google/gnostic/openapiv2"
 	openapiv3 "github.com/google/gnostic/openapiv3"
 	plugins "github.com/google/gnostic/plugins"
 	surface "github.com/google/gnostic/surface"
+	workspace "github.com/google/gnostic/workspace"
 )
 
 func main() {
@@ -33,28 +35,9 @@ func main() {
 	env.RespondAndExitIfError(err)
 
 	if env.Verbose {
-		for _, model := range env.Request.Models {
-			log.Printf("model %s", model.TypeUrl)
-			switch model.TypeUrl {
-			case "openapi.v2.Document":
-				document := &openapiv2.Document{}
-				err = proto.Unmarshal(model.Value, document)
-				if err == nil {
-					log.Printf("%+v", document)
-				}
-			case "openapi.v3.Document":
-				document := &openapiv3.Document{}
-				err = proto.Unmarshal(model.Value, document)
-				if err == nil {
-					log.Printf("%+v", document)
-				}
-			case "surface.v1.Model":
-				document := &surface.Model{}
-				err = proto.Unmarshal(model.Value, document)
-				if err == nil {
-					log.Printf("%+v", document)
-				}
-			}
+		for _, document := range env.Request.AllDocuments() {
+			log.Printf("document %s", document.SourceName)
+			logModels(document.Models)
 		}
 	}
 
@@ -78,3 +61,36 @@ func main() {
 	}
 	env.RespondAndExit()
 }
+
+// Log the contents of the models of a document.
+func logModels(models []*any.Any) {
+	for _, model := range models {
+		log.Printf("model %s", model.TypeUrl)
+		switch model.TypeUrl {
+		case "openapi.v2.Document":
+			document := &openapiv2.Document{}
+			err := proto.Unmarshal(model.Value, document)
+			if err == nil {
+				log.Printf("%+v", document)
+			}
+		case "openapi.v3.Document":
+			document := &openapiv3.Document{}
+			err := proto.Unmarshal(model.Value, document)
+			if err == nil {
+				log.Printf("%+v", document)
+			}
+		case "surface.v1.Model":
+			document := &surface.Model{}
+			err := proto.Unmarshal(model.Value, document)
+			if err == nil {
+				log.Printf("%+v", document)
+			}
+		case "gnostic.workspace.v1.Workspace":
+			ws := &workspace.Workspace{}
+			err := proto.Unmarshal(model.Value, ws)
+			if err == nil {
+				log.Printf("%+v", ws)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-proto is a plugin that generates a .proto file that describes an
+// API with gRPC services that are annotated for HTTP transcoding.
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/conversions"
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/protogen"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	packageName := ""
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "package" {
+			packageName = parameter.Value
+		}
+	}
+
+	var document *openapiv3.Document
+	for _, model := range env.Request.Models {
+		switch model.TypeUrl {
+		case "openapi.v2.Document":
+			documentv2 := &openapiv2.Document{}
+			err = proto.Unmarshal(model.Value, documentv2)
+			env.RespondAndExitIfError(err)
+			document, err = conversions.OpenAPIv3FromOpenAPIv2(documentv2)
+			env.RespondAndExitIfError(err)
+		case "openapi.v3.Document":
+			document = &openapiv3.Document{}
+			err = proto.Unmarshal(model.Value, document)
+			env.RespondAndExitIfError(err)
+		}
+	}
+
+	if document != nil {
+		base := filepath.Base(env.Request.SourceName)
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+		env.Response.Files = append(env.Response.Files, &plugins.File{
+			Name: filepath.Join(filepath.Dir(env.Request.SourceName), base+".proto"),
+			Data: protogen.NewFile(document, packageName).Bytes(),
+		})
+	}
+
+	env.RespondAndExit()
+}
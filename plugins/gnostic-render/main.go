@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-render is a plugin that renders a directory of Go templates with
+// the surface model and the document of an API.
+package main
+
+import (
+	"github.com/golang/protobuf/proto"
+	yaml "gopkg.in/yaml.v3"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+	surface "github.com/google/gnostic/surface"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	data := &Data{
+		SourceName: env.Request.SourceName,
+		Parameters: make(map[string]string),
+	}
+	for _, parameter := range env.Request.Parameters {
+		data.Parameters[parameter.Name] = parameter.Value
+	}
+
+	var root *yaml.Node
+	for _, m := range env.Request.Models {
+		switch m.TypeUrl {
+		case "openapi.v2.Document":
+			document := &openapiv2.Document{}
+			err = proto.Unmarshal(m.Value, document)
+			env.RespondAndExitIfError(err)
+			data.Document = document
+			root = document.ToRawInfo()
+		case "openapi.v3.Document":
+			document := &openapiv3.Document{}
+			err = proto.Unmarshal(m.Value, document)
+			env.RespondAndExitIfError(err)
+			data.Document = document
+			root = document.ToRawInfo()
+		case "surface.v1.Model":
+			data.Model = &surface.Model{}
+			err = proto.Unmarshal(m.Value, data.Model)
+			env.RespondAndExitIfError(err)
+		}
+	}
+	if root != nil {
+		err = root.Decode(&data.Raw)
+		env.RespondAndExitIfError(err)
+	}
+
+	files, err := render(data.Parameters["templates"], data)
+	env.RespondAndExitIfError(err)
+	env.Response.Files = append(env.Response.Files, files...)
+
+	env.RespondAndExit()
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	yaml "gopkg.in/yaml.v3"
+
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/clientgen"
+	surface "github.com/google/gnostic/surface"
+)
+
+// Data is the value that templates are executed with.
+type Data struct {
+	// SourceName is the name of the API description.
+	SourceName string
+	// Parameters are the parameters of the plugin invocation.
+	Parameters map[string]string
+	// Model is the surface model of the API.
+	Model *surface.Model
+	// Document is the *openapiv2.Document or *openapiv3.Document of the API.
+	Document interface{}
+	// Raw is the document as maps, slices, and scalars, like the values
+	// that JSON and YAML decoders return.
+	Raw interface{}
+}
+
+// Templates have this extension, which is removed from the names of the
+// files that they render. Other files are copied.
+const templateExtension = ".tmpl"
+
+// render renders the templates in a directory. Files and directories whose
+// names start with underscores aren't written, but templates in them can
+// be used by other templates. The names of files can also be templates,
+// and files whose names or directories render as empty strings, apart
+// from their extensions, aren't written.
+func render(dir string, data *Data) ([]*plugins.File, error) {
+	if dir == "" {
+		return nil, errors.New("the templates parameter must name a directory of templates")
+	}
+	type source struct {
+		name string
+		text []byte
+		mode os.FileMode
+	}
+	var sources []*source
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		text, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, &source{name: filepath.ToSlash(name), text: text, mode: info.Mode()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Parse all templates before rendering any so that they can use each other.
+	set := template.New("").Option("missingkey=zero").Funcs(funcs)
+	for _, s := range sources {
+		if strings.HasSuffix(s.name, templateExtension) {
+			if _, err := set.New(s.name).Parse(string(s.text)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var files []*plugins.File
+	for _, s := range sources {
+		if partial(s.name) {
+			continue
+		}
+		name, err := renderName(s.name, data)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+		file := &plugins.File{Name: name, Data: s.text, Executable: s.mode&0111 != 0}
+		if strings.HasSuffix(s.name, templateExtension) {
+			file.Name = strings.TrimSuffix(name, templateExtension)
+			var b bytes.Buffer
+			if err := set.ExecuteTemplate(&b, s.name, data); err != nil {
+				return nil, err
+			}
+			file.Data = b.Bytes()
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// partial returns true if a file or one of its directories starts with
+// an underscore.
+func partial(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// renderName renders a file name that contains template actions.
+func renderName(name string, data *Data) (string, error) {
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+	t, err := template.New(name).Option("missingkey=zero").Funcs(funcs).Parse(name)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	rendered := b.String()
+	parts := strings.Split(name, "/")
+	renderedParts := strings.Split(rendered, "/")
+	for i, part := range renderedParts {
+		switch {
+		case part == "":
+			return "", nil
+		case part == "..":
+			return "", fmt.Errorf("%s renders as %s, which is outside of the output directory", name, rendered)
+		case len(parts) == len(renderedParts) && strings.HasPrefix(part, ".") && strings.HasPrefix(parts[i], "{{"):
+			// a name that is empty apart from its extension
+			return "", nil
+		}
+	}
+	return rendered, nil
+}
+
+// Functions that templates can call in addition to the builtin functions.
+var funcs = template.FuncMap{
+	"upperCamel": clientgen.UpperCamel,
+	"lowerCamel": clientgen.LowerCamel,
+	"upperSnake": clientgen.UpperSnake,
+	"lowerSnake": func(name string) string {
+		return strings.ToLower(clientgen.UpperSnake(name))
+	},
+	"words":      clientgen.Words,
+	"lines":      clientgen.Lines,
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"quote":      strconv.Quote,
+	"indent":     indent,
+	"default": func(value, fallback interface{}) interface{} {
+		if value == nil || value == "" {
+			return fallback
+		}
+		return value
+	},
+	"keys": keys,
+	"json": func(v interface{}) (string, error) {
+		b, err := json.MarshalIndent(v, "", "  ")
+		return string(b), err
+	},
+	"yaml": func(v interface{}) (string, error) {
+		b, err := yaml.Marshal(v)
+		return string(b), err
+	},
+	"type":   modelType,
+	"method": modelMethod,
+}
+
+// indent adds spaces to the start of each line but the first, so that
+// values can be written after indented keys.
+func indent(spaces int, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", spaces))
+}
+
+// keys returns the sorted keys of a map from a document.
+func keys(m map[string]interface{}) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// modelType returns the type of a surface model with a name.
+func modelType(model *surface.Model, name string) *surface.Type {
+	for _, t := range model.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// modelMethod returns the method of a surface model with a name.
+func modelMethod(model *surface.Model, name string) *surface.Method {
+	for _, m := range model.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
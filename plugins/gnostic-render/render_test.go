@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func testPlugin(t *testing.T, parameters string, inputFile string, outputFile string, referenceFile string) {
+	// remove any preexisting output files
+	os.Remove(outputFile)
+	// run the compiler
+	output, err := exec.Command(
+		"gnostic",
+		"--render-out="+parameters+"-",
+		inputFile).Output()
+	if err != nil {
+		t.Logf("Compile failed: %+v", err)
+		t.FailNow()
+	}
+	_ = ioutil.WriteFile(outputFile, output, 0644)
+	err = exec.Command("diff", outputFile, referenceFile).Run()
+	if err != nil {
+		t.Logf("Diff failed: %s vs %s %+v", outputFile, referenceFile, err)
+		t.FailNow()
+	} else {
+		// if the test succeeded, clean up
+		os.Remove(outputFile)
+	}
+}
+
+func TestRenderWithV2(t *testing.T) {
+	testPlugin(t, "templates=testdata/templates,package=petstore,backend=pets.internal:", "../../examples/v2.0/yaml/petstore.yaml", "render-v2.out", "testdata/v2.txt")
+}
+
+func TestRenderWithV3(t *testing.T) {
+	testPlugin(t, "templates=testdata/templates:", "../../examples/v3.0/yaml/petstore.yaml", "render-v3.out", "testdata/v3.txt")
+}
+
+func TestRenderName(t *testing.T) {
+	data := &Data{Parameters: map[string]string{"package": "petstore"}}
+	for _, test := range []struct {
+		name     string
+		rendered string
+		err      bool
+	}{
+		{"routes.md.tmpl", "routes.md.tmpl", false},
+		{"{{.Parameters.package}}/types.go.tmpl", "petstore/types.go.tmpl", false},
+		{"{{.Parameters.missing}}/types.go.tmpl", "", false},
+		{"{{.Parameters.missing}}.txt", "", false},
+		{"{{if .Parameters.missing}}types.go{{end}}", "", false},
+		{"{{.Parameters.package}}/../../escape.txt", "", true},
+	} {
+		rendered, err := renderName(test.name, data)
+		if (err != nil) != test.err {
+			t.Errorf("renderName(%q) returned error %v", test.name, err)
+		}
+		if rendered != test.rendered {
+			t.Errorf("renderName(%q) = %q, want %q", test.name, rendered, test.rendered)
+		}
+	}
+}
+
+func TestRenderWithoutTemplates(t *testing.T) {
+	if _, err := render("", &Data{}); err == nil {
+		t.Errorf("render succeeded without a templates directory")
+	}
+}
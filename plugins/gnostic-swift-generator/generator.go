@@ -0,0 +1,311 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"text/template"
+
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/clientgen"
+)
+
+// The generated package.
+type swiftPackage struct {
+	Module string
+	*clientgen.API
+}
+
+// generate returns the files of a Swift package with a client of an API.
+// The names of the files are relative to the root of the package.
+func generate(api *clientgen.API, module string) ([]*plugins.File, error) {
+	p := &swiftPackage{Module: module, API: api}
+	var files []*plugins.File
+	for _, f := range []struct{ name, template string }{
+		{"Package.swift", "package"},
+		{"Sources/" + module + "/Types.swift", "types"},
+		{"Sources/" + module + "/Client.swift", "client"},
+	} {
+		var b bytes.Buffer
+		if err := templates.ExecuteTemplate(&b, f.template, p); err != nil {
+			return nil, err
+		}
+		files = append(files, &plugins.File{Name: f.name, Data: b.Bytes()})
+	}
+	return files, nil
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"comment":    comment,
+	"quote":      quote,
+	"path":       path,
+	"codingKeys": codingKeys,
+	"unescape":   unescape,
+}).Parse(swiftTemplates))
+
+// comment returns the lines of a description as a documentation comment.
+func comment(indent, description string) string {
+	var b strings.Builder
+	for _, line := range clientgen.Lines(description) {
+		b.WriteString(strings.TrimRight(indent+"/// "+line, " ") + "\n")
+	}
+	return b.String()
+}
+
+// quote returns a Swift string literal.
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// path returns a Swift string literal that interpolates the path
+// parameters of a method.
+func path(parts []*clientgen.PathPart) string {
+	var b strings.Builder
+	b.WriteString(`"`)
+	for _, part := range parts {
+		if part.Parameter != nil {
+			b.WriteString(`\(Client.escape(` + part.Parameter.ParameterName + `))`)
+		} else {
+			literal := strconv.Quote(part.Literal)
+			b.WriteString(literal[1 : len(literal)-1])
+		}
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+// codingKeys returns true if a struct has fields whose names differ from
+// their names in JSON.
+func codingKeys(t *clientgen.Type) bool {
+	for _, f := range t.Fields {
+		if unescape(f.FieldName) != f.Name {
+			return true
+		}
+	}
+	return false
+}
+
+const swiftTemplates = `
+{{- define "package" -}}
+// swift-tools-version:5.5
+// Code generated by gnostic-swift-generator. DO NOT EDIT.
+
+import PackageDescription
+
+let package = Package(
+    name: {{quote .Module}},
+    platforms: [.macOS(.v12), .iOS(.v15)],
+    products: [
+        .library(name: {{quote .Module}}, targets: [{{quote .Module}}]),
+    ],
+    targets: [
+        .target(name: {{quote .Module}}),
+    ]
+)
+{{end}}
+
+{{- define "types" -}}
+// Code generated by gnostic-swift-generator. DO NOT EDIT.
+
+import Foundation
+{{range .Types}}
+{{comment "" .Description}}{{if eq .Kind "struct" -}}
+public struct {{.Name}}: Codable, Equatable {
+{{- range .Fields}}
+    public var {{.FieldName}}: {{.Type}}?
+{{- end}}
+
+    public init({{range $i, $f := .Fields}}{{if $i}}, {{end}}{{$f.FieldName}}: {{$f.Type}}? = nil{{end}}) {
+{{- range .Fields}}
+        self.{{.FieldName}} = {{.FieldName}}
+{{- end}}
+    }
+{{- if codingKeys .}}
+
+    enum CodingKeys: String, CodingKey {
+{{- range .Fields}}
+        case {{.FieldName}}{{if ne (unescape .FieldName) .Name}} = {{quote .Name}}{{end}}
+{{- end}}
+    }
+{{- end}}
+}
+{{else if eq .Kind "enum" -}}
+public enum {{.Name}}: String, Codable, CustomStringConvertible {
+{{- range .Cases}}
+    case {{.Name}}{{if ne (unescape .Name) .Value}} = {{quote .Value}}{{end}}
+{{- end}}
+
+    public var description: String {
+        rawValue
+    }
+}
+{{else if eq .Kind "array" -}}
+public typealias {{.Name}} = [{{.Element}}]
+{{else -}}
+public typealias {{.Name}} = [String: {{.Element}}]
+{{end -}}
+{{end -}}
+{{if .UsesAny}}
+/// A JSON value of any type.
+public enum JSONValue: Codable, Equatable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .null:
+            try container.encodeNil()
+        case .bool(let value):
+            try container.encode(value)
+        case .number(let value):
+            try container.encode(value)
+        case .string(let value):
+            try container.encode(value)
+        case .array(let value):
+            try container.encode(value)
+        case .object(let value):
+            try container.encode(value)
+        }
+    }
+}
+{{end -}}
+{{end}}
+
+{{- define "client" -}}
+// Code generated by gnostic-swift-generator. DO NOT EDIT.
+
+import Foundation
+#if canImport(FoundationNetworking)
+import FoundationNetworking
+#endif
+
+/// An error response of {{.Name}}.
+public struct APIError: Swift.Error {
+    /// The HTTP status code of the response.
+    public let statusCode: Int
+    /// The body of the response.
+    public let body: Data
+}
+
+/// A client of {{.Name}}.
+public class Client {
+    /// The URL that the paths of requests are appended to.
+    public let baseURL: URL
+    /// The session that sends requests.
+    public let session: URLSession
+    /// Headers that are sent with every request, for example for authentication.
+    public var headers: [String: String] = [:]
+
+    public init(baseURL: URL, session: URLSession = .shared) {
+        self.baseURL = baseURL
+        self.session = session
+    }
+{{range .Methods}}
+{{comment "    " .Description}}    public func {{.Name}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{$p.ParameterName}}: {{$p.Type}}{{if not $p.Required}}? = nil{{end}}{{end}}) async throws{{if .Result}} -> {{.Result}}{{end}} {
+        {{if .In "query"}}var{{else}}let{{end}} queryItems: [URLQueryItem] = []
+{{- range .In "query"}}
+{{- if .Array}}
+        for value in {{.ParameterName}}{{if not .Required}} ?? []{{end}} {
+            queryItems.append(URLQueryItem(name: {{quote .Name}}, value: value.description))
+        }
+{{- else if .Required}}
+        queryItems.append(URLQueryItem(name: {{quote .Name}}, value: {{.ParameterName}}.description))
+{{- else}}
+        if let value = {{.ParameterName}} {
+            queryItems.append(URLQueryItem(name: {{quote .Name}}, value: value.description))
+        }
+{{- end}}
+{{- end}}
+        {{if or (.In "header") .Body}}var{{else}}let{{end}} request = try makeRequest(method: {{quote .HTTPMethod}}, path: {{path .Path}}, queryItems: queryItems)
+{{- range .In "header"}}
+{{- if .Required}}
+        request.setValue({{.ParameterName}}.description, forHTTPHeaderField: {{quote .Name}})
+{{- else}}
+        if let value = {{.ParameterName}} {
+            request.setValue(value.description, forHTTPHeaderField: {{quote .Name}})
+        }
+{{- end}}
+{{- end}}
+{{- with .Body}}
+        request.setValue("application/json", forHTTPHeaderField: "Content-Type")
+        request.httpBody = try JSONEncoder().encode({{.ParameterName}})
+{{- end}}
+{{- if .Result}}
+        let data = try await send(request)
+        return try JSONDecoder().decode({{.Result}}.self, from: data)
+{{- else}}
+        _ = try await send(request)
+{{- end}}
+    }
+{{end}}
+    private func makeRequest(method: String, path: String, queryItems: [URLQueryItem]) throws -> URLRequest {
+        guard var components = URLComponents(string: baseURL.absoluteString + path) else {
+            throw URLError(.badURL)
+        }
+        if !queryItems.isEmpty {
+            components.queryItems = queryItems
+        }
+        guard let url = components.url else {
+            throw URLError(.badURL)
+        }
+        var request = URLRequest(url: url)
+        request.httpMethod = method
+        for (name, value) in headers {
+            request.setValue(value, forHTTPHeaderField: name)
+        }
+        return request
+    }
+
+    private func send(_ request: URLRequest) async throws -> Data {
+        let (data, response) = try await session.data(for: request)
+        if let response = response as? HTTPURLResponse, !(200..<300).contains(response.statusCode) {
+            throw APIError(statusCode: response.statusCode, body: data)
+        }
+        return data
+    }
+
+    private static let pathAllowed = CharacterSet.urlPathAllowed.subtracting(CharacterSet(charactersIn: "/"))
+
+    static func escape(_ value: CustomStringConvertible) -> String {
+        let s = value.description
+        return s.addingPercentEncoding(withAllowedCharacters: pathAllowed) ?? s
+    }
+}
+{{end}}
+`
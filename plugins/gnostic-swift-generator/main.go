@@ -0,0 +1,75 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-swift-generator is a plugin that generates a Swift package with
+// a client of an API and the types of its requests and responses.
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/clientgen"
+	surface "github.com/google/gnostic/surface"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	base := filepath.Base(env.Request.SourceName)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	module := moduleNameForFile(base)
+	for _, parameter := range env.Request.Parameters {
+		if parameter.Name == "package" {
+			module = parameter.Value
+		}
+	}
+
+	for _, m := range env.Request.Models {
+		if m.TypeUrl != "surface.v1.Model" {
+			continue
+		}
+		model := &surface.Model{}
+		err = proto.Unmarshal(m.Value, model)
+		env.RespondAndExitIfError(err)
+		api, warnings := clientgen.NewAPI(model, swift{})
+		for _, warning := range warnings {
+			env.Response.Messages = append(env.Response.Messages, &plugins.Message{
+				Level: plugins.Message_WARNING,
+				Code:  "UNSUPPORTED",
+				Text:  warning,
+			})
+		}
+		files, err := generate(api, module)
+		env.RespondAndExitIfError(err)
+		env.Response.Files = append(env.Response.Files, files...)
+	}
+
+	env.RespondAndExit()
+}
+
+// Returns a Swift module name made from the words of a file name.
+func moduleNameForFile(name string) string {
+	name = clientgen.UpperCamel(name)
+	// Names that aren't identifiers start with underscores.
+	if strings.HasPrefix(name, "_") {
+		name = "API" + name[1:]
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/plugins/internal/clientgen"
+)
+
+// Swift keywords, which are escaped with backticks when they are used as names.
+var keywords = map[string]bool{}
+
+func init() {
+	for _, keyword := range strings.Fields(`
+		associatedtype class deinit enum extension fileprivate func import init
+		inout internal let open operator private protocol public rethrows static
+		struct subscript typealias var break case continue default defer do else
+		fallthrough for guard if in repeat return switch where while as Any catch
+		false is nil super self Self throw throws true try`) {
+		keywords[keyword] = true
+	}
+}
+
+// Names of the types that are generated for every API.
+var reservedTypeNames = map[string]bool{
+	"APIError":  true,
+	"Client":    true,
+	"Error":     true,
+	"JSONValue": true,
+	"Type":      true,
+	"Protocol":  true,
+}
+
+// swift describes Swift types and names to clientgen.
+type swift struct{}
+
+func (swift) Scalar(typeName, format string) string {
+	switch typeName {
+	case "integer":
+		switch format {
+		case "int32":
+			return "Int32"
+		case "int64":
+			return "Int64"
+		}
+		return "Int"
+	case "number":
+		if format == "float" {
+			return "Float"
+		}
+		return "Double"
+	case "boolean":
+		return "Bool"
+	}
+	return "String"
+}
+
+func (swift) Array(element string) string {
+	return "[" + element + "]"
+}
+
+func (swift) Map(value string) string {
+	return "[String: " + value + "]"
+}
+
+func (swift) Any() string {
+	return "JSONValue"
+}
+
+func (swift) TypeName(name string) string {
+	name = clientgen.UpperCamel(name)
+	if keywords[name] || reservedTypeNames[name] {
+		name += "Model"
+	}
+	return name
+}
+
+func (swift) MemberName(name string) string {
+	return escape(clientgen.LowerCamel(name))
+}
+
+func (swift) CaseName(value, name string) string {
+	if name == "" {
+		name = value
+	}
+	return escape(clientgen.LowerCamel(name))
+}
+
+func (swift) Locals() []string {
+	return []string{"queryItems", "request", "data"}
+}
+
+// escape escapes keywords with backticks.
+func escape(name string) string {
+	if keywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// unescape removes the backticks of escaped keywords.
+func unescape(name string) string {
+	return strings.Trim(name, "`")
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+
+	plugins "github.com/google/gnostic/plugins"
+)
+
+// The generated package.
+type providerPackage struct {
+	Package string
+	*provider
+}
+
+// A file with a resource.
+type resourceFile struct {
+	Package string
+	*resource
+}
+
+// generate returns the Go files of a provider package. The lifecycle
+// functions of resources are left for the author of the provider to
+// implement.
+func generate(p *provider, packageName string) ([]*plugins.File, error) {
+	var files []*plugins.File
+	add := func(name, template string, data interface{}) error {
+		var b bytes.Buffer
+		if err := templates.ExecuteTemplate(&b, template, data); err != nil {
+			return err
+		}
+		source, err := format.Source(b.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		files = append(files, &plugins.File{Name: name, Data: source})
+		return nil
+	}
+	if err := add("provider.go", "provider", &providerPackage{Package: packageName, provider: p}); err != nil {
+		return nil, err
+	}
+	for _, r := range p.Resources {
+		if err := add("resource_"+r.Noun+".go", "resource", &resourceFile{Package: packageName, resource: r}); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote":      strconv.Quote,
+	"schema":     schemaMap,
+	"validation": usesValidation,
+}).Parse(terraformTemplates))
+
+// schemaMap returns a Go expression for the schema of attributes.
+func schemaMap(attributes []*attribute) string {
+	var b strings.Builder
+	b.WriteString("map[string]*schema.Schema{\n")
+	for _, a := range attributes {
+		b.WriteString(strconv.Quote(a.Name) + ": " + schemaLiteral(a) + ",\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// schemaLiteral returns a composite literal for the schema of an attribute.
+func schemaLiteral(a *attribute) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	b.WriteString("Type: schema." + a.Type + ",\n")
+	if a.Description != "" {
+		b.WriteString("Description: " + strconv.Quote(a.Description) + ",\n")
+	}
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"Required", a.Required},
+		{"Optional", a.Optional},
+		{"Computed", a.Computed},
+		{"ForceNew", a.ForceNew},
+	} {
+		if flag.set {
+			b.WriteString(flag.name + ": true,\n")
+		}
+	}
+	if a.MaxItems > 0 {
+		b.WriteString("MaxItems: " + strconv.Itoa(a.MaxItems) + ",\n")
+	}
+	if len(a.Enum) > 0 {
+		var values []string
+		for _, value := range a.Enum {
+			values = append(values, strconv.Quote(value))
+		}
+		b.WriteString("ValidateFunc: validation.StringInSlice([]string{" + strings.Join(values, ", ") + "}, false),\n")
+	}
+	switch {
+	case a.Block != nil:
+		b.WriteString("Elem: &schema.Resource{\nSchema: " + schemaMap(a.Block) + ",\n},\n")
+	case a.Elem != nil:
+		b.WriteString("Elem: &schema.Schema" + schemaLiteral(a.Elem) + ",\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// usesValidation returns true if the schema of attributes validates values.
+func usesValidation(attributes []*attribute) bool {
+	for _, a := range attributes {
+		if len(a.Enum) > 0 || a.Elem != nil && usesValidation([]*attribute{a.Elem}) || usesValidation(a.Block) {
+			return true
+		}
+	}
+	return false
+}
+
+const terraformTemplates = `
+{{- define "provider" -}}
+// This file was generated by gnostic-terraform as a starting point for a
+// Terraform provider of {{.Title}}.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the {{.Name}} provider.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"base_url": {
+				Type:        schema.TypeString,
+				Description: "The URL that the paths of API requests are appended to.",
+{{- if .BaseURL}}
+				Optional:    true,
+				Default:     {{quote .BaseURL}},
+{{- else}}
+				Required:    true,
+{{- end}}
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+{{- range .Resources}}
+			{{quote .Type}}: resource{{.Name}}(),
+{{- end}}
+		},
+		ConfigureContextFunc: configure,
+	}
+}
+
+// config is the meta argument of the lifecycle functions of resources.
+type config struct {
+	BaseURL string
+}
+
+func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return &config{BaseURL: d.Get("base_url").(string)}, nil
+}
+{{end}}
+
+{{- define "operation" -}}
+{{.Method}} {{.Path}} ({{.Operation}})
+{{- end}}
+
+{{- define "resource" -}}
+// This file was generated by gnostic-terraform as a starting point for a
+// Terraform resource.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+{{- if validation .Attributes}}
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+{{- end}}
+)
+
+// resource{{.Name}} manages {{.Noun}} resources with these operations:
+//
+//	create: {{template "operation" .Create}}
+//	read:   {{template "operation" .Read}}
+{{- with .Update}}
+//	update: {{template "operation" .}}
+{{- end}}
+//	delete: {{template "operation" .Delete}}
+func resource{{.Name}}() *schema.Resource {
+	return &schema.Resource{
+{{- with .Description}}
+		Description:   {{quote .}},
+{{- end}}
+		CreateContext: resource{{.Name}}Create,
+		ReadContext:   resource{{.Name}}Read,
+{{- if .Update}}
+		UpdateContext: resource{{.Name}}Update,
+{{- end}}
+		DeleteContext: resource{{.Name}}Delete,
+		Schema: {{schema .Attributes}},
+	}
+}
+
+func resource{{.Name}}Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// TODO: Call {{template "operation" .Create}}.
+	// Send the attributes of the resource, set the ID of the resource to the
+	// {{.ID}} path parameter of the new {{.Noun}} with d.SetId, and return
+	// resource{{.Name}}Read(ctx, d, meta).
+	return diag.Errorf("{{.Create.Operation}} is not implemented")
+}
+
+func resource{{.Name}}Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// TODO: Call {{template "operation" .Read}}.
+	// Set the attributes of the resource with d.Set. If the {{.Noun}} doesn't
+	// exist, remove the resource with d.SetId("").
+	return diag.Errorf("{{.Read.Operation}} is not implemented")
+}
+{{with .Update}}
+func resource{{$.Name}}Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// TODO: Call {{template "operation" .}}.
+	// Send the attributes that d.HasChange reports and return
+	// resource{{$.Name}}Read(ctx, d, meta).
+	return diag.Errorf("{{.Operation}} is not implemented")
+}
+{{end}}
+func resource{{.Name}}Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// TODO: Call {{template "operation" .Delete}}.
+	return diag.Errorf("{{.Delete.Operation}} is not implemented")
+}
+{{end}}
+`
@@ -0,0 +1,439 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/plugins/internal/clientgen"
+	surface "github.com/google/gnostic/surface"
+)
+
+// A provider has a resource for each collection of an API.
+type provider struct {
+	Name  string
+	Title string
+	// BaseURL is the URL of the first server of the API.
+	BaseURL   string
+	Resources []*resource
+}
+
+// A resource maps the operations of a collection and its items to the
+// lifecycle functions of a Terraform resource.
+type resource struct {
+	// Type is the Terraform resource type, like "bookstore_book".
+	Type string
+	// Name names the functions of the resource, like "Book".
+	Name string
+	// Noun describes an item of the collection, like "book".
+	Noun        string
+	Description string
+	Create      *surface.Method
+	Read        *surface.Method
+	Update      *surface.Method
+	Delete      *surface.Method
+	// ID is the path parameter that identifies items of the collection.
+	ID         string
+	Attributes []*attribute
+}
+
+// An attribute describes a schema.Schema of a resource. Attributes
+// without names describe the elements of lists and maps.
+type attribute struct {
+	Name        string
+	Description string
+	// Type is the name of a schema.ValueType, like "TypeString".
+	Type     string
+	Required bool
+	Optional bool
+	Computed bool
+	ForceNew bool
+	MaxItems int
+	// Elem describes the elements of lists and maps of values.
+	Elem *attribute
+	// Block describes the attributes of lists of objects.
+	Block []*attribute
+	// Enum lists the values that strings can have.
+	Enum []string
+}
+
+// Names that Terraform reserves for its own meta-arguments.
+var reservedNames = map[string]bool{
+	"connection":  true,
+	"count":       true,
+	"depends_on":  true,
+	"for_each":    true,
+	"id":          true,
+	"lifecycle":   true,
+	"provider":    true,
+	"provisioner": true,
+}
+
+type builder struct {
+	types    map[string]*surface.Type
+	schemas  map[string]interface{}
+	warnings []string
+}
+
+// newProvider finds the resources of an API. A collection is a resource
+// if it has a POST method and its items, at the collection's path followed
+// by a path parameter, have GET and DELETE methods. PUT or PATCH methods
+// of items update resources. Warnings describe collections that can be
+// created but that aren't resources.
+func newProvider(model *surface.Model, root *yaml.Node, name string) (*provider, []string) {
+	b := &builder{types: make(map[string]*surface.Type)}
+	for _, t := range model.Types {
+		b.types[t.Name] = t
+	}
+	var raw map[string]interface{}
+	if err := root.Decode(&raw); err == nil {
+		if definitions, ok := raw["definitions"].(map[string]interface{}); ok {
+			b.schemas = definitions
+		} else if components, ok := raw["components"].(map[string]interface{}); ok {
+			b.schemas, _ = components["schemas"].(map[string]interface{})
+		}
+	}
+	p := &provider{Name: name, Title: model.Name, BaseURL: baseURL(raw)}
+	names := make(map[string]bool)
+	for _, create := range model.Methods {
+		if create.Method != "POST" || strings.HasSuffix(create.Path, "}") {
+			continue
+		}
+		r := b.newResource(model, create)
+		if r == nil {
+			continue
+		}
+		// Collections with the same nouns get numbered names.
+		noun := r.Noun
+		for i := 2; names[noun]; i++ {
+			noun = r.Noun + "_" + strconv.Itoa(i)
+		}
+		names[noun] = true
+		r.Noun = noun
+		r.Type = name + "_" + noun
+		r.Name = clientgen.UpperCamel(noun)
+		p.Resources = append(p.Resources, r)
+	}
+	sort.Slice(p.Resources, func(i, j int) bool {
+		return p.Resources[i].Type < p.Resources[j].Type
+	})
+	return p, b.warnings
+}
+
+func (b *builder) newResource(model *surface.Model, create *surface.Method) *resource {
+	r := &resource{Create: create}
+	for _, m := range model.Methods {
+		id := itemParameter(create.Path, m.Path)
+		if id == "" {
+			continue
+		}
+		switch m.Method {
+		case "GET":
+			r.Read = m
+		case "DELETE":
+			r.Delete = m
+		case "PUT":
+			r.Update = m
+		case "PATCH":
+			if r.Update == nil {
+				r.Update = m
+			}
+		default:
+			continue
+		}
+		r.ID = id
+	}
+	if r.Read == nil || r.Delete == nil {
+		b.warnings = append(b.warnings, create.Path+" can be created with "+create.Operation+
+			" but its items can't be read and deleted, so it isn't a resource")
+		return nil
+	}
+	resultType := b.resultType(r.Read)
+	r.Noun = lowerSnake(resultType)
+	if r.Noun == "" {
+		segments := strings.Split(create.Path, "/")
+		r.Noun = singular(lowerSnake(segments[len(segments)-1]))
+	}
+	if s := b.schema(resultType); s != nil {
+		r.Description, _ = s["description"].(string)
+	}
+
+	// Path parameters of the collection identify the parents of items.
+	names := make(map[string]bool)
+	for _, f := range b.fields(create.ParametersTypeName) {
+		if f.Position == surface.Position_PATH {
+			a := b.value(f, make(map[string]bool))
+			a.Name = b.attributeName(r, f.Name)
+			a.Description = "The " + f.Name + " path parameter of " + create.Operation + "."
+			a.Required = true
+			a.ForceNew = true
+			names[a.Name] = true
+			r.Attributes = append(r.Attributes, a)
+		}
+	}
+	// Attributes are the fields of the request body. Fields that are only
+	// in responses are computed.
+	bodyType := b.bodyType(create)
+	for _, a := range b.attributes(bodyType, make(map[string]bool)) {
+		a.Name = b.attributeName(r, a.Name)
+		if !names[a.Name] {
+			names[a.Name] = true
+			r.Attributes = append(r.Attributes, a)
+		}
+	}
+	for _, a := range b.attributes(resultType, make(map[string]bool)) {
+		a.Name = b.attributeName(r, a.Name)
+		if !names[a.Name] {
+			names[a.Name] = true
+			a.Required = false
+			a.Optional = false
+			a.Computed = true
+			r.Attributes = append(r.Attributes, a)
+		}
+	}
+	if r.Update == nil {
+		// Resources that can't be updated are replaced when their
+		// attributes change.
+		for _, a := range r.Attributes {
+			if a.Required || a.Optional {
+				a.ForceNew = true
+			}
+		}
+	}
+	return r
+}
+
+// baseURL returns the URL of the first server of a v3 description or the
+// URL made from the first scheme, host, and base path of a v2 description.
+func baseURL(raw map[string]interface{}) string {
+	if servers, ok := raw["servers"].([]interface{}); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]interface{}); ok {
+			url, _ := server["url"].(string)
+			return url
+		}
+	}
+	host, _ := raw["host"].(string)
+	if host == "" {
+		return ""
+	}
+	scheme := "https"
+	if schemes, ok := raw["schemes"].([]interface{}); ok && len(schemes) > 0 {
+		if s, ok := schemes[0].(string); ok {
+			scheme = s
+		}
+	}
+	basePath, _ := raw["basePath"].(string)
+	return scheme + "://" + host + basePath
+}
+
+// itemParameter returns the name of the path parameter of an item path,
+// which is a collection path followed by a path parameter.
+func itemParameter(collection, item string) string {
+	rest := strings.TrimPrefix(item, collection+"/")
+	if rest == item || !strings.HasPrefix(rest, "{") || !strings.HasSuffix(rest, "}") || strings.Contains(rest, "/") {
+		return ""
+	}
+	return rest[1 : len(rest)-1]
+}
+
+// attributeName returns the Terraform name of a field. Names that
+// Terraform reserves are prefixed with the noun of the resource.
+func (b *builder) attributeName(r *resource, name string) string {
+	name = lowerSnake(name)
+	if reservedNames[name] {
+		name = r.Noun + "_" + name
+	}
+	return name
+}
+
+// fields returns the fields of a type of the model.
+func (b *builder) fields(typeName string) []*surface.Field {
+	if t := b.types[typeName]; t != nil {
+		return t.Fields
+	}
+	return nil
+}
+
+// bodyType returns the type of the JSON request body of a method.
+func (b *builder) bodyType(m *surface.Method) string {
+	for _, f := range b.fields(m.ParametersTypeName) {
+		if f.Name == "request_body" && f.Kind == surface.FieldKind_REFERENCE {
+			contents := b.fields(f.Type)
+			for _, content := range contents {
+				if content.Name == "application/json" {
+					return content.Type
+				}
+			}
+			if len(contents) > 0 {
+				return contents[0].Type
+			}
+		} else if f.Position == surface.Position_BODY {
+			return f.Type
+		}
+	}
+	return ""
+}
+
+// resultType returns the type of the first successful response of a method.
+func (b *builder) resultType(m *surface.Method) string {
+	for _, f := range b.fields(m.ResponsesTypeName) {
+		if strings.HasPrefix(f.Name, "2") && f.Kind == surface.FieldKind_REFERENCE {
+			return f.Type
+		}
+	}
+	return ""
+}
+
+// schema returns the schema of a type from the API description.
+func (b *builder) schema(typeName string) map[string]interface{} {
+	s, _ := b.schemas[typeName].(map[string]interface{})
+	return s
+}
+
+// attributes returns the attributes of the fields of a struct type.
+// Required fields are required, read-only fields are computed, and other
+// fields are optional.
+func (b *builder) attributes(typeName string, visited map[string]bool) []*attribute {
+	t := b.types[typeName]
+	if t == nil || t.Kind != surface.TypeKind_STRUCT {
+		return nil
+	}
+	visited[typeName] = true
+	defer delete(visited, typeName)
+	required := make(map[string]bool)
+	properties := make(map[string]interface{})
+	if s := b.schema(typeName); s != nil {
+		if names, ok := s["required"].([]interface{}); ok {
+			for _, name := range names {
+				if name, ok := name.(string); ok {
+					required[name] = true
+				}
+			}
+		}
+		properties, _ = s["properties"].(map[string]interface{})
+	}
+	var attributes []*attribute
+	for _, f := range t.Fields {
+		a := b.value(f, visited)
+		a.Name = lowerSnake(f.Name)
+		property, _ := properties[f.Name].(map[string]interface{})
+		if description, ok := property["description"].(string); ok {
+			a.Description = description
+		}
+		switch {
+		case property["readOnly"] == true:
+			a.Computed = true
+		case required[f.Name]:
+			a.Required = true
+		default:
+			a.Optional = true
+		}
+		attributes = append(attributes, a)
+	}
+	return attributes
+}
+
+// value returns an attribute for the values of a field.
+func (b *builder) value(f *surface.Field, visited map[string]bool) *attribute {
+	switch f.Kind {
+	case surface.FieldKind_ARRAY:
+		return b.list(b.named(f.Type, f.Format, visited))
+	case surface.FieldKind_MAP:
+		return b.mapOf(b.named(f.ValueType, f.ValueFormat, visited))
+	case surface.FieldKind_ANY:
+		return jsonString()
+	}
+	return b.named(f.Type, f.Format, visited)
+}
+
+// named returns an attribute for values of a scalar type or of a type of
+// the model.
+func (b *builder) named(name, format string, visited map[string]bool) *attribute {
+	switch name {
+	case "integer":
+		return &attribute{Type: "TypeInt"}
+	case "number":
+		return &attribute{Type: "TypeFloat"}
+	case "boolean":
+		return &attribute{Type: "TypeBool"}
+	case "string":
+		return &attribute{Type: "TypeString"}
+	}
+	t := b.types[name]
+	if t == nil || visited[name] {
+		// Unknown and recursive types are written as JSON.
+		return jsonString()
+	}
+	switch {
+	case t.Kind == surface.TypeKind_ENUM:
+		return &attribute{Type: "TypeString", Enum: t.EnumValues}
+	case len(t.Fields) == 1 && t.Fields[0].Name == "value" && t.Fields[0].Kind == surface.FieldKind_ARRAY,
+		len(t.Fields) == 1 && t.Fields[0].Name == "additional_properties" && t.Fields[0].Kind == surface.FieldKind_MAP:
+		// a schema of an array or of an object with only additional properties
+		return b.value(t.Fields[0], visited)
+	case t.Kind == surface.TypeKind_STRUCT:
+		return &attribute{Type: "TypeList", MaxItems: 1, Block: b.attributes(name, visited)}
+	}
+	return jsonString()
+}
+
+// list returns an attribute for lists of values.
+func (b *builder) list(element *attribute) *attribute {
+	if element.Block != nil {
+		return &attribute{Type: "TypeList", Block: element.Block}
+	}
+	return &attribute{Type: "TypeList", Elem: element}
+}
+
+// mapOf returns an attribute for maps of values. Terraform maps can only
+// have scalar values, so other values are written as JSON.
+func (b *builder) mapOf(value *attribute) *attribute {
+	switch value.Type {
+	case "TypeString", "TypeInt", "TypeFloat", "TypeBool":
+		return &attribute{Type: "TypeMap", Elem: value}
+	}
+	return &attribute{Type: "TypeMap", Elem: jsonString()}
+}
+
+// jsonString returns an attribute for values that are written as JSON.
+func jsonString() *attribute {
+	return &attribute{Type: "TypeString", Description: "A JSON value."}
+}
+
+// lowerSnake returns a name in lowercase words separated by underscores,
+// as Terraform names are written.
+func lowerSnake(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(clientgen.UpperSnake(name))
+}
+
+// singular returns the singular form of a plural noun.
+func singular(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ies"):
+		return strings.TrimSuffix(noun, "ies") + "y"
+	case strings.HasSuffix(noun, "ses"), strings.HasSuffix(noun, "xes"):
+		return strings.TrimSuffix(noun, "es")
+	case strings.HasSuffix(noun, "s") && !strings.HasSuffix(noun, "ss") && !strings.HasSuffix(noun, "us"):
+		return strings.TrimSuffix(noun, "s")
+	}
+	return noun
+}
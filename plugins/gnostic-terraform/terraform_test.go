@@ -0,0 +1,90 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func testPlugin(t *testing.T, parameters string, inputFile string, outputFile string, referenceFile string) {
+	// remove any preexisting output files
+	os.Remove(outputFile)
+	// run the compiler
+	output, err := exec.Command(
+		"gnostic",
+		"--terraform-out="+parameters+"-",
+		inputFile).Output()
+	if err != nil {
+		t.Logf("Compile failed: %+v", err)
+		t.FailNow()
+	}
+	_ = ioutil.WriteFile(outputFile, output, 0644)
+	err = exec.Command("diff", outputFile, referenceFile).Run()
+	if err != nil {
+		t.Logf("Diff failed: %s vs %s %+v", outputFile, referenceFile, err)
+		t.FailNow()
+	} else {
+		// if the test succeeded, clean up
+		os.Remove(outputFile)
+	}
+}
+
+func TestTerraformWithBookstore(t *testing.T) {
+	testPlugin(t, "", "../../testdata/v3.0/yaml/bookstore.yaml", "terraform-bookstore.out", "testdata/bookstore.txt")
+}
+
+func TestTerraformWithoutResources(t *testing.T) {
+	// The petstore's pets can't be deleted, so they aren't resources.
+	testPlugin(t, "provider=pets,package=pets:", "../../examples/v2.0/yaml/petstore.yaml", "terraform-petstore.out", "testdata/petstore.txt")
+}
+
+func TestItemParameter(t *testing.T) {
+	for _, test := range []struct {
+		collection string
+		item       string
+		parameter  string
+	}{
+		{"/shelves", "/shelves/{shelf}", "shelf"},
+		{"/shelves/{shelf}/books", "/shelves/{shelf}/books/{book}", "book"},
+		{"/shelves", "/shelves", ""},
+		{"/shelves", "/shelves/{shelf}/books", ""},
+		{"/shelves", "/shelves/default", ""},
+		{"/shelves", "/shelvesx/{shelf}", ""},
+	} {
+		if parameter := itemParameter(test.collection, test.item); parameter != test.parameter {
+			t.Errorf("itemParameter(%q, %q) = %q, want %q", test.collection, test.item, parameter, test.parameter)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	for noun, want := range map[string]string{
+		"books":      "book",
+		"libraries":  "library",
+		"boxes":      "box",
+		"addresses":  "address",
+		"status":     "status",
+		"glass":      "glass",
+		"equipment":  "equipment",
+		"user_roles": "user_role",
+	} {
+		if got := singular(noun); got != want {
+			t.Errorf("singular(%q) = %q, want %q", noun, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-transcoding is a plugin that generates configurations of proxies
+// that transcode HTTP requests to calls of the gRPC services that
+// gnostic-proto generates for an API.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/gnostic/conversions"
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	plugins "github.com/google/gnostic/plugins"
+	"github.com/google/gnostic/plugins/internal/protogen"
+)
+
+// This is the main function for the plugin.
+func main() {
+	env, err := plugins.NewEnvironment()
+	env.RespondAndExitIfError(err)
+
+	format := ""
+	packageName := ""
+	descriptor := "descriptor.pb"
+	serviceName := ""
+	for _, parameter := range env.Request.Parameters {
+		switch parameter.Name {
+		case "format":
+			format = parameter.Value
+		case "package":
+			packageName = parameter.Value
+		case "descriptor":
+			descriptor = parameter.Value
+		case "name":
+			serviceName = parameter.Value
+		}
+	}
+	if format != "" && format != "envoy" && format != "endpoints" {
+		env.RespondAndExitIfError(fmt.Errorf("unsupported format %q, use \"envoy\" or \"endpoints\"", format))
+	}
+
+	var document *openapiv3.Document
+	for _, model := range env.Request.Models {
+		switch model.TypeUrl {
+		case "openapi.v2.Document":
+			documentv2 := &openapiv2.Document{}
+			err = proto.Unmarshal(model.Value, documentv2)
+			env.RespondAndExitIfError(err)
+			document, err = conversions.OpenAPIv3FromOpenAPIv2(documentv2)
+			env.RespondAndExitIfError(err)
+		case "openapi.v3.Document":
+			document = &openapiv3.Document{}
+			err = proto.Unmarshal(model.Value, document)
+			env.RespondAndExitIfError(err)
+		}
+	}
+
+	if document != nil {
+		file := protogen.NewFile(document, packageName)
+		dir := filepath.Dir(env.Request.SourceName)
+		if format == "" || format == "envoy" {
+			data, err := envoyConfig(file, descriptor)
+			env.RespondAndExitIfError(err)
+			env.Response.Files = append(env.Response.Files, &plugins.File{
+				Name: filepath.Join(dir, "envoy.yaml"),
+				Data: data,
+			})
+		}
+		if format == "" || format == "endpoints" {
+			data, err := endpointsConfig(file, serviceName, document.GetInfo().GetTitle())
+			env.RespondAndExitIfError(err)
+			env.Response.Files = append(env.Response.Files, &plugins.File{
+				Name: filepath.Join(dir, "api_config.yaml"),
+				Data: data,
+			})
+		}
+	}
+
+	env.RespondAndExit()
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/plugins/internal/protogen"
+)
+
+// envoyFilter is an Envoy HTTP filter that is configured with a
+// GrpcJsonTranscoder.
+type envoyFilter struct {
+	Name        string          `yaml:"name"`
+	TypedConfig envoyTranscoder `yaml:"typed_config"`
+}
+
+type envoyTranscoder struct {
+	Type            string            `yaml:"@type"`
+	ProtoDescriptor string            `yaml:"proto_descriptor"`
+	Services        []string          `yaml:"services"`
+	PrintOptions    envoyPrintOptions `yaml:"print_options"`
+}
+
+type envoyPrintOptions struct {
+	AddWhitespace              bool `yaml:"add_whitespace"`
+	AlwaysPrintPrimitiveFields bool `yaml:"always_print_primitive_fields"`
+	PreserveProtoFieldNames    bool `yaml:"preserve_proto_field_names"`
+}
+
+// envoyConfig returns the configuration of an Envoy gRPC-JSON transcoder
+// filter for the services of a file. Descriptor is the path of a descriptor
+// set that contains the file.
+func envoyConfig(file *protogen.File, descriptor string) ([]byte, error) {
+	filter := &envoyFilter{
+		Name: "envoy.filters.http.grpc_json_transcoder",
+		TypedConfig: envoyTranscoder{
+			Type:            "type.googleapis.com/envoy.extensions.filters.http.grpc_json_transcoder.v3.GrpcJsonTranscoder",
+			ProtoDescriptor: descriptor,
+			PrintOptions: envoyPrintOptions{
+				AddWhitespace:              true,
+				AlwaysPrintPrimitiveFields: true,
+			},
+		},
+	}
+	for _, s := range file.Services {
+		filter.TypedConfig.Services = append(filter.TypedConfig.Services, file.Package+"."+s.Name)
+	}
+	return marshal(filter)
+}
+
+// endpointsService is a Google Cloud Endpoints service configuration, which
+// is a google.api.Service in YAML.
+type endpointsService struct {
+	Type          string         `yaml:"type"`
+	ConfigVersion int            `yaml:"config_version"`
+	Name          string         `yaml:"name"`
+	Title         string         `yaml:"title,omitempty"`
+	APIs          []endpointsAPI `yaml:"apis"`
+	HTTP          endpointsHTTP  `yaml:"http"`
+}
+
+type endpointsAPI struct {
+	Name string `yaml:"name"`
+}
+
+type endpointsHTTP struct {
+	Rules []*httpRule `yaml:"rules"`
+}
+
+// httpRule is a google.api.HttpRule.
+type httpRule struct {
+	Selector     string         `yaml:"selector"`
+	Get          string         `yaml:"get,omitempty"`
+	Put          string         `yaml:"put,omitempty"`
+	Post         string         `yaml:"post,omitempty"`
+	Delete       string         `yaml:"delete,omitempty"`
+	Patch        string         `yaml:"patch,omitempty"`
+	Custom       *customPattern `yaml:"custom,omitempty"`
+	Body         string         `yaml:"body,omitempty"`
+	ResponseBody string         `yaml:"response_body,omitempty"`
+}
+
+type customPattern struct {
+	Kind string `yaml:"kind"`
+	Path string `yaml:"path"`
+}
+
+// endpointsConfig returns a Google Cloud Endpoints service configuration for
+// the services of a file. If name is empty, the service is named after the
+// package of the file in a placeholder project.
+func endpointsConfig(file *protogen.File, name, title string) ([]byte, error) {
+	if name == "" {
+		name = strings.NewReplacer(".", "-", "_", "-").Replace(file.Package) + ".endpoints.PROJECT_ID.cloud.goog"
+	}
+	service := &endpointsService{
+		Type:          "google.api.Service",
+		ConfigVersion: 3,
+		Name:          name,
+		Title:         title,
+	}
+	for _, s := range file.Services {
+		serviceName := file.Package + "." + s.Name
+		service.APIs = append(service.APIs, endpointsAPI{Name: serviceName})
+		for _, m := range s.Methods {
+			rule := &httpRule{
+				Selector:     serviceName + "." + m.Name,
+				Body:         m.Body,
+				ResponseBody: m.ResponseBody,
+			}
+			switch m.Verb {
+			case "get":
+				rule.Get = m.Path
+			case "put":
+				rule.Put = m.Path
+			case "post":
+				rule.Post = m.Path
+			case "delete":
+				rule.Delete = m.Path
+			case "patch":
+				rule.Patch = m.Path
+			default:
+				rule.Custom = &customPattern{Kind: strings.ToUpper(m.Verb), Path: m.Path}
+			}
+			service.HTTP.Rules = append(service.HTTP.Rules, rule)
+		}
+	}
+	return marshal(service)
+}
+
+// Returns the YAML encoding of a configuration with the indentation of the
+// examples in the documentation of Envoy and Cloud Endpoints.
+func marshal(config interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), encoder.Close()
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Codes of the findings in a report.
+const (
+	UnusedComponent = "unused-component"
+	OrphanComponent = "orphan-component"
+	DeadReference   = "dead-reference"
+)
+
+// Finding describes a problem found in an API description.
+type Finding struct {
+	Code string `json:"code"`
+	// Pointer is a JSON pointer to the component or reference with the problem.
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+// Report contains the findings for an API description.
+type Report struct {
+	Findings []*Finding `json:"findings"`
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, f := range r.Findings {
+		fmt.Fprintf(&b, "%s: %s (%s)\n", f.Pointer, f.Message, f.Code)
+	}
+	return b.String()
+}
+
+// sectionsV2 and sectionsV3 list the parts of OpenAPI documents that
+// contain components that can be referenced with $ref.
+var sectionsV2 = [][]string{
+	{"definitions"},
+	{"parameters"},
+	{"responses"},
+}
+
+var sectionsV3 = [][]string{
+	{"components", "schemas"},
+	{"components", "responses"},
+	{"components", "parameters"},
+	{"components", "examples"},
+	{"components", "requestBodies"},
+	{"components", "headers"},
+	{"components", "links"},
+	{"components", "callbacks"},
+}
+
+type vetter struct {
+	root       *yaml.Node
+	sections   [][]string
+	components []string
+	// references maps each component to the components that it refers to.
+	// References from outside of the components are stored under "".
+	references map[string][]string
+	// referenced contains components that are referenced from anywhere.
+	referenced map[string]bool
+	report     *Report
+}
+
+// vet finds unused components and references that can't be resolved
+// in a document. Only local references (those beginning with "#") are
+// checked; references to other files are ignored.
+func vet(root *yaml.Node, sections [][]string) *Report {
+	v := &vetter{
+		root:       root,
+		sections:   sections,
+		references: make(map[string][]string),
+		referenced: make(map[string]bool),
+		report:     &Report{Findings: make([]*Finding, 0)},
+	}
+	for _, section := range sections {
+		node, err := jsonpointer.ResolveTokens(root, section)
+		if err != nil || node.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.components = append(v.components, jsonpointer.Append(jsonpointer.Format(section...), node.Content[i].Value))
+		}
+	}
+	v.walk(root, nil)
+
+	reachable := make(map[string]bool)
+	pending := append([]string{}, v.references[""]...)
+	for len(pending) > 0 {
+		component := pending[0]
+		pending = pending[1:]
+		if reachable[component] {
+			continue
+		}
+		reachable[component] = true
+		pending = append(pending, v.references[component]...)
+	}
+	for _, component := range v.components {
+		if !v.referenced[component] {
+			v.add(UnusedComponent, component, "component is never referenced")
+		} else if !reachable[component] {
+			v.add(OrphanComponent, component, "component is only referenced by unused components")
+		}
+	}
+	return v.report
+}
+
+func (v *vetter) add(code, pointer, message string) {
+	v.report.Findings = append(v.report.Findings, &Finding{Code: code, Pointer: pointer, Message: message})
+}
+
+func (v *vetter) walk(node *yaml.Node, tokens []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "$ref" && value.Kind == yaml.ScalarNode {
+				v.reference(value.Value, tokens)
+				continue
+			}
+			v.walk(value, append(tokens[:len(tokens):len(tokens)], key))
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.walk(item, append(tokens[:len(tokens):len(tokens)], fmt.Sprintf("%d", i)))
+		}
+	}
+}
+
+// reference records a reference found at a location in the document.
+func (v *vetter) reference(ref string, tokens []string) {
+	if !strings.HasPrefix(ref, "#") {
+		return
+	}
+	location := jsonpointer.Format(tokens...)
+	targetTokens, err := jsonpointer.ParseFragment(ref)
+	if err == nil {
+		_, err = jsonpointer.ResolveTokens(v.root, targetTokens)
+	}
+	if err != nil {
+		v.add(DeadReference, location, fmt.Sprintf("reference %s can't be resolved", ref))
+		return
+	}
+	target := v.componentFor(targetTokens)
+	if target == "" {
+		return
+	}
+	source := v.componentFor(tokens)
+	v.references[source] = append(v.references[source], target)
+	if source != target {
+		v.referenced[target] = true
+	}
+}
+
+// componentFor returns the component that contains a location, or ""
+// if the location is not inside of a component.
+func (v *vetter) componentFor(tokens []string) string {
+	for _, section := range v.sections {
+		if len(tokens) <= len(section) {
+			continue
+		}
+		matches := true
+		for i := range section {
+			if tokens[i] != section[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return jsonpointer.Format(tokens[:len(section)+1]...)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func testPlugin(t *testing.T, parameters string, inputFile string, outputFile string, referenceFile string) {
+	// remove any preexisting output files
+	os.Remove(outputFile)
+	// run the compiler
+	output, err := exec.Command(
+		"gnostic",
+		"--vet-out="+parameters+"-",
+		inputFile).Output()
+	if err != nil {
+		t.Logf("Compile failed: %+v", err)
+		t.FailNow()
+	}
+	_ = ioutil.WriteFile(outputFile, output, 0644)
+	err = exec.Command("diff", outputFile, referenceFile).Run()
+	if err != nil {
+		t.Logf("Diff failed: %s vs %s %+v", outputFile, referenceFile, err)
+		t.FailNow()
+	} else {
+		// if the test succeeded, clean up
+		os.Remove(outputFile)
+	}
+}
+
+func TestVetWithV2(t *testing.T) {
+	testPlugin(t, "", "testdata/v2.yaml", "vet-v2.out", "testdata/v2.txt")
+}
+
+func TestVetWithV3(t *testing.T) {
+	testPlugin(t, "", "testdata/v3.yaml", "vet-v3.out", "testdata/v3.txt")
+}
+
+func TestVetWithV2AsJSON(t *testing.T) {
+	testPlugin(t, "format=json:", "testdata/v2.yaml", "vet-v2-json.out", "testdata/v2.json")
+}
+
+func TestVetWithV3AsJSON(t *testing.T) {
+	testPlugin(t, "format=json:", "testdata/v3.yaml", "vet-v3-json.out", "testdata/v3.json")
+}
@@ -0,0 +1,542 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clientgen describes the types and methods of an API for the
+// generators of clients in programming languages other than Go. The
+// description is made from a surface.Model, with names and types that are
+// chosen by a Language, and is meant to be rendered with templates.
+package clientgen
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	surface "github.com/google/gnostic/surface"
+)
+
+// A Language chooses the names and native types of a generated client.
+type Language interface {
+	// Scalar returns the native type of a JSON Schema type ("integer",
+	// "number", "string", or "boolean") with an optional format.
+	Scalar(typeName, format string) string
+	// Array returns the native type of arrays of an element type.
+	Array(element string) string
+	// Map returns the native type of maps from strings to a value type.
+	Map(value string) string
+	// Any returns the native type of values that can have any type.
+	Any() string
+	// TypeName returns the name of a generated type.
+	TypeName(name string) string
+	// MemberName returns the name of a field, method, or parameter.
+	MemberName(name string) string
+	// CaseName returns the name of an enum case for a value. The name
+	// is a suggestion from the API description and can be empty.
+	CaseName(value, name string) string
+	// Locals returns the names of the local variables of generated
+	// methods, which parameters don't use.
+	Locals() []string
+}
+
+// API describes the types and methods of an API.
+type API struct {
+	Name    string
+	Types   []*Type
+	Methods []*Method
+	// UsesAny is true if any type or method uses the Any type of the language.
+	UsesAny bool
+}
+
+// Kinds of types.
+const (
+	Struct = "struct"
+	Enum   = "enum"
+	// Arrays and maps are named types for arrays or maps of an element type.
+	Array = "array"
+	Map   = "map"
+)
+
+// Type describes a named type of an API.
+type Type struct {
+	Name        string
+	Kind        string
+	Description string
+	// Fields of structs, in the order of the API description.
+	Fields []*Field
+	// Cases of enums.
+	Cases []*Case
+	// Element is the native type of the elements of arrays and the values
+	// of maps.
+	Element string
+}
+
+// Field describes a field of a struct.
+type Field struct {
+	// Name is the name of the field in JSON.
+	Name string
+	// FieldName is the name of the field in the language.
+	FieldName string
+	Type      string
+}
+
+// Case describes a case of an enum.
+type Case struct {
+	Name  string
+	Value string
+}
+
+// Positions of parameters.
+const (
+	Path   = "path"
+	Query  = "query"
+	Header = "header"
+	Body   = "body"
+)
+
+// Method describes an operation of an API.
+type Method struct {
+	Name        string
+	Description string
+	// HTTPMethod is the method of requests, e.g. "GET".
+	HTTPMethod string
+	// Path is split into literal parts and path parameters.
+	Path []*PathPart
+	// Parameters are in the order of function parameters: required
+	// parameters, then the body, then optional parameters.
+	Parameters []*Parameter
+	// Result is the native type of the body of successful responses, or
+	// empty if they have no body.
+	Result string
+}
+
+// PathPart is a literal part of a path or a path parameter.
+type PathPart struct {
+	Literal   string
+	Parameter *Parameter
+}
+
+// Parameter describes a parameter of a method.
+type Parameter struct {
+	// Name is the name of the parameter in requests.
+	Name string
+	// ParameterName is the name of the parameter in the language.
+	ParameterName string
+	Type          string
+	Position      string
+	Required      bool
+	// Array is true for parameters that are repeated in queries.
+	Array bool
+}
+
+// In returns the parameters of a method in a position.
+func (m *Method) In(position string) []*Parameter {
+	result := make([]*Parameter, 0)
+	for _, p := range m.Parameters {
+		if p.Position == position {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Body returns the body parameter of a method, or nil if it has none.
+func (m *Method) Body() *Parameter {
+	for _, p := range m.Parameters {
+		if p.Position == Body {
+			return p
+		}
+	}
+	return nil
+}
+
+// NewAPI describes the API of a surface model in a language. The
+// parameters, request bodies, and responses of methods are described
+// by the methods instead of types. Warnings describe the parts of the
+// model that can't be described.
+func NewAPI(model *surface.Model, language Language) (api *API, warnings []string) {
+	b := &builder{
+		model:    model,
+		language: language,
+		types:    make(map[string]*surface.Type),
+		api:      &API{Name: model.Name},
+	}
+	for _, t := range model.Types {
+		b.types[t.Name] = t
+	}
+	// Types that describe methods aren't generated.
+	internal := make(map[string]bool)
+	for _, m := range model.Methods {
+		internal[m.ParametersTypeName] = true
+		internal[m.ResponsesTypeName] = true
+		if parameters := b.types[m.ParametersTypeName]; parameters != nil {
+			for _, f := range parameters.Fields {
+				if f.Name == "request_body" && f.Kind == surface.FieldKind_REFERENCE {
+					internal[f.Type] = true
+				}
+			}
+		}
+	}
+	for _, t := range model.Types {
+		if t.Kind == surface.TypeKind_UNION {
+			// Unions are described with values of any type.
+			b.warnings = append(b.warnings, t.Name+" is a union and is described with values of any type")
+			continue
+		}
+		if !internal[t.Name] {
+			b.api.Types = append(b.api.Types, b.newType(t))
+		}
+	}
+	for _, m := range model.Methods {
+		b.api.Methods = append(b.api.Methods, b.newMethod(m))
+	}
+	return b.api, b.warnings
+}
+
+type builder struct {
+	model    *surface.Model
+	language Language
+	types    map[string]*surface.Type
+	api      *API
+	warnings []string
+}
+
+func (b *builder) newType(t *surface.Type) *Type {
+	result := &Type{
+		Name:        b.language.TypeName(t.Name),
+		Kind:        Struct,
+		Description: t.Description,
+	}
+	switch {
+	case t.Kind == surface.TypeKind_ENUM:
+		result.Kind = Enum
+		names := newNames()
+		for i, value := range t.EnumValues {
+			hint := ""
+			if i < len(t.EnumNames) {
+				hint = t.EnumNames[i]
+			}
+			result.Cases = append(result.Cases, &Case{
+				Name:  names.unique(b.language.CaseName(value, hint)),
+				Value: value,
+			})
+		}
+	case len(t.Fields) == 1 && t.Fields[0].Name == "value" && t.Fields[0].Kind == surface.FieldKind_ARRAY:
+		// a schema of an array
+		result.Kind = Array
+		result.Element = b.elementType(t.Fields[0])
+	case len(t.Fields) == 1 && t.Fields[0].Name == "additional_properties" && t.Fields[0].Kind == surface.FieldKind_MAP:
+		// a schema of an object with only additional properties
+		result.Kind = Map
+		result.Element = b.valueType(t.Fields[0])
+	default:
+		names := newNames()
+		for _, f := range t.Fields {
+			result.Fields = append(result.Fields, &Field{
+				Name:      f.Name,
+				FieldName: names.unique(b.language.MemberName(f.Name)),
+				Type:      b.fieldType(f),
+			})
+		}
+	}
+	return result
+}
+
+// fieldType returns the native type of a field.
+func (b *builder) fieldType(f *surface.Field) string {
+	switch f.Kind {
+	case surface.FieldKind_ARRAY:
+		return b.language.Array(b.elementType(f))
+	case surface.FieldKind_MAP:
+		return b.language.Map(b.valueType(f))
+	case surface.FieldKind_ANY:
+		return b.any()
+	}
+	return b.namedType(f.Type, f.Format)
+}
+
+// elementType returns the native type of the elements of an array field.
+func (b *builder) elementType(f *surface.Field) string {
+	return b.namedType(f.Type, f.Format)
+}
+
+// valueType returns the native type of the values of a map field.
+func (b *builder) valueType(f *surface.Field) string {
+	switch f.ValueKind {
+	case surface.FieldKind_ARRAY:
+		return b.language.Array(b.namedType(f.ValueType, f.ValueFormat))
+	case surface.FieldKind_MAP, surface.FieldKind_ANY:
+		return b.any()
+	}
+	return b.namedType(f.ValueType, f.ValueFormat)
+}
+
+// namedType returns the native type of a scalar type or of a type of the model.
+func (b *builder) namedType(name, format string) string {
+	switch name {
+	case "integer", "number", "string", "boolean":
+		return b.language.Scalar(name, format)
+	case "", "object":
+		return b.any()
+	}
+	if t, ok := b.types[name]; !ok || t.Kind == surface.TypeKind_UNION {
+		return b.any()
+	}
+	return b.language.TypeName(name)
+}
+
+func (b *builder) any() string {
+	b.api.UsesAny = true
+	return b.language.Any()
+}
+
+func (b *builder) newMethod(m *surface.Method) *Method {
+	result := &Method{
+		Name:        b.language.MemberName(m.Name),
+		Description: m.Description,
+		HTTPMethod:  strings.ToUpper(m.Method),
+	}
+	names := newNames()
+	for _, local := range b.language.Locals() {
+		names[local] = true
+	}
+	var required, optional []*Parameter
+	var body *Parameter
+	if parameters := b.types[m.ParametersTypeName]; parameters != nil {
+		for _, f := range parameters.Fields {
+			p := &Parameter{
+				Name:     f.Name,
+				Type:     b.fieldType(f),
+				Required: f.Required,
+				Array:    f.Kind == surface.FieldKind_ARRAY,
+			}
+			switch {
+			case f.Name == "request_body" && f.Kind == surface.FieldKind_REFERENCE:
+				p.Position = Body
+				p.Type = b.requestBodyType(b.types[f.Type])
+			case f.Position == surface.Position_BODY:
+				p.Position = Body
+			case f.Position == surface.Position_PATH:
+				p.Position = Path
+				p.Required = true
+			case f.Position == surface.Position_QUERY:
+				p.Position = Query
+			case f.Position == surface.Position_HEADER:
+				p.Position = Header
+			default:
+				b.warnings = append(b.warnings, m.Name+" has a "+strings.ToLower(f.Position.String())+
+					" parameter "+f.Name+" that isn't supported")
+				continue
+			}
+			switch {
+			case p.Position == Body:
+				p.Required = true
+				body = p
+			case p.Required:
+				required = append(required, p)
+			default:
+				optional = append(optional, p)
+			}
+		}
+	}
+	if body != nil {
+		body.ParameterName = names.unique(b.language.MemberName("body"))
+	}
+	for _, p := range append(required, optional...) {
+		p.ParameterName = names.unique(b.language.MemberName(p.Name))
+	}
+	result.Parameters = append(result.Parameters, required...)
+	if body != nil {
+		result.Parameters = append(result.Parameters, body)
+	}
+	result.Parameters = append(result.Parameters, optional...)
+	result.Path = b.path(m.Path, result.Parameters)
+	if responses := b.types[m.ResponsesTypeName]; responses != nil {
+		for _, f := range responses.Fields {
+			if strings.HasPrefix(f.Name, "2") {
+				result.Result = b.fieldType(f)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// requestBodyType returns the native type of a request body, preferring
+// its JSON content.
+func (b *builder) requestBodyType(t *surface.Type) string {
+	if t == nil || len(t.Fields) == 0 {
+		return b.any()
+	}
+	for _, f := range t.Fields {
+		if f.Name == "application/json" {
+			return b.fieldType(f)
+		}
+	}
+	return b.fieldType(t.Fields[0])
+}
+
+// path splits a path template into literals and path parameters.
+func (b *builder) path(path string, parameters []*Parameter) []*PathPart {
+	var parts []*PathPart
+	for path != "" {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			parts = append(parts, &PathPart{Literal: path})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, &PathPart{Literal: path[:start]})
+		}
+		name := path[start+1 : end]
+		var parameter *Parameter
+		for _, p := range parameters {
+			if p.Position == Path && p.Name == name {
+				parameter = p
+			}
+		}
+		if parameter != nil {
+			parts = append(parts, &PathPart{Parameter: parameter})
+		} else {
+			parts = append(parts, &PathPart{Literal: path[start : end+1]})
+		}
+		path = path[end+1:]
+	}
+	return parts
+}
+
+// names makes names unique by adding numbers to them.
+type names map[string]bool
+
+func newNames() names {
+	return make(names)
+}
+
+func (n names) unique(name string) string {
+	result := name
+	for i := 2; n[result]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	n[result] = true
+	return result
+}
+
+// Words splits a name into words at characters that aren't letters or
+// digits, at changes from lowercase to uppercase letters, and before the
+// last letter of a run of uppercase letters that is followed by lowercase
+// letters.
+func Words(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			previous := runes[i-1]
+			lowerNext := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || unicode.IsUpper(previous) && lowerNext {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// UpperCamel returns a name made from the words of a name, each starting
+// with an uppercase letter.
+func UpperCamel(name string) string {
+	var b strings.Builder
+	for _, word := range Words(name) {
+		b.WriteString(upperFirst(word))
+	}
+	return identifier(b.String())
+}
+
+// LowerCamel returns a name made from the words of a name that starts
+// with a lowercase letter and whose other words start with uppercase
+// letters. Words in all caps at the start are written in lowercase.
+func LowerCamel(name string) string {
+	var b strings.Builder
+	for i, word := range Words(name) {
+		if i == 0 {
+			if strings.ToUpper(word) == word {
+				b.WriteString(strings.ToLower(word))
+			} else {
+				b.WriteString(lowerFirst(word))
+			}
+		} else {
+			b.WriteString(upperFirst(word))
+		}
+	}
+	return identifier(b.String())
+}
+
+// UpperSnake returns a name made from the words of a name in uppercase,
+// separated with underscores.
+func UpperSnake(name string) string {
+	words := Words(name)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word)
+	}
+	return identifier(strings.Join(words, "_"))
+}
+
+// identifier returns a name that can be used as an identifier in most
+// languages: names that are empty or that start with digits get prefixes.
+func identifier(name string) string {
+	if name == "" {
+		return "_"
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		return "_" + name
+	}
+	return name
+}
+
+func upperFirst(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+func lowerFirst(s string) string {
+	for i, r := range s {
+		return string(unicode.ToLower(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+// Lines returns the lines of a description without trailing blank lines.
+func Lines(description string) []string {
+	lines := strings.Split(strings.TrimRight(description, " \n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientgen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		words      []string
+		upperCamel string
+		lowerCamel string
+		upperSnake string
+	}{
+		{"petId", []string{"pet", "Id"}, "PetId", "petId", "PET_ID"},
+		{"X-Request-Id", []string{"X", "Request", "Id"}, "XRequestId", "xRequestId", "X_REQUEST_ID"},
+		{"checked-out", []string{"checked", "out"}, "CheckedOut", "checkedOut", "CHECKED_OUT"},
+		{"ListShelvesResponse", []string{"List", "Shelves", "Response"}, "ListShelvesResponse", "listShelvesResponse", "LIST_SHELVES_RESPONSE"},
+		{"HTTPStatus", []string{"HTTP", "Status"}, "HTTPStatus", "httpStatus", "HTTP_STATUS"},
+		{"2xx", []string{"2xx"}, "_2xx", "_2xx", "_2XX"},
+		{"", nil, "_", "_", "_"},
+	} {
+		if words := Words(test.name); !reflect.DeepEqual(words, test.words) {
+			t.Errorf("Words(%q) = %q, want %q", test.name, words, test.words)
+		}
+		if name := UpperCamel(test.name); name != test.upperCamel {
+			t.Errorf("UpperCamel(%q) = %q, want %q", test.name, name, test.upperCamel)
+		}
+		if name := LowerCamel(test.name); name != test.lowerCamel {
+			t.Errorf("LowerCamel(%q) = %q, want %q", test.name, name, test.lowerCamel)
+		}
+		if name := UpperSnake(test.name); name != test.upperSnake {
+			t.Errorf("UpperSnake(%q) = %q, want %q", test.name, name, test.upperSnake)
+		}
+	}
+}
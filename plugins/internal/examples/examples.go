@@ -0,0 +1,474 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package examples generates example values from the schemas of OpenAPI
+// descriptions.
+package examples
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+)
+
+// Example values of strings with formats.
+var formatExamples = map[string]string{
+	"email":     "user@example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"date-time": "2023-01-02T15:04:05Z",
+	"date":      "2023-01-02",
+	"time":      "15:04:05Z",
+	"uri":       "https://example.com/",
+	"url":       "https://example.com/",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+	"password":  "secret",
+}
+
+// An Example is generated for a schema or for the JSON body of a response.
+type Example struct {
+	// Name is the name of the schema, or the name of the operation and the
+	// status code of the response.
+	Name string
+	// Pointer is a JSON pointer to the schema or response.
+	Pointer string
+	Value   *yaml.Node
+}
+
+type exampler struct {
+	root *yaml.Node
+	// visiting contains the references that are being followed, to stop
+	// recursion in recursive schemas.
+	visiting map[string]bool
+}
+
+// Generate returns examples for the named schemas and the JSON responses of
+// the operations of an OpenAPI v2 or v3 document. If update is true, the
+// examples are also added to the schemas and responses that have none.
+func Generate(root *yaml.Node, update bool) []*Example {
+	e := &exampler{root: root, visiting: map[string]bool{}}
+	v3 := compiler.MapValueForKey(root, "openapi") != nil
+	var result []*Example
+
+	schemas := []string{"definitions"}
+	if v3 {
+		schemas = []string{"components", "schemas"}
+	}
+	if node, err := jsonpointer.ResolveTokens(root, schemas); err == nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, schema := node.Content[i].Value, node.Content[i+1]
+			pointer := jsonpointer.Append(jsonpointer.Format(schemas...), name)
+			// References to the schema from its own properties are
+			// recursive.
+			e.visiting["#"+pointer] = true
+			value := e.example(schema)
+			delete(e.visiting, "#"+pointer)
+			if value == nil {
+				continue
+			}
+			result = append(result, &Example{Name: name, Pointer: pointer, Value: value})
+			if update && !hasExample(schema) && compiler.MapValueForKey(schema, "$ref") == nil {
+				schema.Content = append(schema.Content, compiler.NewScalarNodeForString("example"), value)
+			}
+		}
+	}
+
+	paths := compiler.MapValueForKey(root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return result
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, paths.Content[i+1]
+		for j := 0; item.Kind == yaml.MappingNode && j+1 < len(item.Content); j += 2 {
+			method, operation := item.Content[j].Value, item.Content[j+1]
+			responses := compiler.MapValueForKey(operation, "responses")
+			if !isMethod(method) || responses == nil || responses.Kind != yaml.MappingNode {
+				continue
+			}
+			name := operationName(operation, method, path)
+			for k := 0; k+1 < len(responses.Content); k += 2 {
+				code := responses.Content[k].Value
+				response := e.resolve(responses.Content[k+1])
+				pointer := jsonpointer.Format("paths", path, method, "responses", code)
+				var example *Example
+				if v3 {
+					example = e.responseExampleV3(response, update)
+				} else {
+					example = e.responseExampleV2(response, update)
+				}
+				if example != nil {
+					example.Name = name + "-" + code
+					example.Pointer = pointer
+					result = append(result, example)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Value returns an example value for a schema of a document, or nil if the
+// schema refers to itself and has no finite example.
+func Value(root, schema *yaml.Node) *yaml.Node {
+	e := &exampler{root: root, visiting: map[string]bool{}}
+	return e.example(schema)
+}
+
+// Resolve returns the target of a local reference in a document, or the node
+// if it isn't a reference.
+func Resolve(root, node *yaml.Node) *yaml.Node {
+	e := &exampler{root: root}
+	return e.resolve(node)
+}
+
+// Returns an example for the JSON content of an OpenAPI v3 response.
+func (e *exampler) responseExampleV3(response *yaml.Node, update bool) *Example {
+	content := compiler.MapValueForKey(response, "content")
+	if content == nil || content.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(content.Content); i += 2 {
+		mediaType, body := content.Content[i].Value, content.Content[i+1]
+		schema := compiler.MapValueForKey(body, "schema")
+		if !strings.Contains(mediaType, "json") || schema == nil {
+			continue
+		}
+		value := e.example(schema)
+		if value == nil {
+			return nil
+		}
+		if update && compiler.MapValueForKey(body, "example") == nil && compiler.MapValueForKey(body, "examples") == nil {
+			body.Content = append(body.Content, compiler.NewScalarNodeForString("example"), value)
+		}
+		return &Example{Value: value}
+	}
+	return nil
+}
+
+// Returns an example for the body of an OpenAPI v2 response.
+func (e *exampler) responseExampleV2(response *yaml.Node, update bool) *Example {
+	schema := compiler.MapValueForKey(response, "schema")
+	if schema == nil {
+		return nil
+	}
+	value := e.example(schema)
+	if value == nil {
+		return nil
+	}
+	if update && compiler.MapValueForKey(response, "examples") == nil {
+		examples := compiler.NewMappingNode()
+		examples.Content = append(examples.Content, compiler.NewScalarNodeForString("application/json"), value)
+		response.Content = append(response.Content, compiler.NewScalarNodeForString("examples"), examples)
+	}
+	return &Example{Value: value}
+}
+
+// Returns the target of a local reference, or the node if it isn't a
+// reference.
+func (e *exampler) resolve(node *yaml.Node) *yaml.Node {
+	ref, ok := stringValue(node, "$ref")
+	if !ok || !strings.HasPrefix(ref, "#") {
+		return node
+	}
+	tokens, err := jsonpointer.ParseFragment(ref[1:])
+	if err != nil {
+		return node
+	}
+	target, err := jsonpointer.ResolveTokens(e.root, tokens)
+	if err != nil {
+		return node
+	}
+	return target
+}
+
+// Returns an example value for a schema, or nil if the schema refers to
+// itself and has no finite example.
+func (e *exampler) example(schema *yaml.Node) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return compiler.NewMappingNode()
+	}
+	if ref, ok := stringValue(schema, "$ref"); ok {
+		if e.visiting[ref] {
+			return nil
+		}
+		target := e.resolve(schema)
+		if target == schema {
+			return compiler.NewMappingNode()
+		}
+		e.visiting[ref] = true
+		defer delete(e.visiting, ref)
+		return e.example(target)
+	}
+	for _, key := range []string{"example", "default", "const"} {
+		if value := compiler.MapValueForKey(schema, key); value != nil {
+			return value
+		}
+	}
+	for _, key := range []string{"examples", "enum"} {
+		if values := compiler.MapValueForKey(schema, key); values != nil && values.Kind == yaml.SequenceNode && len(values.Content) > 0 {
+			return values.Content[0]
+		}
+	}
+	if allOf := compiler.MapValueForKey(schema, "allOf"); allOf != nil && allOf.Kind == yaml.SequenceNode {
+		return e.allOfExample(schema, allOf.Content)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options := compiler.MapValueForKey(schema, key); options != nil && options.Kind == yaml.SequenceNode {
+			for _, option := range options.Content {
+				if value := e.example(option); value != nil {
+					return value
+				}
+			}
+			return nil
+		}
+	}
+	switch schemaType(schema) {
+	case "object":
+		return e.objectExample(schema)
+	case "array":
+		return e.arrayExample(schema)
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		return compiler.NewScalarNodeForInt(int64(numberExample(schema, true)))
+	case "number":
+		return compiler.NewScalarNodeForFloat(numberExample(schema, false))
+	case "boolean":
+		return compiler.NewScalarNodeForBool(true)
+	case "null":
+		return compiler.NewNullNode()
+	}
+	return compiler.NewMappingNode()
+}
+
+// Returns an example that combines the examples of the schemas of an allOf
+// with the properties of the schema that contains it.
+func (e *exampler) allOfExample(schema *yaml.Node, schemas []*yaml.Node) *yaml.Node {
+	result := compiler.NewMappingNode()
+	for _, s := range schemas {
+		value := e.example(s)
+		if value == nil {
+			return nil
+		}
+		if value.Kind != yaml.MappingNode {
+			return value
+		}
+		addProperties(result, value)
+	}
+	if compiler.MapValueForKey(schema, "properties") != nil {
+		value := e.objectExample(schema)
+		if value == nil {
+			return nil
+		}
+		addProperties(result, value)
+	}
+	return result
+}
+
+// Adds the properties of an object to another object that doesn't have them.
+func addProperties(to, from *yaml.Node) {
+	for i := 0; i+1 < len(from.Content); i += 2 {
+		if compiler.MapValueForKey(to, from.Content[i].Value) == nil {
+			to.Content = append(to.Content, from.Content[i], from.Content[i+1])
+		}
+	}
+}
+
+func (e *exampler) objectExample(schema *yaml.Node) *yaml.Node {
+	result := compiler.NewMappingNode()
+	properties := compiler.MapValueForKey(schema, "properties")
+	required := map[string]bool{}
+	if r := compiler.MapValueForKey(schema, "required"); r != nil {
+		for _, name := range r.Content {
+			required[name.Value] = true
+		}
+	}
+	for i := 0; properties != nil && i+1 < len(properties.Content); i += 2 {
+		name := properties.Content[i].Value
+		value := e.example(properties.Content[i+1])
+		if value == nil {
+			if required[name] {
+				return nil
+			}
+			// Recursive properties that aren't required are omitted.
+			continue
+		}
+		result.Content = append(result.Content, compiler.NewScalarNodeForString(name), value)
+	}
+	if additional := compiler.MapValueForKey(schema, "additionalProperties"); len(result.Content) == 0 &&
+		additional != nil && additional.Kind == yaml.MappingNode {
+		if value := e.example(additional); value != nil {
+			result.Content = append(result.Content, compiler.NewScalarNodeForString("key"), value)
+		}
+	}
+	return result
+}
+
+func (e *exampler) arrayExample(schema *yaml.Node) *yaml.Node {
+	result := compiler.NewSequenceNode()
+	items := compiler.MapValueForKey(schema, "items")
+	if items != nil && items.Kind == yaml.SequenceNode && len(items.Content) > 0 {
+		items = items.Content[0]
+	}
+	count := 1
+	if minItems, ok := numberValue(schema, "minItems"); ok && int(minItems) > count {
+		count = int(minItems)
+	}
+	if maxItems, ok := numberValue(schema, "maxItems"); ok && int(maxItems) < count {
+		count = int(maxItems)
+	}
+	value := e.example(items)
+	if value == nil {
+		// Arrays of recursive schemas are empty.
+		return result
+	}
+	for i := 0; i < count; i++ {
+		result.Content = append(result.Content, value)
+	}
+	return result
+}
+
+func stringExample(schema *yaml.Node) *yaml.Node {
+	format, _ := stringValue(schema, "format")
+	if value, ok := formatExamples[format]; ok {
+		return compiler.NewScalarNodeForString(value)
+	}
+	value := "example"
+	if pattern, ok := stringValue(schema, "pattern"); ok {
+		// Patterns aren't used to generate strings, but a string that
+		// doesn't match is better left empty than made to look valid.
+		if r, err := regexp.Compile(pattern); err == nil && !r.MatchString(value) {
+			value = ""
+		}
+	}
+	if minLength, ok := numberValue(schema, "minLength"); ok && len(value) < int(minLength) {
+		value += strings.Repeat("x", int(minLength)-len(value))
+	}
+	if maxLength, ok := numberValue(schema, "maxLength"); ok && len(value) > int(maxLength) {
+		value = value[:int(maxLength)]
+	}
+	return compiler.NewScalarNodeForString(value)
+}
+
+// Returns a number that is within the bounds of a schema and is a multiple
+// of its multipleOf.
+func numberExample(schema *yaml.Node, integer bool) float64 {
+	value, step := 1.5, 0.5
+	if integer {
+		value, step = 1, 1
+	}
+	minimum, hasMinimum := numberValue(schema, "minimum")
+	maximum, hasMaximum := numberValue(schema, "maximum")
+	// exclusiveMinimum and exclusiveMaximum are booleans in OpenAPI v2 and
+	// v3.0 and numbers in v3.1.
+	if exclusive, ok := numberValue(schema, "exclusiveMinimum"); ok {
+		minimum, hasMinimum = exclusive+step, true
+	} else if b, _ := stringValue(schema, "exclusiveMinimum"); b == "true" && hasMinimum {
+		minimum += step
+	}
+	if exclusive, ok := numberValue(schema, "exclusiveMaximum"); ok {
+		maximum, hasMaximum = exclusive-step, true
+	} else if b, _ := stringValue(schema, "exclusiveMaximum"); b == "true" && hasMaximum {
+		maximum -= step
+	}
+	switch {
+	case hasMinimum && hasMaximum && !integer:
+		value = (minimum + maximum) / 2
+	case hasMinimum && (!hasMaximum || value < minimum):
+		value = minimum
+	case hasMaximum && value > maximum:
+		value = maximum
+	}
+	if multipleOf, ok := numberValue(schema, "multipleOf"); ok && multipleOf > 0 {
+		value = math.Ceil(value/multipleOf) * multipleOf
+		if hasMaximum && value > maximum {
+			value = math.Floor(maximum/multipleOf) * multipleOf
+		}
+	}
+	return value
+}
+
+// Returns the type of a schema, which is inferred from its keywords if it
+// has no type.
+func schemaType(schema *yaml.Node) string {
+	t := compiler.MapValueForKey(schema, "type")
+	if t != nil && t.Kind == yaml.ScalarNode {
+		return t.Value
+	}
+	if t != nil && t.Kind == yaml.SequenceNode {
+		// Types in OpenAPI v3.1 can be lists, which usually allow null.
+		for _, item := range t.Content {
+			if item.Value != "null" {
+				return item.Value
+			}
+		}
+	}
+	switch {
+	case compiler.MapValueForKey(schema, "properties") != nil,
+		compiler.MapValueForKey(schema, "additionalProperties") != nil:
+		return "object"
+	case compiler.MapValueForKey(schema, "items") != nil:
+		return "array"
+	}
+	return ""
+}
+
+func hasExample(schema *yaml.Node) bool {
+	return compiler.MapValueForKey(schema, "example") != nil || compiler.MapValueForKey(schema, "examples") != nil
+}
+
+func isMethod(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
+}
+
+// Returns the operationId of an operation, or a name made from its method
+// and path if it has none.
+func operationName(operation *yaml.Node, method, path string) string {
+	if id, ok := stringValue(operation, "operationId"); ok && id != "" {
+		return id
+	}
+	words := strings.FieldsFunc(method+"/"+path, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
+
+func stringValue(node *yaml.Node, key string) (string, bool) {
+	value := compiler.MapValueForKey(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	return value.Value, true
+}
+
+func numberValue(node *yaml.Node, key string) (float64, bool) {
+	s, ok := stringValue(node, key)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
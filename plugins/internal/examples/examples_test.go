@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package examples
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/jsonwriter"
+)
+
+func TestValue(t *testing.T) {
+	var document yaml.Node
+	err := yaml.Unmarshal([]byte(`
+components:
+  schemas:
+    Node:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string, enum: [a, b]}
+        count: {type: integer, minimum: 5}
+        next: {$ref: "#/components/schemas/Node"}
+`), &document)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	root := document.Content[0]
+	schema := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(`{$ref: "#/components/schemas/Node"}`), schema); err != nil {
+		t.Fatalf("%s", err)
+	}
+	bytes, err := jsonwriter.Marshal(Value(root, schema.Content[0]))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := "{\n  \"name\": \"a\",\n  \"count\": 5\n}\n"
+	if string(bytes) != expected {
+		t.Errorf("unexpected example %s, expected %s", bytes, expected)
+	}
+}
@@ -0,0 +1,889 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protogen generates .proto files that describe OpenAPI documents.
+package protogen
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	yaml "gopkg.in/yaml.v3"
+
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	"github.com/google/gnostic/printer"
+)
+
+// Prefixes of the local references that are followed.
+const (
+	schemaPrefix      = "#/components/schemas/"
+	parameterPrefix   = "#/components/parameters/"
+	requestBodyPrefix = "#/components/requestBodies/"
+	responsePrefix    = "#/components/responses/"
+)
+
+// Files that are imported for well-known types.
+var wellKnownImports = map[string]string{
+	"google.protobuf.Empty":     "google/protobuf/empty.proto",
+	"google.protobuf.Struct":    "google/protobuf/struct.proto",
+	"google.protobuf.Value":     "google/protobuf/struct.proto",
+	"google.protobuf.ListValue": "google/protobuf/struct.proto",
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+}
+
+// A message is generated for an object schema or for the parameters of an
+// operation.
+type message struct {
+	name        string
+	description string
+	fields      []*field
+	// If oneof is set, all fields are in a oneof with that name.
+	oneof    string
+	messages []*message
+	enums    []*enum
+	// Comments describe parts of the schema or operation that have no
+	// representation in the message.
+	comments []string
+}
+
+type field struct {
+	name        string
+	typeName    string
+	repeated    bool
+	jsonName    string
+	description string
+	deprecated  bool
+}
+
+type enum struct {
+	name        string
+	description string
+	values      []string
+}
+
+// A File describes a .proto file.
+type File struct {
+	Package  string
+	Services []*Service
+	imports  map[string]bool
+	messages []*message
+	enums    []*enum
+}
+
+// A Service is generated for the operations with a tag.
+type Service struct {
+	Name        string
+	Description string
+	Methods     []*Method
+}
+
+// A Method is generated for an operation.
+type Method struct {
+	Name        string
+	Description string
+	Deprecated  bool
+	// Request and Response are the names of the input and output types.
+	Request  string
+	Response string
+	// Verb is the lowercase HTTP method and Path is the path template of
+	// the method's HTTP rule.
+	Verb string
+	Path string
+	// Body and ResponseBody name the request fields that are mapped to
+	// the HTTP request and response bodies.
+	Body         string
+	ResponseBody string
+}
+
+type generator struct {
+	document *openapiv3.Document
+	schemas  map[string]*openapiv3.Schema
+	// typeNames maps the names of component schemas to the messages and
+	// enums that are generated for them. Other component schemas are
+	// replaced by their types where they are referenced.
+	typeNames map[string]string
+	// aliases caches the types of component schemas that aren't messages
+	// or enums, and resolving records the aliases that are being resolved.
+	aliases   map[string]*field
+	resolving map[string]bool
+	// messageTypes contains the top-level messages, which can be used as
+	// the input and output types of methods.
+	messageTypes map[string]bool
+	taken        map[string]bool
+	imports      map[string]bool
+	messages     []*message
+	enums        []*enum
+	services     []*Service
+}
+
+// NewFile returns a .proto file that describes the API of an OpenAPI v3
+// document with gRPC services that can be transcoded to the HTTP API with
+// google.api.http annotations. If packageName is empty, the package is named
+// after the title of the API.
+func NewFile(document *openapiv3.Document, packageName string) *File {
+	g := &generator{
+		document:     document,
+		schemas:      map[string]*openapiv3.Schema{},
+		typeNames:    map[string]string{},
+		aliases:      map[string]*field{},
+		resolving:    map[string]bool{},
+		messageTypes: map[string]bool{},
+		taken:        map[string]bool{},
+		imports:      map[string]bool{"google/api/annotations.proto": true},
+	}
+	if packageName == "" {
+		packageName = strings.Join(lowerWords(document.GetInfo().GetTitle()), "_")
+		if packageName == "" {
+			packageName = "api"
+		}
+	}
+	g.buildMessages()
+	g.buildServices()
+	return &File{
+		Package:  packageName,
+		Services: g.services,
+		imports:  g.imports,
+		messages: g.messages,
+		enums:    g.enums,
+	}
+}
+
+// Builds the messages and enums of component schemas.
+func (g *generator) buildMessages() {
+	var names []string
+	for _, pair := range g.document.GetComponents().GetSchemas().GetAdditionalProperties() {
+		schema := pair.Value.GetSchema()
+		if schema == nil {
+			continue
+		}
+		g.schemas[pair.Name] = schema
+		names = append(names, pair.Name)
+		// Names are reserved before any messages are built so that
+		// messages of operations don't take the names of schemas.
+		if isEnum(schema) || isMessage(schema) {
+			g.typeNames[pair.Name] = g.unique(typeName(pair.Name))
+		}
+	}
+	for _, name := range names {
+		schema := g.schemas[name]
+		switch {
+		case isEnum(schema):
+			g.enums = append(g.enums, g.newEnum(g.typeNames[name], schema))
+		case isMessage(schema):
+			m := &message{name: g.typeNames[name], description: schema.Description}
+			g.messages = append(g.messages, m)
+			g.messageTypes[m.name] = true
+			g.addFields(m, schema, map[string]bool{name: true})
+		}
+	}
+}
+
+// Returns true if a schema is an enumeration of strings.
+func isEnum(schema *openapiv3.Schema) bool {
+	return len(schema.Enum) > 0 && (schema.Type == "string" || schema.Type == "")
+}
+
+// Returns true if a schema is represented by a message.
+func isMessage(schema *openapiv3.Schema) bool {
+	return len(schema.GetProperties().GetAdditionalProperties()) > 0 ||
+		len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0
+}
+
+// Adds the fields of a schema to a message. Visited contains the component
+// schemas that are already being added, to stop cycles of allOf.
+func (g *generator) addFields(m *message, schema *openapiv3.Schema, visited map[string]bool) {
+	for _, s := range schema.AllOf {
+		if ref := s.GetReference(); ref != nil {
+			name := strings.TrimPrefix(ref.XRef, schemaPrefix)
+			if target, ok := g.schemas[name]; ok && !visited[name] {
+				visited[name] = true
+				g.addFields(m, target, visited)
+			}
+		} else if s.GetSchema() != nil {
+			g.addFields(m, s.GetSchema(), visited)
+		}
+	}
+	for _, pair := range schema.GetProperties().GetAdditionalProperties() {
+		g.addField(m, pair.Name, pair.Value)
+	}
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+	if len(variants) > 0 && len(m.fields) == 0 {
+		m.oneof = "value"
+		for i, s := range variants {
+			name := "option" + strconv.Itoa(i+1)
+			if ref := s.GetReference(); ref != nil && strings.HasPrefix(ref.XRef, schemaPrefix) {
+				name = strings.TrimPrefix(ref.XRef, schemaPrefix)
+			}
+			f := g.addField(m, name, s)
+			if f == nil {
+				continue
+			}
+			// The names of variants aren't JSON names.
+			f.jsonName = ""
+			if f.repeated || isMap(f) {
+				// Fields in a oneof can't be repeated.
+				m.fields = m.fields[:len(m.fields)-1]
+				m.comments = append(m.comments, "The "+name+" variant can't be represented in a oneof.")
+			}
+		}
+	}
+}
+
+func (m *message) hasField(name string) bool {
+	for _, f := range m.fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Adds a field for a property to a message and returns it, or returns nil
+// if the message already has a field with that name.
+func (g *generator) addField(m *message, propertyName string, value *openapiv3.SchemaOrReference) *field {
+	name := fieldName(propertyName)
+	if m.hasField(name) {
+		return nil
+	}
+	f := g.fieldType(value, m, propertyName)
+	f.name = name
+	if jsonName(name) != propertyName {
+		f.jsonName = propertyName
+	}
+	if schema := value.GetSchema(); schema != nil {
+		f.description = schema.Description
+		f.deprecated = schema.Deprecated
+	}
+	m.fields = append(m.fields, f)
+	return f
+}
+
+// Returns a field with the type of a schema. Messages and enums that are
+// needed for inline schemas are added to parent, or are added as top-level
+// types if parent is nil.
+func (g *generator) fieldType(value *openapiv3.SchemaOrReference, parent *message, name string) *field {
+	if ref := value.GetReference(); ref != nil {
+		return g.referenceType(ref.XRef)
+	}
+	schema := value.GetSchema()
+	if schema == nil {
+		return g.wellKnown("google.protobuf.Value")
+	}
+	switch {
+	case isEnum(schema):
+		e := g.newEnum(g.nestedName(parent, typeName(name)), schema)
+		if parent != nil {
+			parent.enums = append(parent.enums, e)
+		} else {
+			g.enums = append(g.enums, e)
+		}
+		return &field{typeName: e.name}
+	case isMessage(schema):
+		m := &message{name: g.nestedName(parent, typeName(name)), description: schema.Description}
+		if parent != nil {
+			parent.messages = append(parent.messages, m)
+		} else {
+			g.messages = append(g.messages, m)
+			g.messageTypes[m.name] = true
+		}
+		g.addFields(m, schema, map[string]bool{})
+		return &field{typeName: m.name}
+	}
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "byte", "binary":
+			return &field{typeName: "bytes"}
+		case "date-time":
+			return g.wellKnown("google.protobuf.Timestamp")
+		}
+		return &field{typeName: "string"}
+	case "integer":
+		switch schema.Format {
+		case "int64", "uint64", "uint32":
+			return &field{typeName: schema.Format}
+		}
+		return &field{typeName: "int32"}
+	case "number":
+		if schema.Format == "float" {
+			return &field{typeName: "float"}
+		}
+		return &field{typeName: "double"}
+	case "boolean":
+		return &field{typeName: "bool"}
+	case "array":
+		items := schema.GetItems().GetSchemaOrReference()
+		if len(items) == 0 {
+			return g.wellKnown("google.protobuf.ListValue")
+		}
+		f := g.fieldType(items[0], parent, name)
+		if f.repeated || isMap(f) {
+			// Repeated fields can't contain lists or maps.
+			return g.wellKnown("google.protobuf.ListValue")
+		}
+		f.repeated = true
+		return f
+	case "object":
+		if s := schema.GetAdditionalProperties().GetSchemaOrReference(); s != nil {
+			f := g.fieldType(s, parent, name+"Value")
+			if f.repeated || isMap(f) {
+				// Map values can't be lists or maps.
+				return g.wellKnown("google.protobuf.Struct")
+			}
+			return &field{typeName: "map<string, " + f.typeName + ">"}
+		}
+		return g.wellKnown("google.protobuf.Struct")
+	}
+	return g.wellKnown("google.protobuf.Value")
+}
+
+// Returns a field with the type of a referenced schema.
+func (g *generator) referenceType(ref string) *field {
+	if !strings.HasPrefix(ref, schemaPrefix) {
+		return g.wellKnown("google.protobuf.Value")
+	}
+	name := strings.TrimPrefix(ref, schemaPrefix)
+	if t, ok := g.typeNames[name]; ok {
+		return &field{typeName: t}
+	}
+	if f, ok := g.aliases[name]; ok {
+		return &field{typeName: f.typeName, repeated: f.repeated}
+	}
+	schema, ok := g.schemas[name]
+	if !ok || g.resolving[name] {
+		return g.wellKnown("google.protobuf.Value")
+	}
+	g.resolving[name] = true
+	f := g.fieldType(&openapiv3.SchemaOrReference{
+		Oneof: &openapiv3.SchemaOrReference_Schema{Schema: schema},
+	}, nil, name)
+	delete(g.resolving, name)
+	g.aliases[name] = f
+	return &field{typeName: f.typeName, repeated: f.repeated}
+}
+
+// Returns a field with a well-known type and imports the type.
+func (g *generator) wellKnown(name string) *field {
+	g.imports[wellKnownImports[name]] = true
+	return &field{typeName: name}
+}
+
+func isMap(f *field) bool {
+	return strings.HasPrefix(f.typeName, "map<")
+}
+
+func (g *generator) newEnum(name string, schema *openapiv3.Schema) *enum {
+	e := &enum{name: name, description: schema.Description}
+	prefix := strings.Join(upperWords(name), "_") + "_"
+	e.values = append(e.values, prefix+"UNSPECIFIED")
+	seen := map[string]bool{e.values[0]: true}
+	for _, value := range schema.Enum {
+		var s string
+		if err := yaml.Unmarshal([]byte(value.Yaml), &s); err != nil {
+			continue
+		}
+		v := prefix + strings.Join(upperWords(s), "_")
+		if !seen[v] && v != prefix {
+			seen[v] = true
+			e.values = append(e.values, v)
+		}
+	}
+	return e
+}
+
+// Returns an unused name for a top-level message or enum.
+func (g *generator) unique(name string) string {
+	result := name
+	for i := 2; g.taken[result]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	g.taken[result] = true
+	return result
+}
+
+// Returns an unused name for a message or enum in parent, or a top-level
+// name if parent is nil.
+func (g *generator) nestedName(parent *message, name string) string {
+	if parent == nil {
+		return g.unique(name)
+	}
+	taken := func(s string) bool {
+		for _, m := range parent.messages {
+			if m.name == s {
+				return true
+			}
+		}
+		for _, e := range parent.enums {
+			if e.name == s {
+				return true
+			}
+		}
+		return false
+	}
+	result := name
+	for i := 2; taken(result); i++ {
+		result = name + strconv.Itoa(i)
+	}
+	return result
+}
+
+// Builds services from tags and methods from operations.
+func (g *generator) buildServices() {
+	services := map[string]*Service{}
+	descriptions := map[string]string{}
+	for _, tag := range g.document.Tags {
+		descriptions[tag.Name] = tag.Description
+	}
+	for _, pair := range g.document.GetPaths().GetPath() {
+		item := pair.Value
+		operations := []struct {
+			verb      string
+			operation *openapiv3.Operation
+		}{
+			{"get", item.Get},
+			{"put", item.Put},
+			{"post", item.Post},
+			{"delete", item.Delete},
+			{"options", item.Options},
+			{"head", item.Head},
+			{"patch", item.Patch},
+			{"trace", item.Trace},
+		}
+		for _, o := range operations {
+			if o.operation == nil {
+				continue
+			}
+			tag := ""
+			if len(o.operation.Tags) > 0 {
+				tag = o.operation.Tags[0]
+			}
+			s, ok := services[tag]
+			if !ok {
+				name := tag
+				if name == "" {
+					name = g.document.GetInfo().GetTitle()
+				}
+				name = typeName(name)
+				if !strings.HasSuffix(name, "Service") {
+					name += "Service"
+				}
+				s = &Service{Name: name, Description: descriptions[tag]}
+				services[tag] = s
+				g.services = append(g.services, s)
+			}
+			s.Methods = append(s.Methods, g.newRPC(pair.Name, o.verb, item, o.operation))
+		}
+	}
+}
+
+// Returns a method for an operation and builds its messages.
+func (g *generator) newRPC(path, verb string, item *openapiv3.PathItem, operation *openapiv3.Operation) *Method {
+	name := operation.OperationId
+	if name == "" {
+		name = verb + " " + path
+	}
+	r := &Method{
+		Name:        typeName(name),
+		Description: strings.TrimSpace(operation.Summary + "\n\n" + operation.Description),
+		Deprecated:  operation.Deprecated,
+		Verb:        verb,
+	}
+
+	request := &message{name: g.unique(r.Name + "Request")}
+	for _, parameter := range g.parameters(item, operation) {
+		switch parameter.In {
+		case "path", "query":
+			f := g.addField(request, parameter.Name, parameter.Schema)
+			if f != nil && parameter.Description != "" {
+				f.description = parameter.Description
+			}
+		default:
+			request.comments = append(request.comments,
+				"The "+parameter.In+" parameter "+parameter.Name+" is not a field.")
+		}
+	}
+	r.Path = pathTemplate(path)
+
+	if schema := g.requestBodySchema(operation.RequestBody); schema != nil {
+		name := "body"
+		if ref := schema.GetReference(); ref != nil && strings.HasPrefix(ref.XRef, schemaPrefix) {
+			name = strings.TrimPrefix(ref.XRef, schemaPrefix)
+		}
+		f := g.fieldType(schema, nil, r.Name+"Body")
+		if len(request.fields) == 0 && len(request.comments) == 0 && g.messageTypes[f.typeName] && !f.repeated {
+			// A message body that is the only input is the request.
+			g.taken[request.name] = false
+			r.Request = f.typeName
+			r.Body = "*"
+		} else {
+			f.name = fieldName(name)
+			if request.hasField(f.name) {
+				f.name = "body"
+			}
+			request.fields = append(request.fields, f)
+			r.Body = f.name
+		}
+	}
+	if r.Request == "" {
+		if len(request.fields) == 0 && len(request.comments) == 0 {
+			g.taken[request.name] = false
+			r.Request = g.wellKnown("google.protobuf.Empty").typeName
+		} else {
+			g.messages = append(g.messages, request)
+			g.messageTypes[request.name] = true
+			r.Request = request.name
+		}
+	}
+
+	r.Response = g.wellKnown("google.protobuf.Empty").typeName
+	if schema := g.responseSchema(operation.Responses); schema != nil {
+		f := g.fieldType(schema, nil, r.Name+"Response")
+		if g.messageTypes[f.typeName] && !f.repeated {
+			r.Response = f.typeName
+		} else {
+			// Other types are wrapped in a message with a field that
+			// contains the response body.
+			response := &message{name: g.unique(r.Name + "Response")}
+			f.name = "value"
+			if f.repeated {
+				f.name = "items"
+			}
+			response.fields = append(response.fields, f)
+			g.messages = append(g.messages, response)
+			g.messageTypes[response.name] = true
+			r.Response = response.name
+			r.ResponseBody = f.name
+		}
+	}
+	return r
+}
+
+// Returns the parameters of an operation, including those of its path
+// that it doesn't override.
+func (g *generator) parameters(item *openapiv3.PathItem, operation *openapiv3.Operation) []*openapiv3.Parameter {
+	var result []*openapiv3.Parameter
+	seen := map[string]bool{}
+	for _, list := range [][]*openapiv3.ParameterOrReference{operation.Parameters, item.Parameters} {
+		for _, p := range list {
+			parameter := p.GetParameter()
+			if ref := p.GetReference(); ref != nil {
+				parameter = nil
+				for _, pair := range g.document.GetComponents().GetParameters().GetAdditionalProperties() {
+					if parameterPrefix+pair.Name == ref.XRef {
+						parameter = pair.Value.GetParameter()
+					}
+				}
+			}
+			if parameter == nil || seen[parameter.In+" "+parameter.Name] {
+				continue
+			}
+			seen[parameter.In+" "+parameter.Name] = true
+			result = append(result, parameter)
+		}
+	}
+	// Path parameters come first, in the order of the path.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].In == "path" && result[j].In != "path"
+	})
+	return result
+}
+
+// Returns the JSON schema of a request body.
+func (g *generator) requestBodySchema(body *openapiv3.RequestBodyOrReference) *openapiv3.SchemaOrReference {
+	requestBody := body.GetRequestBody()
+	if ref := body.GetReference(); ref != nil {
+		for _, pair := range g.document.GetComponents().GetRequestBodies().GetAdditionalProperties() {
+			if requestBodyPrefix+pair.Name == ref.XRef {
+				requestBody = pair.Value.GetRequestBody()
+			}
+		}
+	}
+	return contentSchema(requestBody.GetContent())
+}
+
+// Returns the JSON schema of the successful response with the lowest code.
+func (g *generator) responseSchema(responses *openapiv3.Responses) *openapiv3.SchemaOrReference {
+	var code string
+	var response *openapiv3.ResponseOrReference
+	for _, pair := range responses.GetResponseOrReference() {
+		if strings.HasPrefix(pair.Name, "2") && (code == "" || pair.Name < code) {
+			code, response = pair.Name, pair.Value
+		}
+	}
+	r := response.GetResponse()
+	if ref := response.GetReference(); ref != nil {
+		for _, pair := range g.document.GetComponents().GetResponses().GetAdditionalProperties() {
+			if responsePrefix+pair.Name == ref.XRef {
+				r = pair.Value.GetResponse()
+			}
+		}
+	}
+	return contentSchema(r.GetContent())
+}
+
+// Returns the schema of the JSON media type, or of the first media type if
+// there is no JSON media type.
+func contentSchema(content *openapiv3.MediaTypes) *openapiv3.SchemaOrReference {
+	mediaTypes := content.GetAdditionalProperties()
+	for _, pair := range mediaTypes {
+		if pair.Name == "application/json" {
+			return pair.Value.GetSchema()
+		}
+	}
+	if len(mediaTypes) > 0 {
+		return mediaTypes[0].Value.GetSchema()
+	}
+	return nil
+}
+
+// Returns a path template with the names of parameters replaced by the names
+// of their fields.
+func pathTemplate(path string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			b.WriteString(path)
+			return b.String()
+		}
+		b.WriteString(path[:start+1])
+		b.WriteString(fieldName(path[start+1 : end]))
+		b.WriteString("}")
+		path = path[end+1:]
+	}
+}
+
+// Bytes returns the text of the file.
+func (f *File) Bytes() []byte {
+	code := &printer.Code{}
+	code.Print("syntax = \"proto3\";")
+	code.Print()
+	code.Print("package %s;", f.Package)
+	code.Print()
+	var imports []string
+	for name := range f.imports {
+		imports = append(imports, name)
+	}
+	sort.Strings(imports)
+	for _, name := range imports {
+		code.Print("import \"%s\";", name)
+	}
+	for _, s := range f.Services {
+		code.Print()
+		printComment(code, s.Description)
+		code.Print("service %s {", s.Name)
+		code.Indent()
+		for i, r := range s.Methods {
+			if i > 0 {
+				code.Print()
+			}
+			printComment(code, r.Description)
+			code.Print("rpc %s(%s) returns (%s) {", r.Name, r.Request, r.Response)
+			code.Indent()
+			code.Print("option (google.api.http) = {")
+			code.Indent()
+			switch r.Verb {
+			case "get", "put", "post", "delete", "patch":
+				code.Print("%s: \"%s\"", r.Verb, r.Path)
+			default:
+				code.Print("custom: {")
+				code.Indent()
+				code.Print("kind: \"%s\"", strings.ToUpper(r.Verb))
+				code.Print("path: \"%s\"", r.Path)
+				code.Outdent()
+				code.Print("}")
+			}
+			if r.Body != "" {
+				code.Print("body: \"%s\"", r.Body)
+			}
+			if r.ResponseBody != "" {
+				code.Print("response_body: \"%s\"", r.ResponseBody)
+			}
+			code.Outdent()
+			code.Print("};")
+			if r.Deprecated {
+				code.Print("option deprecated = true;")
+			}
+			code.Outdent()
+			code.Print("}")
+		}
+		code.Outdent()
+		code.Print("}")
+	}
+	for _, m := range f.messages {
+		code.Print()
+		printMessage(code, m)
+	}
+	for _, e := range f.enums {
+		code.Print()
+		printEnum(code, e)
+	}
+	return []byte(code.String())
+}
+
+func printMessage(code *printer.Code, m *message) {
+	printComment(code, strings.Join(append([]string{m.description}, m.comments...), "\n\n"))
+	code.Print("message %s {", m.name)
+	code.Indent()
+	for _, e := range m.enums {
+		printEnum(code, e)
+		code.Print()
+	}
+	for _, nested := range m.messages {
+		printMessage(code, nested)
+		code.Print()
+	}
+	if m.oneof != "" {
+		code.Print("oneof %s {", m.oneof)
+		code.Indent()
+	}
+	for i, f := range m.fields {
+		printComment(code, f.description)
+		label := ""
+		if f.repeated {
+			label = "repeated "
+		}
+		var options []string
+		if f.jsonName != "" {
+			options = append(options, "json_name = \""+f.jsonName+"\"")
+		}
+		if f.deprecated {
+			options = append(options, "deprecated = true")
+		}
+		suffix := ""
+		if len(options) > 0 {
+			suffix = " [" + strings.Join(options, ", ") + "]"
+		}
+		code.Print("%s%s %s = %d%s;", label, f.typeName, f.name, i+1, suffix)
+	}
+	if m.oneof != "" {
+		code.Outdent()
+		code.Print("}")
+	}
+	code.Outdent()
+	code.Print("}")
+}
+
+func printEnum(code *printer.Code, e *enum) {
+	printComment(code, e.description)
+	code.Print("enum %s {", e.name)
+	code.Indent()
+	for i, value := range e.values {
+		code.Print("%s = %d;", value, i)
+	}
+	code.Outdent()
+	code.Print("}")
+}
+
+func printComment(code *printer.Code, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		code.Print("%s", strings.TrimRight("// "+line, " "))
+	}
+}
+
+// Splits a name into words at characters that aren't letters or digits and
+// where the case changes from lower to upper.
+func words(name string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			previous := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && next) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+func lowerWords(name string) []string {
+	result := words(name)
+	for i, w := range result {
+		result[i] = strings.ToLower(w)
+	}
+	return result
+}
+
+func upperWords(name string) []string {
+	result := words(name)
+	for i, w := range result {
+		result[i] = strings.ToUpper(w)
+	}
+	return result
+}
+
+// Returns the name of a message, enum, service, or method.
+func typeName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	result := b.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "X" + result
+	}
+	return result
+}
+
+// Returns the name of a field.
+func fieldName(name string) string {
+	result := strings.Join(lowerWords(name), "_")
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "field_" + result
+	}
+	return result
+}
+
+// Returns the JSON name that protoc derives from a field name.
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
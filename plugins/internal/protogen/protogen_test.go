@@ -0,0 +1,46 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protogen
+
+import (
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	for _, test := range []struct {
+		name, typeName, fieldName string
+	}{
+		{"pet", "Pet", "pet"},
+		{"petId", "PetId", "pet_id"},
+		{"HTTPServer", "HTTPServer", "http_server"},
+		{"next-page_token", "NextPageToken", "next_page_token"},
+		{"v2Beta", "V2Beta", "v2_beta"},
+		{"2fa", "X2fa", "field_2fa"},
+		{"", "X", "field_"},
+	} {
+		if actual := typeName(test.name); actual != test.typeName {
+			t.Errorf("unexpected type name for %q: %s, expected %s", test.name, actual, test.typeName)
+		}
+		if actual := fieldName(test.name); actual != test.fieldName {
+			t.Errorf("unexpected field name for %q: %s, expected %s", test.name, actual, test.fieldName)
+		}
+	}
+	if actual := jsonName("next_page_token"); actual != "nextPageToken" {
+		t.Errorf("unexpected JSON name %s", actual)
+	}
+	if actual := pathTemplate("/shelves/{shelfId}/books/{bookId}:publish"); actual != "/shelves/{shelf_id}/books/{book_id}:publish" {
+		t.Errorf("unexpected path template %s", actual)
+	}
+}
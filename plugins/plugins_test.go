@@ -15,10 +15,15 @@
 package gnostic_plugin_v1
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 )
 
 func testPlugin(t *testing.T, plugin string, inputFile string, outputFile string, referenceFile string) {
@@ -111,3 +116,132 @@ func TestValidPluginInvocations(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestLintPlugin(t *testing.T) {
+	// The built-in rules find an error, so gnostic exits with an error.
+	output, err := exec.Command(
+		"gnostic",
+		"lint",
+		"../examples/v3.0/yaml/petstore.yaml",
+		"--lint-paths",
+		"--format=json").Output()
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("expected lint errors, got %+v", err)
+	}
+	var findings []struct {
+		Rule    string `json:"rule"`
+		Pointer string `json:"pointer"`
+		Linter  string `json:"linter"`
+	}
+	if err := json.Unmarshal(output, &findings); err != nil {
+		t.Fatalf("%+v\n%s", err, output)
+	}
+	linters := make(map[string]string)
+	for _, f := range findings {
+		linters[f.Rule+" "+f.Pointer] = f.Linter
+	}
+	if linters["AIP-140 /paths/~1pets~1{petId}/get/parameters/name"] != "builtin" {
+		t.Errorf("missing built-in finding in %s", output)
+	}
+	if linters["PATH /paths/~1pets"] != "gnostic-lint-paths" {
+		t.Errorf("missing plugin finding in %s", output)
+	}
+}
+
+func TestBatchPluginRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "batch")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	output, err := exec.Command(
+		"gnostic",
+		"batch",
+		"../examples/v2.0/yaml/petstore.yaml",
+		"../examples/v3.0/yaml/petstore.yaml",
+		"--plugin-request-out=name=value:"+dir,
+	).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%+v\n%s", err, output)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "plugin-request.pb"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	request := &Request{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	documents := request.AllDocuments()
+	if len(documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(documents))
+	}
+	expected := []struct{ sourceName, model string }{
+		{"../examples/v2.0/yaml/petstore.yaml", "openapi.v2.Document"},
+		{"../examples/v3.0/yaml/petstore.yaml", "openapi.v3.Document"},
+	}
+	for i, document := range documents {
+		if document.SourceName != expected[i].sourceName {
+			t.Errorf("expected source %s, got %s", expected[i].sourceName, document.SourceName)
+		}
+		if len(document.Models) == 0 || document.Models[0].TypeUrl != expected[i].model {
+			t.Errorf("expected a %s model for %s", expected[i].model, document.SourceName)
+		}
+		if len(document.SourceLocations) == 0 {
+			t.Errorf("missing source locations for %s", document.SourceName)
+		}
+	}
+	// The first document is also described by the request.
+	if request.SourceName != documents[0].SourceName || len(request.Models) != len(documents[0].Models) {
+		t.Errorf("request doesn't describe the first document")
+	}
+	if len(request.Parameters) != 1 || request.Parameters[0].Name != "name" {
+		t.Errorf("expected shared parameters, got %v", request.Parameters)
+	}
+	// Requests for one document return that document.
+	single := &Request{SourceName: "petstore.yaml"}
+	if documents := single.AllDocuments(); len(documents) != 1 || documents[0].SourceName != "petstore.yaml" {
+		t.Errorf("unexpected documents of a single request: %v", documents)
+	}
+}
+
+func TestHandleResponseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "response")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	unchanged := filepath.Join(dir, "unchanged.txt")
+	if err := ioutil.WriteFile(unchanged, []byte("same"), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(unchanged, old, old); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response := &Response{Files: []*File{
+		{Name: "bin/run.sh", Data: []byte("#!/bin/sh\n"), Executable: true},
+		{Name: "empty/dir", Directory: true},
+		{Name: "unchanged.txt", Data: []byte("same"), SkipIfUnchanged: true},
+	}}
+	if err := HandleResponse(response, dir); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "bin", "run.sh"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected an executable file, got mode %s", info.Mode())
+	}
+	if !isDirectory(filepath.Join(dir, "empty", "dir")) {
+		t.Errorf("expected a directory")
+	}
+	info, err = os.Stat(unchanged)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("unchanged file was rewritten")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package query selects values in compiled API descriptions with JSONPath
+// expressions, so that scripts can read descriptions without converting
+// them to JSON or YAML first.
+package query
+
+import (
+	"github.com/google/gnostic/compiler/jsonpath"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Document is a compiled description, like an *openapi_v3.Document, that
+// can be exported as YAML.
+type Document interface {
+	ToRawInfo() *yaml.Node
+}
+
+// Result is a value selected by a query.
+type Result struct {
+	// Pointer is a JSON pointer to the value in the document.
+	Pointer string
+	// Node is the YAML representation of the value.
+	Node *yaml.Node
+}
+
+// Value returns the value of a result as a string, int, float64, bool,
+// nil, []interface{}, or map[string]interface{}.
+func (r *Result) Value() interface{} {
+	var value interface{}
+	if err := r.Node.Decode(&value); err != nil {
+		return r.Node.Value
+	}
+	return value
+}
+
+// Eval evaluates a JSONPath expression, like "$.paths[*].get.operationId",
+// against a document and returns the selected values in the order that
+// jsonpath.Path.Find selects them. Queries that don't select any values
+// return an empty list.
+func Eval(document Document, expression string) ([]*Result, error) {
+	return EvalNode(document.ToRawInfo(), expression)
+}
+
+// EvalNode evaluates a JSONPath expression against the YAML representation
+// of a document.
+func EvalNode(root *yaml.Node, expression string) ([]*Result, error) {
+	path, err := jsonpath.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	results := []*Result{}
+	for _, m := range path.Find(root) {
+		results = append(results, &Result{Pointer: m.Pointer(), Node: m.Node})
+	}
+	return results, nil
+}
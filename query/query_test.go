@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	openapi3 "github.com/google/gnostic/openapiv3"
+)
+
+func readDocument(t *testing.T, filename string) *openapi3.Document {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	document, err := openapi3.ParseDocument(bytes)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return document
+}
+
+func TestEval(t *testing.T) {
+	document := readDocument(t, "../examples/v3.0/yaml/petstore.yaml")
+	for _, test := range []struct {
+		expression string
+		pointers   []string
+		values     []interface{}
+	}{
+		{
+			"$.paths[*].get.operationId",
+			[]string{"/paths/~1pets/get/operationId", "/paths/~1pets~1{petId}/get/operationId"},
+			[]interface{}{"listPets", "showPetById"},
+		},
+		{
+			"$.paths['/pets/{petId}','/pets'].get.operationId",
+			[]string{"/paths/~1pets~1{petId}/get/operationId", "/paths/~1pets/get/operationId"},
+			[]interface{}{"showPetById", "listPets"},
+		},
+		{
+			"$.info.license",
+			[]string{"/info/license"},
+			[]interface{}{map[string]interface{}{"name": "MIT"}},
+		},
+		{
+			"$..parameters[?(@.in == 'query')].schema.format",
+			[]string{"/paths/~1pets/get/parameters/0/schema/format"},
+			[]interface{}{"int32"},
+		},
+		{
+			"$.servers[0].url",
+			[]string{"/servers/0/url"},
+			[]interface{}{"https://petstore.openapis.org/v1"},
+		},
+		{
+			"$.paths['/pets'].delete",
+			nil,
+			nil,
+		},
+		{
+			"$.webhooks",
+			nil,
+			nil,
+		},
+	} {
+		results, err := Eval(document, test.expression)
+		if err != nil {
+			t.Errorf("%s: %s", test.expression, err)
+			continue
+		}
+		if len(results) != len(test.values) {
+			t.Errorf("%s: got %d results, want %d", test.expression, len(results), len(test.values))
+			continue
+		}
+		for i, result := range results {
+			if result.Pointer != test.pointers[i] {
+				t.Errorf("%s: got pointer %s, want %s", test.expression, result.Pointer, test.pointers[i])
+			}
+			if value := result.Value(); !reflect.DeepEqual(value, test.values[i]) {
+				t.Errorf("%s: got %#v, want %#v", test.expression, value, test.values[i])
+			}
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	document := readDocument(t, "../examples/v3.0/yaml/petstore.yaml")
+	for _, expression := range []string{"", "paths", "$.paths[", "$..[?(@.in)]x"} {
+		if _, err := Eval(document, expression); err == nil {
+			t.Errorf("%q: expected an error", expression)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Length in bytes of the hashes that are used as identifiers.
+const idLength = 8
+
+// Sets the identifiers of all types and methods of the model.
+//
+// Identifiers are computed from the contents of elements and not from their
+// positions in the model, so an element keeps its identifier when other
+// elements are added, removed, or reordered, and gets a new identifier when
+// it changes. Generators can compare identifiers with those of a previous run
+// to regenerate only the code of elements that changed. Types are referred to
+// by name, so the identifier of a method doesn't change when the fields of its
+// parameter or response types change.
+func (m *Model) setIDs() {
+	for _, t := range m.Types {
+		t.Id = ""
+		t.Id = contentID(t)
+	}
+	for _, method := range m.Methods {
+		setMethodID(method)
+	}
+}
+
+// Sets the identifiers of a method and of the methods of its callbacks.
+func setMethodID(method *Method) {
+	for _, callback := range method.Callbacks {
+		for _, m := range callback.Methods {
+			setMethodID(m)
+		}
+	}
+	method.Id = ""
+	method.Id = contentID(method)
+}
+
+// Returns a hash of the deterministic encoding of a message.
+func contentID(message proto.Message) string {
+	bytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:idLength])
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surface_v1
+
+import (
+	"testing"
+)
+
+func TestIDs(t *testing.T) {
+	pet := &Type{Name: "Pet", Fields: []*Field{{Name: "name", Type: "string"}}}
+	owner := &Type{Name: "Owner", Fields: []*Field{{Name: "pets", Type: "Pet", Kind: FieldKind_ARRAY}}}
+	list := &Method{Operation: "listPets", Path: "/pets", Method: "GET", ResponsesTypeName: "ListPetsResponses"}
+	m := &Model{Types: []*Type{pet, owner}, Methods: []*Method{list}}
+	m.setIDs()
+	petID, ownerID, listID := pet.Id, owner.Id, list.Id
+	if petID == "" || ownerID == "" || listID == "" {
+		t.Fatalf("expected identifiers, got %q %q %q", petID, ownerID, listID)
+	}
+	if petID == ownerID {
+		t.Errorf("expected different identifiers for different types")
+	}
+
+	// Identifiers don't depend on positions or on previous identifiers.
+	m.Types = []*Type{owner, pet}
+	m.setIDs()
+	if pet.Id != petID || owner.Id != ownerID || list.Id != listID {
+		t.Errorf("expected identifiers to be unchanged")
+	}
+
+	// Identifiers change when elements change.
+	pet.Fields = append(pet.Fields, &Field{Name: "tag", Type: "string"})
+	list.Description = "Lists all pets."
+	m.setIDs()
+	if pet.Id == petID || list.Id == listID {
+		t.Errorf("expected identifiers of changed elements to change")
+	}
+	if owner.Id != ownerID {
+		t.Errorf("expected identifier of unchanged type to be unchanged")
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"unicode"
+
+	yaml "gopkg.in/yaml.v3"
 )
 
 // The structure to transport information during the recursive calls inside model_openapiv2.go
@@ -33,6 +36,13 @@ type FieldInfo struct {
 	fieldPosition Position
 	fieldName     string
 	enumValues    []string
+	enumNames     []string
+	required      bool
+	defaultValue  string
+	// For maps
+	valueKind   FieldKind
+	valueType   string
+	valueFormat string
 }
 
 func (m *Model) addType(t *Type) {
@@ -91,10 +101,79 @@ func makeFieldAndAppendToType(info *FieldInfo, schemaType *Type, fieldName strin
 			f.Name = fieldName
 		}
 		f.Type, f.Kind, f.Format, f.Position, f.EnumValues = info.fieldType, info.fieldKind, info.fieldFormat, info.fieldPosition, info.enumValues
+		f.Required, f.DefaultValue = info.required, info.defaultValue
+		f.EnumNames = info.enumNames
+		f.ValueKind, f.ValueType, f.ValueFormat = info.valueKind, info.valueType, info.valueFormat
 		schemaType.Fields = append(schemaType.Fields, f)
 	}
 }
 
+// Helper method to build a surface model Type for a named enum
+func makeEnumType(name string, info *FieldInfo) *Type {
+	return &Type{
+		Name:        name,
+		Kind:        TypeKind_ENUM,
+		ContentType: info.fieldType,
+		Fields:      make([]*Field, 0),
+		EnumValues:  info.enumValues,
+		EnumNames:   info.enumNames,
+	}
+}
+
+// Returns true if a field has a scalar type with enumerated values.
+func isEnum(info *FieldInfo) bool {
+	return info != nil && info.fieldKind == FieldKind_SCALAR && len(info.enumValues) > 0
+}
+
+// Turns the field into a map with values of the type that it describes.
+func (info *FieldInfo) makeMap() {
+	info.valueKind, info.valueType, info.valueFormat = info.fieldKind, info.fieldType, info.fieldFormat
+	mapValueType := determineMapValueType(*info)
+	info.fieldKind, info.fieldType, info.fieldFormat = FieldKind_MAP, "map[string]"+mapValueType, ""
+}
+
+// Returns names for enum values. The names of an x-enum-varnames extension are used if there is one for every value,
+// and otherwise names are derived from the values.
+func enumNames(values []string, varnames []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	if len(varnames) == len(values) {
+		return varnames
+	}
+	names := make([]string, len(values))
+	used := make(map[string]bool)
+	for i, value := range values {
+		var b strings.Builder
+		words := strings.FieldsFunc(strings.Trim(value, `"'`), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			runes := []rune(word)
+			b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+		}
+		name := b.String()
+		if name == "" || unicode.IsDigit([]rune(name)[0]) {
+			name = "Value" + name
+		}
+		if used[name] {
+			name += strconv.Itoa(i)
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// Returns the strings of a specification extension whose value is a list of strings.
+func extensionStrings(text string) []string {
+	var values []string
+	if err := yaml.Unmarshal([]byte(text), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
 // Helper method to determine the type of the value property for a map.
 func determineMapValueType(fInfo FieldInfo) (mapValueType string) {
 	if fInfo.fieldKind == FieldKind_ARRAY {
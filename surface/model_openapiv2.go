@@ -17,6 +17,7 @@ package surface_v1
 import (
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/google/gnostic/compiler"
 	openapiv2 "github.com/google/gnostic/openapiv2"
@@ -53,6 +54,7 @@ func (b *OpenAPI2Builder) buildModel(document *openapiv2.Document, sourceName st
 	if err != nil {
 		log.Printf("Error while building symbolic references. This might cause the plugin to fail: %v", err)
 	}
+	b.model.setIDs()
 	return b.model, nil
 }
 
@@ -61,6 +63,7 @@ func (b *OpenAPI2Builder) buildFromDocument(document *openapiv2.Document) {
 	b.buildFromDefinitions(document.Definitions)
 	b.buildFromParameterDefinitions(document.Parameters)
 	b.buildFromResponseDefinitions(document.Responses)
+	b.buildFromSecurityDefinitions(document.SecurityDefinitions)
 	b.buildFromPaths(document.Paths)
 }
 
@@ -76,6 +79,10 @@ func (b *OpenAPI2Builder) buildFromDefinitions(definitions *openapiv2.Definition
 			// In certain cases no type will be created during the recursion: e.g.: the schema is of type scalar, array
 			// or an reference. So we check whether the surface model Type already exists, and if not then we create it.
 			if t := findType(b.model.Types, namedSchema.Name); t == nil {
+				if isEnum(fInfo) {
+					b.model.addType(makeEnumType(namedSchema.Name, fInfo))
+					continue
+				}
 				t = makeType(namedSchema.Name)
 				makeFieldAndAppendToType(fInfo, t, "value")
 				b.model.addType(t)
@@ -129,7 +136,7 @@ func (b *OpenAPI2Builder) buildSymbolicReferences(document *openapiv2.Document,
 	cache := compiler.GetInfoCache()
 	if len(cache) == 0 && sourceName != "" {
 		// Fills the compiler cache with all kind of references.
-		_, err = document.ResolveReferences(sourceName)
+		_, err = compiler.ResolveReferences(document, sourceName)
 		if err != nil {
 			return err
 		}
@@ -185,11 +192,66 @@ func (b *OpenAPI2Builder) buildFromNamedPath(name string, pathItem *openapiv2.Pa
 				m.Name = generateOperationName(method, name)
 			}
 			m.ParametersTypeName, m.ResponsesTypeName = b.buildFromNamedOperation(m.Name, op)
+			// Operations without their own requirements use those of the document.
+			if op.Security != nil {
+				m.Security = buildSecurityRequirementsV2(op.Security)
+			} else {
+				m.Security = buildSecurityRequirementsV2(b.document.Security)
+			}
 			b.model.addMethod(m)
 		}
 	}
 }
 
+// Builds SecuritySchemes from security definitions. The schemes are described with the terms of OpenAPI v3.
+func (b *OpenAPI2Builder) buildFromSecurityDefinitions(definitions *openapiv2.SecurityDefinitions) {
+	for _, pair := range definitions.GetAdditionalProperties() {
+		scheme := &SecurityScheme{Name: pair.Name}
+		if s := pair.Value.GetBasicAuthenticationSecurity(); s != nil {
+			scheme.Type, scheme.Scheme, scheme.Description = "http", "basic", s.Description
+		} else if s := pair.Value.GetApiKeySecurity(); s != nil {
+			scheme.Type, scheme.In, scheme.ParameterName, scheme.Description = "apiKey", s.In, s.Name, s.Description
+		} else if s := pair.Value.GetOauth2ImplicitSecurity(); s != nil {
+			scheme.Type, scheme.Description = "oauth2", s.Description
+			scheme.Flows = []*OAuthFlow{{Type: "implicit", AuthorizationUrl: s.AuthorizationUrl, Scopes: scopeNamesV2(s.Scopes)}}
+		} else if s := pair.Value.GetOauth2PasswordSecurity(); s != nil {
+			scheme.Type, scheme.Description = "oauth2", s.Description
+			scheme.Flows = []*OAuthFlow{{Type: "password", TokenUrl: s.TokenUrl, Scopes: scopeNamesV2(s.Scopes)}}
+		} else if s := pair.Value.GetOauth2ApplicationSecurity(); s != nil {
+			scheme.Type, scheme.Description = "oauth2", s.Description
+			scheme.Flows = []*OAuthFlow{{Type: "clientCredentials", TokenUrl: s.TokenUrl, Scopes: scopeNamesV2(s.Scopes)}}
+		} else if s := pair.Value.GetOauth2AccessCodeSecurity(); s != nil {
+			scheme.Type, scheme.Description = "oauth2", s.Description
+			scheme.Flows = []*OAuthFlow{{Type: "authorizationCode", AuthorizationUrl: s.AuthorizationUrl, TokenUrl: s.TokenUrl, Scopes: scopeNamesV2(s.Scopes)}}
+		} else {
+			continue
+		}
+		b.model.SecuritySchemes = append(b.model.SecuritySchemes, scheme)
+	}
+}
+
+// Returns the names of OAuth2 scopes.
+func scopeNamesV2(scopes *openapiv2.Oauth2Scopes) []string {
+	var names []string
+	for _, pair := range scopes.GetAdditionalProperties() {
+		names = append(names, pair.Name)
+	}
+	return names
+}
+
+// Builds SecurityRequirements from the security requirements of an operation or document.
+func buildSecurityRequirementsV2(requirements []*openapiv2.SecurityRequirement) []*SecurityRequirement {
+	var result []*SecurityRequirement
+	for _, requirement := range requirements {
+		r := &SecurityRequirement{}
+		for _, pair := range requirement.AdditionalProperties {
+			r.Schemes = append(r.Schemes, &SchemeRequirement{Name: pair.Name, Scopes: pair.Value.GetValue()})
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
 // Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types.
 // If no such Type is added to the model an empty string is returned.
 func (b *OpenAPI2Builder) buildFromNamedOperation(name string, operation *openapiv2.Operation) (parametersTypeName string, responseTypeName string) {
@@ -257,6 +319,7 @@ func (b *OpenAPI2Builder) buildFromParam(parameter *openapiv2.Parameter) (fInfo
 		fInfo = b.buildFromSchemaOrReference(bodyParam.Name, bodyParam.Schema)
 		if fInfo != nil {
 			fInfo.fieldName, fInfo.fieldPosition = bodyParam.Name, Position_BODY
+			fInfo.required = bodyParam.Required
 			return fInfo
 		}
 	} else if nonBodyParam := parameter.GetNonBodyParameter(); nonBodyParam != nil {
@@ -274,25 +337,34 @@ func (b *OpenAPI2Builder) buildFromNonBodyParameter(nonBodyParameter *openapiv2.
 	if headerParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = headerParameter.Name, Position_HEADER, headerParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, headerParameter.Type, headerParameter.Items)
+		fInfo.required, fInfo.defaultValue = headerParameter.Required, parameterDefaultValue(headerParameter.Default)
 	}
 	formDataParameter := nonBodyParameter.GetFormDataParameterSubSchema()
 	if formDataParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = formDataParameter.Name, Position_FORMDATA, formDataParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, formDataParameter.Type, formDataParameter.Items)
+		fInfo.required, fInfo.defaultValue = formDataParameter.Required, parameterDefaultValue(formDataParameter.Default)
 	}
 	queryParameter := nonBodyParameter.GetQueryParameterSubSchema()
 	if queryParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = queryParameter.Name, Position_QUERY, queryParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, queryParameter.Type, queryParameter.Items)
+		fInfo.required, fInfo.defaultValue = queryParameter.Required, parameterDefaultValue(queryParameter.Default)
 	}
 	pathParameter := nonBodyParameter.GetPathParameterSubSchema()
 	if pathParameter != nil {
 		fInfo.fieldName, fInfo.fieldPosition, fInfo.fieldFormat = pathParameter.Name, Position_PATH, pathParameter.Format
 		b.adaptFieldKindAndFieldType(fInfo, pathParameter.Type, pathParameter.Items)
+		fInfo.required = pathParameter.Required
 	}
 	return fInfo
 }
 
+// parameterDefaultValue returns the text of the default value of a parameter, or an empty string if there is none.
+func parameterDefaultValue(d *openapiv2.Any) string {
+	return strings.TrimSuffix(d.GetYaml(), "\n")
+}
+
 // Changes the fieldKind and fieldType inside of 'fInfo' based on different conditions. In case of an array we have to
 // consider that it consists of indefinitely nested items.
 func (b *OpenAPI2Builder) adaptFieldKindAndFieldType(fInfo *FieldInfo, parameterType string, parameterItems *openapiv2.PrimitivesItems) {
@@ -395,8 +467,7 @@ func (b *OpenAPI2Builder) buildFromSchema(name string, schema *openapiv2.Schema)
 			// AdditionalProperties are represented as map
 			fieldInfo := b.buildFromSchemaOrReference(name+"AdditionalProperties", schema)
 			if fieldInfo != nil {
-				mapValueType := determineMapValueType(*fieldInfo)
-				fieldInfo.fieldKind, fieldInfo.fieldType, fieldInfo.fieldFormat = FieldKind_MAP, "map[string]"+mapValueType, ""
+				fieldInfo.makeMap()
 				makeFieldAndAppendToType(fieldInfo, schemaType, "additional_properties")
 			}
 		}
@@ -435,12 +506,23 @@ func (b *OpenAPI2Builder) buildFromSchema(name string, schema *openapiv2.Schema)
 			arrayFieldInfo := b.buildFromSchemaOrReference(name, s)
 			if arrayFieldInfo != nil {
 				fInfo.fieldKind, fInfo.fieldType, fInfo.fieldFormat = FieldKind_ARRAY, arrayFieldInfo.fieldType, arrayFieldInfo.fieldFormat
+				fInfo.enumValues, fInfo.enumNames = arrayFieldInfo.enumValues, arrayFieldInfo.enumNames
 				return fInfo
 			}
 		}
 	default:
 		// We got a scalar value
 		fInfo.fieldKind, fInfo.fieldType, fInfo.fieldFormat = FieldKind_SCALAR, t, schema.Format
+		var varnames []string
+		for _, enum := range schema.Enum {
+			fInfo.enumValues = append(fInfo.enumValues, strings.TrimSuffix(enum.Yaml, "\n"))
+		}
+		for _, extension := range schema.VendorExtension {
+			if extension.Name == "x-enum-varnames" {
+				varnames = extensionStrings(extension.Value.GetYaml())
+			}
+		}
+		fInfo.enumNames = enumNames(fInfo.enumValues, varnames)
 		return fInfo
 	}
 	log.Printf("Unimplemented: could not find field info for schema with name: '%v' and properties: %v", name, schema)
@@ -11,10 +11,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
-func TestModelOpenAPIV2(t *testing.T) {
-	refFile := "testdata/v2.0/petstore.json"
-	modelFile := "testdata/v2.0/petstore.model.json"
-
+func testModelOpenAPIV2(t *testing.T, refFile string, modelFile string) {
 	bFile, err := os.ReadFile(refFile)
 	if err != nil {
 		t.Logf("Failed to read file: %+v", err)
@@ -53,3 +50,19 @@ func TestModelOpenAPIV2(t *testing.T) {
 	x, _ := protojson.Marshal(m)
 	t.Logf("Model: %s", x)
 }
+
+func TestModelOpenAPIV2(t *testing.T) {
+	testModelOpenAPIV2(t, "testdata/v2.0/petstore.json", "testdata/v2.0/petstore.model.json")
+}
+
+func TestModelOpenAPIV2Parameters(t *testing.T) {
+	testModelOpenAPIV2(t, "testdata/v2.0/parameters.yaml", "testdata/v2.0/parameters.model.json")
+}
+
+func TestModelOpenAPIV2Security(t *testing.T) {
+	testModelOpenAPIV2(t, "testdata/v2.0/security.yaml", "testdata/v2.0/security.model.json")
+}
+
+func TestModelOpenAPIV2Types(t *testing.T) {
+	testModelOpenAPIV2(t, "testdata/v2.0/types.yaml", "testdata/v2.0/types.model.json")
+}
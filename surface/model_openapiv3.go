@@ -16,9 +16,13 @@ package surface_v1
 
 import (
 	"log"
+	"strconv"
 	"strings"
 
+	yaml "gopkg.in/yaml.v3"
+
 	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
 	openapiv3 "github.com/google/gnostic/openapiv3"
 )
 
@@ -49,10 +53,12 @@ func (b *OpenAPI3Builder) buildModel(document *openapiv3.Document, sourceName st
 	// Set model properties from passed-in document.
 	b.model.Name = document.Info.Title
 	b.buildFromDocument(document)
+	b.resolveLinks()
 	err := b.buildSymbolicReferences(document, sourceName)
 	if err != nil {
 		log.Printf("Error while building symbolic references. This might cause the plugin to fail: %v", err)
 	}
+	b.model.setIDs()
 	return b.model, nil
 }
 
@@ -96,6 +102,66 @@ func (b *OpenAPI3Builder) buildFromComponents(components *openapiv3.Components)
 		fInfo := b.buildFromRequestBodyOrRef(namedRequestBody.Name, namedRequestBody.Value)
 		b.checkForExistence(namedRequestBody.Name, fInfo)
 	}
+
+	b.buildFromSecuritySchemes(components.GetSecuritySchemes())
+}
+
+// Builds SecuritySchemes from the security schemes of the component section.
+func (b *OpenAPI3Builder) buildFromSecuritySchemes(schemes *openapiv3.SecuritySchemesOrReferences) {
+	for _, pair := range schemes.GetAdditionalProperties() {
+		s := pair.Value.GetSecurityScheme()
+		if s == nil {
+			continue
+		}
+		scheme := &SecurityScheme{
+			Name:             pair.Name,
+			Type:             s.Type,
+			Description:      s.Description,
+			In:               s.In,
+			ParameterName:    s.Name,
+			Scheme:           s.Scheme,
+			BearerFormat:     s.BearerFormat,
+			OpenIdConnectUrl: s.OpenIdConnectUrl,
+		}
+		flows := s.GetFlows()
+		for _, flow := range []struct {
+			name string
+			flow *openapiv3.OauthFlow
+		}{
+			{"implicit", flows.GetImplicit()},
+			{"password", flows.GetPassword()},
+			{"clientCredentials", flows.GetClientCredentials()},
+			{"authorizationCode", flows.GetAuthorizationCode()},
+		} {
+			if flow.flow == nil {
+				continue
+			}
+			f := &OAuthFlow{
+				Type:             flow.name,
+				AuthorizationUrl: flow.flow.AuthorizationUrl,
+				TokenUrl:         flow.flow.TokenUrl,
+				RefreshUrl:       flow.flow.RefreshUrl,
+			}
+			for _, scope := range flow.flow.GetScopes().GetAdditionalProperties() {
+				f.Scopes = append(f.Scopes, scope.Name)
+			}
+			scheme.Flows = append(scheme.Flows, f)
+		}
+		b.model.SecuritySchemes = append(b.model.SecuritySchemes, scheme)
+	}
+}
+
+// Builds SecurityRequirements from the security requirements of an operation or document.
+func buildSecurityRequirementsV3(requirements []*openapiv3.SecurityRequirement) []*SecurityRequirement {
+	var result []*SecurityRequirement
+	for _, requirement := range requirements {
+		r := &SecurityRequirement{}
+		for _, pair := range requirement.AdditionalProperties {
+			r.Schemes = append(r.Schemes, &SchemeRequirement{Name: pair.Name, Scopes: pair.Value.GetValue()})
+		}
+		result = append(result, r)
+	}
+	return result
 }
 
 // Builds Methods and Types (parameters, request bodies, responses) from all paths
@@ -111,7 +177,7 @@ func (b *OpenAPI3Builder) buildSymbolicReferences(document *openapiv3.Document,
 	cache := compiler.GetInfoCache()
 	if len(cache) == 0 && sourceName != "" {
 		// Fills the compiler cache with all kind of references.
-		_, err = document.ResolveReferences(sourceName)
+		_, err = compiler.ResolveReferences(document, sourceName)
 		if err != nil {
 			return err
 		}
@@ -130,6 +196,16 @@ func (b *OpenAPI3Builder) buildSymbolicReferences(document *openapiv3.Document,
 
 // Builds a Method and adds it to the surface model
 func (b *OpenAPI3Builder) buildFromNamedPath(name string, pathItem *openapiv3.PathItem) {
+	for _, m := range b.buildMethods(name, pathItem, nil) {
+		b.model.addMethod(m)
+	}
+}
+
+// Builds a Method for each operation of a path item. Methods of callbacks
+// don't have their own paths, so if their operations have no IDs, they are
+// named after the methods that they are callbacks of.
+func (b *OpenAPI3Builder) buildMethods(name string, pathItem *openapiv3.PathItem, callbackName func(method string) string) []*Method {
+	methods := make([]*Method, 0)
 	for _, method := range []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"} {
 		var op *openapiv3.Operation
 		switch method {
@@ -158,13 +234,202 @@ func (b *OpenAPI3Builder) buildFromNamedPath(name string, pathItem *openapiv3.Pa
 				Name:        sanitizeOperationName(op.OperationId),
 				Description: op.Description,
 			}
-			if m.Name == "" {
+			if m.Name == "" && callbackName != nil {
+				m.Name = callbackName(method)
+			} else if m.Name == "" {
 				m.Name = generateOperationName(method, name)
 			}
 			m.ParametersTypeName, m.ResponsesTypeName = b.buildFromNamedOperation(m.Name, op)
-			b.model.addMethod(m)
+			m.Callbacks = b.buildCallbacks(m.Name, op.Callbacks)
+			m.Links = b.buildLinks(op.Responses)
+			// Operations without their own requirements use those of the document.
+			if op.Security != nil {
+				m.Security = buildSecurityRequirementsV3(op.Security)
+			} else {
+				m.Security = buildSecurityRequirementsV3(b.document.Security)
+			}
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// Builds the Callbacks of an operation. Each runtime expression of a callback
+// is represented by a separate Callback, and its operations become nested Methods.
+func (b *OpenAPI3Builder) buildCallbacks(methodName string, callbacks *openapiv3.CallbacksOrReferences) []*Callback {
+	var result []*Callback
+	for _, namedCallback := range callbacks.GetAdditionalProperties() {
+		callback := b.resolveCallback(namedCallback.Value)
+		if callback == nil {
+			continue
+		}
+		for _, namedPathItem := range callback.Path {
+			name := namedCallback.Name
+			result = append(result, &Callback{
+				Name:       name,
+				Expression: namedPathItem.Name,
+				Methods: b.buildMethods(namedPathItem.Name, namedPathItem.Value, func(method string) string {
+					return methodName + sanitizeOperationName(name) + strings.Title(strings.ToLower(method))
+				}),
+			})
+		}
+	}
+	return result
+}
+
+// Returns the Callback for a CallbackOrReference. References are resolved to the callbacks in the
+// components section of the document.
+func (b *OpenAPI3Builder) resolveCallback(callbackOrRef *openapiv3.CallbackOrReference) *openapiv3.Callback {
+	if callback := callbackOrRef.GetCallback(); callback != nil {
+		return callback
+	}
+	if ref := callbackOrRef.GetReference(); ref != nil {
+		name := validTypeForRef(ref.XRef)
+		for _, namedCallback := range b.document.GetComponents().GetCallbacks().GetAdditionalProperties() {
+			if namedCallback.Name == name {
+				return namedCallback.Value.GetCallback()
+			}
+		}
+		log.Printf("Callback reference %s could not be resolved", ref.XRef)
+	}
+	return nil
+}
+
+// Builds the Links of the responses of an operation. The linked methods are
+// found by resolveLinks after all methods have been built.
+func (b *OpenAPI3Builder) buildLinks(responses *openapiv3.Responses) []*Link {
+	var result []*Link
+	add := func(code string, responseOrRef *openapiv3.ResponseOrReference) {
+		response := b.resolveResponse(responseOrRef)
+		for _, namedLink := range response.GetLinks().GetAdditionalProperties() {
+			link := b.resolveLink(namedLink.Value)
+			if link == nil {
+				continue
+			}
+			l := &Link{
+				Name:         namedLink.Name,
+				Response:     code,
+				Description:  link.Description,
+				Operation:    link.OperationId,
+				OperationRef: link.OperationRef,
+				RequestBody:  anyOrExpressionText(link.RequestBody),
+			}
+			for _, parameter := range link.GetParameters().GetExpression().GetAdditionalProperties() {
+				l.Parameters = append(l.Parameters, &LinkParameter{Name: parameter.Name, Expression: anyText(parameter.Value)})
+			}
+			result = append(result, l)
+		}
+	}
+	for _, namedResponse := range responses.GetResponseOrReference() {
+		add(namedResponse.Name, namedResponse.Value)
+	}
+	if responses.GetDefault() != nil {
+		add("default", responses.Default)
+	}
+	return result
+}
+
+// Returns the Response for a ResponseOrReference. References are resolved to the responses in the
+// components section of the document.
+func (b *OpenAPI3Builder) resolveResponse(responseOrRef *openapiv3.ResponseOrReference) *openapiv3.Response {
+	if response := responseOrRef.GetResponse(); response != nil {
+		return response
+	}
+	if ref := responseOrRef.GetReference(); ref != nil {
+		response := openapiv3.FindNamedResponseOrReference(b.document.GetComponents().GetResponses().GetAdditionalProperties(), validTypeForRef(ref.XRef))
+		if response.GetResponse() == nil {
+			log.Printf("Response reference %s could not be resolved", ref.XRef)
+		}
+		return response.GetResponse()
+	}
+	return nil
+}
+
+// Returns the Link for a LinkOrReference. References are resolved to the links in the
+// components section of the document.
+func (b *OpenAPI3Builder) resolveLink(linkOrRef *openapiv3.LinkOrReference) *openapiv3.Link {
+	if link := linkOrRef.GetLink(); link != nil {
+		return link
+	}
+	if ref := linkOrRef.GetReference(); ref != nil {
+		link := openapiv3.FindNamedLinkOrReference(b.document.GetComponents().GetLinks().GetAdditionalProperties(), validTypeForRef(ref.XRef))
+		if link.GetLink() == nil {
+			log.Printf("Link reference %s could not be resolved", ref.XRef)
 		}
+		return link.GetLink()
 	}
+	return nil
+}
+
+// Sets the names of the methods that links refer to. Links can name an operation by its ID or refer to
+// it with a JSON pointer into the document, like "#/paths/~1pets~1{id}/get". Operations in other
+// documents aren't part of the model, so links to them have no method name.
+func (b *OpenAPI3Builder) resolveLinks() {
+	byOperation := make(map[string]string)
+	byPathAndMethod := make(map[string]string)
+	for _, m := range b.model.Methods {
+		if m.Operation != "" {
+			byOperation[m.Operation] = m.Name
+		}
+		byPathAndMethod[m.Path+" "+m.Method] = m.Name
+	}
+	var resolve func(methods []*Method)
+	resolve = func(methods []*Method) {
+		for _, m := range methods {
+			for _, callback := range m.Callbacks {
+				resolve(callback.Methods)
+			}
+			for _, link := range m.Links {
+				if link.Operation != "" {
+					link.MethodName = byOperation[link.Operation]
+					continue
+				}
+				if !strings.HasPrefix(link.OperationRef, "#") {
+					continue
+				}
+				tokens, err := jsonpointer.ParseFragment(link.OperationRef)
+				if err != nil || len(tokens) != 3 || tokens[0] != "paths" {
+					log.Printf("Operation reference %s of link %s could not be resolved", link.OperationRef, link.Name)
+					continue
+				}
+				link.MethodName = byPathAndMethod[tokens[1]+" "+strings.ToUpper(tokens[2])]
+			}
+		}
+	}
+	resolve(b.model.Methods)
+}
+
+// Returns the text of a scalar value, or the YAML of other values.
+func anyText(a *openapiv3.Any) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(a.GetYaml()), &node); err == nil && len(node.Content) == 1 && node.Content[0].Kind == yaml.ScalarNode {
+		return node.Content[0].Value
+	}
+	return strings.TrimSpace(a.GetYaml())
+}
+
+// Returns the YAML of an AnyOrExpression. Mappings are compiled as expressions, which are written in flow style.
+func anyOrExpressionText(value *openapiv3.AnyOrExpression) string {
+	if a := value.GetAny(); a != nil {
+		return anyText(a)
+	}
+	expression := value.GetExpression()
+	if expression == nil {
+		return ""
+	}
+	node := &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+	for _, pair := range expression.AdditionalProperties {
+		var value yaml.Node
+		if err := yaml.Unmarshal([]byte(pair.Value.GetYaml()), &value); err != nil || len(value.Content) != 1 {
+			continue
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: pair.Name}, value.Content[0])
+	}
+	bytes, err := yaml.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(bytes))
 }
 
 // Builds the "Parameters" and "Responses" types for an operation, adds them to the model, and returns the names of the types.
@@ -179,8 +444,14 @@ func (b *OpenAPI3Builder) buildFromNamedOperation(name string, operation *openap
 		makeFieldAndAppendToType(fieldInfo, operationParameters, "")
 	}
 
+	// Types of request bodies and default responses are named after the operation ID, or
+	// after the method if the operation has no ID.
+	operationName := operation.OperationId
+	if operationName == "" {
+		operationName = name
+	}
 	if operation.RequestBody != nil {
-		fInfo := b.buildFromRequestBodyOrRef(operation.OperationId+"RequestBody", operation.RequestBody)
+		fInfo := b.buildFromRequestBodyOrRef(operationName+"RequestBody", operation.RequestBody)
 		makeFieldAndAppendToType(fInfo, operationParameters, "request_body")
 	}
 
@@ -201,7 +472,7 @@ func (b *OpenAPI3Builder) buildFromNamedOperation(name string, operation *openap
 			}
 		}
 		if responses.Default != nil {
-			fieldInfos := b.buildFromResponseOrRef(operation.OperationId+"Default", responses.Default)
+			fieldInfos := b.buildFromResponseOrRef(operationName+"Default", responses.Default)
 			for _, fieldInfo := range fieldInfos {
 				makeFieldAndAppendToType(fieldInfo, operationResponses, "default")
 			}
@@ -240,6 +511,8 @@ func (b *OpenAPI3Builder) buildFromParam(parameter *openapiv3.Parameter) (fInfo
 	if schemaOrRef := parameter.Schema; schemaOrRef != nil {
 		fInfo = b.buildFromSchemaOrReference(parameter.Name, schemaOrRef)
 		fInfo.fieldName = parameter.Name
+		fInfo.required = parameter.Required
+		fInfo.defaultValue = schemaDefaultValue(schemaOrRef.GetSchema().GetDefault())
 		switch parameter.In {
 		case "body":
 			fInfo.fieldPosition = Position_BODY
@@ -257,6 +530,19 @@ func (b *OpenAPI3Builder) buildFromParam(parameter *openapiv3.Parameter) (fInfo
 	return nil
 }
 
+// schemaDefaultValue returns the text of the default value of a schema, or an empty string if there is none.
+func schemaDefaultValue(d *openapiv3.DefaultType) string {
+	switch v := d.GetOneof().(type) {
+	case *openapiv3.DefaultType_Number:
+		return strconv.FormatFloat(v.Number, 'g', -1, 64)
+	case *openapiv3.DefaultType_Boolean:
+		return strconv.FormatBool(v.Boolean)
+	case *openapiv3.DefaultType_String_:
+		return v.String_
+	}
+	return ""
+}
+
 // A helper method to differentiate between references and actual objects
 func (b *OpenAPI3Builder) buildFromRequestBodyOrRef(name string, reqBodyOrRef *openapiv3.RequestBodyOrReference) (fInfo *FieldInfo) {
 	fInfo = &FieldInfo{}
@@ -349,12 +635,28 @@ func (b *OpenAPI3Builder) buildFromSchema(name string, schema *openapiv3.Schema)
 			// AdditionalProperties are represented as map
 			fieldInfo := b.buildFromSchemaOrReference(name+"AdditionalProperties", schemaOrRef)
 			if fieldInfo != nil {
-				mapValueType := determineMapValueType(*fieldInfo)
-				fieldInfo.fieldKind, fieldInfo.fieldType, fieldInfo.fieldFormat = FieldKind_MAP, "map[string]"+mapValueType, ""
+				fieldInfo.makeMap()
 				makeFieldAndAppendToType(fieldInfo, schemaType, "additional_properties")
 			}
 		}
 
+		if isUnion(schema) {
+			// Each variant of a union is a field that is named after its type.
+			schemaType.Kind = TypeKind_UNION
+			schemaType.Discriminator = schema.GetDiscriminator().GetPropertyName()
+			for idx, schemaOrRef := range schema.OneOf {
+				fieldInfo := b.buildFromSchemaOrReference(name+"Option"+strconv.Itoa(idx+1), schemaOrRef)
+				if fieldInfo != nil {
+					makeFieldAndAppendToType(fieldInfo, schemaType, fieldInfo.fieldType)
+				}
+			}
+			if t := findType(b.model.Types, schemaType.Name); t == nil {
+				b.model.addType(schemaType)
+			}
+			fInfo.fieldKind, fInfo.fieldType = FieldKind_REFERENCE, schemaType.Name
+			return fInfo
+		}
+
 		for _, schemaOrRef := range schema.AnyOf {
 			b.buildFromOneOfAnyOfAndAllOf(schemaOrRef, schemaType)
 		}
@@ -392,6 +694,7 @@ func (b *OpenAPI3Builder) buildFromSchema(name string, schema *openapiv3.Schema)
 			arrayFieldInfo := b.buildFromSchemaOrReference(name, schemaOrRef)
 			if arrayFieldInfo != nil {
 				fInfo.fieldKind, fInfo.fieldType, fInfo.fieldFormat, fInfo.enumValues = FieldKind_ARRAY, arrayFieldInfo.fieldType, arrayFieldInfo.fieldFormat, arrayFieldInfo.enumValues
+				fInfo.enumNames = arrayFieldInfo.enumNames
 				return fInfo
 			}
 		}
@@ -399,6 +702,7 @@ func (b *OpenAPI3Builder) buildFromSchema(name string, schema *openapiv3.Schema)
 		for _, enum := range schema.GetEnum() {
 			fInfo.enumValues = append(fInfo.enumValues, strings.TrimSuffix(enum.Yaml, "\n"))
 		}
+		fInfo.enumNames = enumNames(fInfo.enumValues, schemaEnumVarnames(schema))
 		// We go a scalar value
 		fInfo.fieldKind, fInfo.fieldType, fInfo.fieldFormat = FieldKind_SCALAR, schema.Type, schema.Format
 		return fInfo
@@ -407,6 +711,22 @@ func (b *OpenAPI3Builder) buildFromSchema(name string, schema *openapiv3.Schema)
 	return nil
 }
 
+// Returns true if a schema is a union of the schemas of its oneOf and has no fields of its own.
+func isUnion(schema *openapiv3.Schema) bool {
+	return len(schema.OneOf) > 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0 &&
+		len(schema.GetProperties().GetAdditionalProperties()) == 0 && schema.AdditionalProperties.GetSchemaOrReference() == nil
+}
+
+// Returns the names of enum values in the x-enum-varnames extension of a schema.
+func schemaEnumVarnames(schema *openapiv3.Schema) []string {
+	for _, extension := range schema.SpecificationExtension {
+		if extension.Name == "x-enum-varnames" {
+			return extensionStrings(extension.Value.GetYaml())
+		}
+	}
+	return nil
+}
+
 // buildFromOneOfAnyOfAndAllOf adds appropriate fields to the 'schemaType' given a new 'schemaOrRef'.
 func (b *OpenAPI3Builder) buildFromOneOfAnyOfAndAllOf(schemaOrRef *openapiv3.SchemaOrReference, schemaType *Type) {
 	// Related: https://github.com/google/gnostic-grpc/issues/22
@@ -457,6 +777,10 @@ func (b *OpenAPI3Builder) removeType(toRemove *Type) {
 func (b *OpenAPI3Builder) checkForExistence(name string, fInfo *FieldInfo) {
 	// In certain cases no type will be created during the recursion. (e.g.: the schema is a primitive schema)
 	if t := findType(b.model.Types, name); t == nil {
+		if isEnum(fInfo) {
+			b.model.addType(makeEnumType(name, fInfo))
+			return
+		}
 		t = makeType(name)
 		makeFieldAndAppendToType(fInfo, t, "value")
 		b.model.addType(t)
@@ -11,10 +11,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
-func TestModelOpenAPIV3(t *testing.T) {
-	refFile := "testdata/v3.0/petstore.json"
-	modelFile := "testdata/v3.0/petstore.model.json"
-
+func testModelOpenAPIV3(t *testing.T, refFile string, modelFile string) {
 	bFile, err := os.ReadFile(refFile)
 	if err != nil {
 		t.Logf("Failed to read file: %+v", err)
@@ -53,3 +50,27 @@ func TestModelOpenAPIV3(t *testing.T) {
 	x, _ := protojson.Marshal(m)
 	t.Logf("Model: %s", x)
 }
+
+func TestModelOpenAPIV3(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/petstore.json", "testdata/v3.0/petstore.model.json")
+}
+
+func TestModelOpenAPIV3Callbacks(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/callbacks.yaml", "testdata/v3.0/callbacks.model.json")
+}
+
+func TestModelOpenAPIV3Links(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/links.yaml", "testdata/v3.0/links.model.json")
+}
+
+func TestModelOpenAPIV3Parameters(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/parameters.yaml", "testdata/v3.0/parameters.model.json")
+}
+
+func TestModelOpenAPIV3Security(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/security.yaml", "testdata/v3.0/security.model.json")
+}
+
+func TestModelOpenAPIV3Types(t *testing.T) {
+	testModelOpenAPIV3(t, "testdata/v3.0/types.yaml", "testdata/v3.0/types.model.json")
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Deduplicate replaces structurally equal components of a document with
+// one of them. Values are equal when they only differ in the order of
+// their keys, the styles of their scalars, or their comments. The first
+// of each set of equal components is kept and references to the others
+// are rewritten to refer to it, which can make more components equal.
+// Then inline schemas that occur more than once are moved into named
+// schemas as with Extract, using the same equality. References to the
+// removed components from other documents aren't rewritten.
+func Deduplicate(root *yaml.Node) *yaml.Node {
+	root = compiler.CopyNode(root)
+	doc := document(root)
+	for {
+		renames := make(map[string]string)
+		for _, section := range componentSections(doc) {
+			components := doc
+			for _, key := range section.path {
+				components = compiler.MapValueForKey(components, key)
+			}
+			if components == nil || components.Kind != yaml.MappingNode {
+				continue
+			}
+			names := make(map[string]string)
+			content := components.Content[:0]
+			for i := 0; i+1 < len(components.Content); i += 2 {
+				name, node := components.Content[i].Value, components.Content[i+1]
+				key := structure(node)
+				if kept, ok := names[key]; ok {
+					renames[section.prefix+jsonpointer.Escape(name)] = section.prefix + jsonpointer.Escape(kept)
+					continue
+				}
+				names[key] = name
+				content = append(content, components.Content[i], node)
+			}
+			components.Content = content
+		}
+		if len(renames) == 0 {
+			break
+		}
+		renameReferences(doc, renames)
+	}
+	extract(doc, structuralSchemaKey)
+	return root
+}
+
+// componentSection describes a section of named components.
+type componentSection struct {
+	path   []string
+	prefix string
+}
+
+// componentSections returns the sections of a document that contain
+// components that are referenced with $ref.
+func componentSections(doc *yaml.Node) []componentSection {
+	if compiler.MapValueForKey(doc, "swagger") != nil {
+		return []componentSection{
+			{path: []string{"definitions"}, prefix: "#/definitions/"},
+			{path: []string{"parameters"}, prefix: "#/parameters/"},
+			{path: []string{"responses"}, prefix: "#/responses/"},
+		}
+	}
+	var sections []componentSection
+	for _, kind := range []string{"schemas", "responses", "parameters", "examples",
+		"requestBodies", "headers", "links", "callbacks", "pathItems"} {
+		sections = append(sections, componentSection{
+			path:   []string{"components", kind},
+			prefix: "#/components/" + kind + "/",
+		})
+	}
+	return sections
+}
+
+// renameReferences rewrites references to renamed components and to
+// values inside them. The targets of discriminator mappings are
+// rewritten too.
+func renameReferences(node *yaml.Node, renames map[string]string) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			renameReferences(child, renames)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case key == "$ref" && value.Kind == yaml.ScalarNode:
+				value.Value = renameReference(value.Value, renames)
+			case key == "discriminator" && value.Kind == yaml.MappingNode:
+				if mapping := compiler.MapValueForKey(value, "mapping"); mapping != nil {
+					for _, target := range mapping.Content {
+						if target.Kind == yaml.ScalarNode && strings.HasPrefix(target.Value, "#/") {
+							target.Value = renameReference(target.Value, renames)
+						}
+					}
+				}
+			default:
+				renameReferences(value, renames)
+			}
+		}
+	}
+}
+
+func renameReference(ref string, renames map[string]string) string {
+	if renamed, ok := renames[ref]; ok {
+		return renamed
+	}
+	for i := len(ref) - 1; i > 0; i-- {
+		if ref[i] != '/' {
+			continue
+		}
+		if renamed, ok := renames[ref[:i]]; ok {
+			return renamed + ref[i:]
+		}
+	}
+	return ref
+}
+
+// structuralSchemaKey returns a key that is the same for structurally
+// equal schemas. Object, enum, and composed schemas are considered for
+// extraction; smaller schemas are no larger than references to them.
+func structuralSchemaKey(node *yaml.Node) (string, bool) {
+	if node.Kind != yaml.MappingNode {
+		return "", false
+	}
+	if _, ok := reference(node); ok {
+		return "", false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case "properties", "enum", "allOf", "anyOf", "oneOf":
+			return structure(node), true
+		}
+	}
+	return "", false
+}
+
+// structure returns a string that is the same for structurally equal
+// values: keys are sorted and the styles of scalars and comments are
+// ignored.
+func structure(node *yaml.Node) string {
+	var b strings.Builder
+	writeStructure(&b, node)
+	return b.String()
+}
+
+func writeStructure(b *strings.Builder, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			writeStructure(b, child)
+		}
+	case yaml.AliasNode:
+		writeStructure(b, node.Alias)
+	case yaml.ScalarNode:
+		b.WriteString(node.ShortTag())
+		b.WriteString(strconv.Quote(node.Value))
+	case yaml.SequenceNode:
+		b.WriteString("[")
+		for _, child := range node.Content {
+			writeStructure(b, child)
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	case yaml.MappingNode:
+		type pair struct{ key, value string }
+		pairs := make([]pair, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, pair{structure(node.Content[i]), structure(node.Content[i+1])})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+		b.WriteString("{")
+		for _, p := range pairs {
+			b.WriteString(p.key)
+			b.WriteString(":")
+			b.WriteString(p.value)
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	}
+}
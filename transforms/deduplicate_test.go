@@ -0,0 +1,198 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"testing"
+)
+
+func TestDeduplicate(t *testing.T) {
+	checkTransformation(t, Deduplicate, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/PetList"
+        default:
+          $ref: "#/components/responses/Error"
+  /animals:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [available, sold]
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/AnimalList"
+        default:
+          $ref: "#/components/responses/Problem"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        status:
+          type: string
+          enum: [available, sold]
+    PetList:
+      type: array
+      items:
+        $ref: "#/components/schemas/Pet"
+    Animal:
+      properties:
+        status:
+          enum: [available, sold]
+          type: string
+        name:
+          type: "string"
+      type: object
+    AnimalList:
+      type: array
+      items:
+        $ref: "#/components/schemas/Animal"
+    Zoo:
+      type: object
+      properties:
+        animals:
+          $ref: "#/components/schemas/AnimalList/items"
+  responses:
+    Error:
+      description: error
+    Problem:
+      description: error
+`, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/PetList"
+        default:
+          $ref: "#/components/responses/Error"
+  /animals:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            $ref: "#/components/schemas/Status"
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/PetList"
+        default:
+          $ref: "#/components/responses/Error"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        status:
+          $ref: "#/components/schemas/Status"
+    PetList:
+      type: array
+      items:
+        $ref: "#/components/schemas/Pet"
+    Zoo:
+      type: object
+      properties:
+        animals:
+          $ref: "#/components/schemas/PetList/items"
+    Status:
+      type: string
+      enum: [available, sold]
+  responses:
+    Error:
+      description: error
+`)
+}
+
+func TestDeduplicateV2(t *testing.T) {
+	checkTransformation(t, Deduplicate, `
+swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: "#/parameters/limit"
+        - $ref: "#/parameters/max"
+      responses:
+        "200":
+          schema:
+            $ref: "#/definitions/Pets"
+definitions:
+  Pet:
+    type: object
+  Pets:
+    type: array
+    items:
+      $ref: "#/definitions/Pet"
+  Cat:
+    type: object
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+  max:
+    in: query
+    name: limit
+    type: integer
+`, `
+swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: "#/parameters/limit"
+        - $ref: "#/parameters/limit"
+      responses:
+        "200":
+          schema:
+            $ref: "#/definitions/Pets"
+definitions:
+  Pet:
+    type: object
+  Pets:
+    type: array
+    items:
+      $ref: "#/definitions/Pet"
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+`)
+}
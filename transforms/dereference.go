@@ -0,0 +1,185 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Dereference replaces every reference in a document with a copy of its
+// target, for tools that can't follow references. References to other
+// files are resolved relative to base, which is the file name or URL of
+// the document. Unlike Inline, Dereference can't keep references that
+// would make a value contain itself, so these are reported as errors.
+func Dereference(root *yaml.Node, base string) (*yaml.Node, error) {
+	return DereferenceContext(context.Background(), root, base)
+}
+
+// DereferenceContext is like Dereference, but reads other files with the
+// limits and fetch policy of a context and stops when it is done or when
+// the dereferenced document has more nodes than the MaxNodes limit.
+func DereferenceContext(ctx context.Context, root *yaml.Node, base string) (*yaml.Node, error) {
+	c := newCopier(ctx)
+	root, err := c.copy(root)
+	if err != nil {
+		return nil, err
+	}
+	d := &dereferencer{ctx: ctx, copier: c, root: document(root), base: base}
+	if _, err := d.value(d.root, ""); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// DereferenceV2 replaces the references in an OpenAPI v2 document and
+// returns the resulting document.
+func DereferenceV2(document *openapi_v2.Document, base string) (*openapi_v2.Document, error) {
+	return DereferenceV2Context(context.Background(), document, base)
+}
+
+// DereferenceV2Context is like DereferenceV2 with the context of DereferenceContext.
+func DereferenceV2Context(ctx context.Context, document *openapi_v2.Document, base string) (*openapi_v2.Document, error) {
+	root, err := DereferenceContext(ctx, document.ToRawInfo(), base)
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+// DereferenceV3 replaces the references in an OpenAPI v3 document and
+// returns the resulting document.
+func DereferenceV3(document *openapi_v3.Document, base string) (*openapi_v3.Document, error) {
+	return DereferenceV3Context(context.Background(), document, base)
+}
+
+// DereferenceV3Context is like DereferenceV3 with the context of DereferenceContext.
+func DereferenceV3Context(ctx context.Context, document *openapi_v3.Document, base string) (*openapi_v3.Document, error) {
+	root, err := DereferenceContext(ctx, document.ToRawInfo(), base)
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+type dereferencer struct {
+	ctx    context.Context
+	copier *copier
+	root   *yaml.Node
+	base   string
+	// chain contains the references that are being replaced.
+	chain []string
+}
+
+// value returns a node with the references in it replaced. The key is the
+// key of the node in its parent mapping.
+func (d *dereferencer) value(node *yaml.Node, key string) (*yaml.Node, error) {
+	if ref, ok := reference(node); ok {
+		for i, r := range d.chain {
+			if r == ref {
+				return nil, fmt.Errorf("circular reference %s", strings.Join(append(d.chain[i:], ref), " -> "))
+			}
+		}
+		target, err := d.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		copy, err := d.copier.copy(target)
+		if err != nil {
+			return nil, err
+		}
+		d.chain = append(d.chain, ref)
+		defer func() { d.chain = d.chain[:len(d.chain)-1] }()
+		return d.value(copy, key)
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i].Value
+			if !namedValues[key] && (k == "example" || strings.HasPrefix(k, "x-")) {
+				// these values aren't part of the API description
+				continue
+			}
+			value, err := d.value(node.Content[i+1], k)
+			if err != nil {
+				return nil, err
+			}
+			node.Content[i+1] = value
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			value, err := d.value(child, "")
+			if err != nil {
+				return nil, err
+			}
+			node.Content[i] = value
+		}
+	}
+	return node, nil
+}
+
+// namedValues contains the keys of mappings whose keys are names, like
+// the names of properties and headers, instead of fields. These names can
+// begin with "x-" without being extensions.
+var namedValues = map[string]bool{
+	"properties":      true,
+	"headers":         true,
+	"definitions":     true,
+	"schemas":         true,
+	"parameters":      true,
+	"responses":       true,
+	"examples":        true,
+	"requestBodies":   true,
+	"securitySchemes": true,
+	"links":           true,
+	"callbacks":       true,
+	"content":         true,
+	"encoding":        true,
+	"variables":       true,
+}
+
+// resolve returns the target of a reference. References to other files
+// are read with the compiler, which rewrites the references in their
+// targets to be relative to the base file.
+func (d *dereferencer) resolve(ref string) (*yaml.Node, error) {
+	if err := d.ctx.Err(); err != nil {
+		return nil, err
+	}
+	var target *yaml.Node
+	var err error
+	if strings.HasPrefix(ref, "#") {
+		var tokens []string
+		tokens, err = jsonpointer.ParseFragment(ref)
+		if err == nil {
+			target, err = jsonpointer.ResolveTokens(d.root, tokens)
+		}
+	} else {
+		target, err = compiler.ReadInfoForRefContext(d.ctx, d.base, ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve %s: %s", ref, err)
+	}
+	if target == nil {
+		return nil, fmt.Errorf("could not resolve %s", ref)
+	}
+	return target, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestDereference(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dereference")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "common.yaml"), []byte(`
+parameters:
+  Limit:
+    name: limit
+    in: query
+    schema: {$ref: "#/schemas/Count"}
+schemas:
+  Count: {type: integer}
+`), 0644)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	root := parse(t, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: "common.yaml#/parameters/Limit"
+      responses:
+        "200":
+          headers:
+            x-next: {$ref: "#/components/headers/Next"}
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pets"}
+      x-examples:
+        $ref: "#/missing"
+components:
+  headers:
+    Next:
+      schema: {type: string}
+  schemas:
+    Pets:
+      type: array
+      items: {$ref: "#/components/schemas/Pet"}
+    Pet:
+      type: object
+      example: {$ref: "#/missing"}
+`)
+	before, _ := yaml.Marshal(root)
+	result, err := Dereference(root, filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	after, _ := yaml.Marshal(root)
+	if string(before) != string(after) {
+		t.Errorf("dereference modified its argument")
+	}
+	actual, _ := yaml.Marshal(clearStyles(result))
+	expected, _ := yaml.Marshal(clearStyles(parse(t, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer}
+      responses:
+        "200":
+          headers:
+            x-next:
+              schema: {type: string}
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  example: {$ref: "#/missing"}
+      x-examples:
+        $ref: "#/missing"
+components:
+  headers:
+    Next:
+      schema: {type: string}
+  schemas:
+    Pets:
+      type: array
+      items:
+        type: object
+        example: {$ref: "#/missing"}
+    Pet:
+      type: object
+      example: {$ref: "#/missing"}
+`)))
+	if string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestDereferenceErrors(t *testing.T) {
+	for _, test := range []struct {
+		input, err string
+	}{
+		{`
+components:
+  schemas:
+    Node:
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`, "circular reference #/components/schemas/Node -> #/components/schemas/Node"},
+		{`
+definitions:
+  A: {$ref: "#/definitions/B"}
+  B:
+    items: {$ref: "#/definitions/A"}
+`, "circular reference #/definitions/B -> #/definitions/A -> #/definitions/B"},
+		{`
+schema: {$ref: "#/definitions/Missing"}
+`, "could not resolve #/definitions/Missing"},
+	} {
+		_, err := Dereference(parse(t, test.input), "openapi.yaml")
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("unexpected error %v, expected %s", err, test.err)
+		}
+	}
+}
+
+func TestDereferenceContext(t *testing.T) {
+	_, err := DereferenceContext(limited(t), parse(t, fanOut(8)), "openapi.yaml")
+	if err == nil || !strings.Contains(err.Error(), "more than 100000 nodes") {
+		t.Errorf("expected the dereferenced document to be too large, got %v", err)
+	}
+	if _, err = DereferenceContext(limited(t), parse(t, fanOut(2)), "openapi.yaml"); err != nil {
+		t.Errorf("%s", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Inline replaces references to named schemas with copies of the schemas
+// that they refer to. References in the copies are also replaced, up to
+// the specified depth; references below that depth are kept. A depth of
+// zero or less means that there is no limit. References that would
+// make a schema contain itself are always kept, so recursive schemas
+// remain recursive. The named schemas are not removed. The size of the
+// copies isn't limited; InlineContext limits it.
+func Inline(root *yaml.Node, depth int) *yaml.Node {
+	root, _ = inline(&copier{ctx: context.Background()}, root, depth)
+	return root
+}
+
+// InlineContext is like Inline, but stops when a context is done or when
+// the inlined document has more nodes than the MaxNodes limit of the
+// context.
+func InlineContext(ctx context.Context, root *yaml.Node, depth int) (*yaml.Node, error) {
+	return inline(newCopier(ctx), root, depth)
+}
+
+func inline(c *copier, root *yaml.Node, depth int) (*yaml.Node, error) {
+	root, err := c.copy(root)
+	if err != nil {
+		return nil, err
+	}
+	section, prefix := schemaSection(document(root))
+	in := &inliner{
+		copier: c,
+		root:   document(root),
+		prefix: prefix,
+		depth:  depth,
+		active: make(map[string]bool),
+	}
+	walkDocument(in.root, section, func(node *yaml.Node, name string) *yaml.Node {
+		if name != "" {
+			// named schemas are visited with their names
+			ref := prefix + jsonpointer.Escape(name)
+			in.active[ref] = true
+			defer delete(in.active, ref)
+		}
+		return in.schema(node, 0)
+	})
+	if in.err != nil {
+		return nil, in.err
+	}
+	return root, nil
+}
+
+type inliner struct {
+	copier *copier
+	root   *yaml.Node
+	prefix string
+	depth  int
+	// active contains the references that are being inlined.
+	active map[string]bool
+	// err is the first error from copier, after which nothing is inlined.
+	err error
+}
+
+func (in *inliner) schema(node *yaml.Node, level int) *yaml.Node {
+	if in.err != nil {
+		return node
+	}
+	if ref, ok := reference(node); ok {
+		if !strings.HasPrefix(ref, in.prefix) || in.active[ref] || (in.depth > 0 && level >= in.depth) {
+			return node
+		}
+		target := in.resolve(ref)
+		if target == nil {
+			return node
+		}
+		copy, err := in.copier.copy(target)
+		if err != nil {
+			in.err = err
+			return node
+		}
+		in.active[ref] = true
+		defer delete(in.active, ref)
+		return in.schema(copy, level+1)
+	}
+	walkSchema(node, "", func(child *yaml.Node, name string) *yaml.Node {
+		return in.schema(child, level)
+	})
+	return node
+}
+
+func (in *inliner) resolve(ref string) *yaml.Node {
+	tokens, err := jsonpointer.ParseFragment(ref)
+	if err != nil {
+		return nil
+	}
+	target, err := jsonpointer.ResolveTokens(in.root, tokens)
+	if err != nil {
+		return nil
+	}
+	return target
+}
+
+// Extract moves object schemas that are written inline more than once
+// into named schemas and replaces them with references. Inline schemas
+// that are identical to a named schema are replaced with references to
+// it. New schemas are named after the properties that use them.
+func Extract(root *yaml.Node) *yaml.Node {
+	root = compiler.CopyNode(root)
+	extract(document(root), schemaKey)
+	return root
+}
+
+// extract moves repeated inline schemas of a document into named schemas.
+// Schemas are grouped by the strings returned by key; schemas that it
+// rejects are left inline.
+func extract(doc *yaml.Node, key func(*yaml.Node) (string, bool)) {
+	section, prefix := schemaSection(doc)
+	ex := &extractor{
+		key:    key,
+		groups: make(map[string]*schemaGroup),
+		named:  make(map[string]string),
+		roots:  make(map[*yaml.Node]bool),
+		names:  make(map[string]bool),
+	}
+	schemas := doc
+	for _, key := range section {
+		schemas = compiler.MapValueForKey(schemas, key)
+	}
+	for i := 0; schemas != nil && i+1 < len(schemas.Content); i += 2 {
+		name, node := schemas.Content[i].Value, schemas.Content[i+1]
+		ex.names[name] = true
+		ex.roots[node] = true
+		if key, ok := ex.key(node); ok {
+			if _, ok := ex.named[key]; !ok {
+				ex.named[key] = name
+			}
+		}
+	}
+	walkDocument(doc, section, func(node *yaml.Node, name string) *yaml.Node {
+		ex.schema(node, name)
+		return node
+	})
+	// Groups are in the order in which their schemas were visited, so
+	// nested schemas are extracted before the schemas that contain them.
+	for _, group := range ex.order {
+		name := group.name
+		if name == "" {
+			if len(group.nodes) < 2 {
+				continue
+			}
+			name = ex.uniqueName(group.hint)
+			if schemas == nil {
+				schemas = doc
+				for _, key := range section {
+					schemas = mapValue(schemas, key)
+				}
+			}
+			schemas.Content = append(schemas.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+				compiler.CopyNode(group.nodes[0]))
+		}
+		ref := prefix + jsonpointer.Escape(name)
+		for _, node := range group.nodes {
+			*node = yaml.Node{
+				Kind: yaml.MappingNode,
+				Tag:  "!!map",
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: "$ref"},
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: ref},
+				},
+			}
+		}
+	}
+}
+
+type extractor struct {
+	key    func(*yaml.Node) (string, bool)
+	groups map[string]*schemaGroup
+	order  []*schemaGroup
+	// named maps the contents of named schemas to their names.
+	named map[string]string
+	// roots contains the nodes of named schemas.
+	roots map[*yaml.Node]bool
+	names map[string]bool
+}
+
+// schemaGroup contains identical inline schemas.
+type schemaGroup struct {
+	nodes []*yaml.Node
+	// hint is used to name a new schema for the group.
+	hint string
+	// name is the name of an existing schema that is identical to the group.
+	name string
+}
+
+func (ex *extractor) schema(node *yaml.Node, name string) {
+	walkSchema(node, name, func(child *yaml.Node, name string) *yaml.Node {
+		ex.schema(child, name)
+		return child
+	})
+	if ex.roots[node] {
+		return
+	}
+	key, ok := ex.key(node)
+	if !ok {
+		return
+	}
+	group, ok := ex.groups[key]
+	if !ok {
+		group = &schemaGroup{hint: name, name: ex.named[key]}
+		ex.groups[key] = group
+		ex.order = append(ex.order, group)
+	} else if group.hint == "" {
+		group.hint = name
+	}
+	group.nodes = append(group.nodes, node)
+}
+
+// schemaKey returns a string that is the same for identical schemas.
+// Only object schemas with properties are considered for extraction.
+func schemaKey(node *yaml.Node) (string, bool) {
+	if node.Kind != yaml.MappingNode {
+		return "", false
+	}
+	if _, ok := reference(node); ok || !hasProperties(node) {
+		return "", false
+	}
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func hasProperties(node *yaml.Node) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "properties" {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueName returns a schema name derived from a hint that is not
+// used by any other schema.
+func (ex *extractor) uniqueName(hint string) string {
+	base := "Schema"
+	if r, size := utf8.DecodeRuneInString(hint); size > 0 {
+		base = string(unicode.ToUpper(r)) + hint[size:]
+	}
+	name := base
+	for i := 2; ex.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	ex.names[name] = true
+	return name
+}
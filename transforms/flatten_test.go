@@ -0,0 +1,325 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/gnostic/compiler"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, text string) *yaml.Node {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimSpace(text)), &node); err != nil {
+		t.Fatalf("%s", err)
+	}
+	return &node
+}
+
+// clearStyles removes the styles of the nodes in a tree so that
+// trees can be compared by marshaling them.
+func clearStyles(node *yaml.Node) *yaml.Node {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyles(child)
+	}
+	return node
+}
+
+func checkTransformation(t *testing.T, transformation Transformation, input, expected string) {
+	root := parse(t, input)
+	before, _ := yaml.Marshal(root)
+	result := transformation(root)
+	after, _ := yaml.Marshal(root)
+	if string(before) != string(after) {
+		t.Errorf("transformation modified its argument")
+	}
+	actual, err := yaml.Marshal(clearStyles(compiler.CopyNode(result)))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expectedBytes, _ := yaml.Marshal(clearStyles(parse(t, expected)))
+	if string(actual) != string(expectedBytes) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expectedBytes)
+	}
+}
+
+const inlineInput = `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: "#/components/schemas/Person"
+    Person:
+      type: object
+      properties:
+        friends:
+          type: array
+          items:
+            $ref: "#/components/schemas/Person"
+`
+
+func TestInline(t *testing.T) {
+	checkTransformation(t, func(root *yaml.Node) *yaml.Node { return Inline(root, 0) }, inlineInput, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    owner:
+                      type: object
+                      properties:
+                        friends:
+                          type: array
+                          items:
+                            $ref: "#/components/schemas/Person"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          type: object
+          properties:
+            friends:
+              type: array
+              items:
+                $ref: "#/components/schemas/Person"
+    Person:
+      type: object
+      properties:
+        friends:
+          type: array
+          items:
+            $ref: "#/components/schemas/Person"
+`)
+}
+
+func TestInlineContext(t *testing.T) {
+	_, err := InlineContext(limited(t), parse(t, fanOut(8)), 0)
+	if err == nil || !strings.Contains(err.Error(), "more than 100000 nodes") {
+		t.Errorf("expected the inlined document to be too large, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = InlineContext(ctx, parse(t, fanOut(2)), 0); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	// Documents within the limits are inlined as they are by Inline.
+	root := parse(t, fanOut(2))
+	result, err := InlineContext(limited(t), root, 0)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected, _ := yaml.Marshal(Inline(root, 0))
+	actual, _ := yaml.Marshal(result)
+	if string(actual) != string(expected) || strings.Contains(string(actual), "$ref") {
+		t.Errorf("unexpected result:\n%s", actual)
+	}
+}
+
+func TestInlineWithDepth(t *testing.T) {
+	checkTransformation(t, func(root *yaml.Node) *yaml.Node { return Inline(root, 1) }, inlineInput, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    owner:
+                      $ref: "#/components/schemas/Person"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          type: object
+          properties:
+            friends:
+              type: array
+              items:
+                $ref: "#/components/schemas/Person"
+    Person:
+      type: object
+      properties:
+        friends:
+          type: array
+          items:
+            $ref: "#/components/schemas/Person"
+`)
+}
+
+func TestExtract(t *testing.T) {
+	checkTransformation(t, Extract, `
+swagger: "2.0"
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+              owner:
+                type: object
+                properties:
+                  name:
+                    type: string
+    get:
+      responses:
+        "200":
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+              owner:
+                type: object
+                properties:
+                  name:
+                    type: string
+definitions:
+  Owner:
+    type: object
+    properties:
+      id:
+        type: integer
+  Error:
+    type: object
+    properties:
+      owner:
+        type: object
+        properties:
+          name:
+            type: string
+`, `
+swagger: "2.0"
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: "#/definitions/Schema"
+    get:
+      responses:
+        "200":
+          schema:
+            $ref: "#/definitions/Schema"
+definitions:
+  Owner:
+    type: object
+    properties:
+      id:
+        type: integer
+  Error:
+    type: object
+    properties:
+      owner:
+        $ref: "#/definitions/Owner2"
+  Owner2:
+    type: object
+    properties:
+      name:
+        type: string
+  Schema:
+    type: object
+    properties:
+      name:
+        type: string
+      owner:
+        $ref: "#/definitions/Owner2"
+`)
+}
+
+func TestExtractWithNamedSchema(t *testing.T) {
+	checkTransformation(t, Extract, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`, `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`)
+}
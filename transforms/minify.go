@@ -0,0 +1,210 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// MinifyOptions selects the parts of a document that Minify removes.
+type MinifyOptions struct {
+	// Descriptions removes descriptions and summaries. The required
+	// descriptions of responses are replaced with empty strings.
+	Descriptions bool
+	// Examples removes examples.
+	Examples bool
+	// Extensions removes vendor extensions.
+	Extensions bool
+	// UnusedTags removes tags that no operation uses.
+	UnusedTags bool
+}
+
+// Minify removes documentation and other parts of a document that aren't
+// needed to serve or call an API, leaving a smaller document for tools
+// that only use it at runtime.
+func Minify(root *yaml.Node, opts MinifyOptions) *yaml.Node {
+	root = compiler.CopyNode(root)
+	doc := document(root)
+	m := &minifier{opts: opts, v2: compiler.MapValueForKey(doc, "swagger") != nil}
+	m.object(doc, "")
+	if opts.UnusedTags {
+		m.removeUnusedTags(doc)
+	}
+	return root
+}
+
+type minifier struct {
+	opts MinifyOptions
+	// v2 is set for OpenAPI v2 documents, where examples are values
+	// instead of Example objects.
+	v2 bool
+}
+
+// nameKeys are the keys of mappings from names to objects.
+var nameKeys = map[string]bool{
+	"$defs":               true,
+	"callbacks":           true,
+	"content":             true,
+	"definitions":         true,
+	"dependentSchemas":    true,
+	"encoding":            true,
+	"headers":             true,
+	"links":               true,
+	"parameters":          true,
+	"paths":               true,
+	"pathItems":           true,
+	"patternProperties":   true,
+	"properties":          true,
+	"requestBodies":       true,
+	"responses":           true,
+	"schemas":             true,
+	"securityDefinitions": true,
+	"securitySchemes":     true,
+	"variables":           true,
+	"webhooks":            true,
+}
+
+// dataKeys are the keys of values that aren't part of the API description.
+var dataKeys = map[string]bool{
+	"const":   true,
+	"default": true,
+	"enum":    true,
+	"mapping": true,
+	"value":   true,
+}
+
+// object removes the selected parts of an object. The parent is the key
+// of the mapping of names that contains the object, if any.
+func (m *minifier) object(node *yaml.Node, parent string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			m.object(child, "")
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case strings.HasPrefix(key, "x-"):
+			if m.opts.Extensions {
+				continue
+			}
+		case key == "description" || key == "summary":
+			if m.opts.Descriptions {
+				if parent != "responses" || key != "description" {
+					continue
+				}
+				value.Value = ""
+				value.Style = 0
+			}
+		case key == "example" || key == "examples":
+			if m.opts.Examples {
+				continue
+			}
+			if key == "examples" && !m.v2 && value.Kind == yaml.MappingNode {
+				m.names(value, key)
+			}
+		case key == "security" || dataKeys[key]:
+		case nameKeys[key] && value.Kind == yaml.MappingNode:
+			m.names(value, key)
+		default:
+			m.object(value, "")
+		}
+		content = append(content, node.Content[i], value)
+	}
+	node.Content = content
+}
+
+// names removes the selected parts of the objects in a mapping of names
+// to objects. Paths and responses can also have extensions; in other
+// mappings, such as headers and properties, names that start with "x-"
+// are ordinary names.
+func (m *minifier) names(node *yaml.Node, key string) {
+	extensible := key == "paths" || key == "responses"
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, node.Content[i+1]
+		if strings.HasPrefix(name, "x-") && extensible {
+			if m.opts.Extensions {
+				continue
+			}
+		} else {
+			m.object(value, key)
+		}
+		content = append(content, node.Content[i], value)
+	}
+	node.Content = content
+}
+
+// removeUnusedTags removes the top-level tags that aren't in the tags of
+// any operation.
+func (m *minifier) removeUnusedTags(doc *yaml.Node) {
+	used := make(map[string]bool)
+	var tags *yaml.Node
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "tags" {
+			tags = doc.Content[i+1]
+		} else {
+			collectTags(doc.Content[i+1], used)
+		}
+	}
+	if tags == nil || tags.Kind != yaml.SequenceNode {
+		return
+	}
+	content := tags.Content[:0]
+	for _, tag := range tags.Content {
+		name := ""
+		for i := 0; tag.Kind == yaml.MappingNode && i+1 < len(tag.Content); i += 2 {
+			if tag.Content[i].Value == "name" {
+				name = tag.Content[i+1].Value
+			}
+		}
+		if used[name] {
+			content = append(content, tag)
+		}
+	}
+	tags.Content = content
+}
+
+// collectTags records the names in the tags of operations.
+func collectTags(node *yaml.Node, used map[string]bool) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			collectTags(child, used)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "tags" && value.Kind == yaml.SequenceNode {
+				for _, tag := range value.Content {
+					if tag.Kind == yaml.ScalarNode {
+						used[tag.Value] = true
+					}
+				}
+			} else {
+				collectTags(value, used)
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const minifyInput = `
+openapi: 3.0.0
+info:
+  title: Pets
+  description: An API for pets.
+  version: 1.0.0
+  x-logo: pets.png
+tags:
+  - name: pets
+    description: Operations on pets.
+  - name: stores
+x-tagGroups:
+  - name: All
+    tags: [pets, stores]
+paths:
+  x-internal: true
+  /pets:
+    summary: Pets
+    get:
+      tags: [pets]
+      description: Lists pets.
+      parameters:
+        - name: limit
+          in: query
+          description: The maximum number of pets.
+          schema:
+            type: integer
+            default: 10
+          example: 20
+      responses:
+        "200":
+          description: The pets.
+          headers:
+            x-rate-limit:
+              description: The number of remaining requests.
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+              examples:
+                fido:
+                  summary: A dog.
+                  value:
+                    description: A good dog.
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet.
+      x-go-type: Pet
+      properties:
+        description:
+          type: string
+          description: The description of the pet.
+        x-tag:
+          type: string
+`
+
+func TestMinify(t *testing.T) {
+	checkTransformation(t, func(root *yaml.Node) *yaml.Node {
+		return Minify(root, MinifyOptions{Descriptions: true, Examples: true, Extensions: true, UnusedTags: true})
+	}, minifyInput, `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 10
+      responses:
+        "200":
+          description: ""
+          headers:
+            x-rate-limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        description:
+          type: string
+        x-tag:
+          type: string
+`)
+}
+
+func TestMinifyDescriptions(t *testing.T) {
+	checkTransformation(t, func(root *yaml.Node) *yaml.Node {
+		return Minify(root, MinifyOptions{Descriptions: true})
+	}, minifyInput, `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+  x-logo: pets.png
+tags:
+  - name: pets
+  - name: stores
+x-tagGroups:
+  - name: All
+    tags: [pets, stores]
+paths:
+  x-internal: true
+  /pets:
+    get:
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 10
+          example: 20
+      responses:
+        "200":
+          description: ""
+          headers:
+            x-rate-limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+              examples:
+                fido:
+                  value:
+                    description: A good dog.
+components:
+  schemas:
+    Pet:
+      type: object
+      x-go-type: Pet
+      properties:
+        description:
+          type: string
+        x-tag:
+          type: string
+`)
+}
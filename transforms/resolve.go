@@ -0,0 +1,154 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ResolveInternal replaces the references in a document to values in the
+// same document, such as components of every kind, with copies of those
+// values. References to other files are kept. References that would make
+// a value contain itself are reported as errors, unless keepCycles is
+// set, in which case they are kept. Components are not removed, so the
+// references that are kept remain valid.
+func ResolveInternal(root *yaml.Node, keepCycles bool) (*yaml.Node, error) {
+	return ResolveInternalContext(context.Background(), root, keepCycles)
+}
+
+// ResolveInternalContext is like ResolveInternal, but stops when a context
+// is done or when the resolved document has more nodes than the MaxNodes
+// limit of the context.
+func ResolveInternalContext(ctx context.Context, root *yaml.Node, keepCycles bool) (*yaml.Node, error) {
+	c := newCopier(ctx)
+	root, err := c.copy(root)
+	if err != nil {
+		return nil, err
+	}
+	r := &resolver{
+		copier:     c,
+		root:       document(root),
+		keepCycles: keepCycles,
+		active:     make(map[string]bool),
+	}
+	if _, err := r.value(r.root, "", ""); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// ResolveInternalV3 replaces the internal references in an OpenAPI v3
+// document and returns the resulting document.
+func ResolveInternalV3(document *openapi_v3.Document, keepCycles bool) (*openapi_v3.Document, error) {
+	return ResolveInternalV3Context(context.Background(), document, keepCycles)
+}
+
+// ResolveInternalV3Context is like ResolveInternalV3 with the context of ResolveInternalContext.
+func ResolveInternalV3Context(ctx context.Context, document *openapi_v3.Document, keepCycles bool) (*openapi_v3.Document, error) {
+	root, err := ResolveInternalContext(ctx, document.ToRawInfo(), keepCycles)
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+type resolver struct {
+	copier     *copier
+	root       *yaml.Node
+	keepCycles bool
+	// active contains the pointers of the values that are being resolved,
+	// which are the locations of the values, or of the targets that they
+	// were copied from, and of the values that contain them.
+	active map[string]bool
+	// chain contains the references that are being replaced.
+	chain []string
+}
+
+// value returns a node with the internal references in it replaced. The
+// pointer is the location of the node in the document, and the key is the
+// key of the node in its parent mapping.
+func (r *resolver) value(node *yaml.Node, pointer, key string) (*yaml.Node, error) {
+	if ref, ok := reference(node); ok && strings.HasPrefix(ref, "#") {
+		tokens, err := jsonpointer.ParseFragment(ref)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve %s: %s", ref, err)
+		}
+		target, err := jsonpointer.ResolveTokens(r.root, tokens)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve %s: %s", ref, err)
+		}
+		targetPointer := jsonpointer.Format(tokens...)
+		if r.active[targetPointer] {
+			if r.keepCycles {
+				return node, nil
+			}
+			return nil, fmt.Errorf("circular reference %s", strings.Join(append(r.cycle(targetPointer), ref), " -> "))
+		}
+		copy, err := r.copier.copy(target)
+		if err != nil {
+			return nil, err
+		}
+		r.chain = append(r.chain, ref)
+		defer func() { r.chain = r.chain[:len(r.chain)-1] }()
+		return r.value(copy, targetPointer, key)
+	}
+	r.active[pointer] = true
+	defer delete(r.active, pointer)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k := node.Content[i].Value
+			if !namedValues[key] && (k == "example" || strings.HasPrefix(k, "x-")) {
+				// these values aren't part of the API description
+				continue
+			}
+			value, err := r.value(node.Content[i+1], jsonpointer.Append(pointer, k), k)
+			if err != nil {
+				return nil, err
+			}
+			node.Content[i+1] = value
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			value, err := r.value(child, jsonpointer.Append(pointer, fmt.Sprintf("%d", i)), "")
+			if err != nil {
+				return nil, err
+			}
+			node.Content[i] = value
+		}
+	}
+	return node, nil
+}
+
+// cycle returns the references that lead back to a value that contains
+// the value at a pointer.
+func (r *resolver) cycle(pointer string) []string {
+	for i, ref := range r.chain {
+		if tokens, err := jsonpointer.ParseFragment(ref); err == nil {
+			if p := jsonpointer.Format(tokens...); p == pointer || strings.HasPrefix(pointer, p+"/") {
+				return r.chain[i:]
+			}
+		}
+	}
+	// The value is in the document, outside of the copies of targets.
+	return r.chain
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gnostic/compiler"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const resolveSource = `
+openapi: 3.0.0
+info: {title: pets, version: 1.0.0}
+paths:
+  /pets:
+    parameters:
+      - $ref: "#/components/parameters/Limit"
+    post:
+      requestBody: {$ref: "#/components/requestBodies/Pet"}
+      responses:
+        "200": {$ref: "#/components/responses/Pets"}
+        default: {$ref: "common.yaml#/responses/Error"}
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema: {type: integer}
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema: {$ref: "#/components/schemas/Pet"}
+  responses:
+    Pets:
+      description: pets
+      headers:
+        Next: {$ref: "#/components/headers/Next"}
+      content:
+        application/json:
+          schema:
+            type: array
+            items: {$ref: "#/components/schemas/Pet"}
+  headers:
+    Next:
+      schema: {type: string}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        parent: {$ref: "#/components/schemas/Pet"}
+`
+
+func TestResolveInternal(t *testing.T) {
+	root := parse(t, resolveSource)
+	before, _ := yaml.Marshal(root)
+	result, err := ResolveInternal(root, true)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	after, _ := yaml.Marshal(root)
+	if string(before) != string(after) {
+		t.Errorf("resolution modified its argument")
+	}
+	pet := `
+type: object
+properties:
+  parent: {$ref: "#/components/schemas/Pet"}`
+	actual, _ := yaml.Marshal(clearStyles(result))
+	expected, _ := yaml.Marshal(clearStyles(parse(t, `
+openapi: 3.0.0
+info: {title: pets, version: 1.0.0}
+paths:
+  /pets:
+    parameters:
+      - name: limit
+        in: query
+        schema: {type: integer}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:`+indent(pet, 14)+`
+      responses:
+        "200":
+          description: pets
+          headers:
+            Next:
+              schema: {type: string}
+          content:
+            application/json:
+              schema:
+                type: array
+                items:`+indent(pet, 18)+`
+        default: {$ref: "common.yaml#/responses/Error"}
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema: {type: integer}
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:`+indent(pet, 12)+`
+  responses:
+    Pets:
+      description: pets
+      headers:
+        Next:
+          schema: {type: string}
+      content:
+        application/json:
+          schema:
+            type: array
+            items:`+indent(pet, 14)+`
+  headers:
+    Next:
+      schema: {type: string}
+  schemas:
+    Pet:`+indent(pet, 6)+`
+`)))
+	if string(actual) != string(expected) {
+		t.Errorf("unexpected result:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+// indent indents the lines of a YAML value.
+func indent(value string, n int) string {
+	return strings.Replace(value, "\n", "\n"+strings.Repeat(" ", n), -1)
+}
+
+func TestResolveInternalErrors(t *testing.T) {
+	for _, test := range []struct {
+		input, err string
+	}{
+		{resolveSource, "circular reference #/components/schemas/Pet -> #/components/schemas/Pet"},
+		{`
+components:
+  schemas:
+    A: {$ref: "#/components/schemas/B"}
+    B:
+      items: {$ref: "#/components/schemas/A"}
+`, "circular reference #/components/schemas/B -> #/components/schemas/A -> #/components/schemas/B"},
+		{`
+components:
+  schemas:
+    A:
+      properties:
+        b: {$ref: "#/components/schemas/B"}
+    B:
+      items: {$ref: "#/components/schemas/A"}
+`, "circular reference #/components/schemas/B -> #/components/schemas/A"},
+		{`
+schema: {$ref: "#/components/schemas/Missing"}
+`, "could not resolve #/components/schemas/Missing"},
+	} {
+		_, err := ResolveInternal(parse(t, test.input), false)
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("unexpected error %v, expected %s", err, test.err)
+		}
+	}
+}
+
+// fanOut returns a document whose schemas each refer to the next schema
+// ten times, so that replacing its references makes copies of the last
+// schema 10^levels times.
+func fanOut(levels int) string {
+	var b strings.Builder
+	b.WriteString("openapi: 3.0.0\ncomponents:\n  schemas:\n")
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&b, "    S%d:\n      properties:\n", i)
+		for j := 0; j < 10; j++ {
+			fmt.Fprintf(&b, "        p%d: {$ref: \"#/components/schemas/S%d\"}\n", j, i+1)
+		}
+	}
+	fmt.Fprintf(&b, "    S%d: {type: string}\n", levels)
+	return b.String()
+}
+
+// limited returns a context with limits that stop transformations of the
+// documents returned by fanOut.
+func limited(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(compiler.WithLimits(context.Background(), compiler.Limits{MaxNodes: 100000}), 3*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestResolveInternalContext(t *testing.T) {
+	_, err := ResolveInternalContext(limited(t), parse(t, fanOut(8)), false)
+	if err == nil || !strings.Contains(err.Error(), "more than 100000 nodes") {
+		t.Errorf("expected the resolved document to be too large, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = ResolveInternalContext(ctx, parse(t, fanOut(2)), false); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	// Documents within the limits are resolved.
+	result, err := ResolveInternalContext(limited(t), parse(t, fanOut(2)), false)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	output, err := yaml.Marshal(result)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(string(output), "$ref") {
+		t.Errorf("expected the references to be replaced: %s", output)
+	}
+}
+
+func TestResolveInternalV3(t *testing.T) {
+	root := parse(t, resolveSource)
+	document, err := openapi_v3.NewDocument(root.Content[0], compiler.NewContext("$root", root, nil))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	result, err := ResolveInternalV3(document, true)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	responses := result.Paths.Path[0].Value.Post.Responses
+	if description := responses.ResponseOrReference[0].Value.GetResponse().GetDescription(); description != "pets" {
+		t.Errorf("unexpected description of resolved response: %q", description)
+	}
+	if ref := responses.Default.GetReference().GetXRef(); ref != "common.yaml#/responses/Error" {
+		t.Errorf("unexpected reference to other file: %q", ref)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transforms rewrites OpenAPI documents into equivalent forms
+// for tools that can't handle all of the features of OpenAPI.
+//
+// Transformations operate on the YAML representation of a document so
+// that the same code can be used for OpenAPI v2 and v3. TransformV2 and
+// TransformV3 apply them to compiled documents.
+package transforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/gnostic/compiler"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Transformation rewrites the YAML representation of an OpenAPI document.
+// Transformations return a new node and don't modify their argument.
+type Transformation func(root *yaml.Node) *yaml.Node
+
+// TransformV2 applies transformations to an OpenAPI v2 document and
+// returns the transformed document.
+func TransformV2(document *openapi_v2.Document, transformations ...Transformation) (*openapi_v2.Document, error) {
+	root := apply(document.ToRawInfo(), transformations)
+	return openapi_v2.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+// TransformV3 applies transformations to an OpenAPI v3 document and
+// returns the transformed document.
+func TransformV3(document *openapi_v3.Document, transformations ...Transformation) (*openapi_v3.Document, error) {
+	root := apply(document.ToRawInfo(), transformations)
+	return openapi_v3.NewDocument(root, compiler.NewContext("$root", root, nil))
+}
+
+func apply(root *yaml.Node, transformations []Transformation) *yaml.Node {
+	for _, t := range transformations {
+		root = t(root)
+	}
+	return root
+}
+
+// A copier copies the targets of the references that transformations
+// replace. Targets can contain references to other targets, so copies can
+// be exponentially larger than the documents that contain them. Copiers
+// return errors when their context is done or when the copied nodes
+// exceed the MaxNodes limit of the context.
+type copier struct {
+	ctx   context.Context
+	limit int
+	count int
+}
+
+func newCopier(ctx context.Context) *copier {
+	return &copier{ctx: ctx, limit: compiler.GetLimitsContext(ctx).MaxNodes}
+}
+
+// copy returns a deep copy of a node.
+func (c *copier) copy(node *yaml.Node) (*yaml.Node, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.limit > 0 {
+		c.count += countNodes(node)
+		if c.count > c.limit {
+			return nil, fmt.Errorf("document is too large after replacing references (more than %d nodes)", c.limit)
+		}
+	}
+	return compiler.CopyNode(node), nil
+}
+
+// countNodes counts the nodes in a tree.
+func countNodes(node *yaml.Node) int {
+	count := 1
+	for _, child := range node.Content {
+		count += countNodes(child)
+	}
+	return count
+}
+
+// document returns the mapping node that contains a document.
+func document(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+// schemaSection returns the path to the named schemas of a document and
+// the prefix of references to them.
+func schemaSection(root *yaml.Node) ([]string, string) {
+	if compiler.MapValueForKey(root, "swagger") != nil {
+		return []string{"definitions"}, "#/definitions/"
+	}
+	return []string{"components", "schemas"}, "#/components/schemas/"
+}
+
+// mapValue returns the value for a key in a mapping node, creating an
+// empty mapping for it if the key is missing.
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if value := compiler.MapValueForKey(node, key); value != nil {
+		return value
+	}
+	value := compiler.NewMappingNode()
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(key), value)
+	return value
+}
+
+// reference returns the value of the $ref key of a mapping node.
+func reference(node *yaml.Node) (string, bool) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return "", false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value, true
+		}
+	}
+	return "", false
+}
+
+// schemaVisitor is called for each schema in a document and returns
+// the node that should replace it. The name is a hint that can be used
+// to name the schema.
+type schemaVisitor func(node *yaml.Node, name string) *yaml.Node
+
+// walkDocument calls a visitor for each of the top-level schemas in a
+// document: the named schemas and the schemas of parameters, responses,
+// and media types. Named schemas are visited with their names and other
+// schemas with an empty name. Visitors use walkSchema to visit nested schemas.
+func walkDocument(root *yaml.Node, section []string, visit schemaVisitor) {
+	schemas := root
+	for _, key := range section {
+		schemas = compiler.MapValueForKey(schemas, key)
+	}
+	walkNode(root, schemas, visit)
+	if schemas != nil && schemas.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(schemas.Content); i += 2 {
+			schemas.Content[i+1] = visit(schemas.Content[i+1], schemas.Content[i].Value)
+		}
+	}
+}
+
+func walkNode(node, schemas *yaml.Node, visit schemaVisitor) {
+	if node == schemas {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			switch {
+			case key == "schema":
+				node.Content[i+1] = visit(node.Content[i+1], "")
+			case key == "example" || key == "examples" || strings.HasPrefix(key, "x-"):
+				// these values aren't part of the API description
+			default:
+				walkNode(node.Content[i+1], schemas, visit)
+			}
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			walkNode(child, schemas, visit)
+		}
+	}
+}
+
+// walkSchema calls a visitor for each of the schemas nested in a schema.
+func walkSchema(node *yaml.Node, name string, visit schemaVisitor) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		switch node.Content[i].Value {
+		case "properties":
+			if value.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					value.Content[j+1] = visit(value.Content[j+1], value.Content[j].Value)
+				}
+			}
+		case "items":
+			if value.Kind == yaml.SequenceNode {
+				for j, item := range value.Content {
+					value.Content[j] = visit(item, name+"Item")
+				}
+			} else {
+				node.Content[i+1] = visit(value, name+"Item")
+			}
+		case "additionalProperties":
+			if value.Kind == yaml.MappingNode {
+				node.Content[i+1] = visit(value, name+"Value")
+			}
+		case "not":
+			node.Content[i+1] = visit(value, name)
+		case "allOf", "anyOf", "oneOf":
+			if value.Kind == yaml.SequenceNode {
+				for j, item := range value.Content {
+					value.Content[j] = visit(item, name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_workspace_v1
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/google/gnostic/compiler"
+	"github.com/google/gnostic/compiler/jsonpointer"
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+// NewWorkspace compiles a set of API descriptions and the files that they
+// refer to into a workspace. Descriptions can refer to each other and to
+// files that contain only components, which are added to the workspace as
+// libraries. The targets of references to other files, and the components
+// in the same files that those targets refer to, are compiled into the
+// component pool with the format of the descriptions that use them.
+func NewWorkspace(sources []string) (*Workspace, error) {
+	if len(sources) == 0 {
+		return nil, errNoSources
+	}
+	b := &builder{
+		workspace:  &Workspace{},
+		files:      make(map[string]*file),
+		components: make(map[string]*Component),
+	}
+	for _, source := range sources {
+		b.read(cleanName(source), formatUnknown)
+	}
+	// Libraries are added to the list of files as they are found.
+	for i := 0; i < len(b.order); i++ {
+		f := b.order[i]
+		walk(f.root, nil, func(ref string, keys []string) {
+			if !strings.HasPrefix(ref, "#") {
+				b.reference(f, ref, kindForLocation(keys))
+			}
+		})
+	}
+	for _, f := range b.order {
+		if f.document != nil {
+			b.workspace.Documents = append(b.workspace.Documents, f.document)
+		} else {
+			b.workspace.Libraries = append(b.workspace.Libraries, f.name)
+		}
+	}
+	return b.workspace, compiler.NewErrorGroupOrNil(b.errors)
+}
+
+// Formats of the files of a workspace.
+const (
+	formatUnknown = iota
+	formatOpenAPI2
+	formatOpenAPI3
+)
+
+type file struct {
+	name   string
+	root   *yaml.Node
+	format int
+	// document is nil for libraries.
+	document *Document
+}
+
+type builder struct {
+	workspace  *Workspace
+	files      map[string]*file
+	order      []*file
+	components map[string]*Component
+	errors     []error
+}
+
+// read reads and compiles a file if it hasn't been read already. Files that
+// aren't API descriptions are libraries, which use the format of the first
+// description that refers to them.
+func (b *builder) read(name string, format int) *file {
+	if f, ok := b.files[name]; ok {
+		return f
+	}
+	f := &file{name: name, format: format}
+	b.files[name] = f
+	bytes, err := compiler.ReadBytesForFile(name)
+	if err != nil {
+		b.errors = append(b.errors, err)
+		return nil
+	}
+	info, err := compiler.ReadInfoFromBytes(name, bytes)
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("%s: %s", name, err))
+		return nil
+	}
+	if len(info.Content) == 0 {
+		b.errors = append(b.errors, fmt.Errorf("%s: document has no content", name))
+		return nil
+	}
+	f.root = info.Content[0]
+	b.order = append(b.order, f)
+	var document proto.Message
+	context := compiler.NewContext("$root", f.root, nil)
+	switch {
+	case compiler.MapHasKey(f.root, "openapi"):
+		f.format = formatOpenAPI3
+		document, err = openapi_v3.NewDocument(f.root, context)
+	case compiler.MapHasKey(f.root, "swagger"):
+		f.format = formatOpenAPI2
+		document, err = openapi_v2.NewDocument(f.root, context)
+	default:
+		return f
+	}
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("%s: %s", name, err))
+		return f
+	}
+	model, err := newAny(document)
+	if err != nil {
+		b.errors = append(b.errors, err)
+	}
+	f.document = &Document{SourceName: name, Model: model}
+	return f
+}
+
+// reference records a reference from a file to another file.
+func (b *builder) reference(from *file, ref string, kind string) {
+	name := cleanName(compiler.FilenameForRef(from.name, ref))
+	to := b.read(name, from.format)
+	if to == nil {
+		return
+	}
+	if from.document != nil && to != from && !contains(from.document.Dependencies, to.name) {
+		from.document.Dependencies = append(from.document.Dependencies, to.name)
+	}
+	fragment := ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		fragment = ref[i:]
+	}
+	tokens, err := jsonpointer.ParseFragment(fragment)
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("%s: %s", from.name, err))
+		return
+	}
+	b.component(to, tokens, kind, from.name)
+}
+
+// component adds the value at a location in a file to the component pool.
+func (b *builder) component(f *file, tokens []string, kind string, referrer string) {
+	pointer := jsonpointer.FormatFragment(tokens...)
+	key := f.name + pointer
+	if c, ok := b.components[key]; ok {
+		if !contains(c.ReferencedBy, referrer) {
+			c.ReferencedBy = append(c.ReferencedBy, referrer)
+		}
+		return
+	}
+	node, err := jsonpointer.ResolveTokens(f.root, tokens)
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("could not resolve %s%s: %s", f.name, pointer, err))
+		return
+	}
+	c := &Component{
+		SourceName:   f.name,
+		Pointer:      pointer,
+		Kind:         kind,
+		ReferencedBy: []string{referrer},
+	}
+	if k, name, ok := componentName(tokens); ok {
+		c.Kind, c.Name = k, name
+	} else if len(tokens) > 0 {
+		c.Name = tokens[len(tokens)-1]
+	} else {
+		c.Name = strings.TrimSuffix(path.Base(filepath.ToSlash(f.name)), path.Ext(f.name))
+	}
+	b.components[key] = c
+	b.workspace.Components = append(b.workspace.Components, c)
+	message, err := compileComponent(node, c.Kind, f.format, compiler.NewContext(c.Name, node, nil))
+	if err != nil {
+		b.errors = append(b.errors, fmt.Errorf("%s%s: %s", f.name, pointer, err))
+	} else if c.Value, err = newAny(message); err != nil {
+		b.errors = append(b.errors, err)
+	}
+	// Add the components in the same file that the component refers to.
+	walk(node, keysForTokens(c.Kind, tokens), func(ref string, keys []string) {
+		if !strings.HasPrefix(ref, "#") {
+			return
+		}
+		tokens, err := jsonpointer.ParseFragment(ref)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Errorf("%s%s: %s", f.name, pointer, err))
+			return
+		}
+		b.component(f, tokens, kindForLocation(keys), f.name)
+	})
+}
+
+// componentName returns the kind and name of a component from its location
+// in the components of an OpenAPI v3 document, the definitions,
+// parameters, or responses of an OpenAPI v2 document, or a mapping of a
+// library that is named after a kind of component.
+func componentName(tokens []string) (kind, name string, ok bool) {
+	switch {
+	case len(tokens) == 3 && tokens[0] == "components" && componentKinds[tokens[1]]:
+		return tokens[1], tokens[2], true
+	case len(tokens) == 2 && tokens[0] == "definitions":
+		return "schemas", tokens[1], true
+	case len(tokens) == 2 && componentKinds[tokens[0]]:
+		return tokens[0], tokens[1], true
+	}
+	return "", "", false
+}
+
+// keysForTokens returns the keys used to find the kinds of the values
+// that a component refers to.
+func keysForTokens(kind string, tokens []string) []string {
+	if kind != "" {
+		return []string{kind, ""}
+	}
+	return tokens
+}
+
+// componentKinds are the kinds of components in OpenAPI v3 documents.
+var componentKinds = map[string]bool{
+	"schemas":         true,
+	"responses":       true,
+	"parameters":      true,
+	"examples":        true,
+	"requestBodies":   true,
+	"headers":         true,
+	"securitySchemes": true,
+	"links":           true,
+	"callbacks":       true,
+	"pathItems":       true,
+}
+
+// kindForLocation returns the kind of the component that is expected at a
+// location, given the keys of the mappings and the indexes of the
+// sequences that contain it.
+func kindForLocation(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	key := keys[len(keys)-1]
+	switch key {
+	case "schema", "items", "not", "additionalProperties":
+		return "schemas"
+	case "requestBody":
+		return "requestBodies"
+	}
+	if len(keys) < 2 {
+		return ""
+	}
+	switch parent := keys[len(keys)-2]; parent {
+	case "properties", "allOf", "anyOf", "oneOf", "definitions":
+		return "schemas"
+	case "paths":
+		return "pathItems"
+	default:
+		if componentKinds[parent] {
+			return parent
+		}
+	}
+	return ""
+}
+
+type constructor func(*yaml.Node, *compiler.Context) (proto.Message, error)
+
+var openAPIv3Constructors = map[string]constructor{
+	"schemas": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewSchemaOrReference(n, c)
+	},
+	"responses": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewResponseOrReference(n, c)
+	},
+	"parameters": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewParameterOrReference(n, c)
+	},
+	"examples": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewExampleOrReference(n, c)
+	},
+	"requestBodies": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewRequestBodyOrReference(n, c)
+	},
+	"headers": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewHeaderOrReference(n, c)
+	},
+	"securitySchemes": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewSecuritySchemeOrReference(n, c)
+	},
+	"links": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewLinkOrReference(n, c)
+	},
+	"callbacks": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewCallbackOrReference(n, c)
+	},
+	"pathItems": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v3.NewPathItem(n, c)
+	},
+}
+
+var openAPIv2Constructors = map[string]constructor{
+	"schemas": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewSchema(n, c)
+	},
+	"responses": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewResponse(n, c)
+	},
+	"parameters": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewParameter(n, c)
+	},
+	"headers": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewHeader(n, c)
+	},
+	"pathItems": func(n *yaml.Node, c *compiler.Context) (proto.Message, error) {
+		return openapi_v2.NewPathItem(n, c)
+	},
+}
+
+// compileComponent compiles a component with the models of a format.
+// Components of unknown kinds are kept as YAML.
+func compileComponent(node *yaml.Node, kind string, format int, context *compiler.Context) (proto.Message, error) {
+	if format == formatOpenAPI2 {
+		if build, ok := openAPIv2Constructors[kind]; ok {
+			return build(node, context)
+		}
+		return openapi_v2.NewAny(node, context)
+	}
+	if build, ok := openAPIv3Constructors[kind]; ok {
+		return build(node, context)
+	}
+	return openapi_v3.NewAny(node, context)
+}
+
+// walk calls a function for each reference in a node with the keys of the
+// mappings and the indexes of the sequences that contain it. Examples and
+// specification extensions aren't searched.
+func walk(node *yaml.Node, keys []string, f func(ref string, keys []string)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		named := len(keys) > 0 && namedValues[keys[len(keys)-1]]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i].Value, node.Content[i+1]
+			if k == "$ref" && v.Kind == yaml.ScalarNode {
+				f(v.Value, keys)
+				continue
+			}
+			if !named && (k == "example" || strings.HasPrefix(k, "x-")) {
+				continue
+			}
+			walk(v, append(keys[:len(keys):len(keys)], k), f)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walk(child, append(keys[:len(keys):len(keys)], fmt.Sprintf("%d", i)), f)
+		}
+	}
+}
+
+// namedValues contains the keys of mappings whose keys are names instead
+// of fields. These names can begin with "x-" without being extensions.
+var namedValues = map[string]bool{
+	"properties":  true,
+	"definitions": true,
+	"paths":       true,
+	"content":     true,
+	"encoding":    true,
+	"variables":   true,
+}
+
+func init() {
+	for kind := range componentKinds {
+		namedValues[kind] = true
+	}
+}
+
+func newAny(message proto.Message) (*anypb.Any, error) {
+	bytes, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return &anypb.Any{TypeUrl: string(proto.MessageName(message)), Value: bytes}, nil
+}
+
+// cleanName cleans local file names so that each file has one name.
+func cleanName(name string) string {
+	if strings.Contains(name, "://") {
+		return name
+	}
+	return filepath.Clean(name)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var errNoSources = errors.New("no sources")
@@ -0,0 +1,164 @@
+// Copyright 2023 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic_workspace_v1
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi_v3 "github.com/google/gnostic/openapiv3"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "workspace")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for name, text := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+	return dir
+}
+
+const commonLibrary = `
+schemas:
+  Error:
+    type: object
+    properties:
+      code: {$ref: "#/schemas/Code"}
+  Code: {type: integer}
+parameters:
+  Limit:
+    name: limit
+    in: query
+    schema: {type: integer}
+`
+
+const petsDocument = `
+openapi: 3.0.0
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: "common.yaml#/parameters/Limit"
+      responses:
+        default:
+          description: An error.
+          content:
+            application/json:
+              schema: {$ref: "common.yaml#/schemas/Error"}
+`
+
+const storesDocument = `
+openapi: 3.0.0
+info: {title: Stores, version: 1.0.0}
+paths:
+  /stores:
+    get:
+      responses:
+        default:
+          description: An error.
+          content:
+            application/json:
+              schema: {$ref: "./common.yaml#/schemas/Error"}
+        "200":
+          description: A store.
+          content:
+            application/json:
+              schema: {$ref: "store.yaml"}
+`
+
+func TestWorkspace(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"common.yaml": commonLibrary,
+		"pets.yaml":   petsDocument,
+		"stores.yaml": storesDocument,
+		"store.yaml":  "{type: object, properties: {name: {type: string}}}",
+	})
+	defer os.RemoveAll(dir)
+	pets := filepath.Join(dir, "pets.yaml")
+	stores := filepath.Join(dir, "stores.yaml")
+	common := filepath.Join(dir, "common.yaml")
+	store := filepath.Join(dir, "store.yaml")
+
+	ws, err := NewWorkspace([]string{pets, stores})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(ws.Documents) != 2 || ws.Documents[0].SourceName != pets || ws.Documents[1].SourceName != stores {
+		t.Fatalf("unexpected documents %+v", ws.Documents)
+	}
+	document := &openapi_v3.Document{}
+	if err := ws.Documents[0].Model.UnmarshalTo(document); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if document.Info.Title != "Pets" {
+		t.Errorf("unexpected title %q", document.Info.Title)
+	}
+	if !reflect.DeepEqual(ws.Documents[1].Dependencies, []string{common, store}) {
+		t.Errorf("unexpected dependencies %v", ws.Documents[1].Dependencies)
+	}
+	if !reflect.DeepEqual(ws.Libraries, []string{common, store}) {
+		t.Errorf("unexpected libraries %v", ws.Libraries)
+	}
+
+	type component struct {
+		source, pointer, kind, name string
+		referencedBy                []string
+	}
+	components := make([]component, 0)
+	for _, c := range ws.Components {
+		components = append(components, component{c.SourceName, c.Pointer, c.Kind, c.Name, c.ReferencedBy})
+	}
+	expected := []component{
+		{common, "#/parameters/Limit", "parameters", "Limit", []string{pets}},
+		{common, "#/schemas/Error", "schemas", "Error", []string{pets, stores}},
+		{common, "#/schemas/Code", "schemas", "Code", []string{common}},
+		{store, "#", "schemas", "store", []string{stores}},
+	}
+	if !reflect.DeepEqual(components, expected) {
+		t.Errorf("unexpected components\n%+v\nexpected\n%+v", components, expected)
+	}
+
+	schema := &openapi_v3.SchemaOrReference{}
+	if err := ws.Components[1].Value.UnmarshalTo(schema); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if schema.GetSchema().GetType() != "object" {
+		t.Errorf("unexpected value %+v", schema)
+	}
+}
+
+func TestWorkspaceErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"pets.yaml": strings.Replace(petsDocument, "#/schemas/Error", "#/schemas/Missing", 1),
+	})
+	defer os.RemoveAll(dir)
+	_, err := NewWorkspace([]string{filepath.Join(dir, "pets.yaml")})
+	if err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+	if _, err := NewWorkspace(nil); err != errNoSources {
+		t.Errorf("unexpected error %v", err)
+	}
+}